@@ -251,6 +251,51 @@ func GetNotImplementedError(innerErrors ...error) model.AppError {
 	)
 }
 
+// GetTooManyRequestsError creates an error related to TooManyRequests
+func GetTooManyRequestsError(innerErrors ...error) model.AppError {
+	return wrapErrorFunc(
+		innerErrors,
+		enum.CodeTooManyRequests,
+		"Operation refused due to too many requests from client",
+	)
+}
+
+// GetRequestEntityTooLargeError creates an error related to RequestEntityTooLarge
+func GetRequestEntityTooLargeError(innerErrors ...error) model.AppError {
+	return wrapErrorFunc(
+		innerErrors,
+		enum.CodeRequestEntityTooLarge,
+		"Operation refused due to request entity too large",
+	)
+}
+
+// GetNotAcceptableError creates an error related to NotAcceptable
+func GetNotAcceptableError(innerErrors ...error) model.AppError {
+	return wrapErrorFunc(
+		innerErrors,
+		enum.CodeNotAcceptable,
+		"Operation refused due to no acceptable representation for client",
+	)
+}
+
+// GetUnsupportedMediaTypeError creates an error related to UnsupportedMediaType
+func GetUnsupportedMediaTypeError(innerErrors ...error) model.AppError {
+	return wrapErrorFunc(
+		innerErrors,
+		enum.CodeUnsupportedMediaType,
+		"Operation refused due to unsupported media type of request",
+	)
+}
+
+// GetPreconditionFailedError creates an error related to PreconditionFailed
+func GetPreconditionFailedError(innerErrors ...error) model.AppError {
+	return wrapErrorFunc(
+		innerErrors,
+		enum.CodePreconditionFailed,
+		"Operation refused due to unmet precondition of request",
+	)
+}
+
 // GetCustomError creates a customized error with given code and formatted message
 func GetCustomError(errorCode enum.Code, messageFormat string, parameters ...interface{}) model.AppError {
 	return &appError{
@@ -1359,6 +1359,156 @@ func TestGetNotImplementedError(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestGetTooManyRequestsError(t *testing.T) {
+	// arrange
+	var expectedInnerError = errors.New("dummy inner error")
+	var expectedResult = &appError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	wrapErrorFuncExpected = 1
+	wrapErrorFunc = func(innerErrors []error, errorCode enum.Code, messageFormat string, parameters ...interface{}) model.AppError {
+		wrapErrorFuncCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, expectedInnerError, innerErrors[0])
+		assert.Equal(t, enum.CodeTooManyRequests, errorCode)
+		assert.Equal(t, "Operation refused due to too many requests from client", messageFormat)
+		assert.Equal(t, 0, len(parameters))
+		return expectedResult
+	}
+
+	// SUT + act
+	var appError = GetTooManyRequestsError(expectedInnerError)
+
+	// assert
+	assert.Equal(t, expectedResult, appError)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestEntityTooLargeError(t *testing.T) {
+	// arrange
+	var expectedInnerError = errors.New("dummy inner error")
+	var expectedResult = &appError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	wrapErrorFuncExpected = 1
+	wrapErrorFunc = func(innerErrors []error, errorCode enum.Code, messageFormat string, parameters ...interface{}) model.AppError {
+		wrapErrorFuncCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, expectedInnerError, innerErrors[0])
+		assert.Equal(t, enum.CodeRequestEntityTooLarge, errorCode)
+		assert.Equal(t, "Operation refused due to request entity too large", messageFormat)
+		assert.Equal(t, 0, len(parameters))
+		return expectedResult
+	}
+
+	// SUT + act
+	var appError = GetRequestEntityTooLargeError(expectedInnerError)
+
+	// assert
+	assert.Equal(t, expectedResult, appError)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetNotAcceptableError(t *testing.T) {
+	// arrange
+	var expectedInnerError = errors.New("dummy inner error")
+	var expectedResult = &appError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	wrapErrorFuncExpected = 1
+	wrapErrorFunc = func(innerErrors []error, errorCode enum.Code, messageFormat string, parameters ...interface{}) model.AppError {
+		wrapErrorFuncCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, expectedInnerError, innerErrors[0])
+		assert.Equal(t, enum.CodeNotAcceptable, errorCode)
+		assert.Equal(t, "Operation refused due to no acceptable representation for client", messageFormat)
+		assert.Equal(t, 0, len(parameters))
+		return expectedResult
+	}
+
+	// SUT + act
+	var appError = GetNotAcceptableError(expectedInnerError)
+
+	// assert
+	assert.Equal(t, expectedResult, appError)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetUnsupportedMediaTypeError(t *testing.T) {
+	// arrange
+	var expectedInnerError = errors.New("dummy inner error")
+	var expectedResult = &appError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	wrapErrorFuncExpected = 1
+	wrapErrorFunc = func(innerErrors []error, errorCode enum.Code, messageFormat string, parameters ...interface{}) model.AppError {
+		wrapErrorFuncCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, expectedInnerError, innerErrors[0])
+		assert.Equal(t, enum.CodeUnsupportedMediaType, errorCode)
+		assert.Equal(t, "Operation refused due to unsupported media type of request", messageFormat)
+		assert.Equal(t, 0, len(parameters))
+		return expectedResult
+	}
+
+	// SUT + act
+	var appError = GetUnsupportedMediaTypeError(expectedInnerError)
+
+	// assert
+	assert.Equal(t, expectedResult, appError)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetPreconditionFailedError(t *testing.T) {
+	// arrange
+	var expectedInnerError = errors.New("dummy inner error")
+	var expectedResult = &appError{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	wrapErrorFuncExpected = 1
+	wrapErrorFunc = func(innerErrors []error, errorCode enum.Code, messageFormat string, parameters ...interface{}) model.AppError {
+		wrapErrorFuncCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, expectedInnerError, innerErrors[0])
+		assert.Equal(t, enum.CodePreconditionFailed, errorCode)
+		assert.Equal(t, "Operation refused due to unmet precondition of request", messageFormat)
+		assert.Equal(t, 0, len(parameters))
+		return expectedResult
+	}
+
+	// SUT + act
+	var appError = GetPreconditionFailedError(expectedInnerError)
+
+	// assert
+	assert.Equal(t, expectedResult, appError)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestGetCustomError(t *testing.T) {
 	// arrange
 	var dummyErrorCode = enum.Code(rand.Intn(255))
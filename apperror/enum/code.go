@@ -17,6 +17,11 @@ const (
 	CodeAccessForbidden
 	CodeDataCorruption
 	CodeNotImplemented
+	CodeTooManyRequests
+	CodeRequestEntityTooLarge
+	CodeNotAcceptable
+	CodeUnsupportedMediaType
+	CodePreconditionFailed
 	CodeReservedCount
 )
 
@@ -33,6 +38,11 @@ func (code Code) String() string {
 		"AccessForbidden",
 		"DataCorruption",
 		"NotImplemented",
+		"TooManyRequests",
+		"RequestEntityTooLarge",
+		"NotAcceptable",
+		"UnsupportedMediaType",
+		"PreconditionFailed",
 	}
 	if code < 0 || code >= CodeReservedCount {
 		return "Unknown"
@@ -64,6 +74,16 @@ func (code Code) HTTPStatusCode() int {
 		statusCode = http.StatusConflict
 	case CodeNotImplemented:
 		statusCode = http.StatusNotImplemented
+	case CodeTooManyRequests:
+		statusCode = http.StatusTooManyRequests
+	case CodeRequestEntityTooLarge:
+		statusCode = http.StatusRequestEntityTooLarge
+	case CodeNotAcceptable:
+		statusCode = http.StatusNotAcceptable
+	case CodeUnsupportedMediaType:
+		statusCode = http.StatusUnsupportedMediaType
+	case CodePreconditionFailed:
+		statusCode = http.StatusPreconditionFailed
 	default:
 		statusCode = http.StatusInternalServerError
 	}
@@ -199,6 +199,91 @@ func TestCodeEnumString_GetNotImplemented(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestCodeEnumString_GetTooManyRequests(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var testCode = CodeTooManyRequests
+
+	// act
+	var convertedString = testCode.String()
+
+	// assert
+	assert.Equal(t, "TooManyRequests", convertedString)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCodeEnumString_GetRequestEntityTooLarge(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var testCode = CodeRequestEntityTooLarge
+
+	// act
+	var convertedString = testCode.String()
+
+	// assert
+	assert.Equal(t, "RequestEntityTooLarge", convertedString)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCodeEnumString_GetNotAcceptable(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var testCode = CodeNotAcceptable
+
+	// act
+	var convertedString = testCode.String()
+
+	// assert
+	assert.Equal(t, "NotAcceptable", convertedString)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCodeEnumString_GetUnsupportedMediaType(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var testCode = CodeUnsupportedMediaType
+
+	// act
+	var convertedString = testCode.String()
+
+	// assert
+	assert.Equal(t, "UnsupportedMediaType", convertedString)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCodeEnumString_GetPreconditionFailed(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var testCode = CodePreconditionFailed
+
+	// act
+	var convertedString = testCode.String()
+
+	// assert
+	assert.Equal(t, "PreconditionFailed", convertedString)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestCodeEnumString_UnknownTooBig(t *testing.T) {
 	// arrange
 	var testCode Code
@@ -389,6 +474,91 @@ func TestCodeEnumHTTPStatusCode_NotImplemented(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestCodeEnumHTTPStatusCode_TooManyRequests(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var dummyCode = CodeTooManyRequests
+
+	// act
+	var result = dummyCode.HTTPStatusCode()
+
+	// assert
+	assert.Equal(t, http.StatusTooManyRequests, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCodeEnumHTTPStatusCode_RequestEntityTooLarge(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var dummyCode = CodeRequestEntityTooLarge
+
+	// act
+	var result = dummyCode.HTTPStatusCode()
+
+	// assert
+	assert.Equal(t, http.StatusRequestEntityTooLarge, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCodeEnumHTTPStatusCode_NotAcceptable(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var dummyCode = CodeNotAcceptable
+
+	// act
+	var result = dummyCode.HTTPStatusCode()
+
+	// assert
+	assert.Equal(t, http.StatusNotAcceptable, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCodeEnumHTTPStatusCode_UnsupportedMediaType(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var dummyCode = CodeUnsupportedMediaType
+
+	// act
+	var result = dummyCode.HTTPStatusCode()
+
+	// assert
+	assert.Equal(t, http.StatusUnsupportedMediaType, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCodeEnumHTTPStatusCode_PreconditionFailed(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var dummyCode = CodePreconditionFailed
+
+	// act
+	var result = dummyCode.HTTPStatusCode()
+
+	// assert
+	assert.Equal(t, http.StatusPreconditionFailed, result)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestCodeEnumHTTPStatusCode_OtherCode(t *testing.T) {
 	// mock
 	createMock(t)
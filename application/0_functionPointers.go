@@ -1,6 +1,9 @@
 package application
 
 import (
+	"os/signal"
+	"time"
+
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
 	"github.com/zhongjie-cai/WebServiceTemplate/certificate"
 	"github.com/zhongjie-cai/WebServiceTemplate/config"
@@ -12,18 +15,36 @@ import (
 
 // func pointers for injection / testing: main.go
 var (
-	sessionInitialize         = session.Initialize
-	configInitialize          = config.Initialize
-	certificateInitialize     = certificate.Initialize
-	apperrorInitialize        = apperror.Initialize
-	networkInitialize         = network.Initialize
-	loggerInitialize          = logger.Initialize
-	loggerAppRoot             = logger.AppRoot
-	serverHost                = server.Host
-	serverHalt                = server.Halt
-	doPreBootstrapingFunc     = doPreBootstraping
-	bootstrapApplicationFunc  = bootstrapApplication
-	doPostBootstrapingFunc    = doPostBootstraping
-	doApplicationStartingFunc = doApplicationStarting
-	doApplicationClosingFunc  = doApplicationClosing
+	sessionInitialize             = session.Initialize
+	configInitialize              = config.Initialize
+	configReport                  = config.Report
+	configIsStrictValidationError = config.IsStrictValidationError
+	certificateInitialize         = certificate.Initialize
+	apperrorInitialize            = apperror.Initialize
+	networkInitialize             = network.Initialize
+	loggerInitialize              = logger.Initialize
+	loggerAppRoot                 = logger.AppRoot
+	serverRequiresCertificates    = server.RequiresCertificates
+	serverHost                    = server.Host
+	serverHalt                    = server.Halt
+	doPreBootstrapingFunc         = doPreBootstraping
+	bootstrapApplicationFunc      = bootstrapApplication
+	doPostBootstrapingFunc        = doPostBootstraping
+	doApplicationStartingFunc     = doApplicationStarting
+	doApplicationClosingFunc      = doApplicationClosing
+)
+
+// func pointers for injection / testing: reload.go
+var (
+	configReload                = config.Reload
+	configSourcesChanged        = config.SourcesChanged
+	signalNotify                = signal.Notify
+	signalStop                  = signal.Stop
+	timeNewTicker               = time.NewTicker
+	isNetworkTimeoutChangedFunc = isNetworkTimeoutChanged
+	doConfigReloadedFunc        = doConfigReloaded
+	reloadConfigFunc            = reloadConfig
+	watchConfigChangesFunc      = watchConfigChanges
+	getConfigWatchIntervalFunc  = getConfigWatchInterval
+	startConfigWatcherFunc      = startConfigWatcher
 )
@@ -1,6 +1,8 @@
 package application
 
 import (
+	"os"
+	"os/signal"
 	"testing"
 	"time"
 
@@ -8,6 +10,7 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
 	"github.com/zhongjie-cai/WebServiceTemplate/certificate"
 	"github.com/zhongjie-cai/WebServiceTemplate/config"
+	configModel "github.com/zhongjie-cai/WebServiceTemplate/config/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/customization"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger"
 	"github.com/zhongjie-cai/WebServiceTemplate/network"
@@ -24,6 +27,10 @@ var (
 	configAppVersionCalled                   int
 	configInitializeExpected                 int
 	configInitializeCalled                   int
+	configReportExpected                     int
+	configReportCalled                       int
+	configIsStrictValidationErrorExpected    int
+	configIsStrictValidationErrorCalled      int
 	configServeHTTPSExpected                 int
 	configServeHTTPSCalled                   int
 	configServerCertContentExpected          int
@@ -44,6 +51,8 @@ var (
 	configSkipServerCertVerificationCalled   int
 	certificateInitializeExpected            int
 	certificateInitializeCalled              int
+	serverRequiresCertificatesExpected       int
+	serverRequiresCertificatesCalled         int
 	apperrorInitializeExpected               int
 	apperrorInitializeCalled                 int
 	networkInitializeExpected                int
@@ -66,6 +75,28 @@ var (
 	doApplicationStartingFuncCalled          int
 	doApplicationClosingFuncExpected         int
 	doApplicationClosingFuncCalled           int
+	configReloadExpected                     int
+	configReloadCalled                       int
+	configSourcesChangedExpected             int
+	configSourcesChangedCalled               int
+	signalNotifyExpected                     int
+	signalNotifyCalled                       int
+	signalStopExpected                       int
+	signalStopCalled                         int
+	timeNewTickerExpected                    int
+	timeNewTickerCalled                      int
+	isNetworkTimeoutChangedFuncExpected      int
+	isNetworkTimeoutChangedFuncCalled        int
+	doConfigReloadedFuncExpected             int
+	doConfigReloadedFuncCalled               int
+	reloadConfigFuncExpected                 int
+	reloadConfigFuncCalled                   int
+	watchConfigChangesFuncExpected           int
+	watchConfigChangesFuncCalled             int
+	getConfigWatchIntervalFuncExpected       int
+	getConfigWatchIntervalFuncCalled         int
+	startConfigWatcherFuncExpected           int
+	startConfigWatcherFuncCalled             int
 )
 
 func createMock(t *testing.T) {
@@ -92,6 +123,18 @@ func createMock(t *testing.T) {
 		configInitializeCalled++
 		return nil
 	}
+	configReportExpected = 0
+	configReportCalled = 0
+	configReport = func() []configModel.Setting {
+		configReportCalled++
+		return nil
+	}
+	configIsStrictValidationErrorExpected = 0
+	configIsStrictValidationErrorCalled = 0
+	configIsStrictValidationError = func(err error) bool {
+		configIsStrictValidationErrorCalled++
+		return false
+	}
 	configServeHTTPSExpected = 0
 	configServeHTTPSCalled = 0
 	config.ServeHTTPS = func() bool {
@@ -152,6 +195,12 @@ func createMock(t *testing.T) {
 		certificateInitializeCalled++
 		return nil
 	}
+	serverRequiresCertificatesExpected = 0
+	serverRequiresCertificatesCalled = 0
+	serverRequiresCertificates = func(serveHTTPS bool, validateClientCert bool, appPort string) (bool, bool) {
+		serverRequiresCertificatesCalled++
+		return false, false
+	}
 	apperrorInitializeExpected = 0
 	apperrorInitializeCalled = 0
 	apperrorInitialize = func() error {
@@ -213,6 +262,67 @@ func createMock(t *testing.T) {
 	doApplicationClosingFunc = func() {
 		doApplicationClosingFuncCalled++
 	}
+	configReloadExpected = 0
+	configReloadCalled = 0
+	configReload = func() ([]configModel.Change, error) {
+		configReloadCalled++
+		return nil, nil
+	}
+	configSourcesChangedExpected = 0
+	configSourcesChangedCalled = 0
+	configSourcesChanged = func() bool {
+		configSourcesChangedCalled++
+		return false
+	}
+	signalNotifyExpected = 0
+	signalNotifyCalled = 0
+	signalNotify = func(c chan<- os.Signal, sig ...os.Signal) {
+		signalNotifyCalled++
+	}
+	signalStopExpected = 0
+	signalStopCalled = 0
+	signalStop = func(c chan<- os.Signal) {
+		signalStopCalled++
+	}
+	timeNewTickerExpected = 0
+	timeNewTickerCalled = 0
+	timeNewTicker = func(d time.Duration) *time.Ticker {
+		timeNewTickerCalled++
+		return nil
+	}
+	isNetworkTimeoutChangedFuncExpected = 0
+	isNetworkTimeoutChangedFuncCalled = 0
+	isNetworkTimeoutChangedFunc = func(changes []configModel.Change) bool {
+		isNetworkTimeoutChangedFuncCalled++
+		return false
+	}
+	doConfigReloadedFuncExpected = 0
+	doConfigReloadedFuncCalled = 0
+	doConfigReloadedFunc = func(changes []configModel.Change) {
+		doConfigReloadedFuncCalled++
+	}
+	reloadConfigFuncExpected = 0
+	reloadConfigFuncCalled = 0
+	reloadConfigFunc = func(trigger string) {
+		reloadConfigFuncCalled++
+	}
+	watchConfigChangesFuncExpected = 0
+	watchConfigChangesFuncCalled = 0
+	watchConfigChangesFunc = func(signals <-chan os.Signal, ticks <-chan time.Time, stop <-chan struct{}) {
+		watchConfigChangesFuncCalled++
+	}
+	getConfigWatchIntervalFuncExpected = 0
+	getConfigWatchIntervalFuncCalled = 0
+	getConfigWatchIntervalFunc = func() time.Duration {
+		getConfigWatchIntervalFuncCalled++
+		return 0
+	}
+	startConfigWatcherFuncExpected = 0
+	startConfigWatcherFuncCalled = 0
+	startConfigWatcherFunc = func() func() {
+		startConfigWatcherFuncCalled++
+		return func() {}
+	}
 }
 
 func verifyAll(t *testing.T) {
@@ -222,6 +332,10 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, configAppVersionExpected, configAppVersionCalled, "Unexpected number of calls to configAppVersion")
 	configInitialize = config.Initialize
 	assert.Equal(t, configInitializeExpected, configInitializeCalled, "Unexpected number of calls to configInitialize")
+	configReport = config.Report
+	assert.Equal(t, configReportExpected, configReportCalled, "Unexpected number of calls to configReport")
+	configIsStrictValidationError = config.IsStrictValidationError
+	assert.Equal(t, configIsStrictValidationErrorExpected, configIsStrictValidationErrorCalled, "Unexpected number of calls to configIsStrictValidationError")
 	config.ServeHTTPS = func() bool { return false }
 	assert.Equal(t, configServeHTTPSExpected, configServeHTTPSCalled, "Unexpected number of calls to configServeHTTPS")
 	config.ServerCertContent = func() string { return "" }
@@ -244,6 +358,8 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, sessionInitializeExpected, sessionInitializeCalled, "Unexpected number of calls to sessionInitialize")
 	certificateInitialize = certificate.Initialize
 	assert.Equal(t, certificateInitializeExpected, certificateInitializeCalled, "Unexpected number of calls to certificateInitialize")
+	serverRequiresCertificates = server.RequiresCertificates
+	assert.Equal(t, serverRequiresCertificatesExpected, serverRequiresCertificatesCalled, "Unexpected number of calls to serverRequiresCertificates")
 	apperrorInitialize = apperror.Initialize
 	assert.Equal(t, apperrorInitializeExpected, apperrorInitializeCalled, "Unexpected number of calls to apperrorInitialize")
 	networkInitialize = network.Initialize
@@ -266,8 +382,33 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, doApplicationStartingFuncExpected, doApplicationStartingFuncCalled, "Unexpected number of calls to doApplicationStartingFunc")
 	doApplicationClosingFunc = doApplicationClosing
 	assert.Equal(t, doApplicationClosingFuncExpected, doApplicationClosingFuncCalled, "Unexpected number of calls to doApplicationClosingFunc")
+	configReload = config.Reload
+	assert.Equal(t, configReloadExpected, configReloadCalled, "Unexpected number of calls to configReload")
+	configSourcesChanged = config.SourcesChanged
+	assert.Equal(t, configSourcesChangedExpected, configSourcesChangedCalled, "Unexpected number of calls to configSourcesChanged")
+	signalNotify = signal.Notify
+	assert.Equal(t, signalNotifyExpected, signalNotifyCalled, "Unexpected number of calls to signalNotify")
+	signalStop = signal.Stop
+	assert.Equal(t, signalStopExpected, signalStopCalled, "Unexpected number of calls to signalStop")
+	timeNewTicker = time.NewTicker
+	assert.Equal(t, timeNewTickerExpected, timeNewTickerCalled, "Unexpected number of calls to timeNewTicker")
+	isNetworkTimeoutChangedFunc = isNetworkTimeoutChanged
+	assert.Equal(t, isNetworkTimeoutChangedFuncExpected, isNetworkTimeoutChangedFuncCalled, "Unexpected number of calls to isNetworkTimeoutChangedFunc")
+	doConfigReloadedFunc = doConfigReloaded
+	assert.Equal(t, doConfigReloadedFuncExpected, doConfigReloadedFuncCalled, "Unexpected number of calls to doConfigReloadedFunc")
+	reloadConfigFunc = reloadConfig
+	assert.Equal(t, reloadConfigFuncExpected, reloadConfigFuncCalled, "Unexpected number of calls to reloadConfigFunc")
+	watchConfigChangesFunc = watchConfigChanges
+	assert.Equal(t, watchConfigChangesFuncExpected, watchConfigChangesFuncCalled, "Unexpected number of calls to watchConfigChangesFunc")
+	getConfigWatchIntervalFunc = getConfigWatchInterval
+	assert.Equal(t, getConfigWatchIntervalFuncExpected, getConfigWatchIntervalFuncCalled, "Unexpected number of calls to getConfigWatchIntervalFunc")
+	startConfigWatcherFunc = startConfigWatcher
+	assert.Equal(t, startConfigWatcherFuncExpected, startConfigWatcherFuncCalled, "Unexpected number of calls to startConfigWatcherFunc")
 
 	customization.PreBootstrapFunc = nil
 	customization.PostBootstrapFunc = nil
 	customization.AppClosingFunc = nil
+	customization.ConfigSources = nil
+	customization.ConfigWatchInterval = nil
+	customization.ConfigReloadedFunc = nil
 }
@@ -43,7 +43,21 @@ func bootstrapApplication() bool {
 		)
 	}
 	var configError = configInitialize()
-	if configError != nil {
+	loggerAppRoot(
+		"application",
+		"bootstrapApplication",
+		"Application configuration resolved as: %v",
+		configReport(),
+	)
+	if configIsStrictValidationError(configError) {
+		loggerAppRoot(
+			"application",
+			"bootstrapApplication",
+			"Failed to bootstrap server application due to strict configuration validation. Error: %v",
+			configError,
+		)
+		return false
+	} else if configError != nil {
 		loggerAppRoot(
 			"application",
 			"bootstrapApplication",
@@ -51,11 +65,16 @@ func bootstrapApplication() bool {
 			configError,
 		)
 	}
-	var certError = certificateInitialize(
+	var requiresServerCert, requiresCaCertPool = serverRequiresCertificates(
 		config.ServeHTTPS(),
+		config.ValidateClientCert(),
+		config.AppPort(),
+	)
+	var certError = certificateInitialize(
+		requiresServerCert,
 		config.ServerCertContent(),
 		config.ServerKeyContent(),
-		config.ValidateClientCert(),
+		requiresCaCertPool,
 		config.CaCertContent(),
 		config.ClientCertContent(),
 		config.ClientKeyContent(),
@@ -185,6 +204,8 @@ func Start() {
 		return
 	}
 	defer doApplicationClosingFunc()
+	var stopConfigWatcher = startConfigWatcherFunc()
+	defer stopConfigWatcher()
 	doApplicationStartingFunc()
 }
 
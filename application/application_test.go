@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/zhongjie-cai/WebServiceTemplate/config"
+	configModel "github.com/zhongjie-cai/WebServiceTemplate/config/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/customization"
 )
 
@@ -113,11 +114,70 @@ func TestDoPreBootstraping_PreBoostrapFuncSuccess(t *testing.T) {
 	assert.Equal(t, preBootstrapFuncExpected, preBootstrapFuncCalled, "Unexpected number of calls to PreBootstrapFunc")
 }
 
+func TestBootstrapApplication_StrictConfigError(t *testing.T) {
+	// arrange
+	var dummyConfigError = errors.New("some config error")
+	var dummyConfigReport = []configModel.Setting{
+		{Name: "some name", Source: "some source", Defaulted: true},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	loggerInitializeExpected = 1
+	configInitializeExpected = 1
+	configInitialize = func() error {
+		configInitializeCalled++
+		return dummyConfigError
+	}
+	configReportExpected = 1
+	configReport = func() []configModel.Setting {
+		configReportCalled++
+		return dummyConfigReport
+	}
+	configIsStrictValidationErrorExpected = 1
+	configIsStrictValidationError = func(err error) bool {
+		configIsStrictValidationErrorCalled++
+		assert.Equal(t, dummyConfigError, err)
+		return true
+	}
+	loggerAppRootExpected = 2
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "application", category)
+		assert.Equal(t, "bootstrapApplication", subcategory)
+		assert.Equal(t, 1, len(parameters))
+		if loggerAppRootCalled == 1 {
+			assert.Equal(t, "Application configuration resolved as: %v", messageFormat)
+			assert.Equal(t, dummyConfigReport, parameters[0])
+		} else if loggerAppRootCalled == 2 {
+			assert.Equal(t, "Failed to bootstrap server application due to strict configuration validation. Error: %v", messageFormat)
+			assert.Equal(t, dummyConfigError, parameters[0])
+		}
+	}
+
+	// SUT + act
+	var result = bootstrapApplication()
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestBootstrapApplication_CertError(t *testing.T) {
 	// arrange
 	var dummyLoggerError = errors.New("some logger error")
 	var dummyConfigError = errors.New("some config error")
+	var dummyConfigReport = []configModel.Setting{
+		{Name: "some name", Source: "some source"},
+	}
 	var dummyServeHTTPS = rand.Intn(100) < 50
+	var dummyAppPort = "some app port"
+	var dummyRequiresServerCert = rand.Intn(100) < 50
+	var dummyRequiresCaCertPool = rand.Intn(100) < 50
 	var dummyServerCertContent = "some server cert content"
 	var dummyServerKeyContent = "some server key content"
 	var dummyValidateClientCert = rand.Intn(100) < 50
@@ -140,6 +200,17 @@ func TestBootstrapApplication_CertError(t *testing.T) {
 		configInitializeCalled++
 		return dummyConfigError
 	}
+	configReportExpected = 1
+	configReport = func() []configModel.Setting {
+		configReportCalled++
+		return dummyConfigReport
+	}
+	configIsStrictValidationErrorExpected = 1
+	configIsStrictValidationError = func(err error) bool {
+		configIsStrictValidationErrorCalled++
+		assert.Equal(t, dummyConfigError, err)
+		return false
+	}
 	configServeHTTPSExpected = 1
 	config.ServeHTTPS = func() bool {
 		configServeHTTPSCalled++
@@ -175,19 +246,32 @@ func TestBootstrapApplication_CertError(t *testing.T) {
 		configClientKeyContentCalled++
 		return dummyClientKeyContent
 	}
+	configAppPortExpected = 1
+	config.AppPort = func() string {
+		configAppPortCalled++
+		return dummyAppPort
+	}
+	serverRequiresCertificatesExpected = 1
+	serverRequiresCertificates = func(serveHTTPS bool, validateClientCert bool, appPort string) (bool, bool) {
+		serverRequiresCertificatesCalled++
+		assert.Equal(t, dummyServeHTTPS, serveHTTPS)
+		assert.Equal(t, dummyValidateClientCert, validateClientCert)
+		assert.Equal(t, dummyAppPort, appPort)
+		return dummyRequiresServerCert, dummyRequiresCaCertPool
+	}
 	certificateInitializeExpected = 1
 	certificateInitialize = func(serveHTTPS bool, serverCertContent string, serverKeyContent string, validateClientCert bool, caCertContent string, clientCertContent string, clientKeyContent string) error {
 		certificateInitializeCalled++
-		assert.Equal(t, dummyServeHTTPS, serveHTTPS)
+		assert.Equal(t, dummyRequiresServerCert, serveHTTPS)
 		assert.Equal(t, dummyServerCertContent, serverCertContent)
 		assert.Equal(t, dummyServerKeyContent, serverKeyContent)
-		assert.Equal(t, dummyValidateClientCert, validateClientCert)
+		assert.Equal(t, dummyRequiresCaCertPool, validateClientCert)
 		assert.Equal(t, dummyCaCertContent, caCertContent)
 		assert.Equal(t, dummyClientCertContent, clientCertContent)
 		assert.Equal(t, dummyClientKeyContent, clientKeyContent)
 		return dummyCertError
 	}
-	loggerAppRootExpected = 3
+	loggerAppRootExpected = 4
 	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAppRootCalled++
 		assert.Equal(t, "application", category)
@@ -197,10 +281,14 @@ func TestBootstrapApplication_CertError(t *testing.T) {
 			assert.Equal(t, 1, len(parameters))
 			assert.Equal(t, dummyLoggerError, parameters[0])
 		} else if loggerAppRootCalled == 2 {
+			assert.Equal(t, "Application configuration resolved as: %v", messageFormat)
+			assert.Equal(t, 1, len(parameters))
+			assert.Equal(t, dummyConfigReport, parameters[0])
+		} else if loggerAppRootCalled == 3 {
 			assert.Equal(t, "Application configuration not initialized cleanly. Potential error: %v", messageFormat)
 			assert.Equal(t, 1, len(parameters))
 			assert.Equal(t, dummyConfigError, parameters[0])
-		} else if loggerAppRootCalled == 3 {
+		} else if loggerAppRootCalled == 4 {
 			assert.Equal(t, "Failed to bootstrap server application. Error: %v", messageFormat)
 			assert.Equal(t, 1, len(parameters))
 			assert.Equal(t, dummyCertError, parameters[0])
@@ -221,7 +309,13 @@ func TestBootstrapApplication_AppError(t *testing.T) {
 	// arrange
 	var dummyLoggerError = errors.New("some logger error")
 	var dummyConfigError = errors.New("some config error")
+	var dummyConfigReport = []configModel.Setting{
+		{Name: "some name", Source: "some source"},
+	}
 	var dummyServeHTTPS = rand.Intn(100) < 50
+	var dummyAppPort = "some app port"
+	var dummyRequiresServerCert = rand.Intn(100) < 50
+	var dummyRequiresCaCertPool = rand.Intn(100) < 50
 	var dummyServerCertContent = "some server cert content"
 	var dummyServerKeyContent = "some server key content"
 	var dummyValidateClientCert = rand.Intn(100) < 50
@@ -244,6 +338,17 @@ func TestBootstrapApplication_AppError(t *testing.T) {
 		configInitializeCalled++
 		return dummyConfigError
 	}
+	configReportExpected = 1
+	configReport = func() []configModel.Setting {
+		configReportCalled++
+		return dummyConfigReport
+	}
+	configIsStrictValidationErrorExpected = 1
+	configIsStrictValidationError = func(err error) bool {
+		configIsStrictValidationErrorCalled++
+		assert.Equal(t, dummyConfigError, err)
+		return false
+	}
 	configServeHTTPSExpected = 1
 	config.ServeHTTPS = func() bool {
 		configServeHTTPSCalled++
@@ -279,13 +384,26 @@ func TestBootstrapApplication_AppError(t *testing.T) {
 		configClientKeyContentCalled++
 		return dummyClientKeyContent
 	}
+	configAppPortExpected = 1
+	config.AppPort = func() string {
+		configAppPortCalled++
+		return dummyAppPort
+	}
+	serverRequiresCertificatesExpected = 1
+	serverRequiresCertificates = func(serveHTTPS bool, validateClientCert bool, appPort string) (bool, bool) {
+		serverRequiresCertificatesCalled++
+		assert.Equal(t, dummyServeHTTPS, serveHTTPS)
+		assert.Equal(t, dummyValidateClientCert, validateClientCert)
+		assert.Equal(t, dummyAppPort, appPort)
+		return dummyRequiresServerCert, dummyRequiresCaCertPool
+	}
 	certificateInitializeExpected = 1
 	certificateInitialize = func(serveHTTPS bool, serverCertContent string, serverKeyContent string, validateClientCert bool, caCertContent string, clientCertContent string, clientKeyContent string) error {
 		certificateInitializeCalled++
-		assert.Equal(t, dummyServeHTTPS, serveHTTPS)
+		assert.Equal(t, dummyRequiresServerCert, serveHTTPS)
 		assert.Equal(t, dummyServerCertContent, serverCertContent)
 		assert.Equal(t, dummyServerKeyContent, serverKeyContent)
-		assert.Equal(t, dummyValidateClientCert, validateClientCert)
+		assert.Equal(t, dummyRequiresCaCertPool, validateClientCert)
 		assert.Equal(t, dummyCaCertContent, caCertContent)
 		assert.Equal(t, dummyClientCertContent, clientCertContent)
 		assert.Equal(t, dummyClientKeyContent, clientKeyContent)
@@ -296,7 +414,7 @@ func TestBootstrapApplication_AppError(t *testing.T) {
 		apperrorInitializeCalled++
 		return dummyAppError
 	}
-	loggerAppRootExpected = 3
+	loggerAppRootExpected = 4
 	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAppRootCalled++
 		assert.Equal(t, "application", category)
@@ -306,10 +424,14 @@ func TestBootstrapApplication_AppError(t *testing.T) {
 			assert.Equal(t, 1, len(parameters))
 			assert.Equal(t, dummyLoggerError, parameters[0])
 		} else if loggerAppRootCalled == 2 {
+			assert.Equal(t, "Application configuration resolved as: %v", messageFormat)
+			assert.Equal(t, 1, len(parameters))
+			assert.Equal(t, dummyConfigReport, parameters[0])
+		} else if loggerAppRootCalled == 3 {
 			assert.Equal(t, "Application configuration not initialized cleanly. Potential error: %v", messageFormat)
 			assert.Equal(t, 1, len(parameters))
 			assert.Equal(t, dummyConfigError, parameters[0])
-		} else if loggerAppRootCalled == 3 {
+		} else if loggerAppRootCalled == 4 {
 			assert.Equal(t, "Failed to bootstrap server application. Error: %v", messageFormat)
 			assert.Equal(t, 1, len(parameters))
 			assert.Equal(t, dummyAppError, parameters[0])
@@ -329,6 +451,9 @@ func TestBootstrapApplication_AppError(t *testing.T) {
 func TestBootstrapApplication_NoError(t *testing.T) {
 	// arrange
 	var dummyServeHTTPS = rand.Intn(100) < 50
+	var dummyAppPort = "some app port"
+	var dummyRequiresServerCert = rand.Intn(100) < 50
+	var dummyRequiresCaCertPool = rand.Intn(100) < 50
 	var dummyServerCertContent = "some server cert content"
 	var dummyServerKeyContent = "some server key content"
 	var dummyValidateClientCert = rand.Intn(100) < 50
@@ -337,6 +462,9 @@ func TestBootstrapApplication_NoError(t *testing.T) {
 	var dummyClientKeyContent = "some client key content"
 	var dummyDefaultNetworkTimeout = time.Duration(rand.Int())
 	var dummySkipServerCertVerification = rand.Intn(100) < 50
+	var dummyConfigReport = []configModel.Setting{
+		{Name: "some name", Source: "some source"},
+	}
 
 	// mock
 	createMock(t)
@@ -352,6 +480,17 @@ func TestBootstrapApplication_NoError(t *testing.T) {
 		configInitializeCalled++
 		return nil
 	}
+	configReportExpected = 1
+	configReport = func() []configModel.Setting {
+		configReportCalled++
+		return dummyConfigReport
+	}
+	configIsStrictValidationErrorExpected = 1
+	configIsStrictValidationError = func(err error) bool {
+		configIsStrictValidationErrorCalled++
+		assert.Nil(t, err)
+		return false
+	}
 	configServeHTTPSExpected = 1
 	config.ServeHTTPS = func() bool {
 		configServeHTTPSCalled++
@@ -387,13 +526,26 @@ func TestBootstrapApplication_NoError(t *testing.T) {
 		configClientKeyContentCalled++
 		return dummyClientKeyContent
 	}
+	configAppPortExpected = 1
+	config.AppPort = func() string {
+		configAppPortCalled++
+		return dummyAppPort
+	}
+	serverRequiresCertificatesExpected = 1
+	serverRequiresCertificates = func(serveHTTPS bool, validateClientCert bool, appPort string) (bool, bool) {
+		serverRequiresCertificatesCalled++
+		assert.Equal(t, dummyServeHTTPS, serveHTTPS)
+		assert.Equal(t, dummyValidateClientCert, validateClientCert)
+		assert.Equal(t, dummyAppPort, appPort)
+		return dummyRequiresServerCert, dummyRequiresCaCertPool
+	}
 	certificateInitializeExpected = 1
 	certificateInitialize = func(serveHTTPS bool, serverCertContent string, serverKeyContent string, validateClientCert bool, caCertContent string, clientCertContent string, clientKeyContent string) error {
 		certificateInitializeCalled++
-		assert.Equal(t, dummyServeHTTPS, serveHTTPS)
+		assert.Equal(t, dummyRequiresServerCert, serveHTTPS)
 		assert.Equal(t, dummyServerCertContent, serverCertContent)
 		assert.Equal(t, dummyServerKeyContent, serverKeyContent)
-		assert.Equal(t, dummyValidateClientCert, validateClientCert)
+		assert.Equal(t, dummyRequiresCaCertPool, validateClientCert)
 		assert.Equal(t, dummyCaCertContent, caCertContent)
 		assert.Equal(t, dummyClientCertContent, clientCertContent)
 		assert.Equal(t, dummyClientKeyContent, clientKeyContent)
@@ -420,12 +572,18 @@ func TestBootstrapApplication_NoError(t *testing.T) {
 		assert.Equal(t, dummyDefaultNetworkTimeout, networkTimeout)
 		assert.Equal(t, dummySkipServerCertVerification, skipServerCertVerification)
 	}
-	loggerAppRootExpected = 1
+	loggerAppRootExpected = 2
 	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAppRootCalled++
 		assert.Equal(t, "application", category)
 		assert.Equal(t, "bootstrapApplication", subcategory)
-		assert.Equal(t, "Application bootstrapped successfully", messageFormat)
+		if loggerAppRootCalled == 1 {
+			assert.Equal(t, "Application configuration resolved as: %v", messageFormat)
+			assert.Equal(t, 1, len(parameters))
+			assert.Equal(t, dummyConfigReport, parameters[0])
+		} else if loggerAppRootCalled == 2 {
+			assert.Equal(t, "Application bootstrapped successfully", messageFormat)
+		}
 	}
 
 	// SUT + act
@@ -839,6 +997,10 @@ func TestStart_PostBoostrapExit(t *testing.T) {
 }
 
 func TestStart_RunApplication(t *testing.T) {
+	// arrange
+	var stopConfigWatcherExpected = 1
+	var stopConfigWatcherCalled = 0
+
 	// mock
 	createMock(t)
 
@@ -862,13 +1024,22 @@ func TestStart_RunApplication(t *testing.T) {
 		doPostBootstrapingFuncCalled++
 		return true
 	}
+	startConfigWatcherFuncExpected = 1
+	startConfigWatcherFunc = func() func() {
+		startConfigWatcherFuncCalled++
+		return func() {
+			stopConfigWatcherCalled++
+		}
+	}
 	doApplicationStartingFuncExpected = 1
 	doApplicationStartingFunc = func() {
 		doApplicationStartingFuncCalled++
+		assert.Equal(t, 0, stopConfigWatcherCalled)
 	}
 	doApplicationClosingFuncExpected = 1
 	doApplicationClosingFunc = func() {
 		doApplicationClosingFuncCalled++
+		assert.Equal(t, 1, stopConfigWatcherCalled)
 	}
 
 	// SUT + act
@@ -876,6 +1047,7 @@ func TestStart_RunApplication(t *testing.T) {
 
 	// verify
 	verifyAll(t)
+	assert.Equal(t, stopConfigWatcherExpected, stopConfigWatcherCalled, "Unexpected number of calls to stopConfigWatcher")
 }
 
 func TestStop(t *testing.T) {
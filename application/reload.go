@@ -0,0 +1,156 @@
+package application
+
+import (
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/config"
+	configModel "github.com/zhongjie-cai/WebServiceTemplate/config/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+)
+
+func isNetworkTimeoutChanged(changes []configModel.Change) bool {
+	for _, change := range changes {
+		if change.Name == "DefaultNetworkTimeout" {
+			return true
+		}
+	}
+	return false
+}
+
+func doConfigReloaded(changes []configModel.Change) {
+	if customization.ConfigReloadedFunc == nil {
+		loggerAppRoot(
+			"application",
+			"doConfigReloaded",
+			"customization.ConfigReloadedFunc is not configured; skipped execution",
+		)
+		return
+	}
+	var configReloadedError = customization.ConfigReloadedFunc(
+		changes,
+	)
+	if configReloadedError != nil {
+		loggerAppRoot(
+			"application",
+			"doConfigReloaded",
+			"Failed to execute customization.ConfigReloadedFunc. Error: %v",
+			configReloadedError,
+		)
+	} else {
+		loggerAppRoot(
+			"application",
+			"doConfigReloaded",
+			"customization.ConfigReloadedFunc executed successfully",
+		)
+	}
+}
+
+func reloadConfig(trigger string) {
+	var changes, reloadError = configReload()
+	if reloadError != nil {
+		loggerAppRoot(
+			"application",
+			"reloadConfig",
+			"Configuration reload triggered by [%v] not completed cleanly. Potential error: %v",
+			trigger,
+			reloadError,
+		)
+	}
+	if len(changes) == 0 {
+		loggerAppRoot(
+			"application",
+			"reloadConfig",
+			"Configuration reload triggered by [%v] resulted in no changes",
+			trigger,
+		)
+		return
+	}
+	loggerAppRoot(
+		"application",
+		"reloadConfig",
+		"Configuration reloaded by [%v] with changes: %v",
+		trigger,
+		changes,
+	)
+	if isNetworkTimeoutChangedFunc(changes) {
+		networkInitialize(
+			config.DefaultNetworkTimeout(),
+			config.SkipServerCertVerification(),
+		)
+	}
+	doConfigReloadedFunc(changes)
+}
+
+func watchConfigChanges(
+	signals <-chan os.Signal,
+	ticks <-chan time.Time,
+	stop <-chan struct{},
+) {
+	for {
+		select {
+		case <-stop:
+			return
+		case receivedSignal := <-signals:
+			reloadConfigFunc(
+				receivedSignal.String(),
+			)
+		case <-ticks:
+			if configSourcesChanged() {
+				reloadConfigFunc(
+					"source change",
+				)
+			}
+		}
+	}
+}
+
+func getConfigWatchInterval() time.Duration {
+	if customization.ConfigWatchInterval == nil {
+		return 0
+	}
+	return customization.ConfigWatchInterval()
+}
+
+func startConfigWatcher() func() {
+	if customization.ConfigSources == nil {
+		loggerAppRoot(
+			"application",
+			"startConfigWatcher",
+			"customization.ConfigSources is not configured; skipped configuration hot reload",
+		)
+		return func() {}
+	}
+	var signals = make(chan os.Signal, 1)
+	signalNotify(
+		signals,
+		syscall.SIGHUP,
+	)
+	var ticks <-chan time.Time
+	var ticker *time.Ticker
+	var watchInterval = getConfigWatchIntervalFunc()
+	if watchInterval > 0 {
+		ticker = timeNewTicker(watchInterval)
+		ticks = ticker.C
+	}
+	var stop = make(chan struct{})
+	go watchConfigChangesFunc(
+		signals,
+		ticks,
+		stop,
+	)
+	loggerAppRoot(
+		"application",
+		"startConfigWatcher",
+		"Configuration hot reload enabled upon SIGHUP or source change polling every [%v]",
+		watchInterval,
+	)
+	return func() {
+		signalStop(signals)
+		if ticker != nil {
+			ticker.Stop()
+		}
+		close(stop)
+	}
+}
@@ -0,0 +1,584 @@
+package application
+
+import (
+	"errors"
+	"math/rand"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/config"
+	configModel "github.com/zhongjie-cai/WebServiceTemplate/config/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+)
+
+func TestIsNetworkTimeoutChanged_NotChanged(t *testing.T) {
+	// arrange
+	var dummyChanges = []configModel.Change{
+		{Name: "DefaultAllowedLogLevel"},
+		{Name: "DefaultAllowedLogType"},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isNetworkTimeoutChanged(
+		dummyChanges,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsNetworkTimeoutChanged_Changed(t *testing.T) {
+	// arrange
+	var dummyChanges = []configModel.Change{
+		{Name: "DefaultAllowedLogLevel"},
+		{Name: "DefaultNetworkTimeout"},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isNetworkTimeoutChanged(
+		dummyChanges,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDoConfigReloaded_NilConfigReloadedFunc(t *testing.T) {
+	// arrange
+	var dummyChanges = []configModel.Change{
+		{Name: "some name"},
+	}
+
+	// stub
+	customization.ConfigReloadedFunc = nil
+
+	// mock
+	createMock(t)
+
+	// expect
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "application", category)
+		assert.Equal(t, "doConfigReloaded", subcategory)
+		assert.Equal(t, "customization.ConfigReloadedFunc is not configured; skipped execution", messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+
+	// SUT + act
+	doConfigReloaded(
+		dummyChanges,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDoConfigReloaded_ConfigReloadedError(t *testing.T) {
+	// arrange
+	var dummyChanges = []configModel.Change{
+		{Name: "some name"},
+	}
+	var customizationConfigReloadedFuncExpected int
+	var customizationConfigReloadedFuncCalled int
+	var dummyError = errors.New("some error message")
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationConfigReloadedFuncExpected = 1
+	customization.ConfigReloadedFunc = func(changes []configModel.Change) error {
+		customizationConfigReloadedFuncCalled++
+		assert.Equal(t, dummyChanges, changes)
+		return dummyError
+	}
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "application", category)
+		assert.Equal(t, "doConfigReloaded", subcategory)
+		assert.Equal(t, "Failed to execute customization.ConfigReloadedFunc. Error: %v", messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, dummyError, parameters[0])
+	}
+
+	// SUT + act
+	doConfigReloaded(
+		dummyChanges,
+	)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, customizationConfigReloadedFuncExpected, customizationConfigReloadedFuncCalled, "Unexpected number of calls to customization.ConfigReloadedFunc")
+}
+
+func TestDoConfigReloaded_ConfigReloadedSuccess(t *testing.T) {
+	// arrange
+	var dummyChanges = []configModel.Change{
+		{Name: "some name"},
+	}
+	var customizationConfigReloadedFuncExpected int
+	var customizationConfigReloadedFuncCalled int
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationConfigReloadedFuncExpected = 1
+	customization.ConfigReloadedFunc = func(changes []configModel.Change) error {
+		customizationConfigReloadedFuncCalled++
+		assert.Equal(t, dummyChanges, changes)
+		return nil
+	}
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "application", category)
+		assert.Equal(t, "doConfigReloaded", subcategory)
+		assert.Equal(t, "customization.ConfigReloadedFunc executed successfully", messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+
+	// SUT + act
+	doConfigReloaded(
+		dummyChanges,
+	)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, customizationConfigReloadedFuncExpected, customizationConfigReloadedFuncCalled, "Unexpected number of calls to customization.ConfigReloadedFunc")
+}
+
+func TestReloadConfig_NoChanges(t *testing.T) {
+	// arrange
+	var dummyTrigger = "some trigger"
+	var dummyReloadError = errors.New("some reload error")
+	var expectedMessageFormats = []string{
+		"Configuration reload triggered by [%v] not completed cleanly. Potential error: %v",
+		"Configuration reload triggered by [%v] resulted in no changes",
+	}
+	var expectedParameters = [][]interface{}{
+		{dummyTrigger, dummyReloadError},
+		{dummyTrigger},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	configReloadExpected = 1
+	configReload = func() ([]configModel.Change, error) {
+		configReloadCalled++
+		return nil, dummyReloadError
+	}
+	loggerAppRootExpected = 2
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "application", category)
+		assert.Equal(t, "reloadConfig", subcategory)
+		assert.Equal(t, expectedMessageFormats[loggerAppRootCalled-1], messageFormat)
+		assert.Equal(t, expectedParameters[loggerAppRootCalled-1], parameters)
+	}
+
+	// SUT + act
+	reloadConfig(
+		dummyTrigger,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestReloadConfig_NetworkTimeoutNotChanged(t *testing.T) {
+	// arrange
+	var dummyTrigger = "some trigger"
+	var dummyChanges = []configModel.Change{
+		{Name: "some name"},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	configReloadExpected = 1
+	configReload = func() ([]configModel.Change, error) {
+		configReloadCalled++
+		return dummyChanges, nil
+	}
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "application", category)
+		assert.Equal(t, "reloadConfig", subcategory)
+		assert.Equal(t, "Configuration reloaded by [%v] with changes: %v", messageFormat)
+		assert.Equal(t, 2, len(parameters))
+		assert.Equal(t, dummyTrigger, parameters[0])
+		assert.Equal(t, dummyChanges, parameters[1])
+	}
+	isNetworkTimeoutChangedFuncExpected = 1
+	isNetworkTimeoutChangedFunc = func(changes []configModel.Change) bool {
+		isNetworkTimeoutChangedFuncCalled++
+		assert.Equal(t, dummyChanges, changes)
+		return false
+	}
+	doConfigReloadedFuncExpected = 1
+	doConfigReloadedFunc = func(changes []configModel.Change) {
+		doConfigReloadedFuncCalled++
+		assert.Equal(t, dummyChanges, changes)
+	}
+
+	// SUT + act
+	reloadConfig(
+		dummyTrigger,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestReloadConfig_NetworkTimeoutChanged(t *testing.T) {
+	// arrange
+	var dummyTrigger = "some trigger"
+	var dummyChanges = []configModel.Change{
+		{Name: "some name"},
+	}
+	var dummyNetworkTimeout = time.Duration(rand.Intn(100))
+	var dummySkipServerCertVerification = rand.Intn(100) < 50
+
+	// mock
+	createMock(t)
+
+	// expect
+	configReloadExpected = 1
+	configReload = func() ([]configModel.Change, error) {
+		configReloadCalled++
+		return dummyChanges, nil
+	}
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+	}
+	isNetworkTimeoutChangedFuncExpected = 1
+	isNetworkTimeoutChangedFunc = func(changes []configModel.Change) bool {
+		isNetworkTimeoutChangedFuncCalled++
+		assert.Equal(t, dummyChanges, changes)
+		return true
+	}
+	configDefaultNetworkTimeoutExpected = 1
+	config.DefaultNetworkTimeout = func() time.Duration {
+		configDefaultNetworkTimeoutCalled++
+		return dummyNetworkTimeout
+	}
+	configSkipServerCertVerificationExpected = 1
+	config.SkipServerCertVerification = func() bool {
+		configSkipServerCertVerificationCalled++
+		return dummySkipServerCertVerification
+	}
+	networkInitializeExpected = 1
+	networkInitialize = func(networkTimeout time.Duration, skipServerCertVerification bool) {
+		networkInitializeCalled++
+		assert.Equal(t, dummyNetworkTimeout, networkTimeout)
+		assert.Equal(t, dummySkipServerCertVerification, skipServerCertVerification)
+	}
+	doConfigReloadedFuncExpected = 1
+	doConfigReloadedFunc = func(changes []configModel.Change) {
+		doConfigReloadedFuncCalled++
+		assert.Equal(t, dummyChanges, changes)
+	}
+
+	// SUT + act
+	reloadConfig(
+		dummyTrigger,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWatchConfigChanges_Signal(t *testing.T) {
+	// arrange
+	var dummySignals = make(chan os.Signal, 1)
+	var dummyTicks = make(chan time.Time)
+	var dummyStop = make(chan struct{})
+
+	// stub
+	dummySignals <- syscall.SIGHUP
+
+	// mock
+	createMock(t)
+
+	// expect
+	reloadConfigFuncExpected = 1
+	reloadConfigFunc = func(trigger string) {
+		reloadConfigFuncCalled++
+		assert.Equal(t, syscall.SIGHUP.String(), trigger)
+		close(dummyStop)
+	}
+
+	// SUT + act
+	watchConfigChanges(
+		dummySignals,
+		dummyTicks,
+		dummyStop,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWatchConfigChanges_TickNoChange(t *testing.T) {
+	// arrange
+	var dummySignals = make(chan os.Signal)
+	var dummyTicks = make(chan time.Time, 1)
+	var dummyStop = make(chan struct{})
+
+	// stub
+	dummyTicks <- time.Now()
+
+	// mock
+	createMock(t)
+
+	// expect
+	configSourcesChangedExpected = 1
+	configSourcesChanged = func() bool {
+		configSourcesChangedCalled++
+		close(dummyStop)
+		return false
+	}
+
+	// SUT + act
+	watchConfigChanges(
+		dummySignals,
+		dummyTicks,
+		dummyStop,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWatchConfigChanges_TickChanged(t *testing.T) {
+	// arrange
+	var dummySignals = make(chan os.Signal)
+	var dummyTicks = make(chan time.Time, 1)
+	var dummyStop = make(chan struct{})
+
+	// stub
+	dummyTicks <- time.Now()
+
+	// mock
+	createMock(t)
+
+	// expect
+	configSourcesChangedExpected = 1
+	configSourcesChanged = func() bool {
+		configSourcesChangedCalled++
+		return true
+	}
+	reloadConfigFuncExpected = 1
+	reloadConfigFunc = func(trigger string) {
+		reloadConfigFuncCalled++
+		assert.Equal(t, "source change", trigger)
+		close(dummyStop)
+	}
+
+	// SUT + act
+	watchConfigChanges(
+		dummySignals,
+		dummyTicks,
+		dummyStop,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetConfigWatchInterval_NotCustomized(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getConfigWatchInterval()
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetConfigWatchInterval_Customized(t *testing.T) {
+	// arrange
+	var dummyInterval = time.Duration(rand.Intn(100))
+
+	// stub
+	customization.ConfigWatchInterval = func() time.Duration {
+		return dummyInterval
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getConfigWatchInterval()
+
+	// assert
+	assert.Equal(t, dummyInterval, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStartConfigWatcher_NoConfigSources(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "application", category)
+		assert.Equal(t, "startConfigWatcher", subcategory)
+		assert.Equal(t, "customization.ConfigSources is not configured; skipped configuration hot reload", messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+
+	// SUT + act
+	var result = startConfigWatcher()
+	result()
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStartConfigWatcher_NoInterval(t *testing.T) {
+	// arrange
+	var watchStarted = make(chan struct{})
+	var signalChannel chan<- os.Signal
+	var stopChannel <-chan struct{}
+
+	// stub
+	customization.ConfigSources = func() []configModel.Source {
+		return nil
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	signalNotifyExpected = 1
+	signalNotify = func(c chan<- os.Signal, sig ...os.Signal) {
+		signalNotifyCalled++
+		assert.Equal(t, []os.Signal{syscall.SIGHUP}, sig)
+		signalChannel = c
+	}
+	getConfigWatchIntervalFuncExpected = 1
+	getConfigWatchIntervalFunc = func() time.Duration {
+		getConfigWatchIntervalFuncCalled++
+		return 0
+	}
+	watchConfigChangesFuncExpected = 1
+	watchConfigChangesFunc = func(signals <-chan os.Signal, ticks <-chan time.Time, stop <-chan struct{}) {
+		watchConfigChangesFuncCalled++
+		assert.Nil(t, ticks)
+		stopChannel = stop
+		close(watchStarted)
+	}
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "application", category)
+		assert.Equal(t, "startConfigWatcher", subcategory)
+		assert.Equal(t, "Configuration hot reload enabled upon SIGHUP or source change polling every [%v]", messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, time.Duration(0), parameters[0])
+	}
+	signalStopExpected = 1
+	signalStop = func(c chan<- os.Signal) {
+		signalStopCalled++
+		assert.Equal(t, signalChannel, c)
+	}
+
+	// SUT + act
+	var result = startConfigWatcher()
+	<-watchStarted
+	result()
+
+	// assert
+	var _, isOpen = <-stopChannel
+	assert.False(t, isOpen)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStartConfigWatcher_WithInterval(t *testing.T) {
+	// arrange
+	var dummyInterval = time.Hour
+	var dummyTicker = time.NewTicker(dummyInterval)
+	var watchStarted = make(chan struct{})
+	var stopChannel <-chan struct{}
+
+	// stub
+	customization.ConfigSources = func() []configModel.Source {
+		return nil
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	signalNotifyExpected = 1
+	getConfigWatchIntervalFuncExpected = 1
+	getConfigWatchIntervalFunc = func() time.Duration {
+		getConfigWatchIntervalFuncCalled++
+		return dummyInterval
+	}
+	timeNewTickerExpected = 1
+	timeNewTicker = func(d time.Duration) *time.Ticker {
+		timeNewTickerCalled++
+		assert.Equal(t, dummyInterval, d)
+		return dummyTicker
+	}
+	watchConfigChangesFuncExpected = 1
+	watchConfigChangesFunc = func(signals <-chan os.Signal, ticks <-chan time.Time, stop <-chan struct{}) {
+		watchConfigChangesFuncCalled++
+		assert.Equal(t, dummyTicker.C, ticks)
+		stopChannel = stop
+		close(watchStarted)
+	}
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, dummyInterval, parameters[0])
+	}
+	signalStopExpected = 1
+
+	// SUT + act
+	var result = startConfigWatcher()
+	<-watchStarted
+	result()
+
+	// assert
+	var _, isOpen = <-stopChannel
+	assert.False(t, isOpen)
+
+	// verify
+	verifyAll(t)
+}
@@ -0,0 +1,50 @@
+package binding
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	"github.com/zhongjie-cai/WebServiceTemplate/request"
+)
+
+// func pointers for injection / testing: binding.go
+var (
+	fmtSprintf                     = fmt.Sprintf
+	fmtErrorf                      = fmt.Errorf
+	stringsSplit                   = strings.Split
+	stringsJoin                    = strings.Join
+	muxVars                        = mux.Vars
+	requestGetRequestBody          = request.GetRequestBody
+	apperrorGetBadRequestError     = apperror.GetBadRequestError
+	apperrorGetGeneralFailureError = apperror.GetGeneralFailureError
+	apperrorGetInnermostErrors     = apperror.GetInnermostErrors
+	getFieldNameFunc               = getFieldName
+	getBindErrorMessageFunc        = getBindErrorMessage
+	bindParameterFunc              = bindParameter
+	bindValuesFunc                 = bindValues
+	bindBodyFunc                   = bindBody
+	bindFieldFunc                  = bindField
+	getViolationErrorFunc          = getViolationError
+)
+
+// func pointers for injection / testing: validation.go
+var (
+	fmtSprint         = fmt.Sprint
+	stringsTrimSpace  = strings.TrimSpace
+	stringsHasPrefix  = strings.HasPrefix
+	stringsSplitN     = strings.SplitN
+	strconvParseFloat = strconv.ParseFloat
+	regexpCompile     = regexp.Compile
+	parseRulesFunc    = parseRules
+	getElementsFunc   = getElements
+	getMeasureFunc    = getMeasure
+	checkBoundFunc    = checkBound
+	checkEnumFunc     = checkEnum
+	checkRegexFunc    = checkRegex
+	checkRuleFunc     = checkRule
+	validateFieldFunc = validateField
+)
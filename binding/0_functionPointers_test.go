@@ -0,0 +1,492 @@
+package binding
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
+	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/request"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+var (
+	fmtSprintfExpected                     int
+	fmtSprintfCalled                       int
+	fmtErrorfExpected                      int
+	fmtErrorfCalled                        int
+	stringsSplitExpected                   int
+	stringsSplitCalled                     int
+	stringsJoinExpected                    int
+	stringsJoinCalled                      int
+	muxVarsExpected                        int
+	muxVarsCalled                          int
+	requestGetRequestBodyExpected          int
+	requestGetRequestBodyCalled            int
+	apperrorGetBadRequestErrorExpected     int
+	apperrorGetBadRequestErrorCalled       int
+	apperrorGetGeneralFailureErrorExpected int
+	apperrorGetGeneralFailureErrorCalled   int
+	apperrorGetInnermostErrorsExpected     int
+	apperrorGetInnermostErrorsCalled       int
+	getFieldNameFuncExpected               int
+	getFieldNameFuncCalled                 int
+	getBindErrorMessageFuncExpected        int
+	getBindErrorMessageFuncCalled          int
+	bindParameterFuncExpected              int
+	bindParameterFuncCalled                int
+	bindValuesFuncExpected                 int
+	bindValuesFuncCalled                   int
+	bindBodyFuncExpected                   int
+	bindBodyFuncCalled                     int
+	bindFieldFuncExpected                  int
+	bindFieldFuncCalled                    int
+	getViolationErrorFuncExpected          int
+	getViolationErrorFuncCalled            int
+	fmtSprintExpected                      int
+	fmtSprintCalled                        int
+	stringsTrimSpaceExpected               int
+	stringsTrimSpaceCalled                 int
+	stringsHasPrefixExpected               int
+	stringsHasPrefixCalled                 int
+	stringsSplitNExpected                  int
+	stringsSplitNCalled                    int
+	strconvParseFloatExpected              int
+	strconvParseFloatCalled                int
+	regexpCompileExpected                  int
+	regexpCompileCalled                    int
+	parseRulesFuncExpected                 int
+	parseRulesFuncCalled                   int
+	getElementsFuncExpected                int
+	getElementsFuncCalled                  int
+	getMeasureFuncExpected                 int
+	getMeasureFuncCalled                   int
+	checkBoundFuncExpected                 int
+	checkBoundFuncCalled                   int
+	checkEnumFuncExpected                  int
+	checkEnumFuncCalled                    int
+	checkRegexFuncExpected                 int
+	checkRegexFuncCalled                   int
+	checkRuleFuncExpected                  int
+	checkRuleFuncCalled                    int
+	validateFieldFuncExpected              int
+	validateFieldFuncCalled                int
+)
+
+func createMock(t *testing.T) {
+	fmtSprintfExpected = 0
+	fmtSprintfCalled = 0
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		return ""
+	}
+	fmtErrorfExpected = 0
+	fmtErrorfCalled = 0
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		return nil
+	}
+	stringsSplitExpected = 0
+	stringsSplitCalled = 0
+	stringsSplit = func(s string, sep string) []string {
+		stringsSplitCalled++
+		return nil
+	}
+	stringsJoinExpected = 0
+	stringsJoinCalled = 0
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		return ""
+	}
+	muxVarsExpected = 0
+	muxVarsCalled = 0
+	muxVars = func(r *http.Request) map[string]string {
+		muxVarsCalled++
+		return nil
+	}
+	requestGetRequestBodyExpected = 0
+	requestGetRequestBodyCalled = 0
+	requestGetRequestBody = func(httpRequest *http.Request) string {
+		requestGetRequestBodyCalled++
+		return ""
+	}
+	apperrorGetBadRequestErrorExpected = 0
+	apperrorGetBadRequestErrorCalled = 0
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		return nil
+	}
+	apperrorGetGeneralFailureErrorExpected = 0
+	apperrorGetGeneralFailureErrorCalled = 0
+	apperrorGetGeneralFailureError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetGeneralFailureErrorCalled++
+		return nil
+	}
+	apperrorGetInnermostErrorsExpected = 0
+	apperrorGetInnermostErrorsCalled = 0
+	apperrorGetInnermostErrors = func(err error) []error {
+		apperrorGetInnermostErrorsCalled++
+		return nil
+	}
+	getFieldNameFuncExpected = 0
+	getFieldNameFuncCalled = 0
+	getFieldNameFunc = func(field reflect.StructField) string {
+		getFieldNameFuncCalled++
+		return ""
+	}
+	getBindErrorMessageFuncExpected = 0
+	getBindErrorMessageFuncCalled = 0
+	getBindErrorMessageFunc = func(bindError error) string {
+		getBindErrorMessageFuncCalled++
+		return ""
+	}
+	bindParameterFuncExpected = 0
+	bindParameterFuncCalled = 0
+	bindParameterFunc = func(session sessionModel.Session, name string, fieldValue reflect.Value) (bool, error) {
+		bindParameterFuncCalled++
+		return false, nil
+	}
+	bindValuesFuncExpected = 0
+	bindValuesFuncCalled = 0
+	bindValuesFunc = func(name string, fieldValue reflect.Value, loadValues func(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError) (bool, error) {
+		bindValuesFuncCalled++
+		return false, nil
+	}
+	bindBodyFuncExpected = 0
+	bindBodyFuncCalled = 0
+	bindBodyFunc = func(session sessionModel.Session, fieldValue reflect.Value) (bool, error) {
+		bindBodyFuncCalled++
+		return false, nil
+	}
+	bindFieldFuncExpected = 0
+	bindFieldFuncCalled = 0
+	bindFieldFunc = func(session sessionModel.Session, field reflect.StructField, fieldValue reflect.Value) (string, bool, error) {
+		bindFieldFuncCalled++
+		return "", false, nil
+	}
+	getViolationErrorFuncExpected = 0
+	getViolationErrorFuncCalled = 0
+	getViolationErrorFunc = func(fieldViolations *violations) apperrorModel.AppError {
+		getViolationErrorFuncCalled++
+		return nil
+	}
+	fmtSprintExpected = 0
+	fmtSprintCalled = 0
+	fmtSprint = func(a ...interface{}) string {
+		fmtSprintCalled++
+		return ""
+	}
+	stringsTrimSpaceExpected = 0
+	stringsTrimSpaceCalled = 0
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return ""
+	}
+	stringsHasPrefixExpected = 0
+	stringsHasPrefixCalled = 0
+	stringsHasPrefix = func(s string, prefix string) bool {
+		stringsHasPrefixCalled++
+		return false
+	}
+	stringsSplitNExpected = 0
+	stringsSplitNCalled = 0
+	stringsSplitN = func(s string, sep string, n int) []string {
+		stringsSplitNCalled++
+		return nil
+	}
+	strconvParseFloatExpected = 0
+	strconvParseFloatCalled = 0
+	strconvParseFloat = func(s string, bitSize int) (float64, error) {
+		strconvParseFloatCalled++
+		return 0, nil
+	}
+	regexpCompileExpected = 0
+	regexpCompileCalled = 0
+	regexpCompile = func(expr string) (*regexp.Regexp, error) {
+		regexpCompileCalled++
+		return nil, nil
+	}
+	parseRulesFuncExpected = 0
+	parseRulesFuncCalled = 0
+	parseRulesFunc = func(tag string) []validationRule {
+		parseRulesFuncCalled++
+		return nil
+	}
+	getElementsFuncExpected = 0
+	getElementsFuncCalled = 0
+	getElementsFunc = func(value reflect.Value) []reflect.Value {
+		getElementsFuncCalled++
+		return nil
+	}
+	getMeasureFuncExpected = 0
+	getMeasureFuncCalled = 0
+	getMeasureFunc = func(value reflect.Value) (float64, bool, bool) {
+		getMeasureFuncCalled++
+		return 0, false, false
+	}
+	checkBoundFuncExpected = 0
+	checkBoundFuncCalled = 0
+	checkBoundFunc = func(rule validationRule, value reflect.Value) string {
+		checkBoundFuncCalled++
+		return ""
+	}
+	checkEnumFuncExpected = 0
+	checkEnumFuncCalled = 0
+	checkEnumFunc = func(rule validationRule, value reflect.Value) string {
+		checkEnumFuncCalled++
+		return ""
+	}
+	checkRegexFuncExpected = 0
+	checkRegexFuncCalled = 0
+	checkRegexFunc = func(rule validationRule, value reflect.Value) string {
+		checkRegexFuncCalled++
+		return ""
+	}
+	checkRuleFuncExpected = 0
+	checkRuleFuncCalled = 0
+	checkRuleFunc = func(rule validationRule, value reflect.Value) string {
+		checkRuleFuncCalled++
+		return ""
+	}
+	validateFieldFuncExpected = 0
+	validateFieldFuncCalled = 0
+	validateFieldFunc = func(fieldViolations *violations, name string, tag string, fieldValue reflect.Value, isPresent bool) {
+		validateFieldFuncCalled++
+	}
+}
+
+func verifyAll(t *testing.T) {
+	fmtSprintf = fmt.Sprintf
+	assert.Equal(t, fmtSprintfExpected, fmtSprintfCalled, "Unexpected number of calls to fmtSprintf")
+	fmtErrorf = fmt.Errorf
+	assert.Equal(t, fmtErrorfExpected, fmtErrorfCalled, "Unexpected number of calls to fmtErrorf")
+	stringsSplit = strings.Split
+	assert.Equal(t, stringsSplitExpected, stringsSplitCalled, "Unexpected number of calls to stringsSplit")
+	stringsJoin = strings.Join
+	assert.Equal(t, stringsJoinExpected, stringsJoinCalled, "Unexpected number of calls to stringsJoin")
+	muxVars = mux.Vars
+	assert.Equal(t, muxVarsExpected, muxVarsCalled, "Unexpected number of calls to muxVars")
+	requestGetRequestBody = request.GetRequestBody
+	assert.Equal(t, requestGetRequestBodyExpected, requestGetRequestBodyCalled, "Unexpected number of calls to requestGetRequestBody")
+	apperrorGetBadRequestError = apperror.GetBadRequestError
+	assert.Equal(t, apperrorGetBadRequestErrorExpected, apperrorGetBadRequestErrorCalled, "Unexpected number of calls to apperrorGetBadRequestError")
+	apperrorGetGeneralFailureError = apperror.GetGeneralFailureError
+	assert.Equal(t, apperrorGetGeneralFailureErrorExpected, apperrorGetGeneralFailureErrorCalled, "Unexpected number of calls to apperrorGetGeneralFailureError")
+	apperrorGetInnermostErrors = apperror.GetInnermostErrors
+	assert.Equal(t, apperrorGetInnermostErrorsExpected, apperrorGetInnermostErrorsCalled, "Unexpected number of calls to apperrorGetInnermostErrors")
+	getFieldNameFunc = getFieldName
+	assert.Equal(t, getFieldNameFuncExpected, getFieldNameFuncCalled, "Unexpected number of calls to getFieldNameFunc")
+	getBindErrorMessageFunc = getBindErrorMessage
+	assert.Equal(t, getBindErrorMessageFuncExpected, getBindErrorMessageFuncCalled, "Unexpected number of calls to getBindErrorMessageFunc")
+	bindParameterFunc = bindParameter
+	assert.Equal(t, bindParameterFuncExpected, bindParameterFuncCalled, "Unexpected number of calls to bindParameterFunc")
+	bindValuesFunc = bindValues
+	assert.Equal(t, bindValuesFuncExpected, bindValuesFuncCalled, "Unexpected number of calls to bindValuesFunc")
+	bindBodyFunc = bindBody
+	assert.Equal(t, bindBodyFuncExpected, bindBodyFuncCalled, "Unexpected number of calls to bindBodyFunc")
+	bindFieldFunc = bindField
+	assert.Equal(t, bindFieldFuncExpected, bindFieldFuncCalled, "Unexpected number of calls to bindFieldFunc")
+	getViolationErrorFunc = getViolationError
+	assert.Equal(t, getViolationErrorFuncExpected, getViolationErrorFuncCalled, "Unexpected number of calls to getViolationErrorFunc")
+	fmtSprint = fmt.Sprint
+	assert.Equal(t, fmtSprintExpected, fmtSprintCalled, "Unexpected number of calls to fmtSprint")
+	stringsTrimSpace = strings.TrimSpace
+	assert.Equal(t, stringsTrimSpaceExpected, stringsTrimSpaceCalled, "Unexpected number of calls to stringsTrimSpace")
+	stringsHasPrefix = strings.HasPrefix
+	assert.Equal(t, stringsHasPrefixExpected, stringsHasPrefixCalled, "Unexpected number of calls to stringsHasPrefix")
+	stringsSplitN = strings.SplitN
+	assert.Equal(t, stringsSplitNExpected, stringsSplitNCalled, "Unexpected number of calls to stringsSplitN")
+	strconvParseFloat = strconv.ParseFloat
+	assert.Equal(t, strconvParseFloatExpected, strconvParseFloatCalled, "Unexpected number of calls to strconvParseFloat")
+	regexpCompile = regexp.Compile
+	assert.Equal(t, regexpCompileExpected, regexpCompileCalled, "Unexpected number of calls to regexpCompile")
+	parseRulesFunc = parseRules
+	assert.Equal(t, parseRulesFuncExpected, parseRulesFuncCalled, "Unexpected number of calls to parseRulesFunc")
+	getElementsFunc = getElements
+	assert.Equal(t, getElementsFuncExpected, getElementsFuncCalled, "Unexpected number of calls to getElementsFunc")
+	getMeasureFunc = getMeasure
+	assert.Equal(t, getMeasureFuncExpected, getMeasureFuncCalled, "Unexpected number of calls to getMeasureFunc")
+	checkBoundFunc = checkBound
+	assert.Equal(t, checkBoundFuncExpected, checkBoundFuncCalled, "Unexpected number of calls to checkBoundFunc")
+	checkEnumFunc = checkEnum
+	assert.Equal(t, checkEnumFuncExpected, checkEnumFuncCalled, "Unexpected number of calls to checkEnumFunc")
+	checkRegexFunc = checkRegex
+	assert.Equal(t, checkRegexFuncExpected, checkRegexFuncCalled, "Unexpected number of calls to checkRegexFunc")
+	checkRuleFunc = checkRule
+	assert.Equal(t, checkRuleFuncExpected, checkRuleFuncCalled, "Unexpected number of calls to checkRuleFunc")
+	validateFieldFunc = validateField
+	assert.Equal(t, validateFieldFuncExpected, validateFieldFuncCalled, "Unexpected number of calls to validateFieldFunc")
+}
+
+// mock structs
+type dummySession struct {
+	t                   *testing.T
+	httpRequest         *http.Request
+	responseWriter      http.ResponseWriter
+	getRequestBody      func(dataTemplate interface{}) apperrorModel.AppError
+	getRequestParameter func(name string, dataTemplate interface{}) apperrorModel.AppError
+	getRequestQueries   func(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError
+	getRequestHeaders   func(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError
+}
+
+func (session *dummySession) GetID() uuid.UUID {
+	assert.Fail(session.t, "Unexpected call to GetID")
+	return uuid.Nil
+}
+
+func (session *dummySession) GetName() string {
+	assert.Fail(session.t, "Unexpected call to GetName")
+	return ""
+}
+
+func (session *dummySession) GetCorrelationID() string {
+	assert.Fail(session.t, "Unexpected call to GetCorrelationID")
+	return ""
+}
+
+func (session *dummySession) GetRequest() *http.Request {
+	if session.httpRequest == nil {
+		assert.Fail(session.t, "Unexpected call to GetRequest")
+	}
+	return session.httpRequest
+}
+
+func (session *dummySession) GetResponseWriter() http.ResponseWriter {
+	if session.responseWriter == nil {
+		assert.Fail(session.t, "Unexpected call to GetResponseWriter")
+	}
+	return session.responseWriter
+}
+
+func (session *dummySession) SetResponseETag(etag string, weak bool) {
+	assert.Fail(session.t, "Unexpected call to SetResponseETag")
+}
+
+func (session *dummySession) SetResponseLastModified(lastModified time.Time) {
+	assert.Fail(session.t, "Unexpected call to SetResponseLastModified")
+}
+
+func (session *dummySession) GetRequestBody(dataTemplate interface{}) apperrorModel.AppError {
+	if session.getRequestBody == nil {
+		assert.Fail(session.t, "Unexpected call to GetRequestBody")
+		return nil
+	}
+	return session.getRequestBody(dataTemplate)
+}
+
+func (session *dummySession) GetRequestParameter(name string, dataTemplate interface{}) apperrorModel.AppError {
+	if session.getRequestParameter == nil {
+		assert.Fail(session.t, "Unexpected call to GetRequestParameter")
+		return nil
+	}
+	return session.getRequestParameter(name, dataTemplate)
+}
+
+func (session *dummySession) GetRequestQuery(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestQuery")
+	return nil
+}
+
+func (session *dummySession) GetRequestQueries(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	if session.getRequestQueries == nil {
+		assert.Fail(session.t, "Unexpected call to GetRequestQueries")
+		return nil
+	}
+	return session.getRequestQueries(name, dataTemplate, fillCallback)
+}
+
+func (session *dummySession) GetRequestHeader(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestHeader")
+	return nil
+}
+
+func (session *dummySession) GetRequestHeaders(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	if session.getRequestHeaders == nil {
+		assert.Fail(session.t, "Unexpected call to GetRequestHeaders")
+		return nil
+	}
+	return session.getRequestHeaders(name, dataTemplate, fillCallback)
+}
+
+func (session *dummySession) GetRequestFormValue(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValue")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValues(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValues")
+	return nil
+}
+
+func (session *dummySession) GetRequestFile(name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFile")
+	return nil
+}
+
+func (session *dummySession) BindRequest(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to BindRequest")
+	return nil
+}
+
+func (session *dummySession) Attach(name string, value interface{}) bool {
+	assert.Fail(session.t, "Unexpected call to Attach")
+	return false
+}
+
+func (session *dummySession) Detach(name string) bool {
+	assert.Fail(session.t, "Unexpected call to Detach")
+	return false
+}
+
+func (session *dummySession) GetRawAttachment(name string) (interface{}, bool) {
+	assert.Fail(session.t, "Unexpected call to GetRawAttachment")
+	return nil, false
+}
+
+func (session *dummySession) GetAttachment(name string, dataTemplate interface{}) bool {
+	assert.Fail(session.t, "Unexpected call to GetAttachment")
+	return false
+}
+
+func (session *dummySession) IsLoggingAllowed(logType logtype.LogType, logLevel loglevel.LogLevel) bool {
+	assert.Fail(session.t, "Unexpected call to IsLoggingAllowed")
+	return false
+}
+
+func (session *dummySession) LogMethodEnter() {
+	assert.Fail(session.t, "Unexpected call to LogMethodEnter")
+}
+
+func (session *dummySession) LogMethodParameter(parameters ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodParameter")
+}
+
+func (session *dummySession) LogMethodLogic(logLevel loglevel.LogLevel, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodLogic")
+}
+
+func (session *dummySession) LogMethodReturn(returns ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodReturn")
+}
+
+func (session *dummySession) LogMethodExit() {
+	assert.Fail(session.t, "Unexpected call to LogMethodExit")
+}
+
+func (session *dummySession) CreateNetworkRequest(method string, url string, payload string, header map[string]string) networkModel.NetworkRequest {
+	assert.Fail(session.t, "Unexpected call to CreateNetworkRequest")
+	return nil
+}
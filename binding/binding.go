@@ -0,0 +1,262 @@
+package binding
+
+import (
+	"reflect"
+
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+// These are the struct tags used for request binding and validation
+const (
+	PathTag     = "path"
+	QueryTag    = "query"
+	HeaderTag   = "header"
+	BodyTag     = "body"
+	ValidateTag = "validate"
+	jsonTag     = "json"
+)
+
+// These are the constants used for naming fields in violations
+const (
+	tagSeparator   = ","
+	skippedTagName = "-"
+	nameSeparator  = "."
+)
+
+// violations collects the validation failure messages by field names in order of occurrence
+type violations struct {
+	names    []string
+	messages map[string][]string
+}
+
+func (fieldViolations *violations) add(name string, message string) {
+	if fieldViolations.messages == nil {
+		fieldViolations.messages = map[string][]string{}
+	}
+	var _, found = fieldViolations.messages[name]
+	if !found {
+		fieldViolations.names = append(
+			fieldViolations.names,
+			name,
+		)
+	}
+	fieldViolations.messages[name] = append(
+		fieldViolations.messages[name],
+		message,
+	)
+}
+
+// getFieldName returns the name of the given struct field upon its json tag, or its own name if not tagged
+func getFieldName(field reflect.StructField) string {
+	var name = stringsSplit(
+		field.Tag.Get(jsonTag),
+		tagSeparator,
+	)[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func getBindErrorMessage(bindError error) string {
+	var messages = []string{}
+	for _, innermostError := range apperrorGetInnermostErrors(bindError) {
+		messages = append(
+			messages,
+			innermostError.Error(),
+		)
+	}
+	return fmtSprintf(
+		"has invalid value: %v",
+		stringsJoin(messages, "; "),
+	)
+}
+
+func bindParameter(session sessionModel.Session, name string, fieldValue reflect.Value) (bool, error) {
+	var _, found = muxVars(session.GetRequest())[name]
+	if !found {
+		return false, nil
+	}
+	var bindError = session.GetRequestParameter(
+		name,
+		fieldValue.Addr().Interface(),
+	)
+	if bindError != nil {
+		return true, bindError
+	}
+	return true, nil
+}
+
+// bindValues fills the given field with the values loaded for given name, taking all values for slice fields or the first value otherwise
+func bindValues(
+	name string,
+	fieldValue reflect.Value,
+	loadValues func(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError,
+) (bool, error) {
+	var isSlice = fieldValue.Kind() == reflect.Slice &&
+		fieldValue.Type().Elem().Kind() != reflect.Uint8
+	var itemType = fieldValue.Type()
+	if isSlice {
+		itemType = itemType.Elem()
+	}
+	var item = reflect.New(itemType)
+	var items = reflect.MakeSlice(reflect.SliceOf(itemType), 0, 0)
+	var loadError = loadValues(
+		name,
+		item.Interface(),
+		func() {
+			items = reflect.Append(items, item.Elem())
+			item.Elem().Set(reflect.Zero(itemType))
+		},
+	)
+	if items.Len() > 0 {
+		if isSlice {
+			fieldValue.Set(items.Convert(fieldValue.Type()))
+		} else {
+			fieldValue.Set(items.Index(0))
+		}
+	}
+	if loadError != nil {
+		return true, loadError
+	}
+	return items.Len() > 0, nil
+}
+
+func bindBody(session sessionModel.Session, fieldValue reflect.Value) (bool, error) {
+	var requestBody = requestGetRequestBody(
+		session.GetRequest(),
+	)
+	if requestBody == "" {
+		return false, nil
+	}
+	var bindError = session.GetRequestBody(
+		fieldValue.Addr().Interface(),
+	)
+	if bindError != nil {
+		return true, bindError
+	}
+	return true, nil
+}
+
+// bindField populates the given struct field from the request of the session upon its path, query, header or body tag, and returns the field name used in violations, whether the field is present in the request, and the binding error if any; fields without these tags are only checked for presence
+func bindField(session sessionModel.Session, field reflect.StructField, fieldValue reflect.Value) (string, bool, error) {
+	var name, isPath = field.Tag.Lookup(PathTag)
+	if isPath {
+		var isPresent, bindError = bindParameterFunc(
+			session,
+			name,
+			fieldValue,
+		)
+		return name, isPresent, bindError
+	}
+	name, isQuery := field.Tag.Lookup(QueryTag)
+	if isQuery {
+		var isPresent, bindError = bindValuesFunc(
+			name,
+			fieldValue,
+			session.GetRequestQueries,
+		)
+		return name, isPresent, bindError
+	}
+	name, isHeader := field.Tag.Lookup(HeaderTag)
+	if isHeader {
+		var isPresent, bindError = bindValuesFunc(
+			name,
+			fieldValue,
+			session.GetRequestHeaders,
+		)
+		return name, isPresent, bindError
+	}
+	name, isBody := field.Tag.Lookup(BodyTag)
+	if isBody {
+		if name == "" {
+			name = BodyTag
+		}
+		var isPresent, bindError = bindBodyFunc(
+			session,
+			fieldValue,
+		)
+		return name, isPresent, bindError
+	}
+	return getFieldNameFunc(field), !fieldValue.IsZero(), nil
+}
+
+func getViolationError(fieldViolations *violations) apperrorModel.AppError {
+	if len(fieldViolations.names) == 0 {
+		return nil
+	}
+	var innerErrors = []error{}
+	for _, name := range fieldViolations.names {
+		for _, message := range fieldViolations.messages[name] {
+			innerErrors = append(
+				innerErrors,
+				fmtErrorf(
+					"Field [%v] %v",
+					name,
+					message,
+				),
+			)
+		}
+	}
+	var appError = apperrorGetBadRequestError(
+		innerErrors...,
+	)
+	for _, name := range fieldViolations.names {
+		appError.Attach(
+			name,
+			fieldViolations.messages[name],
+		)
+	}
+	return appError
+}
+
+// Bind populates the given data template struct from the HTTP request of the session upon the path, query, header and body tags of its fields, then validates the fields upon their validate tags; all binding and validation failures are returned together in a single BadRequest error, with violation messages by field names in its extra data
+func Bind(session sessionModel.Session, dataTemplate interface{}) apperrorModel.AppError {
+	var templateValue = reflect.ValueOf(dataTemplate)
+	if templateValue.Kind() != reflect.Ptr ||
+		templateValue.IsNil() ||
+		templateValue.Elem().Kind() != reflect.Struct {
+		return apperrorGetGeneralFailureError(
+			fmtErrorf(
+				"Unable to bind request into data template of type [%T]",
+				dataTemplate,
+			),
+		)
+	}
+	var fieldViolations = &violations{}
+	var structValue = templateValue.Elem()
+	var structType = structValue.Type()
+	for index := 0; index < structType.NumField(); index++ {
+		var field = structType.Field(index)
+		if field.PkgPath != "" {
+			continue
+		}
+		var fieldValue = structValue.Field(index)
+		var name, isPresent, bindError = bindFieldFunc(
+			session,
+			field,
+			fieldValue,
+		)
+		if name == skippedTagName {
+			continue
+		}
+		if bindError != nil {
+			fieldViolations.add(
+				name,
+				getBindErrorMessageFunc(bindError),
+			)
+			continue
+		}
+		validateFieldFunc(
+			fieldViolations,
+			name,
+			field.Tag.Get(ValidateTag),
+			fieldValue,
+			isPresent,
+		)
+	}
+	return getViolationErrorFunc(
+		fieldViolations,
+	)
+}
@@ -0,0 +1,922 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/request"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+func TestViolationsAdd(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &violations{}
+
+	// act
+	sut.add("some name 1", "some message 1")
+	sut.add("some name 2", "some message 2")
+	sut.add("some name 1", "some message 3")
+
+	// assert
+	assert.Equal(t, []string{"some name 1", "some name 2"}, sut.names)
+	assert.Equal(t, []string{"some message 1", "some message 3"}, sut.messages["some name 1"])
+	assert.Equal(t, []string{"some message 2"}, sut.messages["some name 2"])
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetFieldName(t *testing.T) {
+	// arrange
+	var dummyStruct struct {
+		Untagged  string
+		Tagged    string `json:"tagged"`
+		Omitempty string `json:",omitempty"`
+		Skipped   string `json:"-"`
+	}
+	var dummyType = reflect.TypeOf(dummyStruct)
+	var expectedNames = []string{"Untagged", "tagged", "Omitempty", "-"}
+
+	for index, expectedName := range expectedNames {
+		// mock
+		createMock(t)
+
+		// expect
+		stringsSplitExpected = 1
+		stringsSplit = func(s string, sep string) []string {
+			stringsSplitCalled++
+			assert.Equal(t, ",", sep)
+			return strings.Split(s, sep)
+		}
+
+		// SUT + act
+		var result = getFieldName(
+			dummyType.Field(index),
+		)
+
+		// assert
+		assert.Equal(t, expectedName, result)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestGetBindErrorMessage(t *testing.T) {
+	// arrange
+	var dummyBindError = errors.New("some bind error")
+	var dummyInnermostErrors = []error{
+		errors.New("some error 1"),
+		errors.New("some error 2"),
+	}
+	var dummyMessage = "some message"
+
+	// mock
+	createMock(t)
+
+	// expect
+	apperrorGetInnermostErrorsExpected = 1
+	apperrorGetInnermostErrors = func(err error) []error {
+		apperrorGetInnermostErrorsCalled++
+		assert.Equal(t, dummyBindError, err)
+		return dummyInnermostErrors
+	}
+	stringsJoinExpected = 1
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		assert.Equal(t, []string{"some error 1", "some error 2"}, elems)
+		assert.Equal(t, "; ", sep)
+		return strings.Join(elems, sep)
+	}
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		assert.Equal(t, "has invalid value: %v", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, "some error 1; some error 2", a[0])
+		return dummyMessage
+	}
+
+	// SUT + act
+	var result = getBindErrorMessage(
+		dummyBindError,
+	)
+
+	// assert
+	assert.Equal(t, dummyMessage, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBindParameter_NotFound(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyHTTPRequest = &http.Request{}
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+	}
+	var dummyValue int
+
+	// mock
+	createMock(t)
+
+	// expect
+	muxVarsExpected = 1
+	muxVars = func(r *http.Request) map[string]string {
+		muxVarsCalled++
+		assert.Equal(t, dummyHTTPRequest, r)
+		return map[string]string{"some other name": "some value"}
+	}
+
+	// SUT + act
+	var isPresent, err = bindParameter(
+		dummySessionObject,
+		dummyName,
+		reflect.ValueOf(&dummyValue).Elem(),
+	)
+
+	// assert
+	assert.False(t, isPresent)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBindParameter_Error(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyHTTPRequest = &http.Request{}
+	var dummyValue int
+	var dummyAppError = apperror.GetGeneralFailureError(errors.New("some error"))
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+		getRequestParameter: func(name string, dataTemplate interface{}) apperrorModel.AppError {
+			assert.Equal(t, dummyName, name)
+			assert.Equal(t, &dummyValue, dataTemplate)
+			return dummyAppError
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	muxVarsExpected = 1
+	muxVars = func(r *http.Request) map[string]string {
+		muxVarsCalled++
+		return map[string]string{dummyName: "some value"}
+	}
+
+	// SUT + act
+	var isPresent, err = bindParameter(
+		dummySessionObject,
+		dummyName,
+		reflect.ValueOf(&dummyValue).Elem(),
+	)
+
+	// assert
+	assert.True(t, isPresent)
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBindParameter_Success(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyHTTPRequest = &http.Request{}
+	var dummyValue int
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+		getRequestParameter: func(name string, dataTemplate interface{}) apperrorModel.AppError {
+			*(dataTemplate.(*int)) = 123
+			return nil
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	muxVarsExpected = 1
+	muxVars = func(r *http.Request) map[string]string {
+		muxVarsCalled++
+		return map[string]string{dummyName: "123"}
+	}
+
+	// SUT + act
+	var isPresent, err = bindParameter(
+		dummySessionObject,
+		dummyName,
+		reflect.ValueOf(&dummyValue).Elem(),
+	)
+
+	// assert
+	assert.True(t, isPresent)
+	assert.NoError(t, err)
+	assert.Equal(t, 123, dummyValue)
+
+	// verify
+	verifyAll(t)
+}
+
+func createDummyLoadValues(t *testing.T, expectedName string, values []string, loadError apperrorModel.AppError) func(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	return func(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+		assert.Equal(t, expectedName, name)
+		for _, value := range values {
+			if jsonutil.TryUnmarshal(value, dataTemplate) == nil {
+				fillCallback()
+			}
+		}
+		return loadError
+	}
+}
+
+func TestBindValues_NotFound(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyValue = 456
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var isPresent, err = bindValues(
+		dummyName,
+		reflect.ValueOf(&dummyValue).Elem(),
+		createDummyLoadValues(t, dummyName, nil, nil),
+	)
+
+	// assert
+	assert.False(t, isPresent)
+	assert.NoError(t, err)
+	assert.Equal(t, 456, dummyValue)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBindValues_Single(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyValue int
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var isPresent, err = bindValues(
+		dummyName,
+		reflect.ValueOf(&dummyValue).Elem(),
+		createDummyLoadValues(t, dummyName, []string{"12", "34"}, nil),
+	)
+
+	// assert
+	assert.True(t, isPresent)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, dummyValue)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBindValues_Slice(t *testing.T) {
+	// arrange
+	type dummyStrings []string
+	var dummyName = "some name"
+	var dummyValue = dummyStrings{"some old value"}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var isPresent, err = bindValues(
+		dummyName,
+		reflect.ValueOf(&dummyValue).Elem(),
+		createDummyLoadValues(t, dummyName, []string{"a", "b"}, nil),
+	)
+
+	// assert
+	assert.True(t, isPresent)
+	assert.NoError(t, err)
+	assert.Equal(t, dummyStrings{"a", "b"}, dummyValue)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBindValues_Error(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyValue []int
+	var dummyAppError = apperror.GetGeneralFailureError(errors.New("some error"))
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var isPresent, err = bindValues(
+		dummyName,
+		reflect.ValueOf(&dummyValue).Elem(),
+		createDummyLoadValues(t, dummyName, []string{"abc"}, dummyAppError),
+	)
+
+	// assert
+	assert.True(t, isPresent)
+	assert.Equal(t, dummyAppError, err)
+	assert.Nil(t, dummyValue)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBindBody_Empty(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+	}
+	var dummyValue int
+
+	// mock
+	createMock(t)
+
+	// expect
+	requestGetRequestBodyExpected = 1
+	requestGetRequestBody = func(httpRequest *http.Request) string {
+		requestGetRequestBodyCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return ""
+	}
+
+	// SUT + act
+	var isPresent, err = bindBody(
+		dummySessionObject,
+		reflect.ValueOf(&dummyValue).Elem(),
+	)
+
+	// assert
+	assert.False(t, isPresent)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBindBody_Error(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyValue int
+	var dummyAppError = apperror.GetGeneralFailureError(errors.New("some error"))
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+		getRequestBody: func(dataTemplate interface{}) apperrorModel.AppError {
+			assert.Equal(t, &dummyValue, dataTemplate)
+			return dummyAppError
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	requestGetRequestBodyExpected = 1
+	requestGetRequestBody = func(httpRequest *http.Request) string {
+		requestGetRequestBodyCalled++
+		return "some body"
+	}
+
+	// SUT + act
+	var isPresent, err = bindBody(
+		dummySessionObject,
+		reflect.ValueOf(&dummyValue).Elem(),
+	)
+
+	// assert
+	assert.True(t, isPresent)
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBindBody_Success(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyValue int
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+		getRequestBody: func(dataTemplate interface{}) apperrorModel.AppError {
+			*(dataTemplate.(*int)) = 123
+			return nil
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	requestGetRequestBodyExpected = 1
+	requestGetRequestBody = func(httpRequest *http.Request) string {
+		requestGetRequestBodyCalled++
+		return "123"
+	}
+
+	// SUT + act
+	var isPresent, err = bindBody(
+		dummySessionObject,
+		reflect.ValueOf(&dummyValue).Elem(),
+	)
+
+	// assert
+	assert.True(t, isPresent)
+	assert.NoError(t, err)
+	assert.Equal(t, 123, dummyValue)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBindField_Path(t *testing.T) {
+	// arrange
+	var dummyStruct struct {
+		ID int `path:"id" query:"ignored"`
+	}
+	var dummySessionObject = &dummySession{t: t}
+	var dummyField = reflect.TypeOf(dummyStruct).Field(0)
+	var dummyFieldValue = reflect.ValueOf(&dummyStruct).Elem().Field(0)
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	bindParameterFuncExpected = 1
+	bindParameterFunc = func(session sessionModel.Session, name string, fieldValue reflect.Value) (bool, error) {
+		bindParameterFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, "id", name)
+		assert.Equal(t, dummyFieldValue, fieldValue)
+		return true, dummyError
+	}
+
+	// SUT + act
+	var name, isPresent, err = bindField(
+		dummySessionObject,
+		dummyField,
+		dummyFieldValue,
+	)
+
+	// assert
+	assert.Equal(t, "id", name)
+	assert.True(t, isPresent)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBindField_QueryAndHeader(t *testing.T) {
+	// arrange
+	var dummyStruct struct {
+		Page   int    `query:"page"`
+		Tenant string `header:"X-Tenant"`
+	}
+	var dummyError = errors.New("some error")
+	var dummyQueryCalled int
+	var dummyHeaderCalled int
+	var dummySessionObject = &dummySession{
+		t: t,
+		getRequestQueries: func(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+			dummyQueryCalled++
+			return nil
+		},
+		getRequestHeaders: func(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+			dummyHeaderCalled++
+			return nil
+		},
+	}
+	var expectedNames = []string{"page", "X-Tenant"}
+
+	for index, expectedName := range expectedNames {
+		// arrange
+		var dummyField = reflect.TypeOf(dummyStruct).Field(index)
+		var dummyFieldValue = reflect.ValueOf(&dummyStruct).Elem().Field(index)
+
+		// mock
+		createMock(t)
+
+		// expect
+		bindValuesFuncExpected = 1
+		bindValuesFunc = func(name string, fieldValue reflect.Value, loadValues func(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError) (bool, error) {
+			bindValuesFuncCalled++
+			assert.Equal(t, expectedName, name)
+			assert.Equal(t, dummyFieldValue, fieldValue)
+			loadValues(name, nil, nil)
+			return true, dummyError
+		}
+
+		// SUT + act
+		var name, isPresent, err = bindField(
+			dummySessionObject,
+			dummyField,
+			dummyFieldValue,
+		)
+
+		// assert
+		assert.Equal(t, expectedName, name)
+		assert.True(t, isPresent)
+		assert.Equal(t, dummyError, err)
+
+		// verify
+		verifyAll(t)
+	}
+	assert.Equal(t, 1, dummyQueryCalled)
+	assert.Equal(t, 1, dummyHeaderCalled)
+}
+
+func TestBindField_Body(t *testing.T) {
+	// arrange
+	var dummyStruct struct {
+		Unnamed int `body:""`
+		Named   int `body:"payload"`
+	}
+	var dummySessionObject = &dummySession{t: t}
+	var expectedNames = []string{"body", "payload"}
+
+	for index, expectedName := range expectedNames {
+		// arrange
+		var dummyField = reflect.TypeOf(dummyStruct).Field(index)
+		var dummyFieldValue = reflect.ValueOf(&dummyStruct).Elem().Field(index)
+
+		// mock
+		createMock(t)
+
+		// expect
+		bindBodyFuncExpected = 1
+		bindBodyFunc = func(session sessionModel.Session, fieldValue reflect.Value) (bool, error) {
+			bindBodyFuncCalled++
+			assert.Equal(t, dummySessionObject, session)
+			assert.Equal(t, dummyFieldValue, fieldValue)
+			return true, nil
+		}
+
+		// SUT + act
+		var name, isPresent, err = bindField(
+			dummySessionObject,
+			dummyField,
+			dummyFieldValue,
+		)
+
+		// assert
+		assert.Equal(t, expectedName, name)
+		assert.True(t, isPresent)
+		assert.NoError(t, err)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestBindField_Untagged(t *testing.T) {
+	// arrange
+	var dummyStruct = struct {
+		Zero    int
+		NonZero int
+	}{
+		NonZero: 1,
+	}
+	var dummySessionObject = &dummySession{t: t}
+	var dummyName = "some name"
+	var expectedPresences = []bool{false, true}
+
+	for index, expectedPresence := range expectedPresences {
+		// arrange
+		var dummyField = reflect.TypeOf(dummyStruct).Field(index)
+		var dummyFieldValue = reflect.ValueOf(&dummyStruct).Elem().Field(index)
+
+		// mock
+		createMock(t)
+
+		// expect
+		getFieldNameFuncExpected = 1
+		getFieldNameFunc = func(field reflect.StructField) string {
+			getFieldNameFuncCalled++
+			assert.Equal(t, dummyField, field)
+			return dummyName
+		}
+
+		// SUT + act
+		var name, isPresent, err = bindField(
+			dummySessionObject,
+			dummyField,
+			dummyFieldValue,
+		)
+
+		// assert
+		assert.Equal(t, dummyName, name)
+		assert.Equal(t, expectedPresence, isPresent)
+		assert.NoError(t, err)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestGetViolationError_NoViolation(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getViolationError(
+		&violations{},
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetViolationError_WithViolations(t *testing.T) {
+	// arrange
+	var dummyViolations = &violations{}
+	var dummyAppError = apperror.GetBadRequestError(errors.New("some error"))
+
+	// stub
+	dummyViolations.add("page", "is required")
+	dummyViolations.add("name", "must have length of at least [1]")
+	dummyViolations.add("page", "must have value of at least [1]")
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 3
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Field [%v] %v", format)
+		return fmt.Errorf(format, a...)
+	}
+	apperrorGetBadRequestErrorExpected = 1
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		assert.Equal(t, 3, len(innerErrors))
+		assert.Equal(t, "Field [page] is required", innerErrors[0].Error())
+		assert.Equal(t, "Field [page] must have value of at least [1]", innerErrors[1].Error())
+		assert.Equal(t, "Field [name] must have length of at least [1]", innerErrors[2].Error())
+		return dummyAppError
+	}
+
+	// SUT + act
+	var result = getViolationError(
+		dummyViolations,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, result)
+	assert.Equal(t, `["is required","must have value of at least [1]"]`, result.ExtraData()["page"])
+	assert.Equal(t, `["must have length of at least [1]"]`, result.ExtraData()["name"])
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBind_InvalidTemplate(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyDataTemplate int
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetGeneralFailureError(errors.New("some error"))
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Unable to bind request into data template of type [%T]", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, &dummyDataTemplate, a[0])
+		return dummyError
+	}
+	apperrorGetGeneralFailureErrorExpected = 1
+	apperrorGetGeneralFailureError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetGeneralFailureErrorCalled++
+		assert.Equal(t, []error{dummyError}, innerErrors)
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = Bind(
+		dummySessionObject,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestBind_ValidTemplate(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyDataTemplate struct {
+		Failed    int `query:"failed"`
+		Validated int `query:"validated" validate:"some rules"`
+		Skipped   int `json:"-"`
+		unexposed int
+	}
+	var dummyError = errors.New("some error")
+	var dummyMessage = "some message"
+	var dummyAppError = apperror.GetGeneralFailureError(errors.New("some error"))
+
+	// mock
+	createMock(t)
+
+	// expect
+	bindFieldFuncExpected = 3
+	bindFieldFunc = func(session sessionModel.Session, field reflect.StructField, fieldValue reflect.Value) (string, bool, error) {
+		bindFieldFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		switch field.Name {
+		case "Failed":
+			return "failed", true, dummyError
+		case "Validated":
+			return "validated", true, nil
+		}
+		return "-", false, nil
+	}
+	getBindErrorMessageFuncExpected = 1
+	getBindErrorMessageFunc = func(bindError error) string {
+		getBindErrorMessageFuncCalled++
+		assert.Equal(t, dummyError, bindError)
+		return dummyMessage
+	}
+	validateFieldFuncExpected = 1
+	validateFieldFunc = func(fieldViolations *violations, name string, tag string, fieldValue reflect.Value, isPresent bool) {
+		validateFieldFuncCalled++
+		assert.Equal(t, "validated", name)
+		assert.Equal(t, "some rules", tag)
+		assert.True(t, isPresent)
+	}
+	getViolationErrorFuncExpected = 1
+	getViolationErrorFunc = func(fieldViolations *violations) apperrorModel.AppError {
+		getViolationErrorFuncCalled++
+		assert.Equal(t, []string{"failed"}, fieldViolations.names)
+		assert.Equal(t, []string{dummyMessage}, fieldViolations.messages["failed"])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = Bind(
+		dummySessionObject,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func useRealFunctions() {
+	fmtSprintf = fmt.Sprintf
+	fmtErrorf = fmt.Errorf
+	stringsSplit = strings.Split
+	stringsJoin = strings.Join
+	muxVars = mux.Vars
+	requestGetRequestBody = request.GetRequestBody
+	apperrorGetBadRequestError = apperror.GetBadRequestError
+	apperrorGetGeneralFailureError = apperror.GetGeneralFailureError
+	apperrorGetInnermostErrors = apperror.GetInnermostErrors
+	getFieldNameFunc = getFieldName
+	getBindErrorMessageFunc = getBindErrorMessage
+	bindParameterFunc = bindParameter
+	bindValuesFunc = bindValues
+	bindBodyFunc = bindBody
+	bindFieldFunc = bindField
+	getViolationErrorFunc = getViolationError
+	fmtSprint = fmt.Sprint
+	stringsTrimSpace = strings.TrimSpace
+	stringsHasPrefix = strings.HasPrefix
+	stringsSplitN = strings.SplitN
+	strconvParseFloat = strconv.ParseFloat
+	regexpCompile = regexp.Compile
+	parseRulesFunc = parseRules
+	getElementsFunc = getElements
+	getMeasureFunc = getMeasure
+	checkBoundFunc = checkBound
+	checkEnumFunc = checkEnum
+	checkRegexFunc = checkRegex
+	checkRuleFunc = checkRule
+	validateFieldFunc = validateField
+}
+
+func TestBind_Integration(t *testing.T) {
+	// arrange
+	type dummyBody struct {
+		Name  string   `json:"name" validate:"required,max=5"`
+		Email string   `json:"email" validate:"regex=^[^@]+@[^@,]+$"`
+		Tags  []string `json:"tags" validate:"enum=a|b"`
+	}
+	var dummyDataTemplate struct {
+		ID     int       `path:"id" validate:"required,min=1"`
+		Page   int       `query:"page" validate:"min=1,max=100"`
+		Sort   []string  `query:"sort" validate:"enum=name|date"`
+		Size   *int      `query:"size" validate:"required"`
+		Tenant string    `header:"X-Tenant" validate:"required,len=3"`
+		Body   dummyBody `body:"" validate:"required"`
+	}
+	var dummyHTTPRequest = &http.Request{}
+	var dummyQueries = map[string][]string{
+		"page": {"0"},
+		"sort": {"name", "size"},
+	}
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+		getRequestParameter: func(name string, dataTemplate interface{}) apperrorModel.AppError {
+			return apperror.GetBadRequestError(jsonutil.TryUnmarshal("abc", dataTemplate))
+		},
+		getRequestQueries: func(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+			for _, value := range dummyQueries[name] {
+				jsonutil.TryUnmarshal(value, dataTemplate)
+				fillCallback()
+			}
+			return nil
+		},
+		getRequestHeaders: func(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+			jsonutil.TryUnmarshal("abcd", dataTemplate)
+			fillCallback()
+			return nil
+		},
+		getRequestBody: func(dataTemplate interface{}) apperrorModel.AppError {
+			return apperror.GetBadRequestError(jsonutil.TryUnmarshal(`{"name":"abcdef","email":"foo","tags":["a","c"]}`, dataTemplate))
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealFunctions()
+	muxVars = func(r *http.Request) map[string]string {
+		return map[string]string{"id": "abc"}
+	}
+	requestGetRequestBody = func(httpRequest *http.Request) string {
+		return "some body"
+	}
+
+	// SUT + act
+	var err = Bind(
+		dummySessionObject,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusBadRequest, err.HTTPStatusCode())
+	var extraData = err.ExtraData()
+	assert.Equal(t, 8, len(extraData))
+	assert.Equal(t, `["has invalid value: Unable to unmarshal value [abc] into data template"]`, extraData["id"])
+	assert.Equal(t, `["must have value of at least [1]"]`, extraData["page"])
+	assert.Equal(t, `["must be one of [name, date]"]`, extraData["sort"])
+	assert.Equal(t, `["is required"]`, extraData["size"])
+	assert.Equal(t, `["must have length of exactly [3]"]`, extraData["X-Tenant"])
+	assert.Equal(t, `["must have length of at most [5]"]`, extraData["body.name"])
+	assert.Equal(t, `["must match pattern [^[^@]+@[^@,]+$]"]`, extraData["body.email"])
+	assert.Equal(t, `["must be one of [a, b]"]`, extraData["body.tags"])
+	assert.Equal(t, 0, dummyDataTemplate.ID)
+	assert.Equal(t, []string{"name", "size"}, dummyDataTemplate.Sort)
+
+	// verify
+	verifyAll(t)
+}
@@ -0,0 +1,259 @@
+package binding
+
+import (
+	"reflect"
+)
+
+// These are the rules supported in validate tags, e.g. `validate:"required,min=1,max=10,enum=a|b|c,regex=^[a-z]+$"`
+const (
+	RuleRequired = "required"
+	RuleMin      = "min"
+	RuleMax      = "max"
+	RuleLen      = "len"
+	RuleEnum     = "enum"
+	RuleRegex    = "regex"
+)
+
+// These are the constants used for parsing validate tags
+const (
+	ruleSeparator     = ","
+	argumentSeparator = "="
+	enumSeparator     = "|"
+)
+
+// validationRule defines a single rule of the validate tag together with its argument
+type validationRule struct {
+	name     string
+	argument string
+}
+
+// parseRules parses the given validate tag into rules; the regex rule takes the remainder of the tag as its pattern, thus it must be the last rule
+func parseRules(tag string) []validationRule {
+	var rules = []validationRule{}
+	var remainder = stringsTrimSpace(tag)
+	for remainder != "" {
+		var ruleText = remainder
+		if !stringsHasPrefix(remainder, RuleRegex+argumentSeparator) {
+			var parts = stringsSplitN(remainder, ruleSeparator, 2)
+			ruleText = parts[0]
+			if len(parts) == 2 {
+				remainder = parts[1]
+			} else {
+				remainder = ""
+			}
+		} else {
+			remainder = ""
+		}
+		var pair = stringsSplitN(ruleText, argumentSeparator, 2)
+		var rule = validationRule{
+			name: stringsTrimSpace(pair[0]),
+		}
+		if len(pair) == 2 {
+			rule.argument = pair[1]
+		}
+		if rule.name != "" {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// getElements returns the elements of slice or array values, or the value itself otherwise
+func getElements(value reflect.Value) []reflect.Value {
+	if (value.Kind() != reflect.Slice &&
+		value.Kind() != reflect.Array) ||
+		value.Type().Elem().Kind() == reflect.Uint8 {
+		return []reflect.Value{value}
+	}
+	var elements = []reflect.Value{}
+	for index := 0; index < value.Len(); index++ {
+		elements = append(
+			elements,
+			value.Index(index),
+		)
+	}
+	return elements
+}
+
+// getMeasure returns the numeric value of number values, or the length of strings, slices, arrays and maps
+func getMeasure(value reflect.Value) (float64, bool, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), false, true
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return float64(value.Len()), true, true
+	}
+	return 0, false, false
+}
+
+func checkBound(rule validationRule, value reflect.Value) string {
+	var limit, parseError = strconvParseFloat(
+		rule.argument,
+		64,
+	)
+	if parseError != nil {
+		return fmtSprintf(
+			"has invalid validation rule [%v=%v]",
+			rule.name,
+			rule.argument,
+		)
+	}
+	var measure, isLength, isMeasurable = getMeasureFunc(value)
+	if !isMeasurable ||
+		(rule.name == RuleLen && !isLength) {
+		return fmtSprintf(
+			"does not support validation rule [%v]",
+			rule.name,
+		)
+	}
+	var subject = "value"
+	if isLength {
+		subject = "length"
+	}
+	switch rule.name {
+	case RuleMin:
+		if measure < limit {
+			return fmtSprintf("must have %v of at least [%v]", subject, rule.argument)
+		}
+	case RuleMax:
+		if measure > limit {
+			return fmtSprintf("must have %v of at most [%v]", subject, rule.argument)
+		}
+	case RuleLen:
+		if measure != limit {
+			return fmtSprintf("must have %v of exactly [%v]", subject, rule.argument)
+		}
+	}
+	return ""
+}
+
+func checkEnum(rule validationRule, value reflect.Value) string {
+	var allowedValues = stringsSplit(
+		rule.argument,
+		enumSeparator,
+	)
+	for _, element := range getElementsFunc(value) {
+		var elementText = fmtSprint(element.Interface())
+		var isAllowed = false
+		for _, allowedValue := range allowedValues {
+			if elementText == allowedValue {
+				isAllowed = true
+				break
+			}
+		}
+		if !isAllowed {
+			return fmtSprintf(
+				"must be one of [%v]",
+				stringsJoin(allowedValues, ", "),
+			)
+		}
+	}
+	return ""
+}
+
+func checkRegex(rule validationRule, value reflect.Value) string {
+	var pattern, compileError = regexpCompile(
+		rule.argument,
+	)
+	if compileError != nil {
+		return fmtSprintf(
+			"has invalid validation rule [%v=%v]",
+			rule.name,
+			rule.argument,
+		)
+	}
+	for _, element := range getElementsFunc(value) {
+		if !pattern.MatchString(fmtSprint(element.Interface())) {
+			return fmtSprintf(
+				"must match pattern [%v]",
+				rule.argument,
+			)
+		}
+	}
+	return ""
+}
+
+func checkRule(rule validationRule, value reflect.Value) string {
+	switch rule.name {
+	case RuleRequired:
+		return ""
+	case RuleMin, RuleMax, RuleLen:
+		return checkBoundFunc(rule, value)
+	case RuleEnum:
+		return checkEnumFunc(rule, value)
+	case RuleRegex:
+		return checkRegexFunc(rule, value)
+	}
+	return fmtSprintf(
+		"has unknown validation rule [%v]",
+		rule.name,
+	)
+}
+
+// validateStruct validates the exported fields of the given struct value upon their validate tags, naming them by their json tags under the given prefix
+func validateStruct(fieldViolations *violations, prefix string, structValue reflect.Value) {
+	var structType = structValue.Type()
+	for index := 0; index < structType.NumField(); index++ {
+		var field = structType.Field(index)
+		if field.PkgPath != "" {
+			continue
+		}
+		var name = getFieldNameFunc(field)
+		if name == skippedTagName {
+			continue
+		}
+		var fieldValue = structValue.Field(index)
+		validateField(
+			fieldViolations,
+			prefix+nameSeparator+name,
+			field.Tag.Get(ValidateTag),
+			fieldValue,
+			!fieldValue.IsZero(),
+		)
+	}
+}
+
+// validateField checks the given field value against the rules of the given validate tag, then validates nested struct fields; rules other than required are skipped for absent fields
+func validateField(fieldViolations *violations, name string, tag string, fieldValue reflect.Value, isPresent bool) {
+	var rules = parseRulesFunc(tag)
+	for _, rule := range rules {
+		if rule.name == RuleRequired &&
+			!isPresent {
+			fieldViolations.add(
+				name,
+				"is required",
+			)
+			return
+		}
+	}
+	if !isPresent {
+		return
+	}
+	var value = fieldValue
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	for _, rule := range rules {
+		var message = checkRuleFunc(rule, value)
+		if message != "" {
+			fieldViolations.add(
+				name,
+				message,
+			)
+		}
+	}
+	if value.Kind() == reflect.Struct {
+		validateStruct(
+			fieldViolations,
+			name,
+			value,
+		)
+	}
+}
@@ -0,0 +1,628 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRules(t *testing.T) {
+	// arrange
+	var dummyTags = []string{
+		"",
+		" required ",
+		"required,min=1,,max=10",
+		"enum=a|b|c,regex=^[a-z]{1,3}$",
+	}
+	var expectedRules = [][]validationRule{
+		{},
+		{{name: "required"}},
+		{{name: "required"}, {name: "min", argument: "1"}, {name: "max", argument: "10"}},
+		{{name: "enum", argument: "a|b|c"}, {name: "regex", argument: "^[a-z]{1,3}$"}},
+	}
+
+	for index, dummyTag := range dummyTags {
+		// mock
+		createMock(t)
+
+		// expect
+		stringsTrimSpace = strings.TrimSpace
+		stringsHasPrefix = strings.HasPrefix
+		stringsSplitN = strings.SplitN
+
+		// SUT + act
+		var result = parseRules(
+			dummyTag,
+		)
+
+		// assert
+		assert.Equal(t, expectedRules[index], result)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestGetElements_NonSlice(t *testing.T) {
+	// arrange
+	var dummyValues = []interface{}{
+		"some value",
+		123,
+		[]byte("some bytes"),
+	}
+
+	for _, dummyValue := range dummyValues {
+		// mock
+		createMock(t)
+
+		// SUT + act
+		var result = getElements(
+			reflect.ValueOf(dummyValue),
+		)
+
+		// assert
+		assert.Equal(t, 1, len(result))
+		assert.Equal(t, dummyValue, result[0].Interface())
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestGetElements_Slice(t *testing.T) {
+	// arrange
+	var dummyValue = [2]string{"some value 1", "some value 2"}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getElements(
+		reflect.ValueOf(dummyValue),
+	)
+
+	// assert
+	assert.Equal(t, 2, len(result))
+	assert.Equal(t, "some value 1", result[0].Interface())
+	assert.Equal(t, "some value 2", result[1].Interface())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetMeasure(t *testing.T) {
+	// arrange
+	var dummyValues = []interface{}{
+		int8(-12),
+		uint(34),
+		float32(5.5),
+		"some value",
+		[]int{1, 2},
+		map[string]int{"a": 1},
+		true,
+	}
+	var expectedMeasures = []float64{-12, 34, 5.5, 10, 2, 1, 0}
+	var expectedLengths = []bool{false, false, false, true, true, true, false}
+	var expectedMeasurables = []bool{true, true, true, true, true, true, false}
+
+	for index, dummyValue := range dummyValues {
+		// mock
+		createMock(t)
+
+		// SUT + act
+		var measure, isLength, isMeasurable = getMeasure(
+			reflect.ValueOf(dummyValue),
+		)
+
+		// assert
+		assert.Equal(t, expectedMeasures[index], measure)
+		assert.Equal(t, expectedLengths[index], isLength)
+		assert.Equal(t, expectedMeasurables[index], isMeasurable)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestCheckBound_InvalidArgument(t *testing.T) {
+	// arrange
+	var dummyRule = validationRule{name: RuleMin, argument: "abc"}
+	var dummyMessage = "some message"
+
+	// mock
+	createMock(t)
+
+	// expect
+	strconvParseFloatExpected = 1
+	strconvParseFloat = func(s string, bitSize int) (float64, error) {
+		strconvParseFloatCalled++
+		assert.Equal(t, "abc", s)
+		assert.Equal(t, 64, bitSize)
+		return 0, errors.New("some error")
+	}
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		assert.Equal(t, "has invalid validation rule [%v=%v]", format)
+		assert.Equal(t, []interface{}{RuleMin, "abc"}, a)
+		return dummyMessage
+	}
+
+	// SUT + act
+	var result = checkBound(
+		dummyRule,
+		reflect.ValueOf(1),
+	)
+
+	// assert
+	assert.Equal(t, dummyMessage, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheckBound_NotSupported(t *testing.T) {
+	// arrange
+	var dummyRules = []validationRule{
+		{name: RuleMin, argument: "1"},
+		{name: RuleLen, argument: "1"},
+	}
+	var dummyValues = []interface{}{
+		true,
+		123,
+	}
+
+	for index, dummyRule := range dummyRules {
+		// mock
+		createMock(t)
+
+		// expect
+		strconvParseFloat = strconv.ParseFloat
+		getMeasureFunc = getMeasure
+		fmtSprintfExpected = 1
+		fmtSprintf = func(format string, a ...interface{}) string {
+			fmtSprintfCalled++
+			assert.Equal(t, "does not support validation rule [%v]", format)
+			return fmt.Sprintf(format, a...)
+		}
+
+		// SUT + act
+		var result = checkBound(
+			dummyRule,
+			reflect.ValueOf(dummyValues[index]),
+		)
+
+		// assert
+		assert.Equal(t, "does not support validation rule ["+dummyRule.name+"]", result)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestCheckBound_Measured(t *testing.T) {
+	// arrange
+	var dummyRules = []validationRule{
+		{name: RuleMin, argument: "2"},
+		{name: RuleMin, argument: "2"},
+		{name: RuleMax, argument: "2.5"},
+		{name: RuleMax, argument: "2"},
+		{name: RuleLen, argument: "3"},
+		{name: RuleLen, argument: "3"},
+	}
+	var dummyValues = []interface{}{
+		1,
+		2,
+		3.0,
+		"ab",
+		[]int{1, 2},
+		"abc",
+	}
+	var expectedMessages = []string{
+		"must have value of at least [2]",
+		"",
+		"must have value of at most [2.5]",
+		"",
+		"must have length of exactly [3]",
+		"",
+	}
+
+	for index, dummyRule := range dummyRules {
+		// mock
+		createMock(t)
+
+		// expect
+		strconvParseFloat = strconv.ParseFloat
+		getMeasureFunc = getMeasure
+		fmtSprintf = fmt.Sprintf
+
+		// SUT + act
+		var result = checkBound(
+			dummyRule,
+			reflect.ValueOf(dummyValues[index]),
+		)
+
+		// assert
+		assert.Equal(t, expectedMessages[index], result)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestCheckEnum(t *testing.T) {
+	// arrange
+	var dummyRule = validationRule{name: RuleEnum, argument: "1|2|3"}
+	var dummyValues = []interface{}{
+		2,
+		[]int{1, 3},
+		[]int{1, 4},
+	}
+	var expectedMessages = []string{
+		"",
+		"",
+		"must be one of [1, 2, 3]",
+	}
+
+	for index, dummyValue := range dummyValues {
+		// mock
+		createMock(t)
+
+		// expect
+		stringsSplit = strings.Split
+		stringsJoin = strings.Join
+		getElementsFunc = getElements
+		fmtSprint = fmt.Sprint
+		fmtSprintf = fmt.Sprintf
+
+		// SUT + act
+		var result = checkEnum(
+			dummyRule,
+			reflect.ValueOf(dummyValue),
+		)
+
+		// assert
+		assert.Equal(t, expectedMessages[index], result)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestCheckRegex_InvalidPattern(t *testing.T) {
+	// arrange
+	var dummyRule = validationRule{name: RuleRegex, argument: "["}
+	var dummyMessage = "some message"
+
+	// mock
+	createMock(t)
+
+	// expect
+	regexpCompileExpected = 1
+	regexpCompile = func(expr string) (*regexp.Regexp, error) {
+		regexpCompileCalled++
+		assert.Equal(t, "[", expr)
+		return nil, errors.New("some error")
+	}
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		assert.Equal(t, "has invalid validation rule [%v=%v]", format)
+		assert.Equal(t, []interface{}{RuleRegex, "["}, a)
+		return dummyMessage
+	}
+
+	// SUT + act
+	var result = checkRegex(
+		dummyRule,
+		reflect.ValueOf("some value"),
+	)
+
+	// assert
+	assert.Equal(t, dummyMessage, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheckRegex_ValidPattern(t *testing.T) {
+	// arrange
+	var dummyRule = validationRule{name: RuleRegex, argument: "^[a-z]+$"}
+	var dummyValues = []interface{}{
+		"abc",
+		[]string{"abc", "def"},
+		[]string{"abc", "DEF"},
+	}
+	var expectedMessages = []string{
+		"",
+		"",
+		"must match pattern [^[a-z]+$]",
+	}
+
+	for index, dummyValue := range dummyValues {
+		// mock
+		createMock(t)
+
+		// expect
+		regexpCompile = regexp.Compile
+		getElementsFunc = getElements
+		fmtSprint = fmt.Sprint
+		fmtSprintf = fmt.Sprintf
+
+		// SUT + act
+		var result = checkRegex(
+			dummyRule,
+			reflect.ValueOf(dummyValue),
+		)
+
+		// assert
+		assert.Equal(t, expectedMessages[index], result)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestCheckRule(t *testing.T) {
+	// arrange
+	var dummyValue = reflect.ValueOf("some value")
+	var dummyRuleNames = []string{
+		RuleRequired,
+		RuleMin,
+		RuleMax,
+		RuleLen,
+		RuleEnum,
+		RuleRegex,
+		"some rule",
+	}
+	var expectedMessages = []string{
+		"",
+		"some bound message",
+		"some bound message",
+		"some bound message",
+		"some enum message",
+		"some regex message",
+		"has unknown validation rule [some rule]",
+	}
+
+	for index, dummyRuleName := range dummyRuleNames {
+		// arrange
+		var dummyRule = validationRule{name: dummyRuleName}
+
+		// mock
+		createMock(t)
+
+		// expect
+		checkBoundFunc = func(rule validationRule, value reflect.Value) string {
+			assert.Equal(t, dummyRule, rule)
+			assert.Equal(t, dummyValue, value)
+			return "some bound message"
+		}
+		checkEnumFunc = func(rule validationRule, value reflect.Value) string {
+			assert.Equal(t, dummyRule, rule)
+			return "some enum message"
+		}
+		checkRegexFunc = func(rule validationRule, value reflect.Value) string {
+			assert.Equal(t, dummyRule, rule)
+			return "some regex message"
+		}
+		fmtSprintf = fmt.Sprintf
+
+		// SUT + act
+		var result = checkRule(
+			dummyRule,
+			dummyValue,
+		)
+
+		// assert
+		assert.Equal(t, expectedMessages[index], result)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestValidateField_RequiredMissing(t *testing.T) {
+	// arrange
+	var dummyViolations = &violations{}
+	var dummyName = "some name"
+	var dummyTag = "some tag"
+
+	// mock
+	createMock(t)
+
+	// expect
+	parseRulesFuncExpected = 1
+	parseRulesFunc = func(tag string) []validationRule {
+		parseRulesFuncCalled++
+		assert.Equal(t, dummyTag, tag)
+		return []validationRule{{name: RuleMin, argument: "1"}, {name: RuleRequired}}
+	}
+
+	// SUT + act
+	validateField(
+		dummyViolations,
+		dummyName,
+		dummyTag,
+		reflect.ValueOf(0),
+		false,
+	)
+
+	// assert
+	assert.Equal(t, []string{dummyName}, dummyViolations.names)
+	assert.Equal(t, []string{"is required"}, dummyViolations.messages[dummyName])
+
+	// verify
+	verifyAll(t)
+}
+
+func TestValidateField_OptionalMissing(t *testing.T) {
+	// arrange
+	var dummyViolations = &violations{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	parseRulesFuncExpected = 1
+	parseRulesFunc = func(tag string) []validationRule {
+		parseRulesFuncCalled++
+		return []validationRule{{name: RuleMin, argument: "1"}}
+	}
+
+	// SUT + act
+	validateField(
+		dummyViolations,
+		"some name",
+		"some tag",
+		reflect.ValueOf(0),
+		false,
+	)
+
+	// assert
+	assert.Empty(t, dummyViolations.names)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestValidateField_NilPointer(t *testing.T) {
+	// arrange
+	var dummyViolations = &violations{}
+	var dummyPointer *int
+
+	// mock
+	createMock(t)
+
+	// expect
+	parseRulesFuncExpected = 1
+	parseRulesFunc = func(tag string) []validationRule {
+		parseRulesFuncCalled++
+		return []validationRule{{name: RuleMin, argument: "1"}}
+	}
+
+	// SUT + act
+	validateField(
+		dummyViolations,
+		"some name",
+		"some tag",
+		reflect.ValueOf(&dummyPointer).Elem(),
+		true,
+	)
+
+	// assert
+	assert.Empty(t, dummyViolations.names)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestValidateField_Pointer(t *testing.T) {
+	// arrange
+	var dummyViolations = &violations{}
+	var dummyName = "some name"
+	var dummyRules = []validationRule{
+		{name: RuleMin, argument: "1"},
+		{name: RuleMax, argument: "10"},
+	}
+	var dummyInteger = 123
+	var dummyPointer = &dummyInteger
+
+	// mock
+	createMock(t)
+
+	// expect
+	parseRulesFuncExpected = 1
+	parseRulesFunc = func(tag string) []validationRule {
+		parseRulesFuncCalled++
+		return dummyRules
+	}
+	checkRuleFuncExpected = 2
+	checkRuleFunc = func(rule validationRule, value reflect.Value) string {
+		checkRuleFuncCalled++
+		assert.Equal(t, dummyRules[checkRuleFuncCalled-1], rule)
+		assert.Equal(t, 123, value.Interface())
+		if rule.name == RuleMax {
+			return "some message"
+		}
+		return ""
+	}
+
+	// SUT + act
+	validateField(
+		dummyViolations,
+		dummyName,
+		"some tag",
+		reflect.ValueOf(&dummyPointer),
+		true,
+	)
+
+	// assert
+	assert.Equal(t, []string{dummyName}, dummyViolations.names)
+	assert.Equal(t, []string{"some message"}, dummyViolations.messages[dummyName])
+
+	// verify
+	verifyAll(t)
+}
+
+func TestValidateField_NestedStruct(t *testing.T) {
+	// arrange
+	type dummyInner struct {
+		Value int `validate:"some inner tag"`
+	}
+	var dummyStruct = struct {
+		Missing   string     `json:"missing" validate:"some missing tag"`
+		Inner     dummyInner `json:"inner" validate:"some nested tag"`
+		Skipped   string     `json:"-" validate:"some skipped tag"`
+		unexposed string
+	}{
+		Inner: dummyInner{Value: 1},
+	}
+	var dummyViolations = &violations{}
+	var expectedTags = []string{
+		"some outer tag",
+		"some missing tag",
+		"some nested tag",
+		"some inner tag",
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsSplit = strings.Split
+	getFieldNameFunc = getFieldName
+	parseRulesFuncExpected = 4
+	parseRulesFunc = func(tag string) []validationRule {
+		parseRulesFuncCalled++
+		assert.Equal(t, expectedTags[parseRulesFuncCalled-1], tag)
+		return []validationRule{{name: RuleRequired}}
+	}
+	checkRuleFuncExpected = 3
+	checkRuleFunc = func(rule validationRule, value reflect.Value) string {
+		checkRuleFuncCalled++
+		return ""
+	}
+
+	// SUT + act
+	validateField(
+		dummyViolations,
+		"body",
+		"some outer tag",
+		reflect.ValueOf(dummyStruct),
+		true,
+	)
+
+	// assert
+	assert.Equal(t, []string{"body.missing"}, dummyViolations.names)
+	assert.Equal(t, []string{"is required"}, dummyViolations.messages["body.missing"])
+
+	// verify
+	verifyAll(t)
+}
@@ -1,10 +1,14 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"reflect"
 
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
 	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
 )
 
@@ -25,4 +29,48 @@ var (
 	validateDefaultAllowedLogLevelFunc = validateDefaultAllowedLogLevel
 	validateDefaultNetworkTimeoutFunc  = validateDefaultNetworkTimeout
 	validateGraceShutdownWaitTimeFunc  = validateGraceShutdownWaitTime
+	resolveReloadableSettingsFunc      = resolveReloadableSettings
+	mergeSettingErrorFunc              = mergeSettingError
+)
+
+// func pointers for injection / testing: source.go
+var (
+	osLookupEnv                = os.LookupEnv
+	osStat                     = os.Stat
+	ioutilReadFile             = ioutil.ReadFile
+	jsonUnmarshal              = json.Unmarshal
+	fmtErrorf                  = fmt.Errorf
+	jsonutilMarshalIgnoreError = jsonutil.MarshalIgnoreError
+	toEnvironmentNameFunc      = toEnvironmentName
+	parseDotEnvContentFunc     = parseDotEnvContent
+	parseJSONContentFunc       = parseJSONContent
+	parseYAMLContentFunc       = parseYAMLContent
+	loadSourcesFunc            = loadSources
+	lookupSourcesFunc          = lookupSources
+	lookupSourceNameFunc       = lookupSourceName
+	getParseErrorFunc          = getParseError
+	getStringFunctionFunc      = getStringFunction
+	getBooleanFunctionFunc     = getBooleanFunction
+	getDurationFunctionFunc    = getDurationFunction
+	getLogTypeFunctionFunc     = getLogTypeFunction
+	getLogLevelFunctionFunc    = getLogLevelFunction
+	getHeaderStyleFunctionFunc = getHeaderStyleFunction
+	getModTimeFunc             = getModTime
+)
+
+// func pointers for injection / testing: reload.go
+var (
+	validateDefaultHTTPHeaderLogStyleFunc = validateDefaultHTTPHeaderLogStyle
+	getReloadableSettingsFunc             = getReloadableSettings
+	appendChangeFunc                      = appendChange
+	compareReloadableSettingsFunc         = compareReloadableSettings
+)
+
+// func pointers for injection / testing: report.go
+var (
+	getCustomizedSettingsFunc    = getCustomizedSettings
+	getSettingSourceFunc         = getSettingSource
+	getSettingErrorsFunc         = getSettingErrors
+	generateSettingsReportFunc   = generateSettingsReport
+	validateRequiredSettingsFunc = validateRequiredSettings
 )
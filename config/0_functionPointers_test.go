@@ -1,7 +1,10 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"reflect"
 	"testing"
 	"time"
@@ -10,42 +13,109 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
 	apperrorEnum "github.com/zhongjie-cai/WebServiceTemplate/apperror/enum"
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/config/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/headerutil/headerstyle"
+	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
 	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
 )
 
 var (
-	timeutilGetTimeNowUTCExpected              int
-	timeutilGetTimeNowUTCCalled                int
-	timeutilFormatDateTimeExpected             int
-	timeutilFormatDateTimeCalled               int
-	apperrorGetCustomErrorExpected             int
-	apperrorGetCustomErrorCalled               int
-	apperrorWrapSimpleErrorExpected            int
-	apperrorWrapSimpleErrorCalled              int
-	reflectValueOfExpected                     int
-	reflectValueOfCalled                       int
-	fmtSprintfExpected                         int
-	fmtSprintfCalled                           int
-	functionPointerEqualsFuncExpected          int
-	functionPointerEqualsFuncCalled            int
-	isServerCertificateAvailableFuncExpected   int
-	isServerCertificateAvailableFuncCalled     int
-	isCaCertificateAvailableFuncExpected       int
-	isCaCertificateAvailableFuncCalled         int
-	validateStringFunctionFuncExpected         int
-	validateStringFunctionFuncCalled           int
-	validateBooleanFunctionFuncExpected        int
-	validateBooleanFunctionFuncCalled          int
-	validateDefaultAllowedLogTypeFuncExpected  int
-	validateDefaultAllowedLogTypeFuncCalled    int
-	validateDefaultAllowedLogLevelFuncExpected int
-	validateDefaultAllowedLogLevelFuncCalled   int
-	validateDefaultNetworkTimeoutFuncExpected  int
-	validateDefaultNetworkTimeoutFuncCalled    int
-	validateGraceShutdownWaitTimeFuncExpected  int
-	validateGraceShutdownWaitTimeFuncCalled    int
+	timeutilGetTimeNowUTCExpected                 int
+	timeutilGetTimeNowUTCCalled                   int
+	timeutilFormatDateTimeExpected                int
+	timeutilFormatDateTimeCalled                  int
+	apperrorGetCustomErrorExpected                int
+	apperrorGetCustomErrorCalled                  int
+	apperrorWrapSimpleErrorExpected               int
+	apperrorWrapSimpleErrorCalled                 int
+	reflectValueOfExpected                        int
+	reflectValueOfCalled                          int
+	fmtSprintfExpected                            int
+	fmtSprintfCalled                              int
+	functionPointerEqualsFuncExpected             int
+	functionPointerEqualsFuncCalled               int
+	isServerCertificateAvailableFuncExpected      int
+	isServerCertificateAvailableFuncCalled        int
+	isCaCertificateAvailableFuncExpected          int
+	isCaCertificateAvailableFuncCalled            int
+	validateStringFunctionFuncExpected            int
+	validateStringFunctionFuncCalled              int
+	validateBooleanFunctionFuncExpected           int
+	validateBooleanFunctionFuncCalled             int
+	validateDefaultAllowedLogTypeFuncExpected     int
+	validateDefaultAllowedLogTypeFuncCalled       int
+	validateDefaultAllowedLogLevelFuncExpected    int
+	validateDefaultAllowedLogLevelFuncCalled      int
+	validateDefaultNetworkTimeoutFuncExpected     int
+	validateDefaultNetworkTimeoutFuncCalled       int
+	validateGraceShutdownWaitTimeFuncExpected     int
+	validateGraceShutdownWaitTimeFuncCalled       int
+	osLookupEnvExpected                           int
+	osLookupEnvCalled                             int
+	ioutilReadFileExpected                        int
+	ioutilReadFileCalled                          int
+	jsonUnmarshalExpected                         int
+	jsonUnmarshalCalled                           int
+	fmtErrorfExpected                             int
+	fmtErrorfCalled                               int
+	jsonutilMarshalIgnoreErrorExpected            int
+	jsonutilMarshalIgnoreErrorCalled              int
+	toEnvironmentNameFuncExpected                 int
+	toEnvironmentNameFuncCalled                   int
+	parseDotEnvContentFuncExpected                int
+	parseDotEnvContentFuncCalled                  int
+	parseJSONContentFuncExpected                  int
+	parseJSONContentFuncCalled                    int
+	parseYAMLContentFuncExpected                  int
+	parseYAMLContentFuncCalled                    int
+	loadSourcesFuncExpected                       int
+	loadSourcesFuncCalled                         int
+	lookupSourcesFuncExpected                     int
+	lookupSourcesFuncCalled                       int
+	getStringFunctionFuncExpected                 int
+	getStringFunctionFuncCalled                   int
+	getBooleanFunctionFuncExpected                int
+	getBooleanFunctionFuncCalled                  int
+	getDurationFunctionFuncExpected               int
+	getDurationFunctionFuncCalled                 int
+	getLogTypeFunctionFuncExpected                int
+	getLogTypeFunctionFuncCalled                  int
+	getLogLevelFunctionFuncExpected               int
+	getLogLevelFunctionFuncCalled                 int
+	osStatExpected                                int
+	osStatCalled                                  int
+	getHeaderStyleFunctionFuncExpected            int
+	getHeaderStyleFunctionFuncCalled              int
+	getModTimeFuncExpected                        int
+	getModTimeFuncCalled                          int
+	resolveReloadableSettingsFuncExpected         int
+	resolveReloadableSettingsFuncCalled           int
+	mergeSettingErrorFuncExpected                 int
+	mergeSettingErrorFuncCalled                   int
+	validateDefaultHTTPHeaderLogStyleFuncExpected int
+	validateDefaultHTTPHeaderLogStyleFuncCalled   int
+	getReloadableSettingsFuncExpected             int
+	getReloadableSettingsFuncCalled               int
+	appendChangeFuncExpected                      int
+	appendChangeFuncCalled                        int
+	compareReloadableSettingsFuncExpected         int
+	compareReloadableSettingsFuncCalled           int
+	lookupSourceNameFuncExpected                  int
+	lookupSourceNameFuncCalled                    int
+	getParseErrorFuncExpected                     int
+	getParseErrorFuncCalled                       int
+	getCustomizedSettingsFuncExpected             int
+	getCustomizedSettingsFuncCalled               int
+	getSettingSourceFuncExpected                  int
+	getSettingSourceFuncCalled                    int
+	getSettingErrorsFuncExpected                  int
+	getSettingErrorsFuncCalled                    int
+	generateSettingsReportFuncExpected            int
+	generateSettingsReportFuncCalled              int
+	validateRequiredSettingsFuncExpected          int
+	validateRequiredSettingsFuncCalled            int
 )
 
 func createMock(t *testing.T) {
@@ -139,6 +209,198 @@ func createMock(t *testing.T) {
 		validateGraceShutdownWaitTimeFuncCalled++
 		return nil, nil
 	}
+	osLookupEnvExpected = 0
+	osLookupEnvCalled = 0
+	osLookupEnv = func(key string) (string, bool) {
+		osLookupEnvCalled++
+		return "", false
+	}
+	ioutilReadFileExpected = 0
+	ioutilReadFileCalled = 0
+	ioutilReadFile = func(filename string) ([]byte, error) {
+		ioutilReadFileCalled++
+		return nil, nil
+	}
+	jsonUnmarshalExpected = 0
+	jsonUnmarshalCalled = 0
+	jsonUnmarshal = func(data []byte, v interface{}) error {
+		jsonUnmarshalCalled++
+		return nil
+	}
+	fmtErrorfExpected = 0
+	fmtErrorfCalled = 0
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		return nil
+	}
+	jsonutilMarshalIgnoreErrorExpected = 0
+	jsonutilMarshalIgnoreErrorCalled = 0
+	jsonutilMarshalIgnoreError = func(v interface{}) string {
+		jsonutilMarshalIgnoreErrorCalled++
+		return ""
+	}
+	toEnvironmentNameFuncExpected = 0
+	toEnvironmentNameFuncCalled = 0
+	toEnvironmentNameFunc = func(name string) string {
+		toEnvironmentNameFuncCalled++
+		return ""
+	}
+	parseDotEnvContentFuncExpected = 0
+	parseDotEnvContentFuncCalled = 0
+	parseDotEnvContentFunc = func(content []byte) (map[string]string, error) {
+		parseDotEnvContentFuncCalled++
+		return nil, nil
+	}
+	parseJSONContentFuncExpected = 0
+	parseJSONContentFuncCalled = 0
+	parseJSONContentFunc = func(content []byte) (map[string]string, error) {
+		parseJSONContentFuncCalled++
+		return nil, nil
+	}
+	parseYAMLContentFuncExpected = 0
+	parseYAMLContentFuncCalled = 0
+	parseYAMLContentFunc = func(content []byte) (map[string]string, error) {
+		parseYAMLContentFuncCalled++
+		return nil, nil
+	}
+	loadSourcesFuncExpected = 0
+	loadSourcesFuncCalled = 0
+	loadSourcesFunc = func() error {
+		loadSourcesFuncCalled++
+		return nil
+	}
+	lookupSourcesFuncExpected = 0
+	lookupSourcesFuncCalled = 0
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		return "", false
+	}
+	getStringFunctionFuncExpected = 0
+	getStringFunctionFuncCalled = 0
+	getStringFunctionFunc = func(customizedFunc func() string, name string) func() string {
+		getStringFunctionFuncCalled++
+		return nil
+	}
+	getBooleanFunctionFuncExpected = 0
+	getBooleanFunctionFuncCalled = 0
+	getBooleanFunctionFunc = func(customizedFunc func() bool, name string) (func() bool, error) {
+		getBooleanFunctionFuncCalled++
+		return nil, nil
+	}
+	getDurationFunctionFuncExpected = 0
+	getDurationFunctionFuncCalled = 0
+	getDurationFunctionFunc = func(customizedFunc func() time.Duration, name string) (func() time.Duration, error) {
+		getDurationFunctionFuncCalled++
+		return nil, nil
+	}
+	getLogTypeFunctionFuncExpected = 0
+	getLogTypeFunctionFuncCalled = 0
+	getLogTypeFunctionFunc = func(customizedFunc func() logtype.LogType, name string) func() logtype.LogType {
+		getLogTypeFunctionFuncCalled++
+		return nil
+	}
+	getLogLevelFunctionFuncExpected = 0
+	getLogLevelFunctionFuncCalled = 0
+	getLogLevelFunctionFunc = func(customizedFunc func() loglevel.LogLevel, name string) func() loglevel.LogLevel {
+		getLogLevelFunctionFuncCalled++
+		return nil
+	}
+	osStatExpected = 0
+	osStatCalled = 0
+	osStat = func(name string) (os.FileInfo, error) {
+		osStatCalled++
+		return nil, nil
+	}
+	getHeaderStyleFunctionFuncExpected = 0
+	getHeaderStyleFunctionFuncCalled = 0
+	getHeaderStyleFunctionFunc = func(customizedFunc func() headerstyle.HeaderStyle, name string) func() headerstyle.HeaderStyle {
+		getHeaderStyleFunctionFuncCalled++
+		return nil
+	}
+	getModTimeFuncExpected = 0
+	getModTimeFuncCalled = 0
+	getModTimeFunc = func(path string) time.Time {
+		getModTimeFuncCalled++
+		return time.Time{}
+	}
+	resolveReloadableSettingsFuncExpected = 0
+	resolveReloadableSettingsFuncCalled = 0
+	resolveReloadableSettingsFunc = func() (*reloadableSettings, []settingError) {
+		resolveReloadableSettingsFuncCalled++
+		return nil, nil
+	}
+	mergeSettingErrorFuncExpected = 0
+	mergeSettingErrorFuncCalled = 0
+	mergeSettingErrorFunc = func(parseError error, validationError error) error {
+		mergeSettingErrorFuncCalled++
+		return nil
+	}
+	validateDefaultHTTPHeaderLogStyleFuncExpected = 0
+	validateDefaultHTTPHeaderLogStyleFuncCalled = 0
+	validateDefaultHTTPHeaderLogStyleFunc = func(customizedFunc func() headerstyle.HeaderStyle, defaultFunc func() headerstyle.HeaderStyle) (func() headerstyle.HeaderStyle, error) {
+		validateDefaultHTTPHeaderLogStyleFuncCalled++
+		return nil, nil
+	}
+	getReloadableSettingsFuncExpected = 0
+	getReloadableSettingsFuncCalled = 0
+	getReloadableSettingsFunc = func() *reloadableSettings {
+		getReloadableSettingsFuncCalled++
+		return nil
+	}
+	appendChangeFuncExpected = 0
+	appendChangeFuncCalled = 0
+	appendChangeFunc = func(changes []model.Change, name string, previous interface{}, current interface{}) []model.Change {
+		appendChangeFuncCalled++
+		return nil
+	}
+	compareReloadableSettingsFuncExpected = 0
+	compareReloadableSettingsFuncCalled = 0
+	compareReloadableSettingsFunc = func(previous *reloadableSettings, current *reloadableSettings) []model.Change {
+		compareReloadableSettingsFuncCalled++
+		return nil
+	}
+	lookupSourceNameFuncExpected = 0
+	lookupSourceNameFuncCalled = 0
+	lookupSourceNameFunc = func(name string) (string, bool) {
+		lookupSourceNameFuncCalled++
+		return "", false
+	}
+	getParseErrorFuncExpected = 0
+	getParseErrorFuncCalled = 0
+	getParseErrorFunc = func(name string, parseError error) error {
+		getParseErrorFuncCalled++
+		return nil
+	}
+	getCustomizedSettingsFuncExpected = 0
+	getCustomizedSettingsFuncCalled = 0
+	getCustomizedSettingsFunc = func() map[string]bool {
+		getCustomizedSettingsFuncCalled++
+		return nil
+	}
+	getSettingSourceFuncExpected = 0
+	getSettingSourceFuncCalled = 0
+	getSettingSourceFunc = func(name string, customized bool) string {
+		getSettingSourceFuncCalled++
+		return ""
+	}
+	getSettingErrorsFuncExpected = 0
+	getSettingErrorsFuncCalled = 0
+	getSettingErrorsFunc = func(settingErrors []settingError) []error {
+		getSettingErrorsFuncCalled++
+		return nil
+	}
+	generateSettingsReportFuncExpected = 0
+	generateSettingsReportFuncCalled = 0
+	generateSettingsReportFunc = func(settingErrors []settingError) []model.Setting {
+		generateSettingsReportFuncCalled++
+		return nil
+	}
+	validateRequiredSettingsFuncExpected = 0
+	validateRequiredSettingsFuncCalled = 0
+	validateRequiredSettingsFunc = func(report []model.Setting) error {
+		validateRequiredSettingsFuncCalled++
+		return nil
+	}
 }
 
 func verifyAll(t *testing.T) {
@@ -172,6 +434,74 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, validateDefaultNetworkTimeoutFuncExpected, validateDefaultNetworkTimeoutFuncCalled, "Unexpected number of calls to validateDefaultNetworkTimeoutFunc")
 	validateGraceShutdownWaitTimeFunc = validateGraceShutdownWaitTime
 	assert.Equal(t, validateGraceShutdownWaitTimeFuncExpected, validateGraceShutdownWaitTimeFuncCalled, "Unexpected number of calls to validateGraceShutdownWaitTimeFunc")
+	osLookupEnv = os.LookupEnv
+	assert.Equal(t, osLookupEnvExpected, osLookupEnvCalled, "Unexpected number of calls to osLookupEnv")
+	ioutilReadFile = ioutil.ReadFile
+	assert.Equal(t, ioutilReadFileExpected, ioutilReadFileCalled, "Unexpected number of calls to ioutilReadFile")
+	jsonUnmarshal = json.Unmarshal
+	assert.Equal(t, jsonUnmarshalExpected, jsonUnmarshalCalled, "Unexpected number of calls to jsonUnmarshal")
+	fmtErrorf = fmt.Errorf
+	assert.Equal(t, fmtErrorfExpected, fmtErrorfCalled, "Unexpected number of calls to fmtErrorf")
+	jsonutilMarshalIgnoreError = jsonutil.MarshalIgnoreError
+	assert.Equal(t, jsonutilMarshalIgnoreErrorExpected, jsonutilMarshalIgnoreErrorCalled, "Unexpected number of calls to jsonutilMarshalIgnoreError")
+	toEnvironmentNameFunc = toEnvironmentName
+	assert.Equal(t, toEnvironmentNameFuncExpected, toEnvironmentNameFuncCalled, "Unexpected number of calls to toEnvironmentNameFunc")
+	parseDotEnvContentFunc = parseDotEnvContent
+	assert.Equal(t, parseDotEnvContentFuncExpected, parseDotEnvContentFuncCalled, "Unexpected number of calls to parseDotEnvContentFunc")
+	parseJSONContentFunc = parseJSONContent
+	assert.Equal(t, parseJSONContentFuncExpected, parseJSONContentFuncCalled, "Unexpected number of calls to parseJSONContentFunc")
+	parseYAMLContentFunc = parseYAMLContent
+	assert.Equal(t, parseYAMLContentFuncExpected, parseYAMLContentFuncCalled, "Unexpected number of calls to parseYAMLContentFunc")
+	loadSourcesFunc = loadSources
+	assert.Equal(t, loadSourcesFuncExpected, loadSourcesFuncCalled, "Unexpected number of calls to loadSourcesFunc")
+	lookupSourcesFunc = lookupSources
+	assert.Equal(t, lookupSourcesFuncExpected, lookupSourcesFuncCalled, "Unexpected number of calls to lookupSourcesFunc")
+	getStringFunctionFunc = getStringFunction
+	assert.Equal(t, getStringFunctionFuncExpected, getStringFunctionFuncCalled, "Unexpected number of calls to getStringFunctionFunc")
+	getBooleanFunctionFunc = getBooleanFunction
+	assert.Equal(t, getBooleanFunctionFuncExpected, getBooleanFunctionFuncCalled, "Unexpected number of calls to getBooleanFunctionFunc")
+	getDurationFunctionFunc = getDurationFunction
+	assert.Equal(t, getDurationFunctionFuncExpected, getDurationFunctionFuncCalled, "Unexpected number of calls to getDurationFunctionFunc")
+	getLogTypeFunctionFunc = getLogTypeFunction
+	assert.Equal(t, getLogTypeFunctionFuncExpected, getLogTypeFunctionFuncCalled, "Unexpected number of calls to getLogTypeFunctionFunc")
+	getLogLevelFunctionFunc = getLogLevelFunction
+	assert.Equal(t, getLogLevelFunctionFuncExpected, getLogLevelFunctionFuncCalled, "Unexpected number of calls to getLogLevelFunctionFunc")
+	osStat = os.Stat
+	assert.Equal(t, osStatExpected, osStatCalled, "Unexpected number of calls to osStat")
+	getHeaderStyleFunctionFunc = getHeaderStyleFunction
+	assert.Equal(t, getHeaderStyleFunctionFuncExpected, getHeaderStyleFunctionFuncCalled, "Unexpected number of calls to getHeaderStyleFunctionFunc")
+	getModTimeFunc = getModTime
+	assert.Equal(t, getModTimeFuncExpected, getModTimeFuncCalled, "Unexpected number of calls to getModTimeFunc")
+	resolveReloadableSettingsFunc = resolveReloadableSettings
+	assert.Equal(t, resolveReloadableSettingsFuncExpected, resolveReloadableSettingsFuncCalled, "Unexpected number of calls to resolveReloadableSettingsFunc")
+	mergeSettingErrorFunc = mergeSettingError
+	assert.Equal(t, mergeSettingErrorFuncExpected, mergeSettingErrorFuncCalled, "Unexpected number of calls to mergeSettingErrorFunc")
+	validateDefaultHTTPHeaderLogStyleFunc = validateDefaultHTTPHeaderLogStyle
+	assert.Equal(t, validateDefaultHTTPHeaderLogStyleFuncExpected, validateDefaultHTTPHeaderLogStyleFuncCalled, "Unexpected number of calls to validateDefaultHTTPHeaderLogStyleFunc")
+	getReloadableSettingsFunc = getReloadableSettings
+	assert.Equal(t, getReloadableSettingsFuncExpected, getReloadableSettingsFuncCalled, "Unexpected number of calls to getReloadableSettingsFunc")
+	appendChangeFunc = appendChange
+	assert.Equal(t, appendChangeFuncExpected, appendChangeFuncCalled, "Unexpected number of calls to appendChangeFunc")
+	compareReloadableSettingsFunc = compareReloadableSettings
+	assert.Equal(t, compareReloadableSettingsFuncExpected, compareReloadableSettingsFuncCalled, "Unexpected number of calls to compareReloadableSettingsFunc")
+	lookupSourceNameFunc = lookupSourceName
+	assert.Equal(t, lookupSourceNameFuncExpected, lookupSourceNameFuncCalled, "Unexpected number of calls to lookupSourceNameFunc")
+	getParseErrorFunc = getParseError
+	assert.Equal(t, getParseErrorFuncExpected, getParseErrorFuncCalled, "Unexpected number of calls to getParseErrorFunc")
+	getCustomizedSettingsFunc = getCustomizedSettings
+	assert.Equal(t, getCustomizedSettingsFuncExpected, getCustomizedSettingsFuncCalled, "Unexpected number of calls to getCustomizedSettingsFunc")
+	getSettingSourceFunc = getSettingSource
+	assert.Equal(t, getSettingSourceFuncExpected, getSettingSourceFuncCalled, "Unexpected number of calls to getSettingSourceFunc")
+	getSettingErrorsFunc = getSettingErrors
+	assert.Equal(t, getSettingErrorsFuncExpected, getSettingErrorsFuncCalled, "Unexpected number of calls to getSettingErrorsFunc")
+	generateSettingsReportFunc = generateSettingsReport
+	assert.Equal(t, generateSettingsReportFuncExpected, generateSettingsReportFuncCalled, "Unexpected number of calls to generateSettingsReportFunc")
+	validateRequiredSettingsFunc = validateRequiredSettings
+	assert.Equal(t, validateRequiredSettingsFuncExpected, validateRequiredSettingsFuncCalled, "Unexpected number of calls to validateRequiredSettingsFunc")
+
+	configSources = nil
+	reloadableStore.Store((*reloadableSettings)(nil))
+	settingsReport = nil
 
 	AppVersion = defaultAppVersion
 	AppPort = defaultAppPort
@@ -185,8 +515,9 @@ func verifyAll(t *testing.T) {
 	CaCertContent = defaultCaCertContent
 	ClientCertContent = defaultClientCertContent
 	ClientKeyContent = defaultClientKeyContent
-	DefaultAllowedLogType = defaultAllowedLogType
-	DefaultAllowedLogLevel = defaultAllowedLogLevel
-	DefaultNetworkTimeout = defaultNetworkTimeout
+	DefaultAllowedLogType = currentDefaultAllowedLogType
+	DefaultAllowedLogLevel = currentDefaultAllowedLogLevel
+	DefaultNetworkTimeout = currentDefaultNetworkTimeout
+	DefaultHTTPHeaderLogStyle = currentDefaultHTTPHeaderLogStyle
 	GraceShutdownWaitTime = graceShutdownWaitTime
 }
@@ -45,14 +45,17 @@ var ClientCertContent = defaultClientCertContent
 // ClientKeyContent returns the client certificate key content of the application
 var ClientKeyContent = defaultClientKeyContent
 
-// DefaultAllowedLogType returns the default allowed log type of the application
-var DefaultAllowedLogType = defaultAllowedLogType
+// DefaultAllowedLogType returns the default allowed log type of the application; hot-reloadable through Reload
+var DefaultAllowedLogType = currentDefaultAllowedLogType
 
-// DefaultAllowedLogLevel returns the default allowed log level of the application
-var DefaultAllowedLogLevel = defaultAllowedLogLevel
+// DefaultAllowedLogLevel returns the default allowed log level of the application; hot-reloadable through Reload
+var DefaultAllowedLogLevel = currentDefaultAllowedLogLevel
 
-// DefaultNetworkTimeout returns the default network timeout value of the application
-var DefaultNetworkTimeout = defaultNetworkTimeout
+// DefaultNetworkTimeout returns the default network timeout value of the application; hot-reloadable through Reload
+var DefaultNetworkTimeout = currentDefaultNetworkTimeout
+
+// DefaultHTTPHeaderLogStyle returns the default log style for HTTP headers of the application; hot-reloadable through Reload
+var DefaultHTTPHeaderLogStyle = currentDefaultHTTPHeaderLogStyle
 
 // SkipServerCertVerification returns the choice whether or not skipping the server certificate verification for network communications
 var SkipServerCertVerification = defaultSkipServerCertVerification
@@ -251,6 +254,14 @@ func validateGraceShutdownWaitTime(
 	return customizedFunc, nil
 }
 
+// mergeSettingError returns the parse error of a setting in preference to its validation error, as the former explains why the setting falls back to default
+func mergeSettingError(parseError error, validationError error) error {
+	if parseError != nil {
+		return parseError
+	}
+	return validationError
+}
+
 func isServerCertificateAvailable() bool {
 	return len(ServerCertContent()) != 0 && len(ServerKeyContent()) != 0
 }
@@ -259,142 +270,213 @@ func isCaCertificateAvailable() bool {
 	return len(CaCertContent()) != 0
 }
 
-// Initialize initiates and checks all application config related function injections
+// Initialize initiates and checks all application config related function injections; if customization.RequiredConfigs is configured, returns a strict validation error (see IsStrictValidationError) when any required config falls back to default
 func Initialize() error {
 	const noForceToDefault = false
 	var (
-		appVersionError             error
-		appPortError                error
-		appNameError                error
-		appPathError                error
-		isLocalhostError            error
-		serveHTTPSError             error
-		serverCertContentError      error
-		serverKeyContentError       error
-		validateClientCertError     error
-		caCertContentError          error
-		clientCertContentError      error
-		clientKeyContentError       error
-		defaultAllowedLogTypeError  error
-		defaultAllowedLogLevelError error
-		defaultNetworkTimeoutError  error
-		skipServerCertVerifyError   error
-		graceShutdownWaitTimeError  error
+		loadSourcesError           error
+		appVersionError            error
+		appPortError               error
+		appNameError               error
+		appPathError               error
+		isLocalhostError           error
+		serveHTTPSError            error
+		serverCertContentError     error
+		serverKeyContentError      error
+		validateClientCertError    error
+		caCertContentError         error
+		clientCertContentError     error
+		clientKeyContentError      error
+		skipServerCertVerifyError  error
+		graceShutdownWaitTimeError error
 	)
+	loadSourcesError = loadSourcesFunc()
 	AppVersion, appVersionError = validateStringFunctionFunc(
-		customization.AppVersion,
+		getStringFunctionFunc(
+			customization.AppVersion,
+			"AppVersion",
+		),
 		"AppVersion",
 		defaultAppVersion,
 		noForceToDefault,
 	)
 	AppPort, appPortError = validateStringFunctionFunc(
-		customization.AppPort,
+		getStringFunctionFunc(
+			customization.AppPort,
+			"AppPort",
+		),
 		"AppPort",
 		defaultAppPort,
 		noForceToDefault,
 	)
 	AppName, appNameError = validateStringFunctionFunc(
-		customization.AppName,
+		getStringFunctionFunc(
+			customization.AppName,
+			"AppName",
+		),
 		"AppName",
 		defaultAppName,
 		noForceToDefault,
 	)
 	AppPath, appPathError = validateStringFunctionFunc(
-		customization.AppPath,
+		getStringFunctionFunc(
+			customization.AppPath,
+			"AppPath",
+		),
 		"AppPath",
 		defaultAppPath,
 		noForceToDefault,
 	)
-	IsLocalhost, isLocalhostError = validateBooleanFunctionFunc(
+	var isLocalhostFunc, isLocalhostParseError = getBooleanFunctionFunc(
 		customization.IsLocalhost,
 		"IsLocalhost",
+	)
+	IsLocalhost, isLocalhostError = validateBooleanFunctionFunc(
+		isLocalhostFunc,
+		"IsLocalhost",
 		defaultIsLocalhost,
 		noForceToDefault,
 	)
+	isLocalhostError = mergeSettingErrorFunc(
+		isLocalhostParseError,
+		isLocalhostError,
+	)
 	ServerCertContent, serverCertContentError = validateStringFunctionFunc(
-		customization.ServerCertContent,
+		getStringFunctionFunc(
+			customization.ServerCertContent,
+			"ServerCertContent",
+		),
 		"ServerCertContent",
 		defaultServerCertContent,
 		noForceToDefault,
 	)
 	ServerKeyContent, serverKeyContentError = validateStringFunctionFunc(
-		customization.ServerKeyContent,
+		getStringFunctionFunc(
+			customization.ServerKeyContent,
+			"ServerKeyContent",
+		),
 		"ServerKeyContent",
 		defaultServerKeyContent,
 		noForceToDefault,
 	)
-	ServeHTTPS, serveHTTPSError = validateBooleanFunctionFunc(
+	var serveHTTPSFunc, serveHTTPSParseError = getBooleanFunctionFunc(
 		customization.ServeHTTPS,
 		"ServeHTTPS",
+	)
+	ServeHTTPS, serveHTTPSError = validateBooleanFunctionFunc(
+		serveHTTPSFunc,
+		"ServeHTTPS",
 		defaultServeHTTPS,
 		!isServerCertificateAvailableFunc(),
 	)
+	serveHTTPSError = mergeSettingErrorFunc(
+		serveHTTPSParseError,
+		serveHTTPSError,
+	)
 	CaCertContent, caCertContentError = validateStringFunctionFunc(
-		customization.CaCertContent,
+		getStringFunctionFunc(
+			customization.CaCertContent,
+			"CaCertContent",
+		),
 		"CaCertContent",
 		defaultCaCertContent,
 		noForceToDefault,
 	)
-	ValidateClientCert, validateClientCertError = validateBooleanFunctionFunc(
+	var validateClientCertFunc, validateClientCertParseError = getBooleanFunctionFunc(
 		customization.ValidateClientCert,
 		"ValidateClientCert",
+	)
+	ValidateClientCert, validateClientCertError = validateBooleanFunctionFunc(
+		validateClientCertFunc,
+		"ValidateClientCert",
 		defaultValidateClientCert,
 		!isCaCertificateAvailableFunc(),
 	)
+	validateClientCertError = mergeSettingErrorFunc(
+		validateClientCertParseError,
+		validateClientCertError,
+	)
 	ClientCertContent, clientCertContentError = validateStringFunctionFunc(
-		customization.ClientCertContent,
+		getStringFunctionFunc(
+			customization.ClientCertContent,
+			"ClientCertContent",
+		),
 		"ClientCertContent",
 		defaultClientCertContent,
 		noForceToDefault,
 	)
 	ClientKeyContent, clientKeyContentError = validateStringFunctionFunc(
-		customization.ClientKeyContent,
+		getStringFunctionFunc(
+			customization.ClientKeyContent,
+			"ClientKeyContent",
+		),
 		"ClientKeyContent",
 		defaultClientKeyContent,
 		noForceToDefault,
 	)
-	DefaultAllowedLogType, defaultAllowedLogTypeError = validateDefaultAllowedLogTypeFunc(
-		customization.DefaultAllowedLogType,
-		defaultAllowedLogType,
-	)
-	DefaultAllowedLogLevel, defaultAllowedLogLevelError = validateDefaultAllowedLogLevelFunc(
-		customization.DefaultAllowedLogLevel,
-		defaultAllowedLogLevel,
-	)
-	DefaultNetworkTimeout, defaultNetworkTimeoutError = validateDefaultNetworkTimeoutFunc(
-		customization.DefaultNetworkTimeout,
-		defaultNetworkTimeout,
+	var skipServerCertVerifyFunc, skipServerCertVerifyParseError = getBooleanFunctionFunc(
+		customization.SkipServerCertVerification,
+		"SkipServerCertVerification",
 	)
 	SkipServerCertVerification, skipServerCertVerifyError = validateBooleanFunctionFunc(
-		customization.SkipServerCertVerification,
+		skipServerCertVerifyFunc,
 		"SkipServerCertVerification",
 		defaultSkipServerCertVerification,
 		noForceToDefault,
 	)
-	GraceShutdownWaitTime, graceShutdownWaitTimeError = validateGraceShutdownWaitTimeFunc(
+	skipServerCertVerifyError = mergeSettingErrorFunc(
+		skipServerCertVerifyParseError,
+		skipServerCertVerifyError,
+	)
+	var graceShutdownWaitTimeFunc, graceShutdownWaitTimeParseError = getDurationFunctionFunc(
 		customization.GraceShutdownWaitTime,
+		"GraceShutdownWaitTime",
+	)
+	GraceShutdownWaitTime, graceShutdownWaitTimeError = validateGraceShutdownWaitTimeFunc(
+		graceShutdownWaitTimeFunc,
 		graceShutdownWaitTime,
 	)
-	return apperrorWrapSimpleError(
-		[]error{
-			appVersionError,
-			appPortError,
-			appNameError,
-			appPathError,
-			isLocalhostError,
-			serverCertContentError,
-			serverKeyContentError,
-			serveHTTPSError,
-			caCertContentError,
-			validateClientCertError,
-			clientCertContentError,
-			clientKeyContentError,
-			defaultAllowedLogTypeError,
-			defaultAllowedLogLevelError,
-			defaultNetworkTimeoutError,
-			skipServerCertVerifyError,
-			graceShutdownWaitTimeError,
+	graceShutdownWaitTimeError = mergeSettingErrorFunc(
+		graceShutdownWaitTimeParseError,
+		graceShutdownWaitTimeError,
+	)
+	var reloadableSettings, reloadableErrors = resolveReloadableSettingsFunc()
+	reloadableStore.Store(reloadableSettings)
+	var settingErrors = append(
+		[]settingError{
+			{"AppVersion", appVersionError},
+			{"AppPort", appPortError},
+			{"AppName", appNameError},
+			{"AppPath", appPathError},
+			{"IsLocalhost", isLocalhostError},
+			{"ServerCertContent", serverCertContentError},
+			{"ServerKeyContent", serverKeyContentError},
+			{"ServeHTTPS", serveHTTPSError},
+			{"CaCertContent", caCertContentError},
+			{"ValidateClientCert", validateClientCertError},
+			{"ClientCertContent", clientCertContentError},
+			{"ClientKeyContent", clientKeyContentError},
+			{"SkipServerCertVerification", skipServerCertVerifyError},
+			{"GraceShutdownWaitTime", graceShutdownWaitTimeError},
 		},
+		reloadableErrors...,
+	)
+	settingsReport = generateSettingsReportFunc(
+		settingErrors,
+	)
+	var requiredSettingsError = validateRequiredSettingsFunc(
+		settingsReport,
+	)
+	if requiredSettingsError != nil {
+		return requiredSettingsError
+	}
+	return apperrorWrapSimpleError(
+		append(
+			[]error{
+				loadSourcesError,
+			},
+			getSettingErrorsFunc(settingErrors)...,
+		),
 		"Unexpected errors occur during configuration initialization",
 	)
 }
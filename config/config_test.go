@@ -12,6 +12,7 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
 	apperrorEnum "github.com/zhongjie-cai/WebServiceTemplate/apperror/enum"
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/config/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/customization"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
@@ -1238,6 +1239,47 @@ func TestValidateGraceShutdownWaitTime_ValidFunc(t *testing.T) {
 	assert.Equal(t, dummyDefaultFuncExpected, dummyDefaultFuncCalled, "Unexpected number of calls to dummyDefaultFunc")
 }
 
+func TestMergeSettingError_ParseError(t *testing.T) {
+	// arrange
+	var dummyParseError = errors.New("some parse error")
+	var dummyValidationError = errors.New("some validation error")
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err = mergeSettingError(
+		dummyParseError,
+		dummyValidationError,
+	)
+
+	// assert
+	assert.Equal(t, dummyParseError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMergeSettingError_NoParseError(t *testing.T) {
+	// arrange
+	var dummyValidationError = errors.New("some validation error")
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err = mergeSettingError(
+		nil,
+		dummyValidationError,
+	)
+
+	// assert
+	assert.Equal(t, dummyValidationError, err)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestIsServerCertificateAvailable_CertEmpty(t *testing.T) {
 	// arrange
 	var serverCertContentExpected int
@@ -1444,9 +1486,10 @@ func TestInitialize(t *testing.T) {
 		errors.New("some ClientCertContent error"),
 		errors.New("some ClientKeyContent error"),
 	}
+	var dummyServeHTTPSParseError = errors.New("some ServeHTTPS parse error")
 	var expectedValidateBooleanFunctionFuncParameter1 = []string{
 		fmt.Sprintf("%v", reflect.ValueOf(customization.IsLocalhost)),
-		fmt.Sprintf("%v", reflect.ValueOf(customization.ServeHTTPS)),
+		fmt.Sprintf("%v", reflect.ValueOf((func() bool)(nil))),
 		fmt.Sprintf("%v", reflect.ValueOf(customization.ValidateClientCert)),
 		fmt.Sprintf("%v", reflect.ValueOf(customization.SkipServerCertVerification)),
 	}
@@ -1482,10 +1525,22 @@ func TestInitialize(t *testing.T) {
 		errors.New("some ValidateClientCert error"),
 		errors.New("some SkipServerCertVerification error"),
 	}
-	var expectedDefaultAllowedLogTypeError = errors.New("some default allowed log type error")
-	var expectedDefaultAllowedLogLevelError = errors.New("some default allowed log level error")
-	var expectedDefaultNetworkTimeoutError = errors.New("some default network timeout error")
 	var expectedGraceShutdownWaitTimeError = errors.New("some grace shutdown wait time error")
+	var expectedGetBooleanFunctionFuncParameter2 = []string{
+		"IsLocalhost",
+		"ServeHTTPS",
+		"ValidateClientCert",
+		"SkipServerCertVerification",
+	}
+	var dummyReloadableSettings = &reloadableSettings{}
+	var dummyReloadableErrors = []settingError{
+		{"some reloadable name 1", errors.New("some reloadable error 1")},
+		{"some reloadable name 2", errors.New("some reloadable error 2")},
+	}
+	var dummySettingsReport = []model.Setting{
+		{Name: "some name", Source: "some source", Defaulted: rand.Intn(100) < 50},
+	}
+	var expectedLoadSourcesError = errors.New("some load sources error")
 	var dummyMessageFormat = "Unexpected errors occur during configuration initialization"
 	var dummyAppError = apperror.GetCustomError(0, "some app error")
 
@@ -1493,6 +1548,37 @@ func TestInitialize(t *testing.T) {
 	createMock(t)
 
 	// expect
+	loadSourcesFuncExpected = 1
+	loadSourcesFunc = func() error {
+		loadSourcesFuncCalled++
+		return expectedLoadSourcesError
+	}
+	getStringFunctionFuncExpected = 9
+	getStringFunctionFunc = func(customizedFunc func() string, name string) func() string {
+		getStringFunctionFuncCalled++
+		assert.Equal(t, expectedValidateStringFunctionFuncParameter2[getStringFunctionFuncCalled-1], name)
+		return customizedFunc
+	}
+	getBooleanFunctionFuncExpected = 4
+	getBooleanFunctionFunc = func(customizedFunc func() bool, name string) (func() bool, error) {
+		getBooleanFunctionFuncCalled++
+		assert.Equal(t, expectedGetBooleanFunctionFuncParameter2[getBooleanFunctionFuncCalled-1], name)
+		if name == "ServeHTTPS" {
+			return nil, dummyServeHTTPSParseError
+		}
+		return customizedFunc, nil
+	}
+	getDurationFunctionFuncExpected = 1
+	getDurationFunctionFunc = func(customizedFunc func() time.Duration, name string) (func() time.Duration, error) {
+		getDurationFunctionFuncCalled++
+		assert.Equal(t, "GraceShutdownWaitTime", name)
+		return customizedFunc, nil
+	}
+	mergeSettingErrorFuncExpected = 5
+	mergeSettingErrorFunc = func(parseError error, validationError error) error {
+		mergeSettingErrorFuncCalled++
+		return mergeSettingError(parseError, validationError)
+	}
 	validateStringFunctionFuncExpected = 9
 	validateStringFunctionFunc = func(stringFunc func() string, name string, defaultFunc func() string, forceToDefault bool) (func() string, error) {
 		validateStringFunctionFuncCalled++
@@ -1523,27 +1609,6 @@ func TestInitialize(t *testing.T) {
 		isCaCertificateAvailableFuncCalled++
 		return dummyIsCaCertificateAvailable
 	}
-	validateDefaultAllowedLogTypeFuncExpected = 1
-	validateDefaultAllowedLogTypeFunc = func(customizedFunc func() logtype.LogType, defaultFunc func() logtype.LogType) (func() logtype.LogType, error) {
-		validateDefaultAllowedLogTypeFuncCalled++
-		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(customization.DefaultAllowedLogType)), fmt.Sprintf("%v", reflect.ValueOf(customizedFunc)))
-		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(defaultAllowedLogType)), fmt.Sprintf("%v", reflect.ValueOf(defaultFunc)))
-		return defaultAllowedLogType, expectedDefaultAllowedLogTypeError
-	}
-	validateDefaultAllowedLogLevelFuncExpected = 1
-	validateDefaultAllowedLogLevelFunc = func(customizedFunc func() loglevel.LogLevel, defaultFunc func() loglevel.LogLevel) (func() loglevel.LogLevel, error) {
-		validateDefaultAllowedLogLevelFuncCalled++
-		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(customization.DefaultAllowedLogLevel)), fmt.Sprintf("%v", reflect.ValueOf(customizedFunc)))
-		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(defaultAllowedLogLevel)), fmt.Sprintf("%v", reflect.ValueOf(defaultFunc)))
-		return defaultAllowedLogLevel, expectedDefaultAllowedLogLevelError
-	}
-	validateDefaultNetworkTimeoutFuncExpected = 1
-	validateDefaultNetworkTimeoutFunc = func(customizedFunc func() time.Duration, defaultFunc func() time.Duration) (func() time.Duration, error) {
-		validateDefaultNetworkTimeoutFuncCalled++
-		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(customization.DefaultNetworkTimeout)), fmt.Sprintf("%v", reflect.ValueOf(customizedFunc)))
-		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(defaultNetworkTimeout)), fmt.Sprintf("%v", reflect.ValueOf(defaultFunc)))
-		return defaultNetworkTimeout, expectedDefaultNetworkTimeoutError
-	}
 	validateGraceShutdownWaitTimeFuncExpected = 1
 	validateGraceShutdownWaitTimeFunc = func(customizedFunc func() time.Duration, defaultFunc func() time.Duration) (func() time.Duration, error) {
 		validateGraceShutdownWaitTimeFuncCalled++
@@ -1551,27 +1616,53 @@ func TestInitialize(t *testing.T) {
 		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(graceShutdownWaitTime)), fmt.Sprintf("%v", reflect.ValueOf(defaultFunc)))
 		return graceShutdownWaitTime, expectedGraceShutdownWaitTimeError
 	}
+	resolveReloadableSettingsFuncExpected = 1
+	resolveReloadableSettingsFunc = func() (*reloadableSettings, []settingError) {
+		resolveReloadableSettingsFuncCalled++
+		return dummyReloadableSettings, dummyReloadableErrors
+	}
+	generateSettingsReportFuncExpected = 1
+	generateSettingsReportFunc = func(settingErrors []settingError) []model.Setting {
+		generateSettingsReportFuncCalled++
+		assert.Equal(t, 16, len(settingErrors))
+		assert.Equal(t, settingError{"AppVersion", expectedValidateStringFunctionFuncReturn2[0]}, settingErrors[0])
+		assert.Equal(t, settingError{"ServeHTTPS", dummyServeHTTPSParseError}, settingErrors[7])
+		assert.Equal(t, settingError{"GraceShutdownWaitTime", expectedGraceShutdownWaitTimeError}, settingErrors[13])
+		assert.Equal(t, dummyReloadableErrors[1], settingErrors[15])
+		return dummySettingsReport
+	}
+	getSettingErrorsFuncExpected = 1
+	getSettingErrorsFunc = func(settingErrors []settingError) []error {
+		getSettingErrorsFuncCalled++
+		return getSettingErrors(settingErrors)
+	}
+	validateRequiredSettingsFuncExpected = 1
+	validateRequiredSettingsFunc = func(report []model.Setting) error {
+		validateRequiredSettingsFuncCalled++
+		assert.Equal(t, dummySettingsReport, report)
+		return nil
+	}
 	apperrorWrapSimpleErrorExpected = 1
 	apperrorWrapSimpleError = func(innerErrors []error, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
 		apperrorWrapSimpleErrorCalled++
 		assert.Equal(t, 17, len(innerErrors))
-		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[0], innerErrors[0])
-		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[1], innerErrors[1])
-		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[2], innerErrors[2])
-		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[3], innerErrors[3])
-		assert.Equal(t, expectedValidateBooleanFunctionFuncReturn2[0], innerErrors[4])
-		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[4], innerErrors[5])
-		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[5], innerErrors[6])
-		assert.Equal(t, expectedValidateBooleanFunctionFuncReturn2[1], innerErrors[7])
-		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[6], innerErrors[8])
-		assert.Equal(t, expectedValidateBooleanFunctionFuncReturn2[2], innerErrors[9])
-		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[7], innerErrors[10])
-		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[8], innerErrors[11])
-		assert.Equal(t, expectedDefaultAllowedLogTypeError, innerErrors[12])
-		assert.Equal(t, expectedDefaultAllowedLogLevelError, innerErrors[13])
-		assert.Equal(t, expectedDefaultNetworkTimeoutError, innerErrors[14])
-		assert.Equal(t, expectedValidateBooleanFunctionFuncReturn2[3], innerErrors[15])
-		assert.Equal(t, expectedGraceShutdownWaitTimeError, innerErrors[16])
+		assert.Equal(t, expectedLoadSourcesError, innerErrors[0])
+		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[0], innerErrors[1])
+		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[1], innerErrors[2])
+		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[2], innerErrors[3])
+		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[3], innerErrors[4])
+		assert.Equal(t, expectedValidateBooleanFunctionFuncReturn2[0], innerErrors[5])
+		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[4], innerErrors[6])
+		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[5], innerErrors[7])
+		assert.Equal(t, dummyServeHTTPSParseError, innerErrors[8])
+		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[6], innerErrors[9])
+		assert.Equal(t, expectedValidateBooleanFunctionFuncReturn2[2], innerErrors[10])
+		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[7], innerErrors[11])
+		assert.Equal(t, expectedValidateStringFunctionFuncReturn2[8], innerErrors[12])
+		assert.Equal(t, expectedValidateBooleanFunctionFuncReturn2[3], innerErrors[13])
+		assert.Equal(t, expectedGraceShutdownWaitTimeError, innerErrors[14])
+		assert.Equal(t, dummyReloadableErrors[0].err, innerErrors[15])
+		assert.Equal(t, dummyReloadableErrors[1].err, innerErrors[16])
 		assert.Equal(t, dummyMessageFormat, messageFormat)
 		assert.Equal(t, 0, len(parameters))
 		return dummyAppError
@@ -1582,6 +1673,55 @@ func TestInitialize(t *testing.T) {
 
 	// assert
 	assert.Equal(t, dummyAppError, err)
+	assert.Equal(t, dummyReloadableSettings, reloadableStore.Load())
+	assert.Equal(t, dummySettingsReport, settingsReport)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestInitialize_StrictValidationError(t *testing.T) {
+	// arrange
+	var dummySettingsReport = []model.Setting{
+		{Name: "some name", Source: "some source", Defaulted: true},
+	}
+	var dummyStrictValidationError = &strictValidationError{
+		apperror.GetCustomError(0, "some strict validation error"),
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	loadSourcesFuncExpected = 1
+	getStringFunctionFuncExpected = 9
+	getBooleanFunctionFuncExpected = 4
+	getDurationFunctionFuncExpected = 1
+	mergeSettingErrorFuncExpected = 5
+	validateStringFunctionFuncExpected = 9
+	validateBooleanFunctionFuncExpected = 4
+	isServerCertificateAvailableFuncExpected = 1
+	isCaCertificateAvailableFuncExpected = 1
+	validateGraceShutdownWaitTimeFuncExpected = 1
+	resolveReloadableSettingsFuncExpected = 1
+	generateSettingsReportFuncExpected = 1
+	generateSettingsReportFunc = func(settingErrors []settingError) []model.Setting {
+		generateSettingsReportFuncCalled++
+		return dummySettingsReport
+	}
+	validateRequiredSettingsFuncExpected = 1
+	validateRequiredSettingsFunc = func(report []model.Setting) error {
+		validateRequiredSettingsFuncCalled++
+		assert.Equal(t, dummySettingsReport, report)
+		return dummyStrictValidationError
+	}
+
+	// SUT + act
+	var err = Initialize()
+
+	// assert
+	assert.Equal(t, dummyStrictValidationError, err)
+	assert.Equal(t, dummySettingsReport, settingsReport)
 
 	// verify
 	verifyAll(t)
@@ -0,0 +1,13 @@
+package model
+
+// Change describes a hot-reloadable configuration whose value has been changed during a configuration reload
+type Change struct {
+	Name     string
+	Previous string
+	Current  string
+}
+
+// String returns the human readable description of the change
+func (change Change) String() string {
+	return change.Name + ": [" + change.Previous + "] => [" + change.Current + "]"
+}
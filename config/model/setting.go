@@ -0,0 +1,17 @@
+package model
+
+// Setting describes how an application config is resolved during configuration initialization
+type Setting struct {
+	Name      string
+	Source    string
+	Defaulted bool
+}
+
+// String returns the human readable description of the setting
+func (setting Setting) String() string {
+	var description = setting.Name + " <= " + setting.Source
+	if setting.Defaulted {
+		description += " (defaulted)"
+	}
+	return description
+}
@@ -0,0 +1,18 @@
+package model
+
+// Source is the interface for a configuration source, which is consulted by config.Initialize for any setting that is not customized through functions
+type Source interface {
+	// Name returns the name of this configuration source, mainly for logging purpose
+	Name() string
+	// Load loads or refreshes the configuration values from the underlying storage of this source
+	Load() error
+	// Lookup retrieves the raw string value of the given setting name; returns false if the setting is not present in this source
+	Lookup(name string) (string, bool)
+}
+
+// WatchableSource is the interface for a configuration source which is able to detect changes in its underlying storage, e.g. file modification, for configuration hot reload
+type WatchableSource interface {
+	Source
+	// Changed returns true if the underlying storage has been changed since last load
+	Changed() bool
+}
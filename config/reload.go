@@ -0,0 +1,206 @@
+package config
+
+import (
+	"sync/atomic"
+	"time"
+
+	apperrorEnum "github.com/zhongjie-cai/WebServiceTemplate/apperror/enum"
+	"github.com/zhongjie-cai/WebServiceTemplate/config/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/headerutil/headerstyle"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
+)
+
+type reloadableSettings struct {
+	allowedLogType     func() logtype.LogType
+	allowedLogLevel    func() loglevel.LogLevel
+	networkTimeout     func() time.Duration
+	httpHeaderLogStyle func() headerstyle.HeaderStyle
+}
+
+var (
+	reloadableStore atomic.Value
+)
+
+func defaultHTTPHeaderLogStyle() headerstyle.HeaderStyle {
+	return headerstyle.DoNotLog
+}
+
+func validateDefaultHTTPHeaderLogStyle(
+	customizedFunc func() headerstyle.HeaderStyle,
+	defaultFunc func() headerstyle.HeaderStyle,
+) (func() headerstyle.HeaderStyle, error) {
+	if customizedFunc == nil {
+		return defaultFunc,
+			apperrorGetCustomError(
+				apperrorEnum.CodeGeneralFailure,
+				"customization.DefaultHTTPHeaderLogStyle function is not configured; fallback to default [%v].",
+				defaultFunc(),
+			)
+	}
+	return customizedFunc, nil
+}
+
+func getReloadableSettings() *reloadableSettings {
+	var settings, ok = reloadableStore.Load().(*reloadableSettings)
+	if !ok || settings == nil {
+		return &reloadableSettings{
+			allowedLogType:     defaultAllowedLogType,
+			allowedLogLevel:    defaultAllowedLogLevel,
+			networkTimeout:     defaultNetworkTimeout,
+			httpHeaderLogStyle: defaultHTTPHeaderLogStyle,
+		}
+	}
+	return settings
+}
+
+func resolveReloadableSettings() (*reloadableSettings, []settingError) {
+	var (
+		settings                       = &reloadableSettings{}
+		defaultAllowedLogTypeError     error
+		defaultAllowedLogLevelError    error
+		defaultNetworkTimeoutError     error
+		defaultHTTPHeaderLogStyleError error
+	)
+	settings.allowedLogType, defaultAllowedLogTypeError = validateDefaultAllowedLogTypeFunc(
+		getLogTypeFunctionFunc(
+			customization.DefaultAllowedLogType,
+			"DefaultAllowedLogType",
+		),
+		defaultAllowedLogType,
+	)
+	settings.allowedLogLevel, defaultAllowedLogLevelError = validateDefaultAllowedLogLevelFunc(
+		getLogLevelFunctionFunc(
+			customization.DefaultAllowedLogLevel,
+			"DefaultAllowedLogLevel",
+		),
+		defaultAllowedLogLevel,
+	)
+	var networkTimeoutFunc, defaultNetworkTimeoutParseError = getDurationFunctionFunc(
+		customization.DefaultNetworkTimeout,
+		"DefaultNetworkTimeout",
+	)
+	settings.networkTimeout, defaultNetworkTimeoutError = validateDefaultNetworkTimeoutFunc(
+		networkTimeoutFunc,
+		defaultNetworkTimeout,
+	)
+	defaultNetworkTimeoutError = mergeSettingErrorFunc(
+		defaultNetworkTimeoutParseError,
+		defaultNetworkTimeoutError,
+	)
+	settings.httpHeaderLogStyle, defaultHTTPHeaderLogStyleError = validateDefaultHTTPHeaderLogStyleFunc(
+		getHeaderStyleFunctionFunc(
+			customization.DefaultHTTPHeaderLogStyle,
+			"DefaultHTTPHeaderLogStyle",
+		),
+		defaultHTTPHeaderLogStyle,
+	)
+	return settings,
+		[]settingError{
+			{"DefaultAllowedLogType", defaultAllowedLogTypeError},
+			{"DefaultAllowedLogLevel", defaultAllowedLogLevelError},
+			{"DefaultNetworkTimeout", defaultNetworkTimeoutError},
+			{"DefaultHTTPHeaderLogStyle", defaultHTTPHeaderLogStyleError},
+		}
+}
+
+func appendChange(
+	changes []model.Change,
+	name string,
+	previous interface{},
+	current interface{},
+) []model.Change {
+	var previousValue = fmtSprintf("%v", previous)
+	var currentValue = fmtSprintf("%v", current)
+	if previousValue == currentValue {
+		return changes
+	}
+	return append(
+		changes,
+		model.Change{
+			Name:     name,
+			Previous: previousValue,
+			Current:  currentValue,
+		},
+	)
+}
+
+func compareReloadableSettings(
+	previous *reloadableSettings,
+	current *reloadableSettings,
+) []model.Change {
+	var changes = []model.Change{}
+	changes = appendChangeFunc(
+		changes,
+		"DefaultAllowedLogType",
+		previous.allowedLogType(),
+		current.allowedLogType(),
+	)
+	changes = appendChangeFunc(
+		changes,
+		"DefaultAllowedLogLevel",
+		previous.allowedLogLevel(),
+		current.allowedLogLevel(),
+	)
+	changes = appendChangeFunc(
+		changes,
+		"DefaultNetworkTimeout",
+		previous.networkTimeout(),
+		current.networkTimeout(),
+	)
+	changes = appendChangeFunc(
+		changes,
+		"DefaultHTTPHeaderLogStyle",
+		previous.httpHeaderLogStyle(),
+		current.httpHeaderLogStyle(),
+	)
+	return changes
+}
+
+func currentDefaultAllowedLogType() logtype.LogType {
+	return getReloadableSettingsFunc().allowedLogType()
+}
+
+func currentDefaultAllowedLogLevel() loglevel.LogLevel {
+	return getReloadableSettingsFunc().allowedLogLevel()
+}
+
+func currentDefaultNetworkTimeout() time.Duration {
+	return getReloadableSettingsFunc().networkTimeout()
+}
+
+func currentDefaultHTTPHeaderLogStyle() headerstyle.HeaderStyle {
+	return getReloadableSettingsFunc().httpHeaderLogStyle()
+}
+
+// SourcesChanged returns true if any of the loaded configuration sources reports changes in its underlying storage since last load
+func SourcesChanged() bool {
+	for _, source := range configSources {
+		var watchableSource, ok = source.(model.WatchableSource)
+		if ok && watchableSource.Changed() {
+			return true
+		}
+	}
+	return false
+}
+
+// Reload re-loads all configuration sources and re-validates the hot-reloadable configs (DefaultAllowedLogType, DefaultAllowedLogLevel, DefaultNetworkTimeout and DefaultHTTPHeaderLogStyle), swapping them in place atomically; returns the changes applied, or nothing is changed if any configuration source fails to load
+func Reload() ([]model.Change, error) {
+	var loadSourcesError = loadSourcesFunc()
+	if loadSourcesError != nil {
+		return nil, loadSourcesError
+	}
+	var previousSettings = getReloadableSettingsFunc()
+	var currentSettings, validationErrors = resolveReloadableSettingsFunc()
+	reloadableStore.Store(currentSettings)
+	var changes = compareReloadableSettingsFunc(
+		previousSettings,
+		currentSettings,
+	)
+	return changes,
+		apperrorWrapSimpleError(
+			getSettingErrorsFunc(validationErrors),
+			"Unexpected errors occur during configuration reload",
+		)
+}
@@ -0,0 +1,624 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorEnum "github.com/zhongjie-cai/WebServiceTemplate/apperror/enum"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/config/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/headerutil/headerstyle"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
+)
+
+type dummyWatchableSource struct {
+	dummySource
+	changed       bool
+	changedCalled int
+}
+
+func (source *dummyWatchableSource) Changed() bool {
+	source.changedCalled++
+	return source.changed
+}
+
+func TestDefaultHTTPHeaderLogStyle(t *testing.T) {
+	// arrange
+	var expectedResult = headerstyle.DoNotLog
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = defaultHTTPHeaderLogStyle()
+
+	// assert
+	assert.Equal(t, expectedResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestValidateDefaultHTTPHeaderLogStyle_NilFunc(t *testing.T) {
+	// arrange
+	var dummyDefaultFuncExpected int
+	var dummyDefaultFuncCalled int
+	var dummyDefaultFunc func() headerstyle.HeaderStyle
+	var dummyHeaderStyle = headerstyle.HeaderStyle(rand.Intn(4))
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	dummyDefaultFuncExpected = 1
+	dummyDefaultFunc = func() headerstyle.HeaderStyle {
+		dummyDefaultFuncCalled++
+		return dummyHeaderStyle
+	}
+	apperrorGetCustomErrorExpected = 1
+	apperrorGetCustomError = func(errorCode apperrorEnum.Code, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
+		apperrorGetCustomErrorCalled++
+		assert.Equal(t, apperrorEnum.CodeGeneralFailure, errorCode)
+		assert.Equal(t, "customization.DefaultHTTPHeaderLogStyle function is not configured; fallback to default [%v].", messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, dummyHeaderStyle, parameters[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var result, err = validateDefaultHTTPHeaderLogStyle(
+		nil,
+		dummyDefaultFunc,
+	)
+
+	// assert
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyDefaultFunc)), fmt.Sprintf("%v", reflect.ValueOf(result)))
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyDefaultFuncExpected, dummyDefaultFuncCalled, "Unexpected number of calls to dummyDefaultFunc")
+}
+
+func TestValidateDefaultHTTPHeaderLogStyle_ValidFunc(t *testing.T) {
+	// arrange
+	var dummyCustomizedFunc = func() headerstyle.HeaderStyle { return headerstyle.LogPerValue }
+	var dummyDefaultFunc = func() headerstyle.HeaderStyle { return headerstyle.DoNotLog }
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, err = validateDefaultHTTPHeaderLogStyle(
+		dummyCustomizedFunc,
+		dummyDefaultFunc,
+	)
+
+	// assert
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyCustomizedFunc)), fmt.Sprintf("%v", reflect.ValueOf(result)))
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetReloadableSettings_NotStored(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getReloadableSettings()
+
+	// assert
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(defaultAllowedLogType)), fmt.Sprintf("%v", reflect.ValueOf(result.allowedLogType)))
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(defaultAllowedLogLevel)), fmt.Sprintf("%v", reflect.ValueOf(result.allowedLogLevel)))
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(defaultNetworkTimeout)), fmt.Sprintf("%v", reflect.ValueOf(result.networkTimeout)))
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(defaultHTTPHeaderLogStyle)), fmt.Sprintf("%v", reflect.ValueOf(result.httpHeaderLogStyle)))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetReloadableSettings_Stored(t *testing.T) {
+	// arrange
+	var dummySettings = &reloadableSettings{}
+
+	// stub
+	reloadableStore.Store(dummySettings)
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getReloadableSettings()
+
+	// assert
+	assert.Equal(t, dummySettings, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestResolveReloadableSettings(t *testing.T) {
+	// arrange
+	var dummyLogTypeFunc = func() logtype.LogType { return logtype.FullLogging }
+	var dummyLogLevelFunc = func() loglevel.LogLevel { return loglevel.Error }
+	var dummyNetworkTimeoutFunc = func() time.Duration { return time.Second }
+	var dummyHeaderLogStyleFunc = func() headerstyle.HeaderStyle { return headerstyle.LogCombined }
+	var dummyLogTypeError = errors.New("some log type error")
+	var dummyLogLevelError = errors.New("some log level error")
+	var dummyNetworkTimeoutError = errors.New("some network timeout error")
+	var dummyNetworkTimeoutParseError = errors.New("some network timeout parse error")
+	var dummyMergedNetworkTimeoutError = errors.New("some merged network timeout error")
+	var dummyHeaderLogStyleError = errors.New("some header log style error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	getLogTypeFunctionFuncExpected = 1
+	getLogTypeFunctionFunc = func(customizedFunc func() logtype.LogType, name string) func() logtype.LogType {
+		getLogTypeFunctionFuncCalled++
+		assert.Nil(t, customizedFunc)
+		assert.Equal(t, "DefaultAllowedLogType", name)
+		return dummyLogTypeFunc
+	}
+	validateDefaultAllowedLogTypeFuncExpected = 1
+	validateDefaultAllowedLogTypeFunc = func(customizedFunc func() logtype.LogType, defaultFunc func() logtype.LogType) (func() logtype.LogType, error) {
+		validateDefaultAllowedLogTypeFuncCalled++
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyLogTypeFunc)), fmt.Sprintf("%v", reflect.ValueOf(customizedFunc)))
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(defaultAllowedLogType)), fmt.Sprintf("%v", reflect.ValueOf(defaultFunc)))
+		return customizedFunc, dummyLogTypeError
+	}
+	getLogLevelFunctionFuncExpected = 1
+	getLogLevelFunctionFunc = func(customizedFunc func() loglevel.LogLevel, name string) func() loglevel.LogLevel {
+		getLogLevelFunctionFuncCalled++
+		assert.Nil(t, customizedFunc)
+		assert.Equal(t, "DefaultAllowedLogLevel", name)
+		return dummyLogLevelFunc
+	}
+	validateDefaultAllowedLogLevelFuncExpected = 1
+	validateDefaultAllowedLogLevelFunc = func(customizedFunc func() loglevel.LogLevel, defaultFunc func() loglevel.LogLevel) (func() loglevel.LogLevel, error) {
+		validateDefaultAllowedLogLevelFuncCalled++
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyLogLevelFunc)), fmt.Sprintf("%v", reflect.ValueOf(customizedFunc)))
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(defaultAllowedLogLevel)), fmt.Sprintf("%v", reflect.ValueOf(defaultFunc)))
+		return customizedFunc, dummyLogLevelError
+	}
+	getDurationFunctionFuncExpected = 1
+	getDurationFunctionFunc = func(customizedFunc func() time.Duration, name string) (func() time.Duration, error) {
+		getDurationFunctionFuncCalled++
+		assert.Nil(t, customizedFunc)
+		assert.Equal(t, "DefaultNetworkTimeout", name)
+		return dummyNetworkTimeoutFunc, dummyNetworkTimeoutParseError
+	}
+	validateDefaultNetworkTimeoutFuncExpected = 1
+	validateDefaultNetworkTimeoutFunc = func(customizedFunc func() time.Duration, defaultFunc func() time.Duration) (func() time.Duration, error) {
+		validateDefaultNetworkTimeoutFuncCalled++
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyNetworkTimeoutFunc)), fmt.Sprintf("%v", reflect.ValueOf(customizedFunc)))
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(defaultNetworkTimeout)), fmt.Sprintf("%v", reflect.ValueOf(defaultFunc)))
+		return customizedFunc, dummyNetworkTimeoutError
+	}
+	mergeSettingErrorFuncExpected = 1
+	mergeSettingErrorFunc = func(parseError error, validationError error) error {
+		mergeSettingErrorFuncCalled++
+		assert.Equal(t, dummyNetworkTimeoutParseError, parseError)
+		assert.Equal(t, dummyNetworkTimeoutError, validationError)
+		return dummyMergedNetworkTimeoutError
+	}
+	getHeaderStyleFunctionFuncExpected = 1
+	getHeaderStyleFunctionFunc = func(customizedFunc func() headerstyle.HeaderStyle, name string) func() headerstyle.HeaderStyle {
+		getHeaderStyleFunctionFuncCalled++
+		assert.Nil(t, customizedFunc)
+		assert.Equal(t, "DefaultHTTPHeaderLogStyle", name)
+		return dummyHeaderLogStyleFunc
+	}
+	validateDefaultHTTPHeaderLogStyleFuncExpected = 1
+	validateDefaultHTTPHeaderLogStyleFunc = func(customizedFunc func() headerstyle.HeaderStyle, defaultFunc func() headerstyle.HeaderStyle) (func() headerstyle.HeaderStyle, error) {
+		validateDefaultHTTPHeaderLogStyleFuncCalled++
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyHeaderLogStyleFunc)), fmt.Sprintf("%v", reflect.ValueOf(customizedFunc)))
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(defaultHTTPHeaderLogStyle)), fmt.Sprintf("%v", reflect.ValueOf(defaultFunc)))
+		return customizedFunc, dummyHeaderLogStyleError
+	}
+
+	// SUT + act
+	var result, errs = resolveReloadableSettings()
+
+	// assert
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyLogTypeFunc)), fmt.Sprintf("%v", reflect.ValueOf(result.allowedLogType)))
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyLogLevelFunc)), fmt.Sprintf("%v", reflect.ValueOf(result.allowedLogLevel)))
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyNetworkTimeoutFunc)), fmt.Sprintf("%v", reflect.ValueOf(result.networkTimeout)))
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyHeaderLogStyleFunc)), fmt.Sprintf("%v", reflect.ValueOf(result.httpHeaderLogStyle)))
+	assert.Equal(t, []settingError{
+		{"DefaultAllowedLogType", dummyLogTypeError},
+		{"DefaultAllowedLogLevel", dummyLogLevelError},
+		{"DefaultNetworkTimeout", dummyMergedNetworkTimeoutError},
+		{"DefaultHTTPHeaderLogStyle", dummyHeaderLogStyleError},
+	}, errs)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAppendChange_NotChanged(t *testing.T) {
+	// arrange
+	var dummyChanges = []model.Change{
+		{Name: "some name"},
+	}
+	var dummyName = "some other name"
+	var dummyPrevious = rand.Int()
+	var dummyCurrent = dummyPrevious
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintfExpected = 2
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		return fmt.Sprintf(format, a...)
+	}
+
+	// SUT + act
+	var result = appendChange(
+		dummyChanges,
+		dummyName,
+		dummyPrevious,
+		dummyCurrent,
+	)
+
+	// assert
+	assert.Equal(t, dummyChanges, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAppendChange_Changed(t *testing.T) {
+	// arrange
+	var dummyChanges = []model.Change{
+		{Name: "some name"},
+	}
+	var dummyName = "some other name"
+	var dummyPrevious = loglevel.Warn
+	var dummyCurrent = loglevel.Debug
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintfExpected = 2
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		return fmt.Sprintf(format, a...)
+	}
+
+	// SUT + act
+	var result = appendChange(
+		dummyChanges,
+		dummyName,
+		dummyPrevious,
+		dummyCurrent,
+	)
+
+	// assert
+	assert.Equal(t, 2, len(result))
+	assert.Equal(t, dummyChanges[0], result[0])
+	assert.Equal(t, dummyName, result[1].Name)
+	assert.Equal(t, "Warn", result[1].Previous)
+	assert.Equal(t, "Debug", result[1].Current)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCompareReloadableSettings(t *testing.T) {
+	// arrange
+	var previous = &reloadableSettings{
+		allowedLogType:     func() logtype.LogType { return logtype.BasicLogging },
+		allowedLogLevel:    func() loglevel.LogLevel { return loglevel.Warn },
+		networkTimeout:     func() time.Duration { return time.Minute },
+		httpHeaderLogStyle: func() headerstyle.HeaderStyle { return headerstyle.DoNotLog },
+	}
+	var current = &reloadableSettings{
+		allowedLogType:     func() logtype.LogType { return logtype.FullLogging },
+		allowedLogLevel:    func() loglevel.LogLevel { return loglevel.Debug },
+		networkTimeout:     func() time.Duration { return time.Second },
+		httpHeaderLogStyle: func() headerstyle.HeaderStyle { return headerstyle.LogCombined },
+	}
+	var expectedNames = []string{
+		"DefaultAllowedLogType",
+		"DefaultAllowedLogLevel",
+		"DefaultNetworkTimeout",
+		"DefaultHTTPHeaderLogStyle",
+	}
+	var expectedPrevious = []interface{}{
+		logtype.BasicLogging,
+		loglevel.Warn,
+		time.Minute,
+		headerstyle.DoNotLog,
+	}
+	var expectedCurrent = []interface{}{
+		logtype.FullLogging,
+		loglevel.Debug,
+		time.Second,
+		headerstyle.LogCombined,
+	}
+	var dummyChange = model.Change{Name: "some name"}
+
+	// mock
+	createMock(t)
+
+	// expect
+	appendChangeFuncExpected = 4
+	appendChangeFunc = func(changes []model.Change, name string, previous interface{}, current interface{}) []model.Change {
+		appendChangeFuncCalled++
+		assert.Equal(t, appendChangeFuncCalled-1, len(changes))
+		assert.Equal(t, expectedNames[appendChangeFuncCalled-1], name)
+		assert.Equal(t, expectedPrevious[appendChangeFuncCalled-1], previous)
+		assert.Equal(t, expectedCurrent[appendChangeFuncCalled-1], current)
+		return append(changes, dummyChange)
+	}
+
+	// SUT + act
+	var result = compareReloadableSettings(
+		previous,
+		current,
+	)
+
+	// assert
+	assert.Equal(t, []model.Change{dummyChange, dummyChange, dummyChange, dummyChange}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCurrentDefaultAllowedLogType(t *testing.T) {
+	// arrange
+	var dummyLogType = logtype.LogType(rand.Int())
+
+	// mock
+	createMock(t)
+
+	// expect
+	getReloadableSettingsFuncExpected = 1
+	getReloadableSettingsFunc = func() *reloadableSettings {
+		getReloadableSettingsFuncCalled++
+		return &reloadableSettings{
+			allowedLogType: func() logtype.LogType { return dummyLogType },
+		}
+	}
+
+	// SUT + act
+	var result = currentDefaultAllowedLogType()
+
+	// assert
+	assert.Equal(t, dummyLogType, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCurrentDefaultAllowedLogLevel(t *testing.T) {
+	// arrange
+	var dummyLogLevel = loglevel.LogLevel(rand.Int())
+
+	// mock
+	createMock(t)
+
+	// expect
+	getReloadableSettingsFuncExpected = 1
+	getReloadableSettingsFunc = func() *reloadableSettings {
+		getReloadableSettingsFuncCalled++
+		return &reloadableSettings{
+			allowedLogLevel: func() loglevel.LogLevel { return dummyLogLevel },
+		}
+	}
+
+	// SUT + act
+	var result = currentDefaultAllowedLogLevel()
+
+	// assert
+	assert.Equal(t, dummyLogLevel, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCurrentDefaultNetworkTimeout(t *testing.T) {
+	// arrange
+	var dummyNetworkTimeout = time.Duration(rand.Int())
+
+	// mock
+	createMock(t)
+
+	// expect
+	getReloadableSettingsFuncExpected = 1
+	getReloadableSettingsFunc = func() *reloadableSettings {
+		getReloadableSettingsFuncCalled++
+		return &reloadableSettings{
+			networkTimeout: func() time.Duration { return dummyNetworkTimeout },
+		}
+	}
+
+	// SUT + act
+	var result = currentDefaultNetworkTimeout()
+
+	// assert
+	assert.Equal(t, dummyNetworkTimeout, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCurrentDefaultHTTPHeaderLogStyle(t *testing.T) {
+	// arrange
+	var dummyHeaderLogStyle = headerstyle.HeaderStyle(rand.Int())
+
+	// mock
+	createMock(t)
+
+	// expect
+	getReloadableSettingsFuncExpected = 1
+	getReloadableSettingsFunc = func() *reloadableSettings {
+		getReloadableSettingsFuncCalled++
+		return &reloadableSettings{
+			httpHeaderLogStyle: func() headerstyle.HeaderStyle { return dummyHeaderLogStyle },
+		}
+	}
+
+	// SUT + act
+	var result = currentDefaultHTTPHeaderLogStyle()
+
+	// assert
+	assert.Equal(t, dummyHeaderLogStyle, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSourcesChanged_NoChanges(t *testing.T) {
+	// arrange
+	var dummySource1 = &dummySource{}
+	var dummySource2 = &dummyWatchableSource{changed: false}
+
+	// stub
+	configSources = []model.Source{dummySource1, dummySource2}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = SourcesChanged()
+
+	// assert
+	assert.False(t, result)
+	assert.Equal(t, 1, dummySource2.changedCalled)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSourcesChanged_Changed(t *testing.T) {
+	// arrange
+	var dummySource1 = &dummyWatchableSource{changed: true}
+	var dummySource2 = &dummyWatchableSource{changed: true}
+
+	// stub
+	configSources = []model.Source{dummySource1, dummySource2}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = SourcesChanged()
+
+	// assert
+	assert.True(t, result)
+	assert.Equal(t, 1, dummySource1.changedCalled)
+	assert.Equal(t, 0, dummySource2.changedCalled)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestReload_LoadSourcesError(t *testing.T) {
+	// arrange
+	var dummyLoadSourcesError = errors.New("some load sources error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	loadSourcesFuncExpected = 1
+	loadSourcesFunc = func() error {
+		loadSourcesFuncCalled++
+		return dummyLoadSourcesError
+	}
+
+	// SUT + act
+	var result, err = Reload()
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyLoadSourcesError, err)
+	assert.Nil(t, reloadableStore.Load())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestReload_Success(t *testing.T) {
+	// arrange
+	var dummyPreviousSettings = &reloadableSettings{}
+	var dummyCurrentSettings = &reloadableSettings{}
+	var dummySettingErrors = []settingError{
+		{"some name", errors.New("some validation error")},
+	}
+	var dummyValidationErrors = []error{
+		errors.New("some validation error"),
+	}
+	var dummyChanges = []model.Change{
+		{Name: "some name"},
+	}
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	loadSourcesFuncExpected = 1
+	loadSourcesFunc = func() error {
+		loadSourcesFuncCalled++
+		return nil
+	}
+	getReloadableSettingsFuncExpected = 1
+	getReloadableSettingsFunc = func() *reloadableSettings {
+		getReloadableSettingsFuncCalled++
+		return dummyPreviousSettings
+	}
+	resolveReloadableSettingsFuncExpected = 1
+	resolveReloadableSettingsFunc = func() (*reloadableSettings, []settingError) {
+		resolveReloadableSettingsFuncCalled++
+		return dummyCurrentSettings, dummySettingErrors
+	}
+	getSettingErrorsFuncExpected = 1
+	getSettingErrorsFunc = func(settingErrors []settingError) []error {
+		getSettingErrorsFuncCalled++
+		assert.Equal(t, dummySettingErrors, settingErrors)
+		return dummyValidationErrors
+	}
+	compareReloadableSettingsFuncExpected = 1
+	compareReloadableSettingsFunc = func(previous *reloadableSettings, current *reloadableSettings) []model.Change {
+		compareReloadableSettingsFuncCalled++
+		assert.Equal(t, dummyPreviousSettings, previous)
+		assert.Equal(t, dummyCurrentSettings, current)
+		return dummyChanges
+	}
+	apperrorWrapSimpleErrorExpected = 1
+	apperrorWrapSimpleError = func(innerErrors []error, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
+		apperrorWrapSimpleErrorCalled++
+		assert.Equal(t, dummyValidationErrors, innerErrors)
+		assert.Equal(t, "Unexpected errors occur during configuration reload", messageFormat)
+		assert.Empty(t, parameters)
+		return dummyAppError
+	}
+
+	// SUT + act
+	var result, err = Reload()
+
+	// assert
+	assert.Equal(t, dummyChanges, result)
+	assert.Equal(t, dummyAppError, err)
+	assert.Equal(t, dummyCurrentSettings, reloadableStore.Load())
+
+	// verify
+	verifyAll(t)
+}
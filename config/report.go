@@ -0,0 +1,143 @@
+package config
+
+import (
+	apperrorEnum "github.com/zhongjie-cai/WebServiceTemplate/apperror/enum"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/config/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+)
+
+// These are the names of the pseudo sources reported for configs not resolved from configuration sources
+const (
+	CustomizationSourceName = "Customization"
+	DefaultSourceName       = "Default"
+)
+
+type strictValidationError struct {
+	apperrorModel.AppError
+}
+
+// settingError pairs an application config with the error of its resolution, if any
+type settingError struct {
+	name string
+	err  error
+}
+
+var (
+	settingsReport []model.Setting
+)
+
+func getCustomizedSettings() map[string]bool {
+	return map[string]bool{
+		"AppVersion":                 customization.AppVersion != nil,
+		"AppPort":                    customization.AppPort != nil,
+		"AppName":                    customization.AppName != nil,
+		"AppPath":                    customization.AppPath != nil,
+		"IsLocalhost":                customization.IsLocalhost != nil,
+		"ServerCertContent":          customization.ServerCertContent != nil,
+		"ServerKeyContent":           customization.ServerKeyContent != nil,
+		"ServeHTTPS":                 customization.ServeHTTPS != nil,
+		"CaCertContent":              customization.CaCertContent != nil,
+		"ValidateClientCert":         customization.ValidateClientCert != nil,
+		"ClientCertContent":          customization.ClientCertContent != nil,
+		"ClientKeyContent":           customization.ClientKeyContent != nil,
+		"SkipServerCertVerification": customization.SkipServerCertVerification != nil,
+		"GraceShutdownWaitTime":      customization.GraceShutdownWaitTime != nil,
+		"DefaultAllowedLogType":      customization.DefaultAllowedLogType != nil,
+		"DefaultAllowedLogLevel":     customization.DefaultAllowedLogLevel != nil,
+		"DefaultNetworkTimeout":      customization.DefaultNetworkTimeout != nil,
+		"DefaultHTTPHeaderLogStyle":  customization.DefaultHTTPHeaderLogStyle != nil,
+	}
+}
+
+func getSettingSource(name string, customized bool) string {
+	if customized {
+		return CustomizationSourceName
+	}
+	var sourceName, found = lookupSourceNameFunc(name)
+	if found {
+		return sourceName
+	}
+	return DefaultSourceName
+}
+
+func getSettingErrors(settingErrors []settingError) []error {
+	var errs = []error{}
+	for _, entry := range settingErrors {
+		errs = append(
+			errs,
+			entry.err,
+		)
+	}
+	return errs
+}
+
+func generateSettingsReport(settingErrors []settingError) []model.Setting {
+	var customizedSettings = getCustomizedSettingsFunc()
+	var report = []model.Setting{}
+	for _, entry := range settingErrors {
+		report = append(
+			report,
+			model.Setting{
+				Name:      entry.name,
+				Source:    getSettingSourceFunc(entry.name, customizedSettings[entry.name]),
+				Defaulted: entry.err != nil,
+			},
+		)
+	}
+	return report
+}
+
+func validateRequiredSettings(report []model.Setting) error {
+	if customization.RequiredConfigs == nil {
+		return nil
+	}
+	var settings = map[string]model.Setting{}
+	for _, setting := range report {
+		settings[setting.Name] = setting
+	}
+	var requiredErrors = []error{}
+	for _, name := range customization.RequiredConfigs() {
+		var setting, found = settings[name]
+		if !found {
+			requiredErrors = append(
+				requiredErrors,
+				apperrorGetCustomError(
+					apperrorEnum.CodeGeneralFailure,
+					"Required config [%v] is not a known application config",
+					name,
+				),
+			)
+		} else if setting.Defaulted {
+			requiredErrors = append(
+				requiredErrors,
+				apperrorGetCustomError(
+					apperrorEnum.CodeGeneralFailure,
+					"Required config [%v] is not configured properly and falls back to default from [%v]",
+					name,
+					setting.Source,
+				),
+			)
+		}
+	}
+	if len(requiredErrors) == 0 {
+		return nil
+	}
+	return &strictValidationError{
+		apperrorWrapSimpleError(
+			requiredErrors,
+			"Strict configuration validation failed for required configs",
+		),
+	}
+}
+
+// IsStrictValidationError returns true if the given error, returned from Initialize, is caused by strict configuration validation failure upon required configs, which should stop the application from starting
+func IsStrictValidationError(err error) bool {
+	var _, ok = err.(*strictValidationError)
+	return ok
+}
+
+// Report returns the resolution report of all application configs from last Initialize, listing each config with its source and whether it falls back to default
+func Report() []model.Setting {
+	return settingsReport
+}
@@ -0,0 +1,326 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorEnum "github.com/zhongjie-cai/WebServiceTemplate/apperror/enum"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/config/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+)
+
+func TestGetCustomizedSettings(t *testing.T) {
+	// stub
+	customization.AppPort = func() string { return "some port" }
+	customization.DefaultNetworkTimeout = func() time.Duration { return time.Second }
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getCustomizedSettings()
+
+	// assert
+	assert.Equal(t, 18, len(result))
+	for name, customized := range result {
+		var expected = name == "AppPort" || name == "DefaultNetworkTimeout"
+		assert.Equal(t, expected, customized, name)
+	}
+
+	// verify
+	verifyAll(t)
+	customization.AppPort = nil
+	customization.DefaultNetworkTimeout = nil
+}
+
+func TestGetSettingSource_Customized(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getSettingSource(dummyName, true)
+
+	// assert
+	assert.Equal(t, CustomizationSourceName, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSettingSource_NotFound(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourceNameFuncExpected = 1
+	lookupSourceNameFunc = func(name string) (string, bool) {
+		lookupSourceNameFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return "", false
+	}
+
+	// SUT + act
+	var result = getSettingSource(dummyName, false)
+
+	// assert
+	assert.Equal(t, DefaultSourceName, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSettingSource_Found(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummySourceName = "some source name"
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourceNameFuncExpected = 1
+	lookupSourceNameFunc = func(name string) (string, bool) {
+		lookupSourceNameFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return dummySourceName, true
+	}
+
+	// SUT + act
+	var result = getSettingSource(dummyName, false)
+
+	// assert
+	assert.Equal(t, dummySourceName, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSettingErrors(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var dummySettingErrors = []settingError{
+		{"some name 1", nil},
+		{"some name 2", dummyError},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getSettingErrors(
+		dummySettingErrors,
+	)
+
+	// assert
+	assert.Equal(t, []error{nil, dummyError}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGenerateSettingsReport(t *testing.T) {
+	// arrange
+	var dummySettingErrors = []settingError{
+		{"some name 1", nil},
+		{"some name 2", errors.New("some validation error")},
+		{"some name 3", nil},
+	}
+	var dummyCustomizedSettings = map[string]bool{
+		"some name 1": true,
+	}
+	var expectedCustomized = []bool{
+		true,
+		false,
+		false,
+	}
+	var expectedReport = []model.Setting{
+		{Name: "some name 1", Source: "some source 1", Defaulted: false},
+		{Name: "some name 2", Source: "some source 2", Defaulted: true},
+		{Name: "some name 3", Source: "some source 3", Defaulted: false},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getCustomizedSettingsFuncExpected = 1
+	getCustomizedSettingsFunc = func() map[string]bool {
+		getCustomizedSettingsFuncCalled++
+		return dummyCustomizedSettings
+	}
+	getSettingSourceFuncExpected = 3
+	getSettingSourceFunc = func(name string, customized bool) string {
+		getSettingSourceFuncCalled++
+		assert.Equal(t, dummySettingErrors[getSettingSourceFuncCalled-1].name, name)
+		assert.Equal(t, expectedCustomized[getSettingSourceFuncCalled-1], customized)
+		return expectedReport[getSettingSourceFuncCalled-1].Source
+	}
+
+	// SUT + act
+	var result = generateSettingsReport(
+		dummySettingErrors,
+	)
+
+	// assert
+	assert.Equal(t, expectedReport, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestValidateRequiredSettings_NotStrict(t *testing.T) {
+	// arrange
+	var dummyReport = []model.Setting{
+		{Name: "some name", Source: DefaultSourceName, Defaulted: true},
+	}
+
+	// stub
+	customization.RequiredConfigs = nil
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err = validateRequiredSettings(dummyReport)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestValidateRequiredSettings_AllConfigured(t *testing.T) {
+	// arrange
+	var dummyReport = []model.Setting{
+		{Name: "some name 1", Source: "some source", Defaulted: false},
+		{Name: "some name 2", Source: DefaultSourceName, Defaulted: true},
+	}
+
+	// stub
+	customization.RequiredConfigs = func() []string {
+		return []string{"some name 1"}
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err = validateRequiredSettings(dummyReport)
+
+	// assert
+	assert.NoError(t, err)
+	assert.False(t, IsStrictValidationError(err))
+
+	// verify
+	verifyAll(t)
+	customization.RequiredConfigs = nil
+}
+
+func TestValidateRequiredSettings_Failed(t *testing.T) {
+	// arrange
+	var dummyReport = []model.Setting{
+		{Name: "some name 1", Source: "some source", Defaulted: false},
+		{Name: "some name 2", Source: DefaultSourceName, Defaulted: true},
+	}
+	var dummyUnknownError = apperror.GetCustomError(0, "some unknown error")
+	var dummyDefaultedError = apperror.GetCustomError(0, "some defaulted error")
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// stub
+	customization.RequiredConfigs = func() []string {
+		return []string{
+			"some name 1",
+			"some name 2",
+			"some name 3",
+		}
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	apperrorGetCustomErrorExpected = 2
+	apperrorGetCustomError = func(errorCode apperrorEnum.Code, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
+		apperrorGetCustomErrorCalled++
+		assert.Equal(t, apperrorEnum.CodeGeneralFailure, errorCode)
+		if apperrorGetCustomErrorCalled == 1 {
+			assert.Equal(t, "Required config [%v] is not configured properly and falls back to default from [%v]", messageFormat)
+			assert.Equal(t, 2, len(parameters))
+			assert.Equal(t, "some name 2", parameters[0])
+			assert.Equal(t, DefaultSourceName, parameters[1])
+			return dummyDefaultedError
+		}
+		assert.Equal(t, "Required config [%v] is not a known application config", messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, "some name 3", parameters[0])
+		return dummyUnknownError
+	}
+	apperrorWrapSimpleErrorExpected = 1
+	apperrorWrapSimpleError = func(innerErrors []error, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
+		apperrorWrapSimpleErrorCalled++
+		assert.Equal(t, []error{dummyDefaultedError, dummyUnknownError}, innerErrors)
+		assert.Equal(t, "Strict configuration validation failed for required configs", messageFormat)
+		assert.Empty(t, parameters)
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = validateRequiredSettings(dummyReport)
+
+	// assert
+	assert.Equal(t, &strictValidationError{dummyAppError}, err)
+	assert.True(t, IsStrictValidationError(err))
+
+	// verify
+	verifyAll(t)
+	customization.RequiredConfigs = nil
+}
+
+func TestIsStrictValidationError(t *testing.T) {
+	// arrange
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// mock
+	createMock(t)
+
+	// SUT + act + assert
+	assert.False(t, IsStrictValidationError(nil))
+	assert.False(t, IsStrictValidationError(dummyAppError))
+	assert.True(t, IsStrictValidationError(&strictValidationError{dummyAppError}))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestReport(t *testing.T) {
+	// arrange
+	var dummyReport = []model.Setting{
+		{Name: "some name", Source: "some source", Defaulted: true},
+	}
+
+	// stub
+	settingsReport = dummyReport
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = Report()
+
+	// assert
+	assert.Equal(t, dummyReport, result)
+
+	// verify
+	verifyAll(t)
+}
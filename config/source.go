@@ -0,0 +1,447 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/config/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/headerutil/headerstyle"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
+)
+
+// These are the names of the built-in configuration sources
+const (
+	EnvironmentSourceName = "Environment"
+	DotEnvSourceName      = "DotEnv"
+	JSONFileSourceName    = "JSONFile"
+	YAMLFileSourceName    = "YAMLFile"
+)
+
+var (
+	configSources []model.Source
+)
+
+func toEnvironmentName(name string) string {
+	var runes = []rune(name)
+	var result []rune
+	for index, current := range runes {
+		if index > 0 && unicode.IsUpper(current) {
+			var previous = runes[index-1]
+			var hasNext = index+1 < len(runes)
+			if unicode.IsLower(previous) ||
+				unicode.IsDigit(previous) ||
+				(unicode.IsUpper(previous) && hasNext && unicode.IsLower(runes[index+1])) {
+				result = append(result, '_')
+			}
+		}
+		result = append(result, unicode.ToUpper(current))
+	}
+	return string(result)
+}
+
+func trimQuotes(value string) string {
+	if len(value) >= 2 {
+		var first, last = value[0], value[len(value)-1]
+		if (first == '"' && last == '"') ||
+			(first == '\'' && last == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+type environmentSource struct {
+	prefix string
+}
+
+func (source *environmentSource) Name() string {
+	return EnvironmentSourceName
+}
+
+func (source *environmentSource) Load() error {
+	return nil
+}
+
+func (source *environmentSource) Lookup(name string) (string, bool) {
+	return osLookupEnv(
+		source.prefix + toEnvironmentNameFunc(name),
+	)
+}
+
+// NewEnvironmentSource creates a configuration source backed by system environment variables; setting names are looked up in upper snake case with given prefix, e.g. AppPort => {prefix}APP_PORT
+func NewEnvironmentSource(prefix string) model.Source {
+	return &environmentSource{
+		prefix,
+	}
+}
+
+type fileSource struct {
+	name      string
+	path      string
+	prefix    string
+	parseFunc func(content []byte) (map[string]string, error)
+	keyFunc   func(name string) string
+	values    map[string]string
+	modTime   time.Time
+}
+
+func getModTime(path string) time.Time {
+	var fileInfo, statError = osStat(path)
+	if statError != nil {
+		return time.Time{}
+	}
+	return fileInfo.ModTime()
+}
+
+func (source *fileSource) Name() string {
+	return fmtSprintf(
+		"%v[%v]",
+		source.name,
+		source.path,
+	)
+}
+
+func (source *fileSource) Load() error {
+	var content, readError = ioutilReadFile(
+		source.path,
+	)
+	if readError != nil {
+		return apperrorWrapSimpleError(
+			[]error{readError},
+			"Failed to read configuration file [%v]",
+			source.path,
+		)
+	}
+	var values, parseError = source.parseFunc(
+		content,
+	)
+	if parseError != nil {
+		return apperrorWrapSimpleError(
+			[]error{parseError},
+			"Failed to parse configuration file [%v]",
+			source.path,
+		)
+	}
+	source.values = values
+	source.modTime = getModTimeFunc(
+		source.path,
+	)
+	return nil
+}
+
+func (source *fileSource) Changed() bool {
+	return !getModTimeFunc(
+		source.path,
+	).Equal(
+		source.modTime,
+	)
+}
+
+func (source *fileSource) Lookup(name string) (string, bool) {
+	var key = name
+	if source.keyFunc != nil {
+		key = source.prefix + source.keyFunc(name)
+	}
+	var value, found = source.values[key]
+	return value, found
+}
+
+func parseDotEnvContent(content []byte) (map[string]string, error) {
+	var values = map[string]string{}
+	for index, line := range strings.Split(string(content), "\n") {
+		var trimmed = strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		trimmed = strings.TrimPrefix(trimmed, "export ")
+		var separator = strings.Index(trimmed, "=")
+		if separator <= 0 {
+			return nil,
+				fmtErrorf(
+					"Invalid entry at line %v: expecting KEY=VALUE",
+					index+1,
+				)
+		}
+		var key = strings.TrimSpace(trimmed[:separator])
+		var value = strings.TrimSpace(trimmed[separator+1:])
+		values[key] = trimQuotes(value)
+	}
+	return values, nil
+}
+
+func parseJSONContent(content []byte) (map[string]string, error) {
+	var rawValues map[string]interface{}
+	var unmarshalError = jsonUnmarshal(
+		content,
+		&rawValues,
+	)
+	if unmarshalError != nil {
+		return nil, unmarshalError
+	}
+	var values = map[string]string{}
+	for key, rawValue := range rawValues {
+		var stringValue, isString = rawValue.(string)
+		if isString {
+			values[key] = stringValue
+		} else {
+			values[key] = jsonutilMarshalIgnoreError(rawValue)
+		}
+	}
+	return values, nil
+}
+
+func parseYAMLContent(content []byte) (map[string]string, error) {
+	var values = map[string]string{}
+	for index, line := range strings.Split(string(content), "\n") {
+		var trimmed = strings.TrimSpace(line)
+		if trimmed == "" ||
+			trimmed == "---" ||
+			strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.TrimLeftFunc(line, unicode.IsSpace) != line {
+			return nil,
+				fmtErrorf(
+					"Invalid entry at line %v: only top level key-value pairs are supported",
+					index+1,
+				)
+		}
+		var separator = strings.Index(trimmed, ":")
+		if separator <= 0 {
+			return nil,
+				fmtErrorf(
+					"Invalid entry at line %v: expecting key: value",
+					index+1,
+				)
+		}
+		var key = strings.TrimSpace(trimmed[:separator])
+		var value = strings.TrimSpace(trimmed[separator+1:])
+		if !strings.HasPrefix(value, "\"") &&
+			!strings.HasPrefix(value, "'") {
+			var commentIndex = strings.Index(value, " #")
+			if commentIndex >= 0 {
+				value = strings.TrimSpace(value[:commentIndex])
+			}
+		}
+		values[key] = trimQuotes(value)
+	}
+	return values, nil
+}
+
+// NewDotEnvSource creates a configuration source backed by a .env file of KEY=VALUE lines; setting names are looked up in upper snake case with given prefix, e.g. AppPort => {prefix}APP_PORT
+func NewDotEnvSource(path string, prefix string) model.Source {
+	return &fileSource{
+		name:      DotEnvSourceName,
+		path:      path,
+		prefix:    prefix,
+		parseFunc: parseDotEnvContentFunc,
+		keyFunc:   toEnvironmentNameFunc,
+	}
+}
+
+// NewJSONFileSource creates a configuration source backed by a flat JSON object file; setting names are looked up as is, e.g. AppPort => "AppPort"
+func NewJSONFileSource(path string) model.Source {
+	return &fileSource{
+		name:      JSONFileSourceName,
+		path:      path,
+		parseFunc: parseJSONContentFunc,
+	}
+}
+
+// NewYAMLFileSource creates a configuration source backed by a flat (top level key-value pairs only) YAML file; setting names are looked up as is, e.g. AppPort => AppPort
+func NewYAMLFileSource(path string) model.Source {
+	return &fileSource{
+		name:      YAMLFileSourceName,
+		path:      path,
+		parseFunc: parseYAMLContentFunc,
+	}
+}
+
+func loadSources() error {
+	configSources = nil
+	if customization.ConfigSources == nil {
+		return nil
+	}
+	var sources = customization.ConfigSources()
+	var loadErrors = []error{}
+	for _, source := range sources {
+		if source == nil {
+			continue
+		}
+		var loadError = source.Load()
+		if loadError != nil {
+			loadErrors = append(
+				loadErrors,
+				loadError,
+			)
+		}
+		configSources = append(
+			configSources,
+			source,
+		)
+	}
+	return apperrorWrapSimpleError(
+		loadErrors,
+		"Failed to load one or more configuration sources",
+	)
+}
+
+func lookupSources(name string) (string, bool) {
+	for _, source := range configSources {
+		var value, found = source.Lookup(name)
+		if found {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func lookupSourceName(name string) (string, bool) {
+	for _, source := range configSources {
+		var _, found = source.Lookup(name)
+		if found {
+			return source.Name(), true
+		}
+	}
+	return "", false
+}
+
+func getStringFunction(
+	customizedFunc func() string,
+	name string,
+) func() string {
+	if customizedFunc != nil {
+		return customizedFunc
+	}
+	var value, found = lookupSourcesFunc(name)
+	if !found {
+		return nil
+	}
+	return func() string {
+		return value
+	}
+}
+
+// getParseError describes the given failure of parsing the value of the named setting, along with the source it is resolved from
+func getParseError(name string, parseError error) error {
+	var sourceName, _ = lookupSourceNameFunc(name)
+	return apperrorWrapSimpleError(
+		[]error{parseError},
+		"Config [%v] from source [%v] is invalid and ignored",
+		name,
+		sourceName,
+	)
+}
+
+func getBooleanFunction(
+	customizedFunc func() bool,
+	name string,
+) (func() bool, error) {
+	if customizedFunc != nil {
+		return customizedFunc, nil
+	}
+	var value, found = lookupSourcesFunc(name)
+	if !found {
+		return nil, nil
+	}
+	var parsedValue, parseError = strconv.ParseBool(
+		strings.TrimSpace(value),
+	)
+	if parseError != nil {
+		return nil,
+			getParseErrorFunc(
+				name,
+				parseError,
+			)
+	}
+	return func() bool {
+		return parsedValue
+	}, nil
+}
+
+func getDurationFunction(
+	customizedFunc func() time.Duration,
+	name string,
+) (func() time.Duration, error) {
+	if customizedFunc != nil {
+		return customizedFunc, nil
+	}
+	var value, found = lookupSourcesFunc(name)
+	if !found {
+		return nil, nil
+	}
+	var parsedValue, parseError = time.ParseDuration(
+		strings.TrimSpace(value),
+	)
+	if parseError != nil {
+		return nil,
+			getParseErrorFunc(
+				name,
+				parseError,
+			)
+	}
+	return func() time.Duration {
+		return parsedValue
+	}, nil
+}
+
+func getLogTypeFunction(
+	customizedFunc func() logtype.LogType,
+	name string,
+) func() logtype.LogType {
+	if customizedFunc != nil {
+		return customizedFunc
+	}
+	var value, found = lookupSourcesFunc(name)
+	if !found {
+		return nil
+	}
+	var parsedValue = logtype.FromString(
+		strings.TrimSpace(value),
+	)
+	return func() logtype.LogType {
+		return parsedValue
+	}
+}
+
+func getLogLevelFunction(
+	customizedFunc func() loglevel.LogLevel,
+	name string,
+) func() loglevel.LogLevel {
+	if customizedFunc != nil {
+		return customizedFunc
+	}
+	var value, found = lookupSourcesFunc(name)
+	if !found {
+		return nil
+	}
+	var parsedValue = loglevel.FromString(
+		strings.TrimSpace(value),
+	)
+	return func() loglevel.LogLevel {
+		return parsedValue
+	}
+}
+
+func getHeaderStyleFunction(
+	customizedFunc func() headerstyle.HeaderStyle,
+	name string,
+) func() headerstyle.HeaderStyle {
+	if customizedFunc != nil {
+		return customizedFunc
+	}
+	var value, found = lookupSourcesFunc(name)
+	if !found {
+		return nil
+	}
+	var parsedValue = headerstyle.FromString(
+		strings.TrimSpace(value),
+	)
+	return func() headerstyle.HeaderStyle {
+		return parsedValue
+	}
+}
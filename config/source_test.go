@@ -0,0 +1,1492 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/config/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/headerutil/headerstyle"
+	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
+)
+
+type dummySource struct {
+	name        string
+	loadError   error
+	values      map[string]string
+	loadCalled  int
+	lookupNames []string
+}
+
+func (source *dummySource) Name() string {
+	return source.name
+}
+
+func (source *dummySource) Load() error {
+	source.loadCalled++
+	return source.loadError
+}
+
+func (source *dummySource) Lookup(name string) (string, bool) {
+	source.lookupNames = append(source.lookupNames, name)
+	var value, found = source.values[name]
+	return value, found
+}
+
+type dummyFileInfo struct {
+	os.FileInfo
+	modTime time.Time
+}
+
+func (fileInfo *dummyFileInfo) ModTime() time.Time {
+	return fileInfo.modTime
+}
+
+func TestToEnvironmentName(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act + assert
+	assert.Equal(t, "APP_PORT", toEnvironmentName("AppPort"))
+	assert.Equal(t, "SERVE_HTTPS", toEnvironmentName("ServeHTTPS"))
+	assert.Equal(t, "CA_CERT_CONTENT", toEnvironmentName("CaCertContent"))
+	assert.Equal(t, "DEFAULT_ALLOWED_LOG_TYPE", toEnvironmentName("DefaultAllowedLogType"))
+	assert.Equal(t, "HTTPS_PORT", toEnvironmentName("HTTPSPort"))
+	assert.Equal(t, "PORT2_NAME", toEnvironmentName("Port2Name"))
+	assert.Equal(t, "", toEnvironmentName(""))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestTrimQuotes(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act + assert
+	assert.Equal(t, "some value", trimQuotes("\"some value\""))
+	assert.Equal(t, "some value", trimQuotes("'some value'"))
+	assert.Equal(t, "\"some value'", trimQuotes("\"some value'"))
+	assert.Equal(t, "some value", trimQuotes("some value"))
+	assert.Equal(t, "\"", trimQuotes("\""))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEnvironmentSourceName(t *testing.T) {
+	// arrange
+	var sut = &environmentSource{}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = sut.Name()
+
+	// assert
+	assert.Equal(t, EnvironmentSourceName, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEnvironmentSourceLoad(t *testing.T) {
+	// arrange
+	var sut = &environmentSource{}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err = sut.Load()
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEnvironmentSourceLookup(t *testing.T) {
+	// arrange
+	var dummyPrefix = "SOME_PREFIX_"
+	var dummyName = "some name"
+	var dummyEnvironmentName = "SOME_NAME"
+	var dummyValue = "some value"
+	var dummyFound = rand.Intn(100) < 50
+	var sut = &environmentSource{
+		prefix: dummyPrefix,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	toEnvironmentNameFuncExpected = 1
+	toEnvironmentNameFunc = func(name string) string {
+		toEnvironmentNameFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return dummyEnvironmentName
+	}
+	osLookupEnvExpected = 1
+	osLookupEnv = func(key string) (string, bool) {
+		osLookupEnvCalled++
+		assert.Equal(t, dummyPrefix+dummyEnvironmentName, key)
+		return dummyValue, dummyFound
+	}
+
+	// SUT + act
+	var result, found = sut.Lookup(dummyName)
+
+	// assert
+	assert.Equal(t, dummyValue, result)
+	assert.Equal(t, dummyFound, found)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewEnvironmentSource(t *testing.T) {
+	// arrange
+	var dummyPrefix = "SOME_PREFIX_"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = NewEnvironmentSource(dummyPrefix)
+
+	// assert
+	var typedResult, ok = result.(*environmentSource)
+	assert.True(t, ok)
+	assert.Equal(t, dummyPrefix, typedResult.prefix)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetModTime_StatError(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	osStatExpected = 1
+	osStat = func(name string) (os.FileInfo, error) {
+		osStatCalled++
+		assert.Equal(t, dummyPath, name)
+		return nil, dummyError
+	}
+
+	// SUT + act
+	var result = getModTime(dummyPath)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetModTime_Success(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyModTime = time.Now()
+	var dummyFileInfo = &dummyFileInfo{modTime: dummyModTime}
+
+	// mock
+	createMock(t)
+
+	// expect
+	osStatExpected = 1
+	osStat = func(name string) (os.FileInfo, error) {
+		osStatCalled++
+		assert.Equal(t, dummyPath, name)
+		return dummyFileInfo, nil
+	}
+
+	// SUT + act
+	var result = getModTime(dummyPath)
+
+	// assert
+	assert.Equal(t, dummyModTime, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFileSourceName(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyPath = "some path"
+	var dummyResult = "some result"
+	var sut = &fileSource{
+		name: dummyName,
+		path: dummyPath,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		assert.Equal(t, "%v[%v]", format)
+		assert.Equal(t, 2, len(a))
+		assert.Equal(t, dummyName, a[0])
+		assert.Equal(t, dummyPath, a[1])
+		return dummyResult
+	}
+
+	// SUT + act
+	var result = sut.Name()
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFileSourceLoad_ReadError(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyReadError = errors.New("some read error")
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+	var parseFuncExpected = 0
+	var parseFuncCalled = 0
+	var sut = &fileSource{
+		path: dummyPath,
+		parseFunc: func(content []byte) (map[string]string, error) {
+			parseFuncCalled++
+			return nil, nil
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	ioutilReadFileExpected = 1
+	ioutilReadFile = func(filename string) ([]byte, error) {
+		ioutilReadFileCalled++
+		assert.Equal(t, dummyPath, filename)
+		return nil, dummyReadError
+	}
+	apperrorWrapSimpleErrorExpected = 1
+	apperrorWrapSimpleError = func(innerErrors []error, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
+		apperrorWrapSimpleErrorCalled++
+		assert.Equal(t, []error{dummyReadError}, innerErrors)
+		assert.Equal(t, "Failed to read configuration file [%v]", messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, dummyPath, parameters[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = sut.Load()
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+	assert.Nil(t, sut.values)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, parseFuncExpected, parseFuncCalled, "Unexpected number of calls to parseFunc")
+}
+
+func TestFileSourceLoad_ParseError(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyContent = []byte("some content")
+	var dummyParseError = errors.New("some parse error")
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+	var parseFuncExpected = 1
+	var parseFuncCalled = 0
+	var sut = &fileSource{
+		path: dummyPath,
+		parseFunc: func(content []byte) (map[string]string, error) {
+			parseFuncCalled++
+			assert.Equal(t, dummyContent, content)
+			return nil, dummyParseError
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	ioutilReadFileExpected = 1
+	ioutilReadFile = func(filename string) ([]byte, error) {
+		ioutilReadFileCalled++
+		assert.Equal(t, dummyPath, filename)
+		return dummyContent, nil
+	}
+	apperrorWrapSimpleErrorExpected = 1
+	apperrorWrapSimpleError = func(innerErrors []error, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
+		apperrorWrapSimpleErrorCalled++
+		assert.Equal(t, []error{dummyParseError}, innerErrors)
+		assert.Equal(t, "Failed to parse configuration file [%v]", messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, dummyPath, parameters[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = sut.Load()
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+	assert.Nil(t, sut.values)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, parseFuncExpected, parseFuncCalled, "Unexpected number of calls to parseFunc")
+}
+
+func TestFileSourceLoad_Success(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyContent = []byte("some content")
+	var dummyValues = map[string]string{
+		"foo": "bar",
+	}
+	var dummyModTime = time.Now()
+	var parseFuncExpected = 1
+	var parseFuncCalled = 0
+	var sut = &fileSource{
+		path: dummyPath,
+		parseFunc: func(content []byte) (map[string]string, error) {
+			parseFuncCalled++
+			assert.Equal(t, dummyContent, content)
+			return dummyValues, nil
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	ioutilReadFileExpected = 1
+	ioutilReadFile = func(filename string) ([]byte, error) {
+		ioutilReadFileCalled++
+		assert.Equal(t, dummyPath, filename)
+		return dummyContent, nil
+	}
+	getModTimeFuncExpected = 1
+	getModTimeFunc = func(path string) time.Time {
+		getModTimeFuncCalled++
+		assert.Equal(t, dummyPath, path)
+		return dummyModTime
+	}
+
+	// SUT + act
+	var err = sut.Load()
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, dummyValues, sut.values)
+	assert.Equal(t, dummyModTime, sut.modTime)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, parseFuncExpected, parseFuncCalled, "Unexpected number of calls to parseFunc")
+}
+
+func TestFileSourceChanged_NotChanged(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyModTime = time.Now()
+	var sut = &fileSource{
+		path:    dummyPath,
+		modTime: dummyModTime,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getModTimeFuncExpected = 1
+	getModTimeFunc = func(path string) time.Time {
+		getModTimeFuncCalled++
+		assert.Equal(t, dummyPath, path)
+		return dummyModTime
+	}
+
+	// SUT + act
+	var result = sut.Changed()
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFileSourceChanged_Changed(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyModTime = time.Now()
+	var sut = &fileSource{
+		path:    dummyPath,
+		modTime: dummyModTime,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getModTimeFuncExpected = 1
+	getModTimeFunc = func(path string) time.Time {
+		getModTimeFuncCalled++
+		assert.Equal(t, dummyPath, path)
+		return dummyModTime.Add(time.Second)
+	}
+
+	// SUT + act
+	var result = sut.Changed()
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFileSourceLookup_NoKeyFunc(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyValue = "some value"
+	var sut = &fileSource{
+		values: map[string]string{
+			dummyName: dummyValue,
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, found = sut.Lookup(dummyName)
+
+	// assert
+	assert.Equal(t, dummyValue, result)
+	assert.True(t, found)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFileSourceLookup_WithKeyFunc(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyPrefix = "SOME_PREFIX_"
+	var dummyKey = "SOME_KEY"
+	var dummyValue = "some value"
+	var keyFuncExpected = 1
+	var keyFuncCalled = 0
+	var sut = &fileSource{
+		prefix: dummyPrefix,
+		keyFunc: func(name string) string {
+			keyFuncCalled++
+			assert.Equal(t, dummyName, name)
+			return dummyKey
+		},
+		values: map[string]string{
+			dummyName:              "some other value",
+			dummyPrefix + dummyKey: dummyValue,
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, found = sut.Lookup(dummyName)
+
+	// assert
+	assert.Equal(t, dummyValue, result)
+	assert.True(t, found)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, keyFuncExpected, keyFuncCalled, "Unexpected number of calls to keyFunc")
+}
+
+func TestParseDotEnvContent_InvalidEntry(t *testing.T) {
+	// arrange
+	var dummyContent = []byte("# some comment\n\nAPP_PORT=1234\nsome invalid line\n")
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Invalid entry at line %v: expecting KEY=VALUE", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, 4, a[0])
+		return dummyError
+	}
+
+	// SUT + act
+	var result, err = parseDotEnvContent(dummyContent)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseDotEnvContent_Success(t *testing.T) {
+	// arrange
+	var dummyContent = []byte("# some comment\r\n\r\nAPP_PORT=1234\r\nexport APP_NAME = \"some name\"\r\n  SERVE_HTTPS='true'\r\nEMPTY=\r\nEQUALS=a=b\r\n")
+	var expectedResult = map[string]string{
+		"APP_PORT":    "1234",
+		"APP_NAME":    "some name",
+		"SERVE_HTTPS": "true",
+		"EMPTY":       "",
+		"EQUALS":      "a=b",
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, err = parseDotEnvContent(dummyContent)
+
+	// assert
+	assert.Equal(t, expectedResult, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseJSONContent_Error(t *testing.T) {
+	// arrange
+	var dummyContent = []byte("some content")
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	jsonUnmarshalExpected = 1
+	jsonUnmarshal = func(data []byte, v interface{}) error {
+		jsonUnmarshalCalled++
+		assert.Equal(t, dummyContent, data)
+		return dummyError
+	}
+
+	// SUT + act
+	var result, err = parseJSONContent(dummyContent)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseJSONContent_Success(t *testing.T) {
+	// arrange
+	var dummyContent = []byte("{\"AppPort\":\"1234\",\"ServeHTTPS\":true,\"Count\":123}")
+	var expectedResult = map[string]string{
+		"AppPort":    "1234",
+		"ServeHTTPS": "true",
+		"Count":      "123",
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	jsonUnmarshalExpected = 1
+	jsonUnmarshal = func(data []byte, v interface{}) error {
+		jsonUnmarshalCalled++
+		return json.Unmarshal(data, v)
+	}
+	jsonutilMarshalIgnoreErrorExpected = 2
+	jsonutilMarshalIgnoreError = func(v interface{}) string {
+		jsonutilMarshalIgnoreErrorCalled++
+		return jsonutil.MarshalIgnoreError(v)
+	}
+
+	// SUT + act
+	var result, err = parseJSONContent(dummyContent)
+
+	// assert
+	assert.Equal(t, expectedResult, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseYAMLContent_NestedEntry(t *testing.T) {
+	// arrange
+	var dummyContent = []byte("AppPort: 1234\nAppName:\n  foo: bar\n")
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Invalid entry at line %v: only top level key-value pairs are supported", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, 3, a[0])
+		return dummyError
+	}
+
+	// SUT + act
+	var result, err = parseYAMLContent(dummyContent)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseYAMLContent_InvalidEntry(t *testing.T) {
+	// arrange
+	var dummyContent = []byte("---\nAppPort: 1234\n- some list item\n")
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Invalid entry at line %v: expecting key: value", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, 3, a[0])
+		return dummyError
+	}
+
+	// SUT + act
+	var result, err = parseYAMLContent(dummyContent)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseYAMLContent_Success(t *testing.T) {
+	// arrange
+	var dummyContent = []byte("---\n# some comment\n\nAppPort: 1234 # some port\nAppName: \"some # name\"\nServeHTTPS: 'true'\nAppPath: http://localhost\nEmpty:\n")
+	var expectedResult = map[string]string{
+		"AppPort":    "1234",
+		"AppName":    "some # name",
+		"ServeHTTPS": "true",
+		"AppPath":    "http://localhost",
+		"Empty":      "",
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, err = parseYAMLContent(dummyContent)
+
+	// assert
+	assert.Equal(t, expectedResult, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewDotEnvSource(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummyPrefix = "SOME_PREFIX_"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = NewDotEnvSource(dummyPath, dummyPrefix)
+
+	// assert
+	var typedResult, ok = result.(*fileSource)
+	assert.True(t, ok)
+	assert.Equal(t, DotEnvSourceName, typedResult.name)
+	assert.Equal(t, dummyPath, typedResult.path)
+	assert.Equal(t, dummyPrefix, typedResult.prefix)
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(parseDotEnvContentFunc)), fmt.Sprintf("%v", reflect.ValueOf(typedResult.parseFunc)))
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(toEnvironmentNameFunc)), fmt.Sprintf("%v", reflect.ValueOf(typedResult.keyFunc)))
+	assert.Nil(t, typedResult.values)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewJSONFileSource(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = NewJSONFileSource(dummyPath)
+
+	// assert
+	var typedResult, ok = result.(*fileSource)
+	assert.True(t, ok)
+	assert.Equal(t, JSONFileSourceName, typedResult.name)
+	assert.Equal(t, dummyPath, typedResult.path)
+	assert.Empty(t, typedResult.prefix)
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(parseJSONContentFunc)), fmt.Sprintf("%v", reflect.ValueOf(typedResult.parseFunc)))
+	assert.Nil(t, typedResult.keyFunc)
+	assert.Nil(t, typedResult.values)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewYAMLFileSource(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = NewYAMLFileSource(dummyPath)
+
+	// assert
+	var typedResult, ok = result.(*fileSource)
+	assert.True(t, ok)
+	assert.Equal(t, YAMLFileSourceName, typedResult.name)
+	assert.Equal(t, dummyPath, typedResult.path)
+	assert.Empty(t, typedResult.prefix)
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(parseYAMLContentFunc)), fmt.Sprintf("%v", reflect.ValueOf(typedResult.parseFunc)))
+	assert.Nil(t, typedResult.keyFunc)
+	assert.Nil(t, typedResult.values)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLoadSources_NoCustomization(t *testing.T) {
+	// stub
+	configSources = []model.Source{&dummySource{}}
+	customization.ConfigSources = nil
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err = loadSources()
+
+	// assert
+	assert.NoError(t, err)
+	assert.Nil(t, configSources)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLoadSources_WithCustomization(t *testing.T) {
+	// arrange
+	var dummyLoadError = errors.New("some load error")
+	var dummySource1 = &dummySource{name: "source 1", loadError: dummyLoadError}
+	var dummySource2 = &dummySource{name: "source 2"}
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// stub
+	customization.ConfigSources = func() []model.Source {
+		return []model.Source{
+			dummySource1,
+			nil,
+			dummySource2,
+		}
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	apperrorWrapSimpleErrorExpected = 1
+	apperrorWrapSimpleError = func(innerErrors []error, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
+		apperrorWrapSimpleErrorCalled++
+		assert.Equal(t, []error{dummyLoadError}, innerErrors)
+		assert.Equal(t, "Failed to load one or more configuration sources", messageFormat)
+		assert.Empty(t, parameters)
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = loadSources()
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+	assert.Equal(t, []model.Source{dummySource1, dummySource2}, configSources)
+	assert.Equal(t, 1, dummySource1.loadCalled)
+	assert.Equal(t, 1, dummySource2.loadCalled)
+
+	// verify
+	verifyAll(t)
+	customization.ConfigSources = nil
+}
+
+func TestLookupSources_NotFound(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummySource1 = &dummySource{}
+	var dummySource2 = &dummySource{}
+
+	// stub
+	configSources = []model.Source{dummySource1, dummySource2}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, found = lookupSources(dummyName)
+
+	// assert
+	assert.Empty(t, result)
+	assert.False(t, found)
+	assert.Equal(t, []string{dummyName}, dummySource1.lookupNames)
+	assert.Equal(t, []string{dummyName}, dummySource2.lookupNames)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLookupSources_Found(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyValue = "some value"
+	var dummySource1 = &dummySource{values: map[string]string{dummyName: dummyValue}}
+	var dummySource2 = &dummySource{values: map[string]string{dummyName: "some other value"}}
+
+	// stub
+	configSources = []model.Source{dummySource1, dummySource2}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, found = lookupSources(dummyName)
+
+	// assert
+	assert.Equal(t, dummyValue, result)
+	assert.True(t, found)
+	assert.Equal(t, []string{dummyName}, dummySource1.lookupNames)
+	assert.Empty(t, dummySource2.lookupNames)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLookupSourceName_NotFound(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummySource1 = &dummySource{name: "some source 1"}
+	var dummySource2 = &dummySource{name: "some source 2"}
+
+	// stub
+	configSources = []model.Source{dummySource1, dummySource2}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, found = lookupSourceName(dummyName)
+
+	// assert
+	assert.Empty(t, result)
+	assert.False(t, found)
+	assert.Equal(t, []string{dummyName}, dummySource1.lookupNames)
+	assert.Equal(t, []string{dummyName}, dummySource2.lookupNames)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLookupSourceName_Found(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummySourceName = "some source 2"
+	var dummySource1 = &dummySource{name: "some source 1"}
+	var dummySource2 = &dummySource{name: dummySourceName, values: map[string]string{dummyName: "some value"}}
+	var dummySource3 = &dummySource{name: "some source 3", values: map[string]string{dummyName: "some other value"}}
+
+	// stub
+	configSources = []model.Source{dummySource1, dummySource2, dummySource3}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, found = lookupSourceName(dummyName)
+
+	// assert
+	assert.Equal(t, dummySourceName, result)
+	assert.True(t, found)
+	assert.Equal(t, []string{dummyName}, dummySource1.lookupNames)
+	assert.Equal(t, []string{dummyName}, dummySource2.lookupNames)
+	assert.Empty(t, dummySource3.lookupNames)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetStringFunction_Customized(t *testing.T) {
+	// arrange
+	var dummyCustomizedFunc = func() string { return "some value" }
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getStringFunction(dummyCustomizedFunc, dummyName)
+
+	// assert
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyCustomizedFunc)), fmt.Sprintf("%v", reflect.ValueOf(result)))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetStringFunction_NotFound(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourcesFuncExpected = 1
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return "", false
+	}
+
+	// SUT + act
+	var result = getStringFunction(nil, dummyName)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetStringFunction_Found(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyValue = "some value"
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourcesFuncExpected = 1
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return dummyValue, true
+	}
+
+	// SUT + act
+	var result = getStringFunction(nil, dummyName)
+
+	// assert
+	assert.Equal(t, dummyValue, result())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetParseError(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyParseError = errors.New("some parse error")
+	var dummySourceName = "some source name"
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourceNameFuncExpected = 1
+	lookupSourceNameFunc = func(name string) (string, bool) {
+		lookupSourceNameFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return dummySourceName, true
+	}
+	apperrorWrapSimpleErrorExpected = 1
+	apperrorWrapSimpleError = func(innerErrors []error, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
+		apperrorWrapSimpleErrorCalled++
+		assert.Equal(t, []error{dummyParseError}, innerErrors)
+		assert.Equal(t, "Config [%v] from source [%v] is invalid and ignored", messageFormat)
+		assert.Equal(t, 2, len(parameters))
+		assert.Equal(t, dummyName, parameters[0])
+		assert.Equal(t, dummySourceName, parameters[1])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = getParseError(
+		dummyName,
+		dummyParseError,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetBooleanFunction_Customized(t *testing.T) {
+	// arrange
+	var dummyCustomizedFunc = func() bool { return true }
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, err = getBooleanFunction(dummyCustomizedFunc, dummyName)
+
+	// assert
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyCustomizedFunc)), fmt.Sprintf("%v", reflect.ValueOf(result)))
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetBooleanFunction_NotFound(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourcesFuncExpected = 1
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return "", false
+	}
+
+	// SUT + act
+	var result, err = getBooleanFunction(nil, dummyName)
+
+	// assert
+	assert.Nil(t, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetBooleanFunction_InvalidValue(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourcesFuncExpected = 1
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return "some invalid value", true
+	}
+	getParseErrorFuncExpected = 1
+	getParseErrorFunc = func(name string, parseError error) error {
+		getParseErrorFuncCalled++
+		assert.Equal(t, dummyName, name)
+		assert.Error(t, parseError)
+		return dummyError
+	}
+
+	// SUT + act
+	var result, err = getBooleanFunction(nil, dummyName)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetBooleanFunction_Found(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourcesFuncExpected = 1
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return " TRUE ", true
+	}
+
+	// SUT + act
+	var result, err = getBooleanFunction(nil, dummyName)
+
+	// assert
+	assert.NoError(t, err)
+	assert.True(t, result())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetDurationFunction_Customized(t *testing.T) {
+	// arrange
+	var dummyCustomizedFunc = func() time.Duration { return time.Second }
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, err = getDurationFunction(dummyCustomizedFunc, dummyName)
+
+	// assert
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyCustomizedFunc)), fmt.Sprintf("%v", reflect.ValueOf(result)))
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetDurationFunction_NotFound(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourcesFuncExpected = 1
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return "", false
+	}
+
+	// SUT + act
+	var result, err = getDurationFunction(nil, dummyName)
+
+	// assert
+	assert.Nil(t, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetDurationFunction_InvalidValue(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourcesFuncExpected = 1
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return "some invalid value", true
+	}
+	getParseErrorFuncExpected = 1
+	getParseErrorFunc = func(name string, parseError error) error {
+		getParseErrorFuncCalled++
+		assert.Equal(t, dummyName, name)
+		assert.Error(t, parseError)
+		return dummyError
+	}
+
+	// SUT + act
+	var result, err = getDurationFunction(nil, dummyName)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetDurationFunction_Found(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourcesFuncExpected = 1
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return "1m30s", true
+	}
+
+	// SUT + act
+	var result, err = getDurationFunction(nil, dummyName)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second, result())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetLogTypeFunction_Customized(t *testing.T) {
+	// arrange
+	var dummyCustomizedFunc = func() logtype.LogType { return logtype.FullLogging }
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getLogTypeFunction(dummyCustomizedFunc, dummyName)
+
+	// assert
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyCustomizedFunc)), fmt.Sprintf("%v", reflect.ValueOf(result)))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetLogTypeFunction_NotFound(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourcesFuncExpected = 1
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return "", false
+	}
+
+	// SUT + act
+	var result = getLogTypeFunction(nil, dummyName)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetLogTypeFunction_Found(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourcesFuncExpected = 1
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return "APIEnter|APIExit", true
+	}
+
+	// SUT + act
+	var result = getLogTypeFunction(nil, dummyName)
+
+	// assert
+	assert.Equal(t, logtype.APIEnter|logtype.APIExit, result())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetLogLevelFunction_Customized(t *testing.T) {
+	// arrange
+	var dummyCustomizedFunc = func() loglevel.LogLevel { return loglevel.Error }
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getLogLevelFunction(dummyCustomizedFunc, dummyName)
+
+	// assert
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyCustomizedFunc)), fmt.Sprintf("%v", reflect.ValueOf(result)))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetLogLevelFunction_NotFound(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourcesFuncExpected = 1
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return "", false
+	}
+
+	// SUT + act
+	var result = getLogLevelFunction(nil, dummyName)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetLogLevelFunction_Found(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourcesFuncExpected = 1
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return "Error", true
+	}
+
+	// SUT + act
+	var result = getLogLevelFunction(nil, dummyName)
+
+	// assert
+	assert.Equal(t, loglevel.Error, result())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetHeaderStyleFunction_Customized(t *testing.T) {
+	// arrange
+	var dummyCustomizedFunc = func() headerstyle.HeaderStyle { return headerstyle.LogCombined }
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getHeaderStyleFunction(dummyCustomizedFunc, dummyName)
+
+	// assert
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyCustomizedFunc)), fmt.Sprintf("%v", reflect.ValueOf(result)))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetHeaderStyleFunction_NotFound(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourcesFuncExpected = 1
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return "", false
+	}
+
+	// SUT + act
+	var result = getHeaderStyleFunction(nil, dummyName)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetHeaderStyleFunction_Found(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+
+	// mock
+	createMock(t)
+
+	// expect
+	lookupSourcesFuncExpected = 1
+	lookupSourcesFunc = func(name string) (string, bool) {
+		lookupSourcesFuncCalled++
+		assert.Equal(t, dummyName, name)
+		return "LogPerName", true
+	}
+
+	// SUT + act
+	var result = getHeaderStyleFunction(nil, dummyName)
+
+	// assert
+	assert.Equal(t, headerstyle.LogPerName, result())
+
+	// verify
+	verifyAll(t)
+}
@@ -16,6 +16,13 @@ func verifyAll(t *testing.T) {
 	SessionAllowedLogType = nil
 	SessionAllowedLogLevel = nil
 	LoggingFunc = nil
+	CorrelationIDHeader = nil
+	CorrelationIDAsSessionID = nil
+	SpanExporter = nil
+	ConfigSources = nil
+	ConfigWatchInterval = nil
+	ConfigReloadedFunc = nil
+	RequiredConfigs = nil
 	AppVersion = nil
 	AppPort = nil
 	AppName = nil
@@ -31,10 +38,25 @@ func verifyAll(t *testing.T) {
 	ClientKeyContent = nil
 	PreActionFunc = nil
 	PostActionFunc = nil
+	RequestDecoders = nil
 	CreateErrorResponseFunc = nil
+	CreateEncodedErrorResponseFunc = nil
+	ResponseEncoders = nil
+	ResponseCompression = nil
+	ResponseETag = nil
+	Listeners = nil
 	Routes = nil
+	RouteGroups = nil
 	Statics = nil
 	Middlewares = nil
+	HealthChecks = nil
+	MetricsPath = nil
+	OpenAPIPath = nil
+	RateLimit = nil
+	RateLimitStore = nil
+	MaxRequestBodyBytes = nil
+	Authenticators = nil
+	CORS = nil
 	NotFoundHandler = nil
 	MethodNotAllowedHandler = nil
 	InstrumentRouter = nil
@@ -42,6 +64,13 @@ func verifyAll(t *testing.T) {
 	WrapHTTPRequest = nil
 	DefaultNetworkRetryDelay = nil
 	DefaultNetworkTimeout = nil
+	NetworkCircuitBreaker = nil
 	SkipServerCertVerification = nil
 	GraceShutdownWaitTime = nil
+	ServerReadTimeout = nil
+	ServerReadHeaderTimeout = nil
+	ServerWriteTimeout = nil
+	ServerIdleTimeout = nil
+	ServerMaxHeaderBytes = nil
+	ServerShutdownDrainDelay = nil
 }
@@ -6,11 +6,16 @@ import (
 
 	"github.com/gorilla/mux"
 	apperrorEnum "github.com/zhongjie-cai/WebServiceTemplate/apperror/enum"
+	configModel "github.com/zhongjie-cai/WebServiceTemplate/config/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/headerutil/headerstyle"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
+	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
+	responseModel "github.com/zhongjie-cai/WebServiceTemplate/response/model"
 	serverModel "github.com/zhongjie-cai/WebServiceTemplate/server/model"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+	tracingModel "github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
 )
 
 // PreBootstrapFunc is to customize the pre-processing logic before bootstrapping
@@ -43,6 +48,27 @@ var SessionHTTPHeaderLogStyle func(session sessionModel.Session) headerstyle.Hea
 // LoggingFunc is to customize the logging backend for the whole application
 var LoggingFunc func(session sessionModel.Session, logType logtype.LogType, logLevel loglevel.LogLevel, category, subcategory, description string)
 
+// CorrelationIDHeader is to customize the name of the HTTP header (e.g. X-Correlation-ID) carrying the correlation ID, which is adopted from incoming requests if valid, echoed in responses and forwarded on network requests; no correlation ID header is handled if not set
+var CorrelationIDHeader func() string
+
+// CorrelationIDAsSessionID is to customize whether the adopted correlation ID is used as the session ID as well, when it is a valid UUID; the correlation ID is kept as a separate field with a newly generated session ID if not set
+var CorrelationIDAsSessionID func() bool
+
+// SpanExporter is to customize the exporter of ended spans for distributed tracing of API calls, pre/post actions and network requests, e.g. tracing.NewStdoutExporter or tracing.NewJSONFileExporter; trace context is still propagated but no span is exported if not set
+var SpanExporter func() tracingModel.SpanExporter
+
+// ConfigSources is to customize the configuration sources (e.g. environment variables, .env files, JSON or YAML files) consulted for any application config not customized through functions; sources listed first take precedence
+var ConfigSources func() []configModel.Source
+
+// ConfigWatchInterval is to customize the polling interval for detecting changes in configuration sources (e.g. file modification) to trigger configuration hot reload; SIGHUP always triggers configuration hot reload when ConfigSources is customized
+var ConfigWatchInterval func() time.Duration
+
+// ConfigReloadedFunc is to customize the post-processing logic after configuration hot reload, with the changes applied to hot-reloadable configs
+var ConfigReloadedFunc func(changes []configModel.Change) error
+
+// RequiredConfigs is to customize the names of application configs (e.g. AppPort, ServeHTTPS) which must be explicitly configured through customization or configuration sources; customizing this enables strict configuration validation, which fails the application bootstrap if any of them is not configured or falls back to default
+var RequiredConfigs func() []string
+
 // AppVersion is to customize the application version string
 var AppVersion func() string
 
@@ -88,18 +114,63 @@ var PreActionFunc func(session sessionModel.Session) error
 // PostActionFunc is to customize the post-action function used after each route action takes place, e.g. finalization, etc.
 var PostActionFunc func(session sessionModel.Session) error
 
+// RequestDecoders is to customize the decoders of HTTP request bodies by media type (e.g. "application/yaml"), in addition to or replacing the built-in JSON, XML, URL-encoded form and multipart form decoders; a nil decoder removes the built-in one for that media type
+var RequestDecoders func() map[string]requestModel.Decoder
+
 // CreateErrorResponseFunc is to customize the generation of HTTP error response
 var CreateErrorResponseFunc func(err error) (responseMessage string, statusCode int)
 
+// CreateEncodedErrorResponseFunc is to customize the generation of HTTP error response with the encoder negotiated for the request, as the response is labelled with its content type; takes precedence over CreateErrorResponseFunc if set
+var CreateEncodedErrorResponseFunc func(err error, encoder responseModel.Encoder) (responseMessage string, statusCode int)
+
+// ResponseEncoders is to customize the encoders of HTTP response bodies by media type (e.g. "application/yaml"), in addition to or replacing the built-in JSON, XML, plain text, CSV and MessagePack encoders; a nil encoder removes the built-in one for that media type
+var ResponseEncoders func() map[string]responseModel.Encoder
+
+// ResponseCompression is to customize the gzip/deflate compression of response bodies negotiated upon the Accept-Encoding header of requests; response bodies are not compressed if not set
+var ResponseCompression func() *responseModel.Compression
+
+// ResponseETag is to customize the entity tags computed over serialized response bodies of successful requests after content coding, against which the If-None-Match request header is evaluated; entity tags are not computed if not set, unless supplied through session.SetResponseETag
+var ResponseETag func() *responseModel.ETag
+
+// Listeners is to customize the server listeners hosted simultaneously, each with its own port, HTTPS/mTLS settings and subset of routes; a single listener upon AppPort, ServeHTTPS and ValidateClientCert is hosted if not set
+var Listeners func() []serverModel.Listener
+
 // Routes is to customize the routes registration
 var Routes func() []serverModel.Route
 
+// RouteGroups is to customize the registration of route groups, each sharing its own path prefix, middlewares and pre/post action functions
+var RouteGroups func() []serverModel.RouteGroup
+
 // Statics is to customize the static contents registration
 var Statics func() []serverModel.Static
 
 // Middlewares is to customize the middlewares registration
 var Middlewares func() []serverModel.MiddlewareFunc
 
+// HealthChecks is to customize the named checks aggregated by the built-in readiness endpoint; the built-in liveness and readiness endpoints are only registered when set
+var HealthChecks func() []serverModel.HealthCheck
+
+// MetricsPath is to customize the route path of the built-in metrics endpoint exposing API session and network request metrics in Prometheus text format; metrics are only recorded and exposed when set
+var MetricsPath func() string
+
+// OpenAPIPath is to customize the route path of the built-in endpoint serving the OpenAPI 3 document generated from the registered routes, enriched by their request and response types; the endpoint is only registered when set
+var OpenAPIPath func() string
+
+// RateLimit is to customize the token-bucket rate limiting applied to all routes, each client sharing one bucket across routes; routes could further customize their own rate limiting through serverModel.Route.RateLimit
+var RateLimit func() *serverModel.RateLimit
+
+// RateLimitStore is to customize the storage of token buckets for rate limiting, e.g. a distributed store shared by multiple instances; an in-memory store is used if not set
+var RateLimitStore func() serverModel.RateLimitStore
+
+// MaxRequestBodyBytes is to customize the maximum size in bytes of the request body allowed for all routes, enforced before pre-action and route action; routes could override it through serverModel.Route.MaxBodyBytes; request body size is not limited if not set or not positive
+var MaxRequestBodyBytes func() int64
+
+// Authenticators is to customize the authenticators tried in order to identify the client of each request, the first one recognising a credential deciding the principal; routes could require authentication and authorization through serverModel.Route.Auth
+var Authenticators func() []serverModel.Authenticator
+
+// CORS is to customize the cross-origin resource sharing policy applied to all routes, answering preflight requests and decorating actual responses; routes could override it through serverModel.Route.CORS; cross-origin requests are not allowed if not set
+var CORS func() *serverModel.CORS
+
 // NotFoundHandler is to customize the handler for routes that are not found in router
 var NotFoundHandler func() http.Handler
 
@@ -124,12 +195,33 @@ var DefaultNetworkRetryDelay func() time.Duration
 // DefaultNetworkTimeout is to customize the default timeout for any network communications through HTTP/HTTPS by session
 var DefaultNetworkTimeout func() time.Duration
 
+// NetworkCircuitBreaker is to customize the circuit breaker settings for network communications through HTTP/HTTPS by session to the given dependency, named through SetDependency or the host of the request URL otherwise; no circuit breaking applies if not set or nil returned
+var NetworkCircuitBreaker func(dependency string) *networkModel.CircuitBreaker
+
 // SkipServerCertVerification is to customize the skip of server certificate verification for any network communications through HTTP/HTTPS by session
 var SkipServerCertVerification func() bool
 
 // GraceShutdownWaitTime is to customize the graceful shutdown wait time for the application
 var GraceShutdownWaitTime func() time.Duration
 
+// ServerReadTimeout is to customize the maximum duration for the hosted server to read an entire request, including the body; no timeout is applied if not set
+var ServerReadTimeout func() time.Duration
+
+// ServerReadHeaderTimeout is to customize the maximum duration for the hosted server to read request headers; ServerReadTimeout is applied if not set
+var ServerReadHeaderTimeout func() time.Duration
+
+// ServerWriteTimeout is to customize the maximum duration for the hosted server before timing out writes of the response; no timeout is applied if not set
+var ServerWriteTimeout func() time.Duration
+
+// ServerIdleTimeout is to customize the maximum duration for the hosted server to wait for the next request when keep-alives are enabled; ServerReadTimeout is applied if not set
+var ServerIdleTimeout func() time.Duration
+
+// ServerMaxHeaderBytes is to customize the maximum size in bytes of request headers, including the request line, read by the hosted server; http.DefaultMaxHeaderBytes is applied if not set
+var ServerMaxHeaderBytes func() int
+
+// ServerShutdownDrainDelay is to customize the duration for the hosted server to wait after being marked as not ready before shutting down, allowing load balancers to stop routing new requests to it; no delay is applied if not set
+var ServerShutdownDrainDelay func() time.Duration
+
 // Reset clears all customization of functions for the whole application
 func Reset() {
 	PreBootstrapFunc = nil
@@ -142,6 +234,13 @@ func Reset() {
 	SessionAllowedLogLevel = nil
 	SessionHTTPHeaderLogStyle = nil
 	LoggingFunc = nil
+	CorrelationIDHeader = nil
+	CorrelationIDAsSessionID = nil
+	SpanExporter = nil
+	ConfigSources = nil
+	ConfigWatchInterval = nil
+	ConfigReloadedFunc = nil
+	RequiredConfigs = nil
 	AppVersion = nil
 	AppPort = nil
 	AppName = nil
@@ -157,10 +256,25 @@ func Reset() {
 	ClientKeyContent = nil
 	PreActionFunc = nil
 	PostActionFunc = nil
+	RequestDecoders = nil
 	CreateErrorResponseFunc = nil
+	CreateEncodedErrorResponseFunc = nil
+	ResponseEncoders = nil
+	ResponseCompression = nil
+	ResponseETag = nil
+	Listeners = nil
 	Routes = nil
+	RouteGroups = nil
 	Statics = nil
 	Middlewares = nil
+	HealthChecks = nil
+	MetricsPath = nil
+	OpenAPIPath = nil
+	RateLimit = nil
+	RateLimitStore = nil
+	MaxRequestBodyBytes = nil
+	Authenticators = nil
+	CORS = nil
 	NotFoundHandler = nil
 	MethodNotAllowedHandler = nil
 	InstrumentRouter = nil
@@ -169,6 +283,13 @@ func Reset() {
 	WrapHTTPRequest = nil
 	DefaultNetworkRetryDelay = nil
 	DefaultNetworkTimeout = nil
+	NetworkCircuitBreaker = nil
 	SkipServerCertVerification = nil
 	GraceShutdownWaitTime = nil
+	ServerReadTimeout = nil
+	ServerReadHeaderTimeout = nil
+	ServerWriteTimeout = nil
+	ServerIdleTimeout = nil
+	ServerMaxHeaderBytes = nil
+	ServerShutdownDrainDelay = nil
 }
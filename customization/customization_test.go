@@ -8,11 +8,16 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	apperrorEnum "github.com/zhongjie-cai/WebServiceTemplate/apperror/enum"
+	configModel "github.com/zhongjie-cai/WebServiceTemplate/config/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/headerutil/headerstyle"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
+	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
+	responseModel "github.com/zhongjie-cai/WebServiceTemplate/response/model"
 	serverModel "github.com/zhongjie-cai/WebServiceTemplate/server/model"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+	tracingModel "github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
 )
 
 func TestReset(t *testing.T) {
@@ -28,6 +33,13 @@ func TestReset(t *testing.T) {
 	SessionHTTPHeaderLogStyle = func(session sessionModel.Session) headerstyle.HeaderStyle { return headerstyle.HeaderStyle(0) }
 	LoggingFunc = func(session sessionModel.Session, logType logtype.LogType, logLevel loglevel.LogLevel, category, subcategory, description string) {
 	}
+	CorrelationIDHeader = func() string { return "" }
+	CorrelationIDAsSessionID = func() bool { return false }
+	SpanExporter = func() tracingModel.SpanExporter { return nil }
+	ConfigSources = func() []configModel.Source { return nil }
+	ConfigWatchInterval = func() time.Duration { return 0 }
+	ConfigReloadedFunc = func(changes []configModel.Change) error { return nil }
+	RequiredConfigs = func() []string { return nil }
 	AppVersion = func() string { return "" }
 	AppPort = func() string { return "" }
 	AppName = func() string { return "" }
@@ -43,18 +55,40 @@ func TestReset(t *testing.T) {
 	ClientKeyContent = func() string { return "" }
 	PreActionFunc = func(session sessionModel.Session) error { return nil }
 	PostActionFunc = func(session sessionModel.Session) error { return nil }
+	RequestDecoders = func() map[string]requestModel.Decoder { return nil }
 	CreateErrorResponseFunc = func(err error) (responseMessage string, statusCode int) { return "", 0 }
+	CreateEncodedErrorResponseFunc = func(err error, encoder responseModel.Encoder) (responseMessage string, statusCode int) { return "", 0 }
+	ResponseEncoders = func() map[string]responseModel.Encoder { return nil }
+	ResponseCompression = func() *responseModel.Compression { return nil }
+	ResponseETag = func() *responseModel.ETag { return nil }
+	Listeners = func() []serverModel.Listener { return nil }
 	Routes = func() []serverModel.Route { return nil }
+	RouteGroups = func() []serverModel.RouteGroup { return nil }
 	Statics = func() []serverModel.Static { return nil }
 	Middlewares = func() []serverModel.MiddlewareFunc { return nil }
+	HealthChecks = func() []serverModel.HealthCheck { return nil }
+	MetricsPath = func() string { return "" }
+	OpenAPIPath = func() string { return "" }
+	RateLimit = func() *serverModel.RateLimit { return nil }
+	RateLimitStore = func() serverModel.RateLimitStore { return nil }
+	MaxRequestBodyBytes = func() int64 { return 0 }
+	Authenticators = func() []serverModel.Authenticator { return nil }
+	CORS = func() *serverModel.CORS { return nil }
 	InstrumentRouter = func(router *mux.Router) *mux.Router { return nil }
 	AppErrors = func() (map[apperrorEnum.Code]string, map[apperrorEnum.Code]int) { return nil, nil }
 	HTTPRoundTripper = func(originalTransport http.RoundTripper) http.RoundTripper { return nil }
 	WrapHTTPRequest = func(session sessionModel.Session, httpRequest *http.Request) *http.Request { return nil }
 	DefaultNetworkRetryDelay = func() time.Duration { return 0 }
 	DefaultNetworkTimeout = func() time.Duration { return 0 }
+	NetworkCircuitBreaker = func(dependency string) *networkModel.CircuitBreaker { return nil }
 	SkipServerCertVerification = func() bool { return false }
 	GraceShutdownWaitTime = func() time.Duration { return 0 }
+	ServerReadTimeout = func() time.Duration { return 0 }
+	ServerReadHeaderTimeout = func() time.Duration { return 0 }
+	ServerWriteTimeout = func() time.Duration { return 0 }
+	ServerIdleTimeout = func() time.Duration { return 0 }
+	ServerMaxHeaderBytes = func() int { return 0 }
+	ServerShutdownDrainDelay = func() time.Duration { return 0 }
 
 	// mock
 	createMock(t)
@@ -73,6 +107,13 @@ func TestReset(t *testing.T) {
 	assert.Nil(t, SessionAllowedLogLevel)
 	assert.Nil(t, SessionHTTPHeaderLogStyle)
 	assert.Nil(t, LoggingFunc)
+	assert.Nil(t, CorrelationIDHeader)
+	assert.Nil(t, CorrelationIDAsSessionID)
+	assert.Nil(t, SpanExporter)
+	assert.Nil(t, ConfigSources)
+	assert.Nil(t, ConfigWatchInterval)
+	assert.Nil(t, ConfigReloadedFunc)
+	assert.Nil(t, RequiredConfigs)
 	assert.Nil(t, AppVersion)
 	assert.Nil(t, AppPort)
 	assert.Nil(t, AppName)
@@ -88,18 +129,40 @@ func TestReset(t *testing.T) {
 	assert.Nil(t, ClientKeyContent)
 	assert.Nil(t, PreActionFunc)
 	assert.Nil(t, PostActionFunc)
+	assert.Nil(t, RequestDecoders)
 	assert.Nil(t, CreateErrorResponseFunc)
+	assert.Nil(t, CreateEncodedErrorResponseFunc)
+	assert.Nil(t, ResponseEncoders)
+	assert.Nil(t, ResponseCompression)
+	assert.Nil(t, ResponseETag)
+	assert.Nil(t, Listeners)
 	assert.Nil(t, Routes)
+	assert.Nil(t, RouteGroups)
 	assert.Nil(t, Statics)
 	assert.Nil(t, Middlewares)
+	assert.Nil(t, HealthChecks)
+	assert.Nil(t, MetricsPath)
+	assert.Nil(t, OpenAPIPath)
+	assert.Nil(t, RateLimit)
+	assert.Nil(t, RateLimitStore)
+	assert.Nil(t, MaxRequestBodyBytes)
+	assert.Nil(t, Authenticators)
+	assert.Nil(t, CORS)
 	assert.Nil(t, InstrumentRouter)
 	assert.Nil(t, AppErrors)
 	assert.Nil(t, HTTPRoundTripper)
 	assert.Nil(t, WrapHTTPRequest)
 	assert.Nil(t, DefaultNetworkRetryDelay)
 	assert.Nil(t, DefaultNetworkTimeout)
+	assert.Nil(t, NetworkCircuitBreaker)
 	assert.Nil(t, SkipServerCertVerification)
 	assert.Nil(t, GraceShutdownWaitTime)
+	assert.Nil(t, ServerReadTimeout)
+	assert.Nil(t, ServerReadHeaderTimeout)
+	assert.Nil(t, ServerWriteTimeout)
+	assert.Nil(t, ServerIdleTimeout)
+	assert.Nil(t, ServerMaxHeaderBytes)
+	assert.Nil(t, ServerShutdownDrainDelay)
 
 	// verify
 	verifyAll(t)
@@ -4,10 +4,12 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/config"
 	"github.com/zhongjie-cai/WebServiceTemplate/customization"
 	"github.com/zhongjie-cai/WebServiceTemplate/headerutil/headerstyle"
 	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
@@ -15,6 +17,7 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
 	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
 )
 
@@ -35,10 +38,14 @@ var (
 	logPerValueHTTPHeaderFuncCalled                int
 	logHTTPHeaderFuncExpected                      int
 	logHTTPHeaderFuncCalled                        int
+	getCorrelationIDHeaderFuncExpected             int
+	getCorrelationIDHeaderFuncCalled               int
 	customizationSessionHTTPHeaderLogStyleExpected int
 	customizationSessionHTTPHeaderLogStyleCalled   int
-	customizationDefaultHTTPHeaderLogStyleExpected int
-	customizationDefaultHTTPHeaderLogStyleCalled   int
+	configDefaultHTTPHeaderLogStyleExpected        int
+	configDefaultHTTPHeaderLogStyleCalled          int
+	customizationCorrelationIDHeaderExpected       int
+	customizationCorrelationIDHeaderCalled         int
 )
 
 func createMock(t *testing.T) {
@@ -85,12 +92,24 @@ func createMock(t *testing.T) {
 	logHTTPHeaderFunc = func(session sessionModel.Session, header http.Header, logFunc logger.LogFunc) {
 		logHTTPHeaderFuncCalled++
 	}
+	getCorrelationIDHeaderFuncExpected = 0
+	getCorrelationIDHeaderFuncCalled = 0
+	getCorrelationIDHeaderFunc = func() string {
+		getCorrelationIDHeaderFuncCalled++
+		return ""
+	}
 	customizationSessionHTTPHeaderLogStyleExpected = 0
 	customizationSessionHTTPHeaderLogStyleCalled = 0
 	customization.SessionHTTPHeaderLogStyle = nil
-	customizationDefaultHTTPHeaderLogStyleExpected = 0
-	customizationDefaultHTTPHeaderLogStyleCalled = 0
-	customization.DefaultHTTPHeaderLogStyle = nil
+	configDefaultHTTPHeaderLogStyleExpected = 0
+	configDefaultHTTPHeaderLogStyleCalled = 0
+	configDefaultHTTPHeaderLogStyle = func() headerstyle.HeaderStyle {
+		configDefaultHTTPHeaderLogStyleCalled++
+		return 0
+	}
+	customizationCorrelationIDHeaderExpected = 0
+	customizationCorrelationIDHeaderCalled = 0
+	customization.CorrelationIDHeader = nil
 }
 
 func verifyAll(t *testing.T) {
@@ -110,13 +129,19 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, logPerValueHTTPHeaderFuncExpected, logPerValueHTTPHeaderFuncCalled, "Unexpected number of calls to logPerValueHTTPHeaderFunc")
 	logHTTPHeaderFunc = LogHTTPHeader
 	assert.Equal(t, logHTTPHeaderFuncExpected, logHTTPHeaderFuncCalled, "Unexpected number of calls to logHTTPHeaderFunc")
+	getCorrelationIDHeaderFunc = GetCorrelationIDHeader
+	assert.Equal(t, getCorrelationIDHeaderFuncExpected, getCorrelationIDHeaderFuncCalled, "Unexpected number of calls to getCorrelationIDHeaderFunc")
 	assert.Equal(t, customizationSessionHTTPHeaderLogStyleExpected, customizationSessionHTTPHeaderLogStyleCalled, "Unexpected number of calls to customization.SessionHTTPHeaderLogStyle")
-	assert.Equal(t, customizationDefaultHTTPHeaderLogStyleExpected, customizationDefaultHTTPHeaderLogStyleCalled, "Unexpected number of calls to customization.DefaultHTTPHeaderLogStyle")
+	configDefaultHTTPHeaderLogStyle = config.DefaultHTTPHeaderLogStyle
+	assert.Equal(t, configDefaultHTTPHeaderLogStyleExpected, configDefaultHTTPHeaderLogStyleCalled, "Unexpected number of calls to configDefaultHTTPHeaderLogStyle")
+	customization.CorrelationIDHeader = nil
+	assert.Equal(t, customizationCorrelationIDHeaderExpected, customizationCorrelationIDHeaderCalled, "Unexpected number of calls to customization.CorrelationIDHeader")
 }
 
 // mock structs
 type dummySession struct {
-	t *testing.T
+	t             *testing.T
+	correlationID *string
 }
 
 func (session *dummySession) GetID() uuid.UUID {
@@ -129,6 +154,14 @@ func (session *dummySession) GetName() string {
 	return ""
 }
 
+func (session *dummySession) GetCorrelationID() string {
+	if session.correlationID == nil {
+		assert.Fail(session.t, "Unexpected call to GetCorrelationID")
+		return ""
+	}
+	return *session.correlationID
+}
+
 func (session *dummySession) GetRequest() *http.Request {
 	assert.Fail(session.t, "Unexpected call to GetRequest")
 	return nil
@@ -139,6 +172,14 @@ func (session *dummySession) GetResponseWriter() http.ResponseWriter {
 	return nil
 }
 
+func (session *dummySession) SetResponseETag(etag string, weak bool) {
+	assert.Fail(session.t, "Unexpected call to SetResponseETag")
+}
+
+func (session *dummySession) SetResponseLastModified(lastModified time.Time) {
+	assert.Fail(session.t, "Unexpected call to SetResponseLastModified")
+}
+
 func (session *dummySession) GetRequestBody(dataTemplate interface{}) apperrorModel.AppError {
 	assert.Fail(session.t, "Unexpected call to GetRequestBody")
 	return nil
@@ -169,6 +210,26 @@ func (session *dummySession) GetRequestHeaders(name string, dataTemplate interfa
 	return nil
 }
 
+func (session *dummySession) GetRequestFormValue(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValue")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValues(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValues")
+	return nil
+}
+
+func (session *dummySession) GetRequestFile(name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFile")
+	return nil
+}
+
+func (session *dummySession) BindRequest(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to BindRequest")
+	return nil
+}
+
 func (session *dummySession) Attach(name string, value interface{}) bool {
 	assert.Fail(session.t, "Unexpected call to Attach")
 	return false
@@ -10,13 +10,10 @@ import (
 )
 
 func getHeaderLogStyle(session sessionModel.Session) headerstyle.HeaderStyle {
-	var headerLogStyle = headerstyle.DoNotLog
 	if customization.SessionHTTPHeaderLogStyle != nil {
-		headerLogStyle = customization.SessionHTTPHeaderLogStyle(session)
-	} else if customization.DefaultHTTPHeaderLogStyle != nil {
-		headerLogStyle = customization.DefaultHTTPHeaderLogStyle()
+		return customization.SessionHTTPHeaderLogStyle(session)
 	}
-	return headerLogStyle
+	return configDefaultHTTPHeaderLogStyle()
 }
 
 func logCombinedHTTPHeader(session sessionModel.Session, header http.Header, logFunc logger.LogFunc) {
@@ -80,3 +77,30 @@ func LogHTTPHeaderForName(session sessionModel.Session, name string, values []st
 		logFunc,
 	)
 }
+
+// GetCorrelationIDHeader returns the name of the HTTP header carrying the correlation ID according to customizations, or empty if not customized
+func GetCorrelationIDHeader() string {
+	if customization.CorrelationIDHeader == nil {
+		return ""
+	}
+	return customization.CorrelationIDHeader()
+}
+
+// SetCorrelationIDHeader sets the correlation ID of the given session onto the given HTTP header, unless the correlation ID header is not customized or already present
+func SetCorrelationIDHeader(session sessionModel.Session, header http.Header) {
+	var headerName = getCorrelationIDHeaderFunc()
+	if headerName == "" ||
+		session == nil ||
+		header == nil ||
+		header.Get(headerName) != "" {
+		return
+	}
+	var correlationID = session.GetCorrelationID()
+	if correlationID == "" {
+		return
+	}
+	header.Set(
+		headerName,
+		correlationID,
+	)
+}
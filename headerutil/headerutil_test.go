@@ -15,28 +15,9 @@ import (
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
 )
 
-func TestGetHeaderLogStyle_NoCustomization(t *testing.T) {
-	// arrange
-	var dummySessionObject = &dummySession{t}
-
-	// mock
-	createMock(t)
-
-	// SUT + act
-	var result = getHeaderLogStyle(
-		dummySessionObject,
-	)
-
-	// assert
-	assert.Equal(t, headerstyle.DoNotLog, result)
-
-	// verify
-	verifyAll(t)
-}
-
 func TestGetHeaderLogStyle_SessionCustomized(t *testing.T) {
 	// arrange
-	var dummySessionObject = &dummySession{t}
+	var dummySessionObject = &dummySession{t: t}
 	var dummyHeaderStyle = headerstyle.HeaderStyle(rand.Int())
 
 	// mock
@@ -62,18 +43,18 @@ func TestGetHeaderLogStyle_SessionCustomized(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestGetHeaderLogStyle_DefaultCustomized(t *testing.T) {
+func TestGetHeaderLogStyle_DefaultConfig(t *testing.T) {
 	// arrange
-	var dummySessionObject = &dummySession{t}
+	var dummySessionObject = &dummySession{t: t}
 	var dummyHeaderStyle = headerstyle.HeaderStyle(rand.Int())
 
 	// mock
 	createMock(t)
 
 	// expect
-	customizationDefaultHTTPHeaderLogStyleExpected = 1
-	customization.DefaultHTTPHeaderLogStyle = func() headerstyle.HeaderStyle {
-		customizationDefaultHTTPHeaderLogStyleCalled++
+	configDefaultHTTPHeaderLogStyleExpected = 1
+	configDefaultHTTPHeaderLogStyle = func() headerstyle.HeaderStyle {
+		configDefaultHTTPHeaderLogStyleCalled++
 		return dummyHeaderStyle
 	}
 
@@ -91,7 +72,7 @@ func TestGetHeaderLogStyle_DefaultCustomized(t *testing.T) {
 
 func TestLogCombinedHTTPHeader(t *testing.T) {
 	// arrange
-	var dummySessionObject = &dummySession{t}
+	var dummySessionObject = &dummySession{t: t}
 	var dummyHeader = http.Header{
 		"foo":  []string{"bar1", "bar2"},
 		"test": []string{"123"},
@@ -132,7 +113,7 @@ func TestLogCombinedHTTPHeader(t *testing.T) {
 
 func TestLogPerNameHTTPHeader(t *testing.T) {
 	// arrange
-	var dummySessionObject = &dummySession{t}
+	var dummySessionObject = &dummySession{t: t}
 	var dummyHeader = http.Header{
 		"foo":  []string{"bar1", "bar2"},
 		"test": []string{"123"},
@@ -175,7 +156,7 @@ func TestLogPerNameHTTPHeader(t *testing.T) {
 
 func TestLogPerValueHTTPHeader(t *testing.T) {
 	// arrange
-	var dummySessionObject = &dummySession{t}
+	var dummySessionObject = &dummySession{t: t}
 	var dummyHeader = http.Header{
 		"foo":  []string{"bar1", "bar2"},
 		"test": []string{"123"},
@@ -214,7 +195,7 @@ func TestLogPerValueHTTPHeader(t *testing.T) {
 
 func TestLogHTTPHeader_DoNotLog(t *testing.T) {
 	// arrange
-	var dummySessionObject = &dummySession{t}
+	var dummySessionObject = &dummySession{t: t}
 	var dummyHeader = http.Header{
 		"foo":  []string{"bar1", "bar2"},
 		"test": []string{"123"},
@@ -248,7 +229,7 @@ func TestLogHTTPHeader_DoNotLog(t *testing.T) {
 
 func TestLogHTTPHeader_LogCombined(t *testing.T) {
 	// arrange
-	var dummySessionObject = &dummySession{t}
+	var dummySessionObject = &dummySession{t: t}
 	var dummyHeader = http.Header{
 		"foo":  []string{"bar1", "bar2"},
 		"test": []string{"123"},
@@ -289,7 +270,7 @@ func TestLogHTTPHeader_LogCombined(t *testing.T) {
 
 func TestLogHTTPHeader_LogPerName(t *testing.T) {
 	// arrange
-	var dummySessionObject = &dummySession{t}
+	var dummySessionObject = &dummySession{t: t}
 	var dummyHeader = http.Header{
 		"foo":  []string{"bar1", "bar2"},
 		"test": []string{"123"},
@@ -330,7 +311,7 @@ func TestLogHTTPHeader_LogPerName(t *testing.T) {
 
 func TestLogHTTPHeader_LogPerValue(t *testing.T) {
 	// arrange
-	var dummySessionObject = &dummySession{t}
+	var dummySessionObject = &dummySession{t: t}
 	var dummyHeader = http.Header{
 		"foo":  []string{"bar1", "bar2"},
 		"test": []string{"123"},
@@ -371,7 +352,7 @@ func TestLogHTTPHeader_LogPerValue(t *testing.T) {
 
 func TestLogHTTPHeader_Other(t *testing.T) {
 	// arrange
-	var dummySessionObject = &dummySession{t}
+	var dummySessionObject = &dummySession{t: t}
 	var dummyHeader = http.Header{
 		"foo":  []string{"bar1", "bar2"},
 		"test": []string{"123"},
@@ -405,7 +386,7 @@ func TestLogHTTPHeader_Other(t *testing.T) {
 
 func TestLogHTTPHeaderForName(t *testing.T) {
 	// arrange
-	var dummySessionObject = &dummySession{t}
+	var dummySessionObject = &dummySession{t: t}
 	var dummyName = "some name"
 	var dummyValues = []string{"some value 1", "some value 2"}
 	var loggerLogFunc = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
@@ -435,3 +416,218 @@ func TestLogHTTPHeaderForName(t *testing.T) {
 	// verify
 	verifyAll(t)
 }
+
+func TestGetCorrelationIDHeader_NotSet(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = GetCorrelationIDHeader()
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCorrelationIDHeader_Customized(t *testing.T) {
+	// arrange
+	var dummyHeaderName = "some header name"
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationCorrelationIDHeaderExpected = 1
+	customization.CorrelationIDHeader = func() string {
+		customizationCorrelationIDHeaderCalled++
+		return dummyHeaderName
+	}
+
+	// SUT + act
+	var result = GetCorrelationIDHeader()
+
+	// assert
+	assert.Equal(t, dummyHeaderName, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetCorrelationIDHeader_NoHeaderName(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyHeader = http.Header{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getCorrelationIDHeaderFuncExpected = 1
+	getCorrelationIDHeaderFunc = func() string {
+		getCorrelationIDHeaderFuncCalled++
+		return ""
+	}
+
+	// SUT + act
+	SetCorrelationIDHeader(
+		dummySessionObject,
+		dummyHeader,
+	)
+
+	// assert
+	assert.Empty(t, dummyHeader)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetCorrelationIDHeader_NilSession(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getCorrelationIDHeaderFuncExpected = 1
+	getCorrelationIDHeaderFunc = func() string {
+		getCorrelationIDHeaderFuncCalled++
+		return "some header name"
+	}
+
+	// SUT + act
+	SetCorrelationIDHeader(
+		nil,
+		dummyHeader,
+	)
+
+	// assert
+	assert.Empty(t, dummyHeader)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetCorrelationIDHeader_NilHeader(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getCorrelationIDHeaderFuncExpected = 1
+	getCorrelationIDHeaderFunc = func() string {
+		getCorrelationIDHeaderFuncCalled++
+		return "some header name"
+	}
+
+	// SUT + act
+	SetCorrelationIDHeader(
+		dummySessionObject,
+		nil,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetCorrelationIDHeader_AlreadyPresent(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyHeaderName = "X-Correlation-ID"
+	var dummyValue = "some existing value"
+	var dummyHeader = http.Header{}
+
+	// stub
+	dummyHeader.Set(dummyHeaderName, dummyValue)
+
+	// mock
+	createMock(t)
+
+	// expect
+	getCorrelationIDHeaderFuncExpected = 1
+	getCorrelationIDHeaderFunc = func() string {
+		getCorrelationIDHeaderFuncCalled++
+		return dummyHeaderName
+	}
+
+	// SUT + act
+	SetCorrelationIDHeader(
+		dummySessionObject,
+		dummyHeader,
+	)
+
+	// assert
+	assert.Equal(t, dummyValue, dummyHeader.Get(dummyHeaderName))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetCorrelationIDHeader_EmptyCorrelationID(t *testing.T) {
+	// arrange
+	var dummyCorrelationID = ""
+	var dummySessionObject = &dummySession{
+		t:             t,
+		correlationID: &dummyCorrelationID,
+	}
+	var dummyHeader = http.Header{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getCorrelationIDHeaderFuncExpected = 1
+	getCorrelationIDHeaderFunc = func() string {
+		getCorrelationIDHeaderFuncCalled++
+		return "X-Correlation-ID"
+	}
+
+	// SUT + act
+	SetCorrelationIDHeader(
+		dummySessionObject,
+		dummyHeader,
+	)
+
+	// assert
+	assert.Empty(t, dummyHeader)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetCorrelationIDHeader_Success(t *testing.T) {
+	// arrange
+	var dummyCorrelationID = "some correlation ID"
+	var dummySessionObject = &dummySession{
+		t:             t,
+		correlationID: &dummyCorrelationID,
+	}
+	var dummyHeaderName = "X-Correlation-ID"
+	var dummyHeader = http.Header{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getCorrelationIDHeaderFuncExpected = 1
+	getCorrelationIDHeaderFunc = func() string {
+		getCorrelationIDHeaderFuncCalled++
+		return dummyHeaderName
+	}
+
+	// SUT + act
+	SetCorrelationIDHeader(
+		dummySessionObject,
+		dummyHeader,
+	)
+
+	// assert
+	assert.Equal(t, dummyCorrelationID, dummyHeader.Get(dummyHeaderName))
+
+	// verify
+	verifyAll(t)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
 	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
 	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/tracing"
 )
 
 // func pointers for injection / testing: logger.go
@@ -19,6 +20,7 @@ var (
 	timeutilGetTimeNowUTC      = timeutil.GetTimeNowUTC
 	jsonutilMarshalIgnoreError = jsonutil.MarshalIgnoreError
 	apperrorGetCustomError     = apperror.GetCustomError
+	tracingGetSessionSpan      = tracing.GetSessionSpan
 	defaultLoggingFunc         = defaultLogging
 	prepareLoggingFunc         = prepareLogging
 )
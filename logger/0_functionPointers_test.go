@@ -17,8 +17,11 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
 	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/tracing"
+	tracingModel "github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
 )
 
 var (
@@ -40,6 +43,8 @@ var (
 	configAppVersionCalled             int
 	apperrorGetCustomErrorExpected     int
 	apperrorGetCustomErrorCalled       int
+	tracingGetSessionSpanExpected      int
+	tracingGetSessionSpanCalled        int
 	defaultLoggingFuncExpected         int
 	defaultLoggingFuncCalled           int
 	prepareLoggingFuncExpected         int
@@ -101,6 +106,12 @@ func createMock(t *testing.T) {
 		apperrorGetCustomErrorCalled++
 		return nil
 	}
+	tracingGetSessionSpanExpected = 0
+	tracingGetSessionSpanCalled = 0
+	tracingGetSessionSpan = func(session sessionModel.Session) *tracingModel.Span {
+		tracingGetSessionSpanCalled++
+		return nil
+	}
 	defaultLoggingFuncExpected = 0
 	defaultLoggingFuncCalled = 0
 	defaultLoggingFunc = func(session sessionModel.Session, logType logtype.LogType, logLevel loglevel.LogLevel, category, subcategory, description string) {
@@ -132,6 +143,8 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, configAppVersionExpected, configAppVersionCalled, "Unexpected number of calls to configAppVersion")
 	apperrorGetCustomError = apperror.GetCustomError
 	assert.Equal(t, apperrorGetCustomErrorExpected, apperrorGetCustomErrorCalled, "Unexpected number of calls to apperrorGetCustomError")
+	tracingGetSessionSpan = tracing.GetSessionSpan
+	assert.Equal(t, tracingGetSessionSpanExpected, tracingGetSessionSpanCalled, "Unexpected number of calls to tracingGetSessionSpan")
 	defaultLoggingFunc = defaultLogging
 	assert.Equal(t, defaultLoggingFuncExpected, defaultLoggingFuncCalled, "Unexpected number of calls to defaultLoggingFunc")
 	prepareLoggingFunc = prepareLogging
@@ -141,10 +154,11 @@ func verifyAll(t *testing.T) {
 
 // mock structs
 type dummySession struct {
-	t            *testing.T
-	id           *uuid.UUID
-	name         *string
-	isLogAllowed *bool
+	t             *testing.T
+	id            *uuid.UUID
+	name          *string
+	correlationID *string
+	isLogAllowed  *bool
 }
 
 func (session *dummySession) GetID() uuid.UUID {
@@ -163,6 +177,14 @@ func (session *dummySession) GetName() string {
 	return *session.name
 }
 
+func (session *dummySession) GetCorrelationID() string {
+	if session.correlationID == nil {
+		assert.Fail(session.t, "Unexpected call to GetCorrelationID")
+		return ""
+	}
+	return *session.correlationID
+}
+
 func (session *dummySession) GetRequest() *http.Request {
 	assert.Fail(session.t, "Unexpected call to GetRequest")
 	return nil
@@ -173,6 +195,14 @@ func (session *dummySession) GetResponseWriter() http.ResponseWriter {
 	return nil
 }
 
+func (session *dummySession) SetResponseETag(etag string, weak bool) {
+	assert.Fail(session.t, "Unexpected call to SetResponseETag")
+}
+
+func (session *dummySession) SetResponseLastModified(lastModified time.Time) {
+	assert.Fail(session.t, "Unexpected call to SetResponseLastModified")
+}
+
 func (session *dummySession) GetRequestBody(dataTemplate interface{}) apperrorModel.AppError {
 	assert.Fail(session.t, "Unexpected call to GetRequestBody")
 	return nil
@@ -203,6 +233,26 @@ func (session *dummySession) GetRequestHeaders(name string, dataTemplate interfa
 	return nil
 }
 
+func (session *dummySession) GetRequestFormValue(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValue")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValues(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValues")
+	return nil
+}
+
+func (session *dummySession) GetRequestFile(name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFile")
+	return nil
+}
+
+func (session *dummySession) BindRequest(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to BindRequest")
+	return nil
+}
+
 func (session *dummySession) Attach(name string, value interface{}) bool {
 	assert.Fail(session.t, "Unexpected call to Attach")
 	return false
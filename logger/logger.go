@@ -16,16 +16,19 @@ import (
 type LogFunc func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{})
 
 type logEntry struct {
-	Application string            `json:"application"`
-	Version     string            `json:"version"`
-	Timestamp   time.Time         `json:"timestamp"`
-	Session     uuid.UUID         `json:"session"`
-	Name        string            `json:"name"`
-	Type        logtype.LogType   `json:"type"`
-	Level       loglevel.LogLevel `json:"level"`
-	Category    string            `json:"category"`
-	Subcategory string            `json:"subcategory"`
-	Description string            `json:"description"`
+	Application   string            `json:"application"`
+	Version       string            `json:"version"`
+	Timestamp     time.Time         `json:"timestamp"`
+	Session       uuid.UUID         `json:"session"`
+	Name          string            `json:"name"`
+	CorrelationID string            `json:"correlationId,omitempty"`
+	TraceID       string            `json:"traceId,omitempty"`
+	SpanID        string            `json:"spanId,omitempty"`
+	Type          logtype.LogType   `json:"type"`
+	Level         loglevel.LogLevel `json:"level"`
+	Category      string            `json:"category"`
+	Subcategory   string            `json:"subcategory"`
+	Description   string            `json:"description"`
 }
 
 // Initialize initiates and checks all application logging related function injections
@@ -47,19 +50,26 @@ func defaultLogging(
 	subcategory,
 	description string,
 ) {
+	var entry = logEntry{
+		Application:   config.AppName(),
+		Version:       config.AppVersion(),
+		Timestamp:     timeutilGetTimeNowUTC(),
+		Session:       session.GetID(),
+		Name:          session.GetName(),
+		CorrelationID: session.GetCorrelationID(),
+		Type:          logType,
+		Level:         logLevel,
+		Category:      category,
+		Subcategory:   subcategory,
+		Description:   description,
+	}
+	var span = tracingGetSessionSpan(session)
+	if span != nil {
+		entry.TraceID = span.TraceID
+		entry.SpanID = span.SpanID
+	}
 	var logEntryString = jsonutilMarshalIgnoreError(
-		logEntry{
-			Application: config.AppName(),
-			Version:     config.AppVersion(),
-			Timestamp:   timeutilGetTimeNowUTC(),
-			Session:     session.GetID(),
-			Name:        session.GetName(),
-			Type:        logType,
-			Level:       logLevel,
-			Category:    category,
-			Subcategory: subcategory,
-			Description: description,
-		},
+		entry,
 	)
 	fmtPrintln(
 		logEntryString,
@@ -15,6 +15,7 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+	tracingModel "github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
 )
 
 func TestInitialize_NotSet(t *testing.T) {
@@ -66,14 +67,16 @@ func TestInitialize_Set(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestDefaultLogging(t *testing.T) {
+func TestDefaultLogging_NoSpan(t *testing.T) {
 	// arrange
 	var dummySessionID = uuid.New()
 	var dummyName = "some Name"
+	var dummyCorrelationID = "some correlation ID"
 	var dummySessionObject = &dummySession{
-		t:    t,
-		id:   &dummySessionID,
-		name: &dummyName,
+		t:             t,
+		id:            &dummySessionID,
+		name:          &dummyName,
+		correlationID: &dummyCorrelationID,
 	}
 	var dummyLogType = logtype.MethodLogic
 	var dummyLogLevel = loglevel.Warn
@@ -84,16 +87,17 @@ func TestDefaultLogging(t *testing.T) {
 	var dummyAppVersion = "some app version"
 	var dummyTimestamp = time.Now().UTC()
 	var dummyLogEntry = logEntry{
-		Application: dummyAppName,
-		Version:     dummyAppVersion,
-		Timestamp:   dummyTimestamp,
-		Session:     dummySessionID,
-		Name:        dummyName,
-		Type:        dummyLogType,
-		Level:       dummyLogLevel,
-		Category:    dummyCategory,
-		Subcategory: dummySubCategory,
-		Description: dummyDescription,
+		Application:   dummyAppName,
+		Version:       dummyAppVersion,
+		Timestamp:     dummyTimestamp,
+		Session:       dummySessionID,
+		Name:          dummyName,
+		CorrelationID: dummyCorrelationID,
+		Type:          dummyLogType,
+		Level:         dummyLogLevel,
+		Category:      dummyCategory,
+		Subcategory:   dummySubCategory,
+		Description:   dummyDescription,
 	}
 	var dummyLogEntryString = "some log entry string"
 
@@ -116,6 +120,105 @@ func TestDefaultLogging(t *testing.T) {
 		timeutilGetTimeNowUTCCalled++
 		return dummyTimestamp
 	}
+	tracingGetSessionSpanExpected = 1
+	tracingGetSessionSpan = func(session sessionModel.Session) *tracingModel.Span {
+		tracingGetSessionSpanCalled++
+		assert.Equal(t, dummySessionObject, session)
+		return nil
+	}
+	jsonutilMarshalIgnoreErrorExpected = 1
+	jsonutilMarshalIgnoreError = func(v interface{}) string {
+		jsonutilMarshalIgnoreErrorCalled++
+		assert.Equal(t, dummyLogEntry, v)
+		return dummyLogEntryString
+	}
+	fmtPrintlnExpected = 1
+	fmtPrintln = func(a ...interface{}) (n int, err error) {
+		fmtPrintlnCalled++
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyLogEntryString, a[0])
+		return 0, nil
+	}
+
+	// SUT + act
+	defaultLogging(
+		dummySessionObject,
+		dummyLogType,
+		dummyLogLevel,
+		dummyCategory,
+		dummySubCategory,
+		dummyDescription,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDefaultLogging_WithSpan(t *testing.T) {
+	// arrange
+	var dummySessionID = uuid.New()
+	var dummyName = "some Name"
+	var dummyCorrelationID = "some correlation ID"
+	var dummySessionObject = &dummySession{
+		t:             t,
+		id:            &dummySessionID,
+		name:          &dummyName,
+		correlationID: &dummyCorrelationID,
+	}
+	var dummyLogType = logtype.MethodLogic
+	var dummyLogLevel = loglevel.Warn
+	var dummyCategory = "some category"
+	var dummySubCategory = "some sub category"
+	var dummyDescription = "some description"
+	var dummySpan = &tracingModel.Span{
+		TraceID: "some trace ID",
+		SpanID:  "some span ID",
+	}
+	var dummyAppName = "some app name"
+	var dummyAppVersion = "some app version"
+	var dummyTimestamp = time.Now().UTC()
+	var dummyLogEntry = logEntry{
+		Application:   dummyAppName,
+		Version:       dummyAppVersion,
+		Timestamp:     dummyTimestamp,
+		Session:       dummySessionID,
+		Name:          dummyName,
+		CorrelationID: dummyCorrelationID,
+		TraceID:       dummySpan.TraceID,
+		SpanID:        dummySpan.SpanID,
+		Type:          dummyLogType,
+		Level:         dummyLogLevel,
+		Category:      dummyCategory,
+		Subcategory:   dummySubCategory,
+		Description:   dummyDescription,
+	}
+	var dummyLogEntryString = "some log entry string"
+
+	// mock
+	createMock(t)
+
+	// expect
+	configAppNameExpected = 1
+	config.AppName = func() string {
+		configAppNameCalled++
+		return dummyAppName
+	}
+	configAppVersionExpected = 1
+	config.AppVersion = func() string {
+		configAppVersionCalled++
+		return dummyAppVersion
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyTimestamp
+	}
+	tracingGetSessionSpanExpected = 1
+	tracingGetSessionSpan = func(session sessionModel.Session) *tracingModel.Span {
+		tracingGetSessionSpanCalled++
+		assert.Equal(t, dummySessionObject, session)
+		return dummySpan
+	}
 	jsonutilMarshalIgnoreErrorExpected = 1
 	jsonutilMarshalIgnoreError = func(v interface{}) string {
 		jsonutilMarshalIgnoreErrorCalled++
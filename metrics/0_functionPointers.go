@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// func pointers for injection / testing: metrics.go
+var (
+	strconvItoa        = strconv.Itoa
+	isEnabledFunc      = isEnabled
+	getStatusLabelFunc = getStatusLabel
+	getStatusCodeFunc  = getStatusCode
+)
+
+// func pointers for injection / testing: collector.go
+var (
+	stringsJoin        = strings.Join
+	stringsNewReplacer = strings.NewReplacer
+	sortStrings        = sort.Strings
+	strconvFormatFloat = strconv.FormatFloat
+	strconvFormatUint  = strconv.FormatUint
+)
@@ -0,0 +1,177 @@
+package metrics
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	strconvItoaExpected        int
+	strconvItoaCalled          int
+	isEnabledFuncExpected      int
+	isEnabledFuncCalled        int
+	getStatusLabelFuncExpected int
+	getStatusLabelFuncCalled   int
+	getStatusCodeFuncExpected  int
+	getStatusCodeFuncCalled    int
+	stringsJoinExpected        int
+	stringsJoinCalled          int
+	stringsNewReplacerExpected int
+	stringsNewReplacerCalled   int
+	sortStringsExpected        int
+	sortStringsCalled          int
+	strconvFormatFloatExpected int
+	strconvFormatFloatCalled   int
+	strconvFormatUintExpected  int
+	strconvFormatUintCalled    int
+)
+
+func createMock(t *testing.T) {
+	strconvItoaExpected = 0
+	strconvItoaCalled = 0
+	strconvItoa = func(i int) string {
+		strconvItoaCalled++
+		return ""
+	}
+	isEnabledFuncExpected = 0
+	isEnabledFuncCalled = 0
+	isEnabledFunc = func() bool {
+		isEnabledFuncCalled++
+		return false
+	}
+	getStatusLabelFuncExpected = 0
+	getStatusLabelFuncCalled = 0
+	getStatusLabelFunc = func(statusCode int) string {
+		getStatusLabelFuncCalled++
+		return ""
+	}
+	getStatusCodeFuncExpected = 0
+	getStatusCodeFuncCalled = 0
+	getStatusCodeFunc = func(responseWriter http.ResponseWriter) int {
+		getStatusCodeFuncCalled++
+		return 0
+	}
+	stringsJoinExpected = 0
+	stringsJoinCalled = 0
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		return ""
+	}
+	stringsNewReplacerExpected = 0
+	stringsNewReplacerCalled = 0
+	stringsNewReplacer = func(oldnew ...string) *strings.Replacer {
+		stringsNewReplacerCalled++
+		return nil
+	}
+	sortStringsExpected = 0
+	sortStringsCalled = 0
+	sortStrings = func(x []string) {
+		sortStringsCalled++
+	}
+	strconvFormatFloatExpected = 0
+	strconvFormatFloatCalled = 0
+	strconvFormatFloat = func(f float64, fmt byte, prec int, bitSize int) string {
+		strconvFormatFloatCalled++
+		return ""
+	}
+	strconvFormatUintExpected = 0
+	strconvFormatUintCalled = 0
+	strconvFormatUint = func(i uint64, base int) string {
+		strconvFormatUintCalled++
+		return ""
+	}
+}
+
+func verifyAll(t *testing.T) {
+	strconvItoa = strconv.Itoa
+	assert.Equal(t, strconvItoaExpected, strconvItoaCalled, "Unexpected number of calls to strconvItoa")
+	isEnabledFunc = isEnabled
+	assert.Equal(t, isEnabledFuncExpected, isEnabledFuncCalled, "Unexpected number of calls to isEnabledFunc")
+	getStatusLabelFunc = getStatusLabel
+	assert.Equal(t, getStatusLabelFuncExpected, getStatusLabelFuncCalled, "Unexpected number of calls to getStatusLabelFunc")
+	getStatusCodeFunc = getStatusCode
+	assert.Equal(t, getStatusCodeFuncExpected, getStatusCodeFuncCalled, "Unexpected number of calls to getStatusCodeFunc")
+	stringsJoin = strings.Join
+	assert.Equal(t, stringsJoinExpected, stringsJoinCalled, "Unexpected number of calls to stringsJoin")
+	stringsNewReplacer = strings.NewReplacer
+	assert.Equal(t, stringsNewReplacerExpected, stringsNewReplacerCalled, "Unexpected number of calls to stringsNewReplacer")
+	sortStrings = sort.Strings
+	assert.Equal(t, sortStringsExpected, sortStringsCalled, "Unexpected number of calls to sortStrings")
+	strconvFormatFloat = strconv.FormatFloat
+	assert.Equal(t, strconvFormatFloatExpected, strconvFormatFloatCalled, "Unexpected number of calls to strconvFormatFloat")
+	strconvFormatUint = strconv.FormatUint
+	assert.Equal(t, strconvFormatUintExpected, strconvFormatUintCalled, "Unexpected number of calls to strconvFormatUint")
+	serverRequestsTotal.series = map[string]*valueSeries{}
+	serverRequestDuration.series = map[string]*histogramSeries{}
+	serverRequestsInFlight.series = map[string]*valueSeries{}
+	serverPanicsRecovered.series = map[string]*valueSeries{}
+	clientRequestsTotal.series = map[string]*valueSeries{}
+	clientRequestDuration.series = map[string]*histogramSeries{}
+}
+
+// mock structs
+type dummyResponseWriter struct {
+	header     http.Header
+	statusCode int
+	body       []byte
+}
+
+func (drw *dummyResponseWriter) Header() http.Header {
+	if drw.header == nil {
+		drw.header = http.Header{}
+	}
+	return drw.header
+}
+
+func (drw *dummyResponseWriter) WriteHeader(statusCode int) {
+	drw.statusCode = statusCode
+}
+
+func (drw *dummyResponseWriter) Write(bytes []byte) (int, error) {
+	drw.body = append(drw.body, bytes...)
+	return len(bytes), nil
+}
+
+type dummyFlushResponseWriter struct {
+	dummyResponseWriter
+	flushed int
+}
+
+func (drw *dummyFlushResponseWriter) Flush() {
+	drw.flushed++
+}
+
+type dummyHijackResponseWriter struct {
+	dummyResponseWriter
+}
+
+func (drw *dummyHijackResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, nil
+}
+
+type dummyPushResponseWriter struct {
+	dummyResponseWriter
+}
+
+func (drw *dummyPushResponseWriter) Push(target string, opts *http.PushOptions) error {
+	return nil
+}
+
+type dummyHijackPushResponseWriter struct {
+	dummyResponseWriter
+}
+
+func (drw *dummyHijackPushResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, nil
+}
+
+func (drw *dummyHijackPushResponseWriter) Push(target string, opts *http.PushOptions) error {
+	return nil
+}
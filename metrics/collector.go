@@ -0,0 +1,215 @@
+package metrics
+
+import (
+	"math"
+	"strings"
+	"sync"
+)
+
+// These are the metric types supported in Prometheus text exposition format
+const (
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+)
+
+type collector interface {
+	write(builder *strings.Builder)
+}
+
+type metricVector struct {
+	name       string
+	help       string
+	metricType string
+	labelNames []string
+}
+
+type valueSeries struct {
+	labelValues []string
+	value       float64
+}
+
+type valueVector struct {
+	metricVector
+	lock   sync.Mutex
+	series map[string]*valueSeries
+}
+
+type histogramSeries struct {
+	labelValues  []string
+	bucketCounts []uint64
+	sum          float64
+	count        uint64
+}
+
+type histogramVector struct {
+	metricVector
+	buckets []float64
+	lock    sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+func newValueVector(name string, help string, metricType string, labelNames ...string) *valueVector {
+	return &valueVector{
+		metricVector: metricVector{
+			name:       name,
+			help:       help,
+			metricType: metricType,
+			labelNames: labelNames,
+		},
+		series: map[string]*valueSeries{},
+	}
+}
+
+func newHistogramVector(name string, help string, buckets []float64, labelNames ...string) *histogramVector {
+	return &histogramVector{
+		metricVector: metricVector{
+			name:       name,
+			help:       help,
+			metricType: typeHistogram,
+			labelNames: labelNames,
+		},
+		buckets: buckets,
+		series:  map[string]*histogramSeries{},
+	}
+}
+
+func getSeriesKey(labelValues []string) string {
+	return stringsJoin(labelValues, "\xff")
+}
+
+func getSortedKeys(keys []string) []string {
+	sortStrings(keys)
+	return keys
+}
+
+func escapeLabelValue(value string) string {
+	return stringsNewReplacer(
+		"\\", "\\\\",
+		"\"", "\\\"",
+		"\n", "\\n",
+	).Replace(value)
+}
+
+func formatLabels(labelNames []string, labelValues []string, extraName string, extraValue string) string {
+	var pairs = []string{}
+	for index, labelName := range labelNames {
+		pairs = append(
+			pairs,
+			labelName+"=\""+escapeLabelValue(labelValues[index])+"\"",
+		)
+	}
+	if extraName != "" {
+		pairs = append(
+			pairs,
+			extraName+"=\""+escapeLabelValue(extraValue)+"\"",
+		)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + stringsJoin(pairs, ",") + "}"
+}
+
+func formatValue(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	if math.IsInf(value, -1) {
+		return "-Inf"
+	}
+	return strconvFormatFloat(value, 'g', -1, 64)
+}
+
+func (vector *metricVector) writeHeader(builder *strings.Builder) {
+	builder.WriteString("# HELP " + vector.name + " " + vector.help + "\n")
+	builder.WriteString("# TYPE " + vector.name + " " + vector.metricType + "\n")
+}
+
+func (vector *valueVector) add(value float64, labelValues ...string) {
+	var key = getSeriesKey(labelValues)
+	vector.lock.Lock()
+	defer vector.lock.Unlock()
+	var series, found = vector.series[key]
+	if !found {
+		series = &valueSeries{
+			labelValues: labelValues,
+		}
+		vector.series[key] = series
+	}
+	series.value += value
+}
+
+func (vector *valueVector) write(builder *strings.Builder) {
+	vector.lock.Lock()
+	defer vector.lock.Unlock()
+	vector.writeHeader(builder)
+	var keys = []string{}
+	for key := range vector.series {
+		keys = append(keys, key)
+	}
+	for _, key := range getSortedKeys(keys) {
+		var series = vector.series[key]
+		builder.WriteString(
+			vector.name +
+				formatLabels(vector.labelNames, series.labelValues, "", "") +
+				" " + formatValue(series.value) + "\n",
+		)
+	}
+}
+
+func (vector *histogramVector) observe(value float64, labelValues ...string) {
+	var key = getSeriesKey(labelValues)
+	vector.lock.Lock()
+	defer vector.lock.Unlock()
+	var series, found = vector.series[key]
+	if !found {
+		series = &histogramSeries{
+			labelValues:  labelValues,
+			bucketCounts: make([]uint64, len(vector.buckets)),
+		}
+		vector.series[key] = series
+	}
+	for index, bucket := range vector.buckets {
+		if value <= bucket {
+			series.bucketCounts[index]++
+		}
+	}
+	series.sum += value
+	series.count++
+}
+
+func (vector *histogramVector) write(builder *strings.Builder) {
+	vector.lock.Lock()
+	defer vector.lock.Unlock()
+	vector.writeHeader(builder)
+	var keys = []string{}
+	for key := range vector.series {
+		keys = append(keys, key)
+	}
+	for _, key := range getSortedKeys(keys) {
+		var series = vector.series[key]
+		for index, bucket := range vector.buckets {
+			builder.WriteString(
+				vector.name + "_bucket" +
+					formatLabels(vector.labelNames, series.labelValues, "le", formatValue(bucket)) +
+					" " + strconvFormatUint(series.bucketCounts[index], 10) + "\n",
+			)
+		}
+		builder.WriteString(
+			vector.name + "_bucket" +
+				formatLabels(vector.labelNames, series.labelValues, "le", formatValue(math.Inf(1))) +
+				" " + strconvFormatUint(series.count, 10) + "\n",
+		)
+		builder.WriteString(
+			vector.name + "_sum" +
+				formatLabels(vector.labelNames, series.labelValues, "", "") +
+				" " + formatValue(series.sum) + "\n",
+		)
+		builder.WriteString(
+			vector.name + "_count" +
+				formatLabels(vector.labelNames, series.labelValues, "", "") +
+				" " + strconvFormatUint(series.count, 10) + "\n",
+		)
+	}
+}
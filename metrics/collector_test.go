@@ -0,0 +1,349 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewValueVector(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyHelp = "some help"
+	var dummyType = "some type"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = newValueVector(
+		dummyName,
+		dummyHelp,
+		dummyType,
+		"label1",
+		"label2",
+	)
+
+	// assert
+	assert.Equal(t, dummyName, result.name)
+	assert.Equal(t, dummyHelp, result.help)
+	assert.Equal(t, dummyType, result.metricType)
+	assert.Equal(t, []string{"label1", "label2"}, result.labelNames)
+	assert.Empty(t, result.series)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewHistogramVector(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyHelp = "some help"
+	var dummyBuckets = []float64{1, 2, 3}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = newHistogramVector(
+		dummyName,
+		dummyHelp,
+		dummyBuckets,
+		"label1",
+	)
+
+	// assert
+	assert.Equal(t, dummyName, result.name)
+	assert.Equal(t, dummyHelp, result.help)
+	assert.Equal(t, typeHistogram, result.metricType)
+	assert.Equal(t, []string{"label1"}, result.labelNames)
+	assert.Equal(t, dummyBuckets, result.buckets)
+	assert.Empty(t, result.series)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	// arrange
+	var dummyValue = "some \\ \"value\"\nnext"
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsNewReplacerExpected = 1
+	stringsNewReplacer = func(oldnew ...string) *strings.Replacer {
+		stringsNewReplacerCalled++
+		assert.Equal(t, []string{"\\", "\\\\", "\"", "\\\"", "\n", "\\n"}, oldnew)
+		return strings.NewReplacer(oldnew...)
+	}
+
+	// SUT + act
+	var result = escapeLabelValue(
+		dummyValue,
+	)
+
+	// assert
+	assert.Equal(t, "some \\\\ \\\"value\\\"\\nnext", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatLabels_NoLabels(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = formatLabels(
+		nil,
+		nil,
+		"",
+		"",
+	)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatLabels_WithExtraLabel(t *testing.T) {
+	// arrange
+	var dummyLabelNames = []string{"name1", "name2"}
+	var dummyLabelValues = []string{"value1", "value\"2"}
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsNewReplacerExpected = 3
+	stringsNewReplacer = func(oldnew ...string) *strings.Replacer {
+		stringsNewReplacerCalled++
+		return strings.NewReplacer(oldnew...)
+	}
+	stringsJoinExpected = 1
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		return strings.Join(elems, sep)
+	}
+
+	// SUT + act
+	var result = formatLabels(
+		dummyLabelNames,
+		dummyLabelValues,
+		"le",
+		"0.5",
+	)
+
+	// assert
+	assert.Equal(t, "{name1=\"value1\",name2=\"value\\\"2\",le=\"0.5\"}", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatValue(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	strconvFormatFloatExpected = 2
+	strconvFormatFloat = func(f float64, fmt byte, prec int, bitSize int) string {
+		strconvFormatFloatCalled++
+		assert.Equal(t, byte('g'), fmt)
+		assert.Equal(t, -1, prec)
+		assert.Equal(t, 64, bitSize)
+		return strconv.FormatFloat(f, fmt, prec, bitSize)
+	}
+
+	// SUT + act + assert
+	assert.Equal(t, "+Inf", formatValue(math.Inf(1)))
+	assert.Equal(t, "-Inf", formatValue(math.Inf(-1)))
+	assert.Equal(t, "0.25", formatValue(0.25))
+	assert.Equal(t, "3", formatValue(3))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestValueVector_AddAndWrite(t *testing.T) {
+	// arrange
+	var dummyVector = newValueVector(
+		"some_metric",
+		"some help",
+		typeCounter,
+		"label",
+	)
+	var builder strings.Builder
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsJoinExpected = 5
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		return strings.Join(elems, sep)
+	}
+	stringsNewReplacerExpected = 2
+	stringsNewReplacer = func(oldnew ...string) *strings.Replacer {
+		stringsNewReplacerCalled++
+		return strings.NewReplacer(oldnew...)
+	}
+	sortStringsExpected = 1
+	sortStrings = func(x []string) {
+		sortStringsCalled++
+		sort.Strings(x)
+	}
+	strconvFormatFloatExpected = 2
+	strconvFormatFloat = func(f float64, fmt byte, prec int, bitSize int) string {
+		strconvFormatFloatCalled++
+		assert.Equal(t, byte('g'), fmt)
+		assert.Equal(t, -1, prec)
+		assert.Equal(t, 64, bitSize)
+		return strconv.FormatFloat(f, fmt, prec, bitSize)
+	}
+
+	// SUT + act
+	dummyVector.add(1, "b")
+	dummyVector.add(2, "a")
+	dummyVector.add(3, "b")
+	dummyVector.write(&builder)
+
+	// assert
+	assert.Equal(
+		t,
+		"# HELP some_metric some help\n"+
+			"# TYPE some_metric counter\n"+
+			"some_metric{label=\"a\"} 2\n"+
+			"some_metric{label=\"b\"} 4\n",
+		builder.String(),
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestValueVector_WriteNoLabels(t *testing.T) {
+	// arrange
+	var dummyVector = newValueVector(
+		"some_gauge",
+		"some help",
+		typeGauge,
+	)
+	var builder strings.Builder
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsJoinExpected = 2
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		return strings.Join(elems, sep)
+	}
+	sortStringsExpected = 1
+	sortStrings = func(x []string) {
+		sortStringsCalled++
+		sort.Strings(x)
+	}
+	strconvFormatFloatExpected = 1
+	strconvFormatFloat = func(f float64, fmt byte, prec int, bitSize int) string {
+		strconvFormatFloatCalled++
+		assert.Equal(t, byte('g'), fmt)
+		assert.Equal(t, -1, prec)
+		assert.Equal(t, 64, bitSize)
+		return strconv.FormatFloat(f, fmt, prec, bitSize)
+	}
+
+	// SUT + act
+	dummyVector.add(2)
+	dummyVector.add(-1)
+	dummyVector.write(&builder)
+
+	// assert
+	assert.Equal(
+		t,
+		"# HELP some_gauge some help\n"+
+			"# TYPE some_gauge gauge\n"+
+			"some_gauge 1\n",
+		builder.String(),
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestHistogramVector_ObserveAndWrite(t *testing.T) {
+	// arrange
+	var dummyVector = newHistogramVector(
+		"some_histogram",
+		"some help",
+		[]float64{0.5, 1},
+		"label",
+	)
+	var builder strings.Builder
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsJoinExpected = 8
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		return strings.Join(elems, sep)
+	}
+	stringsNewReplacerExpected = 8
+	stringsNewReplacer = func(oldnew ...string) *strings.Replacer {
+		stringsNewReplacerCalled++
+		return strings.NewReplacer(oldnew...)
+	}
+	sortStringsExpected = 1
+	sortStrings = func(x []string) {
+		sortStringsCalled++
+		sort.Strings(x)
+	}
+	strconvFormatFloatExpected = 3
+	strconvFormatFloat = func(f float64, fmt byte, prec int, bitSize int) string {
+		strconvFormatFloatCalled++
+		assert.Equal(t, byte('g'), fmt)
+		assert.Equal(t, -1, prec)
+		assert.Equal(t, 64, bitSize)
+		return strconv.FormatFloat(f, fmt, prec, bitSize)
+	}
+	strconvFormatUintExpected = 4
+	strconvFormatUint = func(i uint64, base int) string {
+		strconvFormatUintCalled++
+		assert.Equal(t, 10, base)
+		return strconv.FormatUint(i, base)
+	}
+
+	// SUT + act
+	dummyVector.observe(0.25, "a")
+	dummyVector.observe(0.75, "a")
+	dummyVector.observe(2, "a")
+	dummyVector.write(&builder)
+
+	// assert
+	assert.Equal(
+		t,
+		"# HELP some_histogram some help\n"+
+			"# TYPE some_histogram histogram\n"+
+			"some_histogram_bucket{label=\"a\",le=\"0.5\"} 1\n"+
+			"some_histogram_bucket{label=\"a\",le=\"1\"} 2\n"+
+			"some_histogram_bucket{label=\"a\",le=\"+Inf\"} 3\n"+
+			"some_histogram_sum{label=\"a\"} 3\n"+
+			"some_histogram_count{label=\"a\"} 3\n",
+		builder.String(),
+	)
+
+	// verify
+	verifyAll(t)
+}
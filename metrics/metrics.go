@@ -0,0 +1,249 @@
+package metrics
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+)
+
+// These are the constants used by the built-in metrics endpoint
+const (
+	Endpoint    = "Metrics"
+	contentType = "text/plain; version=0.0.4; charset=utf-8"
+	statusError = "error"
+)
+
+var (
+	defaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+	serverRequestsTotal = newValueVector(
+		"http_server_requests_total",
+		"Total number of API sessions handled, by endpoint, method and status code.",
+		typeCounter,
+		"endpoint",
+		"method",
+		"status",
+	)
+	serverRequestDuration = newHistogramVector(
+		"http_server_request_duration_seconds",
+		"Latency of API sessions in seconds, by endpoint and method.",
+		defaultBuckets,
+		"endpoint",
+		"method",
+	)
+	serverRequestsInFlight = newValueVector(
+		"http_server_requests_in_flight",
+		"Number of API sessions currently being handled.",
+		typeGauge,
+	)
+	serverPanicsRecovered = newValueVector(
+		"http_server_panics_recovered_total",
+		"Total number of panics recovered from API sessions, by endpoint.",
+		typeCounter,
+		"endpoint",
+	)
+	clientRequestsTotal = newValueVector(
+		"http_client_requests_total",
+		"Total number of outbound network requests, by host, method and status code.",
+		typeCounter,
+		"host",
+		"method",
+		"status",
+	)
+	clientRequestDuration = newHistogramVector(
+		"http_client_request_duration_seconds",
+		"Latency of outbound network requests in seconds, by host and method.",
+		defaultBuckets,
+		"host",
+		"method",
+	)
+
+	collectors = []collector{
+		serverRequestsTotal,
+		serverRequestDuration,
+		serverRequestsInFlight,
+		serverPanicsRecovered,
+		clientRequestsTotal,
+		clientRequestDuration,
+	}
+)
+
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (recorder *statusRecorder) WriteHeader(statusCode int) {
+	if recorder.statusCode == 0 {
+		recorder.statusCode = statusCode
+	}
+	recorder.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (recorder *statusRecorder) Write(body []byte) (int, error) {
+	if recorder.statusCode == 0 {
+		recorder.statusCode = http.StatusOK
+	}
+	return recorder.ResponseWriter.Write(body)
+}
+
+func (recorder *statusRecorder) Flush() {
+	var flusher, ok = recorder.ResponseWriter.(http.Flusher)
+	if ok {
+		flusher.Flush()
+	}
+}
+
+func (recorder *statusRecorder) recordedStatusCode() int {
+	return recorder.statusCode
+}
+
+type statusCodeRecorder interface {
+	recordedStatusCode() int
+}
+
+// the wrappers below expose http.Hijacker and http.Pusher only when the wrapped response writer implements them
+type hijackerRecorder struct {
+	*statusRecorder
+	http.Hijacker
+}
+
+type pusherRecorder struct {
+	*statusRecorder
+	http.Pusher
+}
+
+type hijackerPusherRecorder struct {
+	*statusRecorder
+	http.Hijacker
+	http.Pusher
+}
+
+func isEnabled() bool {
+	return customization.MetricsPath != nil
+}
+
+func getStatusLabel(statusCode int) string {
+	if statusCode <= 0 {
+		return statusError
+	}
+	return strconvItoa(statusCode)
+}
+
+func getStatusCode(responseWriter http.ResponseWriter) int {
+	var recorder, ok = responseWriter.(statusCodeRecorder)
+	if !ok || recorder.recordedStatusCode() == 0 {
+		return http.StatusOK
+	}
+	return recorder.recordedStatusCode()
+}
+
+// WrapResponseWriter wraps the given HTTP response writer to record the status code written for the current API session when metrics are enabled
+func WrapResponseWriter(responseWriter http.ResponseWriter) http.ResponseWriter {
+	if !isEnabledFunc() {
+		return responseWriter
+	}
+	var recorder = &statusRecorder{
+		ResponseWriter: responseWriter,
+	}
+	var hijacker, isHijacker = responseWriter.(http.Hijacker)
+	var pusher, isPusher = responseWriter.(http.Pusher)
+	if isHijacker && isPusher {
+		return &hijackerPusherRecorder{
+			statusRecorder: recorder,
+			Hijacker:       hijacker,
+			Pusher:         pusher,
+		}
+	} else if isHijacker {
+		return &hijackerRecorder{
+			statusRecorder: recorder,
+			Hijacker:       hijacker,
+		}
+	} else if isPusher {
+		return &pusherRecorder{
+			statusRecorder: recorder,
+			Pusher:         pusher,
+		}
+	}
+	return recorder
+}
+
+// SessionStarted records the start of an API session as in flight
+func SessionStarted() {
+	if !isEnabledFunc() {
+		return
+	}
+	serverRequestsInFlight.add(1)
+}
+
+// SessionFinished records the completion of an API session for the given endpoint and method, with the status code taken from the wrapped response writer
+func SessionFinished(endpoint string, method string, responseWriter http.ResponseWriter, duration time.Duration) {
+	if !isEnabledFunc() {
+		return
+	}
+	serverRequestsInFlight.add(-1)
+	serverRequestsTotal.add(
+		1,
+		endpoint,
+		method,
+		getStatusLabelFunc(
+			getStatusCodeFunc(
+				responseWriter,
+			),
+		),
+	)
+	serverRequestDuration.observe(
+		duration.Seconds(),
+		endpoint,
+		method,
+	)
+}
+
+// PanicRecovered records a panic recovered from an API session for the given endpoint
+func PanicRecovered(endpoint string) {
+	if !isEnabledFunc() {
+		return
+	}
+	serverPanicsRecovered.add(
+		1,
+		endpoint,
+	)
+}
+
+// NetworkRequestFinished records the completion of an outbound network request for the given host and method; status code should be 0 if no response is received
+func NetworkRequestFinished(host string, method string, statusCode int, duration time.Duration) {
+	if !isEnabledFunc() {
+		return
+	}
+	clientRequestsTotal.add(
+		1,
+		host,
+		method,
+		getStatusLabelFunc(
+			statusCode,
+		),
+	)
+	clientRequestDuration.observe(
+		duration.Seconds(),
+		host,
+		method,
+	)
+}
+
+// Handler writes all recorded metrics to the HTTP response in Prometheus text exposition format
+func Handler(
+	responseWriter http.ResponseWriter,
+	httpRequest *http.Request,
+) {
+	var builder strings.Builder
+	for _, collector := range collectors {
+		collector.write(&builder)
+	}
+	responseWriter.Header().Set("Content-Type", contentType)
+	responseWriter.WriteHeader(http.StatusOK)
+	responseWriter.Write(
+		[]byte(builder.String()),
+	)
+}
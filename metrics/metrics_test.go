@@ -0,0 +1,706 @@
+package metrics
+
+import (
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+)
+
+func TestStatusRecorder_WriteHeader(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyStatusCode = rand.Intn(600)
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &statusRecorder{
+		ResponseWriter: dummyResponseWriter,
+	}
+
+	// act
+	sut.WriteHeader(dummyStatusCode)
+	sut.WriteHeader(dummyStatusCode + 1)
+
+	// assert
+	assert.Equal(t, dummyStatusCode, sut.statusCode)
+	assert.Equal(t, dummyStatusCode+1, dummyResponseWriter.statusCode)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStatusRecorder_Write(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyBody = []byte("some body")
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &statusRecorder{
+		ResponseWriter: dummyResponseWriter,
+	}
+
+	// act
+	var count, err = sut.Write(dummyBody)
+
+	// assert
+	assert.Equal(t, len(dummyBody), count)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, sut.statusCode)
+	assert.Equal(t, dummyBody, dummyResponseWriter.body)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStatusRecorder_Flush_NotFlusher(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &statusRecorder{
+		ResponseWriter: dummyResponseWriter,
+	}
+
+	// act
+	sut.Flush()
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStatusRecorder_Flush_Flusher(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyFlushResponseWriter{}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &statusRecorder{
+		ResponseWriter: dummyResponseWriter,
+	}
+
+	// act
+	sut.Flush()
+
+	// assert
+	assert.Equal(t, 1, dummyResponseWriter.flushed)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsEnabled_NotSet(t *testing.T) {
+	// stub
+	customization.MetricsPath = nil
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isEnabled()
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsEnabled_Set(t *testing.T) {
+	// stub
+	customization.MetricsPath = func() string {
+		return "some path"
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isEnabled()
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+	customization.MetricsPath = nil
+}
+
+func TestGetStatusLabel_NoStatus(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getStatusLabel(0)
+
+	// assert
+	assert.Equal(t, statusError, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetStatusLabel_WithStatus(t *testing.T) {
+	// arrange
+	var dummyStatusCode = rand.Intn(600) + 1
+	var dummyLabel = "some label"
+
+	// mock
+	createMock(t)
+
+	// expect
+	strconvItoaExpected = 1
+	strconvItoa = func(i int) string {
+		strconvItoaCalled++
+		assert.Equal(t, dummyStatusCode, i)
+		return dummyLabel
+	}
+
+	// SUT + act
+	var result = getStatusLabel(dummyStatusCode)
+
+	// assert
+	assert.Equal(t, dummyLabel, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetStatusCode_NotRecorder(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getStatusCode(dummyResponseWriter)
+
+	// assert
+	assert.Equal(t, http.StatusOK, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetStatusCode_NothingWritten(t *testing.T) {
+	// arrange
+	var dummyRecorder = &statusRecorder{}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getStatusCode(dummyRecorder)
+
+	// assert
+	assert.Equal(t, http.StatusOK, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetStatusCode_StatusWritten(t *testing.T) {
+	// arrange
+	var dummyStatusCode = rand.Intn(600) + 1
+	var dummyRecorder = &statusRecorder{
+		statusCode: dummyStatusCode,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getStatusCode(dummyRecorder)
+
+	// assert
+	assert.Equal(t, dummyStatusCode, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetStatusCode_WrappedRecorder(t *testing.T) {
+	// arrange
+	var dummyStatusCode = rand.Intn(600) + 1
+	var dummyRecorder = &hijackerPusherRecorder{
+		statusRecorder: &statusRecorder{
+			statusCode: dummyStatusCode,
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getStatusCode(dummyRecorder)
+
+	// assert
+	assert.Equal(t, dummyStatusCode, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWrapResponseWriter_NotEnabled(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEnabledFuncExpected = 1
+	isEnabledFunc = func() bool {
+		isEnabledFuncCalled++
+		return false
+	}
+
+	// SUT + act
+	var result = WrapResponseWriter(dummyResponseWriter)
+
+	// assert
+	assert.Equal(t, dummyResponseWriter, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWrapResponseWriter_Enabled(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEnabledFuncExpected = 1
+	isEnabledFunc = func() bool {
+		isEnabledFuncCalled++
+		return true
+	}
+
+	// SUT + act
+	var result = WrapResponseWriter(dummyResponseWriter)
+
+	// assert
+	assert.Equal(t, &statusRecorder{ResponseWriter: dummyResponseWriter}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWrapResponseWriter_Enabled_Hijacker(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyHijackResponseWriter{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEnabledFuncExpected = 1
+	isEnabledFunc = func() bool {
+		isEnabledFuncCalled++
+		return true
+	}
+
+	// SUT + act
+	var result = WrapResponseWriter(dummyResponseWriter)
+
+	// assert
+	assert.Equal(t, &hijackerRecorder{statusRecorder: &statusRecorder{ResponseWriter: dummyResponseWriter}, Hijacker: dummyResponseWriter}, result)
+	var _, isHijacker = result.(http.Hijacker)
+	assert.True(t, isHijacker)
+	var _, isPusher = result.(http.Pusher)
+	assert.False(t, isPusher)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWrapResponseWriter_Enabled_Pusher(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyPushResponseWriter{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEnabledFuncExpected = 1
+	isEnabledFunc = func() bool {
+		isEnabledFuncCalled++
+		return true
+	}
+
+	// SUT + act
+	var result = WrapResponseWriter(dummyResponseWriter)
+
+	// assert
+	assert.Equal(t, &pusherRecorder{statusRecorder: &statusRecorder{ResponseWriter: dummyResponseWriter}, Pusher: dummyResponseWriter}, result)
+	var _, isHijacker = result.(http.Hijacker)
+	assert.False(t, isHijacker)
+	var _, isPusher = result.(http.Pusher)
+	assert.True(t, isPusher)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWrapResponseWriter_Enabled_HijackerPusher(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyHijackPushResponseWriter{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEnabledFuncExpected = 1
+	isEnabledFunc = func() bool {
+		isEnabledFuncCalled++
+		return true
+	}
+
+	// SUT + act
+	var result = WrapResponseWriter(dummyResponseWriter)
+
+	// assert
+	assert.Equal(t, &hijackerPusherRecorder{statusRecorder: &statusRecorder{ResponseWriter: dummyResponseWriter}, Hijacker: dummyResponseWriter, Pusher: dummyResponseWriter}, result)
+	var _, isHijacker = result.(http.Hijacker)
+	assert.True(t, isHijacker)
+	var _, isPusher = result.(http.Pusher)
+	assert.True(t, isPusher)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSessionStarted_NotEnabled(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	isEnabledFuncExpected = 1
+	isEnabledFunc = func() bool {
+		isEnabledFuncCalled++
+		return false
+	}
+
+	// SUT + act
+	SessionStarted()
+
+	// assert
+	assert.Empty(t, serverRequestsInFlight.series)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSessionStarted_Enabled(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	isEnabledFuncExpected = 1
+	isEnabledFunc = func() bool {
+		isEnabledFuncCalled++
+		return true
+	}
+	stringsJoinExpected = 1
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		return strings.Join(elems, sep)
+	}
+
+	// SUT + act
+	SessionStarted()
+
+	// assert
+	assert.Equal(t, float64(1), serverRequestsInFlight.series[""].value)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSessionFinished_NotEnabled(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEnabledFuncExpected = 1
+	isEnabledFunc = func() bool {
+		isEnabledFuncCalled++
+		return false
+	}
+
+	// SUT + act
+	SessionFinished(
+		"some endpoint",
+		http.MethodGet,
+		dummyResponseWriter,
+		time.Second,
+	)
+
+	// assert
+	assert.Empty(t, serverRequestsInFlight.series)
+	assert.Empty(t, serverRequestsTotal.series)
+	assert.Empty(t, serverRequestDuration.series)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSessionFinished_Enabled(t *testing.T) {
+	// arrange
+	var dummyEndpoint = "some endpoint"
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyStatusCode = rand.Intn(600)
+	var dummyStatusLabel = "some status"
+	var dummyDuration = 250 * time.Millisecond
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEnabledFuncExpected = 1
+	isEnabledFunc = func() bool {
+		isEnabledFuncCalled++
+		return true
+	}
+	getStatusCodeFuncExpected = 1
+	getStatusCodeFunc = func(responseWriter http.ResponseWriter) int {
+		getStatusCodeFuncCalled++
+		assert.Equal(t, dummyResponseWriter, responseWriter)
+		return dummyStatusCode
+	}
+	getStatusLabelFuncExpected = 1
+	getStatusLabelFunc = func(statusCode int) string {
+		getStatusLabelFuncCalled++
+		assert.Equal(t, dummyStatusCode, statusCode)
+		return dummyStatusLabel
+	}
+	stringsJoinExpected = 3
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		return strings.Join(elems, sep)
+	}
+
+	// SUT + act
+	SessionFinished(
+		dummyEndpoint,
+		http.MethodGet,
+		dummyResponseWriter,
+		dummyDuration,
+	)
+
+	// assert
+	assert.Equal(t, float64(-1), serverRequestsInFlight.series[""].value)
+	var totalSeries = serverRequestsTotal.series[strings.Join([]string{dummyEndpoint, http.MethodGet, dummyStatusLabel}, "\xff")]
+	assert.NotNil(t, totalSeries)
+	assert.Equal(t, float64(1), totalSeries.value)
+	var durationSeries = serverRequestDuration.series[strings.Join([]string{dummyEndpoint, http.MethodGet}, "\xff")]
+	assert.NotNil(t, durationSeries)
+	assert.Equal(t, uint64(1), durationSeries.count)
+	assert.Equal(t, dummyDuration.Seconds(), durationSeries.sum)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestPanicRecovered_NotEnabled(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	isEnabledFuncExpected = 1
+	isEnabledFunc = func() bool {
+		isEnabledFuncCalled++
+		return false
+	}
+
+	// SUT + act
+	PanicRecovered("some endpoint")
+
+	// assert
+	assert.Empty(t, serverPanicsRecovered.series)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestPanicRecovered_Enabled(t *testing.T) {
+	// arrange
+	var dummyEndpoint = "some endpoint"
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEnabledFuncExpected = 1
+	isEnabledFunc = func() bool {
+		isEnabledFuncCalled++
+		return true
+	}
+	stringsJoinExpected = 1
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		return strings.Join(elems, sep)
+	}
+
+	// SUT + act
+	PanicRecovered(dummyEndpoint)
+
+	// assert
+	assert.Equal(t, float64(1), serverPanicsRecovered.series[dummyEndpoint].value)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestFinished_NotEnabled(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	isEnabledFuncExpected = 1
+	isEnabledFunc = func() bool {
+		isEnabledFuncCalled++
+		return false
+	}
+
+	// SUT + act
+	NetworkRequestFinished(
+		"some host",
+		http.MethodGet,
+		http.StatusOK,
+		time.Second,
+	)
+
+	// assert
+	assert.Empty(t, clientRequestsTotal.series)
+	assert.Empty(t, clientRequestDuration.series)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestFinished_Enabled(t *testing.T) {
+	// arrange
+	var dummyHost = "some host"
+	var dummyStatusCode = rand.Intn(600)
+	var dummyStatusLabel = "some status"
+	var dummyDuration = 2 * time.Second
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEnabledFuncExpected = 1
+	isEnabledFunc = func() bool {
+		isEnabledFuncCalled++
+		return true
+	}
+	getStatusLabelFuncExpected = 1
+	getStatusLabelFunc = func(statusCode int) string {
+		getStatusLabelFuncCalled++
+		assert.Equal(t, dummyStatusCode, statusCode)
+		return dummyStatusLabel
+	}
+	stringsJoinExpected = 2
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		return strings.Join(elems, sep)
+	}
+
+	// SUT + act
+	NetworkRequestFinished(
+		dummyHost,
+		http.MethodPost,
+		dummyStatusCode,
+		dummyDuration,
+	)
+
+	// assert
+	var totalSeries = clientRequestsTotal.series[strings.Join([]string{dummyHost, http.MethodPost, dummyStatusLabel}, "\xff")]
+	assert.NotNil(t, totalSeries)
+	assert.Equal(t, float64(1), totalSeries.value)
+	var durationSeries = clientRequestDuration.series[strings.Join([]string{dummyHost, http.MethodPost}, "\xff")]
+	assert.NotNil(t, durationSeries)
+	assert.Equal(t, uint64(1), durationSeries.count)
+	assert.Equal(t, dummyDuration.Seconds(), durationSeries.sum)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestHandler(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyHTTPRequest = &http.Request{}
+
+	// stub
+	serverPanicsRecovered.add(1, "some endpoint")
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsJoinExpected = 1
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		return strings.Join(elems, sep)
+	}
+	stringsNewReplacerExpected = 1
+	stringsNewReplacer = func(oldnew ...string) *strings.Replacer {
+		stringsNewReplacerCalled++
+		return strings.NewReplacer(oldnew...)
+	}
+	sortStringsExpected = 6
+	sortStrings = func(x []string) {
+		sortStringsCalled++
+		sort.Strings(x)
+	}
+	strconvFormatFloatExpected = 1
+	strconvFormatFloat = func(f float64, fmt byte, prec int, bitSize int) string {
+		strconvFormatFloatCalled++
+		return strconv.FormatFloat(f, fmt, prec, bitSize)
+	}
+
+	// SUT + act
+	Handler(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	var body = string(dummyResponseWriter.body)
+	assert.Equal(t, contentType, dummyResponseWriter.header.Get("Content-Type"))
+	assert.Equal(t, http.StatusOK, dummyResponseWriter.statusCode)
+	assert.True(t, strings.Contains(body, "# TYPE http_server_requests_total counter\n"))
+	assert.True(t, strings.Contains(body, "# TYPE http_server_request_duration_seconds histogram\n"))
+	assert.True(t, strings.Contains(body, "# TYPE http_server_requests_in_flight gauge\n"))
+	assert.True(t, strings.Contains(body, "# TYPE http_client_requests_total counter\n"))
+	assert.True(t, strings.Contains(body, "# TYPE http_client_request_duration_seconds histogram\n"))
+	assert.True(t, strings.Contains(body, "http_server_panics_recovered_total{endpoint=\"some endpoint\"} 1\n"))
+
+	// verify
+	verifyAll(t)
+}
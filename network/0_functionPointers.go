@@ -2,8 +2,15 @@ package network
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"math"
+	"math/rand"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -13,40 +20,95 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/headerutil"
 	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger"
+	"github.com/zhongjie-cai/WebServiceTemplate/metrics"
 	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/tracing"
 )
 
 // func pointers for injection / testing: logCategory.go
 var (
-	stringsNewReader                = strings.NewReader
-	httpNewRequest                  = http.NewRequest
-	apperrorWrapSimpleError         = apperror.WrapSimpleError
-	loggerNetworkCall               = logger.NetworkCall
-	loggerNetworkRequest            = logger.NetworkRequest
-	loggerNetworkResponse           = logger.NetworkResponse
-	loggerNetworkFinish             = logger.NetworkFinish
-	loggerAppRoot                   = logger.AppRoot
-	ioutilReadAll                   = ioutil.ReadAll
-	httpStatusText                  = http.StatusText
-	strconvItoa                     = strconv.Itoa
-	ioutilNopCloser                 = ioutil.NopCloser
-	bytesNewBuffer                  = bytes.NewBuffer
-	timeutilGetTimeNowUTC           = timeutil.GetTimeNowUTC
-	timeSince                       = time.Since
-	timeSleep                       = time.Sleep
-	headerutilLogHTTPHeader         = headerutil.LogHTTPHeader
-	createHTTPRequestFunc           = createHTTPRequest
-	clientDoFunc                    = clientDo
-	delayForRetryFunc               = delayForRetry
-	clientDoWithRetryFunc           = clientDoWithRetry
-	logErrorResponseFunc            = logErrorResponse
-	logHTTPResponseFunc             = logHTTPResponse
-	doRequestProcessingFunc         = doRequestProcessing
-	jsonutilTryUnmarshal            = jsonutil.TryUnmarshal
-	parseResponseFunc               = parseResponse
-	certificateGetClientCertificate = certificate.GetClientCertificate
-	customizeRoundTripperFunc       = customizeRoundTripper
-	getHTTPTransportFunc            = getHTTPTransport
-	customizeHTTPRequestFunc        = customizeHTTPRequest
-	getClientForRequestFunc         = getClientForRequest
+	stringsNewReader                 = strings.NewReader
+	httpNewRequestWithContext        = http.NewRequestWithContext
+	apperrorWrapSimpleError          = apperror.WrapSimpleError
+	loggerNetworkCall                = logger.NetworkCall
+	loggerNetworkRequest             = logger.NetworkRequest
+	loggerNetworkResponse            = logger.NetworkResponse
+	loggerNetworkFinish              = logger.NetworkFinish
+	loggerAppRoot                    = logger.AppRoot
+	ioutilReadAll                    = ioutil.ReadAll
+	httpStatusText                   = http.StatusText
+	strconvItoa                      = strconv.Itoa
+	ioutilNopCloser                  = ioutil.NopCloser
+	bytesNewBuffer                   = bytes.NewBuffer
+	timeutilGetTimeNowUTC            = timeutil.GetTimeNowUTC
+	timeSince                        = time.Since
+	timeNewTimer                     = time.NewTimer
+	headerutilLogHTTPHeader          = headerutil.LogHTTPHeader
+	createHTTPRequestFunc            = createHTTPRequest
+	clientDoFunc                     = clientDo
+	delayForRetryFunc                = delayForRetry
+	clientDoWithRetryFunc            = clientDoWithRetry
+	logErrorResponseFunc             = logErrorResponse
+	logHTTPResponseFunc              = logHTTPResponse
+	metricsNetworkRequestFinished    = metrics.NetworkRequestFinished
+	recordNetworkMetricsFunc         = recordNetworkMetrics
+	tracingStartSpan                 = tracing.StartSpan
+	tracingEndSpan                   = tracing.EndSpan
+	tracingSetAttribute              = tracing.SetAttribute
+	tracingInjectHeader              = tracing.InjectHeader
+	headerutilSetCorrelationIDHeader = headerutil.SetCorrelationIDHeader
+	tracingGetSessionSpan            = tracing.GetSessionSpan
+	startNetworkSpanFunc             = startNetworkSpan
+	endNetworkSpanFunc               = endNetworkSpan
+	doRequestProcessingFunc          = doRequestProcessing
+	jsonutilTryUnmarshal             = jsonutil.TryUnmarshal
+	parseResponseFunc                = parseResponse
+	certificateGetClientCertificate  = certificate.GetClientCertificate
+	customizeRoundTripperFunc        = customizeRoundTripper
+	getHTTPTransportFunc             = getHTTPTransport
+	customizeHTTPRequestFunc         = customizeHTTPRequest
+	getClientForRequestFunc          = getClientForRequest
+	contextBackground                = context.Background
+	contextWithCancel                = context.WithCancel
+	contextWithTimeout               = context.WithTimeout
+	getRequestContextFunc            = getRequestContext
+	jsonMarshal                      = json.Marshal
+	base64StdEncodingEncodeToString  = base64.StdEncoding.EncodeToString
+	getRequestURLFunc                = getRequestURL
+	isPayloadReplayableFunc          = isPayloadReplayable
+	getRequestBodyFunc               = getRequestBody
+	closePayloadFunc                 = closePayload
+)
+
+// func pointers for injection / testing: circuitBreaker.go
+var (
+	urlParse                     = url.Parse
+	fmtErrorf                    = fmt.Errorf
+	apperrorGetCircuitBreakError = apperror.GetCircuitBreakError
+	getDependencyFunc            = getDependency
+	getCircuitBreakerFunc        = getCircuitBreaker
+	getCircuitFunc               = getCircuit
+	transitCircuitFunc           = transitCircuit
+	getWindowFunc                = getWindow
+	getCoolDownFunc              = getCoolDown
+	getHalfOpenRequestsFunc      = getHalfOpenRequests
+	allowRequestFunc             = allowRequest
+	isFailureFunc                = isFailure
+	shouldTripFunc               = shouldTrip
+	releaseTrialFunc             = releaseTrial
+	recordResultFunc             = recordResult
+)
+
+// func pointers for injection / testing: retry.go
+var (
+	strconvAtoi              = strconv.Atoi
+	httpParseTime            = http.ParseTime
+	mathPow                  = math.Pow
+	randFloat64              = rand.Float64
+	isIdempotentMethodFunc   = isIdempotentMethod
+	isRetryAllowedFunc       = isRetryAllowed
+	getInitialRetryDelayFunc = getInitialRetryDelay
+	getRetryAfterFunc        = getRetryAfter
+	getRetryDelayFunc        = getRetryDelay
+	logRetryAttemptFunc      = logRetryAttempt
 )
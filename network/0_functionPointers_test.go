@@ -2,10 +2,17 @@ package network
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
+	"math/rand"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"testing"
@@ -22,16 +29,20 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/logger"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
+	"github.com/zhongjie-cai/WebServiceTemplate/metrics"
 	"github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/tracing"
+	tracingModel "github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
 )
 
 var (
 	stringsNewReaderExpected                      int
 	stringsNewReaderCalled                        int
-	httpNewRequestExpected                        int
-	httpNewRequestCalled                          int
+	httpNewRequestWithContextExpected             int
+	httpNewRequestWithContextCalled               int
 	apperrorWrapSimpleErrorExpected               int
 	apperrorWrapSimpleErrorCalled                 int
 	loggerNetworkCallExpected                     int
@@ -58,8 +69,8 @@ var (
 	timeutilGetTimeNowUTCCalled                   int
 	timeSinceExpected                             int
 	timeSinceCalled                               int
-	timeSleepExpected                             int
-	timeSleepCalled                               int
+	timeNewTimerExpected                          int
+	timeNewTimerCalled                            int
 	headerutilLogHTTPHeaderExpected               int
 	headerutilLogHTTPHeaderCalled                 int
 	customizationDefaultNetworkRetryDelayExpected int
@@ -76,6 +87,26 @@ var (
 	logErrorResponseFuncCalled                    int
 	logHTTPResponseFuncExpected                   int
 	logHTTPResponseFuncCalled                     int
+	metricsNetworkRequestFinishedExpected         int
+	metricsNetworkRequestFinishedCalled           int
+	recordNetworkMetricsFuncExpected              int
+	recordNetworkMetricsFuncCalled                int
+	tracingStartSpanExpected                      int
+	tracingStartSpanCalled                        int
+	tracingEndSpanExpected                        int
+	tracingEndSpanCalled                          int
+	tracingSetAttributeExpected                   int
+	tracingSetAttributeCalled                     int
+	tracingInjectHeaderExpected                   int
+	tracingInjectHeaderCalled                     int
+	headerutilSetCorrelationIDHeaderExpected      int
+	headerutilSetCorrelationIDHeaderCalled        int
+	tracingGetSessionSpanExpected                 int
+	tracingGetSessionSpanCalled                   int
+	startNetworkSpanFuncExpected                  int
+	startNetworkSpanFuncCalled                    int
+	endNetworkSpanFuncExpected                    int
+	endNetworkSpanFuncCalled                      int
 	doRequestProcessingFuncExpected               int
 	doRequestProcessingFuncCalled                 int
 	jsonutilTryUnmarshalExpected                  int
@@ -94,8 +125,80 @@ var (
 	customizationHTTPRoundTripperCalled           int
 	customizationWrapHTTPRequestExpected          int
 	customizationWrapHTTPRequestCalled            int
+	customizationNetworkCircuitBreakerExpected    int
+	customizationNetworkCircuitBreakerCalled      int
 	getClientForRequestFuncExpected               int
 	getClientForRequestFuncCalled                 int
+	contextBackgroundExpected                     int
+	contextBackgroundCalled                       int
+	contextWithCancelExpected                     int
+	contextWithCancelCalled                       int
+	contextWithTimeoutExpected                    int
+	contextWithTimeoutCalled                      int
+	getRequestContextFuncExpected                 int
+	getRequestContextFuncCalled                   int
+	jsonMarshalExpected                           int
+	jsonMarshalCalled                             int
+	base64StdEncodingEncodeToStringExpected       int
+	base64StdEncodingEncodeToStringCalled         int
+	getRequestURLFuncExpected                     int
+	getRequestURLFuncCalled                       int
+	isPayloadReplayableFuncExpected               int
+	isPayloadReplayableFuncCalled                 int
+	getRequestBodyFuncExpected                    int
+	getRequestBodyFuncCalled                      int
+	closePayloadFuncExpected                      int
+	closePayloadFuncCalled                        int
+	urlParseExpected                              int
+	urlParseCalled                                int
+	fmtErrorfExpected                             int
+	fmtErrorfCalled                               int
+	apperrorGetCircuitBreakErrorExpected          int
+	apperrorGetCircuitBreakErrorCalled            int
+	getDependencyFuncExpected                     int
+	getDependencyFuncCalled                       int
+	getCircuitBreakerFuncExpected                 int
+	getCircuitBreakerFuncCalled                   int
+	getCircuitFuncExpected                        int
+	getCircuitFuncCalled                          int
+	transitCircuitFuncExpected                    int
+	transitCircuitFuncCalled                      int
+	getWindowFuncExpected                         int
+	getWindowFuncCalled                           int
+	getCoolDownFuncExpected                       int
+	getCoolDownFuncCalled                         int
+	getHalfOpenRequestsFuncExpected               int
+	getHalfOpenRequestsFuncCalled                 int
+	allowRequestFuncExpected                      int
+	allowRequestFuncCalled                        int
+	isFailureFuncExpected                         int
+	isFailureFuncCalled                           int
+	shouldTripFuncExpected                        int
+	shouldTripFuncCalled                          int
+	releaseTrialFuncExpected                      int
+	releaseTrialFuncCalled                        int
+	recordResultFuncExpected                      int
+	recordResultFuncCalled                        int
+	strconvAtoiExpected                           int
+	strconvAtoiCalled                             int
+	httpParseTimeExpected                         int
+	httpParseTimeCalled                           int
+	mathPowExpected                               int
+	mathPowCalled                                 int
+	randFloat64Expected                           int
+	randFloat64Called                             int
+	isIdempotentMethodFuncExpected                int
+	isIdempotentMethodFuncCalled                  int
+	isRetryAllowedFuncExpected                    int
+	isRetryAllowedFuncCalled                      int
+	getInitialRetryDelayFuncExpected              int
+	getInitialRetryDelayFuncCalled                int
+	getRetryAfterFuncExpected                     int
+	getRetryAfterFuncCalled                       int
+	getRetryDelayFuncExpected                     int
+	getRetryDelayFuncCalled                       int
+	logRetryAttemptFuncExpected                   int
+	logRetryAttemptFuncCalled                     int
 )
 
 func createMock(t *testing.T) {
@@ -105,10 +208,10 @@ func createMock(t *testing.T) {
 		stringsNewReaderCalled++
 		return nil
 	}
-	httpNewRequestExpected = 0
-	httpNewRequestCalled = 0
-	httpNewRequest = func(method, url string, body io.Reader) (*http.Request, error) {
-		httpNewRequestCalled++
+	httpNewRequestWithContextExpected = 0
+	httpNewRequestWithContextCalled = 0
+	httpNewRequestWithContext = func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+		httpNewRequestWithContextCalled++
 		return nil, nil
 	}
 	apperrorWrapSimpleErrorExpected = 0
@@ -184,10 +287,11 @@ func createMock(t *testing.T) {
 		timeSinceCalled++
 		return 0
 	}
-	timeSleepExpected = 0
-	timeSleepCalled = 0
-	timeSleep = func(d time.Duration) {
-		timeSleepCalled++
+	timeNewTimerExpected = 0
+	timeNewTimerCalled = 0
+	timeNewTimer = func(d time.Duration) *time.Timer {
+		timeNewTimerCalled++
+		return nil
 	}
 	headerutilLogHTTPHeaderExpected = 0
 	headerutilLogHTTPHeaderCalled = 0
@@ -196,7 +300,7 @@ func createMock(t *testing.T) {
 	}
 	createHTTPRequestFuncExpected = 0
 	createHTTPRequestFuncCalled = 0
-	createHTTPRequestFunc = func(networkRequest *networkRequest) (*http.Request, error) {
+	createHTTPRequestFunc = func(networkRequest *networkRequest, attempt int) (*http.Request, error) {
 		createHTTPRequestFuncCalled++
 		return nil, nil
 	}
@@ -211,25 +315,79 @@ func createMock(t *testing.T) {
 	customization.DefaultNetworkRetryDelay = nil
 	delayForRetryFuncExpected = 0
 	delayForRetryFuncCalled = 0
-	delayForRetryFunc = func() {
+	delayForRetryFunc = func(ctx context.Context, delay time.Duration) error {
 		delayForRetryFuncCalled++
+		return nil
 	}
 	clientDoWithRetryFuncExpected = 0
 	clientDoWithRetryFuncCalled = 0
-	clientDoWithRetryFunc = func(client *http.Client, request *http.Request, connRetry int, httpRetry map[int]int) (*http.Response, error) {
+	clientDoWithRetryFunc = func(networkRequest *networkRequest, client *http.Client, request *http.Request) (*http.Response, error) {
 		clientDoWithRetryFuncCalled++
 		return nil, nil
 	}
 	logErrorResponseFuncExpected = 0
 	logErrorResponseFuncCalled = 0
-	logErrorResponseFunc = func(session sessionModel.Session, responseError error, startTime time.Time) {
+	logErrorResponseFunc = func(session sessionModel.Session, responseError error, startTime time.Time, attempt int) {
 		logErrorResponseFuncCalled++
 	}
 	logHTTPResponseFuncExpected = 0
 	logHTTPResponseFuncCalled = 0
-	logHTTPResponseFunc = func(session sessionModel.Session, response *http.Response, startTime time.Time) {
+	logHTTPResponseFunc = func(session sessionModel.Session, response *http.Response, startTime time.Time, attempt int) {
 		logHTTPResponseFuncCalled++
 	}
+	metricsNetworkRequestFinishedExpected = 0
+	metricsNetworkRequestFinishedCalled = 0
+	metricsNetworkRequestFinished = func(host string, method string, statusCode int, duration time.Duration) {
+		metricsNetworkRequestFinishedCalled++
+	}
+	recordNetworkMetricsFuncExpected = 0
+	recordNetworkMetricsFuncCalled = 0
+	recordNetworkMetricsFunc = func(requestObject *http.Request, responseObject *http.Response, startTime time.Time) {
+		recordNetworkMetricsFuncCalled++
+	}
+	tracingStartSpanExpected = 0
+	tracingStartSpanCalled = 0
+	tracingStartSpan = func(parent *tracingModel.Span, name string, kind tracingModel.SpanKind) *tracingModel.Span {
+		tracingStartSpanCalled++
+		return nil
+	}
+	tracingEndSpanExpected = 0
+	tracingEndSpanCalled = 0
+	tracingEndSpan = func(span *tracingModel.Span, err error) {
+		tracingEndSpanCalled++
+	}
+	tracingSetAttributeExpected = 0
+	tracingSetAttributeCalled = 0
+	tracingSetAttribute = func(span *tracingModel.Span, key string, value string) {
+		tracingSetAttributeCalled++
+	}
+	tracingInjectHeaderExpected = 0
+	tracingInjectHeaderCalled = 0
+	tracingInjectHeader = func(header http.Header, span *tracingModel.Span) {
+		tracingInjectHeaderCalled++
+	}
+	headerutilSetCorrelationIDHeaderExpected = 0
+	headerutilSetCorrelationIDHeaderCalled = 0
+	headerutilSetCorrelationIDHeader = func(session sessionModel.Session, header http.Header) {
+		headerutilSetCorrelationIDHeaderCalled++
+	}
+	tracingGetSessionSpanExpected = 0
+	tracingGetSessionSpanCalled = 0
+	tracingGetSessionSpan = func(session sessionModel.Session) *tracingModel.Span {
+		tracingGetSessionSpanCalled++
+		return nil
+	}
+	startNetworkSpanFuncExpected = 0
+	startNetworkSpanFuncCalled = 0
+	startNetworkSpanFunc = func(networkRequest *networkRequest) *tracingModel.Span {
+		startNetworkSpanFuncCalled++
+		return nil
+	}
+	endNetworkSpanFuncExpected = 0
+	endNetworkSpanFuncCalled = 0
+	endNetworkSpanFunc = func(span *tracingModel.Span, responseObject *http.Response, responseError error) {
+		endNetworkSpanFuncCalled++
+	}
 	doRequestProcessingFuncExpected = 0
 	doRequestProcessingFuncCalled = 0
 	doRequestProcessingFunc = func(networkRequest *networkRequest) (*http.Response, error) {
@@ -278,19 +436,227 @@ func createMock(t *testing.T) {
 		getClientForRequestFuncCalled++
 		return nil
 	}
+	contextBackgroundExpected = 0
+	contextBackgroundCalled = 0
+	contextBackground = func() context.Context {
+		contextBackgroundCalled++
+		return nil
+	}
+	contextWithCancelExpected = 0
+	contextWithCancelCalled = 0
+	contextWithCancel = func(parent context.Context) (context.Context, context.CancelFunc) {
+		contextWithCancelCalled++
+		return nil, nil
+	}
+	contextWithTimeoutExpected = 0
+	contextWithTimeoutCalled = 0
+	contextWithTimeout = func(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+		contextWithTimeoutCalled++
+		return nil, nil
+	}
+	getRequestContextFuncExpected = 0
+	getRequestContextFuncCalled = 0
+	getRequestContextFunc = func(networkRequest *networkRequest) (context.Context, context.CancelFunc) {
+		getRequestContextFuncCalled++
+		return nil, nil
+	}
+	jsonMarshalExpected = 0
+	jsonMarshalCalled = 0
+	jsonMarshal = func(v interface{}) ([]byte, error) {
+		jsonMarshalCalled++
+		return nil, nil
+	}
+	base64StdEncodingEncodeToStringExpected = 0
+	base64StdEncodingEncodeToStringCalled = 0
+	base64StdEncodingEncodeToString = func(src []byte) string {
+		base64StdEncodingEncodeToStringCalled++
+		return ""
+	}
+	getRequestURLFuncExpected = 0
+	getRequestURLFuncCalled = 0
+	getRequestURLFunc = func(networkRequest *networkRequest) string {
+		getRequestURLFuncCalled++
+		return ""
+	}
+	isPayloadReplayableFuncExpected = 0
+	isPayloadReplayableFuncCalled = 0
+	isPayloadReplayableFunc = func(networkRequest *networkRequest) bool {
+		isPayloadReplayableFuncCalled++
+		return false
+	}
+	getRequestBodyFuncExpected = 0
+	getRequestBodyFuncCalled = 0
+	getRequestBodyFunc = func(networkRequest *networkRequest, attempt int) (io.Reader, error) {
+		getRequestBodyFuncCalled++
+		return nil, nil
+	}
+	closePayloadFuncExpected = 0
+	closePayloadFuncCalled = 0
+	closePayloadFunc = func(networkRequest *networkRequest) {
+		closePayloadFuncCalled++
+	}
+	urlParseExpected = 0
+	urlParseCalled = 0
+	urlParse = func(rawURL string) (*url.URL, error) {
+		urlParseCalled++
+		return nil, nil
+	}
+	fmtErrorfExpected = 0
+	fmtErrorfCalled = 0
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		return nil
+	}
+	apperrorGetCircuitBreakErrorExpected = 0
+	apperrorGetCircuitBreakErrorCalled = 0
+	apperrorGetCircuitBreakError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetCircuitBreakErrorCalled++
+		return nil
+	}
+	getDependencyFuncExpected = 0
+	getDependencyFuncCalled = 0
+	getDependencyFunc = func(networkRequest *networkRequest) string {
+		getDependencyFuncCalled++
+		return ""
+	}
+	getCircuitBreakerFuncExpected = 0
+	getCircuitBreakerFuncCalled = 0
+	getCircuitBreakerFunc = func(dependency string) *model.CircuitBreaker {
+		getCircuitBreakerFuncCalled++
+		return nil
+	}
+	getCircuitFuncExpected = 0
+	getCircuitFuncCalled = 0
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		return nil
+	}
+	transitCircuitFuncExpected = 0
+	transitCircuitFuncCalled = 0
+	transitCircuitFunc = func(dependency string, dependencyCircuit *circuit, state circuitState, now time.Time) {
+		transitCircuitFuncCalled++
+	}
+	getWindowFuncExpected = 0
+	getWindowFuncCalled = 0
+	getWindowFunc = func(circuitBreaker *model.CircuitBreaker) time.Duration {
+		getWindowFuncCalled++
+		return 0
+	}
+	getCoolDownFuncExpected = 0
+	getCoolDownFuncCalled = 0
+	getCoolDownFunc = func(circuitBreaker *model.CircuitBreaker) time.Duration {
+		getCoolDownFuncCalled++
+		return 0
+	}
+	getHalfOpenRequestsFuncExpected = 0
+	getHalfOpenRequestsFuncCalled = 0
+	getHalfOpenRequestsFunc = func(circuitBreaker *model.CircuitBreaker) int {
+		getHalfOpenRequestsFuncCalled++
+		return 0
+	}
+	allowRequestFuncExpected = 0
+	allowRequestFuncCalled = 0
+	allowRequestFunc = func(dependency string, circuitBreaker *model.CircuitBreaker) error {
+		allowRequestFuncCalled++
+		return nil
+	}
+	isFailureFuncExpected = 0
+	isFailureFuncCalled = 0
+	isFailureFunc = func(circuitBreaker *model.CircuitBreaker, responseObject *http.Response, responseError error) bool {
+		isFailureFuncCalled++
+		return false
+	}
+	shouldTripFuncExpected = 0
+	shouldTripFuncCalled = 0
+	shouldTripFunc = func(circuitBreaker *model.CircuitBreaker, dependencyCircuit *circuit) bool {
+		shouldTripFuncCalled++
+		return false
+	}
+	releaseTrialFuncExpected = 0
+	releaseTrialFuncCalled = 0
+	releaseTrialFunc = func(dependency string, circuitBreaker *model.CircuitBreaker) {
+		releaseTrialFuncCalled++
+	}
+	recordResultFuncExpected = 0
+	recordResultFuncCalled = 0
+	recordResultFunc = func(dependency string, circuitBreaker *model.CircuitBreaker, responseObject *http.Response, responseError error) {
+		recordResultFuncCalled++
+	}
+	strconvAtoiExpected = 0
+	strconvAtoiCalled = 0
+	strconvAtoi = func(s string) (int, error) {
+		strconvAtoiCalled++
+		return 0, nil
+	}
+	httpParseTimeExpected = 0
+	httpParseTimeCalled = 0
+	httpParseTime = func(text string) (time.Time, error) {
+		httpParseTimeCalled++
+		return time.Time{}, nil
+	}
+	mathPowExpected = 0
+	mathPowCalled = 0
+	mathPow = func(x, y float64) float64 {
+		mathPowCalled++
+		return 0
+	}
+	randFloat64Expected = 0
+	randFloat64Called = 0
+	randFloat64 = func() float64 {
+		randFloat64Called++
+		return 0
+	}
+	isIdempotentMethodFuncExpected = 0
+	isIdempotentMethodFuncCalled = 0
+	isIdempotentMethodFunc = func(method string) bool {
+		isIdempotentMethodFuncCalled++
+		return false
+	}
+	isRetryAllowedFuncExpected = 0
+	isRetryAllowedFuncCalled = 0
+	isRetryAllowedFunc = func(method string, retryPolicy *model.RetryPolicy) bool {
+		isRetryAllowedFuncCalled++
+		return false
+	}
+	getInitialRetryDelayFuncExpected = 0
+	getInitialRetryDelayFuncCalled = 0
+	getInitialRetryDelayFunc = func(retryPolicy *model.RetryPolicy) time.Duration {
+		getInitialRetryDelayFuncCalled++
+		return 0
+	}
+	getRetryAfterFuncExpected = 0
+	getRetryAfterFuncCalled = 0
+	getRetryAfterFunc = func(responseObject *http.Response) time.Duration {
+		getRetryAfterFuncCalled++
+		return 0
+	}
+	getRetryDelayFuncExpected = 0
+	getRetryDelayFuncCalled = 0
+	getRetryDelayFunc = func(retryPolicy *model.RetryPolicy, attempt int, responseObject *http.Response) time.Duration {
+		getRetryDelayFuncCalled++
+		return 0
+	}
+	logRetryAttemptFuncExpected = 0
+	logRetryAttemptFuncCalled = 0
+	logRetryAttemptFunc = func(session sessionModel.Session, attempt int, responseObject *http.Response, responseError error, delay time.Duration) {
+		logRetryAttemptFuncCalled++
+	}
 	customizationHTTPRoundTripperExpected = 0
 	customizationHTTPRoundTripperCalled = 0
 	customization.HTTPRoundTripper = nil
 	customizationWrapHTTPRequestExpected = 0
 	customizationWrapHTTPRequestCalled = 0
 	customization.WrapHTTPRequest = nil
+	customizationNetworkCircuitBreakerExpected = 0
+	customizationNetworkCircuitBreakerCalled = 0
+	customization.NetworkCircuitBreaker = nil
 }
 
 func verifyAll(t *testing.T) {
 	stringsNewReader = strings.NewReader
 	assert.Equal(t, stringsNewReaderExpected, stringsNewReaderCalled, "Unexpected number of calls to method stringsNewReader")
-	httpNewRequest = http.NewRequest
-	assert.Equal(t, httpNewRequestExpected, httpNewRequestCalled, "Unexpected number of calls to method httpNewRequest")
+	httpNewRequestWithContext = http.NewRequestWithContext
+	assert.Equal(t, httpNewRequestWithContextExpected, httpNewRequestWithContextCalled, "Unexpected number of calls to method httpNewRequestWithContext")
 	apperrorWrapSimpleError = apperror.WrapSimpleError
 	assert.Equal(t, apperrorWrapSimpleErrorExpected, apperrorWrapSimpleErrorCalled, "Unexpected number of calls to method apperrorWrapSimpleError")
 	loggerNetworkCall = logger.NetworkCall
@@ -319,8 +685,8 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, timeutilGetTimeNowUTCExpected, timeutilGetTimeNowUTCCalled, "Unexpected number of calls to timeutilGetTimeNowUTC")
 	timeSince = time.Since
 	assert.Equal(t, timeSinceExpected, timeSinceCalled, "Unexpected number of calls to timeSince")
-	timeSleep = time.Sleep
-	assert.Equal(t, timeSleepExpected, timeSleepCalled, "Unexpected number of calls to method timeSleep")
+	timeNewTimer = time.NewTimer
+	assert.Equal(t, timeNewTimerExpected, timeNewTimerCalled, "Unexpected number of calls to method timeNewTimer")
 	headerutilLogHTTPHeader = headerutil.LogHTTPHeader
 	assert.Equal(t, headerutilLogHTTPHeaderExpected, headerutilLogHTTPHeaderCalled, "Unexpected number of calls to method headerutilLogHTTPHeader")
 	createHTTPRequestFunc = createHTTPRequest
@@ -335,6 +701,26 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, logErrorResponseFuncExpected, logErrorResponseFuncCalled, "Unexpected number of calls to method logErrorResponseFunc")
 	logHTTPResponseFunc = logHTTPResponse
 	assert.Equal(t, logHTTPResponseFuncExpected, logHTTPResponseFuncCalled, "Unexpected number of calls to method logHTTPResponseFunc")
+	metricsNetworkRequestFinished = metrics.NetworkRequestFinished
+	assert.Equal(t, metricsNetworkRequestFinishedExpected, metricsNetworkRequestFinishedCalled, "Unexpected number of calls to method metricsNetworkRequestFinished")
+	recordNetworkMetricsFunc = recordNetworkMetrics
+	assert.Equal(t, recordNetworkMetricsFuncExpected, recordNetworkMetricsFuncCalled, "Unexpected number of calls to method recordNetworkMetricsFunc")
+	tracingStartSpan = tracing.StartSpan
+	assert.Equal(t, tracingStartSpanExpected, tracingStartSpanCalled, "Unexpected number of calls to method tracingStartSpan")
+	tracingEndSpan = tracing.EndSpan
+	assert.Equal(t, tracingEndSpanExpected, tracingEndSpanCalled, "Unexpected number of calls to method tracingEndSpan")
+	tracingSetAttribute = tracing.SetAttribute
+	assert.Equal(t, tracingSetAttributeExpected, tracingSetAttributeCalled, "Unexpected number of calls to method tracingSetAttribute")
+	tracingInjectHeader = tracing.InjectHeader
+	assert.Equal(t, tracingInjectHeaderExpected, tracingInjectHeaderCalled, "Unexpected number of calls to method tracingInjectHeader")
+	headerutilSetCorrelationIDHeader = headerutil.SetCorrelationIDHeader
+	assert.Equal(t, headerutilSetCorrelationIDHeaderExpected, headerutilSetCorrelationIDHeaderCalled, "Unexpected number of calls to method headerutilSetCorrelationIDHeader")
+	tracingGetSessionSpan = tracing.GetSessionSpan
+	assert.Equal(t, tracingGetSessionSpanExpected, tracingGetSessionSpanCalled, "Unexpected number of calls to method tracingGetSessionSpan")
+	startNetworkSpanFunc = startNetworkSpan
+	assert.Equal(t, startNetworkSpanFuncExpected, startNetworkSpanFuncCalled, "Unexpected number of calls to method startNetworkSpanFunc")
+	endNetworkSpanFunc = endNetworkSpan
+	assert.Equal(t, endNetworkSpanFuncExpected, endNetworkSpanFuncCalled, "Unexpected number of calls to method endNetworkSpanFunc")
 	doRequestProcessingFunc = doRequestProcessing
 	assert.Equal(t, doRequestProcessingFuncExpected, doRequestProcessingFuncCalled, "Unexpected number of calls to method doRequestProcessingFunc")
 	jsonutilTryUnmarshal = jsonutil.TryUnmarshal
@@ -351,10 +737,82 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, customizeHTTPRequestFuncExpected, customizeHTTPRequestFuncCalled, "Unexpected number of calls to method customizeHTTPRequestFunc")
 	getClientForRequestFunc = getClientForRequest
 	assert.Equal(t, getClientForRequestFuncExpected, getClientForRequestFuncCalled, "Unexpected number of calls to method getClientForRequestFunc")
+	contextBackground = context.Background
+	assert.Equal(t, contextBackgroundExpected, contextBackgroundCalled, "Unexpected number of calls to method contextBackground")
+	contextWithCancel = context.WithCancel
+	assert.Equal(t, contextWithCancelExpected, contextWithCancelCalled, "Unexpected number of calls to method contextWithCancel")
+	contextWithTimeout = context.WithTimeout
+	assert.Equal(t, contextWithTimeoutExpected, contextWithTimeoutCalled, "Unexpected number of calls to method contextWithTimeout")
+	getRequestContextFunc = getRequestContext
+	assert.Equal(t, getRequestContextFuncExpected, getRequestContextFuncCalled, "Unexpected number of calls to method getRequestContextFunc")
+	jsonMarshal = json.Marshal
+	assert.Equal(t, jsonMarshalExpected, jsonMarshalCalled, "Unexpected number of calls to method jsonMarshal")
+	base64StdEncodingEncodeToString = base64.StdEncoding.EncodeToString
+	assert.Equal(t, base64StdEncodingEncodeToStringExpected, base64StdEncodingEncodeToStringCalled, "Unexpected number of calls to method base64StdEncodingEncodeToString")
+	getRequestURLFunc = getRequestURL
+	assert.Equal(t, getRequestURLFuncExpected, getRequestURLFuncCalled, "Unexpected number of calls to method getRequestURLFunc")
+	isPayloadReplayableFunc = isPayloadReplayable
+	assert.Equal(t, isPayloadReplayableFuncExpected, isPayloadReplayableFuncCalled, "Unexpected number of calls to method isPayloadReplayableFunc")
+	getRequestBodyFunc = getRequestBody
+	assert.Equal(t, getRequestBodyFuncExpected, getRequestBodyFuncCalled, "Unexpected number of calls to method getRequestBodyFunc")
+	closePayloadFunc = closePayload
+	assert.Equal(t, closePayloadFuncExpected, closePayloadFuncCalled, "Unexpected number of calls to method closePayloadFunc")
+	urlParse = url.Parse
+	assert.Equal(t, urlParseExpected, urlParseCalled, "Unexpected number of calls to method urlParse")
+	fmtErrorf = fmt.Errorf
+	assert.Equal(t, fmtErrorfExpected, fmtErrorfCalled, "Unexpected number of calls to method fmtErrorf")
+	apperrorGetCircuitBreakError = apperror.GetCircuitBreakError
+	assert.Equal(t, apperrorGetCircuitBreakErrorExpected, apperrorGetCircuitBreakErrorCalled, "Unexpected number of calls to method apperrorGetCircuitBreakError")
+	getDependencyFunc = getDependency
+	assert.Equal(t, getDependencyFuncExpected, getDependencyFuncCalled, "Unexpected number of calls to method getDependencyFunc")
+	getCircuitBreakerFunc = getCircuitBreaker
+	assert.Equal(t, getCircuitBreakerFuncExpected, getCircuitBreakerFuncCalled, "Unexpected number of calls to method getCircuitBreakerFunc")
+	getCircuitFunc = getCircuit
+	assert.Equal(t, getCircuitFuncExpected, getCircuitFuncCalled, "Unexpected number of calls to method getCircuitFunc")
+	transitCircuitFunc = transitCircuit
+	assert.Equal(t, transitCircuitFuncExpected, transitCircuitFuncCalled, "Unexpected number of calls to method transitCircuitFunc")
+	getWindowFunc = getWindow
+	assert.Equal(t, getWindowFuncExpected, getWindowFuncCalled, "Unexpected number of calls to method getWindowFunc")
+	getCoolDownFunc = getCoolDown
+	assert.Equal(t, getCoolDownFuncExpected, getCoolDownFuncCalled, "Unexpected number of calls to method getCoolDownFunc")
+	getHalfOpenRequestsFunc = getHalfOpenRequests
+	assert.Equal(t, getHalfOpenRequestsFuncExpected, getHalfOpenRequestsFuncCalled, "Unexpected number of calls to method getHalfOpenRequestsFunc")
+	allowRequestFunc = allowRequest
+	assert.Equal(t, allowRequestFuncExpected, allowRequestFuncCalled, "Unexpected number of calls to method allowRequestFunc")
+	isFailureFunc = isFailure
+	assert.Equal(t, isFailureFuncExpected, isFailureFuncCalled, "Unexpected number of calls to method isFailureFunc")
+	shouldTripFunc = shouldTrip
+	assert.Equal(t, shouldTripFuncExpected, shouldTripFuncCalled, "Unexpected number of calls to method shouldTripFunc")
+	releaseTrialFunc = releaseTrial
+	assert.Equal(t, releaseTrialFuncExpected, releaseTrialFuncCalled, "Unexpected number of calls to method releaseTrialFunc")
+	recordResultFunc = recordResult
+	assert.Equal(t, recordResultFuncExpected, recordResultFuncCalled, "Unexpected number of calls to method recordResultFunc")
+	strconvAtoi = strconv.Atoi
+	assert.Equal(t, strconvAtoiExpected, strconvAtoiCalled, "Unexpected number of calls to method strconvAtoi")
+	httpParseTime = http.ParseTime
+	assert.Equal(t, httpParseTimeExpected, httpParseTimeCalled, "Unexpected number of calls to method httpParseTime")
+	mathPow = math.Pow
+	assert.Equal(t, mathPowExpected, mathPowCalled, "Unexpected number of calls to method mathPow")
+	randFloat64 = rand.Float64
+	assert.Equal(t, randFloat64Expected, randFloat64Called, "Unexpected number of calls to method randFloat64")
+	isIdempotentMethodFunc = isIdempotentMethod
+	assert.Equal(t, isIdempotentMethodFuncExpected, isIdempotentMethodFuncCalled, "Unexpected number of calls to method isIdempotentMethodFunc")
+	isRetryAllowedFunc = isRetryAllowed
+	assert.Equal(t, isRetryAllowedFuncExpected, isRetryAllowedFuncCalled, "Unexpected number of calls to method isRetryAllowedFunc")
+	getInitialRetryDelayFunc = getInitialRetryDelay
+	assert.Equal(t, getInitialRetryDelayFuncExpected, getInitialRetryDelayFuncCalled, "Unexpected number of calls to method getInitialRetryDelayFunc")
+	getRetryAfterFunc = getRetryAfter
+	assert.Equal(t, getRetryAfterFuncExpected, getRetryAfterFuncCalled, "Unexpected number of calls to method getRetryAfterFunc")
+	getRetryDelayFunc = getRetryDelay
+	assert.Equal(t, getRetryDelayFuncExpected, getRetryDelayFuncCalled, "Unexpected number of calls to method getRetryDelayFunc")
+	logRetryAttemptFunc = logRetryAttempt
+	assert.Equal(t, logRetryAttemptFuncExpected, logRetryAttemptFuncCalled, "Unexpected number of calls to method logRetryAttemptFunc")
 	customization.HTTPRoundTripper = nil
 	assert.Equal(t, customizationHTTPRoundTripperExpected, customizationHTTPRoundTripperCalled, "Unexpected number of calls to method customization.HTTPRoundTripper")
 	customization.WrapHTTPRequest = nil
 	assert.Equal(t, customizationWrapHTTPRequestExpected, customizationWrapHTTPRequestCalled, "Unexpected number of calls to method customization.WrapHTTPRequest")
+	customization.NetworkCircuitBreaker = nil
+	assert.Equal(t, customizationNetworkCircuitBreakerExpected, customizationNetworkCircuitBreakerCalled, "Unexpected number of calls to method customization.NetworkCircuitBreaker")
 
 	httpClientWithCert = nil
 	httpClientNoCert = nil
@@ -375,6 +833,11 @@ func (session *dummySession) GetName() string {
 	return ""
 }
 
+func (session *dummySession) GetCorrelationID() string {
+	assert.Fail(session.t, "Unexpected call to GetCorrelationID")
+	return ""
+}
+
 func (session *dummySession) GetRequest() *http.Request {
 	assert.Fail(session.t, "Unexpected call to GetRequest")
 	return nil
@@ -385,6 +848,14 @@ func (session *dummySession) GetResponseWriter() http.ResponseWriter {
 	return nil
 }
 
+func (session *dummySession) SetResponseETag(etag string, weak bool) {
+	assert.Fail(session.t, "Unexpected call to SetResponseETag")
+}
+
+func (session *dummySession) SetResponseLastModified(lastModified time.Time) {
+	assert.Fail(session.t, "Unexpected call to SetResponseLastModified")
+}
+
 func (session *dummySession) GetRequestBody(dataTemplate interface{}) apperrorModel.AppError {
 	assert.Fail(session.t, "Unexpected call to GetRequestBody")
 	return nil
@@ -415,6 +886,26 @@ func (session *dummySession) GetRequestHeaders(name string, dataTemplate interfa
 	return nil
 }
 
+func (session *dummySession) GetRequestFormValue(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValue")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValues(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValues")
+	return nil
+}
+
+func (session *dummySession) GetRequestFile(name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFile")
+	return nil
+}
+
+func (session *dummySession) BindRequest(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to BindRequest")
+	return nil
+}
+
 func (session *dummySession) Attach(name string, value interface{}) bool {
 	assert.Fail(session.t, "Unexpected call to Attach")
 	return false
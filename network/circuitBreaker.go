@@ -0,0 +1,290 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/network/model"
+)
+
+// These are the default settings of circuit breaking
+const (
+	// DefaultCircuitWindow is the default sampling window of request and failure counts while a circuit is closed
+	DefaultCircuitWindow = time.Minute
+	// DefaultCircuitCoolDown is the default duration a circuit stays open before allowing trial requests
+	DefaultCircuitCoolDown = 30 * time.Second
+)
+
+const (
+	minHalfOpenRequests = 1
+)
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// String translates the circuit state
+func (state circuitState) String() string {
+	switch state {
+	case circuitClosed:
+		return "Closed"
+	case circuitOpen:
+		return "Open"
+	case circuitHalfOpen:
+		return "HalfOpen"
+	}
+	return "Unknown"
+}
+
+type circuit struct {
+	state               circuitState
+	windowStart         time.Time
+	openedAt            time.Time
+	requests            int
+	failures            int
+	consecutiveFailures int
+	trialRequests       int
+	trialSuccesses      int
+}
+
+var (
+	circuits    = map[string]*circuit{}
+	circuitLock sync.Mutex
+)
+
+func getDependency(networkRequest *networkRequest) string {
+	if networkRequest.dependency != "" {
+		return networkRequest.dependency
+	}
+	var parsedURL, parseError = urlParse(
+		networkRequest.url,
+	)
+	if parseError != nil ||
+		parsedURL.Host == "" {
+		return networkRequest.url
+	}
+	return parsedURL.Host
+}
+
+func getCircuitBreaker(dependency string) *model.CircuitBreaker {
+	if customization.NetworkCircuitBreaker == nil {
+		return nil
+	}
+	return customization.NetworkCircuitBreaker(
+		dependency,
+	)
+}
+
+func getCircuit(dependency string, now time.Time) *circuit {
+	var dependencyCircuit, found = circuits[dependency]
+	if !found {
+		dependencyCircuit = &circuit{
+			state:       circuitClosed,
+			windowStart: now,
+		}
+		circuits[dependency] = dependencyCircuit
+	}
+	return dependencyCircuit
+}
+
+func transitCircuit(dependency string, dependencyCircuit *circuit, state circuitState, now time.Time) {
+	loggerAppRoot(
+		"network",
+		"circuitBreaker",
+		"Circuit for dependency [%v] transited from [%v] to [%v]",
+		dependency,
+		dependencyCircuit.state,
+		state,
+	)
+	*dependencyCircuit = circuit{
+		state:       state,
+		windowStart: now,
+	}
+	if state == circuitOpen {
+		dependencyCircuit.openedAt = now
+	}
+}
+
+func getWindow(circuitBreaker *model.CircuitBreaker) time.Duration {
+	if circuitBreaker.Window <= 0 {
+		return DefaultCircuitWindow
+	}
+	return circuitBreaker.Window
+}
+
+func getCoolDown(circuitBreaker *model.CircuitBreaker) time.Duration {
+	if circuitBreaker.CoolDown <= 0 {
+		return DefaultCircuitCoolDown
+	}
+	return circuitBreaker.CoolDown
+}
+
+func getHalfOpenRequests(circuitBreaker *model.CircuitBreaker) int {
+	if circuitBreaker.HalfOpenRequests < minHalfOpenRequests {
+		return minHalfOpenRequests
+	}
+	return circuitBreaker.HalfOpenRequests
+}
+
+// allowRequest checks the circuit of the given dependency, moving an open circuit to half-open once cooled down, and returns a CircuitBreak error if the request should fail fast
+func allowRequest(dependency string, circuitBreaker *model.CircuitBreaker) error {
+	if circuitBreaker == nil {
+		return nil
+	}
+	circuitLock.Lock()
+	defer circuitLock.Unlock()
+	var now = timeutilGetTimeNowUTC()
+	var dependencyCircuit = getCircuitFunc(
+		dependency,
+		now,
+	)
+	switch dependencyCircuit.state {
+	case circuitOpen:
+		if now.Sub(dependencyCircuit.openedAt) < getCoolDownFunc(circuitBreaker) {
+			break
+		}
+		transitCircuitFunc(
+			dependency,
+			dependencyCircuit,
+			circuitHalfOpen,
+			now,
+		)
+		fallthrough
+	case circuitHalfOpen:
+		if dependencyCircuit.trialRequests >= getHalfOpenRequestsFunc(circuitBreaker) {
+			break
+		}
+		dependencyCircuit.trialRequests++
+		return nil
+	default:
+		if now.Sub(dependencyCircuit.windowStart) >= getWindowFunc(circuitBreaker) {
+			dependencyCircuit.windowStart = now
+			dependencyCircuit.requests = 0
+			dependencyCircuit.failures = 0
+		}
+		return nil
+	}
+	return apperrorGetCircuitBreakError(
+		fmtErrorf(
+			"Circuit is [%v] for dependency [%v]",
+			dependencyCircuit.state,
+			dependency,
+		),
+	)
+}
+
+func isFailure(circuitBreaker *model.CircuitBreaker, responseObject *http.Response, responseError error) bool {
+	if circuitBreaker.IsFailure != nil {
+		return circuitBreaker.IsFailure(
+			responseObject,
+			responseError,
+		)
+	}
+	if responseError != nil {
+		return true
+	}
+	return responseObject != nil &&
+		responseObject.StatusCode >= http.StatusInternalServerError
+}
+
+func shouldTrip(circuitBreaker *model.CircuitBreaker, dependencyCircuit *circuit) bool {
+	if circuitBreaker.ConsecutiveFailures > 0 &&
+		dependencyCircuit.consecutiveFailures >= circuitBreaker.ConsecutiveFailures {
+		return true
+	}
+	if circuitBreaker.FailureRatio <= 0 ||
+		dependencyCircuit.requests == 0 ||
+		dependencyCircuit.requests < circuitBreaker.MinRequests {
+		return false
+	}
+	return float64(dependencyCircuit.failures)/float64(dependencyCircuit.requests) >= circuitBreaker.FailureRatio
+}
+
+// releaseTrial releases the trial slot taken by a request allowed through the half-open circuit of the given dependency, without recording its result
+func releaseTrial(dependency string, circuitBreaker *model.CircuitBreaker) {
+	if circuitBreaker == nil {
+		return
+	}
+	circuitLock.Lock()
+	defer circuitLock.Unlock()
+	var dependencyCircuit = getCircuitFunc(
+		dependency,
+		timeutilGetTimeNowUTC(),
+	)
+	if dependencyCircuit.state != circuitHalfOpen ||
+		dependencyCircuit.trialRequests == 0 {
+		return
+	}
+	dependencyCircuit.trialRequests--
+}
+
+// recordResult records the result of a request allowed through the circuit of the given dependency, tripping the circuit open upon failure thresholds or failed trial requests, and closing it once all trial requests succeed; a cancelled request is not recorded, but only releases its trial slot
+func recordResult(dependency string, circuitBreaker *model.CircuitBreaker, responseObject *http.Response, responseError error) {
+	if circuitBreaker == nil {
+		return
+	}
+	if errors.Is(responseError, context.Canceled) {
+		releaseTrialFunc(
+			dependency,
+			circuitBreaker,
+		)
+		return
+	}
+	var failed = isFailureFunc(
+		circuitBreaker,
+		responseObject,
+		responseError,
+	)
+	circuitLock.Lock()
+	defer circuitLock.Unlock()
+	var now = timeutilGetTimeNowUTC()
+	var dependencyCircuit = getCircuitFunc(
+		dependency,
+		now,
+	)
+	switch dependencyCircuit.state {
+	case circuitHalfOpen:
+		if failed {
+			transitCircuitFunc(
+				dependency,
+				dependencyCircuit,
+				circuitOpen,
+				now,
+			)
+			return
+		}
+		dependencyCircuit.trialSuccesses++
+		if dependencyCircuit.trialSuccesses >= getHalfOpenRequestsFunc(circuitBreaker) {
+			transitCircuitFunc(
+				dependency,
+				dependencyCircuit,
+				circuitClosed,
+				now,
+			)
+		}
+	case circuitClosed:
+		dependencyCircuit.requests++
+		if failed {
+			dependencyCircuit.failures++
+			dependencyCircuit.consecutiveFailures++
+		} else {
+			dependencyCircuit.consecutiveFailures = 0
+		}
+		if shouldTripFunc(circuitBreaker, dependencyCircuit) {
+			transitCircuitFunc(
+				dependency,
+				dependencyCircuit,
+				circuitOpen,
+				now,
+			)
+		}
+	}
+}
@@ -0,0 +1,1348 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorEnum "github.com/zhongjie-cai/WebServiceTemplate/apperror/enum"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/network/model"
+)
+
+func TestCircuitStateString(t *testing.T) {
+	// arrange
+	var testCases = map[circuitState]string{
+		circuitClosed:    "Closed",
+		circuitOpen:      "Open",
+		circuitHalfOpen:  "HalfOpen",
+		circuitState(-1): "Unknown",
+	}
+
+	for state, expected := range testCases {
+		// mock
+		createMock(t)
+
+		// SUT + act
+		var result = state.String()
+
+		// assert
+		assert.Equal(t, expected, result)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestGetDependency_Named(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyNetworkRequest = &networkRequest{
+		url:        "http://some.host/some/path",
+		dependency: dummyDependency,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getDependency(
+		dummyNetworkRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyDependency, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetDependency_ParseError(t *testing.T) {
+	// arrange
+	var dummyURL = "some URL"
+	var dummyNetworkRequest = &networkRequest{
+		url: dummyURL,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	urlParseExpected = 1
+	urlParse = func(rawURL string) (*url.URL, error) {
+		urlParseCalled++
+		assert.Equal(t, dummyURL, rawURL)
+		return nil, errors.New("some parse error")
+	}
+
+	// SUT + act
+	var result = getDependency(
+		dummyNetworkRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyURL, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetDependency_NoHost(t *testing.T) {
+	// arrange
+	var dummyURL = "/some/path"
+	var dummyNetworkRequest = &networkRequest{
+		url: dummyURL,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	urlParseExpected = 1
+	urlParse = func(rawURL string) (*url.URL, error) {
+		urlParseCalled++
+		return url.Parse(rawURL)
+	}
+
+	// SUT + act
+	var result = getDependency(
+		dummyNetworkRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyURL, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetDependency_Host(t *testing.T) {
+	// arrange
+	var dummyNetworkRequest = &networkRequest{
+		url: "https://some.host:8443/some/path?foo=bar",
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	urlParseExpected = 1
+	urlParse = func(rawURL string) (*url.URL, error) {
+		urlParseCalled++
+		return url.Parse(rawURL)
+	}
+
+	// SUT + act
+	var result = getDependency(
+		dummyNetworkRequest,
+	)
+
+	// assert
+	assert.Equal(t, "some.host:8443", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCircuitBreaker_NoCustomization(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getCircuitBreaker(
+		"some dependency",
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCircuitBreaker_WithCustomization(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationNetworkCircuitBreakerExpected = 1
+	customization.NetworkCircuitBreaker = func(dependency string) *model.CircuitBreaker {
+		customizationNetworkCircuitBreakerCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		return dummyCircuitBreaker
+	}
+
+	// SUT + act
+	var result = getCircuitBreaker(
+		dummyDependency,
+	)
+
+	// assert
+	assert.Equal(t, dummyCircuitBreaker, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCircuit_New(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyNow = time.Now()
+	circuits = map[string]*circuit{}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getCircuit(
+		dummyDependency,
+		dummyNow,
+	)
+
+	// assert
+	assert.Equal(t, circuitClosed, result.state)
+	assert.Equal(t, dummyNow, result.windowStart)
+	assert.Equal(t, result, circuits[dummyDependency])
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCircuit_Existing(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuit = &circuit{state: circuitOpen}
+	circuits = map[string]*circuit{
+		dummyDependency: dummyCircuit,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getCircuit(
+		dummyDependency,
+		time.Now(),
+	)
+
+	// assert
+	assert.Equal(t, dummyCircuit, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestTransitCircuit_Open(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyNow = time.Now()
+	var dummyCircuit = &circuit{
+		state:               circuitClosed,
+		requests:            10,
+		failures:            5,
+		consecutiveFailures: 3,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "network", category)
+		assert.Equal(t, "circuitBreaker", subcategory)
+		assert.Equal(t, "Circuit for dependency [%v] transited from [%v] to [%v]", messageFormat)
+		assert.Equal(t, 3, len(parameters))
+		assert.Equal(t, dummyDependency, parameters[0])
+		assert.Equal(t, circuitClosed, parameters[1])
+		assert.Equal(t, circuitOpen, parameters[2])
+	}
+
+	// SUT + act
+	transitCircuit(
+		dummyDependency,
+		dummyCircuit,
+		circuitOpen,
+		dummyNow,
+	)
+
+	// assert
+	assert.Equal(t, circuit{
+		state:       circuitOpen,
+		windowStart: dummyNow,
+		openedAt:    dummyNow,
+	}, *dummyCircuit)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestTransitCircuit_Closed(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyNow = time.Now()
+	var dummyCircuit = &circuit{
+		state:          circuitHalfOpen,
+		openedAt:       dummyNow.Add(-time.Minute),
+		trialRequests:  2,
+		trialSuccesses: 2,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, circuitHalfOpen, parameters[1])
+		assert.Equal(t, circuitClosed, parameters[2])
+	}
+
+	// SUT + act
+	transitCircuit(
+		dummyDependency,
+		dummyCircuit,
+		circuitClosed,
+		dummyNow,
+	)
+
+	// assert
+	assert.Equal(t, circuit{
+		state:       circuitClosed,
+		windowStart: dummyNow,
+	}, *dummyCircuit)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetWindow(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var defaultResult = getWindow(&model.CircuitBreaker{})
+	var customResult = getWindow(&model.CircuitBreaker{Window: time.Hour})
+
+	// assert
+	assert.Equal(t, DefaultCircuitWindow, defaultResult)
+	assert.Equal(t, time.Hour, customResult)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCoolDown(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var defaultResult = getCoolDown(&model.CircuitBreaker{CoolDown: -time.Second})
+	var customResult = getCoolDown(&model.CircuitBreaker{CoolDown: time.Hour})
+
+	// assert
+	assert.Equal(t, DefaultCircuitCoolDown, defaultResult)
+	assert.Equal(t, time.Hour, customResult)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetHalfOpenRequests(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var defaultResult = getHalfOpenRequests(&model.CircuitBreaker{})
+	var customResult = getHalfOpenRequests(&model.CircuitBreaker{HalfOpenRequests: 3})
+
+	// assert
+	assert.Equal(t, 1, defaultResult)
+	assert.Equal(t, 3, customResult)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAllowRequest_NilCircuitBreaker(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err = allowRequest(
+		"some dependency",
+		nil,
+	)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAllowRequest_Closed_WithinWindow(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyNow = time.Now()
+	var dummyCircuit = &circuit{
+		state:       circuitClosed,
+		windowStart: dummyNow.Add(-time.Second),
+		requests:    10,
+		failures:    2,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	getCircuitFuncExpected = 1
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyNow, now)
+		return dummyCircuit
+	}
+	getWindowFuncExpected = 1
+	getWindowFunc = func(circuitBreaker *model.CircuitBreaker) time.Duration {
+		getWindowFuncCalled++
+		assert.Equal(t, dummyCircuitBreaker, circuitBreaker)
+		return time.Minute
+	}
+
+	// SUT + act
+	var err = allowRequest(
+		dummyDependency,
+		dummyCircuitBreaker,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 10, dummyCircuit.requests)
+	assert.Equal(t, 2, dummyCircuit.failures)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAllowRequest_Closed_WindowExpired(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyNow = time.Now()
+	var dummyCircuit = &circuit{
+		state:               circuitClosed,
+		windowStart:         dummyNow.Add(-time.Hour),
+		requests:            10,
+		failures:            2,
+		consecutiveFailures: 1,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	getCircuitFuncExpected = 1
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		return dummyCircuit
+	}
+	getWindowFuncExpected = 1
+	getWindowFunc = func(circuitBreaker *model.CircuitBreaker) time.Duration {
+		getWindowFuncCalled++
+		return time.Minute
+	}
+
+	// SUT + act
+	var err = allowRequest(
+		dummyDependency,
+		dummyCircuitBreaker,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, dummyNow, dummyCircuit.windowStart)
+	assert.Zero(t, dummyCircuit.requests)
+	assert.Zero(t, dummyCircuit.failures)
+	assert.Equal(t, 1, dummyCircuit.consecutiveFailures)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAllowRequest_Open_CoolingDown(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyNow = time.Now()
+	var dummyCircuit = &circuit{
+		state:    circuitOpen,
+		openedAt: dummyNow.Add(-time.Second),
+	}
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	getCircuitFuncExpected = 1
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		return dummyCircuit
+	}
+	getCoolDownFuncExpected = 1
+	getCoolDownFunc = func(circuitBreaker *model.CircuitBreaker) time.Duration {
+		getCoolDownFuncCalled++
+		assert.Equal(t, dummyCircuitBreaker, circuitBreaker)
+		return time.Minute
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Circuit is [%v] for dependency [%v]", format)
+		assert.Equal(t, 2, len(a))
+		assert.Equal(t, circuitOpen, a[0])
+		assert.Equal(t, dummyDependency, a[1])
+		return dummyError
+	}
+	apperrorGetCircuitBreakErrorExpected = 1
+	apperrorGetCircuitBreakError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetCircuitBreakErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = allowRequest(
+		dummyDependency,
+		dummyCircuitBreaker,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+	assert.Equal(t, circuitOpen, dummyCircuit.state)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAllowRequest_Open_CooledDown(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyNow = time.Now()
+	var dummyCircuit = &circuit{
+		state:    circuitOpen,
+		openedAt: dummyNow.Add(-time.Hour),
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	getCircuitFuncExpected = 1
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		return dummyCircuit
+	}
+	getCoolDownFuncExpected = 1
+	getCoolDownFunc = func(circuitBreaker *model.CircuitBreaker) time.Duration {
+		getCoolDownFuncCalled++
+		return time.Minute
+	}
+	transitCircuitFuncExpected = 1
+	transitCircuitFunc = func(dependency string, dependencyCircuit *circuit, state circuitState, now time.Time) {
+		transitCircuitFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyCircuit, dependencyCircuit)
+		assert.Equal(t, circuitHalfOpen, state)
+		assert.Equal(t, dummyNow, now)
+		*dependencyCircuit = circuit{state: state}
+	}
+	getHalfOpenRequestsFuncExpected = 1
+	getHalfOpenRequestsFunc = func(circuitBreaker *model.CircuitBreaker) int {
+		getHalfOpenRequestsFuncCalled++
+		assert.Equal(t, dummyCircuitBreaker, circuitBreaker)
+		return 1
+	}
+
+	// SUT + act
+	var err = allowRequest(
+		dummyDependency,
+		dummyCircuitBreaker,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, circuitHalfOpen, dummyCircuit.state)
+	assert.Equal(t, 1, dummyCircuit.trialRequests)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAllowRequest_HalfOpen_TrialsExhausted(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyNow = time.Now()
+	var dummyCircuit = &circuit{
+		state:         circuitHalfOpen,
+		trialRequests: 2,
+	}
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	getCircuitFuncExpected = 1
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		return dummyCircuit
+	}
+	getHalfOpenRequestsFuncExpected = 1
+	getHalfOpenRequestsFunc = func(circuitBreaker *model.CircuitBreaker) int {
+		getHalfOpenRequestsFuncCalled++
+		return 2
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, circuitHalfOpen, a[0])
+		return dummyError
+	}
+	apperrorGetCircuitBreakErrorExpected = 1
+	apperrorGetCircuitBreakError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetCircuitBreakErrorCalled++
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = allowRequest(
+		dummyDependency,
+		dummyCircuitBreaker,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+	assert.Equal(t, 2, dummyCircuit.trialRequests)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsFailure_Customized(t *testing.T) {
+	// arrange
+	var dummyResponseObject = &http.Response{StatusCode: http.StatusTooManyRequests}
+	var dummyResponseError = errors.New("some error")
+	var customizedCalled int
+	var dummyCircuitBreaker = &model.CircuitBreaker{
+		IsFailure: func(responseObject *http.Response, responseError error) bool {
+			customizedCalled++
+			assert.Equal(t, dummyResponseObject, responseObject)
+			assert.Equal(t, dummyResponseError, responseError)
+			return false
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isFailure(
+		dummyCircuitBreaker,
+		dummyResponseObject,
+		dummyResponseError,
+	)
+
+	// assert
+	assert.False(t, result)
+	assert.Equal(t, 1, customizedCalled)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsFailure_Default(t *testing.T) {
+	// arrange
+	type testCase struct {
+		responseObject *http.Response
+		responseError  error
+		expected       bool
+	}
+	var testCases = []testCase{
+		{nil, errors.New("some error"), true},
+		{nil, nil, false},
+		{&http.Response{StatusCode: http.StatusOK}, nil, false},
+		{&http.Response{StatusCode: http.StatusNotFound}, nil, false},
+		{&http.Response{StatusCode: http.StatusInternalServerError}, nil, true},
+		{&http.Response{StatusCode: http.StatusServiceUnavailable}, nil, true},
+	}
+
+	for _, test := range testCases {
+		// mock
+		createMock(t)
+
+		// SUT + act
+		var result = isFailure(
+			&model.CircuitBreaker{},
+			test.responseObject,
+			test.responseError,
+		)
+
+		// assert
+		assert.Equal(t, test.expected, result)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestShouldTrip(t *testing.T) {
+	// arrange
+	type testCase struct {
+		circuitBreaker *model.CircuitBreaker
+		circuit        *circuit
+		expected       bool
+	}
+	var testCases = []testCase{
+		{&model.CircuitBreaker{}, &circuit{requests: 10, failures: 10, consecutiveFailures: 10}, false},
+		{&model.CircuitBreaker{ConsecutiveFailures: 3}, &circuit{requests: 3, failures: 3, consecutiveFailures: 3}, true},
+		{&model.CircuitBreaker{ConsecutiveFailures: 3}, &circuit{requests: 3, failures: 2, consecutiveFailures: 2}, false},
+		{&model.CircuitBreaker{FailureRatio: 0.5}, &circuit{}, false},
+		{&model.CircuitBreaker{FailureRatio: 0.5, MinRequests: 10}, &circuit{requests: 9, failures: 9}, false},
+		{&model.CircuitBreaker{FailureRatio: 0.5, MinRequests: 10}, &circuit{requests: 10, failures: 5}, true},
+		{&model.CircuitBreaker{FailureRatio: 0.5, MinRequests: 10}, &circuit{requests: 10, failures: 4}, false},
+	}
+
+	for index, test := range testCases {
+		// mock
+		createMock(t)
+
+		// SUT + act
+		var result = shouldTrip(
+			test.circuitBreaker,
+			test.circuit,
+		)
+
+		// assert
+		assert.Equal(t, test.expected, result, fmt.Sprintf("case %v", index))
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestReleaseTrial_NilCircuitBreaker(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	releaseTrial(
+		"some dependency",
+		nil,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestReleaseTrial_NotHalfOpen(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyNow = time.Now()
+	var dummyCircuit = &circuit{
+		state:         circuitOpen,
+		trialRequests: 1,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	getCircuitFuncExpected = 1
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyNow, now)
+		return dummyCircuit
+	}
+
+	// SUT + act
+	releaseTrial(
+		dummyDependency,
+		&model.CircuitBreaker{},
+	)
+
+	// assert
+	assert.Equal(t, 1, dummyCircuit.trialRequests)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestReleaseTrial_NoTrialRequests(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuit = &circuit{
+		state: circuitHalfOpen,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now()
+	}
+	getCircuitFuncExpected = 1
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		return dummyCircuit
+	}
+
+	// SUT + act
+	releaseTrial(
+		dummyDependency,
+		&model.CircuitBreaker{},
+	)
+
+	// assert
+	assert.Equal(t, 0, dummyCircuit.trialRequests)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestReleaseTrial_Released(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuit = &circuit{
+		state:          circuitHalfOpen,
+		trialRequests:  2,
+		trialSuccesses: 1,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now()
+	}
+	getCircuitFuncExpected = 1
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		return dummyCircuit
+	}
+
+	// SUT + act
+	releaseTrial(
+		dummyDependency,
+		&model.CircuitBreaker{},
+	)
+
+	// assert
+	assert.Equal(t, circuitHalfOpen, dummyCircuit.state)
+	assert.Equal(t, 1, dummyCircuit.trialRequests)
+	assert.Equal(t, 1, dummyCircuit.trialSuccesses)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRecordResult_NilCircuitBreaker(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	recordResult(
+		"some dependency",
+		nil,
+		nil,
+		errors.New("some error"),
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRecordResult_Cancelled(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyResponseError = fmt.Errorf("some wrapped error: %w", context.Canceled)
+
+	// mock
+	createMock(t)
+
+	// expect
+	releaseTrialFuncExpected = 1
+	releaseTrialFunc = func(dependency string, circuitBreaker *model.CircuitBreaker) {
+		releaseTrialFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyCircuitBreaker, circuitBreaker)
+	}
+
+	// SUT + act
+	recordResult(
+		dummyDependency,
+		dummyCircuitBreaker,
+		nil,
+		dummyResponseError,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRecordResult_Open(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyResponseObject = &http.Response{}
+	var dummyNow = time.Now()
+	var dummyCircuit = &circuit{
+		state:    circuitOpen,
+		openedAt: dummyNow,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isFailureFuncExpected = 1
+	isFailureFunc = func(circuitBreaker *model.CircuitBreaker, responseObject *http.Response, responseError error) bool {
+		isFailureFuncCalled++
+		assert.Equal(t, dummyCircuitBreaker, circuitBreaker)
+		assert.Equal(t, dummyResponseObject, responseObject)
+		assert.NoError(t, responseError)
+		return true
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	getCircuitFuncExpected = 1
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyNow, now)
+		return dummyCircuit
+	}
+
+	// SUT + act
+	recordResult(
+		dummyDependency,
+		dummyCircuitBreaker,
+		dummyResponseObject,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, circuit{
+		state:    circuitOpen,
+		openedAt: dummyNow,
+	}, *dummyCircuit)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRecordResult_HalfOpen_Failure(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyNow = time.Now()
+	var dummyCircuit = &circuit{
+		state:         circuitHalfOpen,
+		trialRequests: 1,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isFailureFuncExpected = 1
+	isFailureFunc = func(circuitBreaker *model.CircuitBreaker, responseObject *http.Response, responseError error) bool {
+		isFailureFuncCalled++
+		return true
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	getCircuitFuncExpected = 1
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		return dummyCircuit
+	}
+	transitCircuitFuncExpected = 1
+	transitCircuitFunc = func(dependency string, dependencyCircuit *circuit, state circuitState, now time.Time) {
+		transitCircuitFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyCircuit, dependencyCircuit)
+		assert.Equal(t, circuitOpen, state)
+		assert.Equal(t, dummyNow, now)
+	}
+
+	// SUT + act
+	recordResult(
+		dummyDependency,
+		dummyCircuitBreaker,
+		nil,
+		errors.New("some error"),
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRecordResult_HalfOpen_SuccessPending(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyCircuit = &circuit{
+		state:         circuitHalfOpen,
+		trialRequests: 3,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isFailureFuncExpected = 1
+	isFailureFunc = func(circuitBreaker *model.CircuitBreaker, responseObject *http.Response, responseError error) bool {
+		isFailureFuncCalled++
+		return false
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now()
+	}
+	getCircuitFuncExpected = 1
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		return dummyCircuit
+	}
+	getHalfOpenRequestsFuncExpected = 1
+	getHalfOpenRequestsFunc = func(circuitBreaker *model.CircuitBreaker) int {
+		getHalfOpenRequestsFuncCalled++
+		assert.Equal(t, dummyCircuitBreaker, circuitBreaker)
+		return 3
+	}
+
+	// SUT + act
+	recordResult(
+		dummyDependency,
+		dummyCircuitBreaker,
+		&http.Response{},
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, circuitHalfOpen, dummyCircuit.state)
+	assert.Equal(t, 1, dummyCircuit.trialSuccesses)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRecordResult_HalfOpen_SuccessClosed(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyNow = time.Now()
+	var dummyCircuit = &circuit{
+		state:          circuitHalfOpen,
+		trialRequests:  2,
+		trialSuccesses: 1,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isFailureFuncExpected = 1
+	isFailureFunc = func(circuitBreaker *model.CircuitBreaker, responseObject *http.Response, responseError error) bool {
+		isFailureFuncCalled++
+		return false
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	getCircuitFuncExpected = 1
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		return dummyCircuit
+	}
+	getHalfOpenRequestsFuncExpected = 1
+	getHalfOpenRequestsFunc = func(circuitBreaker *model.CircuitBreaker) int {
+		getHalfOpenRequestsFuncCalled++
+		return 2
+	}
+	transitCircuitFuncExpected = 1
+	transitCircuitFunc = func(dependency string, dependencyCircuit *circuit, state circuitState, now time.Time) {
+		transitCircuitFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyCircuit, dependencyCircuit)
+		assert.Equal(t, circuitClosed, state)
+		assert.Equal(t, dummyNow, now)
+	}
+
+	// SUT + act
+	recordResult(
+		dummyDependency,
+		dummyCircuitBreaker,
+		&http.Response{},
+		nil,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRecordResult_Closed_Success(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyCircuit = &circuit{
+		state:               circuitClosed,
+		requests:            5,
+		failures:            2,
+		consecutiveFailures: 2,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isFailureFuncExpected = 1
+	isFailureFunc = func(circuitBreaker *model.CircuitBreaker, responseObject *http.Response, responseError error) bool {
+		isFailureFuncCalled++
+		return false
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now()
+	}
+	getCircuitFuncExpected = 1
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		return dummyCircuit
+	}
+	shouldTripFuncExpected = 1
+	shouldTripFunc = func(circuitBreaker *model.CircuitBreaker, dependencyCircuit *circuit) bool {
+		shouldTripFuncCalled++
+		assert.Equal(t, dummyCircuitBreaker, circuitBreaker)
+		assert.Equal(t, dummyCircuit, dependencyCircuit)
+		return false
+	}
+
+	// SUT + act
+	recordResult(
+		dummyDependency,
+		dummyCircuitBreaker,
+		&http.Response{},
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, 6, dummyCircuit.requests)
+	assert.Equal(t, 2, dummyCircuit.failures)
+	assert.Zero(t, dummyCircuit.consecutiveFailures)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRecordResult_Closed_FailureTripped(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyNow = time.Now()
+	var dummyCircuit = &circuit{
+		state:               circuitClosed,
+		requests:            5,
+		failures:            2,
+		consecutiveFailures: 2,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isFailureFuncExpected = 1
+	isFailureFunc = func(circuitBreaker *model.CircuitBreaker, responseObject *http.Response, responseError error) bool {
+		isFailureFuncCalled++
+		return true
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	getCircuitFuncExpected = 1
+	getCircuitFunc = func(dependency string, now time.Time) *circuit {
+		getCircuitFuncCalled++
+		return dummyCircuit
+	}
+	shouldTripFuncExpected = 1
+	shouldTripFunc = func(circuitBreaker *model.CircuitBreaker, dependencyCircuit *circuit) bool {
+		shouldTripFuncCalled++
+		assert.Equal(t, 6, dependencyCircuit.requests)
+		assert.Equal(t, 3, dependencyCircuit.failures)
+		assert.Equal(t, 3, dependencyCircuit.consecutiveFailures)
+		return true
+	}
+	transitCircuitFuncExpected = 1
+	transitCircuitFunc = func(dependency string, dependencyCircuit *circuit, state circuitState, now time.Time) {
+		transitCircuitFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyCircuit, dependencyCircuit)
+		assert.Equal(t, circuitOpen, state)
+		assert.Equal(t, dummyNow, now)
+	}
+
+	// SUT + act
+	recordResult(
+		dummyDependency,
+		dummyCircuitBreaker,
+		nil,
+		errors.New("some error"),
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCircuitBreaker_Integration(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{
+		ConsecutiveFailures: 2,
+		CoolDown:            time.Minute,
+	}
+	var dummyNow = time.Now()
+	var dummyError = errors.New("some error")
+	var transitions = []string{}
+	circuits = map[string]*circuit{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 9
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	loggerAppRootExpected = 3
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		transitions = append(transitions, fmt.Sprintf("%v", parameters[2]))
+	}
+	fmtErrorfExpected = 2
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		return fmt.Errorf(format, a...)
+	}
+	apperrorGetCircuitBreakErrorExpected = 2
+	apperrorGetCircuitBreakError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetCircuitBreakErrorCalled++
+		return apperror.GetCircuitBreakError(innerErrors...)
+	}
+	getCircuitFunc = getCircuit
+	transitCircuitFunc = transitCircuit
+	getWindowFunc = getWindow
+	getCoolDownFunc = getCoolDown
+	getHalfOpenRequestsFunc = getHalfOpenRequests
+	isFailureFunc = isFailure
+	shouldTripFunc = shouldTrip
+
+	// SUT + act
+	// two consecutive failures trip the circuit
+	assert.NoError(t, allowRequest(dummyDependency, dummyCircuitBreaker))
+	recordResult(dummyDependency, dummyCircuitBreaker, nil, dummyError)
+	assert.NoError(t, allowRequest(dummyDependency, dummyCircuitBreaker))
+	recordResult(dummyDependency, dummyCircuitBreaker, nil, dummyError)
+	// requests fail fast while cooling down
+	var openError = allowRequest(dummyDependency, dummyCircuitBreaker)
+	// one trial request is allowed once cooled down
+	dummyNow = dummyNow.Add(time.Minute)
+	assert.NoError(t, allowRequest(dummyDependency, dummyCircuitBreaker))
+	var halfOpenError = allowRequest(dummyDependency, dummyCircuitBreaker)
+	// the successful trial request closes the circuit
+	recordResult(dummyDependency, dummyCircuitBreaker, &http.Response{StatusCode: http.StatusOK}, nil)
+	assert.NoError(t, allowRequest(dummyDependency, dummyCircuitBreaker))
+
+	// assert
+	assert.Equal(t, []string{"Open", "HalfOpen", "Closed"}, transitions)
+	var openAppError, isOpenAppError = openError.(apperrorModel.AppError)
+	assert.True(t, isOpenAppError)
+	assert.Equal(t, apperrorEnum.CodeCircuitBreak.String(), openAppError.Code())
+	assert.Contains(t, openError.Error(), "Circuit is [Open] for dependency [some dependency]")
+	assert.Contains(t, halfOpenError.Error(), "Circuit is [HalfOpen] for dependency [some dependency]")
+	assert.Equal(t, circuitClosed, circuits[dummyDependency].state)
+
+	// verify
+	verifyAll(t)
+}
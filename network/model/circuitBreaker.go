@@ -0,0 +1,30 @@
+package model
+
+import (
+	"net/http"
+	"time"
+)
+
+// CircuitBreakerFailureFunc determines whether the given response or error of an outbound network request counts as a failure for circuit breaking
+type CircuitBreakerFailureFunc func(
+	responseObject *http.Response,
+	responseError error,
+) bool
+
+// CircuitBreaker holds the circuit breaker settings applied per dependency of outbound network requests
+type CircuitBreaker struct {
+	// FailureRatio is the ratio of failed requests, from 0 to 1, within a sampling window that trips the circuit; disabled if not positive
+	FailureRatio float64
+	// MinRequests is the minimum number of requests within a sampling window before FailureRatio is evaluated
+	MinRequests int
+	// ConsecutiveFailures is the number of consecutive failures that trips the circuit; disabled if not positive
+	ConsecutiveFailures int
+	// Window is the sampling window of request and failure counts while the circuit is closed; network.DefaultCircuitWindow applies if not positive
+	Window time.Duration
+	// CoolDown is the duration the circuit stays open, failing requests fast, before allowing trial requests; network.DefaultCircuitCoolDown applies if not positive
+	CoolDown time.Duration
+	// HalfOpenRequests is the number of trial requests allowed while the circuit is half-open, all of which must succeed to close the circuit again; at least 1 trial request is allowed
+	HalfOpenRequests int
+	// IsFailure determines whether a response or error counts as a failure; errors and 5xx status codes are failures if not set; requests cancelled by the caller are never recorded
+	IsFailure CircuitBreakerFailureFunc
+}
@@ -1,11 +1,39 @@
 package model
 
-import "net/http"
+import (
+	"io"
+	"net/http"
+	"time"
+)
 
-// NetworkRequest is an interface for easy operating on network requests and responses
+// NetworkRequest is an interface for easy operating on network requests and responses; the setup methods return the network request itself, so that they could be chained
 type NetworkRequest interface {
 	// EnableRetry sets up automatic retry upon error of specific HTTP status codes; each entry maps an HTTP status code to how many times retry should happen if code matches
-	EnableRetry(connectivityRetryCount int, httpStatusRetryCount map[int]int)
+	EnableRetry(connectivityRetryCount int, httpStatusRetryCount map[int]int) NetworkRequest
+	// SetRetryPolicy sets up automatic retry with backoff, jitter and Retry-After awareness as specified by the retry policy, replacing any retry set up previously
+	SetRetryPolicy(retryPolicy *RetryPolicy) NetworkRequest
+	// SetTimeout sets up the deadline of the network request, covering all retries and delays in between, relative to when it is processed; the network request is still cancelled along with the inbound session request regardless
+	SetTimeout(timeout time.Duration) NetworkRequest
+	// SetDependency names the dependency of the network request for circuit breaking, so that requests to different hosts could share one circuit; the host of the request URL is used if not set
+	SetDependency(dependency string) NetworkRequest
+	// SetHeader sets the header of the given name to the given value, replacing any existing values
+	SetHeader(name string, value string) NetworkRequest
+	// AddHeader adds the given value to the header of the given name, keeping any existing values
+	AddHeader(name string, value string) NetworkRequest
+	// AddHeaders adds all values of the given HTTP headers, keeping any existing values
+	AddHeaders(header http.Header) NetworkRequest
+	// SetQuery sets the query parameter of the given name to the given value, replacing any existing values, to be appended to the URL
+	SetQuery(name string, value string) NetworkRequest
+	// AddQuery adds the given value to the query parameter of the given name, keeping any existing values, to be appended to the URL
+	AddQuery(name string, value string) NetworkRequest
+	// SetBasicAuth sets the Authorization header for basic authentication with the given username and password
+	SetBasicAuth(username string, password string) NetworkRequest
+	// SetBearerToken sets the Authorization header for bearer authentication with the given token
+	SetBearerToken(token string) NetworkRequest
+	// SetPayload sets up the payload to be streamed from the given reader, replacing the string payload; the network request could only be retried if the reader is also an io.Seeker, which is rewound to its start for each retry, and the reader is closed after the last attempt if it is also an io.Closer
+	SetPayload(payload io.Reader) NetworkRequest
+	// SetJSONPayload marshals the given object into JSON as the payload, and sets the Content-Type header to application/json unless already set; any marshal error is returned upon processing
+	SetJSONPayload(payload interface{}) NetworkRequest
 	// Process sends the network request over the wire, retrieves and serialize the response to dataTemplate, and provides status code, header and error if applicable
 	Process(dataTemplate interface{}) (statusCode int, responseHeader http.Header, responseError error)
 	// ProcessRaw sends the network request over the wire, retrieves the response, and returns that response and error if applicable
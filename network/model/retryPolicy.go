@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// RetryPolicy holds the settings of automatic retry applied to a network request
+type RetryPolicy struct {
+	// ConnectivityRetryCount is how many times retry should happen upon error not mapped to an HTTP status code, e.g. network or connectivity issue
+	ConnectivityRetryCount int
+	// HTTPStatusRetryCount maps an HTTP status code to how many times retry should happen if code matches
+	HTTPStatusRetryCount map[int]int
+	// InitialDelay is the delay before the first retry; customization.DefaultNetworkRetryDelay or network.DefaultRetryDelay applies if not positive
+	InitialDelay time.Duration
+	// Multiplier is the growth factor of the delay between consecutive retries for exponential backoff; the delay stays constant if not greater than 1
+	Multiplier float64
+	// MaxDelay caps the delay between consecutive retries before jitter is applied; no cap if not positive
+	MaxDelay time.Duration
+	// Jitter is the ratio, from 0 to 1, of each delay to be randomly deducted, so that concurrent clients do not retry in lockstep; 1 stands for full jitter
+	Jitter float64
+	// MaxElapsedTime stops retrying once the time elapsed since the first attempt plus the next delay would exceed it; no limit if not positive
+	MaxElapsedTime time.Duration
+	// RespectRetryAfter honors the Retry-After header of retried responses, in either seconds or HTTP date, whenever it asks for a longer delay
+	RespectRetryAfter bool
+	// RetryNonIdempotent allows retry of requests with non-idempotent methods, e.g. POST or PATCH; only idempotent methods are retried if not set
+	RetryNonIdempotent bool
+}
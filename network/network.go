@@ -1,23 +1,40 @@
 package network
 
 import (
+	"context"
 	"crypto/tls"
 	"io"
 	"net/http"
+	neturl "net/url"
+	"sync"
 	"time"
 
 	"github.com/zhongjie-cai/WebServiceTemplate/customization"
 	"github.com/zhongjie-cai/WebServiceTemplate/network/model"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+	tracingModel "github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
+)
+
+const (
+	networkSpanNamePrefix = "HTTP "
+	firstAttempt          = 1
+	contentTypeHeader     = "Content-Type"
+	contentTypeJSON       = "application/json"
+	authorizationHeader   = "Authorization"
+	basicAuthPrefix       = "Basic "
+	bearerAuthPrefix      = "Bearer "
+	streamedPayload       = "[streamed]"
 )
 
 var (
 	httpClientWithCert *http.Client
 	httpClientNoCert   *http.Client
-	retryDelay         = 3 * time.Second
+	httpClientLock     sync.RWMutex
 )
 
 func getClientForRequest(sendClientCert bool) *http.Client {
+	httpClientLock.RLock()
+	defer httpClientLock.RUnlock()
 	if sendClientCert {
 		return httpClientWithCert
 	}
@@ -33,32 +50,60 @@ func clientDo(
 	)
 }
 
-func delayForRetry() {
-	if customization.DefaultNetworkRetryDelay != nil {
-		retryDelay = customization.DefaultNetworkRetryDelay()
+// delayForRetry waits for the given delay before next retry, and returns the context error if the context is done before the delay elapses
+func delayForRetry(ctx context.Context, delay time.Duration) error {
+	var timer = timeNewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
-	timeSleep(retryDelay)
 }
 
+// clientDoWithRetry sends the HTTP request of the first attempt and retries upon errors or HTTP status codes as specified by the retry policy, rebuilding the HTTP request for each retry so that its body is sent again, and counting remaining retries locally so that the retry policy could be shared by requests; the last response or error is returned if the HTTP request could not be rebuilt for a retry
 func clientDoWithRetry(
+	networkRequest *networkRequest,
 	httpClient *http.Client,
 	httpRequest *http.Request,
-	connectivityRetryCount int,
-	httpStatusRetryCount map[int]int,
 ) (*http.Response, error) {
+	var retryPolicy = networkRequest.retryPolicy
+	var connectivityRetryCount int
+	var httpStatusRetryCount = map[int]int{}
+	if isRetryAllowedFunc(httpRequest.Method, retryPolicy) {
+		connectivityRetryCount = retryPolicy.ConnectivityRetryCount
+		for statusCode, retry := range retryPolicy.HTTPStatusRetryCount {
+			httpStatusRetryCount[statusCode] = retry
+		}
+	}
 	var responseObject *http.Response
 	var responseError error
-	for {
+	var startTime = timeutilGetTimeNowUTC()
+	var attemptStartTime = startTime
+	for attempt := firstAttempt; ; attempt++ {
 		responseObject, responseError = clientDoFunc(
 			httpClient,
 			httpRequest,
 		)
 		if responseError != nil {
+			logErrorResponseFunc(
+				networkRequest.session,
+				responseError,
+				attemptStartTime,
+				attempt,
+			)
 			if connectivityRetryCount <= 0 {
 				break
 			}
 			connectivityRetryCount--
 		} else if responseObject != nil {
+			logHTTPResponseFunc(
+				networkRequest.session,
+				responseObject,
+				attemptStartTime,
+				attempt,
+			)
 			var retry, found = httpStatusRetryCount[responseObject.StatusCode]
 			if !found || retry <= 0 {
 				break
@@ -67,7 +112,57 @@ func clientDoWithRetry(
 		} else {
 			break
 		}
-		delayForRetryFunc()
+		if networkRequest.context.Err() != nil ||
+			!isPayloadReplayableFunc(networkRequest) {
+			break
+		}
+		var delay = getRetryDelayFunc(
+			retryPolicy,
+			attempt,
+			responseObject,
+		)
+		if retryPolicy.MaxElapsedTime > 0 &&
+			timeSince(startTime)+delay > retryPolicy.MaxElapsedTime {
+			break
+		}
+		logRetryAttemptFunc(
+			networkRequest.session,
+			attempt,
+			responseObject,
+			responseError,
+			delay,
+		)
+		var delayError = delayForRetryFunc(
+			networkRequest.context,
+			delay,
+		)
+		if delayError != nil {
+			if responseObject != nil &&
+				responseObject.Body != nil {
+				responseObject.Body.Close()
+			}
+			return nil, delayError
+		}
+		// create the retry request only after the delay, so it is logged and wrapped only when actually sent
+		var retryRequest, requestError = createHTTPRequestFunc(
+			networkRequest,
+			attempt+1,
+		)
+		if requestError != nil {
+			logErrorResponseFunc(
+				networkRequest.session,
+				requestError,
+				timeutilGetTimeNowUTC(),
+				attempt+1,
+			)
+			break
+		}
+		httpRequest = retryRequest
+		if responseObject != nil &&
+			responseObject.Body != nil {
+			responseObject.Body.Close()
+		}
+		attemptStartTime = timeutilGetTimeNowUTC()
 	}
 	return responseObject, responseError
 }
@@ -110,19 +205,32 @@ func getHTTPTransport(sendClientCert bool, skipServerCertVerification bool) http
 	)
 }
 
-// Initialize creates a singleton instance for the network package to make HTTP request to external web services
+// Initialize creates a singleton instance for the network package to make HTTP request to external web services; safe to be called again at runtime, e.g. upon configuration reload, as in-flight requests keep using the previous instance while its idle connections are closed
 func Initialize(
 	networkTimeout time.Duration,
 	skipServerCertVerification bool,
 ) {
-	httpClientWithCert = &http.Client{
+	var clientWithCert = &http.Client{
 		Transport: getHTTPTransportFunc(true, skipServerCertVerification),
 		Timeout:   networkTimeout,
 	}
-	httpClientNoCert = &http.Client{
+	var clientNoCert = &http.Client{
 		Transport: getHTTPTransportFunc(false, skipServerCertVerification),
 		Timeout:   networkTimeout,
 	}
+	httpClientLock.Lock()
+	var previousClients = []*http.Client{
+		httpClientWithCert,
+		httpClientNoCert,
+	}
+	httpClientWithCert = clientWithCert
+	httpClientNoCert = clientNoCert
+	httpClientLock.Unlock()
+	for _, previousClient := range previousClients {
+		if previousClient != nil {
+			previousClient.CloseIdleConnections()
+		}
+	}
 }
 
 type networkRequest struct {
@@ -130,10 +238,16 @@ type networkRequest struct {
 	method         string
 	url            string
 	payload        string
-	header         map[string]string
-	connRetry      int
-	httpRetry      map[int]int
+	header         http.Header
+	retryPolicy    *model.RetryPolicy
 	sendClientCert bool
+	span           *tracingModel.Span
+	dependency     string
+	timeout        time.Duration
+	context        context.Context
+	query          neturl.Values
+	payloadStream  io.Reader
+	payloadError   error
 }
 
 // NewNetworkRequest creates a new network request for consumer to use
@@ -145,22 +259,225 @@ func NewNetworkRequest(
 	header map[string]string,
 	sendClientCert bool,
 ) model.NetworkRequest {
+	var requestHeader = make(http.Header)
+	for name, value := range header {
+		requestHeader.Add(name, value)
+	}
 	return &networkRequest{
 		session,
 		method,
 		url,
 		payload,
-		header,
-		0,
+		requestHeader,
 		nil,
 		sendClientCert,
+		nil,
+		"",
+		0,
+		nil,
+		nil,
+		nil,
+		nil,
 	}
 }
 
 // EnableRetry sets up automatic retry upon error of specific HTTP status codes; each entry maps an HTTP status code to how many times retry should happen if code matches; 0 stands for error not mapped to an HTTP status code, e.g. network or connectivity issue
-func (networkRequest *networkRequest) EnableRetry(connectivityRetryCount int, httpStatusRetryCount map[int]int) {
-	networkRequest.connRetry = connectivityRetryCount
-	networkRequest.httpRetry = httpStatusRetryCount
+func (networkRequest *networkRequest) EnableRetry(connectivityRetryCount int, httpStatusRetryCount map[int]int) model.NetworkRequest {
+	networkRequest.retryPolicy = &model.RetryPolicy{
+		ConnectivityRetryCount: connectivityRetryCount,
+		HTTPStatusRetryCount:   httpStatusRetryCount,
+		RetryNonIdempotent:     true,
+	}
+	return networkRequest
+}
+
+// SetRetryPolicy sets up automatic retry with backoff, jitter and Retry-After awareness as specified by the retry policy, replacing any retry set up previously
+func (networkRequest *networkRequest) SetRetryPolicy(retryPolicy *model.RetryPolicy) model.NetworkRequest {
+	networkRequest.retryPolicy = retryPolicy
+	return networkRequest
+}
+
+// SetDependency names the dependency of the network request for circuit breaking, so that requests to different hosts could share one circuit; the host of the request URL is used if not set
+func (networkRequest *networkRequest) SetDependency(dependency string) model.NetworkRequest {
+	networkRequest.dependency = dependency
+	return networkRequest
+}
+
+// SetTimeout sets up the deadline of the network request, covering all retries and delays in between, relative to when it is processed; the network request is still cancelled along with the inbound session request regardless
+func (networkRequest *networkRequest) SetTimeout(timeout time.Duration) model.NetworkRequest {
+	networkRequest.timeout = timeout
+	return networkRequest
+}
+
+// SetHeader sets the header of the given name to the given value, replacing any existing values
+func (networkRequest *networkRequest) SetHeader(name string, value string) model.NetworkRequest {
+	networkRequest.header.Set(name, value)
+	return networkRequest
+}
+
+// AddHeader adds the given value to the header of the given name, keeping any existing values
+func (networkRequest *networkRequest) AddHeader(name string, value string) model.NetworkRequest {
+	networkRequest.header.Add(name, value)
+	return networkRequest
+}
+
+// AddHeaders adds all values of the given HTTP headers, keeping any existing values
+func (networkRequest *networkRequest) AddHeaders(header http.Header) model.NetworkRequest {
+	for name, values := range header {
+		for _, value := range values {
+			networkRequest.header.Add(name, value)
+		}
+	}
+	return networkRequest
+}
+
+// SetQuery sets the query parameter of the given name to the given value, replacing any existing values, to be appended to the URL
+func (networkRequest *networkRequest) SetQuery(name string, value string) model.NetworkRequest {
+	if networkRequest.query == nil {
+		networkRequest.query = neturl.Values{}
+	}
+	networkRequest.query.Set(name, value)
+	return networkRequest
+}
+
+// AddQuery adds the given value to the query parameter of the given name, keeping any existing values, to be appended to the URL
+func (networkRequest *networkRequest) AddQuery(name string, value string) model.NetworkRequest {
+	if networkRequest.query == nil {
+		networkRequest.query = neturl.Values{}
+	}
+	networkRequest.query.Add(name, value)
+	return networkRequest
+}
+
+// SetBasicAuth sets the Authorization header for basic authentication with the given username and password
+func (networkRequest *networkRequest) SetBasicAuth(username string, password string) model.NetworkRequest {
+	networkRequest.header.Set(
+		authorizationHeader,
+		basicAuthPrefix+base64StdEncodingEncodeToString(
+			[]byte(username+":"+password),
+		),
+	)
+	return networkRequest
+}
+
+// SetBearerToken sets the Authorization header for bearer authentication with the given token
+func (networkRequest *networkRequest) SetBearerToken(token string) model.NetworkRequest {
+	networkRequest.header.Set(
+		authorizationHeader,
+		bearerAuthPrefix+token,
+	)
+	return networkRequest
+}
+
+// SetPayload sets up the payload to be streamed from the given reader, replacing the string payload; the network request could only be retried if the reader is also an io.Seeker, which is rewound to its start for each retry, and the reader is closed after the last attempt if it is also an io.Closer
+func (networkRequest *networkRequest) SetPayload(payload io.Reader) model.NetworkRequest {
+	networkRequest.payload = ""
+	networkRequest.payloadStream = payload
+	networkRequest.payloadError = nil
+	return networkRequest
+}
+
+// SetJSONPayload marshals the given object into JSON as the payload, and sets the Content-Type header to application/json unless already set; any marshal error is returned upon processing
+func (networkRequest *networkRequest) SetJSONPayload(payload interface{}) model.NetworkRequest {
+	var payloadBytes, marshalError = jsonMarshal(
+		payload,
+	)
+	networkRequest.payload = string(payloadBytes)
+	networkRequest.payloadStream = nil
+	networkRequest.payloadError = marshalError
+	if networkRequest.header.Get(contentTypeHeader) == "" {
+		networkRequest.header.Set(
+			contentTypeHeader,
+			contentTypeJSON,
+		)
+	}
+	return networkRequest
+}
+
+// getRequestContext derives the context of the network request from the inbound session request, so that the network request is cancelled when the inbound request is, with the timeout applied if set
+func getRequestContext(networkRequest *networkRequest) (context.Context, context.CancelFunc) {
+	var parentContext = contextBackground()
+	if networkRequest.session != nil {
+		var httpRequest = networkRequest.session.GetRequest()
+		if httpRequest != nil {
+			parentContext = httpRequest.Context()
+		}
+	}
+	if networkRequest.timeout > 0 {
+		return contextWithTimeout(
+			parentContext,
+			networkRequest.timeout,
+		)
+	}
+	return contextWithCancel(
+		parentContext,
+	)
+}
+
+// getRequestURL appends the query parameters set up for the network request to its URL, keeping any query parameters already in the URL
+func getRequestURL(networkRequest *networkRequest) string {
+	if len(networkRequest.query) == 0 {
+		return networkRequest.url
+	}
+	var parsedURL, parseError = urlParse(
+		networkRequest.url,
+	)
+	if parseError != nil {
+		return networkRequest.url
+	}
+	var query = parsedURL.Query()
+	for name, values := range networkRequest.query {
+		for _, value := range values {
+			query.Add(name, value)
+		}
+	}
+	parsedURL.RawQuery = query.Encode()
+	return parsedURL.String()
+}
+
+func isPayloadReplayable(networkRequest *networkRequest) bool {
+	if networkRequest.payloadStream == nil {
+		return true
+	}
+	var _, isSeeker = networkRequest.payloadStream.(io.Seeker)
+	return isSeeker
+}
+
+// getRequestBody returns the body of the given attempt (starting from 1) of the network request, which is either a fresh reader of the string payload or the payload stream, rewound to its start for retries and shielded from being closed by the HTTP transport after each attempt
+func getRequestBody(networkRequest *networkRequest, attempt int) (io.Reader, error) {
+	if networkRequest.payloadError != nil {
+		return nil, networkRequest.payloadError
+	}
+	if networkRequest.payloadStream == nil {
+		return stringsNewReader(
+			networkRequest.payload,
+		), nil
+	}
+	if attempt > firstAttempt {
+		var seeker, isSeeker = networkRequest.payloadStream.(io.Seeker)
+		if !isSeeker {
+			return nil, fmtErrorf(
+				"Payload stream cannot be rewound for attempt [%v]",
+				attempt,
+			)
+		}
+		var _, seekError = seeker.Seek(0, io.SeekStart)
+		if seekError != nil {
+			return nil, seekError
+		}
+	}
+	return ioutilNopCloser(
+		networkRequest.payloadStream,
+	), nil
+}
+
+// closePayload closes the payload stream of the network request if it is an io.Closer, once all attempts are done
+func closePayload(networkRequest *networkRequest) {
+	var closer, isCloser = networkRequest.payloadStream.(io.Closer)
+	if !isCloser {
+		return
+	}
+	closer.Close()
 }
 
 func customizeHTTPRequest(session sessionModel.Session, httpRequest *http.Request) *http.Request {
@@ -173,13 +490,27 @@ func customizeHTTPRequest(session sessionModel.Session, httpRequest *http.Reques
 	)
 }
 
-func createHTTPRequest(networkRequest *networkRequest) (*http.Request, error) {
-	var requestBody = stringsNewReader(
-		networkRequest.payload,
+// createHTTPRequest builds a new HTTP request with a fresh body for the given attempt (starting from 1) of the network request
+func createHTTPRequest(networkRequest *networkRequest, attempt int) (*http.Request, error) {
+	var requestURL = getRequestURLFunc(
+		networkRequest,
 	)
-	var requestObject, requestError = httpNewRequest(
+	var requestBody, bodyError = getRequestBodyFunc(
+		networkRequest,
+		attempt,
+	)
+	if bodyError != nil {
+		return nil,
+			apperrorWrapSimpleError(
+				[]error{bodyError},
+				"Failed to generate request to [%v]",
+				requestURL,
+			)
+	}
+	var requestObject, requestError = httpNewRequestWithContext(
+		networkRequest.context,
 		networkRequest.method,
-		networkRequest.url,
+		requestURL,
 		requestBody,
 	)
 	if requestError != nil {
@@ -187,25 +518,39 @@ func createHTTPRequest(networkRequest *networkRequest) (*http.Request, error) {
 			apperrorWrapSimpleError(
 				[]error{requestError},
 				"Failed to generate request to [%v]",
-				networkRequest.url,
+				requestURL,
 			)
 	}
 	loggerNetworkCall(
 		networkRequest.session,
 		networkRequest.method,
-		"",
-		networkRequest.url,
+		strconvItoa(attempt),
+		requestURL,
 	)
+	var payload = networkRequest.payload
+	if networkRequest.payloadStream != nil {
+		payload = streamedPayload
+	}
 	loggerNetworkRequest(
 		networkRequest.session,
 		"Payload",
 		"",
-		networkRequest.payload,
+		payload,
 	)
 	requestObject.Header = make(http.Header)
-	for name, value := range networkRequest.header {
-		requestObject.Header.Add(name, value)
+	for name, values := range networkRequest.header {
+		for _, value := range values {
+			requestObject.Header.Add(name, value)
+		}
 	}
+	tracingInjectHeader(
+		requestObject.Header,
+		networkRequest.span,
+	)
+	headerutilSetCorrelationIDHeader(
+		networkRequest.session,
+		requestObject.Header,
+	)
 	headerutilLogHTTPHeader(
 		networkRequest.session,
 		requestObject.Header,
@@ -217,7 +562,7 @@ func createHTTPRequest(networkRequest *networkRequest) (*http.Request, error) {
 	), nil
 }
 
-func logErrorResponse(session sessionModel.Session, responseError error, startTime time.Time) {
+func logErrorResponse(session sessionModel.Session, responseError error, startTime time.Time, attempt int) {
 	loggerNetworkResponse(
 		session,
 		"Message",
@@ -228,13 +573,13 @@ func logErrorResponse(session sessionModel.Session, responseError error, startTi
 	loggerNetworkFinish(
 		session,
 		"Error",
-		"",
+		strconvItoa(attempt),
 		"%s",
 		timeSince(startTime),
 	)
 }
 
-func logHTTPResponse(session sessionModel.Session, response *http.Response, startTime time.Time) {
+func logHTTPResponse(session sessionModel.Session, response *http.Response, startTime time.Time, attempt int) {
 	if response == nil {
 		return
 	}
@@ -263,17 +608,109 @@ func logHTTPResponse(session sessionModel.Session, response *http.Response, star
 	loggerNetworkFinish(
 		session,
 		httpStatusText(responseStatusCode),
-		strconvItoa(responseStatusCode),
-		"%s",
+		strconvItoa(attempt),
+		"%v: %s",
+		responseStatusCode,
 		timeSince(startTime),
 	)
 }
 
+func recordNetworkMetrics(requestObject *http.Request, responseObject *http.Response, startTime time.Time) {
+	var host string
+	if requestObject.URL != nil {
+		host = requestObject.URL.Host
+	}
+	var statusCode int
+	if responseObject != nil {
+		statusCode = responseObject.StatusCode
+	}
+	metricsNetworkRequestFinished(
+		host,
+		requestObject.Method,
+		statusCode,
+		timeSince(startTime),
+	)
+}
+
+func startNetworkSpan(networkRequest *networkRequest) *tracingModel.Span {
+	var span = tracingStartSpan(
+		tracingGetSessionSpan(
+			networkRequest.session,
+		),
+		networkSpanNamePrefix+networkRequest.method,
+		tracingModel.SpanKindClient,
+	)
+	tracingSetAttribute(
+		span,
+		"http.method",
+		networkRequest.method,
+	)
+	tracingSetAttribute(
+		span,
+		"http.url",
+		networkRequest.url,
+	)
+	return span
+}
+
+func endNetworkSpan(span *tracingModel.Span, responseObject *http.Response, responseError error) {
+	if responseObject != nil {
+		tracingSetAttribute(
+			span,
+			"http.status_code",
+			strconvItoa(responseObject.StatusCode),
+		)
+	}
+	tracingEndSpan(
+		span,
+		responseError,
+	)
+}
+
 func doRequestProcessing(networkRequest *networkRequest) (*http.Response, error) {
+	networkRequest.span = startNetworkSpanFunc(
+		networkRequest,
+	)
+	var requestContext, cancelRequest = getRequestContextFunc(
+		networkRequest,
+	)
+	defer cancelRequest()
+	defer closePayloadFunc(
+		networkRequest,
+	)
+	networkRequest.context = requestContext
+	var dependency = getDependencyFunc(
+		networkRequest,
+	)
+	var circuitBreaker = getCircuitBreakerFunc(
+		dependency,
+	)
+	var circuitError = allowRequestFunc(
+		dependency,
+		circuitBreaker,
+	)
+	if circuitError != nil {
+		endNetworkSpanFunc(
+			networkRequest.span,
+			nil,
+			circuitError,
+		)
+		return nil, circuitError
+	}
 	var requestObject, requestError = createHTTPRequestFunc(
 		networkRequest,
+		firstAttempt,
 	)
 	if requestError != nil {
+		releaseTrialFunc(
+			dependency,
+			circuitBreaker,
+		)
+		endNetworkSpanFunc(
+			networkRequest.span,
+			nil,
+			requestError,
+		)
 		return nil, requestError
 	}
 	var httpClient = getClientForRequestFunc(
@@ -281,24 +718,26 @@ func doRequestProcessing(networkRequest *networkRequest) (*http.Response, error)
 	)
 	var startTime = timeutilGetTimeNowUTC()
 	var responseObject, responseError = clientDoWithRetryFunc(
+		networkRequest,
 		httpClient,
 		requestObject,
-		networkRequest.connRetry,
-		networkRequest.httpRetry,
 	)
-	if responseError != nil {
-		logErrorResponseFunc(
-			networkRequest.session,
-			responseError,
-			startTime,
-		)
-	} else {
-		logHTTPResponseFunc(
-			networkRequest.session,
-			responseObject,
-			startTime,
-		)
-	}
+	recordResultFunc(
+		dependency,
+		circuitBreaker,
+		responseObject,
+		responseError,
+	)
+	recordNetworkMetricsFunc(
+		requestObject,
+		responseObject,
+		startTime,
+	)
+	endNetworkSpanFunc(
+		networkRequest.span,
+		responseObject,
+		responseError,
+	)
 	return responseObject, responseError
 }
 
@@ -2,6 +2,7 @@ package network
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
@@ -9,6 +10,9 @@ import (
 	"io/ioutil"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
 	"reflect"
 	"strconv"
 	"strings"
@@ -20,7 +24,9 @@ import (
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/customization"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger"
+	"github.com/zhongjie-cai/WebServiceTemplate/network/model"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+	tracingModel "github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
 )
 
 func TestGetClientForRequest_SendClientCert(t *testing.T) {
@@ -89,96 +95,138 @@ func TestClientDo(t *testing.T) {
 	)
 }
 
-func TestDelayForRetry_NoCustomization(t *testing.T) {
+func TestDelayForRetry_Elapsed(t *testing.T) {
 	// arrange
-	var dummyDelay = 3 * time.Second
+	var dummyContext = context.Background()
+	var dummyDelay = time.Duration(rand.Int())
 
 	// mock
 	createMock(t)
 
 	// expect
-	timeSleepExpected = 1
-	timeSleep = func(d time.Duration) {
-		timeSleepCalled++
+	timeNewTimerExpected = 1
+	timeNewTimer = func(d time.Duration) *time.Timer {
+		timeNewTimerCalled++
 		assert.Equal(t, dummyDelay, d)
+		return time.NewTimer(0)
 	}
 
 	// SUT + act
-	delayForRetry()
+	var err = delayForRetry(
+		dummyContext,
+		dummyDelay,
+	)
+
+	// assert
+	assert.NoError(t, err)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestDelayForRetry_WithCustomization(t *testing.T) {
+func TestDelayForRetry_Cancelled(t *testing.T) {
 	// arrange
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
 	var dummyDelay = time.Duration(rand.Int())
 
 	// mock
 	createMock(t)
 
 	// expect
-	customizationDefaultNetworkRetryDelayExpected = 1
-	customization.DefaultNetworkRetryDelay = func() time.Duration {
-		customizationDefaultNetworkRetryDelayCalled++
-		return dummyDelay
-	}
-	timeSleepExpected = 1
-	timeSleep = func(d time.Duration) {
-		timeSleepCalled++
+	timeNewTimerExpected = 1
+	timeNewTimer = func(d time.Duration) *time.Timer {
+		timeNewTimerCalled++
 		assert.Equal(t, dummyDelay, d)
+		return time.NewTimer(time.Hour)
 	}
 
 	// SUT + act
-	delayForRetry()
+	dummyCancel()
+	var err = delayForRetry(
+		dummyContext,
+		dummyDelay,
+	)
+
+	// assert
+	assert.Equal(t, context.Canceled, err)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestClientDoWithRetry_ConnError_NoRetry(t *testing.T) {
+func TestClientDoWithRetry_NotAllowed(t *testing.T) {
 	// arrange
+	var dummySessionObject = &dummySession{t}
 	var dummyClient = &http.Client{}
-	var dummyRequestObject = &http.Request{}
-	var dummyConnRetry = 0
-	var dummyHTTPRetry = map[int]int{}
-	var dummyResponseObject = &http.Response{}
+	var dummyRequestObject = &http.Request{Method: http.MethodPost}
+	var dummyRetryPolicy = &model.RetryPolicy{
+		ConnectivityRetryCount: 2,
+	}
+	var dummyNetworkRequest = &networkRequest{
+		session:     dummySessionObject,
+		context:     context.Background(),
+		retryPolicy: dummyRetryPolicy,
+	}
 	var dummyResponseError = errors.New("some error")
+	var dummyStartTime = time.Now()
 
 	// mock
 	createMock(t)
 
 	// expect
+	isRetryAllowedFuncExpected = 1
+	isRetryAllowedFunc = func(method string, retryPolicy *model.RetryPolicy) bool {
+		isRetryAllowedFuncCalled++
+		assert.Equal(t, http.MethodPost, method)
+		assert.Equal(t, dummyRetryPolicy, retryPolicy)
+		return false
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyStartTime
+	}
 	clientDoFuncExpected = 1
 	clientDoFunc = func(client *http.Client, request *http.Request) (*http.Response, error) {
 		clientDoFuncCalled++
 		assert.Equal(t, dummyClient, client)
 		assert.Equal(t, dummyRequestObject, request)
-		return dummyResponseObject, dummyResponseError
+		return nil, dummyResponseError
+	}
+	logErrorResponseFuncExpected = 1
+	logErrorResponseFunc = func(session sessionModel.Session, responseError error, startTime time.Time, attempt int) {
+		logErrorResponseFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyResponseError, responseError)
+		assert.Equal(t, dummyStartTime, startTime)
+		assert.Equal(t, 1, attempt)
 	}
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
+		dummyNetworkRequest,
 		dummyClient,
 		dummyRequestObject,
-		dummyConnRetry,
-		dummyHTTPRetry,
 	)
 
 	// assert
-	assert.Equal(t, dummyResponseObject, result)
+	assert.Nil(t, result)
 	assert.Equal(t, dummyResponseError, err)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestClientDoWithRetry_ConnError_RetryOK(t *testing.T) {
+func TestClientDoWithRetry_ConnError_NoRetry(t *testing.T) {
 	// arrange
+	var dummySessionObject = &dummySession{t}
 	var dummyClient = &http.Client{}
 	var dummyRequestObject = &http.Request{}
-	var dummyConnRetry = 2
-	var dummyHTTPRetry = map[int]int{}
+	var dummyNetworkRequest = &networkRequest{
+		session:     dummySessionObject,
+		context:     context.Background(),
+		retryPolicy: &model.RetryPolicy{},
+	}
 	var dummyResponseObject = &http.Response{}
 	var dummyResponseError = errors.New("some error")
 
@@ -186,238 +234,441 @@ func TestClientDoWithRetry_ConnError_RetryOK(t *testing.T) {
 	createMock(t)
 
 	// expect
-	clientDoFuncExpected = 2
+	isRetryAllowedFuncExpected = 1
+	isRetryAllowedFunc = func(method string, retryPolicy *model.RetryPolicy) bool {
+		isRetryAllowedFuncCalled++
+		return true
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now()
+	}
+	clientDoFuncExpected = 1
 	clientDoFunc = func(client *http.Client, request *http.Request) (*http.Response, error) {
 		clientDoFuncCalled++
 		assert.Equal(t, dummyClient, client)
 		assert.Equal(t, dummyRequestObject, request)
-		if clientDoFuncCalled == 1 {
-			return dummyResponseObject, dummyResponseError
-		} else if clientDoFuncCalled == 2 {
-			return dummyResponseObject, nil
-		}
-		return nil, nil
+		return dummyResponseObject, dummyResponseError
 	}
-	delayForRetryFuncExpected = 1
-	delayForRetryFunc = func() {
-		delayForRetryFuncCalled++
+	logErrorResponseFuncExpected = 1
+	logErrorResponseFunc = func(session sessionModel.Session, responseError error, startTime time.Time, attempt int) {
+		logErrorResponseFuncCalled++
 	}
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
+		dummyNetworkRequest,
 		dummyClient,
 		dummyRequestObject,
-		dummyConnRetry,
-		dummyHTTPRetry,
 	)
 
 	// assert
 	assert.Equal(t, dummyResponseObject, result)
-	assert.NoError(t, err)
+	assert.Equal(t, dummyResponseError, err)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestClientDoWithRetry_ConnError_RetryFail(t *testing.T) {
+func TestClientDoWithRetry_ConnError_RetryOK(t *testing.T) {
 	// arrange
+	var dummySessionObject = &dummySession{t}
 	var dummyClient = &http.Client{}
-	var dummyRequestObject = &http.Request{}
-	var dummyConnRetry = 2
-	var dummyHTTPRetry = map[int]int{}
+	var dummyRequestObject1 = &http.Request{Method: "some method 1"}
+	var dummyRequestObject2 = &http.Request{Method: "some method 2"}
+	var dummyRetryPolicy = &model.RetryPolicy{
+		ConnectivityRetryCount: 2,
+	}
+	var dummyNetworkRequest = &networkRequest{
+		session:     dummySessionObject,
+		context:     context.Background(),
+		retryPolicy: dummyRetryPolicy,
+	}
 	var dummyResponseObject = &http.Response{}
 	var dummyResponseError = errors.New("some error")
+	var dummyDelay = time.Duration(rand.Int())
+	var dummyStartTime1 = time.Now()
+	var dummyStartTime2 = dummyStartTime1.Add(time.Second)
 
 	// mock
 	createMock(t)
 
 	// expect
-	clientDoFuncExpected = 3
+	isRetryAllowedFuncExpected = 1
+	isRetryAllowedFunc = func(method string, retryPolicy *model.RetryPolicy) bool {
+		isRetryAllowedFuncCalled++
+		return true
+	}
+	timeutilGetTimeNowUTCExpected = 2
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		if timeutilGetTimeNowUTCCalled == 1 {
+			return dummyStartTime1
+		}
+		return dummyStartTime2
+	}
+	clientDoFuncExpected = 2
 	clientDoFunc = func(client *http.Client, request *http.Request) (*http.Response, error) {
 		clientDoFuncCalled++
 		assert.Equal(t, dummyClient, client)
-		assert.Equal(t, dummyRequestObject, request)
-		return dummyResponseObject, dummyResponseError
+		if clientDoFuncCalled == 1 {
+			assert.Equal(t, dummyRequestObject1, request)
+			return nil, dummyResponseError
+		} else if clientDoFuncCalled == 2 {
+			assert.Equal(t, dummyRequestObject2, request)
+			return dummyResponseObject, nil
+		}
+		return nil, nil
 	}
-	delayForRetryFuncExpected = 2
-	delayForRetryFunc = func() {
+	logErrorResponseFuncExpected = 1
+	logErrorResponseFunc = func(session sessionModel.Session, responseError error, startTime time.Time, attempt int) {
+		logErrorResponseFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyResponseError, responseError)
+		assert.Equal(t, dummyStartTime1, startTime)
+		assert.Equal(t, 1, attempt)
+	}
+	isPayloadReplayableFuncExpected = 1
+	isPayloadReplayableFunc = func(networkRequest *networkRequest) bool {
+		isPayloadReplayableFuncCalled++
+		return true
+	}
+	getRetryDelayFuncExpected = 1
+	getRetryDelayFunc = func(retryPolicy *model.RetryPolicy, attempt int, responseObject *http.Response) time.Duration {
+		getRetryDelayFuncCalled++
+		assert.Equal(t, dummyRetryPolicy, retryPolicy)
+		assert.Equal(t, 1, attempt)
+		assert.Nil(t, responseObject)
+		return dummyDelay
+	}
+	logRetryAttemptFuncExpected = 1
+	logRetryAttemptFunc = func(session sessionModel.Session, attempt int, responseObject *http.Response, responseError error, delay time.Duration) {
+		logRetryAttemptFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, 1, attempt)
+		assert.Nil(t, responseObject)
+		assert.Equal(t, dummyResponseError, responseError)
+		assert.Equal(t, dummyDelay, delay)
+	}
+	delayForRetryFuncExpected = 1
+	delayForRetryFunc = func(ctx context.Context, delay time.Duration) error {
 		delayForRetryFuncCalled++
+		assert.Equal(t, dummyDelay, delay)
+		return nil
+	}
+	createHTTPRequestFuncExpected = 1
+	createHTTPRequestFunc = func(networkRequest *networkRequest, attempt int) (*http.Request, error) {
+		createHTTPRequestFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		assert.Equal(t, 2, attempt)
+		return dummyRequestObject2, nil
+	}
+	logHTTPResponseFuncExpected = 1
+	logHTTPResponseFunc = func(session sessionModel.Session, response *http.Response, startTime time.Time, attempt int) {
+		logHTTPResponseFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyResponseObject, response)
+		assert.Equal(t, dummyStartTime2, startTime)
+		assert.Equal(t, 2, attempt)
 	}
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
+		dummyNetworkRequest,
 		dummyClient,
-		dummyRequestObject,
-		dummyConnRetry,
-		dummyHTTPRetry,
+		dummyRequestObject1,
 	)
 
 	// assert
 	assert.Equal(t, dummyResponseObject, result)
-	assert.Equal(t, dummyResponseError, err)
+	assert.NoError(t, err)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestClientDoWithRetry_HTTPError_NilResponse(t *testing.T) {
+func TestClientDoWithRetry_ConnError_RetryFail(t *testing.T) {
 	// arrange
+	var dummySessionObject = &dummySession{t}
 	var dummyClient = &http.Client{}
 	var dummyRequestObject = &http.Request{}
-	var dummyConnRetry = rand.Int()
-	var dummyHTTPRetry = map[int]int{}
-	var dummyResponseObject *http.Response
+	var dummyNetworkRequest = &networkRequest{
+		session: dummySessionObject,
+		context: context.Background(),
+		retryPolicy: &model.RetryPolicy{
+			ConnectivityRetryCount: 2,
+		},
+	}
+	var dummyResponseError = errors.New("some error")
 
 	// mock
 	createMock(t)
 
 	// expect
-	clientDoFuncExpected = 1
+	isRetryAllowedFuncExpected = 1
+	isRetryAllowedFunc = func(method string, retryPolicy *model.RetryPolicy) bool {
+		isRetryAllowedFuncCalled++
+		return true
+	}
+	timeutilGetTimeNowUTCExpected = 3
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now()
+	}
+	clientDoFuncExpected = 3
 	clientDoFunc = func(client *http.Client, request *http.Request) (*http.Response, error) {
 		clientDoFuncCalled++
-		assert.Equal(t, dummyClient, client)
-		assert.Equal(t, dummyRequestObject, request)
-		return dummyResponseObject, nil
+		return nil, dummyResponseError
+	}
+	logErrorResponseFuncExpected = 3
+	logErrorResponseFunc = func(session sessionModel.Session, responseError error, startTime time.Time, attempt int) {
+		logErrorResponseFuncCalled++
+		assert.Equal(t, logErrorResponseFuncCalled, attempt)
+	}
+	isPayloadReplayableFuncExpected = 2
+	isPayloadReplayableFunc = func(networkRequest *networkRequest) bool {
+		isPayloadReplayableFuncCalled++
+		return true
+	}
+	getRetryDelayFuncExpected = 2
+	getRetryDelayFunc = func(retryPolicy *model.RetryPolicy, attempt int, responseObject *http.Response) time.Duration {
+		getRetryDelayFuncCalled++
+		assert.Equal(t, getRetryDelayFuncCalled, attempt)
+		return time.Duration(attempt) * time.Second
+	}
+	logRetryAttemptFuncExpected = 2
+	logRetryAttemptFunc = func(session sessionModel.Session, attempt int, responseObject *http.Response, responseError error, delay time.Duration) {
+		logRetryAttemptFuncCalled++
+		assert.Equal(t, logRetryAttemptFuncCalled, attempt)
+	}
+	delayForRetryFuncExpected = 2
+	delayForRetryFunc = func(ctx context.Context, delay time.Duration) error {
+		delayForRetryFuncCalled++
+		assert.Equal(t, time.Duration(delayForRetryFuncCalled)*time.Second, delay)
+		return nil
+	}
+	createHTTPRequestFuncExpected = 2
+	createHTTPRequestFunc = func(networkRequest *networkRequest, attempt int) (*http.Request, error) {
+		createHTTPRequestFuncCalled++
+		assert.Equal(t, createHTTPRequestFuncCalled+1, attempt)
+		return dummyRequestObject, nil
 	}
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
+		dummyNetworkRequest,
 		dummyClient,
 		dummyRequestObject,
-		dummyConnRetry,
-		dummyHTTPRetry,
 	)
 
 	// assert
-	assert.Equal(t, dummyResponseObject, result)
-	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, dummyResponseError, err)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestClientDoWithRetry_HTTPError_NoRetry(t *testing.T) {
+func TestClientDoWithRetry_RequestError(t *testing.T) {
 	// arrange
+	var dummySessionObject = &dummySession{t}
 	var dummyClient = &http.Client{}
 	var dummyRequestObject = &http.Request{}
-	var dummyConnRetry = rand.Int()
-	var dummyHTTPRetry = map[int]int{}
-	var dummyResponseObject = &http.Response{}
+	var dummyNetworkRequest = &networkRequest{
+		session: dummySessionObject,
+		context: context.Background(),
+		retryPolicy: &model.RetryPolicy{
+			HTTPStatusRetryCount: map[int]int{
+				http.StatusServiceUnavailable: 2,
+			},
+		},
+	}
+	var dummyResponseBody = &dummyReadCloser{
+		Reader: strings.NewReader("some response body"),
+	}
+	var dummyResponseObject = &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Body:       dummyResponseBody,
+	}
+	var dummyRequestError = errors.New("some request error")
 
 	// mock
 	createMock(t)
 
 	// expect
+	isRetryAllowedFuncExpected = 1
+	isRetryAllowedFunc = func(method string, retryPolicy *model.RetryPolicy) bool {
+		isRetryAllowedFuncCalled++
+		return true
+	}
+	timeutilGetTimeNowUTCExpected = 2
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now()
+	}
 	clientDoFuncExpected = 1
 	clientDoFunc = func(client *http.Client, request *http.Request) (*http.Response, error) {
 		clientDoFuncCalled++
-		assert.Equal(t, dummyClient, client)
 		assert.Equal(t, dummyRequestObject, request)
 		return dummyResponseObject, nil
 	}
+	logHTTPResponseFuncExpected = 1
+	logHTTPResponseFunc = func(session sessionModel.Session, response *http.Response, startTime time.Time, attempt int) {
+		logHTTPResponseFuncCalled++
+	}
+	isPayloadReplayableFuncExpected = 1
+	isPayloadReplayableFunc = func(networkRequest *networkRequest) bool {
+		isPayloadReplayableFuncCalled++
+		return true
+	}
+	getRetryDelayFuncExpected = 1
+	getRetryDelayFunc = func(retryPolicy *model.RetryPolicy, attempt int, responseObject *http.Response) time.Duration {
+		getRetryDelayFuncCalled++
+		return 0
+	}
+	logRetryAttemptFuncExpected = 1
+	logRetryAttemptFunc = func(session sessionModel.Session, attempt int, responseObject *http.Response, responseError error, delay time.Duration) {
+		logRetryAttemptFuncCalled++
+		assert.Equal(t, 1, attempt)
+	}
+	delayForRetryFuncExpected = 1
+	delayForRetryFunc = func(ctx context.Context, delay time.Duration) error {
+		delayForRetryFuncCalled++
+		assert.Equal(t, 1, logRetryAttemptFuncCalled)
+		assert.Zero(t, createHTTPRequestFuncCalled)
+		assert.False(t, dummyResponseBody.closed)
+		return nil
+	}
+	createHTTPRequestFuncExpected = 1
+	createHTTPRequestFunc = func(networkRequest *networkRequest, attempt int) (*http.Request, error) {
+		createHTTPRequestFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		assert.Equal(t, 2, attempt)
+		return nil, dummyRequestError
+	}
+	logErrorResponseFuncExpected = 1
+	logErrorResponseFunc = func(session sessionModel.Session, responseError error, startTime time.Time, attempt int) {
+		logErrorResponseFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyRequestError, responseError)
+		assert.Equal(t, 2, attempt)
+	}
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
+		dummyNetworkRequest,
 		dummyClient,
 		dummyRequestObject,
-		dummyConnRetry,
-		dummyHTTPRetry,
 	)
 
 	// assert
 	assert.Equal(t, dummyResponseObject, result)
 	assert.NoError(t, err)
+	assert.False(t, dummyResponseBody.closed)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestClientDoWithRetry_HTTPError_RetryOK(t *testing.T) {
+func TestClientDoWithRetry_HTTPError_NilResponse(t *testing.T) {
 	// arrange
+	var dummySessionObject = &dummySession{t}
 	var dummyClient = &http.Client{}
 	var dummyRequestObject = &http.Request{}
-	var dummyConnRetry = rand.Int()
-	var dummyStatusCode = rand.Int()
-	var dummyHTTPRetry = map[int]int{
-		dummyStatusCode: 2,
-	}
-	var dummyResponseObject1 = &http.Response{
-		StatusCode: dummyStatusCode,
+	var dummyNetworkRequest = &networkRequest{
+		session: dummySessionObject,
+		context: context.Background(),
+		retryPolicy: &model.RetryPolicy{
+			ConnectivityRetryCount: rand.Int(),
+		},
 	}
-	var dummyResponseObject2 = &http.Response{}
 
 	// mock
 	createMock(t)
 
 	// expect
-	clientDoFuncExpected = 2
+	isRetryAllowedFuncExpected = 1
+	isRetryAllowedFunc = func(method string, retryPolicy *model.RetryPolicy) bool {
+		isRetryAllowedFuncCalled++
+		return true
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now()
+	}
+	clientDoFuncExpected = 1
 	clientDoFunc = func(client *http.Client, request *http.Request) (*http.Response, error) {
 		clientDoFuncCalled++
-		assert.Equal(t, dummyClient, client)
-		assert.Equal(t, dummyRequestObject, request)
-		if clientDoFuncCalled == 1 {
-			return dummyResponseObject1, nil
-		} else if clientDoFuncCalled == 2 {
-			return dummyResponseObject2, nil
-		}
 		return nil, nil
 	}
-	delayForRetryFuncExpected = 1
-	delayForRetryFunc = func() {
-		delayForRetryFuncCalled++
-	}
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
+		dummyNetworkRequest,
 		dummyClient,
 		dummyRequestObject,
-		dummyConnRetry,
-		dummyHTTPRetry,
 	)
 
 	// assert
-	assert.Equal(t, dummyResponseObject2, result)
+	assert.Nil(t, result)
 	assert.NoError(t, err)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestClientDoWithRetry_HTTPError_RetryFail(t *testing.T) {
+func TestClientDoWithRetry_HTTPError_NoRetry(t *testing.T) {
 	// arrange
+	var dummySessionObject = &dummySession{t}
 	var dummyClient = &http.Client{}
 	var dummyRequestObject = &http.Request{}
-	var dummyConnRetry = rand.Int()
-	var dummyStatusCode = rand.Int()
-	var dummyHTTPRetry = map[int]int{
-		dummyStatusCode: 2,
+	var dummyNetworkRequest = &networkRequest{
+		session: dummySessionObject,
+		context: context.Background(),
+		retryPolicy: &model.RetryPolicy{
+			ConnectivityRetryCount: rand.Int(),
+			HTTPStatusRetryCount: map[int]int{
+				http.StatusServiceUnavailable: 2,
+			},
+		},
 	}
 	var dummyResponseObject = &http.Response{
-		StatusCode: dummyStatusCode,
+		StatusCode: http.StatusBadRequest,
 	}
 
 	// mock
 	createMock(t)
 
 	// expect
-	clientDoFuncExpected = 3
+	isRetryAllowedFuncExpected = 1
+	isRetryAllowedFunc = func(method string, retryPolicy *model.RetryPolicy) bool {
+		isRetryAllowedFuncCalled++
+		return true
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now()
+	}
+	clientDoFuncExpected = 1
 	clientDoFunc = func(client *http.Client, request *http.Request) (*http.Response, error) {
 		clientDoFuncCalled++
-		assert.Equal(t, dummyClient, client)
-		assert.Equal(t, dummyRequestObject, request)
 		return dummyResponseObject, nil
 	}
-	delayForRetryFuncExpected = 2
-	delayForRetryFunc = func() {
-		delayForRetryFuncCalled++
+	logHTTPResponseFuncExpected = 1
+	logHTTPResponseFunc = func(session sessionModel.Session, response *http.Response, startTime time.Time, attempt int) {
+		logHTTPResponseFuncCalled++
+		assert.Equal(t, dummyResponseObject, response)
+		assert.Equal(t, 1, attempt)
 	}
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
+		dummyNetworkRequest,
 		dummyClient,
 		dummyRequestObject,
-		dummyConnRetry,
-		dummyHTTPRetry,
 	)
 
 	// assert
@@ -428,177 +679,633 @@ func TestClientDoWithRetry_HTTPError_RetryFail(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestCustomizeRoundTripper_NoCustomization(t *testing.T) {
+func TestClientDoWithRetry_HTTPError_RetryOK(t *testing.T) {
 	// arrange
-	var dummyOriginal = http.DefaultTransport
-
-	// mock
-	createMock(t)
-
-	// SUT + act
-	var result = customizeRoundTripper(
-		dummyOriginal,
+	var dummySessionObject = &dummySession{t}
+	var dummyClient = &http.Client{}
+	var dummyRequestObject = &http.Request{}
+	var dummyStatusCode = rand.Int()
+	var dummyRetryPolicy = &model.RetryPolicy{
+		HTTPStatusRetryCount: map[int]int{
+			dummyStatusCode: 2,
+		},
+	}
+	var dummyNetworkRequest = &networkRequest{
+		session:     dummySessionObject,
+		context:     context.Background(),
+		retryPolicy: dummyRetryPolicy,
+	}
+	var dummyResponseObject1 = &http.Response{
+		StatusCode: dummyStatusCode,
+		Body:       ioutil.NopCloser(strings.NewReader("some body")),
+	}
+	var dummyResponseObject2 = &http.Response{}
+	var dummyDelay = time.Duration(rand.Int())
+
+	// mock
+	createMock(t)
+
+	// expect
+	isRetryAllowedFuncExpected = 1
+	isRetryAllowedFunc = func(method string, retryPolicy *model.RetryPolicy) bool {
+		isRetryAllowedFuncCalled++
+		return true
+	}
+	timeutilGetTimeNowUTCExpected = 2
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now()
+	}
+	clientDoFuncExpected = 2
+	clientDoFunc = func(client *http.Client, request *http.Request) (*http.Response, error) {
+		clientDoFuncCalled++
+		if clientDoFuncCalled == 1 {
+			return dummyResponseObject1, nil
+		} else if clientDoFuncCalled == 2 {
+			return dummyResponseObject2, nil
+		}
+		return nil, nil
+	}
+	logHTTPResponseFuncExpected = 2
+	logHTTPResponseFunc = func(session sessionModel.Session, response *http.Response, startTime time.Time, attempt int) {
+		logHTTPResponseFuncCalled++
+		assert.Equal(t, logHTTPResponseFuncCalled, attempt)
+	}
+	isPayloadReplayableFuncExpected = 1
+	isPayloadReplayableFunc = func(networkRequest *networkRequest) bool {
+		isPayloadReplayableFuncCalled++
+		return true
+	}
+	getRetryDelayFuncExpected = 1
+	getRetryDelayFunc = func(retryPolicy *model.RetryPolicy, attempt int, responseObject *http.Response) time.Duration {
+		getRetryDelayFuncCalled++
+		assert.Equal(t, 1, attempt)
+		assert.Equal(t, dummyResponseObject1, responseObject)
+		return dummyDelay
+	}
+	logRetryAttemptFuncExpected = 1
+	logRetryAttemptFunc = func(session sessionModel.Session, attempt int, responseObject *http.Response, responseError error, delay time.Duration) {
+		logRetryAttemptFuncCalled++
+		assert.Equal(t, dummyResponseObject1, responseObject)
+		assert.NoError(t, responseError)
+	}
+	delayForRetryFuncExpected = 1
+	delayForRetryFunc = func(ctx context.Context, delay time.Duration) error {
+		delayForRetryFuncCalled++
+		assert.Equal(t, dummyDelay, delay)
+		return nil
+	}
+	createHTTPRequestFuncExpected = 1
+	createHTTPRequestFunc = func(networkRequest *networkRequest, attempt int) (*http.Request, error) {
+		createHTTPRequestFuncCalled++
+		return dummyRequestObject, nil
+	}
+
+	// SUT + act
+	var result, err = clientDoWithRetry(
+		dummyNetworkRequest,
+		dummyClient,
+		dummyRequestObject,
 	)
 
 	// assert
-	assert.Equal(t, dummyOriginal, result)
+	assert.Equal(t, dummyResponseObject2, result)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, dummyRetryPolicy.HTTPStatusRetryCount[dummyStatusCode])
 
 	// verify
 	verifyAll(t)
 }
 
-func TestCustomizeRoundTripper_WithCustomization(t *testing.T) {
+func TestClientDoWithRetry_HTTPError_RetryFail(t *testing.T) {
 	// arrange
-	var dummyOriginal = http.DefaultTransport
-	var dummyCustomized = &http.Transport{}
+	var dummySessionObject = &dummySession{t}
+	var dummyClient = &http.Client{}
+	var dummyRequestObject = &http.Request{}
+	var dummyStatusCode = rand.Int()
+	var dummyNetworkRequest = &networkRequest{
+		session: dummySessionObject,
+		context: context.Background(),
+		retryPolicy: &model.RetryPolicy{
+			ConnectivityRetryCount: rand.Int(),
+			HTTPStatusRetryCount: map[int]int{
+				dummyStatusCode: 2,
+			},
+		},
+	}
+	var dummyResponseObject = &http.Response{
+		StatusCode: dummyStatusCode,
+	}
 
 	// mock
 	createMock(t)
 
 	// expect
-	customizationHTTPRoundTripperExpected = 1
-	customization.HTTPRoundTripper = func(originalTransport http.RoundTripper) http.RoundTripper {
-		customizationHTTPRoundTripperCalled++
-		assert.Equal(t, dummyOriginal, originalTransport)
-		return dummyCustomized
+	isRetryAllowedFuncExpected = 1
+	isRetryAllowedFunc = func(method string, retryPolicy *model.RetryPolicy) bool {
+		isRetryAllowedFuncCalled++
+		return true
+	}
+	timeutilGetTimeNowUTCExpected = 3
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now()
+	}
+	clientDoFuncExpected = 3
+	clientDoFunc = func(client *http.Client, request *http.Request) (*http.Response, error) {
+		clientDoFuncCalled++
+		return dummyResponseObject, nil
+	}
+	logHTTPResponseFuncExpected = 3
+	logHTTPResponseFunc = func(session sessionModel.Session, response *http.Response, startTime time.Time, attempt int) {
+		logHTTPResponseFuncCalled++
+	}
+	isPayloadReplayableFuncExpected = 2
+	isPayloadReplayableFunc = func(networkRequest *networkRequest) bool {
+		isPayloadReplayableFuncCalled++
+		return true
+	}
+	getRetryDelayFuncExpected = 2
+	getRetryDelayFunc = func(retryPolicy *model.RetryPolicy, attempt int, responseObject *http.Response) time.Duration {
+		getRetryDelayFuncCalled++
+		return 0
+	}
+	logRetryAttemptFuncExpected = 2
+	logRetryAttemptFunc = func(session sessionModel.Session, attempt int, responseObject *http.Response, responseError error, delay time.Duration) {
+		logRetryAttemptFuncCalled++
+	}
+	delayForRetryFuncExpected = 2
+	delayForRetryFunc = func(ctx context.Context, delay time.Duration) error {
+		delayForRetryFuncCalled++
+		return nil
+	}
+	createHTTPRequestFuncExpected = 2
+	createHTTPRequestFunc = func(networkRequest *networkRequest, attempt int) (*http.Request, error) {
+		createHTTPRequestFuncCalled++
+		return dummyRequestObject, nil
 	}
 
 	// SUT + act
-	var result = customizeRoundTripper(
-		dummyOriginal,
+	var result, err = clientDoWithRetry(
+		dummyNetworkRequest,
+		dummyClient,
+		dummyRequestObject,
 	)
 
 	// assert
-	assert.Equal(t, dummyCustomized, result)
+	assert.Equal(t, dummyResponseObject, result)
+	assert.NoError(t, err)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestGetHTTPTransport_NoSendClientCert(t *testing.T) {
+func TestClientDoWithRetry_MaxElapsedTimeExceeded(t *testing.T) {
 	// arrange
-	var dummySendClientCert = false
-	var dummySkipServerCertVerification = rand.Intn(100) < 50
-	var dummyRoundTripper = &http.Transport{}
+	var dummySessionObject = &dummySession{t}
+	var dummyClient = &http.Client{}
+	var dummyRequestObject = &http.Request{}
+	var dummyNetworkRequest = &networkRequest{
+		session: dummySessionObject,
+		context: context.Background(),
+		retryPolicy: &model.RetryPolicy{
+			ConnectivityRetryCount: 2,
+			MaxElapsedTime:         time.Minute,
+		},
+	}
+	var dummyResponseError = errors.New("some error")
+	var dummyStartTime = time.Now()
 
 	// mock
 	createMock(t)
 
 	// expect
-	customizeRoundTripperFuncExpected = 1
-	customizeRoundTripperFunc = func(original http.RoundTripper) http.RoundTripper {
-		customizeRoundTripperFuncCalled++
-		assert.Equal(t, http.DefaultTransport, original)
-		return dummyRoundTripper
+	isRetryAllowedFuncExpected = 1
+	isRetryAllowedFunc = func(method string, retryPolicy *model.RetryPolicy) bool {
+		isRetryAllowedFuncCalled++
+		return true
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyStartTime
+	}
+	clientDoFuncExpected = 1
+	clientDoFunc = func(client *http.Client, request *http.Request) (*http.Response, error) {
+		clientDoFuncCalled++
+		return nil, dummyResponseError
+	}
+	logErrorResponseFuncExpected = 1
+	logErrorResponseFunc = func(session sessionModel.Session, responseError error, startTime time.Time, attempt int) {
+		logErrorResponseFuncCalled++
+	}
+	isPayloadReplayableFuncExpected = 1
+	isPayloadReplayableFunc = func(networkRequest *networkRequest) bool {
+		isPayloadReplayableFuncCalled++
+		return true
+	}
+	getRetryDelayFuncExpected = 1
+	getRetryDelayFunc = func(retryPolicy *model.RetryPolicy, attempt int, responseObject *http.Response) time.Duration {
+		getRetryDelayFuncCalled++
+		return 30 * time.Second
+	}
+	timeSinceExpected = 1
+	timeSince = func(tm time.Time) time.Duration {
+		timeSinceCalled++
+		assert.Equal(t, dummyStartTime, tm)
+		return 31 * time.Second
 	}
 
 	// SUT + act
-	var result = getHTTPTransport(
-		dummySendClientCert,
-		dummySkipServerCertVerification,
+	var result, err = clientDoWithRetry(
+		dummyNetworkRequest,
+		dummyClient,
+		dummyRequestObject,
 	)
 
 	// assert
-	assert.Equal(t, dummyRoundTripper, result)
+	assert.Nil(t, result)
+	assert.Equal(t, dummyResponseError, err)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestGetHTTPTransport_SendClientCert_NoCertFound(t *testing.T) {
+func TestClientDoWithRetry_ContextCancelled(t *testing.T) {
 	// arrange
-	var dummySendClientCert = true
-	var dummySkipServerCertVerification = rand.Intn(100) < 50
-	var dummyClientCert *tls.Certificate
-	var dummyRoundTripper = &http.Transport{}
+	var dummySessionObject = &dummySession{t}
+	var dummyClient = &http.Client{}
+	var dummyRequestObject = &http.Request{}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyNetworkRequest = &networkRequest{
+		session: dummySessionObject,
+		context: dummyContext,
+		retryPolicy: &model.RetryPolicy{
+			ConnectivityRetryCount: 2,
+		},
+	}
+	var dummyResponseError = errors.New("some error")
 
 	// mock
 	createMock(t)
 
 	// expect
-	certificateGetClientCertificateExpected = 1
-	certificateGetClientCertificate = func() *tls.Certificate {
-		certificateGetClientCertificateCalled++
-		return dummyClientCert
+	isRetryAllowedFuncExpected = 1
+	isRetryAllowedFunc = func(method string, retryPolicy *model.RetryPolicy) bool {
+		isRetryAllowedFuncCalled++
+		return true
 	}
-	loggerAppRootExpected = 1
-	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
-		loggerAppRootCalled++
-		assert.Equal(t, "network", category)
-		assert.Equal(t, "getHTTPTransport", subcategory)
-		assert.Equal(t, "Failed to load client certificate for mTLS communications; fallback to default HTTP transport", messageFormat)
-		assert.Empty(t, parameters)
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now()
 	}
-	customizeRoundTripperFuncExpected = 1
-	customizeRoundTripperFunc = func(original http.RoundTripper) http.RoundTripper {
-		customizeRoundTripperFuncCalled++
-		assert.Equal(t, http.DefaultTransport, original)
-		return dummyRoundTripper
+	clientDoFuncExpected = 1
+	clientDoFunc = func(client *http.Client, request *http.Request) (*http.Response, error) {
+		clientDoFuncCalled++
+		dummyCancel()
+		return nil, dummyResponseError
+	}
+	logErrorResponseFuncExpected = 1
+	logErrorResponseFunc = func(session sessionModel.Session, responseError error, startTime time.Time, attempt int) {
+		logErrorResponseFuncCalled++
 	}
 
 	// SUT + act
-	var result = getHTTPTransport(
-		dummySendClientCert,
-		dummySkipServerCertVerification,
+	var result, err = clientDoWithRetry(
+		dummyNetworkRequest,
+		dummyClient,
+		dummyRequestObject,
 	)
 
 	// assert
-	assert.Equal(t, dummyRoundTripper, result)
+	assert.Nil(t, result)
+	assert.Equal(t, dummyResponseError, err)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestGetHTTPTransport_SendClientCert_CertFound(t *testing.T) {
+func TestClientDoWithRetry_PayloadNotReplayable(t *testing.T) {
 	// arrange
-	var dummySendClientCert = true
-	var dummySkipServerCertVerification = rand.Intn(100) < 50
-	var dummyClientCert = &tls.Certificate{}
-	var dummyRoundTripper = &http.Transport{}
+	var dummySessionObject = &dummySession{t}
+	var dummyClient = &http.Client{}
+	var dummyRequestObject = &http.Request{}
+	var dummyNetworkRequest = &networkRequest{
+		session: dummySessionObject,
+		context: context.Background(),
+		retryPolicy: &model.RetryPolicy{
+			ConnectivityRetryCount: 2,
+		},
+	}
+	var dummyResponseError = errors.New("some error")
 
 	// mock
 	createMock(t)
 
 	// expect
-	certificateGetClientCertificateExpected = 1
-	certificateGetClientCertificate = func() *tls.Certificate {
-		certificateGetClientCertificateCalled++
-		return dummyClientCert
+	isRetryAllowedFuncExpected = 1
+	isRetryAllowedFunc = func(method string, retryPolicy *model.RetryPolicy) bool {
+		isRetryAllowedFuncCalled++
+		return true
 	}
-	customizeRoundTripperFuncExpected = 1
-	customizeRoundTripperFunc = func(original http.RoundTripper) http.RoundTripper {
-		customizeRoundTripperFuncCalled++
-		assert.NotEqual(t, http.DefaultTransport, original)
-		return dummyRoundTripper
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now()
+	}
+	clientDoFuncExpected = 1
+	clientDoFunc = func(client *http.Client, request *http.Request) (*http.Response, error) {
+		clientDoFuncCalled++
+		return nil, dummyResponseError
+	}
+	logErrorResponseFuncExpected = 1
+	logErrorResponseFunc = func(session sessionModel.Session, responseError error, startTime time.Time, attempt int) {
+		logErrorResponseFuncCalled++
+	}
+	isPayloadReplayableFuncExpected = 1
+	isPayloadReplayableFunc = func(networkRequest *networkRequest) bool {
+		isPayloadReplayableFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return false
 	}
 
 	// SUT + act
-	var result = getHTTPTransport(
-		dummySendClientCert,
-		dummySkipServerCertVerification,
+	var result, err = clientDoWithRetry(
+		dummyNetworkRequest,
+		dummyClient,
+		dummyRequestObject,
 	)
 
 	// assert
-	assert.Equal(t, dummyRoundTripper, result)
+	assert.Nil(t, result)
+	assert.Equal(t, dummyResponseError, err)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestInitialize(t *testing.T) {
+func TestClientDoWithRetry_DelayCancelled(t *testing.T) {
 	// arrange
-	var dummyNetworkTimeout = time.Duration(rand.Int())
-	var dummySkipServerCertVerification = rand.Intn(100) < 50
-	var dummyHTTPTransport1 = &http.Transport{MaxConnsPerHost: rand.Int()}
-	var dummyHTTPTransport2 = &http.Transport{MaxConnsPerHost: rand.Int()}
+	var dummySessionObject = &dummySession{t}
+	var dummyClient = &http.Client{}
+	var dummyRequestObject = &http.Request{}
+	var dummyContext = context.WithValue(context.Background(), "foo", "bar")
+	var dummyNetworkRequest = &networkRequest{
+		session: dummySessionObject,
+		context: dummyContext,
+		retryPolicy: &model.RetryPolicy{
+			HTTPStatusRetryCount: map[int]int{
+				http.StatusServiceUnavailable: 2,
+			},
+		},
+	}
+	var dummyResponseBody = &dummyReadCloser{
+		Reader: strings.NewReader("some response body"),
+	}
+	var dummyResponseObject = &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Body:       dummyResponseBody,
+	}
+	var dummyDelay = time.Duration(rand.Int())
+	var dummyDelayError = errors.New("some delay error")
 
 	// mock
 	createMock(t)
 
 	// expect
-	getHTTPTransportFuncExpected = 2
-	getHTTPTransportFunc = func(sendClientCert bool, skipServerCertVerification bool) http.RoundTripper {
-		getHTTPTransportFuncCalled++
+	isRetryAllowedFuncExpected = 1
+	isRetryAllowedFunc = func(method string, retryPolicy *model.RetryPolicy) bool {
+		isRetryAllowedFuncCalled++
+		return true
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now()
+	}
+	clientDoFuncExpected = 1
+	clientDoFunc = func(client *http.Client, request *http.Request) (*http.Response, error) {
+		clientDoFuncCalled++
+		return dummyResponseObject, nil
+	}
+	logHTTPResponseFuncExpected = 1
+	logHTTPResponseFunc = func(session sessionModel.Session, response *http.Response, startTime time.Time, attempt int) {
+		logHTTPResponseFuncCalled++
+	}
+	isPayloadReplayableFuncExpected = 1
+	isPayloadReplayableFunc = func(networkRequest *networkRequest) bool {
+		isPayloadReplayableFuncCalled++
+		return true
+	}
+	getRetryDelayFuncExpected = 1
+	getRetryDelayFunc = func(retryPolicy *model.RetryPolicy, attempt int, responseObject *http.Response) time.Duration {
+		getRetryDelayFuncCalled++
+		return dummyDelay
+	}
+	logRetryAttemptFuncExpected = 1
+	logRetryAttemptFunc = func(session sessionModel.Session, attempt int, responseObject *http.Response, responseError error, delay time.Duration) {
+		logRetryAttemptFuncCalled++
+	}
+	delayForRetryFuncExpected = 1
+	delayForRetryFunc = func(ctx context.Context, delay time.Duration) error {
+		delayForRetryFuncCalled++
+		assert.Equal(t, dummyContext, ctx)
+		assert.Equal(t, dummyDelay, delay)
+		return dummyDelayError
+	}
+
+	// SUT + act
+	var result, err = clientDoWithRetry(
+		dummyNetworkRequest,
+		dummyClient,
+		dummyRequestObject,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyDelayError, err)
+	assert.True(t, dummyResponseBody.closed)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCustomizeRoundTripper_NoCustomization(t *testing.T) {
+	// arrange
+	var dummyOriginal = http.DefaultTransport
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = customizeRoundTripper(
+		dummyOriginal,
+	)
+
+	// assert
+	assert.Equal(t, dummyOriginal, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCustomizeRoundTripper_WithCustomization(t *testing.T) {
+	// arrange
+	var dummyOriginal = http.DefaultTransport
+	var dummyCustomized = &http.Transport{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationHTTPRoundTripperExpected = 1
+	customization.HTTPRoundTripper = func(originalTransport http.RoundTripper) http.RoundTripper {
+		customizationHTTPRoundTripperCalled++
+		assert.Equal(t, dummyOriginal, originalTransport)
+		return dummyCustomized
+	}
+
+	// SUT + act
+	var result = customizeRoundTripper(
+		dummyOriginal,
+	)
+
+	// assert
+	assert.Equal(t, dummyCustomized, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetHTTPTransport_NoSendClientCert(t *testing.T) {
+	// arrange
+	var dummySendClientCert = false
+	var dummySkipServerCertVerification = rand.Intn(100) < 50
+	var dummyRoundTripper = &http.Transport{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizeRoundTripperFuncExpected = 1
+	customizeRoundTripperFunc = func(original http.RoundTripper) http.RoundTripper {
+		customizeRoundTripperFuncCalled++
+		assert.Equal(t, http.DefaultTransport, original)
+		return dummyRoundTripper
+	}
+
+	// SUT + act
+	var result = getHTTPTransport(
+		dummySendClientCert,
+		dummySkipServerCertVerification,
+	)
+
+	// assert
+	assert.Equal(t, dummyRoundTripper, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetHTTPTransport_SendClientCert_NoCertFound(t *testing.T) {
+	// arrange
+	var dummySendClientCert = true
+	var dummySkipServerCertVerification = rand.Intn(100) < 50
+	var dummyClientCert *tls.Certificate
+	var dummyRoundTripper = &http.Transport{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	certificateGetClientCertificateExpected = 1
+	certificateGetClientCertificate = func() *tls.Certificate {
+		certificateGetClientCertificateCalled++
+		return dummyClientCert
+	}
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "network", category)
+		assert.Equal(t, "getHTTPTransport", subcategory)
+		assert.Equal(t, "Failed to load client certificate for mTLS communications; fallback to default HTTP transport", messageFormat)
+		assert.Empty(t, parameters)
+	}
+	customizeRoundTripperFuncExpected = 1
+	customizeRoundTripperFunc = func(original http.RoundTripper) http.RoundTripper {
+		customizeRoundTripperFuncCalled++
+		assert.Equal(t, http.DefaultTransport, original)
+		return dummyRoundTripper
+	}
+
+	// SUT + act
+	var result = getHTTPTransport(
+		dummySendClientCert,
+		dummySkipServerCertVerification,
+	)
+
+	// assert
+	assert.Equal(t, dummyRoundTripper, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetHTTPTransport_SendClientCert_CertFound(t *testing.T) {
+	// arrange
+	var dummySendClientCert = true
+	var dummySkipServerCertVerification = rand.Intn(100) < 50
+	var dummyClientCert = &tls.Certificate{}
+	var dummyRoundTripper = &http.Transport{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	certificateGetClientCertificateExpected = 1
+	certificateGetClientCertificate = func() *tls.Certificate {
+		certificateGetClientCertificateCalled++
+		return dummyClientCert
+	}
+	customizeRoundTripperFuncExpected = 1
+	customizeRoundTripperFunc = func(original http.RoundTripper) http.RoundTripper {
+		customizeRoundTripperFuncCalled++
+		assert.NotEqual(t, http.DefaultTransport, original)
+		return dummyRoundTripper
+	}
+
+	// SUT + act
+	var result = getHTTPTransport(
+		dummySendClientCert,
+		dummySkipServerCertVerification,
+	)
+
+	// assert
+	assert.Equal(t, dummyRoundTripper, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestInitialize(t *testing.T) {
+	// arrange
+	var dummyNetworkTimeout = time.Duration(rand.Int())
+	var dummySkipServerCertVerification = rand.Intn(100) < 50
+	var dummyHTTPTransport1 = &http.Transport{MaxConnsPerHost: rand.Int()}
+	var dummyHTTPTransport2 = &http.Transport{MaxConnsPerHost: rand.Int()}
+
+	// stub
+	httpClientWithCert = nil
+	httpClientNoCert = nil
+
+	// mock
+	createMock(t)
+
+	// expect
+	getHTTPTransportFuncExpected = 2
+	getHTTPTransportFunc = func(sendClientCert bool, skipServerCertVerification bool) http.RoundTripper {
+		getHTTPTransportFuncCalled++
 		assert.Equal(t, dummySkipServerCertVerification, skipServerCertVerification)
 		if getHTTPTransportFuncCalled == 1 {
 			assert.True(t, sendClientCert)
@@ -611,88 +1318,1192 @@ func TestInitialize(t *testing.T) {
 	}
 
 	// SUT + act
-	Initialize(
-		dummyNetworkTimeout,
-		dummySkipServerCertVerification,
+	Initialize(
+		dummyNetworkTimeout,
+		dummySkipServerCertVerification,
+	)
+
+	// assert
+	assert.NotNil(t, httpClientWithCert)
+	assert.Equal(t, dummyHTTPTransport1, httpClientWithCert.Transport)
+	assert.Equal(t, dummyNetworkTimeout, httpClientWithCert.Timeout)
+	assert.NotNil(t, httpClientNoCert)
+	assert.Equal(t, dummyHTTPTransport2, httpClientNoCert.Transport)
+	assert.Equal(t, dummyNetworkTimeout, httpClientNoCert.Timeout)
+
+	// verify
+	verifyAll(t)
+}
+
+type dummyIdleRoundTripper struct {
+	idleClosed int
+}
+
+func (roundTripper *dummyIdleRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	return nil, nil
+}
+
+func (roundTripper *dummyIdleRoundTripper) CloseIdleConnections() {
+	roundTripper.idleClosed++
+}
+
+func TestInitialize_ClosePreviousIdleConnections(t *testing.T) {
+	// arrange
+	var dummyNetworkTimeout = time.Duration(rand.Int())
+	var dummySkipServerCertVerification = rand.Intn(100) < 50
+	var dummyPreviousTransport1 = &dummyIdleRoundTripper{}
+	var dummyPreviousTransport2 = &dummyIdleRoundTripper{}
+	var dummyHTTPTransport1 = &dummyIdleRoundTripper{}
+	var dummyHTTPTransport2 = &dummyIdleRoundTripper{}
+
+	// stub
+	httpClientWithCert = &http.Client{Transport: dummyPreviousTransport1}
+	httpClientNoCert = &http.Client{Transport: dummyPreviousTransport2}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getHTTPTransportFuncExpected = 2
+	getHTTPTransportFunc = func(sendClientCert bool, skipServerCertVerification bool) http.RoundTripper {
+		getHTTPTransportFuncCalled++
+		if sendClientCert {
+			return dummyHTTPTransport1
+		}
+		return dummyHTTPTransport2
+	}
+
+	// SUT + act
+	Initialize(
+		dummyNetworkTimeout,
+		dummySkipServerCertVerification,
+	)
+
+	// assert
+	assert.Equal(t, dummyHTTPTransport1, httpClientWithCert.Transport)
+	assert.Equal(t, dummyHTTPTransport2, httpClientNoCert.Transport)
+	assert.Equal(t, 1, dummyPreviousTransport1.idleClosed)
+	assert.Equal(t, 1, dummyPreviousTransport2.idleClosed)
+	assert.Zero(t, dummyHTTPTransport1.idleClosed)
+	assert.Zero(t, dummyHTTPTransport2.idleClosed)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewNetworkRequest(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t}
+	var dummyMethod = "some method"
+	var dummyURL = "some URL"
+	var dummyPayload = "some payload"
+	var dummyHeader = map[string]string{
+		"foo":  "bar",
+		"test": "123",
+	}
+	var dummySendClientCert = rand.Intn(100) < 50
+	var expectedHeader = http.Header{
+		"Foo":  []string{"bar"},
+		"Test": []string{"123"},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var result = NewNetworkRequest(
+		dummySessionObject,
+		dummyMethod,
+		dummyURL,
+		dummyPayload,
+		dummyHeader,
+		dummySendClientCert,
+	)
+
+	// act
+	var typedResult, ok = result.(*networkRequest)
+
+	// assert
+	assert.True(t, ok)
+	assert.NotNil(t, typedResult)
+	assert.Equal(t, dummySessionObject, typedResult.session)
+	assert.Equal(t, dummyMethod, typedResult.method)
+	assert.Equal(t, dummyURL, typedResult.url)
+	assert.Equal(t, dummyPayload, typedResult.payload)
+	assert.Equal(t, expectedHeader, typedResult.header)
+	assert.Equal(t, dummySendClientCert, typedResult.sendClientCert)
+	assert.Nil(t, typedResult.span)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestEnableRetry(t *testing.T) {
+	// arrange
+	var dummyConnRetry = rand.Int()
+	var dummyHTTPRetry = map[int]int{
+		rand.Int(): rand.Int(),
+		rand.Int(): rand.Int(),
+	}
+
+	// SUT
+	var sut = &networkRequest{}
+
+	// mock
+	createMock(t)
+
+	// act
+	var result = sut.EnableRetry(
+		dummyConnRetry,
+		dummyHTTPRetry,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Equal(t, &model.RetryPolicy{
+		ConnectivityRetryCount: dummyConnRetry,
+		HTTPStatusRetryCount:   dummyHTTPRetry,
+		RetryNonIdempotent:     true,
+	}, sut.retryPolicy)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestSetRetryPolicy(t *testing.T) {
+	// arrange
+	var dummyRetryPolicy = &model.RetryPolicy{
+		ConnectivityRetryCount: rand.Int(),
+		Multiplier:             2,
+		Jitter:                 0.5,
+	}
+
+	// SUT
+	var sut = &networkRequest{
+		retryPolicy: &model.RetryPolicy{},
+	}
+
+	// mock
+	createMock(t)
+
+	// act
+	var result = sut.SetRetryPolicy(
+		dummyRetryPolicy,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Equal(t, dummyRetryPolicy, sut.retryPolicy)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestSetDependency(t *testing.T) {
+	// arrange
+	var dummyDependency = "some dependency"
+
+	// SUT
+	var sut = &networkRequest{}
+
+	// mock
+	createMock(t)
+
+	// act
+	var result = sut.SetDependency(
+		dummyDependency,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Equal(t, dummyDependency, sut.dependency)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestSetTimeout(t *testing.T) {
+	// arrange
+	var dummyTimeout = time.Duration(rand.Int())
+
+	// SUT
+	var sut = &networkRequest{}
+
+	// mock
+	createMock(t)
+
+	// act
+	var result = sut.SetTimeout(
+		dummyTimeout,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Equal(t, dummyTimeout, sut.timeout)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestSetHeader(t *testing.T) {
+	// arrange
+	var dummyName = "some-name"
+	var dummyValue = "some value"
+
+	// SUT
+	var sut = &networkRequest{
+		header: http.Header{
+			"Some-Name": []string{"old value"},
+			"Foo":       []string{"bar"},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// act
+	var result = sut.SetHeader(
+		dummyName,
+		dummyValue,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Equal(t, http.Header{
+		"Some-Name": []string{dummyValue},
+		"Foo":       []string{"bar"},
+	}, sut.header)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestAddHeader(t *testing.T) {
+	// arrange
+	var dummyName = "some-name"
+	var dummyValue = "some value"
+
+	// SUT
+	var sut = &networkRequest{
+		header: http.Header{
+			"Some-Name": []string{"old value"},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// act
+	var result = sut.AddHeader(
+		dummyName,
+		dummyValue,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Equal(t, http.Header{
+		"Some-Name": []string{"old value", dummyValue},
+	}, sut.header)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestAddHeaders(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{
+		"Foo":  []string{"bar", "baz"},
+		"Test": []string{"123"},
+	}
+
+	// SUT
+	var sut = &networkRequest{
+		header: http.Header{
+			"Foo": []string{"old value"},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// act
+	var result = sut.AddHeaders(
+		dummyHeader,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Equal(t, http.Header{
+		"Foo":  []string{"old value", "bar", "baz"},
+		"Test": []string{"123"},
+	}, sut.header)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestSetQuery_NilQuery(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyValue = "some value"
+
+	// SUT
+	var sut = &networkRequest{}
+
+	// mock
+	createMock(t)
+
+	// act
+	var result = sut.SetQuery(
+		dummyName,
+		dummyValue,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Equal(t, url.Values{
+		dummyName: []string{dummyValue},
+	}, sut.query)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestSetQuery_ExistingQuery(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyValue = "some value"
+
+	// SUT
+	var sut = &networkRequest{
+		query: url.Values{
+			dummyName: []string{"old value"},
+			"foo":     []string{"bar"},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// act
+	var result = sut.SetQuery(
+		dummyName,
+		dummyValue,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Equal(t, url.Values{
+		dummyName: []string{dummyValue},
+		"foo":     []string{"bar"},
+	}, sut.query)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestAddQuery_NilQuery(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyValue = "some value"
+
+	// SUT
+	var sut = &networkRequest{}
+
+	// mock
+	createMock(t)
+
+	// act
+	var result = sut.AddQuery(
+		dummyName,
+		dummyValue,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Equal(t, url.Values{
+		dummyName: []string{dummyValue},
+	}, sut.query)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestAddQuery_ExistingQuery(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyValue = "some value"
+
+	// SUT
+	var sut = &networkRequest{
+		query: url.Values{
+			dummyName: []string{"old value"},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// act
+	var result = sut.AddQuery(
+		dummyName,
+		dummyValue,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Equal(t, url.Values{
+		dummyName: []string{"old value", dummyValue},
+	}, sut.query)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestSetBasicAuth(t *testing.T) {
+	// arrange
+	var dummyUsername = "some username"
+	var dummyPassword = "some password"
+	var dummyEncoded = "some encoded"
+
+	// SUT
+	var sut = &networkRequest{
+		header: http.Header{
+			"Authorization": []string{"old value"},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	base64StdEncodingEncodeToStringExpected = 1
+	base64StdEncodingEncodeToString = func(src []byte) string {
+		base64StdEncodingEncodeToStringCalled++
+		assert.Equal(t, []byte(dummyUsername+":"+dummyPassword), src)
+		return dummyEncoded
+	}
+
+	// act
+	var result = sut.SetBasicAuth(
+		dummyUsername,
+		dummyPassword,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Equal(t, http.Header{
+		"Authorization": []string{"Basic " + dummyEncoded},
+	}, sut.header)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestSetBearerToken(t *testing.T) {
+	// arrange
+	var dummyToken = "some token"
+
+	// SUT
+	var sut = &networkRequest{
+		header: http.Header{
+			"Authorization": []string{"old value"},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// act
+	var result = sut.SetBearerToken(
+		dummyToken,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Equal(t, http.Header{
+		"Authorization": []string{"Bearer " + dummyToken},
+	}, sut.header)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestSetPayload(t *testing.T) {
+	// arrange
+	var dummyPayload = strings.NewReader("some payload")
+
+	// SUT
+	var sut = &networkRequest{
+		payload:      "some old payload",
+		payloadError: errors.New("some old error"),
+	}
+
+	// mock
+	createMock(t)
+
+	// act
+	var result = sut.SetPayload(
+		dummyPayload,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Zero(t, sut.payload)
+	assert.Equal(t, dummyPayload, sut.payloadStream)
+	assert.NoError(t, sut.payloadError)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestSetJSONPayload_MarshalError(t *testing.T) {
+	// arrange
+	var dummyPayload = map[string]string{"foo": "bar"}
+	var dummyError = errors.New("some error")
+
+	// SUT
+	var sut = &networkRequest{
+		payloadStream: strings.NewReader("some old payload"),
+		header:        http.Header{},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	jsonMarshalExpected = 1
+	jsonMarshal = func(v interface{}) ([]byte, error) {
+		jsonMarshalCalled++
+		assert.Equal(t, dummyPayload, v)
+		return nil, dummyError
+	}
+
+	// act
+	var result = sut.SetJSONPayload(
+		dummyPayload,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Zero(t, sut.payload)
+	assert.Nil(t, sut.payloadStream)
+	assert.Equal(t, dummyError, sut.payloadError)
+	assert.Equal(t, "application/json", sut.header.Get("Content-Type"))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNetworkRequestSetJSONPayload_ContentTypeSet(t *testing.T) {
+	// arrange
+	var dummyPayload = map[string]string{"foo": "bar"}
+	var dummyBytes = []byte("some bytes")
+	var dummyContentType = "some content type"
+
+	// SUT
+	var sut = &networkRequest{
+		header: http.Header{
+			"Content-Type": []string{dummyContentType},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	jsonMarshalExpected = 1
+	jsonMarshal = func(v interface{}) ([]byte, error) {
+		jsonMarshalCalled++
+		assert.Equal(t, dummyPayload, v)
+		return dummyBytes, nil
+	}
+
+	// act
+	var result = sut.SetJSONPayload(
+		dummyPayload,
+	)
+
+	// assert
+	assert.Equal(t, sut, result)
+	assert.Equal(t, string(dummyBytes), sut.payload)
+	assert.Nil(t, sut.payloadStream)
+	assert.NoError(t, sut.payloadError)
+	assert.Equal(t, dummyContentType, sut.header.Get("Content-Type"))
+
+	// verify
+	verifyAll(t)
+}
+
+type dummyRequestSession struct {
+	*dummySession
+	request *http.Request
+}
+
+func (session *dummyRequestSession) GetRequest() *http.Request {
+	return session.request
+}
+
+func TestGetRequestContext_NoSession(t *testing.T) {
+	// arrange
+	var dummyNetworkRequest = &networkRequest{}
+	var dummyParentContext = context.WithValue(context.Background(), "foo", "bar")
+	var dummyContext = context.WithValue(context.Background(), "test", "123")
+	var dummyCancelCalled int
+
+	// mock
+	createMock(t)
+
+	// expect
+	contextBackgroundExpected = 1
+	contextBackground = func() context.Context {
+		contextBackgroundCalled++
+		return dummyParentContext
+	}
+	contextWithCancelExpected = 1
+	contextWithCancel = func(parent context.Context) (context.Context, context.CancelFunc) {
+		contextWithCancelCalled++
+		assert.Equal(t, dummyParentContext, parent)
+		return dummyContext, func() { dummyCancelCalled++ }
+	}
+
+	// SUT + act
+	var result, cancel = getRequestContext(
+		dummyNetworkRequest,
+	)
+	cancel()
+
+	// assert
+	assert.Equal(t, dummyContext, result)
+	assert.Equal(t, 1, dummyCancelCalled)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestContext_NoSessionRequest(t *testing.T) {
+	// arrange
+	var dummyNetworkRequest = &networkRequest{
+		session: &dummyRequestSession{&dummySession{t}, nil},
+	}
+	var dummyParentContext = context.WithValue(context.Background(), "foo", "bar")
+	var dummyContext = context.WithValue(context.Background(), "test", "123")
+
+	// mock
+	createMock(t)
+
+	// expect
+	contextBackgroundExpected = 1
+	contextBackground = func() context.Context {
+		contextBackgroundCalled++
+		return dummyParentContext
+	}
+	contextWithCancelExpected = 1
+	contextWithCancel = func(parent context.Context) (context.Context, context.CancelFunc) {
+		contextWithCancelCalled++
+		assert.Equal(t, dummyParentContext, parent)
+		return dummyContext, func() {}
+	}
+
+	// SUT + act
+	var result, _ = getRequestContext(
+		dummyNetworkRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyContext, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestContext_SessionRequest_WithTimeout(t *testing.T) {
+	// arrange
+	var dummyParentContext = context.WithValue(context.Background(), "foo", "bar")
+	var dummyRequest = (&http.Request{}).WithContext(dummyParentContext)
+	var dummyTimeout = time.Duration(rand.Intn(1000) + 1)
+	var dummyNetworkRequest = &networkRequest{
+		session: &dummyRequestSession{&dummySession{t}, dummyRequest},
+		timeout: dummyTimeout,
+	}
+	var dummyContext = context.WithValue(context.Background(), "test", "123")
+
+	// mock
+	createMock(t)
+
+	// expect
+	contextBackgroundExpected = 1
+	contextBackground = func() context.Context {
+		contextBackgroundCalled++
+		return context.Background()
+	}
+	contextWithTimeoutExpected = 1
+	contextWithTimeout = func(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+		contextWithTimeoutCalled++
+		assert.Equal(t, dummyParentContext, parent)
+		assert.Equal(t, dummyTimeout, timeout)
+		return dummyContext, func() {}
+	}
+
+	// SUT + act
+	var result, _ = getRequestContext(
+		dummyNetworkRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyContext, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestContext_CancelledWithSessionRequest(t *testing.T) {
+	// arrange
+	var dummyParentContext, dummyParentCancel = context.WithCancel(context.Background())
+	var dummyRequest = (&http.Request{}).WithContext(dummyParentContext)
+	var dummyNetworkRequest = &networkRequest{
+		session: &dummyRequestSession{&dummySession{t}, dummyRequest},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	contextBackgroundExpected = 1
+	contextBackground = func() context.Context {
+		contextBackgroundCalled++
+		return context.Background()
+	}
+	contextWithCancelExpected = 1
+	contextWithCancel = func(parent context.Context) (context.Context, context.CancelFunc) {
+		contextWithCancelCalled++
+		return context.WithCancel(parent)
+	}
+
+	// SUT + act
+	var result, cancel = getRequestContext(
+		dummyNetworkRequest,
+	)
+	defer cancel()
+	dummyParentCancel()
+
+	// assert
+	<-result.Done()
+	assert.Equal(t, context.Canceled, result.Err())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestURL_NoQuery(t *testing.T) {
+	// arrange
+	var dummyURL = "some URL"
+	var dummyNetworkRequest = &networkRequest{
+		url: dummyURL,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getRequestURL(
+		dummyNetworkRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyURL, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestURL_ParseError(t *testing.T) {
+	// arrange
+	var dummyURL = "some URL"
+	var dummyNetworkRequest = &networkRequest{
+		url: dummyURL,
+		query: url.Values{
+			"foo": []string{"bar"},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	urlParseExpected = 1
+	urlParse = func(rawurl string) (*url.URL, error) {
+		urlParseCalled++
+		assert.Equal(t, dummyURL, rawurl)
+		return nil, errors.New("some error")
+	}
+
+	// SUT + act
+	var result = getRequestURL(
+		dummyNetworkRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyURL, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestURL_Success(t *testing.T) {
+	// arrange
+	var dummyURL = "https://localhost/some/path?foo=bar"
+	var dummyNetworkRequest = &networkRequest{
+		url: dummyURL,
+		query: url.Values{
+			"foo":  []string{"baz"},
+			"test": []string{"1 2", "3&4"},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	urlParseExpected = 1
+	urlParse = func(rawurl string) (*url.URL, error) {
+		urlParseCalled++
+		assert.Equal(t, dummyURL, rawurl)
+		return url.Parse(rawurl)
+	}
+
+	// SUT + act
+	var result = getRequestURL(
+		dummyNetworkRequest,
+	)
+
+	// assert
+	assert.Equal(t, "https://localhost/some/path?foo=bar&foo=baz&test=1+2&test=3%264", result)
+
+	// verify
+	verifyAll(t)
+}
+
+type dummySeekerReader struct {
+	*strings.Reader
+	seekError error
+}
+
+func (reader *dummySeekerReader) Seek(offset int64, whence int) (int64, error) {
+	if reader.seekError != nil {
+		return 0, reader.seekError
+	}
+	return reader.Reader.Seek(offset, whence)
+}
+
+type dummyReadCloser struct {
+	*strings.Reader
+	closed bool
+}
+
+func (reader *dummyReadCloser) Close() error {
+	reader.closed = true
+	return nil
+}
+
+func TestIsPayloadReplayable_NoStream(t *testing.T) {
+	// arrange
+	var dummyNetworkRequest = &networkRequest{
+		payload: "some payload",
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isPayloadReplayable(
+		dummyNetworkRequest,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsPayloadReplayable_NotSeeker(t *testing.T) {
+	// arrange
+	var dummyNetworkRequest = &networkRequest{
+		payloadStream: bytes.NewBufferString("some payload"),
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isPayloadReplayable(
+		dummyNetworkRequest,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsPayloadReplayable_Seeker(t *testing.T) {
+	// arrange
+	var dummyNetworkRequest = &networkRequest{
+		payloadStream: strings.NewReader("some payload"),
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isPayloadReplayable(
+		dummyNetworkRequest,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestBody_PayloadError(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var dummyNetworkRequest = &networkRequest{
+		payloadError: dummyError,
+	}
+	var dummyAttempt = rand.Intn(10) + 1
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, err = getRequestBody(
+		dummyNetworkRequest,
+		dummyAttempt,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestBody_NoStream(t *testing.T) {
+	// arrange
+	var dummyPayload = "some payload"
+	var dummyNetworkRequest = &networkRequest{
+		payload: dummyPayload,
+	}
+	var dummyAttempt = rand.Intn(10) + 1
+	var dummyReader = strings.NewReader("some reader")
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsNewReaderExpected = 1
+	stringsNewReader = func(s string) *strings.Reader {
+		stringsNewReaderCalled++
+		assert.Equal(t, dummyPayload, s)
+		return dummyReader
+	}
+
+	// SUT + act
+	var result, err = getRequestBody(
+		dummyNetworkRequest,
+		dummyAttempt,
+	)
+
+	// assert
+	assert.Equal(t, dummyReader, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestBody_FirstAttempt(t *testing.T) {
+	// arrange
+	var dummyStream = bytes.NewBufferString("some payload")
+	var dummyNetworkRequest = &networkRequest{
+		payloadStream: dummyStream,
+	}
+
+	var dummyBody = ioutil.NopCloser(dummyStream)
+
+	// mock
+	createMock(t)
+
+	// expect
+	ioutilNopCloserExpected = 1
+	ioutilNopCloser = func(r io.Reader) io.ReadCloser {
+		ioutilNopCloserCalled++
+		assert.Equal(t, dummyStream, r)
+		return dummyBody
+	}
+
+	// SUT + act
+	var result, err = getRequestBody(
+		dummyNetworkRequest,
+		1,
+	)
+
+	// assert
+	assert.Equal(t, dummyBody, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestBody_NotSeeker(t *testing.T) {
+	// arrange
+	var dummyNetworkRequest = &networkRequest{
+		payloadStream: bytes.NewBufferString("some payload"),
+	}
+	var dummyAttempt = rand.Intn(10) + 2
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Payload stream cannot be rewound for attempt [%v]", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyAttempt, a[0])
+		return dummyError
+	}
+
+	// SUT + act
+	var result, err = getRequestBody(
+		dummyNetworkRequest,
+		dummyAttempt,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestBody_SeekError(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var dummyNetworkRequest = &networkRequest{
+		payloadStream: &dummySeekerReader{
+			strings.NewReader("some payload"),
+			dummyError,
+		},
+	}
+	var dummyAttempt = rand.Intn(10) + 2
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, err = getRequestBody(
+		dummyNetworkRequest,
+		dummyAttempt,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestBody_Rewound(t *testing.T) {
+	// arrange
+	var dummyPayload = "some payload"
+	var dummyStream = &dummySeekerReader{
+		strings.NewReader(dummyPayload),
+		nil,
+	}
+	var dummyNetworkRequest = &networkRequest{
+		payloadStream: dummyStream,
+	}
+	var dummyAttempt = rand.Intn(10) + 2
+	dummyStream.Reader.Seek(0, io.SeekEnd)
+
+	var dummyBody = ioutil.NopCloser(dummyStream)
+
+	// mock
+	createMock(t)
+
+	// expect
+	ioutilNopCloserExpected = 1
+	ioutilNopCloser = func(r io.Reader) io.ReadCloser {
+		ioutilNopCloserCalled++
+		assert.Equal(t, dummyStream, r)
+		return dummyBody
+	}
+
+	// SUT + act
+	var result, err = getRequestBody(
+		dummyNetworkRequest,
+		dummyAttempt,
 	)
 
 	// assert
-	assert.NotNil(t, httpClientWithCert)
-	assert.Equal(t, dummyHTTPTransport1, httpClientWithCert.Transport)
-	assert.Equal(t, dummyNetworkTimeout, httpClientWithCert.Timeout)
-	assert.NotNil(t, httpClientNoCert)
-	assert.Equal(t, dummyHTTPTransport2, httpClientNoCert.Transport)
-	assert.Equal(t, dummyNetworkTimeout, httpClientNoCert.Timeout)
+	assert.Equal(t, dummyBody, result)
+	assert.NoError(t, err)
+	var content, _ = ioutil.ReadAll(result)
+	assert.Equal(t, dummyPayload, string(content))
 
 	// verify
 	verifyAll(t)
 }
 
-func TestNewNetworkRequest(t *testing.T) {
+func TestClosePayload_NotCloser(t *testing.T) {
 	// arrange
-	var dummySessionObject = &dummySession{t}
-	var dummyMethod = "some method"
-	var dummyURL = "some URL"
-	var dummyPayload = "some payload"
-	var dummyHeader = map[string]string{
-		"foo":  "bar",
-		"test": "123",
+	var dummyNetworkRequest = &networkRequest{
+		payloadStream: strings.NewReader("some payload"),
 	}
-	var dummySendClientCert = rand.Intn(100) < 50
 
 	// mock
 	createMock(t)
 
-	// SUT
-	var result = NewNetworkRequest(
-		dummySessionObject,
-		dummyMethod,
-		dummyURL,
-		dummyPayload,
-		dummyHeader,
-		dummySendClientCert,
+	// SUT + act
+	closePayload(
+		dummyNetworkRequest,
 	)
 
-	// act
-	var typedResult, ok = result.(*networkRequest)
-
-	// assert
-	assert.True(t, ok)
-	assert.NotNil(t, typedResult)
-	assert.Equal(t, dummySessionObject, typedResult.session)
-	assert.Equal(t, dummyMethod, typedResult.method)
-	assert.Equal(t, dummyURL, typedResult.url)
-	assert.Equal(t, dummyPayload, typedResult.payload)
-	assert.Equal(t, dummyHeader, typedResult.header)
-	assert.Equal(t, dummySendClientCert, typedResult.sendClientCert)
-
 	// verify
 	verifyAll(t)
 }
 
-func TestNetworkRequestEnableRetry(t *testing.T) {
+func TestClosePayload_Closer(t *testing.T) {
 	// arrange
-	var dummyConnRetry = rand.Int()
-	var dummyHTTPRetry = map[int]int{
-		rand.Int(): rand.Int(),
-		rand.Int(): rand.Int(),
+	var dummyStream = &dummyReadCloser{
+		Reader: strings.NewReader("some payload"),
+	}
+	var dummyNetworkRequest = &networkRequest{
+		payloadStream: dummyStream,
 	}
-
-	// SUT
-	var sut = &networkRequest{}
 
 	// mock
 	createMock(t)
 
-	// act
-	sut.EnableRetry(
-		dummyConnRetry,
-		dummyHTTPRetry,
+	// SUT + act
+	closePayload(
+		dummyNetworkRequest,
 	)
 
 	// assert
-	assert.Equal(t, dummyConnRetry, sut.connRetry)
-	assert.Equal(t, dummyHTTPRetry, sut.httpRetry)
+	assert.True(t, dummyStream.closed)
 
 	// verify
 	verifyAll(t)
@@ -755,32 +2566,119 @@ func TestCustomizeHTTPRequest_WithCustomization(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestCreateHTTPRequest_BodyError(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t}
+	var dummyMethod = "some method"
+	var dummyURL = "some URL"
+	var dummyPayload = "some payload"
+	var dummyHeader = http.Header{
+		"Foo":  []string{"bar", "baz"},
+		"Test": []string{"123"},
+	}
+	var dummyRetryPolicy = &model.RetryPolicy{
+		ConnectivityRetryCount: rand.Int(),
+	}
+	var dummySendClientCert = rand.Intn(100) < 50
+	var dummyContext = context.WithValue(context.Background(), "foo", "bar")
+	var dummyNetworkRequest = &networkRequest{
+		dummySessionObject,
+		dummyMethod,
+		dummyURL,
+		dummyPayload,
+		dummyHeader,
+		dummyRetryPolicy,
+		dummySendClientCert,
+		nil,
+		"",
+		0,
+		dummyContext,
+		nil,
+		nil,
+		nil,
+	}
+	var dummyAttempt = rand.Intn(10) + 1
+	var dummyRequestURL = "some request URL"
+	var dummyError = errors.New("some error message")
+	var expectedErrorMessage = "Failed to generate request to [%v]"
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	getRequestURLFuncExpected = 1
+	getRequestURLFunc = func(networkRequest *networkRequest) string {
+		getRequestURLFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummyRequestURL
+	}
+	getRequestBodyFuncExpected = 1
+	getRequestBodyFunc = func(networkRequest *networkRequest, attempt int) (io.Reader, error) {
+		getRequestBodyFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		assert.Equal(t, dummyAttempt, attempt)
+		return nil, dummyError
+	}
+	apperrorWrapSimpleErrorExpected = 1
+	apperrorWrapSimpleError = func(innerErrors []error, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
+		apperrorWrapSimpleErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyError, innerErrors[0])
+		assert.Equal(t, expectedErrorMessage, messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, dummyRequestURL, parameters[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var result, err = createHTTPRequest(
+		dummyNetworkRequest,
+		dummyAttempt,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestCreateHTTPRequest_RequestError(t *testing.T) {
 	// arrange
 	var dummySessionObject = &dummySession{t}
 	var dummyMethod = "some method"
 	var dummyURL = "some URL"
 	var dummyPayload = "some payload"
-	var dummyHeader = map[string]string{
-		"foo":  "bar",
-		"test": "123",
+	var dummyHeader = http.Header{
+		"Foo":  []string{"bar", "baz"},
+		"Test": []string{"123"},
 	}
-	var dummyConnRetry = rand.Int()
-	var dummyHTTPRetry = map[int]int{
-		rand.Int(): rand.Int(),
-		rand.Int(): rand.Int(),
+	var dummyRetryPolicy = &model.RetryPolicy{
+		ConnectivityRetryCount: rand.Int(),
 	}
 	var dummySendClientCert = rand.Intn(100) < 50
+	var dummyContext = context.WithValue(context.Background(), "foo", "bar")
 	var dummyNetworkRequest = &networkRequest{
 		dummySessionObject,
 		dummyMethod,
 		dummyURL,
 		dummyPayload,
 		dummyHeader,
-		dummyConnRetry,
-		dummyHTTPRetry,
+		dummyRetryPolicy,
 		dummySendClientCert,
+		nil,
+		"",
+		0,
+		dummyContext,
+		nil,
+		nil,
+		nil,
 	}
+	var dummyAttempt = rand.Intn(10) + 1
+	var dummyRequestURL = "some request URL"
+	var dummyBody = strings.NewReader("some body")
 	var dummyRequest *http.Request
 	var dummyError = errors.New("some error message")
 	var expectedErrorMessage = "Failed to generate request to [%v]"
@@ -790,17 +2688,26 @@ func TestCreateHTTPRequest_RequestError(t *testing.T) {
 	createMock(t)
 
 	// expect
-	stringsNewReaderExpected = 1
-	stringsNewReader = func(s string) *strings.Reader {
-		stringsNewReaderCalled++
-		return strings.NewReader(s)
+	getRequestURLFuncExpected = 1
+	getRequestURLFunc = func(networkRequest *networkRequest) string {
+		getRequestURLFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummyRequestURL
 	}
-	httpNewRequestExpected = 1
-	httpNewRequest = func(method, url string, body io.Reader) (*http.Request, error) {
-		httpNewRequestCalled++
+	getRequestBodyFuncExpected = 1
+	getRequestBodyFunc = func(networkRequest *networkRequest, attempt int) (io.Reader, error) {
+		getRequestBodyFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		assert.Equal(t, dummyAttempt, attempt)
+		return dummyBody, nil
+	}
+	httpNewRequestWithContextExpected = 1
+	httpNewRequestWithContext = func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+		httpNewRequestWithContextCalled++
+		assert.Equal(t, dummyContext, ctx)
 		assert.Equal(t, dummyMethod, method)
-		assert.Equal(t, dummyURL, url)
-		assert.NotNil(t, body)
+		assert.Equal(t, dummyRequestURL, url)
+		assert.Equal(t, dummyBody, body)
 		return dummyRequest, dummyError
 	}
 	apperrorWrapSimpleErrorExpected = 1
@@ -810,13 +2717,14 @@ func TestCreateHTTPRequest_RequestError(t *testing.T) {
 		assert.Equal(t, dummyError, innerErrors[0])
 		assert.Equal(t, expectedErrorMessage, messageFormat)
 		assert.Equal(t, 1, len(parameters))
-		assert.Equal(t, dummyURL, parameters[0])
+		assert.Equal(t, dummyRequestURL, parameters[0])
 		return dummyAppError
 	}
 
 	// SUT + act
 	var result, err = createHTTPRequest(
 		dummyNetworkRequest,
+		dummyAttempt,
 	)
 
 	// assert
@@ -829,30 +2737,40 @@ func TestCreateHTTPRequest_RequestError(t *testing.T) {
 
 func TestCreateHTTPRequest_Success(t *testing.T) {
 	// arrange
+	var dummySpan = &tracingModel.Span{Name: "some span"}
 	var dummySessionObject = &dummySession{t}
 	var dummyMethod = "some method"
 	var dummyURL = "some URL"
 	var dummyPayload = "some payload"
-	var dummyHeader = map[string]string{
-		"foo":  "bar",
-		"test": "123",
+	var dummyHeader = http.Header{
+		"Foo":  []string{"bar", "baz"},
+		"Test": []string{"123"},
 	}
-	var dummyConnRetry = rand.Int()
-	var dummyHTTPRetry = map[int]int{
-		rand.Int(): rand.Int(),
-		rand.Int(): rand.Int(),
+	var dummyRetryPolicy = &model.RetryPolicy{
+		ConnectivityRetryCount: rand.Int(),
 	}
 	var dummySendClientCert = rand.Intn(100) < 50
+	var dummyContext = context.WithValue(context.Background(), "foo", "bar")
 	var dummyNetworkRequest = &networkRequest{
 		dummySessionObject,
 		dummyMethod,
 		dummyURL,
 		dummyPayload,
 		dummyHeader,
-		dummyConnRetry,
-		dummyHTTPRetry,
+		dummyRetryPolicy,
 		dummySendClientCert,
+		dummySpan,
+		"",
+		0,
+		dummyContext,
+		nil,
+		nil,
+		nil,
 	}
+	var dummyAttempt = rand.Intn(10) + 1
+	var dummyRequestURL = "some request URL"
+	var dummyBody = strings.NewReader("some body")
+	var dummyAttemptString = "some attempt"
 	var dummyRequest = &http.Request{
 		RequestURI: "abc",
 	}
@@ -864,26 +2782,175 @@ func TestCreateHTTPRequest_Success(t *testing.T) {
 	createMock(t)
 
 	// expect
-	stringsNewReaderExpected = 1
-	stringsNewReader = func(s string) *strings.Reader {
-		stringsNewReaderCalled++
-		return strings.NewReader(s)
+	getRequestURLFuncExpected = 1
+	getRequestURLFunc = func(networkRequest *networkRequest) string {
+		getRequestURLFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummyRequestURL
+	}
+	getRequestBodyFuncExpected = 1
+	getRequestBodyFunc = func(networkRequest *networkRequest, attempt int) (io.Reader, error) {
+		getRequestBodyFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		assert.Equal(t, dummyAttempt, attempt)
+		return dummyBody, nil
+	}
+	httpNewRequestWithContextExpected = 1
+	httpNewRequestWithContext = func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+		httpNewRequestWithContextCalled++
+		assert.Equal(t, dummyContext, ctx)
+		assert.Equal(t, dummyMethod, method)
+		assert.Equal(t, dummyRequestURL, url)
+		assert.Equal(t, dummyBody, body)
+		return dummyRequest, nil
+	}
+	strconvItoaExpected = 1
+	strconvItoa = func(i int) string {
+		strconvItoaCalled++
+		assert.Equal(t, dummyAttempt, i)
+		return dummyAttemptString
+	}
+	loggerNetworkCallExpected = 1
+	loggerNetworkCall = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerNetworkCallCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyMethod, category)
+		assert.Equal(t, dummyRequestURL, messageFormat)
+		assert.Equal(t, dummyAttemptString, subcategory)
+		assert.Empty(t, parameters)
+	}
+	loggerNetworkRequestExpected = 1
+	loggerNetworkRequest = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerNetworkRequestCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, "Payload", category)
+		assert.Zero(t, subcategory)
+		assert.Equal(t, dummyPayload, messageFormat)
+		assert.Empty(t, parameters)
+	}
+	tracingInjectHeaderExpected = 1
+	tracingInjectHeader = func(header http.Header, span *tracingModel.Span) {
+		tracingInjectHeaderCalled++
+		assert.Equal(t, dummyHeader, header)
+		assert.Equal(t, dummySpan, span)
+	}
+	headerutilSetCorrelationIDHeaderExpected = 1
+	headerutilSetCorrelationIDHeader = func(session sessionModel.Session, header http.Header) {
+		headerutilSetCorrelationIDHeaderCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyHeader, header)
+	}
+	headerutilLogHTTPHeaderExpected = 1
+	headerutilLogHTTPHeader = func(session sessionModel.Session, header http.Header, logFunc logger.LogFunc) {
+		headerutilLogHTTPHeaderCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyHeader, header)
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(loggerNetworkRequest)), fmt.Sprintf("%v", reflect.ValueOf(logFunc)))
+	}
+	customizeHTTPRequestFuncExpected = 1
+	customizeHTTPRequestFunc = func(session sessionModel.Session, httpRequest *http.Request) *http.Request {
+		customizeHTTPRequestFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyRequest, httpRequest)
+		return dummyCustomized
+	}
+
+	// SUT + act
+	var result, err = createHTTPRequest(
+		dummyNetworkRequest,
+		dummyAttempt,
+	)
+
+	// assert
+	assert.Equal(t, dummyCustomized, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCreateHTTPRequest_StreamedPayload(t *testing.T) {
+	// arrange
+	var dummySpan = &tracingModel.Span{Name: "some span"}
+	var dummySessionObject = &dummySession{t}
+	var dummyBody = strings.NewReader("some body")
+	var dummyMethod = "some method"
+	var dummyURL = "some URL"
+	var dummyPayload = "some payload"
+	var dummyHeader = http.Header{
+		"Foo":  []string{"bar", "baz"},
+		"Test": []string{"123"},
+	}
+	var dummyRetryPolicy = &model.RetryPolicy{
+		ConnectivityRetryCount: rand.Int(),
+	}
+	var dummySendClientCert = rand.Intn(100) < 50
+	var dummyContext = context.WithValue(context.Background(), "foo", "bar")
+	var dummyNetworkRequest = &networkRequest{
+		dummySessionObject,
+		dummyMethod,
+		dummyURL,
+		dummyPayload,
+		dummyHeader,
+		dummyRetryPolicy,
+		dummySendClientCert,
+		dummySpan,
+		"",
+		0,
+		dummyContext,
+		nil,
+		dummyBody,
+		nil,
+	}
+	var dummyAttempt = rand.Intn(10) + 1
+	var dummyRequestURL = "some request URL"
+	var dummyAttemptString = "some attempt"
+	var dummyRequest = &http.Request{
+		RequestURI: "abc",
+	}
+	var dummyCustomized = &http.Request{
+		RequestURI: "xyz",
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getRequestURLFuncExpected = 1
+	getRequestURLFunc = func(networkRequest *networkRequest) string {
+		getRequestURLFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummyRequestURL
+	}
+	getRequestBodyFuncExpected = 1
+	getRequestBodyFunc = func(networkRequest *networkRequest, attempt int) (io.Reader, error) {
+		getRequestBodyFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		assert.Equal(t, dummyAttempt, attempt)
+		return dummyBody, nil
 	}
-	httpNewRequestExpected = 1
-	httpNewRequest = func(method, url string, body io.Reader) (*http.Request, error) {
-		httpNewRequestCalled++
+	httpNewRequestWithContextExpected = 1
+	httpNewRequestWithContext = func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+		httpNewRequestWithContextCalled++
+		assert.Equal(t, dummyContext, ctx)
 		assert.Equal(t, dummyMethod, method)
-		assert.Equal(t, dummyURL, url)
-		assert.NotNil(t, body)
+		assert.Equal(t, dummyRequestURL, url)
+		assert.Equal(t, dummyBody, body)
 		return dummyRequest, nil
 	}
+	strconvItoaExpected = 1
+	strconvItoa = func(i int) string {
+		strconvItoaCalled++
+		assert.Equal(t, dummyAttempt, i)
+		return dummyAttemptString
+	}
 	loggerNetworkCallExpected = 1
 	loggerNetworkCall = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerNetworkCallCalled++
 		assert.Equal(t, dummySessionObject, session)
 		assert.Equal(t, dummyMethod, category)
-		assert.Equal(t, dummyURL, messageFormat)
-		assert.Zero(t, subcategory)
+		assert.Equal(t, dummyRequestURL, messageFormat)
+		assert.Equal(t, dummyAttemptString, subcategory)
 		assert.Empty(t, parameters)
 	}
 	loggerNetworkRequestExpected = 1
@@ -892,15 +2959,26 @@ func TestCreateHTTPRequest_Success(t *testing.T) {
 		assert.Equal(t, dummySessionObject, session)
 		assert.Equal(t, "Payload", category)
 		assert.Zero(t, subcategory)
-		assert.Equal(t, dummyPayload, messageFormat)
+		assert.Equal(t, "[streamed]", messageFormat)
 		assert.Empty(t, parameters)
 	}
+	tracingInjectHeaderExpected = 1
+	tracingInjectHeader = func(header http.Header, span *tracingModel.Span) {
+		tracingInjectHeaderCalled++
+		assert.Equal(t, dummyHeader, header)
+		assert.Equal(t, dummySpan, span)
+	}
+	headerutilSetCorrelationIDHeaderExpected = 1
+	headerutilSetCorrelationIDHeader = func(session sessionModel.Session, header http.Header) {
+		headerutilSetCorrelationIDHeaderCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyHeader, header)
+	}
 	headerutilLogHTTPHeaderExpected = 1
 	headerutilLogHTTPHeader = func(session sessionModel.Session, header http.Header, logFunc logger.LogFunc) {
 		headerutilLogHTTPHeaderCalled++
 		assert.Equal(t, dummySessionObject, session)
-		assert.Equal(t, dummyHeader["foo"], header["Foo"][0])
-		assert.Equal(t, dummyHeader["test"], header["Test"][0])
+		assert.Equal(t, dummyHeader, header)
 		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(loggerNetworkRequest)), fmt.Sprintf("%v", reflect.ValueOf(logFunc)))
 	}
 	customizeHTTPRequestFuncExpected = 1
@@ -914,6 +2992,7 @@ func TestCreateHTTPRequest_Success(t *testing.T) {
 	// SUT + act
 	var result, err = createHTTPRequest(
 		dummyNetworkRequest,
+		dummyAttempt,
 	)
 
 	// assert
@@ -930,6 +3009,8 @@ func TestLogErrorResponse(t *testing.T) {
 	var dummyError = errors.New("some error")
 	var dummyStartTime = time.Now()
 	var dummyTimeSince = time.Duration(rand.Intn(1000))
+	var dummyAttempt = rand.Intn(10) + 1
+	var dummyAttemptString = "some attempt"
 
 	// mock
 	createMock(t)
@@ -951,12 +3032,18 @@ func TestLogErrorResponse(t *testing.T) {
 		assert.Equal(t, dummyStartTime, ts)
 		return dummyTimeSince
 	}
+	strconvItoaExpected = 1
+	strconvItoa = func(i int) string {
+		strconvItoaCalled++
+		assert.Equal(t, dummyAttempt, i)
+		return dummyAttemptString
+	}
 	loggerNetworkFinishExpected = 1
 	loggerNetworkFinish = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerNetworkFinishCalled++
 		assert.Equal(t, dummySessionObject, session)
 		assert.Equal(t, "Error", category)
-		assert.Zero(t, subcategory)
+		assert.Equal(t, dummyAttemptString, subcategory)
 		assert.Equal(t, "%s", messageFormat)
 		assert.Equal(t, 1, len(parameters))
 		assert.Equal(t, dummyTimeSince, parameters[0])
@@ -967,6 +3054,7 @@ func TestLogErrorResponse(t *testing.T) {
 		dummySessionObject,
 		dummyError,
 		dummyStartTime,
+		dummyAttempt,
 	)
 
 	// verify
@@ -978,6 +3066,7 @@ func TestLogHTTPResponse_NilResponse(t *testing.T) {
 	var dummySessionObject = &dummySession{t}
 	var dummyResponse *http.Response
 	var dummyStartTime = time.Now()
+	var dummyAttempt = rand.Intn(10) + 1
 
 	// mock
 	createMock(t)
@@ -987,6 +3076,7 @@ func TestLogHTTPResponse_NilResponse(t *testing.T) {
 		dummySessionObject,
 		dummyResponse,
 		dummyStartTime,
+		dummyAttempt,
 	)
 
 	// verify
@@ -1015,6 +3105,7 @@ func TestLogHTTPResponse_ValidResponse(t *testing.T) {
 	var dummyNewBody = ioutil.NopCloser(bytes.NewBufferString("some new body"))
 	var dummyStartTime = time.Now()
 	var dummyTimeSince = time.Duration(rand.Intn(1000))
+	var dummyAttempt = rand.Intn(10) + 1
 
 	// mock
 	createMock(t)
@@ -1047,7 +3138,7 @@ func TestLogHTTPResponse_ValidResponse(t *testing.T) {
 	strconvItoaExpected = 1
 	strconvItoa = func(i int) string {
 		strconvItoaCalled++
-		assert.Equal(t, dummyStatusCode, i)
+		assert.Equal(t, dummyAttempt, i)
 		return strconv.Itoa(i)
 	}
 	loggerNetworkResponseExpected = 1
@@ -1077,10 +3168,11 @@ func TestLogHTTPResponse_ValidResponse(t *testing.T) {
 		loggerNetworkFinishCalled++
 		assert.Equal(t, dummySessionObject, session)
 		assert.Equal(t, dummyStatus, category)
-		assert.Equal(t, strconv.Itoa(dummyStatusCode), subcategory)
-		assert.Equal(t, "%s", messageFormat)
-		assert.Equal(t, 1, len(parameters))
-		assert.Equal(t, dummyTimeSince, parameters[0])
+		assert.Equal(t, strconv.Itoa(dummyAttempt), subcategory)
+		assert.Equal(t, "%v: %s", messageFormat)
+		assert.Equal(t, 2, len(parameters))
+		assert.Equal(t, dummyStatusCode, parameters[0])
+		assert.Equal(t, dummyTimeSince, parameters[1])
 	}
 
 	// SUT + act
@@ -1088,6 +3180,7 @@ func TestLogHTTPResponse_ValidResponse(t *testing.T) {
 		dummySessionObject,
 		dummyResponse,
 		dummyStartTime,
+		dummyAttempt,
 	)
 
 	// assert
@@ -1097,22 +3190,286 @@ func TestLogHTTPResponse_ValidResponse(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestRecordNetworkMetrics_NoResponse(t *testing.T) {
+	// arrange
+	var dummyRequestObject = &http.Request{
+		Method: http.MethodPost,
+	}
+	var dummyResponseObject *http.Response
+	var dummyStartTime = time.Now()
+	var dummyTimeSince = time.Duration(rand.Intn(1000))
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeSinceExpected = 1
+	timeSince = func(ts time.Time) time.Duration {
+		timeSinceCalled++
+		assert.Equal(t, dummyStartTime, ts)
+		return dummyTimeSince
+	}
+	metricsNetworkRequestFinishedExpected = 1
+	metricsNetworkRequestFinished = func(host string, method string, statusCode int, duration time.Duration) {
+		metricsNetworkRequestFinishedCalled++
+		assert.Zero(t, host)
+		assert.Equal(t, http.MethodPost, method)
+		assert.Zero(t, statusCode)
+		assert.Equal(t, dummyTimeSince, duration)
+	}
+
+	// SUT + act
+	recordNetworkMetrics(
+		dummyRequestObject,
+		dummyResponseObject,
+		dummyStartTime,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRecordNetworkMetrics_WithResponse(t *testing.T) {
+	// arrange
+	var dummyHost = "some.host:8080"
+	var dummyRequestObject = &http.Request{
+		Method: http.MethodGet,
+		URL: &url.URL{
+			Host: dummyHost,
+		},
+	}
+	var dummyStatusCode = rand.Intn(600)
+	var dummyResponseObject = &http.Response{
+		StatusCode: dummyStatusCode,
+	}
+	var dummyStartTime = time.Now()
+	var dummyTimeSince = time.Duration(rand.Intn(1000))
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeSinceExpected = 1
+	timeSince = func(ts time.Time) time.Duration {
+		timeSinceCalled++
+		assert.Equal(t, dummyStartTime, ts)
+		return dummyTimeSince
+	}
+	metricsNetworkRequestFinishedExpected = 1
+	metricsNetworkRequestFinished = func(host string, method string, statusCode int, duration time.Duration) {
+		metricsNetworkRequestFinishedCalled++
+		assert.Equal(t, dummyHost, host)
+		assert.Equal(t, http.MethodGet, method)
+		assert.Equal(t, dummyStatusCode, statusCode)
+		assert.Equal(t, dummyTimeSince, duration)
+	}
+
+	// SUT + act
+	recordNetworkMetrics(
+		dummyRequestObject,
+		dummyResponseObject,
+		dummyStartTime,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStartNetworkSpan(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t}
+	var dummyMethod = "some method"
+	var dummyURL = "some URL"
+	var dummyNetworkRequest = &networkRequest{
+		session: dummySessionObject,
+		method:  dummyMethod,
+		url:     dummyURL,
+	}
+	var dummySessionSpan = &tracingModel.Span{Name: "some session span"}
+	var dummySpan = &tracingModel.Span{Name: "some span"}
+	var expectedKeys = []string{"http.method", "http.url"}
+	var expectedValues = []string{dummyMethod, dummyURL}
+
+	// mock
+	createMock(t)
+
+	// expect
+	tracingGetSessionSpanExpected = 1
+	tracingGetSessionSpan = func(session sessionModel.Session) *tracingModel.Span {
+		tracingGetSessionSpanCalled++
+		assert.Equal(t, dummySessionObject, session)
+		return dummySessionSpan
+	}
+	tracingStartSpanExpected = 1
+	tracingStartSpan = func(parent *tracingModel.Span, name string, kind tracingModel.SpanKind) *tracingModel.Span {
+		tracingStartSpanCalled++
+		assert.Equal(t, dummySessionSpan, parent)
+		assert.Equal(t, "HTTP "+dummyMethod, name)
+		assert.Equal(t, tracingModel.SpanKindClient, kind)
+		return dummySpan
+	}
+	tracingSetAttributeExpected = 2
+	tracingSetAttribute = func(span *tracingModel.Span, key string, value string) {
+		tracingSetAttributeCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.Equal(t, expectedKeys[tracingSetAttributeCalled-1], key)
+		assert.Equal(t, expectedValues[tracingSetAttributeCalled-1], value)
+	}
+
+	// SUT + act
+	var result = startNetworkSpan(
+		dummyNetworkRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummySpan, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEndNetworkSpan_NoResponse(t *testing.T) {
+	// arrange
+	var dummySpan = &tracingModel.Span{Name: "some span"}
+	var dummyResponseError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	tracingEndSpanExpected = 1
+	tracingEndSpan = func(span *tracingModel.Span, err error) {
+		tracingEndSpanCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.Equal(t, dummyResponseError, err)
+	}
+
+	// SUT + act
+	endNetworkSpan(
+		dummySpan,
+		nil,
+		dummyResponseError,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEndNetworkSpan_WithResponse(t *testing.T) {
+	// arrange
+	var dummySpan = &tracingModel.Span{Name: "some span"}
+	var dummyStatusCode = rand.Intn(600)
+	var dummyResponseObject = &http.Response{
+		StatusCode: dummyStatusCode,
+	}
+	var dummyStatusText = "some status text"
+
+	// mock
+	createMock(t)
+
+	// expect
+	strconvItoaExpected = 1
+	strconvItoa = func(i int) string {
+		strconvItoaCalled++
+		assert.Equal(t, dummyStatusCode, i)
+		return dummyStatusText
+	}
+	tracingSetAttributeExpected = 1
+	tracingSetAttribute = func(span *tracingModel.Span, key string, value string) {
+		tracingSetAttributeCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.Equal(t, "http.status_code", key)
+		assert.Equal(t, dummyStatusText, value)
+	}
+	tracingEndSpanExpected = 1
+	tracingEndSpan = func(span *tracingModel.Span, err error) {
+		tracingEndSpanCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.NoError(t, err)
+	}
+
+	// SUT + act
+	endNetworkSpan(
+		dummySpan,
+		dummyResponseObject,
+		nil,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestDoRequestProcessing_RequestError(t *testing.T) {
 	// arrange
 	var dummyNetworkRequest = &networkRequest{}
 	var dummyRequestObject *http.Request
 	var dummyRequestError = errors.New("some error")
+	var dummySpan = &tracingModel.Span{Name: "some span"}
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyContext = context.WithValue(context.Background(), "foo", "bar")
+	var dummyCancelCalled int
 
 	// mock
 	createMock(t)
 
 	// expect
+	startNetworkSpanFuncExpected = 1
+	startNetworkSpanFunc = func(networkRequest *networkRequest) *tracingModel.Span {
+		startNetworkSpanFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummySpan
+	}
+	getRequestContextFuncExpected = 1
+	getRequestContextFunc = func(networkRequest *networkRequest) (context.Context, context.CancelFunc) {
+		getRequestContextFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummyContext, func() { dummyCancelCalled++ }
+	}
+	closePayloadFuncExpected = 1
+	closePayloadFunc = func(networkRequest *networkRequest) {
+		closePayloadFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+	}
+	getDependencyFuncExpected = 1
+	getDependencyFunc = func(networkRequest *networkRequest) string {
+		getDependencyFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummyDependency
+	}
+	getCircuitBreakerFuncExpected = 1
+	getCircuitBreakerFunc = func(dependency string) *model.CircuitBreaker {
+		getCircuitBreakerFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		return dummyCircuitBreaker
+	}
+	allowRequestFuncExpected = 1
+	allowRequestFunc = func(dependency string, circuitBreaker *model.CircuitBreaker) error {
+		allowRequestFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyCircuitBreaker, circuitBreaker)
+		return nil
+	}
 	createHTTPRequestFuncExpected = 1
-	createHTTPRequestFunc = func(networkRequest *networkRequest) (*http.Request, error) {
+	createHTTPRequestFunc = func(networkRequest *networkRequest, attempt int) (*http.Request, error) {
 		createHTTPRequestFuncCalled++
+		assert.Equal(t, 1, attempt)
 		assert.Equal(t, dummyNetworkRequest, networkRequest)
 		return dummyRequestObject, dummyRequestError
 	}
+	releaseTrialFuncExpected = 1
+	releaseTrialFunc = func(dependency string, circuitBreaker *model.CircuitBreaker) {
+		releaseTrialFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyCircuitBreaker, circuitBreaker)
+	}
+	endNetworkSpanFuncExpected = 1
+	endNetworkSpanFunc = func(span *tracingModel.Span, responseObject *http.Response, responseError error) {
+		endNetworkSpanFuncCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.Nil(t, responseObject)
+		assert.Equal(t, dummyRequestError, responseError)
+	}
 
 	// SUT + act
 	var result, err = doRequestProcessing(
@@ -1120,8 +3477,83 @@ func TestDoRequestProcessing_RequestError(t *testing.T) {
 	)
 
 	// assert
+	assert.Equal(t, dummyContext, dummyNetworkRequest.context)
+	assert.Equal(t, 1, dummyCancelCalled)
 	assert.Nil(t, result)
 	assert.Equal(t, dummyRequestError, err)
+	assert.Equal(t, dummySpan, dummyNetworkRequest.span)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDoRequestProcessing_CircuitOpen(t *testing.T) {
+	// arrange
+	var dummyNetworkRequest = &networkRequest{}
+	var dummySpan = &tracingModel.Span{Name: "some span"}
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyCircuitError = errors.New("some circuit error")
+	var dummyContext = context.WithValue(context.Background(), "foo", "bar")
+	var dummyCancelCalled int
+
+	// mock
+	createMock(t)
+
+	// expect
+	startNetworkSpanFuncExpected = 1
+	startNetworkSpanFunc = func(networkRequest *networkRequest) *tracingModel.Span {
+		startNetworkSpanFuncCalled++
+		return dummySpan
+	}
+	getRequestContextFuncExpected = 1
+	getRequestContextFunc = func(networkRequest *networkRequest) (context.Context, context.CancelFunc) {
+		getRequestContextFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummyContext, func() { dummyCancelCalled++ }
+	}
+	closePayloadFuncExpected = 1
+	closePayloadFunc = func(networkRequest *networkRequest) {
+		closePayloadFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+	}
+	getDependencyFuncExpected = 1
+	getDependencyFunc = func(networkRequest *networkRequest) string {
+		getDependencyFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummyDependency
+	}
+	getCircuitBreakerFuncExpected = 1
+	getCircuitBreakerFunc = func(dependency string) *model.CircuitBreaker {
+		getCircuitBreakerFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		return dummyCircuitBreaker
+	}
+	allowRequestFuncExpected = 1
+	allowRequestFunc = func(dependency string, circuitBreaker *model.CircuitBreaker) error {
+		allowRequestFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyCircuitBreaker, circuitBreaker)
+		return dummyCircuitError
+	}
+	endNetworkSpanFuncExpected = 1
+	endNetworkSpanFunc = func(span *tracingModel.Span, responseObject *http.Response, responseError error) {
+		endNetworkSpanFuncCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.Nil(t, responseObject)
+		assert.Equal(t, dummyCircuitError, responseError)
+	}
+
+	// SUT + act
+	var result, err = doRequestProcessing(
+		dummyNetworkRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyContext, dummyNetworkRequest.context)
+	assert.Equal(t, 1, dummyCancelCalled)
+	assert.Nil(t, result)
+	assert.Equal(t, dummyCircuitError, err)
 
 	// verify
 	verifyAll(t)
@@ -1130,16 +3562,13 @@ func TestDoRequestProcessing_RequestError(t *testing.T) {
 func TestDoRequestProcessing_ResponseError(t *testing.T) {
 	// arrange
 	var dummySessionObject = &dummySession{t}
-	var dummyConnRetry = rand.Int()
-	var dummyHTTPRetry = map[int]int{
-		rand.Int(): rand.Int(),
-		rand.Int(): rand.Int(),
+	var dummyRetryPolicy = &model.RetryPolicy{
+		ConnectivityRetryCount: rand.Int(),
 	}
 	var dummySendClientCert = rand.Intn(100) < 50
 	var dummyNetworkRequest = &networkRequest{
 		session:        dummySessionObject,
-		connRetry:      dummyConnRetry,
-		httpRetry:      dummyHTTPRetry,
+		retryPolicy:    dummyRetryPolicy,
 		sendClientCert: dummySendClientCert,
 	}
 	var dummyHTTPClient = &http.Client{}
@@ -1147,17 +3576,59 @@ func TestDoRequestProcessing_ResponseError(t *testing.T) {
 	var dummyResponseObject *http.Response
 	var dummyResponseError = errors.New("some error")
 	var dummyStartTime = time.Now()
+	var dummySpan = &tracingModel.Span{Name: "some span"}
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyContext = context.WithValue(context.Background(), "foo", "bar")
+	var dummyCancelCalled int
 
 	// mock
 	createMock(t)
 
 	// expect
+	startNetworkSpanFuncExpected = 1
+	startNetworkSpanFunc = func(networkRequest *networkRequest) *tracingModel.Span {
+		startNetworkSpanFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummySpan
+	}
+	getRequestContextFuncExpected = 1
+	getRequestContextFunc = func(networkRequest *networkRequest) (context.Context, context.CancelFunc) {
+		getRequestContextFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummyContext, func() { dummyCancelCalled++ }
+	}
+	closePayloadFuncExpected = 1
+	closePayloadFunc = func(networkRequest *networkRequest) {
+		closePayloadFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+	}
 	createHTTPRequestFuncExpected = 1
-	createHTTPRequestFunc = func(networkRequest *networkRequest) (*http.Request, error) {
+	createHTTPRequestFunc = func(networkRequest *networkRequest, attempt int) (*http.Request, error) {
 		createHTTPRequestFuncCalled++
+		assert.Equal(t, 1, attempt)
 		assert.Equal(t, dummyNetworkRequest, networkRequest)
 		return dummyRequestObject, nil
 	}
+	getDependencyFuncExpected = 1
+	getDependencyFunc = func(networkRequest *networkRequest) string {
+		getDependencyFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummyDependency
+	}
+	getCircuitBreakerFuncExpected = 1
+	getCircuitBreakerFunc = func(dependency string) *model.CircuitBreaker {
+		getCircuitBreakerFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		return dummyCircuitBreaker
+	}
+	allowRequestFuncExpected = 1
+	allowRequestFunc = func(dependency string, circuitBreaker *model.CircuitBreaker) error {
+		allowRequestFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyCircuitBreaker, circuitBreaker)
+		return nil
+	}
 	getClientForRequestFuncExpected = 1
 	getClientForRequestFunc = func(sendClientCert bool) *http.Client {
 		getClientForRequestFuncCalled++
@@ -1170,21 +3641,35 @@ func TestDoRequestProcessing_ResponseError(t *testing.T) {
 		return dummyStartTime
 	}
 	clientDoWithRetryFuncExpected = 1
-	clientDoWithRetryFunc = func(client *http.Client, request *http.Request, connRetry int, httpRetry map[int]int) (*http.Response, error) {
+	clientDoWithRetryFunc = func(networkRequest *networkRequest, client *http.Client, request *http.Request) (*http.Response, error) {
 		clientDoWithRetryFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
 		assert.Equal(t, dummyHTTPClient, client)
 		assert.Equal(t, dummyRequestObject, request)
-		assert.Equal(t, dummyConnRetry, connRetry)
-		assert.Equal(t, dummyHTTPRetry, httpRetry)
 		return dummyResponseObject, dummyResponseError
 	}
-	logErrorResponseFuncExpected = 1
-	logErrorResponseFunc = func(session sessionModel.Session, responseError error, startTime time.Time) {
-		logErrorResponseFuncCalled++
-		assert.Equal(t, dummySessionObject, session)
+	recordResultFuncExpected = 1
+	recordResultFunc = func(dependency string, circuitBreaker *model.CircuitBreaker, responseObject *http.Response, responseError error) {
+		recordResultFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyCircuitBreaker, circuitBreaker)
+		assert.Nil(t, responseObject)
 		assert.Equal(t, dummyResponseError, responseError)
+	}
+	recordNetworkMetricsFuncExpected = 1
+	recordNetworkMetricsFunc = func(requestObject *http.Request, responseObject *http.Response, startTime time.Time) {
+		recordNetworkMetricsFuncCalled++
+		assert.Equal(t, dummyRequestObject, requestObject)
+		assert.Equal(t, dummyResponseObject, responseObject)
 		assert.Equal(t, dummyStartTime, startTime)
 	}
+	endNetworkSpanFuncExpected = 1
+	endNetworkSpanFunc = func(span *tracingModel.Span, responseObject *http.Response, responseError error) {
+		endNetworkSpanFuncCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.Equal(t, dummyResponseObject, responseObject)
+		assert.Equal(t, dummyResponseError, responseError)
+	}
 
 	// SUT + act
 	var result, err = doRequestProcessing(
@@ -1192,6 +3677,8 @@ func TestDoRequestProcessing_ResponseError(t *testing.T) {
 	)
 
 	// assert
+	assert.Equal(t, dummyContext, dummyNetworkRequest.context)
+	assert.Equal(t, 1, dummyCancelCalled)
 	assert.Equal(t, dummyResponseObject, result)
 	assert.Equal(t, dummyResponseError, err)
 
@@ -1202,33 +3689,72 @@ func TestDoRequestProcessing_ResponseError(t *testing.T) {
 func TestDoRequestProcessing_ResponseSuccess(t *testing.T) {
 	// arrange
 	var dummySessionObject = &dummySession{t}
-	var dummyConnRetry = rand.Int()
-	var dummyHTTPRetry = map[int]int{
-		rand.Int(): rand.Int(),
-		rand.Int(): rand.Int(),
+	var dummyRetryPolicy = &model.RetryPolicy{
+		ConnectivityRetryCount: rand.Int(),
 	}
 	var dummySendClientCert = rand.Intn(100) < 50
 	var dummyNetworkRequest = &networkRequest{
 		session:        dummySessionObject,
-		connRetry:      dummyConnRetry,
-		httpRetry:      dummyHTTPRetry,
+		retryPolicy:    dummyRetryPolicy,
 		sendClientCert: dummySendClientCert,
 	}
 	var dummyHTTPClient = &http.Client{}
 	var dummyRequestObject = &http.Request{}
 	var dummyResponseObject = &http.Response{}
 	var dummyStartTime = time.Now()
+	var dummySpan = &tracingModel.Span{Name: "some span"}
+	var dummyDependency = "some dependency"
+	var dummyCircuitBreaker = &model.CircuitBreaker{}
+	var dummyContext = context.WithValue(context.Background(), "foo", "bar")
+	var dummyCancelCalled int
 
 	// mock
 	createMock(t)
 
 	// expect
+	startNetworkSpanFuncExpected = 1
+	startNetworkSpanFunc = func(networkRequest *networkRequest) *tracingModel.Span {
+		startNetworkSpanFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummySpan
+	}
+	getRequestContextFuncExpected = 1
+	getRequestContextFunc = func(networkRequest *networkRequest) (context.Context, context.CancelFunc) {
+		getRequestContextFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummyContext, func() { dummyCancelCalled++ }
+	}
+	closePayloadFuncExpected = 1
+	closePayloadFunc = func(networkRequest *networkRequest) {
+		closePayloadFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+	}
 	createHTTPRequestFuncExpected = 1
-	createHTTPRequestFunc = func(networkRequest *networkRequest) (*http.Request, error) {
+	createHTTPRequestFunc = func(networkRequest *networkRequest, attempt int) (*http.Request, error) {
 		createHTTPRequestFuncCalled++
+		assert.Equal(t, 1, attempt)
 		assert.Equal(t, dummyNetworkRequest, networkRequest)
 		return dummyRequestObject, nil
 	}
+	getDependencyFuncExpected = 1
+	getDependencyFunc = func(networkRequest *networkRequest) string {
+		getDependencyFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
+		return dummyDependency
+	}
+	getCircuitBreakerFuncExpected = 1
+	getCircuitBreakerFunc = func(dependency string) *model.CircuitBreaker {
+		getCircuitBreakerFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		return dummyCircuitBreaker
+	}
+	allowRequestFuncExpected = 1
+	allowRequestFunc = func(dependency string, circuitBreaker *model.CircuitBreaker) error {
+		allowRequestFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyCircuitBreaker, circuitBreaker)
+		return nil
+	}
 	getClientForRequestFuncExpected = 1
 	getClientForRequestFunc = func(sendClientCert bool) *http.Client {
 		getClientForRequestFuncCalled++
@@ -1241,21 +3767,35 @@ func TestDoRequestProcessing_ResponseSuccess(t *testing.T) {
 		return dummyStartTime
 	}
 	clientDoWithRetryFuncExpected = 1
-	clientDoWithRetryFunc = func(client *http.Client, request *http.Request, connRetry int, httpRetry map[int]int) (*http.Response, error) {
+	clientDoWithRetryFunc = func(networkRequest *networkRequest, client *http.Client, request *http.Request) (*http.Response, error) {
 		clientDoWithRetryFuncCalled++
+		assert.Equal(t, dummyNetworkRequest, networkRequest)
 		assert.Equal(t, dummyHTTPClient, client)
 		assert.Equal(t, dummyRequestObject, request)
-		assert.Equal(t, dummyConnRetry, connRetry)
-		assert.Equal(t, dummyHTTPRetry, httpRetry)
 		return dummyResponseObject, nil
 	}
-	logHTTPResponseFuncExpected = 1
-	logHTTPResponseFunc = func(session sessionModel.Session, response *http.Response, startTime time.Time) {
-		logHTTPResponseFuncCalled++
-		assert.Equal(t, dummySessionObject, session)
-		assert.Equal(t, dummyResponseObject, response)
+	recordResultFuncExpected = 1
+	recordResultFunc = func(dependency string, circuitBreaker *model.CircuitBreaker, responseObject *http.Response, responseError error) {
+		recordResultFuncCalled++
+		assert.Equal(t, dummyDependency, dependency)
+		assert.Equal(t, dummyCircuitBreaker, circuitBreaker)
+		assert.Equal(t, dummyResponseObject, responseObject)
+		assert.NoError(t, responseError)
+	}
+	recordNetworkMetricsFuncExpected = 1
+	recordNetworkMetricsFunc = func(requestObject *http.Request, responseObject *http.Response, startTime time.Time) {
+		recordNetworkMetricsFuncCalled++
+		assert.Equal(t, dummyRequestObject, requestObject)
+		assert.Equal(t, dummyResponseObject, responseObject)
 		assert.Equal(t, dummyStartTime, startTime)
 	}
+	endNetworkSpanFuncExpected = 1
+	endNetworkSpanFunc = func(span *tracingModel.Span, responseObject *http.Response, responseError error) {
+		endNetworkSpanFuncCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.Equal(t, dummyResponseObject, responseObject)
+		assert.NoError(t, responseError)
+	}
 
 	// SUT + act
 	var result, err = doRequestProcessing(
@@ -1263,6 +3803,8 @@ func TestDoRequestProcessing_ResponseSuccess(t *testing.T) {
 	)
 
 	// assert
+	assert.Equal(t, dummyContext, dummyNetworkRequest.context)
+	assert.Equal(t, 1, dummyCancelCalled)
 	assert.Equal(t, dummyResponseObject, result)
 	assert.NoError(t, err)
 
@@ -1270,6 +3812,58 @@ func TestDoRequestProcessing_ResponseSuccess(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestDoRequestProcessing_FilePayloadRetried(t *testing.T) {
+	// arrange
+	var dummyPayload = "some payload"
+	var dummyFile, fileError = ioutil.TempFile("", "payload")
+	assert.NoError(t, fileError)
+	defer os.Remove(dummyFile.Name())
+	dummyFile.WriteString(dummyPayload)
+	dummyFile.Seek(0, io.SeekStart)
+	var receivedPayloads []string
+	var dummyServer = httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		var body, _ = ioutil.ReadAll(httpRequest.Body)
+		receivedPayloads = append(receivedPayloads, string(body))
+		if len(receivedPayloads) == 1 {
+			responseWriter.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		responseWriter.WriteHeader(http.StatusOK)
+	}))
+	defer dummyServer.Close()
+	httpClientNoCert = dummyServer.Client()
+	defer func() { httpClientNoCert = nil }()
+
+	// SUT
+	var sut = NewNetworkRequest(
+		nil,
+		http.MethodPut,
+		dummyServer.URL,
+		"",
+		nil,
+		false,
+	).SetRetryPolicy(
+		&model.RetryPolicy{
+			HTTPStatusRetryCount: map[int]int{
+				http.StatusServiceUnavailable: 1,
+			},
+			InitialDelay: time.Millisecond,
+		},
+	).SetPayload(
+		dummyFile,
+	)
+
+	// act
+	var result, err = sut.ProcessRaw()
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.Equal(t, []string{dummyPayload, dummyPayload}, receivedPayloads)
+	var _, readError = dummyFile.Read(make([]byte, 1))
+	assert.True(t, errors.Is(readError, os.ErrClosed))
+}
+
 func TestNetworkRequestProcessRaw(t *testing.T) {
 	// arrange
 	var dummyResponseObject = &http.Response{}
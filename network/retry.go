@@ -0,0 +1,143 @@
+package network
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+// DefaultRetryDelay is the default delay before the first retry of network requests
+const DefaultRetryDelay = 3 * time.Second
+
+const (
+	retryAfterHeader = "Retry-After"
+	maxRetryJitter   = 1.0
+	maxRetryDelay    = time.Duration(math.MaxInt64)
+)
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet,
+		http.MethodHead,
+		http.MethodOptions,
+		http.MethodTrace,
+		http.MethodPut,
+		http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isRetryAllowed(method string, retryPolicy *model.RetryPolicy) bool {
+	if retryPolicy == nil {
+		return false
+	}
+	if retryPolicy.RetryNonIdempotent {
+		return true
+	}
+	return isIdempotentMethodFunc(
+		method,
+	)
+}
+
+func getInitialRetryDelay(retryPolicy *model.RetryPolicy) time.Duration {
+	if retryPolicy.InitialDelay > 0 {
+		return retryPolicy.InitialDelay
+	}
+	if customization.DefaultNetworkRetryDelay != nil {
+		return customization.DefaultNetworkRetryDelay()
+	}
+	return DefaultRetryDelay
+}
+
+// getRetryAfter parses the Retry-After header of the response, in either delay seconds or HTTP date, and returns 0 if absent or invalid
+func getRetryAfter(responseObject *http.Response) time.Duration {
+	if responseObject == nil {
+		return 0
+	}
+	var retryAfter = responseObject.Header.Get(retryAfterHeader)
+	if retryAfter == "" {
+		return 0
+	}
+	var seconds, secondsError = strconvAtoi(retryAfter)
+	if secondsError == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	var retryTime, timeError = httpParseTime(retryAfter)
+	if timeError != nil {
+		return 0
+	}
+	var delay = retryTime.Sub(
+		timeutilGetTimeNowUTC(),
+	)
+	if delay <= 0 {
+		return 0
+	}
+	return delay
+}
+
+// getRetryDelay calculates the delay before the given retry attempt (starting from 1) as exponential backoff capped by MaxDelay with random jitter deducted, or the Retry-After of the response if respected and longer
+func getRetryDelay(retryPolicy *model.RetryPolicy, attempt int, responseObject *http.Response) time.Duration {
+	var delay = float64(
+		getInitialRetryDelayFunc(
+			retryPolicy,
+		),
+	)
+	if retryPolicy.Multiplier > 1 {
+		delay *= mathPow(
+			retryPolicy.Multiplier,
+			float64(attempt-1),
+		)
+	}
+	var maxDelay = maxRetryDelay
+	if retryPolicy.MaxDelay > 0 {
+		maxDelay = retryPolicy.MaxDelay
+	}
+	var backoff = maxDelay
+	if delay < float64(maxDelay) {
+		backoff = time.Duration(delay)
+	}
+	if retryPolicy.Jitter > 0 {
+		backoff -= time.Duration(
+			float64(backoff) * math.Min(retryPolicy.Jitter, maxRetryJitter) * randFloat64(),
+		)
+	}
+	if retryPolicy.RespectRetryAfter {
+		var retryAfter = getRetryAfterFunc(
+			responseObject,
+		)
+		if retryAfter > backoff {
+			backoff = retryAfter
+		}
+	}
+	return backoff
+}
+
+func logRetryAttempt(
+	session sessionModel.Session,
+	attempt int,
+	responseObject *http.Response,
+	responseError error,
+	delay time.Duration,
+) {
+	var outcome interface{} = responseError
+	if responseError == nil &&
+		responseObject != nil {
+		outcome = responseObject.StatusCode
+	}
+	loggerNetworkResponse(
+		session,
+		"Retry",
+		strconvItoa(attempt),
+		"Attempt failed with [%v]; retrying after [%s]",
+		outcome,
+		delay,
+	)
+}
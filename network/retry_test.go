@@ -0,0 +1,761 @@
+package network
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+func TestIsIdempotentMethod(t *testing.T) {
+	// arrange
+	var testCases = map[string]bool{
+		http.MethodGet:     true,
+		http.MethodHead:    true,
+		http.MethodOptions: true,
+		http.MethodTrace:   true,
+		http.MethodPut:     true,
+		http.MethodDelete:  true,
+		http.MethodPost:    false,
+		http.MethodPatch:   false,
+		http.MethodConnect: false,
+		"some method":      false,
+	}
+
+	for method, expected := range testCases {
+		// mock
+		createMock(t)
+
+		// SUT + act
+		var result = isIdempotentMethod(
+			method,
+		)
+
+		// assert
+		assert.Equal(t, expected, result, method)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestIsRetryAllowed_NilRetryPolicy(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isRetryAllowed(
+		http.MethodGet,
+		nil,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsRetryAllowed_RetryNonIdempotent(t *testing.T) {
+	// arrange
+	var dummyRetryPolicy = &model.RetryPolicy{
+		RetryNonIdempotent: true,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isRetryAllowed(
+		http.MethodPost,
+		dummyRetryPolicy,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsRetryAllowed_IdempotentOnly(t *testing.T) {
+	// arrange
+	var dummyMethod = "some method"
+	var dummyRetryPolicy = &model.RetryPolicy{}
+	var dummyResult = rand.Intn(100) < 50
+
+	// mock
+	createMock(t)
+
+	// expect
+	isIdempotentMethodFuncExpected = 1
+	isIdempotentMethodFunc = func(method string) bool {
+		isIdempotentMethodFuncCalled++
+		assert.Equal(t, dummyMethod, method)
+		return dummyResult
+	}
+
+	// SUT + act
+	var result = isRetryAllowed(
+		dummyMethod,
+		dummyRetryPolicy,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetInitialRetryDelay_FromRetryPolicy(t *testing.T) {
+	// arrange
+	var dummyDelay = time.Duration(rand.Intn(1000) + 1)
+	var dummyRetryPolicy = &model.RetryPolicy{
+		InitialDelay: dummyDelay,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getInitialRetryDelay(
+		dummyRetryPolicy,
+	)
+
+	// assert
+	assert.Equal(t, dummyDelay, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetInitialRetryDelay_WithCustomization(t *testing.T) {
+	// arrange
+	var dummyDelay = time.Duration(rand.Int())
+	var dummyRetryPolicy = &model.RetryPolicy{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationDefaultNetworkRetryDelayExpected = 1
+	customization.DefaultNetworkRetryDelay = func() time.Duration {
+		customizationDefaultNetworkRetryDelayCalled++
+		return dummyDelay
+	}
+
+	// SUT + act
+	var result = getInitialRetryDelay(
+		dummyRetryPolicy,
+	)
+
+	// assert
+	assert.Equal(t, dummyDelay, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetInitialRetryDelay_Default(t *testing.T) {
+	// arrange
+	var dummyRetryPolicy = &model.RetryPolicy{}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getInitialRetryDelay(
+		dummyRetryPolicy,
+	)
+
+	// assert
+	assert.Equal(t, DefaultRetryDelay, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryAfter_NilResponse(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getRetryAfter(
+		nil,
+	)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryAfter_NoHeader(t *testing.T) {
+	// arrange
+	var dummyResponseObject = &http.Response{}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getRetryAfter(
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryAfter_Seconds(t *testing.T) {
+	// arrange
+	var dummyResponseObject = &http.Response{
+		Header: http.Header{
+			"Retry-After": []string{"120"},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	strconvAtoiExpected = 1
+	strconvAtoi = func(s string) (int, error) {
+		strconvAtoiCalled++
+		assert.Equal(t, "120", s)
+		return 120, nil
+	}
+
+	// SUT + act
+	var result = getRetryAfter(
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Equal(t, 2*time.Minute, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryAfter_NonPositiveSeconds(t *testing.T) {
+	// arrange
+	var dummyResponseObject = &http.Response{
+		Header: http.Header{
+			"Retry-After": []string{"-1"},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	strconvAtoiExpected = 1
+	strconvAtoi = func(s string) (int, error) {
+		strconvAtoiCalled++
+		return -1, nil
+	}
+
+	// SUT + act
+	var result = getRetryAfter(
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryAfter_InvalidDate(t *testing.T) {
+	// arrange
+	var dummyRetryAfter = "some retry after"
+	var dummyResponseObject = &http.Response{
+		Header: http.Header{
+			"Retry-After": []string{dummyRetryAfter},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	strconvAtoiExpected = 1
+	strconvAtoi = func(s string) (int, error) {
+		strconvAtoiCalled++
+		return 0, errors.New("some atoi error")
+	}
+	httpParseTimeExpected = 1
+	httpParseTime = func(text string) (time.Time, error) {
+		httpParseTimeCalled++
+		assert.Equal(t, dummyRetryAfter, text)
+		return time.Time{}, errors.New("some parse error")
+	}
+
+	// SUT + act
+	var result = getRetryAfter(
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryAfter_PastDate(t *testing.T) {
+	// arrange
+	var dummyNow = time.Now()
+	var dummyResponseObject = &http.Response{
+		Header: http.Header{
+			"Retry-After": []string{"some retry after"},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	strconvAtoiExpected = 1
+	strconvAtoi = func(s string) (int, error) {
+		strconvAtoiCalled++
+		return 0, errors.New("some atoi error")
+	}
+	httpParseTimeExpected = 1
+	httpParseTime = func(text string) (time.Time, error) {
+		httpParseTimeCalled++
+		return dummyNow.Add(-time.Second), nil
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+
+	// SUT + act
+	var result = getRetryAfter(
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryAfter_FutureDate(t *testing.T) {
+	// arrange
+	var dummyNow = time.Now()
+	var dummyResponseObject = &http.Response{
+		Header: http.Header{
+			"Retry-After": []string{"some retry after"},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	strconvAtoiExpected = 1
+	strconvAtoi = func(s string) (int, error) {
+		strconvAtoiCalled++
+		return 0, errors.New("some atoi error")
+	}
+	httpParseTimeExpected = 1
+	httpParseTime = func(text string) (time.Time, error) {
+		httpParseTimeCalled++
+		return dummyNow.Add(time.Minute), nil
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+
+	// SUT + act
+	var result = getRetryAfter(
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Equal(t, time.Minute, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryDelay_Constant(t *testing.T) {
+	// arrange
+	var dummyRetryPolicy = &model.RetryPolicy{}
+	var dummyDelay = time.Duration(rand.Intn(1000))
+
+	// mock
+	createMock(t)
+
+	// expect
+	getInitialRetryDelayFuncExpected = 1
+	getInitialRetryDelayFunc = func(retryPolicy *model.RetryPolicy) time.Duration {
+		getInitialRetryDelayFuncCalled++
+		assert.Equal(t, dummyRetryPolicy, retryPolicy)
+		return dummyDelay
+	}
+
+	// SUT + act
+	var result = getRetryDelay(
+		dummyRetryPolicy,
+		rand.Intn(10)+1,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, dummyDelay, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryDelay_Exponential(t *testing.T) {
+	// arrange
+	var dummyRetryPolicy = &model.RetryPolicy{
+		Multiplier: 2,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getInitialRetryDelayFuncExpected = 1
+	getInitialRetryDelayFunc = func(retryPolicy *model.RetryPolicy) time.Duration {
+		getInitialRetryDelayFuncCalled++
+		return time.Second
+	}
+	mathPowExpected = 1
+	mathPow = func(x, y float64) float64 {
+		mathPowCalled++
+		assert.Equal(t, 2.0, x)
+		assert.Equal(t, 3.0, y)
+		return 8
+	}
+
+	// SUT + act
+	var result = getRetryDelay(
+		dummyRetryPolicy,
+		4,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, 8*time.Second, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryDelay_MaxDelay(t *testing.T) {
+	// arrange
+	var dummyRetryPolicy = &model.RetryPolicy{
+		Multiplier: 2,
+		MaxDelay:   5 * time.Second,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getInitialRetryDelayFuncExpected = 1
+	getInitialRetryDelayFunc = func(retryPolicy *model.RetryPolicy) time.Duration {
+		getInitialRetryDelayFuncCalled++
+		return time.Second
+	}
+	mathPowExpected = 1
+	mathPow = func(x, y float64) float64 {
+		mathPowCalled++
+		return 8
+	}
+
+	// SUT + act
+	var result = getRetryDelay(
+		dummyRetryPolicy,
+		4,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, 5*time.Second, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryDelay_Overflow(t *testing.T) {
+	// arrange
+	var dummyRetryPolicy = &model.RetryPolicy{
+		Multiplier: 10,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getInitialRetryDelayFuncExpected = 1
+	getInitialRetryDelayFunc = func(retryPolicy *model.RetryPolicy) time.Duration {
+		getInitialRetryDelayFuncCalled++
+		return time.Second
+	}
+	mathPowExpected = 1
+	mathPow = func(x, y float64) float64 {
+		mathPowCalled++
+		return 1e100
+	}
+
+	// SUT + act
+	var result = getRetryDelay(
+		dummyRetryPolicy,
+		100,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, maxRetryDelay, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryDelay_Jitter(t *testing.T) {
+	// arrange
+	var dummyRetryPolicy = &model.RetryPolicy{
+		Jitter: 0.5,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getInitialRetryDelayFuncExpected = 1
+	getInitialRetryDelayFunc = func(retryPolicy *model.RetryPolicy) time.Duration {
+		getInitialRetryDelayFuncCalled++
+		return 4 * time.Second
+	}
+	randFloat64Expected = 1
+	randFloat64 = func() float64 {
+		randFloat64Called++
+		return 0.5
+	}
+
+	// SUT + act
+	var result = getRetryDelay(
+		dummyRetryPolicy,
+		1,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, 3*time.Second, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryDelay_FullJitter(t *testing.T) {
+	// arrange
+	var dummyRetryPolicy = &model.RetryPolicy{
+		Jitter: 2,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getInitialRetryDelayFuncExpected = 1
+	getInitialRetryDelayFunc = func(retryPolicy *model.RetryPolicy) time.Duration {
+		getInitialRetryDelayFuncCalled++
+		return 4 * time.Second
+	}
+	randFloat64Expected = 1
+	randFloat64 = func() float64 {
+		randFloat64Called++
+		return 0.25
+	}
+
+	// SUT + act
+	var result = getRetryDelay(
+		dummyRetryPolicy,
+		1,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, 3*time.Second, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryDelay_RetryAfterLonger(t *testing.T) {
+	// arrange
+	var dummyRetryPolicy = &model.RetryPolicy{
+		RespectRetryAfter: true,
+	}
+	var dummyResponseObject = &http.Response{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getInitialRetryDelayFuncExpected = 1
+	getInitialRetryDelayFunc = func(retryPolicy *model.RetryPolicy) time.Duration {
+		getInitialRetryDelayFuncCalled++
+		return time.Second
+	}
+	getRetryAfterFuncExpected = 1
+	getRetryAfterFunc = func(responseObject *http.Response) time.Duration {
+		getRetryAfterFuncCalled++
+		assert.Equal(t, dummyResponseObject, responseObject)
+		return time.Minute
+	}
+
+	// SUT + act
+	var result = getRetryDelay(
+		dummyRetryPolicy,
+		1,
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Equal(t, time.Minute, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryDelay_RetryAfterShorter(t *testing.T) {
+	// arrange
+	var dummyRetryPolicy = &model.RetryPolicy{
+		RespectRetryAfter: true,
+	}
+	var dummyResponseObject = &http.Response{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getInitialRetryDelayFuncExpected = 1
+	getInitialRetryDelayFunc = func(retryPolicy *model.RetryPolicy) time.Duration {
+		getInitialRetryDelayFuncCalled++
+		return time.Minute
+	}
+	getRetryAfterFuncExpected = 1
+	getRetryAfterFunc = func(responseObject *http.Response) time.Duration {
+		getRetryAfterFuncCalled++
+		return time.Second
+	}
+
+	// SUT + act
+	var result = getRetryDelay(
+		dummyRetryPolicy,
+		1,
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Equal(t, time.Minute, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLogRetryAttempt_ResponseError(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t}
+	var dummyAttempt = rand.Int()
+	var dummyResponseError = errors.New("some error")
+	var dummyDelay = time.Duration(rand.Int())
+	var dummyAttemptString = "some attempt"
+
+	// mock
+	createMock(t)
+
+	// expect
+	strconvItoaExpected = 1
+	strconvItoa = func(i int) string {
+		strconvItoaCalled++
+		assert.Equal(t, dummyAttempt, i)
+		return dummyAttemptString
+	}
+	loggerNetworkResponseExpected = 1
+	loggerNetworkResponse = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerNetworkResponseCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, "Retry", category)
+		assert.Equal(t, dummyAttemptString, subcategory)
+		assert.Equal(t, "Attempt failed with [%v]; retrying after [%s]", messageFormat)
+		assert.Equal(t, 2, len(parameters))
+		assert.Equal(t, dummyResponseError, parameters[0])
+		assert.Equal(t, dummyDelay, parameters[1])
+	}
+
+	// SUT + act
+	logRetryAttempt(
+		dummySessionObject,
+		dummyAttempt,
+		nil,
+		dummyResponseError,
+		dummyDelay,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLogRetryAttempt_ResponseStatus(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t}
+	var dummyAttempt = rand.Int()
+	var dummyResponseObject = &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+	}
+	var dummyDelay = time.Duration(rand.Int())
+
+	// mock
+	createMock(t)
+
+	// expect
+	strconvItoaExpected = 1
+	strconvItoa = func(i int) string {
+		strconvItoaCalled++
+		return "some attempt"
+	}
+	loggerNetworkResponseExpected = 1
+	loggerNetworkResponse = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerNetworkResponseCalled++
+		assert.Equal(t, 2, len(parameters))
+		assert.Equal(t, http.StatusServiceUnavailable, parameters[0])
+		assert.Equal(t, dummyDelay, parameters[1])
+	}
+
+	// SUT + act
+	logRetryAttempt(
+		dummySessionObject,
+		dummyAttempt,
+		dummyResponseObject,
+		nil,
+		dummyDelay,
+	)
+
+	// verify
+	verifyAll(t)
+}
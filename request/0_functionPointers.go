@@ -2,12 +2,20 @@ package request
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net/http/httputil"
+	"net/url"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
 )
 
 // func pointers for injection / testing: logCategory.go
@@ -20,4 +28,38 @@ var (
 	bytesNewBuffer         = bytes.NewBuffer
 	httputilDumpRequest    = httputil.DumpRequest
 	fmtSprintf             = fmt.Sprintf
+	gzipNewReader          = gzip.NewReader
+	bytesNewReader         = bytes.NewReader
+	ioLimitReader          = io.LimitReader
+	isGzipEncodedFunc      = isGzipEncoded
+	decompressGzipFunc     = decompressGzip
+)
+
+// func pointers for injection / testing: decoder.go
+var (
+	fmtErrorf                            = fmt.Errorf
+	stringsToLower                       = strings.ToLower
+	stringsTrimSpace                     = strings.TrimSpace
+	stringsHasSuffix                     = strings.HasSuffix
+	stringsNewReader                     = strings.NewReader
+	stringsSplit                         = strings.Split
+	xmlUnmarshal                         = xml.Unmarshal
+	multipartNewReader                   = multipart.NewReader
+	mimeParseMediaType                   = mime.ParseMediaType
+	urlParseQuery                        = url.ParseQuery
+	jsonutilTryUnmarshal                 = jsonutil.TryUnmarshal
+	apperrorGetBadRequestError           = apperror.GetBadRequestError
+	apperrorGetUnsupportedMediaTypeError = apperror.GetUnsupportedMediaTypeError
+	parseMultipartValuesFunc             = parseMultipartValues
+	decodeFormValuesFunc                 = decodeFormValues
+	getDecodersFunc                      = getDecoders
+	getMediaTypeFunc                     = getMediaType
+	getDecoderFunc                       = getDecoder
+)
+
+// func pointers for injection / testing: file.go
+var (
+	apperrorGetRequestEntityTooLargeError = apperror.GetRequestEntityTooLargeError
+	apperrorGetGeneralFailureError        = apperror.GetGeneralFailureError
+	getFileContentFunc                    = getFileContent
 )
@@ -2,11 +2,17 @@ package request
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/http/httputil"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -14,25 +20,82 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
 	apperrorEnum "github.com/zhongjie-cai/WebServiceTemplate/apperror/enum"
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/request/model"
 )
 
 var (
-	uuidParseExpected              int
-	uuidParseCalled                int
-	uuidNewExpected                int
-	uuidNewCalled                  int
-	apperrorGetCustomErrorExpected int
-	apperrorGetCustomErrorCalled   int
-	ioutilReadAllExpected          int
-	ioutilReadAllCalled            int
-	ioutilNopCloserExpected        int
-	ioutilNopCloserCalled          int
-	bytesNewBufferExpected         int
-	bytesNewBufferCalled           int
-	httputilDumpRequestExpected    int
-	httputilDumpRequestCalled      int
-	fmtSprintfExpected             int
-	fmtSprintfCalled               int
+	uuidParseExpected                             int
+	uuidParseCalled                               int
+	uuidNewExpected                               int
+	uuidNewCalled                                 int
+	apperrorGetCustomErrorExpected                int
+	apperrorGetCustomErrorCalled                  int
+	ioutilReadAllExpected                         int
+	ioutilReadAllCalled                           int
+	ioutilNopCloserExpected                       int
+	ioutilNopCloserCalled                         int
+	bytesNewBufferExpected                        int
+	bytesNewBufferCalled                          int
+	httputilDumpRequestExpected                   int
+	httputilDumpRequestCalled                     int
+	fmtSprintfExpected                            int
+	fmtSprintfCalled                              int
+	gzipNewReaderExpected                         int
+	gzipNewReaderCalled                           int
+	bytesNewReaderExpected                        int
+	bytesNewReaderCalled                          int
+	ioLimitReaderExpected                         int
+	ioLimitReaderCalled                           int
+	isGzipEncodedFuncExpected                     int
+	isGzipEncodedFuncCalled                       int
+	decompressGzipFuncExpected                    int
+	decompressGzipFuncCalled                      int
+	fmtErrorfExpected                             int
+	fmtErrorfCalled                               int
+	stringsToLowerExpected                        int
+	stringsToLowerCalled                          int
+	stringsTrimSpaceExpected                      int
+	stringsTrimSpaceCalled                        int
+	stringsHasSuffixExpected                      int
+	stringsHasSuffixCalled                        int
+	stringsNewReaderExpected                      int
+	stringsNewReaderCalled                        int
+	stringsSplitExpected                          int
+	stringsSplitCalled                            int
+	xmlUnmarshalExpected                          int
+	xmlUnmarshalCalled                            int
+	multipartNewReaderExpected                    int
+	multipartNewReaderCalled                      int
+	mimeParseMediaTypeExpected                    int
+	mimeParseMediaTypeCalled                      int
+	urlParseQueryExpected                         int
+	urlParseQueryCalled                           int
+	jsonutilTryUnmarshalExpected                  int
+	jsonutilTryUnmarshalCalled                    int
+	apperrorGetBadRequestErrorExpected            int
+	apperrorGetBadRequestErrorCalled              int
+	apperrorGetUnsupportedMediaTypeErrorExpected  int
+	apperrorGetUnsupportedMediaTypeErrorCalled    int
+	parseMultipartValuesFuncExpected              int
+	parseMultipartValuesFuncCalled                int
+	decodeFormValuesFuncExpected                  int
+	decodeFormValuesFuncCalled                    int
+	getDecodersFuncExpected                       int
+	getDecodersFuncCalled                         int
+	getMediaTypeFuncExpected                      int
+	getMediaTypeFuncCalled                        int
+	getDecoderFuncExpected                        int
+	getDecoderFuncCalled                          int
+	apperrorGetRequestEntityTooLargeErrorExpected int
+	apperrorGetRequestEntityTooLargeErrorCalled   int
+	apperrorGetGeneralFailureErrorExpected        int
+	apperrorGetGeneralFailureErrorCalled          int
+	getFileContentFuncExpected                    int
+	getFileContentFuncCalled                      int
+	customizationRequestDecodersExpected          int
+	customizationRequestDecodersCalled            int
 )
 
 func createMock(t *testing.T) {
@@ -84,6 +147,165 @@ func createMock(t *testing.T) {
 		fmtSprintfCalled++
 		return ""
 	}
+	gzipNewReaderExpected = 0
+	gzipNewReaderCalled = 0
+	gzipNewReader = func(r io.Reader) (*gzip.Reader, error) {
+		gzipNewReaderCalled++
+		return nil, nil
+	}
+	bytesNewReaderExpected = 0
+	bytesNewReaderCalled = 0
+	bytesNewReader = func(b []byte) *bytes.Reader {
+		bytesNewReaderCalled++
+		return nil
+	}
+	ioLimitReaderExpected = 0
+	ioLimitReaderCalled = 0
+	ioLimitReader = func(r io.Reader, n int64) io.Reader {
+		ioLimitReaderCalled++
+		return nil
+	}
+	isGzipEncodedFuncExpected = 0
+	isGzipEncodedFuncCalled = 0
+	isGzipEncodedFunc = func(header http.Header) bool {
+		isGzipEncodedFuncCalled++
+		return false
+	}
+	decompressGzipFuncExpected = 0
+	decompressGzipFuncCalled = 0
+	decompressGzipFunc = func(content []byte, maxBytes int64) ([]byte, error) {
+		decompressGzipFuncCalled++
+		return nil, nil
+	}
+	fmtErrorfExpected = 0
+	fmtErrorfCalled = 0
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		return nil
+	}
+	stringsToLowerExpected = 0
+	stringsToLowerCalled = 0
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		return ""
+	}
+	stringsTrimSpaceExpected = 0
+	stringsTrimSpaceCalled = 0
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return ""
+	}
+	stringsHasSuffixExpected = 0
+	stringsHasSuffixCalled = 0
+	stringsHasSuffix = func(s string, suffix string) bool {
+		stringsHasSuffixCalled++
+		return false
+	}
+	stringsNewReaderExpected = 0
+	stringsNewReaderCalled = 0
+	stringsNewReader = func(s string) *strings.Reader {
+		stringsNewReaderCalled++
+		return nil
+	}
+	stringsSplitExpected = 0
+	stringsSplitCalled = 0
+	stringsSplit = func(s string, sep string) []string {
+		stringsSplitCalled++
+		return nil
+	}
+	xmlUnmarshalExpected = 0
+	xmlUnmarshalCalled = 0
+	xmlUnmarshal = func(data []byte, v interface{}) error {
+		xmlUnmarshalCalled++
+		return nil
+	}
+	multipartNewReaderExpected = 0
+	multipartNewReaderCalled = 0
+	multipartNewReader = func(r io.Reader, boundary string) *multipart.Reader {
+		multipartNewReaderCalled++
+		return nil
+	}
+	mimeParseMediaTypeExpected = 0
+	mimeParseMediaTypeCalled = 0
+	mimeParseMediaType = func(v string) (string, map[string]string, error) {
+		mimeParseMediaTypeCalled++
+		return "", nil, nil
+	}
+	urlParseQueryExpected = 0
+	urlParseQueryCalled = 0
+	urlParseQuery = func(query string) (url.Values, error) {
+		urlParseQueryCalled++
+		return nil, nil
+	}
+	jsonutilTryUnmarshalExpected = 0
+	jsonutilTryUnmarshalCalled = 0
+	jsonutilTryUnmarshal = func(value string, dataTemplate interface{}) error {
+		jsonutilTryUnmarshalCalled++
+		return nil
+	}
+	apperrorGetBadRequestErrorExpected = 0
+	apperrorGetBadRequestErrorCalled = 0
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		return nil
+	}
+	apperrorGetUnsupportedMediaTypeErrorExpected = 0
+	apperrorGetUnsupportedMediaTypeErrorCalled = 0
+	apperrorGetUnsupportedMediaTypeError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetUnsupportedMediaTypeErrorCalled++
+		return nil
+	}
+	parseMultipartValuesFuncExpected = 0
+	parseMultipartValuesFuncCalled = 0
+	parseMultipartValuesFunc = func(body string, boundary string) (url.Values, error) {
+		parseMultipartValuesFuncCalled++
+		return nil, nil
+	}
+	decodeFormValuesFuncExpected = 0
+	decodeFormValuesFuncCalled = 0
+	decodeFormValuesFunc = func(values url.Values, dataTemplate interface{}) error {
+		decodeFormValuesFuncCalled++
+		return nil
+	}
+	getDecodersFuncExpected = 0
+	getDecodersFuncCalled = 0
+	getDecodersFunc = func() map[string]model.Decoder {
+		getDecodersFuncCalled++
+		return nil
+	}
+	getMediaTypeFuncExpected = 0
+	getMediaTypeFuncCalled = 0
+	getMediaTypeFunc = func(httpRequest *http.Request) (string, map[string]string) {
+		getMediaTypeFuncCalled++
+		return "", nil
+	}
+	getDecoderFuncExpected = 0
+	getDecoderFuncCalled = 0
+	getDecoderFunc = func(decoders map[string]model.Decoder, mediaType string) model.Decoder {
+		getDecoderFuncCalled++
+		return nil
+	}
+	apperrorGetRequestEntityTooLargeErrorExpected = 0
+	apperrorGetRequestEntityTooLargeErrorCalled = 0
+	apperrorGetRequestEntityTooLargeError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetRequestEntityTooLargeErrorCalled++
+		return nil
+	}
+	apperrorGetGeneralFailureErrorExpected = 0
+	apperrorGetGeneralFailureErrorCalled = 0
+	apperrorGetGeneralFailureError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetGeneralFailureErrorCalled++
+		return nil
+	}
+	getFileContentFuncExpected = 0
+	getFileContentFuncCalled = 0
+	getFileContentFunc = func(part io.Reader, maxBytes int64) (io.Reader, *sizeLimitedReader) {
+		getFileContentFuncCalled++
+		return nil, nil
+	}
+	customizationRequestDecodersExpected = 0
+	customizationRequestDecodersCalled = 0
+	customization.RequestDecoders = nil
 }
 
 func verifyAll(t *testing.T) {
@@ -103,4 +325,95 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, httputilDumpRequestExpected, httputilDumpRequestCalled, "Unexpected number of calls to httputilDumpRequest")
 	fmtSprintf = fmt.Sprintf
 	assert.Equal(t, fmtSprintfExpected, fmtSprintfCalled, "Unexpected number of calls to fmtSprintf")
+	gzipNewReader = gzip.NewReader
+	assert.Equal(t, gzipNewReaderExpected, gzipNewReaderCalled, "Unexpected number of calls to gzipNewReader")
+	bytesNewReader = bytes.NewReader
+	assert.Equal(t, bytesNewReaderExpected, bytesNewReaderCalled, "Unexpected number of calls to bytesNewReader")
+	ioLimitReader = io.LimitReader
+	assert.Equal(t, ioLimitReaderExpected, ioLimitReaderCalled, "Unexpected number of calls to ioLimitReader")
+	isGzipEncodedFunc = isGzipEncoded
+	assert.Equal(t, isGzipEncodedFuncExpected, isGzipEncodedFuncCalled, "Unexpected number of calls to isGzipEncodedFunc")
+	decompressGzipFunc = decompressGzip
+	assert.Equal(t, decompressGzipFuncExpected, decompressGzipFuncCalled, "Unexpected number of calls to decompressGzipFunc")
+	fmtErrorf = fmt.Errorf
+	assert.Equal(t, fmtErrorfExpected, fmtErrorfCalled, "Unexpected number of calls to fmtErrorf")
+	stringsToLower = strings.ToLower
+	assert.Equal(t, stringsToLowerExpected, stringsToLowerCalled, "Unexpected number of calls to stringsToLower")
+	stringsTrimSpace = strings.TrimSpace
+	assert.Equal(t, stringsTrimSpaceExpected, stringsTrimSpaceCalled, "Unexpected number of calls to stringsTrimSpace")
+	stringsHasSuffix = strings.HasSuffix
+	assert.Equal(t, stringsHasSuffixExpected, stringsHasSuffixCalled, "Unexpected number of calls to stringsHasSuffix")
+	stringsNewReader = strings.NewReader
+	assert.Equal(t, stringsNewReaderExpected, stringsNewReaderCalled, "Unexpected number of calls to stringsNewReader")
+	stringsSplit = strings.Split
+	assert.Equal(t, stringsSplitExpected, stringsSplitCalled, "Unexpected number of calls to stringsSplit")
+	xmlUnmarshal = xml.Unmarshal
+	assert.Equal(t, xmlUnmarshalExpected, xmlUnmarshalCalled, "Unexpected number of calls to xmlUnmarshal")
+	multipartNewReader = multipart.NewReader
+	assert.Equal(t, multipartNewReaderExpected, multipartNewReaderCalled, "Unexpected number of calls to multipartNewReader")
+	mimeParseMediaType = mime.ParseMediaType
+	assert.Equal(t, mimeParseMediaTypeExpected, mimeParseMediaTypeCalled, "Unexpected number of calls to mimeParseMediaType")
+	urlParseQuery = url.ParseQuery
+	assert.Equal(t, urlParseQueryExpected, urlParseQueryCalled, "Unexpected number of calls to urlParseQuery")
+	jsonutilTryUnmarshal = jsonutil.TryUnmarshal
+	assert.Equal(t, jsonutilTryUnmarshalExpected, jsonutilTryUnmarshalCalled, "Unexpected number of calls to jsonutilTryUnmarshal")
+	apperrorGetBadRequestError = apperror.GetBadRequestError
+	assert.Equal(t, apperrorGetBadRequestErrorExpected, apperrorGetBadRequestErrorCalled, "Unexpected number of calls to apperrorGetBadRequestError")
+	apperrorGetUnsupportedMediaTypeError = apperror.GetUnsupportedMediaTypeError
+	assert.Equal(t, apperrorGetUnsupportedMediaTypeErrorExpected, apperrorGetUnsupportedMediaTypeErrorCalled, "Unexpected number of calls to apperrorGetUnsupportedMediaTypeError")
+	parseMultipartValuesFunc = parseMultipartValues
+	assert.Equal(t, parseMultipartValuesFuncExpected, parseMultipartValuesFuncCalled, "Unexpected number of calls to parseMultipartValuesFunc")
+	decodeFormValuesFunc = decodeFormValues
+	assert.Equal(t, decodeFormValuesFuncExpected, decodeFormValuesFuncCalled, "Unexpected number of calls to decodeFormValuesFunc")
+	getDecodersFunc = getDecoders
+	assert.Equal(t, getDecodersFuncExpected, getDecodersFuncCalled, "Unexpected number of calls to getDecodersFunc")
+	getMediaTypeFunc = getMediaType
+	assert.Equal(t, getMediaTypeFuncExpected, getMediaTypeFuncCalled, "Unexpected number of calls to getMediaTypeFunc")
+	getDecoderFunc = getDecoder
+	assert.Equal(t, getDecoderFuncExpected, getDecoderFuncCalled, "Unexpected number of calls to getDecoderFunc")
+	apperrorGetRequestEntityTooLargeError = apperror.GetRequestEntityTooLargeError
+	assert.Equal(t, apperrorGetRequestEntityTooLargeErrorExpected, apperrorGetRequestEntityTooLargeErrorCalled, "Unexpected number of calls to apperrorGetRequestEntityTooLargeError")
+	apperrorGetGeneralFailureError = apperror.GetGeneralFailureError
+	assert.Equal(t, apperrorGetGeneralFailureErrorExpected, apperrorGetGeneralFailureErrorCalled, "Unexpected number of calls to apperrorGetGeneralFailureError")
+	getFileContentFunc = getFileContent
+	assert.Equal(t, getFileContentFuncExpected, getFileContentFuncCalled, "Unexpected number of calls to getFileContentFunc")
+	customization.RequestDecoders = nil
+	assert.Equal(t, customizationRequestDecodersExpected, customizationRequestDecodersCalled, "Unexpected number of calls to customization.RequestDecoders")
+}
+
+type dummyDecoder struct {
+	t          *testing.T
+	body       string
+	parameters map[string]string
+	err        error
+}
+
+func (decoder *dummyDecoder) Decode(body string, parameters map[string]string, dataTemplate interface{}) error {
+	assert.Equal(decoder.t, decoder.body, body)
+	assert.Equal(decoder.t, decoder.parameters, parameters)
+	return decoder.err
+}
+
+type dummyPart struct {
+	name     string
+	fileName string
+	content  string
+}
+
+func createMultipartBody(t *testing.T, parts []dummyPart) (string, string) {
+	var buffer = &bytes.Buffer{}
+	var writer = multipart.NewWriter(buffer)
+	for _, part := range parts {
+		var partWriter io.Writer
+		var partError error
+		if part.fileName == "" {
+			partWriter, partError = writer.CreateFormField(part.name)
+		} else {
+			partWriter, partError = writer.CreateFormFile(part.name, part.fileName)
+		}
+		assert.NoError(t, partError)
+		partWriter.Write([]byte(part.content))
+	}
+	assert.NoError(t, writer.Close())
+	return buffer.String(), writer.FormDataContentType()
 }
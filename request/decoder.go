@@ -0,0 +1,334 @@
+package request
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/request/model"
+)
+
+// These are the media types supported by the built-in request decoders
+const (
+	MediaTypeJSON           = "application/json"
+	MediaTypeXML            = "application/xml"
+	MediaTypeTextXML        = "text/xml"
+	MediaTypeFormURLEncoded = "application/x-www-form-urlencoded"
+	MediaTypeMultipartForm  = "multipart/form-data"
+)
+
+// These are the constants used for decoding form values
+const (
+	contentTypeHeader = "Content-Type"
+	boundaryParameter = "boundary"
+	jsonSuffix        = "+json"
+	xmlSuffix         = "+xml"
+	formTag           = "form"
+	jsonTag           = "json"
+	tagSeparator      = ","
+	skippedTagName    = "-"
+)
+
+var (
+	builtInDecoders = map[string]model.Decoder{
+		MediaTypeJSON:           jsonDecoder{},
+		MediaTypeXML:            xmlDecoder{},
+		MediaTypeTextXML:        xmlDecoder{},
+		MediaTypeFormURLEncoded: formDecoder{},
+		MediaTypeMultipartForm:  multipartDecoder{},
+	}
+)
+
+type jsonDecoder struct{}
+
+func (decoder jsonDecoder) Decode(body string, parameters map[string]string, dataTemplate interface{}) error {
+	return jsonutilTryUnmarshal(
+		body,
+		dataTemplate,
+	)
+}
+
+type xmlDecoder struct{}
+
+func (decoder xmlDecoder) Decode(body string, parameters map[string]string, dataTemplate interface{}) error {
+	return xmlUnmarshal(
+		[]byte(body),
+		dataTemplate,
+	)
+}
+
+type formDecoder struct{}
+
+func (decoder formDecoder) Decode(body string, parameters map[string]string, dataTemplate interface{}) error {
+	var values, parseError = urlParseQuery(body)
+	if parseError != nil {
+		return parseError
+	}
+	return decodeFormValuesFunc(
+		values,
+		dataTemplate,
+	)
+}
+
+type multipartDecoder struct{}
+
+func (decoder multipartDecoder) Decode(body string, parameters map[string]string, dataTemplate interface{}) error {
+	var values, parseError = parseMultipartValuesFunc(
+		body,
+		parameters[boundaryParameter],
+	)
+	if parseError != nil {
+		return parseError
+	}
+	return decodeFormValuesFunc(
+		values,
+		dataTemplate,
+	)
+}
+
+// parseMultipartValues collects the non-file form fields of the given multipart body; file parts are skipped
+func parseMultipartValues(body string, boundary string) (url.Values, error) {
+	if boundary == "" {
+		return nil,
+			fmtErrorf(
+				"The multipart boundary is missing in request content type",
+			)
+	}
+	var reader = multipartNewReader(
+		stringsNewReader(body),
+		boundary,
+	)
+	var values = url.Values{}
+	for {
+		var part, partError = reader.NextPart()
+		if partError == io.EOF {
+			return values, nil
+		}
+		if partError != nil {
+			return nil, partError
+		}
+		if part.FileName() != "" {
+			continue
+		}
+		var valueBytes, readError = ioutilReadAll(part)
+		if readError != nil {
+			return nil, readError
+		}
+		values.Add(
+			part.FormName(),
+			string(valueBytes),
+		)
+	}
+}
+
+// getFormFieldName returns the form field name of the given struct field upon its form tag, then its json tag, then its own name
+func getFormFieldName(field reflect.StructField) string {
+	for _, tag := range []string{formTag, jsonTag} {
+		var name = stringsSplit(
+			field.Tag.Get(tag),
+			tagSeparator,
+		)[0]
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func decodeFormField(fieldValue reflect.Value, values []string) error {
+	if fieldValue.Kind() != reflect.Slice ||
+		fieldValue.Type().Elem().Kind() == reflect.Uint8 {
+		return jsonutilTryUnmarshal(
+			values[0],
+			fieldValue.Addr().Interface(),
+		)
+	}
+	var items = reflect.MakeSlice(
+		fieldValue.Type(),
+		0,
+		len(values),
+	)
+	for _, value := range values {
+		var item = reflect.New(
+			fieldValue.Type().Elem(),
+		)
+		var unmarshalError = jsonutilTryUnmarshal(
+			value,
+			item.Interface(),
+		)
+		if unmarshalError != nil {
+			return unmarshalError
+		}
+		items = reflect.Append(
+			items,
+			item.Elem(),
+		)
+	}
+	fieldValue.Set(items)
+	return nil
+}
+
+// decodeFormValues fills the given data template with form values; url.Values, map[string][]string and map[string]string templates are filled directly, while struct templates are filled field by field upon form or json tags, with slice fields taking all values of the same name
+func decodeFormValues(values url.Values, dataTemplate interface{}) error {
+	switch template := dataTemplate.(type) {
+	case *url.Values:
+		*template = values
+		return nil
+	case *map[string][]string:
+		*template = values
+		return nil
+	case *map[string]string:
+		*template = map[string]string{}
+		for name := range values {
+			(*template)[name] = values.Get(name)
+		}
+		return nil
+	}
+	var templateValue = reflect.ValueOf(dataTemplate)
+	if templateValue.Kind() != reflect.Ptr ||
+		templateValue.IsNil() ||
+		templateValue.Elem().Kind() != reflect.Struct {
+		return fmtErrorf(
+			"Unable to decode form values into data template of type [%T]",
+			dataTemplate,
+		)
+	}
+	var structValue = templateValue.Elem()
+	var structType = structValue.Type()
+	for index := 0; index < structType.NumField(); index++ {
+		var field = structType.Field(index)
+		if field.PkgPath != "" {
+			continue
+		}
+		var name = getFormFieldName(field)
+		if name == skippedTagName {
+			continue
+		}
+		var fieldValues = values[name]
+		if len(fieldValues) == 0 {
+			continue
+		}
+		var decodeError = decodeFormField(
+			structValue.Field(index),
+			fieldValues,
+		)
+		if decodeError != nil {
+			return fmtErrorf(
+				"Unable to decode form field [%v]: %v",
+				name,
+				decodeError,
+			)
+		}
+	}
+	return nil
+}
+
+func getDecoders() map[string]model.Decoder {
+	var decoders = map[string]model.Decoder{}
+	for mediaType, decoder := range builtInDecoders {
+		decoders[mediaType] = decoder
+	}
+	if customization.RequestDecoders == nil {
+		return decoders
+	}
+	for mediaType, decoder := range customization.RequestDecoders() {
+		var normalizedMediaType = stringsToLower(
+			stringsTrimSpace(mediaType),
+		)
+		if decoder == nil {
+			delete(decoders, normalizedMediaType)
+		} else {
+			decoders[normalizedMediaType] = decoder
+		}
+	}
+	return decoders
+}
+
+// getMediaType returns the media type and parameters of the Content-Type header of given HTTP request; JSON is assumed if the header is absent
+func getMediaType(httpRequest *http.Request) (string, map[string]string) {
+	var contentType = stringsTrimSpace(
+		httpRequest.Header.Get(contentTypeHeader),
+	)
+	if contentType == "" {
+		return MediaTypeJSON, nil
+	}
+	var mediaType, parameters, parseError = mimeParseMediaType(
+		contentType,
+	)
+	if parseError != nil {
+		return stringsToLower(contentType), nil
+	}
+	return mediaType, parameters
+}
+
+// getDecoder returns the decoder registered for given media type, falling back to JSON or XML decoders for structured syntax suffixes like "+json" or "+xml"
+func getDecoder(decoders map[string]model.Decoder, mediaType string) model.Decoder {
+	var decoder, found = decoders[mediaType]
+	if found {
+		return decoder
+	}
+	if stringsHasSuffix(mediaType, jsonSuffix) {
+		return decoders[MediaTypeJSON]
+	}
+	if stringsHasSuffix(mediaType, xmlSuffix) {
+		return decoders[MediaTypeXML]
+	}
+	return nil
+}
+
+// DecodeRequestBody decodes the given body of the httpRequest into the dataTemplate with the decoder registered for its Content-Type header
+func DecodeRequestBody(
+	httpRequest *http.Request,
+	body string,
+	dataTemplate interface{},
+) apperrorModel.AppError {
+	var mediaType, parameters = getMediaTypeFunc(
+		httpRequest,
+	)
+	var decoder = getDecoderFunc(
+		getDecodersFunc(),
+		mediaType,
+	)
+	if decoder == nil {
+		return apperrorGetUnsupportedMediaTypeError(
+			fmtErrorf(
+				"No decoder registered for content type [%v]",
+				mediaType,
+			),
+		)
+	}
+	return apperrorGetBadRequestError(
+		decoder.Decode(
+			body,
+			parameters,
+			dataTemplate,
+		),
+	)
+}
+
+// GetFormValues parses and returns the form fields in the given body of the httpRequest, which is either URL-encoded or multipart form; file parts of a multipart form are skipped
+func GetFormValues(
+	httpRequest *http.Request,
+	body string,
+) (url.Values, error) {
+	var mediaType, parameters = getMediaTypeFunc(
+		httpRequest,
+	)
+	switch mediaType {
+	case MediaTypeFormURLEncoded:
+		return urlParseQuery(body)
+	case MediaTypeMultipartForm:
+		return parseMultipartValuesFunc(
+			body,
+			parameters[boundaryParameter],
+		)
+	}
+	return nil,
+		fmtErrorf(
+			"The request content type [%v] is not a form",
+			mediaType,
+		)
+}
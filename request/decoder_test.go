@@ -0,0 +1,976 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/request/model"
+)
+
+func TestJSONDecoder(t *testing.T) {
+	// arrange
+	var dummyBody = "some body"
+	var dummyDataTemplate int
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	jsonutilTryUnmarshalExpected = 1
+	jsonutilTryUnmarshal = func(value string, dataTemplate interface{}) error {
+		jsonutilTryUnmarshalCalled++
+		assert.Equal(t, dummyBody, value)
+		assert.Equal(t, &dummyDataTemplate, dataTemplate)
+		return dummyError
+	}
+
+	// SUT
+	var sut = jsonDecoder{}
+
+	// act
+	var err = sut.Decode(
+		dummyBody,
+		nil,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestXMLDecoder(t *testing.T) {
+	// arrange
+	var dummyBody = "some body"
+	var dummyDataTemplate int
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	xmlUnmarshalExpected = 1
+	xmlUnmarshal = func(data []byte, v interface{}) error {
+		xmlUnmarshalCalled++
+		assert.Equal(t, []byte(dummyBody), data)
+		assert.Equal(t, &dummyDataTemplate, v)
+		return dummyError
+	}
+
+	// SUT
+	var sut = xmlDecoder{}
+
+	// act
+	var err = sut.Decode(
+		dummyBody,
+		nil,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormDecoder_ParseError(t *testing.T) {
+	// arrange
+	var dummyBody = "some body"
+	var dummyDataTemplate int
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	urlParseQueryExpected = 1
+	urlParseQuery = func(query string) (url.Values, error) {
+		urlParseQueryCalled++
+		assert.Equal(t, dummyBody, query)
+		return nil, dummyError
+	}
+
+	// SUT
+	var sut = formDecoder{}
+
+	// act
+	var err = sut.Decode(
+		dummyBody,
+		nil,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormDecoder_Success(t *testing.T) {
+	// arrange
+	var dummyBody = "some body"
+	var dummyDataTemplate int
+	var dummyValues = url.Values{"some key": []string{"some value"}}
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	urlParseQueryExpected = 1
+	urlParseQuery = func(query string) (url.Values, error) {
+		urlParseQueryCalled++
+		return dummyValues, nil
+	}
+	decodeFormValuesFuncExpected = 1
+	decodeFormValuesFunc = func(values url.Values, dataTemplate interface{}) error {
+		decodeFormValuesFuncCalled++
+		assert.Equal(t, dummyValues, values)
+		assert.Equal(t, &dummyDataTemplate, dataTemplate)
+		return dummyError
+	}
+
+	// SUT
+	var sut = formDecoder{}
+
+	// act
+	var err = sut.Decode(
+		dummyBody,
+		nil,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMultipartDecoder_ParseError(t *testing.T) {
+	// arrange
+	var dummyBody = "some body"
+	var dummyBoundary = "some boundary"
+	var dummyDataTemplate int
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	parseMultipartValuesFuncExpected = 1
+	parseMultipartValuesFunc = func(body string, boundary string) (url.Values, error) {
+		parseMultipartValuesFuncCalled++
+		assert.Equal(t, dummyBody, body)
+		assert.Equal(t, dummyBoundary, boundary)
+		return nil, dummyError
+	}
+
+	// SUT
+	var sut = multipartDecoder{}
+
+	// act
+	var err = sut.Decode(
+		dummyBody,
+		map[string]string{"boundary": dummyBoundary},
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMultipartDecoder_Success(t *testing.T) {
+	// arrange
+	var dummyBody = "some body"
+	var dummyDataTemplate int
+	var dummyValues = url.Values{"some key": []string{"some value"}}
+
+	// mock
+	createMock(t)
+
+	// expect
+	parseMultipartValuesFuncExpected = 1
+	parseMultipartValuesFunc = func(body string, boundary string) (url.Values, error) {
+		parseMultipartValuesFuncCalled++
+		assert.Zero(t, boundary)
+		return dummyValues, nil
+	}
+	decodeFormValuesFuncExpected = 1
+	decodeFormValuesFunc = func(values url.Values, dataTemplate interface{}) error {
+		decodeFormValuesFuncCalled++
+		assert.Equal(t, dummyValues, values)
+		assert.Equal(t, &dummyDataTemplate, dataTemplate)
+		return nil
+	}
+
+	// SUT
+	var sut = multipartDecoder{}
+
+	// act
+	var err = sut.Decode(
+		dummyBody,
+		nil,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseMultipartValues_NoBoundary(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "The multipart boundary is missing in request content type", format)
+		assert.Equal(t, 0, len(a))
+		return dummyError
+	}
+
+	// SUT + act
+	var result, err = parseMultipartValues(
+		"some body",
+		"",
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseMultipartValues_InvalidBody(t *testing.T) {
+	// arrange
+	var dummyBody = "--some boundary\r\nsome invalid part"
+	var dummyBoundary = "some boundary"
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsNewReaderExpected = 1
+	stringsNewReader = func(s string) *strings.Reader {
+		stringsNewReaderCalled++
+		assert.Equal(t, dummyBody, s)
+		return strings.NewReader(s)
+	}
+	multipartNewReaderExpected = 1
+	multipartNewReader = func(r io.Reader, boundary string) *multipart.Reader {
+		multipartNewReaderCalled++
+		assert.Equal(t, dummyBoundary, boundary)
+		return multipart.NewReader(r, boundary)
+	}
+
+	// SUT + act
+	var result, err = parseMultipartValues(
+		dummyBody,
+		dummyBoundary,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Error(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseMultipartValues_Success(t *testing.T) {
+	// arrange
+	var dummyBody, dummyContentType = createMultipartBody(
+		t,
+		[]dummyPart{
+			{name: "foo", content: "bar 1"},
+			{name: "file", fileName: "some file name", content: "some file content"},
+			{name: "foo", content: "bar 2"},
+			{name: "test", content: "123"},
+		},
+	)
+	var _, dummyParameters, _ = mime.ParseMediaType(dummyContentType)
+	var dummyBoundary = dummyParameters["boundary"]
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsNewReaderExpected = 1
+	stringsNewReader = func(s string) *strings.Reader {
+		stringsNewReaderCalled++
+		assert.Equal(t, dummyBody, s)
+		return strings.NewReader(s)
+	}
+	multipartNewReaderExpected = 1
+	multipartNewReader = func(r io.Reader, boundary string) *multipart.Reader {
+		multipartNewReaderCalled++
+		assert.Equal(t, dummyBoundary, boundary)
+		return multipart.NewReader(r, boundary)
+	}
+	ioutilReadAllExpected = 3
+	ioutilReadAll = func(r io.Reader) ([]byte, error) {
+		ioutilReadAllCalled++
+		return ioutil.ReadAll(r)
+	}
+
+	// SUT + act
+	var result, err = parseMultipartValues(
+		dummyBody,
+		dummyBoundary,
+	)
+
+	// assert
+	assert.Equal(t, url.Values{"foo": []string{"bar 1", "bar 2"}, "test": []string{"123"}}, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecodeFormValues_DirectTemplates(t *testing.T) {
+	// arrange
+	var dummyValues = url.Values{
+		"foo":  []string{"bar 1", "bar 2"},
+		"test": []string{"123"},
+	}
+	var dummyURLValues url.Values
+	var dummyMultiMap map[string][]string
+	var dummySingleMap map[string]string
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err1 = decodeFormValues(dummyValues, &dummyURLValues)
+	var err2 = decodeFormValues(dummyValues, &dummyMultiMap)
+	var err3 = decodeFormValues(dummyValues, &dummySingleMap)
+
+	// assert
+	assert.NoError(t, err1)
+	assert.Equal(t, dummyValues, dummyURLValues)
+	assert.NoError(t, err2)
+	assert.Equal(t, map[string][]string(dummyValues), dummyMultiMap)
+	assert.NoError(t, err3)
+	assert.Equal(t, map[string]string{"foo": "bar 1", "test": "123"}, dummySingleMap)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecodeFormValues_InvalidTemplate(t *testing.T) {
+	// arrange
+	var dummyDataTemplate int
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Unable to decode form values into data template of type [%T]", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, &dummyDataTemplate, a[0])
+		return dummyError
+	}
+
+	// SUT + act
+	var err = decodeFormValues(
+		url.Values{},
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecodeFormValues_FieldError(t *testing.T) {
+	// arrange
+	var dummyValues = url.Values{
+		"count": []string{"abc"},
+	}
+	var dummyDataTemplate struct {
+		Count int `json:"count"`
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		return fmt.Errorf(format, a...)
+	}
+	jsonutilTryUnmarshalExpected = 1
+	jsonutilTryUnmarshal = func(value string, dataTemplate interface{}) error {
+		jsonutilTryUnmarshalCalled++
+		return jsonutil.TryUnmarshal(value, dataTemplate)
+	}
+	stringsSplitExpected = 2
+	stringsSplit = func(s string, sep string) []string {
+		stringsSplitCalled++
+		assert.Equal(t, tagSeparator, sep)
+		return strings.Split(s, sep)
+	}
+
+	// SUT + act
+	var err = decodeFormValues(
+		dummyValues,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, "Unable to decode form field [count]: Unable to unmarshal value [abc] into data template", err.Error())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecodeFormValues_Struct(t *testing.T) {
+	// arrange
+	var dummyValues = url.Values{
+		"name":    []string{"some name", "some other name"},
+		"count":   []string{"12"},
+		"Enabled": []string{"true"},
+		"tags":    []string{"a", "b"},
+		"scores":  []string{"1.5", "2"},
+		"ignored": []string{"some value"},
+		"hidden":  []string{"some value"},
+	}
+	var dummyDataTemplate struct {
+		Name    string    `form:"name" json:"fullName"`
+		Count   int       `json:"count,omitempty"`
+		Enabled bool      ``
+		Tags    []string  `form:"tags"`
+		Scores  []float64 `json:"scores"`
+		Ignored string    `form:"-"`
+		Missing string    `form:"missing"`
+		hidden  string
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	jsonutilTryUnmarshalExpected = 7
+	jsonutilTryUnmarshal = func(value string, dataTemplate interface{}) error {
+		jsonutilTryUnmarshalCalled++
+		return jsonutil.TryUnmarshal(value, dataTemplate)
+	}
+	stringsSplitExpected = 10
+	stringsSplit = func(s string, sep string) []string {
+		stringsSplitCalled++
+		assert.Equal(t, tagSeparator, sep)
+		return strings.Split(s, sep)
+	}
+
+	// SUT + act
+	var err = decodeFormValues(
+		dummyValues,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "some name", dummyDataTemplate.Name)
+	assert.Equal(t, 12, dummyDataTemplate.Count)
+	assert.True(t, dummyDataTemplate.Enabled)
+	assert.Equal(t, []string{"a", "b"}, dummyDataTemplate.Tags)
+	assert.Equal(t, []float64{1.5, 2}, dummyDataTemplate.Scores)
+	assert.Zero(t, dummyDataTemplate.Ignored)
+	assert.Zero(t, dummyDataTemplate.Missing)
+	assert.Zero(t, dummyDataTemplate.hidden)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetDecoders_NoCustomization(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getDecoders()
+
+	// assert
+	assert.Equal(t, builtInDecoders, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetDecoders_Customized(t *testing.T) {
+	// arrange
+	var dummyYAMLDecoder = &dummyDecoder{t: t}
+	var dummyXMLDecoder = &dummyDecoder{t: t}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationRequestDecodersExpected = 1
+	customization.RequestDecoders = func() map[string]model.Decoder {
+		customizationRequestDecodersCalled++
+		return map[string]model.Decoder{
+			" Application/YAML": dummyYAMLDecoder,
+			MediaTypeXML:        dummyXMLDecoder,
+			MediaTypeTextXML:    nil,
+		}
+	}
+	stringsTrimSpaceExpected = 3
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	stringsToLowerExpected = 3
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		return strings.ToLower(s)
+	}
+
+	// SUT + act
+	var result = getDecoders()
+
+	// assert
+	assert.Equal(t, 5, len(result))
+	assert.Equal(t, builtInDecoders[MediaTypeJSON], result[MediaTypeJSON])
+	assert.Equal(t, dummyXMLDecoder, result[MediaTypeXML])
+	assert.Equal(t, builtInDecoders[MediaTypeFormURLEncoded], result[MediaTypeFormURLEncoded])
+	assert.Equal(t, builtInDecoders[MediaTypeMultipartForm], result[MediaTypeMultipartForm])
+	assert.Equal(t, dummyYAMLDecoder, result["application/yaml"])
+	var _, found = result[MediaTypeTextXML]
+	assert.False(t, found)
+	assert.Equal(t, 5, len(builtInDecoders))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetMediaType_NoHeader(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsTrimSpaceExpected = 1
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+
+	// SUT + act
+	var mediaType, parameters = getMediaType(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, MediaTypeJSON, mediaType)
+	assert.Nil(t, parameters)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetMediaType_ParseError(t *testing.T) {
+	// arrange
+	var dummyContentType = "some content type"
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+	var dummyLowerContentType = "some lower content type"
+	var dummyError = errors.New("some error")
+
+	// stub
+	dummyHTTPRequest.Header.Set("Content-Type", dummyContentType)
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsTrimSpaceExpected = 1
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	mimeParseMediaTypeExpected = 1
+	mimeParseMediaType = func(v string) (string, map[string]string, error) {
+		mimeParseMediaTypeCalled++
+		assert.Equal(t, dummyContentType, v)
+		return "", nil, dummyError
+	}
+	stringsToLowerExpected = 1
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		assert.Equal(t, dummyContentType, s)
+		return dummyLowerContentType
+	}
+
+	// SUT + act
+	var mediaType, parameters = getMediaType(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyLowerContentType, mediaType)
+	assert.Nil(t, parameters)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetMediaType_Success(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+
+	// stub
+	dummyHTTPRequest.Header.Set("Content-Type", " Multipart/Form-Data; boundary=abc ")
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsTrimSpaceExpected = 1
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	mimeParseMediaTypeExpected = 1
+	mimeParseMediaType = func(v string) (string, map[string]string, error) {
+		mimeParseMediaTypeCalled++
+		return mime.ParseMediaType(v)
+	}
+
+	// SUT + act
+	var mediaType, parameters = getMediaType(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, MediaTypeMultipartForm, mediaType)
+	assert.Equal(t, map[string]string{"boundary": "abc"}, parameters)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetDecoder(t *testing.T) {
+	// arrange
+	var dummyJSONDecoder = &dummyDecoder{t: t, body: "json"}
+	var dummyXMLDecoder = &dummyDecoder{t: t, body: "xml"}
+	var dummyYAMLDecoder = &dummyDecoder{t: t, body: "yaml"}
+	var dummyDecoders = map[string]model.Decoder{
+		MediaTypeJSON:      dummyJSONDecoder,
+		MediaTypeXML:       dummyXMLDecoder,
+		"application/yaml": dummyYAMLDecoder,
+	}
+	type testCase struct {
+		mediaType string
+		decoder   model.Decoder
+	}
+	var testCases = []testCase{
+		{"application/yaml", dummyYAMLDecoder},
+		{"application/problem+json", dummyJSONDecoder},
+		{"application/atom+xml", dummyXMLDecoder},
+		{"text/plain", nil},
+	}
+
+	for _, test := range testCases {
+		// mock
+		createMock(t)
+
+		// expect
+		stringsHasSuffix = func(s string, suffix string) bool {
+			stringsHasSuffixCalled++
+			return strings.HasSuffix(s, suffix)
+		}
+
+		// SUT + act
+		var result = getDecoder(
+			dummyDecoders,
+			test.mediaType,
+		)
+
+		// assert
+		assert.Equal(t, test.decoder, result, test.mediaType)
+
+		// tear down
+		stringsHasSuffixExpected = stringsHasSuffixCalled
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestDecodeRequestBody_UnsupportedMediaType(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyBody = "some body"
+	var dummyDataTemplate int
+	var dummyMediaType = "some media type"
+	var dummyDecoders = map[string]model.Decoder{}
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	getMediaTypeFuncExpected = 1
+	getMediaTypeFunc = func(httpRequest *http.Request) (string, map[string]string) {
+		getMediaTypeFuncCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return dummyMediaType, nil
+	}
+	getDecodersFuncExpected = 1
+	getDecodersFunc = func() map[string]model.Decoder {
+		getDecodersFuncCalled++
+		return dummyDecoders
+	}
+	getDecoderFuncExpected = 1
+	getDecoderFunc = func(decoders map[string]model.Decoder, mediaType string) model.Decoder {
+		getDecoderFuncCalled++
+		assert.Equal(t, dummyDecoders, decoders)
+		assert.Equal(t, dummyMediaType, mediaType)
+		return nil
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "No decoder registered for content type [%v]", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyMediaType, a[0])
+		return dummyError
+	}
+	apperrorGetUnsupportedMediaTypeErrorExpected = 1
+	apperrorGetUnsupportedMediaTypeError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetUnsupportedMediaTypeErrorCalled++
+		assert.Equal(t, []error{dummyError}, innerErrors)
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = DecodeRequestBody(
+		dummyHTTPRequest,
+		dummyBody,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecodeRequestBody_Decoded(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyBody = "some body"
+	var dummyDataTemplate int
+	var dummyParameters = map[string]string{"some key": "some value"}
+	var dummyError = errors.New("some error")
+	var dummyDecoderObject = &dummyDecoder{
+		t:          t,
+		body:       dummyBody,
+		parameters: dummyParameters,
+		err:        dummyError,
+	}
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	getMediaTypeFuncExpected = 1
+	getMediaTypeFunc = func(httpRequest *http.Request) (string, map[string]string) {
+		getMediaTypeFuncCalled++
+		return "some media type", dummyParameters
+	}
+	getDecodersFuncExpected = 1
+	getDecodersFunc = func() map[string]model.Decoder {
+		getDecodersFuncCalled++
+		return nil
+	}
+	getDecoderFuncExpected = 1
+	getDecoderFunc = func(decoders map[string]model.Decoder, mediaType string) model.Decoder {
+		getDecoderFuncCalled++
+		return dummyDecoderObject
+	}
+	apperrorGetBadRequestErrorExpected = 1
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		assert.Equal(t, []error{dummyError}, innerErrors)
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = DecodeRequestBody(
+		dummyHTTPRequest,
+		dummyBody,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetFormValues_URLEncoded(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyBody = "some body"
+	var dummyValues = url.Values{"some key": []string{"some value"}}
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	getMediaTypeFuncExpected = 1
+	getMediaTypeFunc = func(httpRequest *http.Request) (string, map[string]string) {
+		getMediaTypeFuncCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return MediaTypeFormURLEncoded, nil
+	}
+	urlParseQueryExpected = 1
+	urlParseQuery = func(query string) (url.Values, error) {
+		urlParseQueryCalled++
+		assert.Equal(t, dummyBody, query)
+		return dummyValues, dummyError
+	}
+
+	// SUT + act
+	var result, err = GetFormValues(
+		dummyHTTPRequest,
+		dummyBody,
+	)
+
+	// assert
+	assert.Equal(t, dummyValues, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetFormValues_Multipart(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyBody = "some body"
+	var dummyBoundary = "some boundary"
+	var dummyValues = url.Values{"some key": []string{"some value"}}
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	getMediaTypeFuncExpected = 1
+	getMediaTypeFunc = func(httpRequest *http.Request) (string, map[string]string) {
+		getMediaTypeFuncCalled++
+		return MediaTypeMultipartForm, map[string]string{"boundary": dummyBoundary}
+	}
+	parseMultipartValuesFuncExpected = 1
+	parseMultipartValuesFunc = func(body string, boundary string) (url.Values, error) {
+		parseMultipartValuesFuncCalled++
+		assert.Equal(t, dummyBody, body)
+		assert.Equal(t, dummyBoundary, boundary)
+		return dummyValues, dummyError
+	}
+
+	// SUT + act
+	var result, err = GetFormValues(
+		dummyHTTPRequest,
+		dummyBody,
+	)
+
+	// assert
+	assert.Equal(t, dummyValues, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetFormValues_NotForm(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyBody = "some body"
+	var dummyMediaType = "some media type"
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	getMediaTypeFuncExpected = 1
+	getMediaTypeFunc = func(httpRequest *http.Request) (string, map[string]string) {
+		getMediaTypeFuncCalled++
+		return dummyMediaType, nil
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "The request content type [%v] is not a form", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyMediaType, a[0])
+		return dummyError
+	}
+
+	// SUT + act
+	var result, err = GetFormValues(
+		dummyHTTPRequest,
+		dummyBody,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
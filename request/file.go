@@ -0,0 +1,116 @@
+package request
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/request/model"
+)
+
+var (
+	errFileTooLarge = errors.New("The uploaded file exceeds the size limit")
+)
+
+// sizeLimitedReader reads from the underlying reader and fails once more than the remaining bytes are read
+type sizeLimitedReader struct {
+	reader    io.Reader
+	remaining int64
+	exceeded  bool
+}
+
+func (limitedReader *sizeLimitedReader) Read(buffer []byte) (int, error) {
+	if int64(len(buffer)) > limitedReader.remaining+1 {
+		buffer = buffer[:limitedReader.remaining+1]
+	}
+	var count, readError = limitedReader.reader.Read(buffer)
+	if int64(count) > limitedReader.remaining {
+		count = int(limitedReader.remaining)
+		limitedReader.remaining = 0
+		limitedReader.exceeded = true
+		return count, errFileTooLarge
+	}
+	limitedReader.remaining -= int64(count)
+	return count, readError
+}
+
+func getFileContent(part io.Reader, maxBytes int64) (io.Reader, *sizeLimitedReader) {
+	if maxBytes <= 0 {
+		return part, nil
+	}
+	var limitedReader = &sizeLimitedReader{
+		reader:    part,
+		remaining: maxBytes,
+	}
+	return limitedReader, limitedReader
+}
+
+// StreamFiles streams the files uploaded in the multipart body of the httpRequest for given form field name to the fileCallback one by one, each limited to maxBytes unless maxBytes is not positive; the httpRequest body is consumed in the process
+func StreamFiles(
+	httpRequest *http.Request,
+	name string,
+	maxBytes int64,
+	fileCallback func(file model.File) error,
+) apperrorModel.AppError {
+	var reader, readerError = httpRequest.MultipartReader()
+	if readerError != nil {
+		return apperrorGetBadRequestError(
+			readerError,
+		)
+	}
+	var fileCount int
+	for {
+		var part, partError = reader.NextPart()
+		if partError == io.EOF {
+			break
+		}
+		if partError != nil {
+			return apperrorGetBadRequestError(
+				partError,
+			)
+		}
+		if part.FormName() != name ||
+			part.FileName() == "" {
+			continue
+		}
+		fileCount++
+		var content, limitedReader = getFileContentFunc(
+			part,
+			maxBytes,
+		)
+		var callbackError = fileCallback(
+			model.File{
+				FieldName:   name,
+				FileName:    part.FileName(),
+				ContentType: part.Header.Get(contentTypeHeader),
+				Content:     content,
+			},
+		)
+		if limitedReader != nil &&
+			limitedReader.exceeded {
+			return apperrorGetRequestEntityTooLargeError(
+				fmtErrorf(
+					"The uploaded file [%v] exceeds the limit of [%v] bytes for form field [%v]",
+					part.FileName(),
+					maxBytes,
+					name,
+				),
+			)
+		}
+		if callbackError != nil {
+			return apperrorGetGeneralFailureError(
+				callbackError,
+			)
+		}
+	}
+	if fileCount == 0 {
+		return apperrorGetBadRequestError(
+			fmtErrorf(
+				"The expected file [%v] is not found in request",
+				name,
+			),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,412 @@
+package request
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/request/model"
+)
+
+func TestSizeLimitedReader_WithinLimit(t *testing.T) {
+	// arrange
+	var dummyContent = "some content"
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &sizeLimitedReader{
+		reader:    strings.NewReader(dummyContent),
+		remaining: int64(len(dummyContent)),
+	}
+
+	// act
+	var result, err = ioutil.ReadAll(sut)
+
+	// assert
+	assert.Equal(t, dummyContent, string(result))
+	assert.NoError(t, err)
+	assert.False(t, sut.exceeded)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSizeLimitedReader_Exceeded(t *testing.T) {
+	// arrange
+	var dummyContent = "some content"
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &sizeLimitedReader{
+		reader:    strings.NewReader(dummyContent),
+		remaining: 4,
+	}
+
+	// act
+	var result, err = ioutil.ReadAll(sut)
+
+	// assert
+	assert.Equal(t, "some", string(result))
+	assert.Equal(t, errFileTooLarge, err)
+	assert.True(t, sut.exceeded)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetFileContent_NoLimit(t *testing.T) {
+	// arrange
+	var dummyPart = strings.NewReader("some content")
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var content, limitedReader = getFileContent(
+		dummyPart,
+		0,
+	)
+
+	// assert
+	assert.Equal(t, dummyPart, content)
+	assert.Nil(t, limitedReader)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetFileContent_WithLimit(t *testing.T) {
+	// arrange
+	var dummyPart = strings.NewReader("some content")
+	var dummyMaxBytes = int64(123)
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var content, limitedReader = getFileContent(
+		dummyPart,
+		dummyMaxBytes,
+	)
+
+	// assert
+	assert.Equal(t, limitedReader, content)
+	assert.Equal(t, dummyPart, limitedReader.reader)
+	assert.Equal(t, dummyMaxBytes, limitedReader.remaining)
+	assert.False(t, limitedReader.exceeded)
+
+	// verify
+	verifyAll(t)
+}
+
+func createMultipartRequest(t *testing.T, parts []dummyPart) *http.Request {
+	var body, contentType = createMultipartBody(t, parts)
+	var httpRequest, _ = http.NewRequest(
+		http.MethodPost,
+		"http://localhost/",
+		strings.NewReader(body),
+	)
+	httpRequest.Header.Set("Content-Type", contentType)
+	return httpRequest
+}
+
+func TestStreamFiles_NotMultipart(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest, _ = http.NewRequest(
+		http.MethodPost,
+		"http://localhost/",
+		strings.NewReader("some body"),
+	)
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	apperrorGetBadRequestErrorExpected = 1
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		assert.Equal(t, http.ErrNotMultipart, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = StreamFiles(
+		dummyHTTPRequest,
+		"some name",
+		0,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStreamFiles_InvalidPart(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest, _ = http.NewRequest(
+		http.MethodPost,
+		"http://localhost/",
+		strings.NewReader("--abc\r\nsome invalid part"),
+	)
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+
+	// stub
+	dummyHTTPRequest.Header.Set("Content-Type", "multipart/form-data; boundary=abc")
+
+	// mock
+	createMock(t)
+
+	// expect
+	apperrorGetBadRequestErrorExpected = 1
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Error(t, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = StreamFiles(
+		dummyHTTPRequest,
+		"some name",
+		0,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStreamFiles_NotFound(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyHTTPRequest = createMultipartRequest(
+		t,
+		[]dummyPart{
+			{name: dummyName, content: "some field value"},
+			{name: "some other name", fileName: "some file name", content: "some file content"},
+		},
+	)
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "The expected file [%v] is not found in request", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyName, a[0])
+		return dummyError
+	}
+	apperrorGetBadRequestErrorExpected = 1
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		assert.Equal(t, []error{dummyError}, innerErrors)
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = StreamFiles(
+		dummyHTTPRequest,
+		dummyName,
+		0,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStreamFiles_TooLarge(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyFileName = "some file name"
+	var dummyMaxBytes = int64(4)
+	var dummyHTTPRequest = createMultipartRequest(
+		t,
+		[]dummyPart{
+			{name: dummyName, fileName: dummyFileName, content: "some file content"},
+		},
+	)
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+	var dummyFileCallbackExpected int
+	var dummyFileCallbackCalled int
+
+	// mock
+	createMock(t)
+
+	// expect
+	getFileContentFuncExpected = 1
+	getFileContentFunc = func(part io.Reader, maxBytes int64) (io.Reader, *sizeLimitedReader) {
+		getFileContentFuncCalled++
+		assert.Equal(t, dummyMaxBytes, maxBytes)
+		return getFileContent(part, maxBytes)
+	}
+	dummyFileCallbackExpected = 1
+	var dummyFileCallback = func(file model.File) error {
+		dummyFileCallbackCalled++
+		var _, readError = ioutil.ReadAll(file.Content)
+		return readError
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "The uploaded file [%v] exceeds the limit of [%v] bytes for form field [%v]", format)
+		assert.Equal(t, 3, len(a))
+		assert.Equal(t, dummyFileName, a[0])
+		assert.Equal(t, dummyMaxBytes, a[1])
+		assert.Equal(t, dummyName, a[2])
+		return dummyError
+	}
+	apperrorGetRequestEntityTooLargeErrorExpected = 1
+	apperrorGetRequestEntityTooLargeError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetRequestEntityTooLargeErrorCalled++
+		assert.Equal(t, []error{dummyError}, innerErrors)
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = StreamFiles(
+		dummyHTTPRequest,
+		dummyName,
+		dummyMaxBytes,
+		dummyFileCallback,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyFileCallbackExpected, dummyFileCallbackCalled, "Unexpected number of calls to dummyFileCallback")
+}
+
+func TestStreamFiles_CallbackError(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyHTTPRequest = createMultipartRequest(
+		t,
+		[]dummyPart{
+			{name: dummyName, fileName: "some file name", content: "some file content"},
+		},
+	)
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+	var dummyFileCallbackExpected int
+	var dummyFileCallbackCalled int
+
+	// mock
+	createMock(t)
+
+	// expect
+	getFileContentFuncExpected = 1
+	getFileContentFunc = func(part io.Reader, maxBytes int64) (io.Reader, *sizeLimitedReader) {
+		getFileContentFuncCalled++
+		return getFileContent(part, maxBytes)
+	}
+	dummyFileCallbackExpected = 1
+	var dummyFileCallback = func(file model.File) error {
+		dummyFileCallbackCalled++
+		return dummyError
+	}
+	apperrorGetGeneralFailureErrorExpected = 1
+	apperrorGetGeneralFailureError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetGeneralFailureErrorCalled++
+		assert.Equal(t, []error{dummyError}, innerErrors)
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = StreamFiles(
+		dummyHTTPRequest,
+		dummyName,
+		100,
+		dummyFileCallback,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyFileCallbackExpected, dummyFileCallbackCalled, "Unexpected number of calls to dummyFileCallback")
+}
+
+func TestStreamFiles_Success(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyHTTPRequest = createMultipartRequest(
+		t,
+		[]dummyPart{
+			{name: dummyName, fileName: "some file 1", content: "some content 1"},
+			{name: dummyName, content: "some field value"},
+			{name: "some other name", fileName: "some other file", content: "some other content"},
+			{name: dummyName, fileName: "some file 2", content: "some content 2"},
+		},
+	)
+	var dummyFileNames = []string{"some file 1", "some file 2"}
+	var dummyContents = []string{"some content 1", "some content 2"}
+	var dummyFileCallbackExpected int
+	var dummyFileCallbackCalled int
+
+	// mock
+	createMock(t)
+
+	// expect
+	getFileContentFuncExpected = 2
+	getFileContentFunc = func(part io.Reader, maxBytes int64) (io.Reader, *sizeLimitedReader) {
+		getFileContentFuncCalled++
+		assert.Zero(t, maxBytes)
+		return getFileContent(part, maxBytes)
+	}
+	dummyFileCallbackExpected = 2
+	var dummyFileCallback = func(file model.File) error {
+		dummyFileCallbackCalled++
+		assert.Equal(t, dummyName, file.FieldName)
+		assert.Equal(t, dummyFileNames[dummyFileCallbackCalled-1], file.FileName)
+		assert.Equal(t, "application/octet-stream", file.ContentType)
+		var content, readError = ioutil.ReadAll(file.Content)
+		assert.Equal(t, dummyContents[dummyFileCallbackCalled-1], string(content))
+		return readError
+	}
+
+	// SUT + act
+	var err = StreamFiles(
+		dummyHTTPRequest,
+		dummyName,
+		0,
+		dummyFileCallback,
+	)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyFileCallbackExpected, dummyFileCallbackCalled, "Unexpected number of calls to dummyFileCallback")
+}
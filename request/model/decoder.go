@@ -0,0 +1,7 @@
+package model
+
+// Decoder is the interface for decoding HTTP request bodies of a specific media type into data templates
+type Decoder interface {
+	// Decode decodes the given HTTP request body into the given data template, with the parameters of the Content-Type header, e.g. "charset" or "boundary"
+	Decode(body string, parameters map[string]string, dataTemplate interface{}) error
+}
@@ -0,0 +1,13 @@
+package model
+
+import (
+	"io"
+)
+
+// File is an uploaded file streamed from a multipart HTTP request body
+type File struct {
+	FieldName   string
+	FileName    string
+	ContentType string
+	Content     io.Reader
+}
@@ -1,9 +1,98 @@
 package request
 
 import (
+	"io"
 	"net/http"
 )
 
+// These are the constants used for request body decoding
+const (
+	contentEncodingHeader = "Content-Encoding"
+	encodingGzip          = "gzip"
+)
+
+func isGzipEncoded(header http.Header) bool {
+	return stringsToLower(
+		stringsTrimSpace(
+			header.Get(contentEncodingHeader),
+		),
+	) == encodingGzip
+}
+
+func decompressGzip(content []byte, maxBytes int64) ([]byte, error) {
+	var reader, readerError = gzipNewReader(
+		bytesNewReader(content),
+	)
+	if readerError != nil {
+		return nil, apperrorGetBadRequestError(
+			readerError,
+		)
+	}
+	defer reader.Close()
+	var limitedReader io.Reader = reader
+	if maxBytes > 0 {
+		limitedReader = ioLimitReader(
+			reader,
+			maxBytes+1,
+		)
+	}
+	var decompressedBytes, decompressError = ioutilReadAll(
+		limitedReader,
+	)
+	if decompressError != nil {
+		return nil, apperrorGetBadRequestError(
+			decompressError,
+		)
+	}
+	if maxBytes > 0 &&
+		int64(len(decompressedBytes)) > maxBytes {
+		return nil, apperrorGetRequestEntityTooLargeError(
+			fmtErrorf(
+				"Decompressed request body exceeds the limit of [%v] bytes",
+				maxBytes,
+			),
+		)
+	}
+	return decompressedBytes, nil
+}
+
+// DecompressBody decompresses the gzip-encoded body of the given HTTP request in place, with the Content-Encoding header removed, reading no more than maxBytes of decompressed content unless maxBytes is not positive; returns a BadRequest error if the body could not be decompressed, or a RequestEntityTooLarge error if the decompressed content exceeds maxBytes
+func DecompressBody(
+	httpRequest *http.Request,
+	maxBytes int64,
+) error {
+	if httpRequest == nil ||
+		httpRequest.Body == nil ||
+		httpRequest.Body == http.NoBody ||
+		!isGzipEncodedFunc(httpRequest.Header) {
+		return nil
+	}
+	defer httpRequest.Body.Close()
+	var bodyBytes, bodyError = ioutilReadAll(
+		httpRequest.Body,
+	)
+	if bodyError != nil {
+		return apperrorGetBadRequestError(
+			bodyError,
+		)
+	}
+	var decompressedBytes, decompressError = decompressGzipFunc(
+		bodyBytes,
+		maxBytes,
+	)
+	if decompressError != nil {
+		return decompressError
+	}
+	httpRequest.Body = ioutilNopCloser(
+		bytesNewBuffer(
+			decompressedBytes,
+		),
+	)
+	httpRequest.Header.Del(contentEncodingHeader)
+	httpRequest.ContentLength = int64(len(decompressedBytes))
+	return nil
+}
+
 // GetRequestBody parses and returns the content of the httpRequest body in string representation
 func GetRequestBody(
 	httpRequest *http.Request,
@@ -2,16 +2,553 @@ package request
 
 import (
 	"bytes"
+	"compress/gzip"
 	"errors"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"net/http"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
 )
 
+func TestIsGzipEncoded_NotGzip(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{}
+	dummyHeader.Set("Content-Encoding", "deflate")
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsTrimSpaceExpected = 1
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	stringsToLowerExpected = 1
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		return strings.ToLower(s)
+	}
+
+	// SUT + act
+	var result = isGzipEncoded(
+		dummyHeader,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsGzipEncoded_Gzip(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{}
+	dummyHeader.Set("Content-Encoding", " GZip ")
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsTrimSpaceExpected = 1
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		assert.Equal(t, " GZip ", s)
+		return strings.TrimSpace(s)
+	}
+	stringsToLowerExpected = 1
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		assert.Equal(t, "GZip", s)
+		return strings.ToLower(s)
+	}
+
+	// SUT + act
+	var result = isGzipEncoded(
+		dummyHeader,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecompressGzip_ReaderError(t *testing.T) {
+	// arrange
+	var dummyContent = []byte("some content")
+	var dummyMaxBytes = rand.Int63n(100)
+	var dummyReader = &bytes.Reader{}
+	var dummyReaderError = errors.New("some reader error")
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	bytesNewReaderExpected = 1
+	bytesNewReader = func(b []byte) *bytes.Reader {
+		bytesNewReaderCalled++
+		assert.Equal(t, dummyContent, b)
+		return dummyReader
+	}
+	gzipNewReaderExpected = 1
+	gzipNewReader = func(r io.Reader) (*gzip.Reader, error) {
+		gzipNewReaderCalled++
+		assert.Equal(t, dummyReader, r)
+		return nil, dummyReaderError
+	}
+	apperrorGetBadRequestErrorExpected = 1
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyReaderError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var result, err = decompressGzip(
+		dummyContent,
+		dummyMaxBytes,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecompressGzip_ReadError(t *testing.T) {
+	// arrange
+	var dummyContent = "some content"
+	var compressedBuffer = &bytes.Buffer{}
+	var gzipWriter = gzip.NewWriter(compressedBuffer)
+	gzipWriter.Write([]byte(dummyContent))
+	gzipWriter.Close()
+	var dummyReadError = errors.New("some read error")
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	bytesNewReaderExpected = 1
+	bytesNewReader = func(b []byte) *bytes.Reader {
+		bytesNewReaderCalled++
+		return bytes.NewReader(b)
+	}
+	gzipNewReaderExpected = 1
+	gzipNewReader = func(r io.Reader) (*gzip.Reader, error) {
+		gzipNewReaderCalled++
+		return gzip.NewReader(r)
+	}
+	ioutilReadAllExpected = 1
+	ioutilReadAll = func(r io.Reader) ([]byte, error) {
+		ioutilReadAllCalled++
+		return nil, dummyReadError
+	}
+	apperrorGetBadRequestErrorExpected = 1
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyReadError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var result, err = decompressGzip(
+		compressedBuffer.Bytes(),
+		0,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecompressGzip_TooLarge(t *testing.T) {
+	// arrange
+	var dummyContent = "some content"
+	var dummyMaxBytes = int64(4)
+	var compressedBuffer = &bytes.Buffer{}
+	var gzipWriter = gzip.NewWriter(compressedBuffer)
+	gzipWriter.Write([]byte(dummyContent))
+	gzipWriter.Close()
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	bytesNewReaderExpected = 1
+	bytesNewReader = func(b []byte) *bytes.Reader {
+		bytesNewReaderCalled++
+		return bytes.NewReader(b)
+	}
+	gzipNewReaderExpected = 1
+	gzipNewReader = func(r io.Reader) (*gzip.Reader, error) {
+		gzipNewReaderCalled++
+		return gzip.NewReader(r)
+	}
+	ioLimitReaderExpected = 1
+	ioLimitReader = func(r io.Reader, n int64) io.Reader {
+		ioLimitReaderCalled++
+		assert.Equal(t, dummyMaxBytes+1, n)
+		return io.LimitReader(r, n)
+	}
+	ioutilReadAllExpected = 1
+	ioutilReadAll = func(r io.Reader) ([]byte, error) {
+		ioutilReadAllCalled++
+		return ioutil.ReadAll(r)
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Decompressed request body exceeds the limit of [%v] bytes", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyMaxBytes, a[0])
+		return dummyError
+	}
+	apperrorGetRequestEntityTooLargeErrorExpected = 1
+	apperrorGetRequestEntityTooLargeError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetRequestEntityTooLargeErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var result, err = decompressGzip(
+		compressedBuffer.Bytes(),
+		dummyMaxBytes,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecompressGzip_WithinLimit(t *testing.T) {
+	// arrange
+	var dummyContent = "some content"
+	var dummyMaxBytes = int64(len(dummyContent))
+	var compressedBuffer = &bytes.Buffer{}
+	var gzipWriter = gzip.NewWriter(compressedBuffer)
+	gzipWriter.Write([]byte(dummyContent))
+	gzipWriter.Close()
+
+	// mock
+	createMock(t)
+
+	// expect
+	bytesNewReaderExpected = 1
+	bytesNewReader = func(b []byte) *bytes.Reader {
+		bytesNewReaderCalled++
+		return bytes.NewReader(b)
+	}
+	gzipNewReaderExpected = 1
+	gzipNewReader = func(r io.Reader) (*gzip.Reader, error) {
+		gzipNewReaderCalled++
+		return gzip.NewReader(r)
+	}
+	ioLimitReaderExpected = 1
+	ioLimitReader = func(r io.Reader, n int64) io.Reader {
+		ioLimitReaderCalled++
+		assert.Equal(t, dummyMaxBytes+1, n)
+		return io.LimitReader(r, n)
+	}
+	ioutilReadAllExpected = 1
+	ioutilReadAll = func(r io.Reader) ([]byte, error) {
+		ioutilReadAllCalled++
+		return ioutil.ReadAll(r)
+	}
+
+	// SUT + act
+	var result, err = decompressGzip(
+		compressedBuffer.Bytes(),
+		dummyMaxBytes,
+	)
+
+	// assert
+	assert.Equal(t, []byte(dummyContent), result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecompressGzip_NoLimit(t *testing.T) {
+	// arrange
+	var dummyContent = "some content"
+	var compressedBuffer = &bytes.Buffer{}
+	var gzipWriter = gzip.NewWriter(compressedBuffer)
+	gzipWriter.Write([]byte(dummyContent))
+	gzipWriter.Close()
+
+	// mock
+	createMock(t)
+
+	// expect
+	bytesNewReaderExpected = 1
+	bytesNewReader = func(b []byte) *bytes.Reader {
+		bytesNewReaderCalled++
+		return bytes.NewReader(b)
+	}
+	gzipNewReaderExpected = 1
+	gzipNewReader = func(r io.Reader) (*gzip.Reader, error) {
+		gzipNewReaderCalled++
+		return gzip.NewReader(r)
+	}
+	ioutilReadAllExpected = 1
+	ioutilReadAll = func(r io.Reader) ([]byte, error) {
+		ioutilReadAllCalled++
+		return ioutil.ReadAll(r)
+	}
+
+	// SUT + act
+	var result, err = decompressGzip(
+		compressedBuffer.Bytes(),
+		0,
+	)
+
+	// assert
+	assert.Equal(t, []byte(dummyContent), result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecompressBody_NotGzipEncoded(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+		Body:   ioutil.NopCloser(strings.NewReader("some body content")),
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isGzipEncodedFuncExpected = 1
+	isGzipEncodedFunc = func(header http.Header) bool {
+		isGzipEncodedFuncCalled++
+		assert.Equal(t, dummyHTTPRequest.Header, header)
+		return false
+	}
+
+	// SUT + act
+	var err = DecompressBody(
+		dummyHTTPRequest,
+		rand.Int63n(100),
+	)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecompressBody_NoBody(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Body: http.NoBody,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var nilRequestError = DecompressBody(
+		nil,
+		rand.Int63n(100),
+	)
+	var noBodyError = DecompressBody(
+		dummyHTTPRequest,
+		rand.Int63n(100),
+	)
+
+	// assert
+	assert.NoError(t, nilRequestError)
+	assert.NoError(t, noBodyError)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecompressBody_ReadError(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{"Content-Encoding": {"gzip"}},
+		Body:   ioutil.NopCloser(strings.NewReader("some body content")),
+	}
+	var dummyReadError = errors.New("some read error")
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	isGzipEncodedFuncExpected = 1
+	isGzipEncodedFunc = func(header http.Header) bool {
+		isGzipEncodedFuncCalled++
+		return true
+	}
+	ioutilReadAllExpected = 1
+	ioutilReadAll = func(r io.Reader) ([]byte, error) {
+		ioutilReadAllCalled++
+		return nil, dummyReadError
+	}
+	apperrorGetBadRequestErrorExpected = 1
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyReadError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = DecompressBody(
+		dummyHTTPRequest,
+		rand.Int63n(100),
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecompressBody_DecompressError(t *testing.T) {
+	// arrange
+	var bodyContent = "some body content"
+	var dummyMaxBytes = rand.Int63n(100)
+	var dummyHTTPRequest = &http.Request{
+		Header:        http.Header{"Content-Encoding": {"gzip"}},
+		Body:          ioutil.NopCloser(strings.NewReader(bodyContent)),
+		ContentLength: int64(len(bodyContent)),
+	}
+	var dummyDecompressError = errors.New("some decompress error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	isGzipEncodedFuncExpected = 1
+	isGzipEncodedFunc = func(header http.Header) bool {
+		isGzipEncodedFuncCalled++
+		return true
+	}
+	ioutilReadAllExpected = 1
+	ioutilReadAll = func(r io.Reader) ([]byte, error) {
+		ioutilReadAllCalled++
+		return ioutil.ReadAll(r)
+	}
+	decompressGzipFuncExpected = 1
+	decompressGzipFunc = func(content []byte, maxBytes int64) ([]byte, error) {
+		decompressGzipFuncCalled++
+		assert.Equal(t, []byte(bodyContent), content)
+		assert.Equal(t, dummyMaxBytes, maxBytes)
+		return nil, dummyDecompressError
+	}
+
+	// SUT + act
+	var err = DecompressBody(
+		dummyHTTPRequest,
+		dummyMaxBytes,
+	)
+
+	// assert
+	assert.Equal(t, dummyDecompressError, err)
+	assert.Equal(t, "gzip", dummyHTTPRequest.Header.Get("Content-Encoding"))
+	assert.Equal(t, int64(len(bodyContent)), dummyHTTPRequest.ContentLength)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestDecompressBody_Decompressed(t *testing.T) {
+	// arrange
+	var bodyContent = "some body content"
+	var decompressedContent = "some decompressed content"
+	var dummyMaxBytes = rand.Int63n(100)
+	var dummyHTTPRequest = &http.Request{
+		Header:        http.Header{"Content-Encoding": {"gzip"}},
+		Body:          ioutil.NopCloser(strings.NewReader(bodyContent)),
+		ContentLength: int64(len(bodyContent)),
+	}
+	var dummyBuffer = &bytes.Buffer{}
+	var dummyReadCloser = ioutil.NopCloser(nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	isGzipEncodedFuncExpected = 1
+	isGzipEncodedFunc = func(header http.Header) bool {
+		isGzipEncodedFuncCalled++
+		return true
+	}
+	ioutilReadAllExpected = 1
+	ioutilReadAll = func(r io.Reader) ([]byte, error) {
+		ioutilReadAllCalled++
+		return ioutil.ReadAll(r)
+	}
+	decompressGzipFuncExpected = 1
+	decompressGzipFunc = func(content []byte, maxBytes int64) ([]byte, error) {
+		decompressGzipFuncCalled++
+		assert.Equal(t, []byte(bodyContent), content)
+		assert.Equal(t, dummyMaxBytes, maxBytes)
+		return []byte(decompressedContent), nil
+	}
+	bytesNewBufferExpected = 1
+	bytesNewBuffer = func(buf []byte) *bytes.Buffer {
+		bytesNewBufferCalled++
+		assert.Equal(t, []byte(decompressedContent), buf)
+		return dummyBuffer
+	}
+	ioutilNopCloserExpected = 1
+	ioutilNopCloser = func(r io.Reader) io.ReadCloser {
+		ioutilNopCloserCalled++
+		assert.Equal(t, dummyBuffer, r)
+		return dummyReadCloser
+	}
+
+	// SUT + act
+	var err = DecompressBody(
+		dummyHTTPRequest,
+		dummyMaxBytes,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, dummyReadCloser, dummyHTTPRequest.Body)
+	assert.Empty(t, dummyHTTPRequest.Header.Get("Content-Encoding"))
+	assert.Equal(t, int64(len(decompressedContent)), dummyHTTPRequest.ContentLength)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestGetRequestBody_NilRequest(t *testing.T) {
 	// arrange
 	var dummySessionID *http.Request
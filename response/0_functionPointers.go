@@ -1,25 +1,82 @@
 package response
 
 import (
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	"github.com/zhongjie-cai/WebServiceTemplate/headerutil"
 	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger"
 )
 
-// func pointers for injection / testing: swagger.go
+// func pointers for injection / testing: response.go
 var (
-	strconvItoa                    = strconv.Itoa
-	jsonutilMarshalIgnoreError     = jsonutil.MarshalIgnoreError
-	apperrorGetGeneralFailureError = apperror.GetGeneralFailureError
-	loggerAPIResponse              = logger.APIResponse
-	httpStatusText                 = http.StatusText
-	writeResponseFunc              = writeResponse
-	getAppErrorFunc                = getAppError
-	generateErrorResponseFunc      = generateErrorResponse
-	createOkResponseFunc           = createOkResponse
-	createErrorResponseFunc        = createErrorResponse
-	constructResponseFunc          = constructResponse
+	strconvItoa                      = strconv.Itoa
+	fmtSprintf                       = fmt.Sprintf
+	stringsJoin                      = strings.Join
+	sortStrings                      = sort.Strings
+	jsonutilMarshalIgnoreError       = jsonutil.MarshalIgnoreError
+	apperrorGetGeneralFailureError   = apperror.GetGeneralFailureError
+	loggerAPIResponse                = logger.APIResponse
+	httpStatusText                   = http.StatusText
+	headerutilSetCorrelationIDHeader = headerutil.SetCorrelationIDHeader
+	writeResponseFunc                = writeResponse
+	getAppErrorFunc                  = getAppError
+	generateErrorResponseFunc        = generateErrorResponse
+	createOkResponseFunc             = createOkResponse
+	createErrorResponseFunc          = createErrorResponse
+	constructResponseFunc            = constructResponse
+)
+
+// func pointers for injection / testing: negotiation.go
+var (
+	fmtErrorf                     = fmt.Errorf
+	stringsToLower                = strings.ToLower
+	stringsTrimSpace              = strings.TrimSpace
+	stringsSplit                  = strings.Split
+	stringsSplitN                 = strings.SplitN
+	strconvParseFloat             = strconv.ParseFloat
+	apperrorGetNotAcceptableError = apperror.GetNotAcceptableError
+	getEncodersFunc               = getEncoders
+	getDefaultMediaTypesFunc      = getDefaultMediaTypes
+	getCandidatesFunc             = getCandidates
+	parseAcceptRangeFunc          = parseAcceptRange
+	parseAcceptFunc               = parseAccept
+	getQualityFunc                = getQuality
+	negotiateFunc                 = negotiate
+	getEncoderFunc                = getEncoder
+)
+
+// func pointers for injection / testing: compression.go
+var (
+	gzipNewWriterLevel     = gzip.NewWriterLevel
+	zlibNewWriterLevel     = zlib.NewWriterLevel
+	getEncodingQualityFunc = getEncodingQuality
+	negotiateEncodingFunc  = negotiateEncoding
+	isCompressibleFunc     = isCompressible
+	createCompressorFunc   = createCompressor
+	compressFunc           = compress
+	compressResponseFunc   = compressResponse
+)
+
+// func pointers for injection / testing: conditional.go
+var (
+	sha256Sum256                       = sha256.Sum256
+	hexEncodeToString                  = hex.EncodeToString
+	httpParseTime                      = http.ParseTime
+	stringsTrimPrefix                  = strings.TrimPrefix
+	stringsHasPrefix                   = strings.HasPrefix
+	apperrorGetPreconditionFailedError = apperror.GetPreconditionFailedError
+	computeETagFunc                    = computeETag
+	isETagMatchFunc                    = isETagMatch
+	isNotModifiedFunc                  = isNotModified
+	evaluateConditionalFunc            = evaluateConditional
 )
@@ -1,48 +1,142 @@
 package response
 
 import (
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/headerutil"
 	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
 	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/response/model"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
 )
 
 var (
-	strconvItoaExpected                          int
-	strconvItoaCalled                            int
-	jsonutilMarshalIgnoreErrorExpected           int
-	jsonutilMarshalIgnoreErrorCalled             int
-	apperrorGetGeneralFailureErrorExpected       int
-	apperrorGetGeneralFailureErrorCalled         int
-	loggerAPIResponseExpected                    int
-	loggerAPIResponseCalled                      int
-	httpStatusTextExpected                       int
-	httpStatusTextCalled                         int
-	writeResponseFuncExpected                    int
-	writeResponseFuncCalled                      int
-	getAppErrorFuncExpected                      int
-	getAppErrorFuncCalled                        int
-	generateErrorResponseFuncExpected            int
-	generateErrorResponseFuncCalled              int
-	createOkResponseFuncExpected                 int
-	createOkResponseFuncCalled                   int
-	createErrorResponseFuncExpected              int
-	createErrorResponseFuncCalled                int
-	constructResponseFuncExpected                int
-	constructResponseFuncCalled                  int
-	customizationCreateErrorResponseFuncExpected int
-	customizationCreateErrorResponseFuncCalled   int
+	strconvItoaExpected                                 int
+	strconvItoaCalled                                   int
+	fmtSprintfExpected                                  int
+	fmtSprintfCalled                                    int
+	stringsJoinExpected                                 int
+	stringsJoinCalled                                   int
+	sortStringsExpected                                 int
+	sortStringsCalled                                   int
+	jsonutilMarshalIgnoreErrorExpected                  int
+	jsonutilMarshalIgnoreErrorCalled                    int
+	apperrorGetGeneralFailureErrorExpected              int
+	apperrorGetGeneralFailureErrorCalled                int
+	loggerAPIResponseExpected                           int
+	loggerAPIResponseCalled                             int
+	httpStatusTextExpected                              int
+	httpStatusTextCalled                                int
+	headerutilSetCorrelationIDHeaderExpected            int
+	headerutilSetCorrelationIDHeaderCalled              int
+	writeResponseFuncExpected                           int
+	writeResponseFuncCalled                             int
+	getAppErrorFuncExpected                             int
+	getAppErrorFuncCalled                               int
+	generateErrorResponseFuncExpected                   int
+	generateErrorResponseFuncCalled                     int
+	createOkResponseFuncExpected                        int
+	createOkResponseFuncCalled                          int
+	createErrorResponseFuncExpected                     int
+	createErrorResponseFuncCalled                       int
+	constructResponseFuncExpected                       int
+	constructResponseFuncCalled                         int
+	fmtErrorfExpected                                   int
+	fmtErrorfCalled                                     int
+	stringsToLowerExpected                              int
+	stringsToLowerCalled                                int
+	stringsTrimSpaceExpected                            int
+	stringsTrimSpaceCalled                              int
+	stringsSplitExpected                                int
+	stringsSplitCalled                                  int
+	stringsSplitNExpected                               int
+	stringsSplitNCalled                                 int
+	strconvParseFloatExpected                           int
+	strconvParseFloatCalled                             int
+	apperrorGetNotAcceptableErrorExpected               int
+	apperrorGetNotAcceptableErrorCalled                 int
+	getEncodersFuncExpected                             int
+	getEncodersFuncCalled                               int
+	getDefaultMediaTypesFuncExpected                    int
+	getDefaultMediaTypesFuncCalled                      int
+	getCandidatesFuncExpected                           int
+	getCandidatesFuncCalled                             int
+	parseAcceptRangeFuncExpected                        int
+	parseAcceptRangeFuncCalled                          int
+	parseAcceptFuncExpected                             int
+	parseAcceptFuncCalled                               int
+	getQualityFuncExpected                              int
+	getQualityFuncCalled                                int
+	negotiateFuncExpected                               int
+	negotiateFuncCalled                                 int
+	getEncoderFuncExpected                              int
+	getEncoderFuncCalled                                int
+	gzipNewWriterLevelExpected                          int
+	gzipNewWriterLevelCalled                            int
+	zlibNewWriterLevelExpected                          int
+	zlibNewWriterLevelCalled                            int
+	getEncodingQualityFuncExpected                      int
+	getEncodingQualityFuncCalled                        int
+	negotiateEncodingFuncExpected                       int
+	negotiateEncodingFuncCalled                         int
+	isCompressibleFuncExpected                          int
+	isCompressibleFuncCalled                            int
+	createCompressorFuncExpected                        int
+	createCompressorFuncCalled                          int
+	compressFuncExpected                                int
+	compressFuncCalled                                  int
+	compressResponseFuncExpected                        int
+	compressResponseFuncCalled                          int
+	sha256Sum256Expected                                int
+	sha256Sum256Called                                  int
+	hexEncodeToStringExpected                           int
+	hexEncodeToStringCalled                             int
+	httpParseTimeExpected                               int
+	httpParseTimeCalled                                 int
+	stringsTrimPrefixExpected                           int
+	stringsTrimPrefixCalled                             int
+	stringsHasPrefixExpected                            int
+	stringsHasPrefixCalled                              int
+	apperrorGetPreconditionFailedErrorExpected          int
+	apperrorGetPreconditionFailedErrorCalled            int
+	computeETagFuncExpected                             int
+	computeETagFuncCalled                               int
+	isETagMatchFuncExpected                             int
+	isETagMatchFuncCalled                               int
+	isNotModifiedFuncExpected                           int
+	isNotModifiedFuncCalled                             int
+	evaluateConditionalFuncExpected                     int
+	evaluateConditionalFuncCalled                       int
+	customizationCreateErrorResponseFuncExpected        int
+	customizationCreateErrorResponseFuncCalled          int
+	customizationCreateEncodedErrorResponseFuncExpected int
+	customizationCreateEncodedErrorResponseFuncCalled   int
+	customizationResponseEncodersExpected               int
+	customizationResponseCompressionExpected            int
+	customizationResponseCompressionCalled              int
+	customizationResponseETagExpected                   int
+	customizationResponseETagCalled                     int
+	customizationResponseEncodersCalled                 int
 )
 
 func createMock(t *testing.T) {
@@ -52,6 +146,23 @@ func createMock(t *testing.T) {
 		strconvItoaCalled++
 		return ""
 	}
+	fmtSprintfExpected = 0
+	fmtSprintfCalled = 0
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		return ""
+	}
+	stringsJoinExpected = 0
+	stringsJoinCalled = 0
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		return ""
+	}
+	sortStringsExpected = 0
+	sortStringsCalled = 0
+	sortStrings = func(x []string) {
+		sortStringsCalled++
+	}
 	jsonutilMarshalIgnoreErrorExpected = 0
 	jsonutilMarshalIgnoreErrorCalled = 0
 	jsonutilMarshalIgnoreError = func(v interface{}) string {
@@ -75,9 +186,14 @@ func createMock(t *testing.T) {
 		httpStatusTextCalled++
 		return ""
 	}
+	headerutilSetCorrelationIDHeaderExpected = 0
+	headerutilSetCorrelationIDHeaderCalled = 0
+	headerutilSetCorrelationIDHeader = func(session sessionModel.Session, header http.Header) {
+		headerutilSetCorrelationIDHeaderCalled++
+	}
 	writeResponseFuncExpected = 0
 	writeResponseFuncCalled = 0
-	writeResponseFunc = func(session sessionModel.Session, statusCode int, responseMessage string) {
+	writeResponseFunc = func(session sessionModel.Session, statusCode int, responseMessage string, contentType string) {
 		writeResponseFuncCalled++
 	}
 	getAppErrorFuncExpected = 0
@@ -94,30 +210,246 @@ func createMock(t *testing.T) {
 	}
 	createOkResponseFuncExpected = 0
 	createOkResponseFuncCalled = 0
-	createOkResponseFunc = func(responseContent interface{}) (string, int) {
+	createOkResponseFunc = func(responseContent interface{}, encoder model.Encoder) (string, int) {
 		createOkResponseFuncCalled++
 		return "", 0
 	}
 	createErrorResponseFuncExpected = 0
 	createErrorResponseFuncCalled = 0
-	createErrorResponseFunc = func(err error) (string, int) {
+	createErrorResponseFunc = func(err error, encoder model.Encoder) (string, int) {
 		createErrorResponseFuncCalled++
 		return "", 0
 	}
 	constructResponseFuncExpected = 0
 	constructResponseFuncCalled = 0
-	constructResponseFunc = func(responseObject interface{}, responseError error) (string, int) {
+	constructResponseFunc = func(responseObject interface{}, responseError error, encoder model.Encoder) (string, int) {
 		constructResponseFuncCalled++
 		return "", 0
 	}
+	fmtErrorfExpected = 0
+	fmtErrorfCalled = 0
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		return nil
+	}
+	stringsToLowerExpected = 0
+	stringsToLowerCalled = 0
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		return ""
+	}
+	stringsTrimSpaceExpected = 0
+	stringsTrimSpaceCalled = 0
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return ""
+	}
+	stringsSplitExpected = 0
+	stringsSplitCalled = 0
+	stringsSplit = func(s string, sep string) []string {
+		stringsSplitCalled++
+		return nil
+	}
+	stringsSplitNExpected = 0
+	stringsSplitNCalled = 0
+	stringsSplitN = func(s string, sep string, n int) []string {
+		stringsSplitNCalled++
+		return nil
+	}
+	strconvParseFloatExpected = 0
+	strconvParseFloatCalled = 0
+	strconvParseFloat = func(s string, bitSize int) (float64, error) {
+		strconvParseFloatCalled++
+		return 0, nil
+	}
+	apperrorGetNotAcceptableErrorExpected = 0
+	apperrorGetNotAcceptableErrorCalled = 0
+	apperrorGetNotAcceptableError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetNotAcceptableErrorCalled++
+		return nil
+	}
+	getEncodersFuncExpected = 0
+	getEncodersFuncCalled = 0
+	getEncodersFunc = func() map[string]model.Encoder {
+		getEncodersFuncCalled++
+		return nil
+	}
+	getDefaultMediaTypesFuncExpected = 0
+	getDefaultMediaTypesFuncCalled = 0
+	getDefaultMediaTypesFunc = func(encoders map[string]model.Encoder) []string {
+		getDefaultMediaTypesFuncCalled++
+		return nil
+	}
+	getCandidatesFuncExpected = 0
+	getCandidatesFuncCalled = 0
+	getCandidatesFunc = func(mediaTypes []string, encoders map[string]model.Encoder) []string {
+		getCandidatesFuncCalled++
+		return nil
+	}
+	parseAcceptRangeFuncExpected = 0
+	parseAcceptRangeFuncCalled = 0
+	parseAcceptRangeFunc = func(value string) (acceptRange, bool) {
+		parseAcceptRangeFuncCalled++
+		return acceptRange{}, false
+	}
+	parseAcceptFuncExpected = 0
+	parseAcceptFuncCalled = 0
+	parseAcceptFunc = func(acceptValue string) []acceptRange {
+		parseAcceptFuncCalled++
+		return nil
+	}
+	getQualityFuncExpected = 0
+	getQualityFuncCalled = 0
+	getQualityFunc = func(acceptRanges []acceptRange, mediaType string) float64 {
+		getQualityFuncCalled++
+		return 0
+	}
+	negotiateFuncExpected = 0
+	negotiateFuncCalled = 0
+	negotiateFunc = func(session sessionModel.Session, mediaTypes []string) (model.Encoder, error) {
+		negotiateFuncCalled++
+		return nil, nil
+	}
+	getEncoderFuncExpected = 0
+	getEncoderFuncCalled = 0
+	getEncoderFunc = func(session sessionModel.Session) (model.Encoder, error) {
+		getEncoderFuncCalled++
+		return nil, nil
+	}
+	gzipNewWriterLevelExpected = 0
+	gzipNewWriterLevelCalled = 0
+	gzipNewWriterLevel = func(w io.Writer, level int) (*gzip.Writer, error) {
+		gzipNewWriterLevelCalled++
+		return nil, nil
+	}
+	zlibNewWriterLevelExpected = 0
+	zlibNewWriterLevelCalled = 0
+	zlibNewWriterLevel = func(w io.Writer, level int) (*zlib.Writer, error) {
+		zlibNewWriterLevelCalled++
+		return nil, nil
+	}
+	getEncodingQualityFuncExpected = 0
+	getEncodingQualityFuncCalled = 0
+	getEncodingQualityFunc = func(acceptRanges []acceptRange, encoding string) float64 {
+		getEncodingQualityFuncCalled++
+		return 0
+	}
+	negotiateEncodingFuncExpected = 0
+	negotiateEncodingFuncCalled = 0
+	negotiateEncodingFunc = func(acceptEncodingValue string) string {
+		negotiateEncodingFuncCalled++
+		return ""
+	}
+	isCompressibleFuncExpected = 0
+	isCompressibleFuncCalled = 0
+	isCompressibleFunc = func(settings *model.Compression, contentType string) bool {
+		isCompressibleFuncCalled++
+		return false
+	}
+	createCompressorFuncExpected = 0
+	createCompressorFuncCalled = 0
+	createCompressorFunc = func(encoding string, level int, writer io.Writer) (io.WriteCloser, error) {
+		createCompressorFuncCalled++
+		return nil, nil
+	}
+	compressFuncExpected = 0
+	compressFuncCalled = 0
+	compressFunc = func(encoding string, level int, content []byte) ([]byte, error) {
+		compressFuncCalled++
+		return nil, nil
+	}
+	compressResponseFuncExpected = 0
+	compressResponseFuncCalled = 0
+	compressResponseFunc = func(httpRequest *http.Request, header http.Header, statusCode int, contentType string, responseMessage string) string {
+		compressResponseFuncCalled++
+		return ""
+	}
+	sha256Sum256Expected = 0
+	sha256Sum256Called = 0
+	sha256Sum256 = func(data []byte) [sha256.Size]byte {
+		sha256Sum256Called++
+		return [sha256.Size]byte{}
+	}
+	hexEncodeToStringExpected = 0
+	hexEncodeToStringCalled = 0
+	hexEncodeToString = func(src []byte) string {
+		hexEncodeToStringCalled++
+		return ""
+	}
+	httpParseTimeExpected = 0
+	httpParseTimeCalled = 0
+	httpParseTime = func(text string) (time.Time, error) {
+		httpParseTimeCalled++
+		return time.Time{}, nil
+	}
+	stringsTrimPrefixExpected = 0
+	stringsTrimPrefixCalled = 0
+	stringsTrimPrefix = func(s, prefix string) string {
+		stringsTrimPrefixCalled++
+		return ""
+	}
+	stringsHasPrefixExpected = 0
+	stringsHasPrefixCalled = 0
+	stringsHasPrefix = func(s, prefix string) bool {
+		stringsHasPrefixCalled++
+		return false
+	}
+	apperrorGetPreconditionFailedErrorExpected = 0
+	apperrorGetPreconditionFailedErrorCalled = 0
+	apperrorGetPreconditionFailedError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetPreconditionFailedErrorCalled++
+		return nil
+	}
+	computeETagFuncExpected = 0
+	computeETagFuncCalled = 0
+	computeETagFunc = func(responseMessage string, weak bool) string {
+		computeETagFuncCalled++
+		return ""
+	}
+	isETagMatchFuncExpected = 0
+	isETagMatchFuncCalled = 0
+	isETagMatchFunc = func(conditionValue string, entityTag string, weakComparison bool) bool {
+		isETagMatchFuncCalled++
+		return false
+	}
+	isNotModifiedFuncExpected = 0
+	isNotModifiedFuncCalled = 0
+	isNotModifiedFunc = func(httpRequest *http.Request, header http.Header) bool {
+		isNotModifiedFuncCalled++
+		return false
+	}
+	evaluateConditionalFuncExpected = 0
+	evaluateConditionalFuncCalled = 0
+	evaluateConditionalFunc = func(httpRequest *http.Request, header http.Header, statusCode int, responseMessage string) (string, int) {
+		evaluateConditionalFuncCalled++
+		return "", 0
+	}
 	customizationCreateErrorResponseFuncExpected = 0
 	customizationCreateErrorResponseFuncCalled = 0
 	customization.CreateErrorResponseFunc = nil
+	customizationCreateEncodedErrorResponseFuncExpected = 0
+	customizationCreateEncodedErrorResponseFuncCalled = 0
+	customization.CreateEncodedErrorResponseFunc = nil
+	customizationResponseEncodersExpected = 0
+	customizationResponseEncodersCalled = 0
+	customizationResponseCompressionExpected = 0
+	customizationResponseCompressionCalled = 0
+	customization.ResponseCompression = nil
+	customizationResponseETagExpected = 0
+	customizationResponseETagCalled = 0
+	customization.ResponseETag = nil
+	customization.ResponseEncoders = nil
 }
 
 func verifyAll(t *testing.T) {
 	strconvItoa = strconv.Itoa
 	assert.Equal(t, strconvItoaExpected, strconvItoaCalled, "Unexpected number of calls to strconvItoa")
+	fmtSprintf = fmt.Sprintf
+	assert.Equal(t, fmtSprintfExpected, fmtSprintfCalled, "Unexpected number of calls to fmtSprintf")
+	stringsJoin = strings.Join
+	assert.Equal(t, stringsJoinExpected, stringsJoinCalled, "Unexpected number of calls to stringsJoin")
+	sortStrings = sort.Strings
+	assert.Equal(t, sortStringsExpected, sortStringsCalled, "Unexpected number of calls to sortStrings")
 	jsonutilMarshalIgnoreError = jsonutil.MarshalIgnoreError
 	assert.Equal(t, jsonutilMarshalIgnoreErrorExpected, jsonutilMarshalIgnoreErrorCalled, "Unexpected number of calls to jsonutilMarshalIgnoreError")
 	apperrorGetGeneralFailureError = apperror.GetGeneralFailureError
@@ -126,6 +458,8 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, loggerAPIResponseExpected, loggerAPIResponseCalled, "Unexpected number of calls to loggerAPIResponse")
 	httpStatusText = http.StatusText
 	assert.Equal(t, httpStatusTextExpected, httpStatusTextCalled, "Unexpected number of calls to httpStatusText")
+	headerutilSetCorrelationIDHeader = headerutil.SetCorrelationIDHeader
+	assert.Equal(t, headerutilSetCorrelationIDHeaderExpected, headerutilSetCorrelationIDHeaderCalled, "Unexpected number of calls to headerutilSetCorrelationIDHeader")
 	writeResponseFunc = writeResponse
 	assert.Equal(t, writeResponseFuncExpected, writeResponseFuncCalled, "Unexpected number of calls to writeResponseFunc")
 	getAppErrorFunc = getAppError
@@ -138,11 +472,102 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, createErrorResponseFuncExpected, createErrorResponseFuncCalled, "Unexpected number of calls to createErrorResponseFunc")
 	constructResponseFunc = constructResponse
 	assert.Equal(t, constructResponseFuncExpected, constructResponseFuncCalled, "Unexpected number of calls to constructResponseFunc")
+	fmtErrorf = fmt.Errorf
+	assert.Equal(t, fmtErrorfExpected, fmtErrorfCalled, "Unexpected number of calls to fmtErrorf")
+	stringsToLower = strings.ToLower
+	assert.Equal(t, stringsToLowerExpected, stringsToLowerCalled, "Unexpected number of calls to stringsToLower")
+	stringsTrimSpace = strings.TrimSpace
+	assert.Equal(t, stringsTrimSpaceExpected, stringsTrimSpaceCalled, "Unexpected number of calls to stringsTrimSpace")
+	stringsSplit = strings.Split
+	assert.Equal(t, stringsSplitExpected, stringsSplitCalled, "Unexpected number of calls to stringsSplit")
+	stringsSplitN = strings.SplitN
+	assert.Equal(t, stringsSplitNExpected, stringsSplitNCalled, "Unexpected number of calls to stringsSplitN")
+	strconvParseFloat = strconv.ParseFloat
+	assert.Equal(t, strconvParseFloatExpected, strconvParseFloatCalled, "Unexpected number of calls to strconvParseFloat")
+	apperrorGetNotAcceptableError = apperror.GetNotAcceptableError
+	assert.Equal(t, apperrorGetNotAcceptableErrorExpected, apperrorGetNotAcceptableErrorCalled, "Unexpected number of calls to apperrorGetNotAcceptableError")
+	getEncodersFunc = getEncoders
+	assert.Equal(t, getEncodersFuncExpected, getEncodersFuncCalled, "Unexpected number of calls to getEncodersFunc")
+	getDefaultMediaTypesFunc = getDefaultMediaTypes
+	assert.Equal(t, getDefaultMediaTypesFuncExpected, getDefaultMediaTypesFuncCalled, "Unexpected number of calls to getDefaultMediaTypesFunc")
+	getCandidatesFunc = getCandidates
+	assert.Equal(t, getCandidatesFuncExpected, getCandidatesFuncCalled, "Unexpected number of calls to getCandidatesFunc")
+	parseAcceptRangeFunc = parseAcceptRange
+	assert.Equal(t, parseAcceptRangeFuncExpected, parseAcceptRangeFuncCalled, "Unexpected number of calls to parseAcceptRangeFunc")
+	parseAcceptFunc = parseAccept
+	assert.Equal(t, parseAcceptFuncExpected, parseAcceptFuncCalled, "Unexpected number of calls to parseAcceptFunc")
+	getQualityFunc = getQuality
+	assert.Equal(t, getQualityFuncExpected, getQualityFuncCalled, "Unexpected number of calls to getQualityFunc")
+	negotiateFunc = negotiate
+	assert.Equal(t, negotiateFuncExpected, negotiateFuncCalled, "Unexpected number of calls to negotiateFunc")
+	getEncoderFunc = getEncoder
+	assert.Equal(t, getEncoderFuncExpected, getEncoderFuncCalled, "Unexpected number of calls to getEncoderFunc")
+	gzipNewWriterLevel = gzip.NewWriterLevel
+	assert.Equal(t, gzipNewWriterLevelExpected, gzipNewWriterLevelCalled, "Unexpected number of calls to gzipNewWriterLevel")
+	zlibNewWriterLevel = zlib.NewWriterLevel
+	assert.Equal(t, zlibNewWriterLevelExpected, zlibNewWriterLevelCalled, "Unexpected number of calls to zlibNewWriterLevel")
+	getEncodingQualityFunc = getEncodingQuality
+	assert.Equal(t, getEncodingQualityFuncExpected, getEncodingQualityFuncCalled, "Unexpected number of calls to getEncodingQualityFunc")
+	negotiateEncodingFunc = negotiateEncoding
+	assert.Equal(t, negotiateEncodingFuncExpected, negotiateEncodingFuncCalled, "Unexpected number of calls to negotiateEncodingFunc")
+	isCompressibleFunc = isCompressible
+	assert.Equal(t, isCompressibleFuncExpected, isCompressibleFuncCalled, "Unexpected number of calls to isCompressibleFunc")
+	createCompressorFunc = createCompressor
+	assert.Equal(t, createCompressorFuncExpected, createCompressorFuncCalled, "Unexpected number of calls to createCompressorFunc")
+	compressFunc = compress
+	assert.Equal(t, compressFuncExpected, compressFuncCalled, "Unexpected number of calls to compressFunc")
+	compressResponseFunc = compressResponse
+	assert.Equal(t, compressResponseFuncExpected, compressResponseFuncCalled, "Unexpected number of calls to compressResponseFunc")
+	sha256Sum256 = sha256.Sum256
+	assert.Equal(t, sha256Sum256Expected, sha256Sum256Called, "Unexpected number of calls to sha256Sum256")
+	hexEncodeToString = hex.EncodeToString
+	assert.Equal(t, hexEncodeToStringExpected, hexEncodeToStringCalled, "Unexpected number of calls to hexEncodeToString")
+	httpParseTime = http.ParseTime
+	assert.Equal(t, httpParseTimeExpected, httpParseTimeCalled, "Unexpected number of calls to httpParseTime")
+	stringsTrimPrefix = strings.TrimPrefix
+	assert.Equal(t, stringsTrimPrefixExpected, stringsTrimPrefixCalled, "Unexpected number of calls to stringsTrimPrefix")
+	stringsHasPrefix = strings.HasPrefix
+	assert.Equal(t, stringsHasPrefixExpected, stringsHasPrefixCalled, "Unexpected number of calls to stringsHasPrefix")
+	apperrorGetPreconditionFailedError = apperror.GetPreconditionFailedError
+	assert.Equal(t, apperrorGetPreconditionFailedErrorExpected, apperrorGetPreconditionFailedErrorCalled, "Unexpected number of calls to apperrorGetPreconditionFailedError")
+	computeETagFunc = computeETag
+	assert.Equal(t, computeETagFuncExpected, computeETagFuncCalled, "Unexpected number of calls to computeETagFunc")
+	isETagMatchFunc = isETagMatch
+	assert.Equal(t, isETagMatchFuncExpected, isETagMatchFuncCalled, "Unexpected number of calls to isETagMatchFunc")
+	isNotModifiedFunc = isNotModified
+	assert.Equal(t, isNotModifiedFuncExpected, isNotModifiedFuncCalled, "Unexpected number of calls to isNotModifiedFunc")
+	evaluateConditionalFunc = evaluateConditional
+	assert.Equal(t, evaluateConditionalFuncExpected, evaluateConditionalFuncCalled, "Unexpected number of calls to evaluateConditionalFunc")
 	customization.CreateErrorResponseFunc = nil
 	assert.Equal(t, customizationCreateErrorResponseFuncExpected, customizationCreateErrorResponseFuncCalled, "Unexpected number of calls to customization.CreateErrorResponseFunc")
+	customization.CreateEncodedErrorResponseFunc = nil
+	assert.Equal(t, customizationCreateEncodedErrorResponseFuncExpected, customizationCreateEncodedErrorResponseFuncCalled, "Unexpected number of calls to customization.CreateEncodedErrorResponseFunc")
+	customization.ResponseEncoders = nil
+	assert.Equal(t, customizationResponseEncodersExpected, customizationResponseEncodersCalled, "Unexpected number of calls to customization.ResponseEncoders")
+	customization.ResponseCompression = nil
+	assert.Equal(t, customizationResponseCompressionExpected, customizationResponseCompressionCalled, "Unexpected number of calls to customization.ResponseCompression")
+	customization.ResponseETag = nil
+	assert.Equal(t, customizationResponseETagExpected, customizationResponseETagCalled, "Unexpected number of calls to customization.ResponseETag")
 }
 
 // mock structs
+type dummyEncoder struct {
+	t           *testing.T
+	contentType string
+	expected    interface{}
+	encoded     []byte
+	err         error
+}
+
+func (encoder *dummyEncoder) ContentType() string {
+	return encoder.contentType
+}
+
+func (encoder *dummyEncoder) Encode(responseObject interface{}) ([]byte, error) {
+	assert.Equal(encoder.t, encoder.expected, responseObject)
+	return encoder.encoded, encoder.err
+}
+
 type dummyResponseWriter struct {
 	t               *testing.T
 	expectedHeader  *http.Header
@@ -234,9 +659,12 @@ func (dae *dummyAppError) Attach(name string, value interface{}) {
 }
 
 type dummySession struct {
-	t              *testing.T
-	httpRequest    *http.Request
-	responseWriter *dummyResponseWriter
+	t               *testing.T
+	name            *string
+	httpRequest     *http.Request
+	allowNilRequest bool
+	responseWriter  *dummyResponseWriter
+	attachment      map[string]interface{}
 }
 
 func (session *dummySession) GetID() uuid.UUID {
@@ -245,12 +673,21 @@ func (session *dummySession) GetID() uuid.UUID {
 }
 
 func (session *dummySession) GetName() string {
-	assert.Fail(session.t, "Unexpected call to GetName")
+	if session.name == nil {
+		assert.Fail(session.t, "Unexpected call to GetName")
+		return ""
+	}
+	return *session.name
+}
+
+func (session *dummySession) GetCorrelationID() string {
+	assert.Fail(session.t, "Unexpected call to GetCorrelationID")
 	return ""
 }
 
 func (session *dummySession) GetRequest() *http.Request {
-	if session.httpRequest == nil {
+	if session.httpRequest == nil &&
+		!session.allowNilRequest {
 		assert.Fail(session.t, "Unexpected call to GetRequest")
 		return nil
 	}
@@ -265,6 +702,14 @@ func (session *dummySession) GetResponseWriter() http.ResponseWriter {
 	return session.responseWriter
 }
 
+func (session *dummySession) SetResponseETag(etag string, weak bool) {
+	assert.Fail(session.t, "Unexpected call to SetResponseETag")
+}
+
+func (session *dummySession) SetResponseLastModified(lastModified time.Time) {
+	assert.Fail(session.t, "Unexpected call to SetResponseLastModified")
+}
+
 func (session *dummySession) GetRequestBody(dataTemplate interface{}) apperrorModel.AppError {
 	assert.Fail(session.t, "Unexpected call to GetRequestBody")
 	return nil
@@ -295,9 +740,33 @@ func (session *dummySession) GetRequestHeaders(name string, dataTemplate interfa
 	return nil
 }
 
+func (session *dummySession) GetRequestFormValue(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValue")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValues(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValues")
+	return nil
+}
+
+func (session *dummySession) GetRequestFile(name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFile")
+	return nil
+}
+
+func (session *dummySession) BindRequest(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to BindRequest")
+	return nil
+}
+
 func (session *dummySession) Attach(name string, value interface{}) bool {
-	assert.Fail(session.t, "Unexpected call to Attach")
-	return false
+	if session.attachment == nil {
+		assert.Fail(session.t, "Unexpected call to Attach")
+		return false
+	}
+	session.attachment[name] = value
+	return true
 }
 
 func (session *dummySession) Detach(name string) bool {
@@ -306,8 +775,12 @@ func (session *dummySession) Detach(name string) bool {
 }
 
 func (session *dummySession) GetRawAttachment(name string) (interface{}, bool) {
-	assert.Fail(session.t, "Unexpected call to GetRawAttachment")
-	return nil, false
+	if session.attachment == nil {
+		assert.Fail(session.t, "Unexpected call to GetRawAttachment")
+		return nil, false
+	}
+	var value, found = session.attachment[name]
+	return value, found
 }
 
 func (session *dummySession) GetAttachment(name string, dataTemplate interface{}) bool {
@@ -0,0 +1,157 @@
+package response
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+	"net/http"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/response/model"
+)
+
+// These are the constants used for response compression
+const (
+	// DefaultCompressionMinSize is the minimum size in bytes of response bodies to be compressed if not customized
+	DefaultCompressionMinSize = 1024
+
+	acceptEncodingHeader  = "Accept-Encoding"
+	contentEncodingHeader = "Content-Encoding"
+	encodingGzip          = "gzip"
+	encodingDeflate       = "deflate"
+	wildcardEncoding      = "*"
+)
+
+// supportedEncodings lists the supported content codings in the order of preference
+var supportedEncodings = []string{
+	encodingGzip,
+	encodingDeflate,
+}
+
+func getEncodingQuality(acceptRanges []acceptRange, encoding string) float64 {
+	var quality float64
+	var isExact bool
+	for _, acceptRange := range acceptRanges {
+		if acceptRange.mediaType == encoding {
+			quality = acceptRange.quality
+			isExact = true
+		} else if acceptRange.mediaType == wildcardEncoding &&
+			!isExact {
+			quality = acceptRange.quality
+		}
+	}
+	return quality
+}
+
+func negotiateEncoding(acceptEncodingValue string) string {
+	var acceptRanges = parseAcceptFunc(
+		acceptEncodingValue,
+	)
+	var bestEncoding string
+	var bestQuality float64
+	for _, encoding := range supportedEncodings {
+		var quality = getEncodingQualityFunc(
+			acceptRanges,
+			encoding,
+		)
+		if quality > bestQuality {
+			bestEncoding = encoding
+			bestQuality = quality
+		}
+	}
+	return bestEncoding
+}
+
+func isCompressible(settings *model.Compression, contentType string) bool {
+	if len(settings.ContentTypes) == 0 {
+		return true
+	}
+	var mediaType = stringsToLower(
+		stringsTrimSpace(
+			stringsSplit(contentType, paramSeparator)[0],
+		),
+	)
+	for _, compressibleType := range settings.ContentTypes {
+		if stringsToLower(stringsTrimSpace(compressibleType)) == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+func createCompressor(encoding string, level int, writer io.Writer) (io.WriteCloser, error) {
+	if level < flate.BestSpeed ||
+		level > flate.BestCompression {
+		level = flate.DefaultCompression
+	}
+	if encoding == encodingGzip {
+		return gzipNewWriterLevel(writer, level)
+	}
+	return zlibNewWriterLevel(writer, level)
+}
+
+func compress(encoding string, level int, content []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	var compressor, compressorError = createCompressorFunc(
+		encoding,
+		level,
+		&buffer,
+	)
+	if compressorError != nil {
+		return nil, compressorError
+	}
+	var _, writeError = compressor.Write(content)
+	if writeError != nil {
+		return nil, writeError
+	}
+	var closeError = compressor.Close()
+	if closeError != nil {
+		return nil, closeError
+	}
+	return buffer.Bytes(), nil
+}
+
+// compressResponse compresses the given response message upon the Accept-Encoding header of the given request according to customization.ResponseCompression, setting the Content-Encoding and Vary response headers accordingly
+func compressResponse(
+	httpRequest *http.Request,
+	header http.Header,
+	statusCode int,
+	contentType string,
+	responseMessage string,
+) string {
+	if customization.ResponseCompression == nil ||
+		httpRequest == nil {
+		return responseMessage
+	}
+	var settings = customization.ResponseCompression()
+	if settings == nil ||
+		statusCode == http.StatusNoContent ||
+		statusCode == http.StatusNotModified ||
+		!isCompressibleFunc(settings, contentType) {
+		return responseMessage
+	}
+	header.Add("Vary", acceptEncodingHeader)
+	var minSize = settings.MinSize
+	if minSize <= 0 {
+		minSize = DefaultCompressionMinSize
+	}
+	if len(responseMessage) < minSize {
+		return responseMessage
+	}
+	var encoding = negotiateEncodingFunc(
+		httpRequest.Header.Get(acceptEncodingHeader),
+	)
+	if encoding == "" {
+		return responseMessage
+	}
+	var compressedBytes, compressError = compressFunc(
+		encoding,
+		settings.Level,
+		[]byte(responseMessage),
+	)
+	if compressError != nil {
+		return responseMessage
+	}
+	header.Set(contentEncodingHeader, encoding)
+	return string(compressedBytes)
+}
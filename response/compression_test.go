@@ -0,0 +1,880 @@
+package response
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/response/model"
+)
+
+func TestGetEncodingQuality(t *testing.T) {
+	// arrange
+	var dummyAcceptRanges = []acceptRange{
+		{mediaType: "gzip", quality: 0.8},
+		{mediaType: "*", quality: 0.3},
+		{mediaType: "br", quality: 1},
+	}
+	type testCase struct {
+		encoding string
+		quality  float64
+	}
+	var testCases = []testCase{
+		{encodingGzip, 0.8},
+		{encodingDeflate, 0.3},
+		{"br", 1},
+	}
+
+	for _, test := range testCases {
+		// mock
+		createMock(t)
+
+		// SUT + act
+		var result = getEncodingQuality(
+			dummyAcceptRanges,
+			test.encoding,
+		)
+
+		// assert
+		assert.Equal(t, test.quality, result, test.encoding)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestGetEncodingQuality_NoMatch(t *testing.T) {
+	// arrange
+	var dummyAcceptRanges = []acceptRange{
+		{mediaType: "br", quality: 1},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getEncodingQuality(
+		dummyAcceptRanges,
+		encodingGzip,
+	)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNegotiateEncoding_NoneAcceptable(t *testing.T) {
+	// arrange
+	var dummyAcceptEncodingValue = "some accept encoding value"
+	var dummyAcceptRanges = []acceptRange{
+		{mediaType: "br", quality: 1},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	parseAcceptFuncExpected = 1
+	parseAcceptFunc = func(acceptValue string) []acceptRange {
+		parseAcceptFuncCalled++
+		assert.Equal(t, dummyAcceptEncodingValue, acceptValue)
+		return dummyAcceptRanges
+	}
+	getEncodingQualityFuncExpected = 2
+	getEncodingQualityFunc = func(acceptRanges []acceptRange, encoding string) float64 {
+		getEncodingQualityFuncCalled++
+		assert.Equal(t, dummyAcceptRanges, acceptRanges)
+		return 0
+	}
+
+	// SUT + act
+	var result = negotiateEncoding(
+		dummyAcceptEncodingValue,
+	)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNegotiateEncoding_BestQuality(t *testing.T) {
+	// arrange
+	var dummyAcceptEncodingValue = "some accept encoding value"
+	var dummyAcceptRanges = []acceptRange{
+		{mediaType: "gzip", quality: 0.5},
+		{mediaType: "deflate", quality: 0.9},
+	}
+	var dummyQualities = map[string]float64{
+		encodingGzip:    0.5,
+		encodingDeflate: 0.9,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	parseAcceptFuncExpected = 1
+	parseAcceptFunc = func(acceptValue string) []acceptRange {
+		parseAcceptFuncCalled++
+		assert.Equal(t, dummyAcceptEncodingValue, acceptValue)
+		return dummyAcceptRanges
+	}
+	getEncodingQualityFuncExpected = 2
+	getEncodingQualityFunc = func(acceptRanges []acceptRange, encoding string) float64 {
+		getEncodingQualityFuncCalled++
+		assert.Equal(t, dummyAcceptRanges, acceptRanges)
+		return dummyQualities[encoding]
+	}
+
+	// SUT + act
+	var result = negotiateEncoding(
+		dummyAcceptEncodingValue,
+	)
+
+	// assert
+	assert.Equal(t, encodingDeflate, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNegotiateEncoding_TiePrefersGzip(t *testing.T) {
+	// arrange
+	var dummyAcceptEncodingValue = "some accept encoding value"
+	var dummyAcceptRanges = []acceptRange{
+		{mediaType: "*", quality: 1},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	parseAcceptFuncExpected = 1
+	parseAcceptFunc = func(acceptValue string) []acceptRange {
+		parseAcceptFuncCalled++
+		return dummyAcceptRanges
+	}
+	getEncodingQualityFuncExpected = 2
+	getEncodingQualityFunc = func(acceptRanges []acceptRange, encoding string) float64 {
+		getEncodingQualityFuncCalled++
+		return 1
+	}
+
+	// SUT + act
+	var result = negotiateEncoding(
+		dummyAcceptEncodingValue,
+	)
+
+	// assert
+	assert.Equal(t, encodingGzip, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsCompressible_NoContentTypes(t *testing.T) {
+	// arrange
+	var dummySettings = &model.Compression{}
+	var dummyContentType = "some content type"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isCompressible(
+		dummySettings,
+		dummyContentType,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsCompressible_NotMatched(t *testing.T) {
+	// arrange
+	var dummySettings = &model.Compression{
+		ContentTypes: []string{
+			MediaTypeJSON,
+			MediaTypeXML,
+		},
+	}
+	var dummyContentType = "image/png"
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsSplitExpected = 1
+	stringsSplit = func(s, sep string) []string {
+		stringsSplitCalled++
+		return strings.Split(s, sep)
+	}
+	stringsTrimSpaceExpected = 3
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	stringsToLowerExpected = 3
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		return strings.ToLower(s)
+	}
+
+	// SUT + act
+	var result = isCompressible(
+		dummySettings,
+		dummyContentType,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsCompressible_Matched(t *testing.T) {
+	// arrange
+	var dummySettings = &model.Compression{
+		ContentTypes: []string{
+			MediaTypeXML,
+			" Application/JSON ",
+		},
+	}
+	var dummyContentType = "application/json; charset=utf-8"
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsSplitExpected = 1
+	stringsSplit = func(s, sep string) []string {
+		stringsSplitCalled++
+		return strings.Split(s, sep)
+	}
+	stringsTrimSpaceExpected = 3
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	stringsToLowerExpected = 3
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		return strings.ToLower(s)
+	}
+
+	// SUT + act
+	var result = isCompressible(
+		dummySettings,
+		dummyContentType,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCreateCompressor_Gzip(t *testing.T) {
+	// arrange
+	var dummyLevel = flate.BestSpeed
+	var dummyWriter = &bytes.Buffer{}
+	var dummyGzipWriter = &gzip.Writer{}
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	gzipNewWriterLevelExpected = 1
+	gzipNewWriterLevel = func(w io.Writer, level int) (*gzip.Writer, error) {
+		gzipNewWriterLevelCalled++
+		assert.Equal(t, dummyWriter, w)
+		assert.Equal(t, dummyLevel, level)
+		return dummyGzipWriter, dummyError
+	}
+
+	// SUT + act
+	var result, err = createCompressor(
+		encodingGzip,
+		dummyLevel,
+		dummyWriter,
+	)
+
+	// assert
+	assert.Equal(t, dummyGzipWriter, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCreateCompressor_Deflate(t *testing.T) {
+	// arrange
+	var dummyLevel = flate.BestCompression + 1 + rand.Intn(100)
+	var dummyWriter = &bytes.Buffer{}
+	var dummyZlibWriter = &zlib.Writer{}
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	zlibNewWriterLevelExpected = 1
+	zlibNewWriterLevel = func(w io.Writer, level int) (*zlib.Writer, error) {
+		zlibNewWriterLevelCalled++
+		assert.Equal(t, dummyWriter, w)
+		assert.Equal(t, flate.DefaultCompression, level)
+		return dummyZlibWriter, dummyError
+	}
+
+	// SUT + act
+	var result, err = createCompressor(
+		encodingDeflate,
+		dummyLevel,
+		dummyWriter,
+	)
+
+	// assert
+	assert.Equal(t, dummyZlibWriter, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+type dummyCompressor struct {
+	t          *testing.T
+	writeError error
+	closeError error
+}
+
+func (compressor *dummyCompressor) Write(p []byte) (int, error) {
+	return len(p), compressor.writeError
+}
+
+func (compressor *dummyCompressor) Close() error {
+	return compressor.closeError
+}
+
+func TestCompress_CompressorError(t *testing.T) {
+	// arrange
+	var dummyLevel = rand.Intn(10)
+	var dummyContent = []byte("some content")
+	var dummyCompressorError = errors.New("some compressor error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	createCompressorFuncExpected = 1
+	createCompressorFunc = func(encoding string, level int, writer io.Writer) (io.WriteCloser, error) {
+		createCompressorFuncCalled++
+		assert.Equal(t, encodingGzip, encoding)
+		assert.Equal(t, dummyLevel, level)
+		return nil, dummyCompressorError
+	}
+
+	// SUT + act
+	var result, err = compress(
+		encodingGzip,
+		dummyLevel,
+		dummyContent,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyCompressorError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCompress_WriteError(t *testing.T) {
+	// arrange
+	var dummyLevel = rand.Intn(10)
+	var dummyContent = []byte("some content")
+	var dummyWriteError = errors.New("some write error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	createCompressorFuncExpected = 1
+	createCompressorFunc = func(encoding string, level int, writer io.Writer) (io.WriteCloser, error) {
+		createCompressorFuncCalled++
+		return &dummyCompressor{t: t, writeError: dummyWriteError}, nil
+	}
+
+	// SUT + act
+	var result, err = compress(
+		encodingGzip,
+		dummyLevel,
+		dummyContent,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyWriteError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCompress_CloseError(t *testing.T) {
+	// arrange
+	var dummyLevel = rand.Intn(10)
+	var dummyContent = []byte("some content")
+	var dummyCloseError = errors.New("some close error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	createCompressorFuncExpected = 1
+	createCompressorFunc = func(encoding string, level int, writer io.Writer) (io.WriteCloser, error) {
+		createCompressorFuncCalled++
+		return &dummyCompressor{t: t, closeError: dummyCloseError}, nil
+	}
+
+	// SUT + act
+	var result, err = compress(
+		encodingGzip,
+		dummyLevel,
+		dummyContent,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyCloseError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCompress_Gzip(t *testing.T) {
+	// arrange
+	var dummyContent = []byte(strings.Repeat("some content ", 100))
+
+	// mock
+	createMock(t)
+
+	// expect
+	createCompressorFuncExpected = 1
+	createCompressorFunc = func(encoding string, level int, writer io.Writer) (io.WriteCloser, error) {
+		createCompressorFuncCalled++
+		return gzip.NewWriterLevel(writer, level)
+	}
+
+	// SUT + act
+	var result, err = compress(
+		encodingGzip,
+		flate.DefaultCompression,
+		dummyContent,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Less(t, len(result), len(dummyContent))
+	var reader, readerError = gzip.NewReader(bytes.NewReader(result))
+	assert.NoError(t, readerError)
+	var decompressed, _ = ioutil.ReadAll(reader)
+	assert.Equal(t, dummyContent, decompressed)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCompress_Deflate(t *testing.T) {
+	// arrange
+	var dummyContent = []byte(strings.Repeat("some content ", 100))
+
+	// mock
+	createMock(t)
+
+	// expect
+	createCompressorFuncExpected = 1
+	createCompressorFunc = func(encoding string, level int, writer io.Writer) (io.WriteCloser, error) {
+		createCompressorFuncCalled++
+		return zlib.NewWriterLevel(writer, level)
+	}
+
+	// SUT + act
+	var result, err = compress(
+		encodingDeflate,
+		flate.DefaultCompression,
+		dummyContent,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Less(t, len(result), len(dummyContent))
+	var reader, readerError = zlib.NewReader(bytes.NewReader(result))
+	assert.NoError(t, readerError)
+	var decompressed, _ = ioutil.ReadAll(reader)
+	assert.Equal(t, dummyContent, decompressed)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCompressResponse_NoCustomization(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyHeader = http.Header{}
+	var dummyStatusCode = http.StatusOK
+	var dummyContentType = "some content type"
+	var dummyResponseMessage = "some response message"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = compressResponse(
+		dummyHTTPRequest,
+		dummyHeader,
+		dummyStatusCode,
+		dummyContentType,
+		dummyResponseMessage,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseMessage, result)
+	assert.Empty(t, dummyHeader)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCompressResponse_NilSettings(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyHeader = http.Header{}
+	var dummyStatusCode = http.StatusOK
+	var dummyContentType = "some content type"
+	var dummyResponseMessage = "some response message"
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationResponseCompressionExpected = 1
+	customization.ResponseCompression = func() *model.Compression {
+		customizationResponseCompressionCalled++
+		return nil
+	}
+
+	// SUT + act
+	var result = compressResponse(
+		dummyHTTPRequest,
+		dummyHeader,
+		dummyStatusCode,
+		dummyContentType,
+		dummyResponseMessage,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseMessage, result)
+	assert.Empty(t, dummyHeader)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCompressResponse_NoContent(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyHeader = http.Header{}
+	var dummyContentType = "some content type"
+	var dummyResponseMessage = "some response message"
+
+	for _, statusCode := range []int{http.StatusNoContent, http.StatusNotModified} {
+		// mock
+		createMock(t)
+
+		// expect
+		customizationResponseCompressionExpected = 1
+		customization.ResponseCompression = func() *model.Compression {
+			customizationResponseCompressionCalled++
+			return &model.Compression{}
+		}
+
+		// SUT + act
+		var result = compressResponse(
+			dummyHTTPRequest,
+			dummyHeader,
+			statusCode,
+			dummyContentType,
+			dummyResponseMessage,
+		)
+
+		// assert
+		assert.Equal(t, dummyResponseMessage, result)
+		assert.Empty(t, dummyHeader)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestCompressResponse_NotCompressible(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyHeader = http.Header{}
+	var dummyStatusCode = http.StatusOK
+	var dummyContentType = "some content type"
+	var dummyResponseMessage = "some response message"
+	var dummySettings = &model.Compression{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationResponseCompressionExpected = 1
+	customization.ResponseCompression = func() *model.Compression {
+		customizationResponseCompressionCalled++
+		return dummySettings
+	}
+	isCompressibleFuncExpected = 1
+	isCompressibleFunc = func(settings *model.Compression, contentType string) bool {
+		isCompressibleFuncCalled++
+		assert.Equal(t, dummySettings, settings)
+		assert.Equal(t, dummyContentType, contentType)
+		return false
+	}
+
+	// SUT + act
+	var result = compressResponse(
+		dummyHTTPRequest,
+		dummyHeader,
+		dummyStatusCode,
+		dummyContentType,
+		dummyResponseMessage,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseMessage, result)
+	assert.Empty(t, dummyHeader)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCompressResponse_BelowMinSize(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyHeader = http.Header{}
+	var dummyStatusCode = http.StatusOK
+	var dummyContentType = "some content type"
+	var dummyResponseMessage = strings.Repeat("a", DefaultCompressionMinSize-1)
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationResponseCompressionExpected = 1
+	customization.ResponseCompression = func() *model.Compression {
+		customizationResponseCompressionCalled++
+		return &model.Compression{}
+	}
+	isCompressibleFuncExpected = 1
+	isCompressibleFunc = func(settings *model.Compression, contentType string) bool {
+		isCompressibleFuncCalled++
+		return true
+	}
+
+	// SUT + act
+	var result = compressResponse(
+		dummyHTTPRequest,
+		dummyHeader,
+		dummyStatusCode,
+		dummyContentType,
+		dummyResponseMessage,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseMessage, result)
+	assert.Equal(t, acceptEncodingHeader, dummyHeader.Get("Vary"))
+	assert.Empty(t, dummyHeader.Get(contentEncodingHeader))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCompressResponse_NoEncodingAccepted(t *testing.T) {
+	// arrange
+	var dummyAcceptEncoding = "some accept encoding"
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{
+			acceptEncodingHeader: []string{dummyAcceptEncoding},
+		},
+	}
+	var dummyHeader = http.Header{}
+	var dummyStatusCode = http.StatusOK
+	var dummyContentType = "some content type"
+	var dummyResponseMessage = "some response message"
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationResponseCompressionExpected = 1
+	customization.ResponseCompression = func() *model.Compression {
+		customizationResponseCompressionCalled++
+		return &model.Compression{MinSize: 1}
+	}
+	isCompressibleFuncExpected = 1
+	isCompressibleFunc = func(settings *model.Compression, contentType string) bool {
+		isCompressibleFuncCalled++
+		return true
+	}
+	negotiateEncodingFuncExpected = 1
+	negotiateEncodingFunc = func(acceptEncodingValue string) string {
+		negotiateEncodingFuncCalled++
+		assert.Equal(t, dummyAcceptEncoding, acceptEncodingValue)
+		return ""
+	}
+
+	// SUT + act
+	var result = compressResponse(
+		dummyHTTPRequest,
+		dummyHeader,
+		dummyStatusCode,
+		dummyContentType,
+		dummyResponseMessage,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseMessage, result)
+	assert.Equal(t, acceptEncodingHeader, dummyHeader.Get("Vary"))
+	assert.Empty(t, dummyHeader.Get(contentEncodingHeader))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCompressResponse_CompressError(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyHeader = http.Header{}
+	var dummyStatusCode = http.StatusOK
+	var dummyContentType = "some content type"
+	var dummyResponseMessage = "some response message"
+	var dummyLevel = rand.Intn(10)
+	var dummyCompressError = errors.New("some compress error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationResponseCompressionExpected = 1
+	customization.ResponseCompression = func() *model.Compression {
+		customizationResponseCompressionCalled++
+		return &model.Compression{MinSize: 1, Level: dummyLevel}
+	}
+	isCompressibleFuncExpected = 1
+	isCompressibleFunc = func(settings *model.Compression, contentType string) bool {
+		isCompressibleFuncCalled++
+		return true
+	}
+	negotiateEncodingFuncExpected = 1
+	negotiateEncodingFunc = func(acceptEncodingValue string) string {
+		negotiateEncodingFuncCalled++
+		return encodingGzip
+	}
+	compressFuncExpected = 1
+	compressFunc = func(encoding string, level int, content []byte) ([]byte, error) {
+		compressFuncCalled++
+		assert.Equal(t, encodingGzip, encoding)
+		assert.Equal(t, dummyLevel, level)
+		assert.Equal(t, []byte(dummyResponseMessage), content)
+		return nil, dummyCompressError
+	}
+
+	// SUT + act
+	var result = compressResponse(
+		dummyHTTPRequest,
+		dummyHeader,
+		dummyStatusCode,
+		dummyContentType,
+		dummyResponseMessage,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseMessage, result)
+	assert.Empty(t, dummyHeader.Get(contentEncodingHeader))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCompressResponse_Success(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyHeader = http.Header{}
+	var dummyStatusCode = http.StatusOK
+	var dummyContentType = "some content type"
+	var dummyResponseMessage = "some response message"
+	var dummyCompressedBytes = []byte("some compressed bytes")
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationResponseCompressionExpected = 1
+	customization.ResponseCompression = func() *model.Compression {
+		customizationResponseCompressionCalled++
+		return &model.Compression{MinSize: 1}
+	}
+	isCompressibleFuncExpected = 1
+	isCompressibleFunc = func(settings *model.Compression, contentType string) bool {
+		isCompressibleFuncCalled++
+		return true
+	}
+	negotiateEncodingFuncExpected = 1
+	negotiateEncodingFunc = func(acceptEncodingValue string) string {
+		negotiateEncodingFuncCalled++
+		return encodingDeflate
+	}
+	compressFuncExpected = 1
+	compressFunc = func(encoding string, level int, content []byte) ([]byte, error) {
+		compressFuncCalled++
+		assert.Equal(t, encodingDeflate, encoding)
+		return dummyCompressedBytes, nil
+	}
+
+	// SUT + act
+	var result = compressResponse(
+		dummyHTTPRequest,
+		dummyHeader,
+		dummyStatusCode,
+		dummyContentType,
+		dummyResponseMessage,
+	)
+
+	// assert
+	assert.Equal(t, string(dummyCompressedBytes), result)
+	assert.Equal(t, acceptEncodingHeader, dummyHeader.Get("Vary"))
+	assert.Equal(t, encodingDeflate, dummyHeader.Get(contentEncodingHeader))
+
+	// verify
+	verifyAll(t)
+}
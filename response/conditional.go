@@ -0,0 +1,150 @@
+package response
+
+import (
+	"net/http"
+
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+// These are the constants used for conditional requests
+const (
+	eTagHeader            = "ETag"
+	lastModifiedHeader    = "Last-Modified"
+	ifNoneMatchHeader     = "If-None-Match"
+	ifModifiedSinceHeader = "If-Modified-Since"
+	ifMatchHeader         = "If-Match"
+	weakETagPrefix        = "W/"
+	wildcardETag          = "*"
+	eTagLength            = 16
+)
+
+func computeETag(responseMessage string, weak bool) string {
+	var checksum = sha256Sum256(
+		[]byte(responseMessage),
+	)
+	var entityTag = fmtSprintf(
+		"\"%v\"",
+		hexEncodeToString(checksum[:eTagLength]),
+	)
+	if weak {
+		entityTag = weakETagPrefix + entityTag
+	}
+	return entityTag
+}
+
+// isETagMatch checks whether the given entity tag matches any of the comma-separated entity tags in the condition value, using weak comparison (ignoring the weak indicator) or strong comparison (both must be strong) as specified
+func isETagMatch(conditionValue string, entityTag string, weakComparison bool) bool {
+	if entityTag == "" {
+		return false
+	}
+	for _, value := range stringsSplit(conditionValue, rangeSeparator) {
+		var conditionTag = stringsTrimSpace(value)
+		if conditionTag == wildcardETag {
+			return true
+		}
+		if weakComparison {
+			if stringsTrimPrefix(conditionTag, weakETagPrefix) ==
+				stringsTrimPrefix(entityTag, weakETagPrefix) {
+				return true
+			}
+		} else if conditionTag == entityTag &&
+			!stringsHasPrefix(entityTag, weakETagPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNotModified(httpRequest *http.Request, header http.Header) bool {
+	var ifNoneMatch = httpRequest.Header.Get(ifNoneMatchHeader)
+	if ifNoneMatch != "" {
+		return isETagMatchFunc(
+			ifNoneMatch,
+			header.Get(eTagHeader),
+			true,
+		)
+	}
+	var ifModifiedSince = httpRequest.Header.Get(ifModifiedSinceHeader)
+	var lastModified = header.Get(lastModifiedHeader)
+	if ifModifiedSince == "" ||
+		lastModified == "" {
+		return false
+	}
+	var sinceTime, sinceError = httpParseTime(ifModifiedSince)
+	if sinceError != nil {
+		return false
+	}
+	var modifiedTime, modifiedError = httpParseTime(lastModified)
+	if modifiedError != nil {
+		return false
+	}
+	return !modifiedTime.After(sinceTime)
+}
+
+// evaluateConditional sets the entity tag computed over the response body after content coding upon customization.ResponseETag unless supplied through session, so that differently encoded representations never share a strong entity tag, and evaluates the If-None-Match and If-Modified-Since headers of successful GET and HEAD requests, turning the response into NotModified (304) without body if not modified
+func evaluateConditional(
+	httpRequest *http.Request,
+	header http.Header,
+	statusCode int,
+	responseMessage string,
+) (string, int) {
+	if httpRequest == nil ||
+		statusCode != http.StatusOK ||
+		(httpRequest.Method != http.MethodGet &&
+			httpRequest.Method != http.MethodHead) {
+		return responseMessage, statusCode
+	}
+	if header.Get(eTagHeader) == "" &&
+		customization.ResponseETag != nil {
+		var settings = customization.ResponseETag()
+		if settings != nil {
+			header.Set(
+				eTagHeader,
+				computeETagFunc(
+					responseMessage,
+					settings.Weak,
+				),
+			)
+		}
+	}
+	if !isNotModifiedFunc(httpRequest, header) {
+		return responseMessage, statusCode
+	}
+	return "", http.StatusNotModified
+}
+
+// CheckIfMatch evaluates the If-Match header of PUT and PATCH requests against the given current entity tag (opaque value without quotes) of the target resource, or empty if it does not exist, and returns a PreconditionFailed error if not matched; other requests or requests without If-Match header always pass
+func CheckIfMatch(
+	session sessionModel.Session,
+	etag string,
+) apperrorModel.AppError {
+	var httpRequest = session.GetRequest()
+	if httpRequest == nil ||
+		(httpRequest.Method != http.MethodPut &&
+			httpRequest.Method != http.MethodPatch) {
+		return nil
+	}
+	var ifMatch = httpRequest.Header.Get(ifMatchHeader)
+	if ifMatch == "" {
+		return nil
+	}
+	var entityTag string
+	if etag != "" {
+		entityTag = fmtSprintf(
+			"\"%v\"",
+			etag,
+		)
+	}
+	if isETagMatchFunc(ifMatch, entityTag, false) {
+		return nil
+	}
+	return apperrorGetPreconditionFailedError(
+		fmtErrorf(
+			"Entity tag [%v] does not match If-Match header [%v]",
+			entityTag,
+			ifMatch,
+		),
+	)
+}
@@ -0,0 +1,965 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/response/model"
+)
+
+func TestComputeETag_Strong(t *testing.T) {
+	// arrange
+	var dummyResponseMessage = "some response message"
+	var dummyChecksum = sha256.Sum256([]byte(dummyResponseMessage))
+	var expectedResult = "\"" + hex.EncodeToString(dummyChecksum[:16]) + "\""
+
+	// mock
+	createMock(t)
+
+	// expect
+	sha256Sum256Expected = 1
+	sha256Sum256 = func(data []byte) [sha256.Size]byte {
+		sha256Sum256Called++
+		assert.Equal(t, []byte(dummyResponseMessage), data)
+		return sha256.Sum256(data)
+	}
+	hexEncodeToStringExpected = 1
+	hexEncodeToString = func(src []byte) string {
+		hexEncodeToStringCalled++
+		assert.Equal(t, 16, len(src))
+		return hex.EncodeToString(src)
+	}
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		return fmt.Sprintf(format, a...)
+	}
+
+	// SUT + act
+	var result = computeETag(
+		dummyResponseMessage,
+		false,
+	)
+
+	// assert
+	assert.Equal(t, expectedResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestComputeETag_Weak(t *testing.T) {
+	// arrange
+	var dummyResponseMessage = "some response message"
+	var dummyChecksum = sha256.Sum256([]byte(dummyResponseMessage))
+	var expectedResult = "W/\"" + hex.EncodeToString(dummyChecksum[:16]) + "\""
+
+	// mock
+	createMock(t)
+
+	// expect
+	sha256Sum256Expected = 1
+	sha256Sum256 = func(data []byte) [sha256.Size]byte {
+		sha256Sum256Called++
+		return sha256.Sum256(data)
+	}
+	hexEncodeToStringExpected = 1
+	hexEncodeToString = func(src []byte) string {
+		hexEncodeToStringCalled++
+		return hex.EncodeToString(src)
+	}
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		return fmt.Sprintf(format, a...)
+	}
+
+	// SUT + act
+	var result = computeETag(
+		dummyResponseMessage,
+		true,
+	)
+
+	// assert
+	assert.Equal(t, expectedResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsETagMatch_EmptyEntityTag(t *testing.T) {
+	// arrange
+	var dummyConditionValue = "*"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isETagMatch(
+		dummyConditionValue,
+		"",
+		true,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsETagMatch_Wildcard(t *testing.T) {
+	// arrange
+	var dummyConditionValue = "\"some other etag\", *"
+	var dummyEntityTag = "\"some etag\""
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsSplitExpected = 1
+	stringsSplit = func(s, sep string) []string {
+		stringsSplitCalled++
+		assert.Equal(t, dummyConditionValue, s)
+		assert.Equal(t, ",", sep)
+		return strings.Split(s, sep)
+	}
+	stringsTrimSpaceExpected = 2
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+
+	// SUT + act
+	var result = isETagMatch(
+		dummyConditionValue,
+		dummyEntityTag,
+		false,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsETagMatch_WeakComparison_Matched(t *testing.T) {
+	// arrange
+	var dummyConditionValue = "W/\"some etag\""
+	var dummyEntityTag = "\"some etag\""
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsSplitExpected = 1
+	stringsSplit = func(s, sep string) []string {
+		stringsSplitCalled++
+		return strings.Split(s, sep)
+	}
+	stringsTrimSpaceExpected = 1
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	stringsTrimPrefixExpected = 2
+	stringsTrimPrefix = func(s, prefix string) string {
+		stringsTrimPrefixCalled++
+		assert.Equal(t, "W/", prefix)
+		return strings.TrimPrefix(s, prefix)
+	}
+
+	// SUT + act
+	var result = isETagMatch(
+		dummyConditionValue,
+		dummyEntityTag,
+		true,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsETagMatch_WeakComparison_NotMatched(t *testing.T) {
+	// arrange
+	var dummyConditionValue = "\"some etag 1\", W/\"some etag 2\""
+	var dummyEntityTag = "W/\"some etag\""
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsSplitExpected = 1
+	stringsSplit = func(s, sep string) []string {
+		stringsSplitCalled++
+		return strings.Split(s, sep)
+	}
+	stringsTrimSpaceExpected = 2
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	stringsTrimPrefixExpected = 4
+	stringsTrimPrefix = func(s, prefix string) string {
+		stringsTrimPrefixCalled++
+		return strings.TrimPrefix(s, prefix)
+	}
+
+	// SUT + act
+	var result = isETagMatch(
+		dummyConditionValue,
+		dummyEntityTag,
+		true,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsETagMatch_StrongComparison_Matched(t *testing.T) {
+	// arrange
+	var dummyConditionValue = " \"some etag\" "
+	var dummyEntityTag = "\"some etag\""
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsSplitExpected = 1
+	stringsSplit = func(s, sep string) []string {
+		stringsSplitCalled++
+		return strings.Split(s, sep)
+	}
+	stringsTrimSpaceExpected = 1
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	stringsHasPrefixExpected = 1
+	stringsHasPrefix = func(s, prefix string) bool {
+		stringsHasPrefixCalled++
+		assert.Equal(t, dummyEntityTag, s)
+		assert.Equal(t, "W/", prefix)
+		return strings.HasPrefix(s, prefix)
+	}
+
+	// SUT + act
+	var result = isETagMatch(
+		dummyConditionValue,
+		dummyEntityTag,
+		false,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsETagMatch_StrongComparison_WeakEntityTag(t *testing.T) {
+	// arrange
+	var dummyConditionValue = "W/\"some etag\""
+	var dummyEntityTag = "W/\"some etag\""
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsSplitExpected = 1
+	stringsSplit = func(s, sep string) []string {
+		stringsSplitCalled++
+		return strings.Split(s, sep)
+	}
+	stringsTrimSpaceExpected = 1
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	stringsHasPrefixExpected = 1
+	stringsHasPrefix = func(s, prefix string) bool {
+		stringsHasPrefixCalled++
+		return strings.HasPrefix(s, prefix)
+	}
+
+	// SUT + act
+	var result = isETagMatch(
+		dummyConditionValue,
+		dummyEntityTag,
+		false,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsNotModified_IfNoneMatch(t *testing.T) {
+	// arrange
+	var dummyIfNoneMatch = "some if none match"
+	var dummyETag = "some etag"
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+	dummyHTTPRequest.Header.Set("If-None-Match", dummyIfNoneMatch)
+	dummyHTTPRequest.Header.Set("If-Modified-Since", "some if modified since")
+	var dummyHeader = http.Header{}
+	dummyHeader.Set("ETag", dummyETag)
+
+	// mock
+	createMock(t)
+
+	// expect
+	isETagMatchFuncExpected = 1
+	isETagMatchFunc = func(conditionValue string, entityTag string, weakComparison bool) bool {
+		isETagMatchFuncCalled++
+		assert.Equal(t, dummyIfNoneMatch, conditionValue)
+		assert.Equal(t, dummyETag, entityTag)
+		assert.True(t, weakComparison)
+		return true
+	}
+
+	// SUT + act
+	var result = isNotModified(
+		dummyHTTPRequest,
+		dummyHeader,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsNotModified_NoIfModifiedSince(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+	var dummyHeader = http.Header{}
+	dummyHeader.Set("Last-Modified", "some last modified")
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isNotModified(
+		dummyHTTPRequest,
+		dummyHeader,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsNotModified_NoLastModified(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+	dummyHTTPRequest.Header.Set("If-Modified-Since", "some if modified since")
+	var dummyHeader = http.Header{}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isNotModified(
+		dummyHTTPRequest,
+		dummyHeader,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsNotModified_InvalidIfModifiedSince(t *testing.T) {
+	// arrange
+	var dummyIfModifiedSince = "some if modified since"
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+	dummyHTTPRequest.Header.Set("If-Modified-Since", dummyIfModifiedSince)
+	var dummyHeader = http.Header{}
+	dummyHeader.Set("Last-Modified", "some last modified")
+
+	// mock
+	createMock(t)
+
+	// expect
+	httpParseTimeExpected = 1
+	httpParseTime = func(text string) (time.Time, error) {
+		httpParseTimeCalled++
+		assert.Equal(t, dummyIfModifiedSince, text)
+		return time.Time{}, errors.New("some parse error")
+	}
+
+	// SUT + act
+	var result = isNotModified(
+		dummyHTTPRequest,
+		dummyHeader,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsNotModified_InvalidLastModified(t *testing.T) {
+	// arrange
+	var dummyIfModifiedSince = "some if modified since"
+	var dummyLastModified = "some last modified"
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+	dummyHTTPRequest.Header.Set("If-Modified-Since", dummyIfModifiedSince)
+	var dummyHeader = http.Header{}
+	dummyHeader.Set("Last-Modified", dummyLastModified)
+
+	// mock
+	createMock(t)
+
+	// expect
+	httpParseTimeExpected = 2
+	httpParseTime = func(text string) (time.Time, error) {
+		httpParseTimeCalled++
+		if httpParseTimeCalled == 1 {
+			assert.Equal(t, dummyIfModifiedSince, text)
+			return time.Now(), nil
+		}
+		assert.Equal(t, dummyLastModified, text)
+		return time.Time{}, errors.New("some parse error")
+	}
+
+	// SUT + act
+	var result = isNotModified(
+		dummyHTTPRequest,
+		dummyHeader,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsNotModified_Modified(t *testing.T) {
+	// arrange
+	var dummySinceTime = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+	dummyHTTPRequest.Header.Set("If-Modified-Since", dummySinceTime.Format(http.TimeFormat))
+	var dummyHeader = http.Header{}
+	dummyHeader.Set("Last-Modified", dummySinceTime.Add(time.Second).Format(http.TimeFormat))
+
+	// mock
+	createMock(t)
+
+	// expect
+	httpParseTimeExpected = 2
+	httpParseTime = func(text string) (time.Time, error) {
+		httpParseTimeCalled++
+		return http.ParseTime(text)
+	}
+
+	// SUT + act
+	var result = isNotModified(
+		dummyHTTPRequest,
+		dummyHeader,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsNotModified_NotModified(t *testing.T) {
+	// arrange
+	var dummySinceTime = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+	dummyHTTPRequest.Header.Set("If-Modified-Since", dummySinceTime.Format(http.TimeFormat))
+	var dummyHeader = http.Header{}
+	dummyHeader.Set("Last-Modified", dummySinceTime.Format(http.TimeFormat))
+
+	// mock
+	createMock(t)
+
+	// expect
+	httpParseTimeExpected = 2
+	httpParseTime = func(text string) (time.Time, error) {
+		httpParseTimeCalled++
+		return http.ParseTime(text)
+	}
+
+	// SUT + act
+	var result = isNotModified(
+		dummyHTTPRequest,
+		dummyHeader,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEvaluateConditional_NilRequest(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{}
+	var dummyResponseMessage = "some response message"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, statusCode = evaluateConditional(
+		nil,
+		dummyHeader,
+		http.StatusOK,
+		dummyResponseMessage,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseMessage, result)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Empty(t, dummyHeader)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEvaluateConditional_NotOK(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method: http.MethodGet,
+	}
+	var dummyHeader = http.Header{}
+	var dummyResponseMessage = "some response message"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, statusCode = evaluateConditional(
+		dummyHTTPRequest,
+		dummyHeader,
+		http.StatusNotFound,
+		dummyResponseMessage,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseMessage, result)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	assert.Empty(t, dummyHeader)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEvaluateConditional_NotGetOrHead(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method: http.MethodPost,
+	}
+	var dummyHeader = http.Header{}
+	var dummyResponseMessage = "some response message"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, statusCode = evaluateConditional(
+		dummyHTTPRequest,
+		dummyHeader,
+		http.StatusOK,
+		dummyResponseMessage,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseMessage, result)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Empty(t, dummyHeader)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEvaluateConditional_ETagSupplied(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method: http.MethodGet,
+	}
+	var dummyETag = "some etag"
+	var dummyHeader = http.Header{}
+	dummyHeader.Set("ETag", dummyETag)
+	var dummyResponseMessage = "some response message"
+
+	// mock
+	createMock(t)
+
+	// expect
+	customization.ResponseETag = func() *model.ETag {
+		customizationResponseETagCalled++
+		return &model.ETag{}
+	}
+	isNotModifiedFuncExpected = 1
+	isNotModifiedFunc = func(httpRequest *http.Request, header http.Header) bool {
+		isNotModifiedFuncCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyHeader, header)
+		return false
+	}
+
+	// SUT + act
+	var result, statusCode = evaluateConditional(
+		dummyHTTPRequest,
+		dummyHeader,
+		http.StatusOK,
+		dummyResponseMessage,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseMessage, result)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, dummyETag, dummyHeader.Get("ETag"))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEvaluateConditional_NoCustomization(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method: http.MethodHead,
+	}
+	var dummyHeader = http.Header{}
+	var dummyResponseMessage = "some response message"
+
+	// mock
+	createMock(t)
+
+	// expect
+	isNotModifiedFuncExpected = 1
+	isNotModifiedFunc = func(httpRequest *http.Request, header http.Header) bool {
+		isNotModifiedFuncCalled++
+		return false
+	}
+
+	// SUT + act
+	var result, statusCode = evaluateConditional(
+		dummyHTTPRequest,
+		dummyHeader,
+		http.StatusOK,
+		dummyResponseMessage,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseMessage, result)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Empty(t, dummyHeader.Get("ETag"))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEvaluateConditional_NilSettings(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method: http.MethodGet,
+	}
+	var dummyHeader = http.Header{}
+	var dummyResponseMessage = "some response message"
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationResponseETagExpected = 1
+	customization.ResponseETag = func() *model.ETag {
+		customizationResponseETagCalled++
+		return nil
+	}
+	isNotModifiedFuncExpected = 1
+	isNotModifiedFunc = func(httpRequest *http.Request, header http.Header) bool {
+		isNotModifiedFuncCalled++
+		return false
+	}
+
+	// SUT + act
+	var result, statusCode = evaluateConditional(
+		dummyHTTPRequest,
+		dummyHeader,
+		http.StatusOK,
+		dummyResponseMessage,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseMessage, result)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Empty(t, dummyHeader.Get("ETag"))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEvaluateConditional_NotModified(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method: http.MethodGet,
+	}
+	var dummyHeader = http.Header{}
+	var dummyResponseMessage = "some response message"
+	var dummyETag = "some etag"
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationResponseETagExpected = 1
+	customization.ResponseETag = func() *model.ETag {
+		customizationResponseETagCalled++
+		return &model.ETag{Weak: true}
+	}
+	computeETagFuncExpected = 1
+	computeETagFunc = func(responseMessage string, weak bool) string {
+		computeETagFuncCalled++
+		assert.Equal(t, dummyResponseMessage, responseMessage)
+		assert.True(t, weak)
+		return dummyETag
+	}
+	isNotModifiedFuncExpected = 1
+	isNotModifiedFunc = func(httpRequest *http.Request, header http.Header) bool {
+		isNotModifiedFuncCalled++
+		assert.Equal(t, dummyETag, header.Get("ETag"))
+		return true
+	}
+
+	// SUT + act
+	var result, statusCode = evaluateConditional(
+		dummyHTTPRequest,
+		dummyHeader,
+		http.StatusOK,
+		dummyResponseMessage,
+	)
+
+	// assert
+	assert.Zero(t, result)
+	assert.Equal(t, http.StatusNotModified, statusCode)
+	assert.Equal(t, dummyETag, dummyHeader.Get("ETag"))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheckIfMatch_NilRequest(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{
+		t:               t,
+		allowNilRequest: true,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err = CheckIfMatch(
+		dummySessionObject,
+		"some etag",
+	)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheckIfMatch_NotPutOrPatch(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method: http.MethodGet,
+		Header: http.Header{},
+	}
+	dummyHTTPRequest.Header.Set("If-Match", "some if match")
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err = CheckIfMatch(
+		dummySessionObject,
+		"some etag",
+	)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheckIfMatch_NoIfMatch(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method: http.MethodPut,
+		Header: http.Header{},
+	}
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err = CheckIfMatch(
+		dummySessionObject,
+		"some etag",
+	)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheckIfMatch_Matched(t *testing.T) {
+	// arrange
+	var dummyIfMatch = "some if match"
+	var dummyHTTPRequest = &http.Request{
+		Method: http.MethodPatch,
+		Header: http.Header{},
+	}
+	dummyHTTPRequest.Header.Set("If-Match", dummyIfMatch)
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		return fmt.Sprintf(format, a...)
+	}
+	isETagMatchFuncExpected = 1
+	isETagMatchFunc = func(conditionValue string, entityTag string, weakComparison bool) bool {
+		isETagMatchFuncCalled++
+		assert.Equal(t, dummyIfMatch, conditionValue)
+		assert.Equal(t, "\"some etag\"", entityTag)
+		assert.False(t, weakComparison)
+		return true
+	}
+
+	// SUT + act
+	var err = CheckIfMatch(
+		dummySessionObject,
+		"some etag",
+	)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheckIfMatch_NotMatched(t *testing.T) {
+	// arrange
+	var dummyIfMatch = "*"
+	var dummyHTTPRequest = &http.Request{
+		Method: http.MethodPut,
+		Header: http.Header{},
+	}
+	dummyHTTPRequest.Header.Set("If-Match", dummyIfMatch)
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+	}
+	var dummyError = errors.New("some error")
+	var dummyAppError = &dummyAppError{t: t}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isETagMatchFuncExpected = 1
+	isETagMatchFunc = func(conditionValue string, entityTag string, weakComparison bool) bool {
+		isETagMatchFuncCalled++
+		assert.Equal(t, dummyIfMatch, conditionValue)
+		assert.Zero(t, entityTag)
+		assert.False(t, weakComparison)
+		return false
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Entity tag [%v] does not match If-Match header [%v]", format)
+		assert.Equal(t, 2, len(a))
+		assert.Equal(t, "", a[0])
+		assert.Equal(t, dummyIfMatch, a[1])
+		return dummyError
+	}
+	apperrorGetPreconditionFailedErrorExpected = 1
+	apperrorGetPreconditionFailedError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetPreconditionFailedErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = CheckIfMatch(
+		dummySessionObject,
+		"",
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
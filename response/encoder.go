@@ -0,0 +1,269 @@
+package response
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/response/model"
+)
+
+// These are the media types supported by the built-in response encoders
+const (
+	MediaTypeJSON    = "application/json"
+	MediaTypeXML     = "application/xml"
+	MediaTypeText    = "text/plain"
+	MediaTypeCSV     = "text/csv"
+	MediaTypeMsgPack = "application/msgpack"
+)
+
+var (
+	builtInEncoders = map[string]model.Encoder{
+		MediaTypeJSON:    jsonEncoder{},
+		MediaTypeXML:     xmlEncoder{},
+		MediaTypeText:    textEncoder{},
+		MediaTypeCSV:     csvEncoder{},
+		MediaTypeMsgPack: msgpackEncoder{},
+	}
+	builtInMediaTypes = []string{
+		MediaTypeJSON,
+		MediaTypeXML,
+		MediaTypeText,
+		MediaTypeCSV,
+		MediaTypeMsgPack,
+	}
+	defaultEncoder model.Encoder = jsonEncoder{}
+)
+
+type jsonEncoder struct{}
+
+func (encoder jsonEncoder) ContentType() string {
+	return ContentTypeJSON
+}
+
+func (encoder jsonEncoder) Encode(responseObject interface{}) ([]byte, error) {
+	return []byte(jsonutilMarshalIgnoreError(responseObject)), nil
+}
+
+type xmlEncoder struct{}
+
+func (encoder xmlEncoder) ContentType() string {
+	return ContentTypeXML
+}
+
+func (encoder xmlEncoder) Encode(responseObject interface{}) ([]byte, error) {
+	return xml.Marshal(responseObject)
+}
+
+type textEncoder struct{}
+
+func (encoder textEncoder) ContentType() string {
+	return ContentTypeText
+}
+
+func (encoder textEncoder) Encode(responseObject interface{}) ([]byte, error) {
+	switch value := responseObject.(type) {
+	case string:
+		return []byte(value), nil
+	case []byte:
+		return value, nil
+	case fmt.Stringer:
+		return []byte(value.String()), nil
+	case error:
+		return []byte(value.Error()), nil
+	}
+	return []byte(jsonutilMarshalIgnoreError(responseObject)), nil
+}
+
+// toGenericValue converts the given object into its generic JSON representation, i.e. maps, slices, strings, booleans, json.Number and nil
+func toGenericValue(responseObject interface{}) (interface{}, error) {
+	var jsonBytes, marshalError = json.Marshal(responseObject)
+	if marshalError != nil {
+		return nil, marshalError
+	}
+	var decoder = json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	var genericValue interface{}
+	var decodeError = decoder.Decode(&genericValue)
+	return genericValue, decodeError
+}
+
+func getSortedKeys(genericMap map[string]interface{}) []string {
+	var keys = []string{}
+	for key := range genericMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type csvEncoder struct{}
+
+func (encoder csvEncoder) ContentType() string {
+	return ContentTypeCSV
+}
+
+func toCSVCell(genericValue interface{}) string {
+	switch value := genericValue.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	var jsonBytes, _ = json.Marshal(genericValue)
+	return string(jsonBytes)
+}
+
+func toCSVRecords(genericValue interface{}) [][]string {
+	var rows, isArray = genericValue.([]interface{})
+	if !isArray {
+		rows = []interface{}{genericValue}
+	}
+	var headerSet = map[string]interface{}{}
+	for _, row := range rows {
+		var rowMap, isMap = row.(map[string]interface{})
+		if !isMap {
+			headerSet = nil
+			break
+		}
+		for key := range rowMap {
+			headerSet[key] = nil
+		}
+	}
+	var records = [][]string{}
+	if len(headerSet) > 0 {
+		var header = getSortedKeys(headerSet)
+		records = append(records, header)
+		for _, row := range rows {
+			var rowMap = row.(map[string]interface{})
+			var record = []string{}
+			for _, key := range header {
+				record = append(record, toCSVCell(rowMap[key]))
+			}
+			records = append(records, record)
+		}
+		return records
+	}
+	for _, row := range rows {
+		var cells, isCells = row.([]interface{})
+		if !isCells {
+			cells = []interface{}{row}
+		}
+		var record = []string{}
+		for _, cell := range cells {
+			record = append(record, toCSVCell(cell))
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// Encode writes arrays of objects as CSV records with a header row of sorted field names, arrays of arrays as plain CSV records, and single values as single records
+func (encoder csvEncoder) Encode(responseObject interface{}) ([]byte, error) {
+	var genericValue, genericError = toGenericValue(responseObject)
+	if genericError != nil {
+		return nil, genericError
+	}
+	var buffer = &bytes.Buffer{}
+	var writer = csv.NewWriter(buffer)
+	var writeError = writer.WriteAll(
+		toCSVRecords(genericValue),
+	)
+	if writeError != nil {
+		return nil, writeError
+	}
+	return buffer.Bytes(), nil
+}
+
+type msgpackEncoder struct{}
+
+func (encoder msgpackEncoder) ContentType() string {
+	return ContentTypeMsgPack
+}
+
+func writeMsgPackHeader(buffer *bytes.Buffer, length int, fixPrefix byte, fixLimit int, prefix16 byte, prefix32 byte) {
+	if length < fixLimit {
+		buffer.WriteByte(fixPrefix | byte(length))
+	} else if length <= math.MaxUint16 {
+		buffer.WriteByte(prefix16)
+		binary.Write(buffer, binary.BigEndian, uint16(length))
+	} else {
+		buffer.WriteByte(prefix32)
+		binary.Write(buffer, binary.BigEndian, uint32(length))
+	}
+}
+
+func writeMsgPackString(buffer *bytes.Buffer, value string) {
+	if len(value) >= 32 && len(value) <= math.MaxUint8 {
+		buffer.WriteByte(0xd9)
+		buffer.WriteByte(byte(len(value)))
+	} else {
+		writeMsgPackHeader(buffer, len(value), 0xa0, 32, 0xda, 0xdb)
+	}
+	buffer.WriteString(value)
+}
+
+func writeMsgPackNumber(buffer *bytes.Buffer, value json.Number) {
+	var intValue, intError = value.Int64()
+	if intError != nil {
+		var floatValue, _ = value.Float64()
+		buffer.WriteByte(0xcb)
+		binary.Write(buffer, binary.BigEndian, floatValue)
+	} else if intValue >= 0 && intValue <= 0x7f {
+		buffer.WriteByte(byte(intValue))
+	} else if intValue < 0 && intValue >= -32 {
+		buffer.WriteByte(byte(int8(intValue)))
+	} else {
+		buffer.WriteByte(0xd3)
+		binary.Write(buffer, binary.BigEndian, intValue)
+	}
+}
+
+func writeMsgPackValue(buffer *bytes.Buffer, genericValue interface{}) {
+	switch value := genericValue.(type) {
+	case nil:
+		buffer.WriteByte(0xc0)
+	case bool:
+		if value {
+			buffer.WriteByte(0xc3)
+		} else {
+			buffer.WriteByte(0xc2)
+		}
+	case json.Number:
+		writeMsgPackNumber(buffer, value)
+	case string:
+		writeMsgPackString(buffer, value)
+	case []interface{}:
+		writeMsgPackHeader(buffer, len(value), 0x90, 16, 0xdc, 0xdd)
+		for _, item := range value {
+			writeMsgPackValue(buffer, item)
+		}
+	case map[string]interface{}:
+		writeMsgPackHeader(buffer, len(value), 0x80, 16, 0xde, 0xdf)
+		for _, key := range getSortedKeys(value) {
+			writeMsgPackString(buffer, key)
+			writeMsgPackValue(buffer, value[key])
+		}
+	}
+}
+
+// Encode writes the generic JSON representation of the given object in MessagePack format, with map keys sorted
+func (encoder msgpackEncoder) Encode(responseObject interface{}) ([]byte, error) {
+	var genericValue, genericError = toGenericValue(responseObject)
+	if genericError != nil {
+		return nil, genericError
+	}
+	var buffer = &bytes.Buffer{}
+	writeMsgPackValue(buffer, genericValue)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,418 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONEncoder(t *testing.T) {
+	// arrange
+	var dummyResponseObject = "some response object"
+	var dummyResponseContent = "some response content"
+
+	// mock
+	createMock(t)
+
+	// expect
+	jsonutilMarshalIgnoreErrorExpected = 1
+	jsonutilMarshalIgnoreError = func(v interface{}) string {
+		jsonutilMarshalIgnoreErrorCalled++
+		assert.Equal(t, dummyResponseObject, v)
+		return dummyResponseContent
+	}
+
+	// SUT
+	var sut = jsonEncoder{}
+
+	// act
+	var contentType = sut.ContentType()
+	var result, err = sut.Encode(
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Equal(t, ContentTypeJSON, contentType)
+	assert.Equal(t, []byte(dummyResponseContent), result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestXMLEncoder(t *testing.T) {
+	// arrange
+	var dummyResponseObject = errorResponseModel{
+		Code:     "some code",
+		Messages: []string{"some message 1", "some message 2"},
+		ExtraData: extraDataModel{
+			"b": "some value 2",
+			"a": "some value 1",
+		},
+	}
+	var expectedResult = "<error><code>some code</code>" +
+		"<messages><message>some message 1</message><message>some message 2</message></messages>" +
+		"<extraData><entry key=\"a\">some value 1</entry><entry key=\"b\">some value 2</entry></extraData>" +
+		"</error>"
+
+	// mock
+	createMock(t)
+
+	// expect
+	sortStringsExpected = 1
+	sortStrings = func(x []string) {
+		sortStringsCalled++
+		sort.Strings(x)
+	}
+
+	// SUT
+	var sut = xmlEncoder{}
+
+	// act
+	var contentType = sut.ContentType()
+	var result, err = sut.Encode(
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Equal(t, ContentTypeXML, contentType)
+	assert.Equal(t, expectedResult, string(result))
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestXMLEncoder_NoExtraData(t *testing.T) {
+	// arrange
+	var dummyResponseObject = errorResponseModel{
+		Code:     "some code",
+		Messages: []string{"some message"},
+	}
+	var expectedResult = "<error><code>some code</code>" +
+		"<messages><message>some message</message></messages>" +
+		"</error>"
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = xmlEncoder{}
+
+	// act
+	var result, err = sut.Encode(
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Equal(t, expectedResult, string(result))
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestTextEncoder_DirectTypes(t *testing.T) {
+	// arrange
+	var dummyResponseObjects = []interface{}{
+		"some string",
+		[]byte("some bytes"),
+		errors.New("some error"),
+	}
+	var expectedResults = []string{
+		"some string",
+		"some bytes",
+		"some error",
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = textEncoder{}
+
+	// act
+	var contentType = sut.ContentType()
+
+	// assert
+	assert.Equal(t, ContentTypeText, contentType)
+	for index, dummyResponseObject := range dummyResponseObjects {
+		// act
+		var result, err = sut.Encode(
+			dummyResponseObject,
+		)
+
+		// assert
+		assert.Equal(t, expectedResults[index], string(result))
+		assert.NoError(t, err)
+	}
+
+	// verify
+	verifyAll(t)
+}
+
+func TestTextEncoder_Stringer(t *testing.T) {
+	// arrange
+	var dummyResponseObject = errorResponseModel{
+		Code:     "some code",
+		Messages: []string{"some message 1", "some message 2"},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsJoinExpected = 1
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		assert.Equal(t, "; ", sep)
+		return strings.Join(elems, sep)
+	}
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		assert.Equal(t, "%v: %v", format)
+		return fmt.Sprintf(format, a...)
+	}
+
+	// SUT
+	var sut = textEncoder{}
+
+	// act
+	var result, err = sut.Encode(
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Equal(t, "some code: some message 1; some message 2", string(result))
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestTextEncoder_Fallback(t *testing.T) {
+	// arrange
+	var dummyResponseObject = map[string]int{"some key": 1}
+	var dummyResponseContent = "some response content"
+
+	// mock
+	createMock(t)
+
+	// expect
+	jsonutilMarshalIgnoreErrorExpected = 1
+	jsonutilMarshalIgnoreError = func(v interface{}) string {
+		jsonutilMarshalIgnoreErrorCalled++
+		assert.Equal(t, dummyResponseObject, v)
+		return dummyResponseContent
+	}
+
+	// SUT
+	var sut = textEncoder{}
+
+	// act
+	var result, err = sut.Encode(
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseContent, string(result))
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCSVEncoder(t *testing.T) {
+	// arrange
+	type dummyRow struct {
+		Name  string      `json:"name"`
+		Count int         `json:"count,omitempty"`
+		Extra interface{} `json:"extra,omitempty"`
+	}
+	type testCase struct {
+		responseObject interface{}
+		expectedResult string
+	}
+	var testCases = []testCase{
+		{
+			[]dummyRow{
+				{Name: "some name 1", Count: 1, Extra: map[string]int{"a": 1}},
+				{Name: "some, name 2"},
+			},
+			"count,extra,name\n1,\"{\"\"a\"\":1}\",some name 1\n,,\"some, name 2\"\n",
+		},
+		{
+			[][]interface{}{
+				{1, "a", 2.5},
+				{true, nil},
+			},
+			"1,a,2.5\ntrue,\n",
+		},
+		{
+			[]interface{}{"some value", 12},
+			"some value\n12\n",
+		},
+		{
+			"some value",
+			"some value\n",
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = csvEncoder{}
+
+	// act
+	var contentType = sut.ContentType()
+
+	// assert
+	assert.Equal(t, ContentTypeCSV, contentType)
+	for _, test := range testCases {
+		// act
+		var result, err = sut.Encode(
+			test.responseObject,
+		)
+
+		// assert
+		assert.Equal(t, test.expectedResult, string(result))
+		assert.NoError(t, err)
+	}
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCSVEncoder_MarshalError(t *testing.T) {
+	// arrange
+	var dummyResponseObject = make(chan int)
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = csvEncoder{}
+
+	// act
+	var result, err = sut.Encode(
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Error(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMsgPackEncoder(t *testing.T) {
+	// arrange
+	var dummyLongString = strings.Repeat("x", 40)
+	type testCase struct {
+		responseObject interface{}
+		expectedResult []byte
+	}
+	var testCases = []testCase{
+		{nil, []byte{0xc0}},
+		{true, []byte{0xc3}},
+		{false, []byte{0xc2}},
+		{5, []byte{0x05}},
+		{-1, []byte{0xff}},
+		{200, []byte{0xd3, 0, 0, 0, 0, 0, 0, 0, 0xc8}},
+		{-100, []byte{0xd3, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x9c}},
+		{1.5, []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{"abc", []byte{0xa3, 'a', 'b', 'c'}},
+		{dummyLongString, append([]byte{0xd9, 40}, []byte(dummyLongString)...)},
+		{[]interface{}{1, nil}, []byte{0x92, 0x01, 0xc0}},
+		{
+			map[string]interface{}{"b": 1, "a": []bool{true}},
+			[]byte{0x82, 0xa1, 'a', 0x91, 0xc3, 0xa1, 'b', 0x01},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = msgpackEncoder{}
+
+	// act
+	var contentType = sut.ContentType()
+
+	// assert
+	assert.Equal(t, ContentTypeMsgPack, contentType)
+	for _, test := range testCases {
+		// act
+		var result, err = sut.Encode(
+			test.responseObject,
+		)
+
+		// assert
+		assert.Equal(t, test.expectedResult, result, test.responseObject)
+		assert.NoError(t, err)
+	}
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMsgPackEncoder_LargeCollections(t *testing.T) {
+	// arrange
+	var dummyArray = make([]int, 20)
+	var dummyString = strings.Repeat("x", 300)
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = msgpackEncoder{}
+
+	// act
+	var arrayResult, arrayError = sut.Encode(
+		dummyArray,
+	)
+	var stringResult, stringError = sut.Encode(
+		dummyString,
+	)
+
+	// assert
+	assert.Equal(t, []byte{0xdc, 0, 20}, arrayResult[:3])
+	assert.Equal(t, 23, len(arrayResult))
+	assert.NoError(t, arrayError)
+	assert.Equal(t, []byte{0xda, 0x01, 0x2c}, stringResult[:3])
+	assert.Equal(t, 303, len(stringResult))
+	assert.NoError(t, stringError)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMsgPackEncoder_MarshalError(t *testing.T) {
+	// arrange
+	var dummyResponseObject = make(chan int)
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = msgpackEncoder{}
+
+	// act
+	var result, err = sut.Encode(
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Error(t, err)
+
+	// verify
+	verifyAll(t)
+}
@@ -0,0 +1,11 @@
+package model
+
+// Compression holds the settings of response body compression negotiated upon the Accept-Encoding header of requests
+type Compression struct {
+	// MinSize is the minimum size in bytes of response bodies to be compressed; response.DefaultCompressionMinSize applies if not positive
+	MinSize int
+	// ContentTypes lists the media types of response bodies to be compressed, e.g. "application/json"; response bodies of any media type are compressed if empty
+	ContentTypes []string
+	// Level is the compression level from 1 (best speed) to 9 (best compression); the default level applies if not within the range
+	Level int
+}
@@ -0,0 +1,9 @@
+package model
+
+// Encoder is the interface for encoding response objects into HTTP response bodies of a specific media type
+type Encoder interface {
+	// ContentType returns the value of the Content-Type header for the encoded response bodies, e.g. "application/xml; charset=utf-8"
+	ContentType() string
+	// Encode encodes the given response object into the HTTP response body
+	Encode(responseObject interface{}) ([]byte, error)
+}
@@ -0,0 +1,7 @@
+package model
+
+// ETag holds the settings of entity tags computed over serialized response bodies for conditional requests
+type ETag struct {
+	// Weak marks the computed entity tags as weak validators (W/"...") instead of strong ones
+	Weak bool
+}
@@ -0,0 +1,234 @@
+package response
+
+import (
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/response/model"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+// These are the constants used for content negotiation
+const (
+	acceptHeader      = "Accept"
+	rangeSeparator    = ","
+	paramSeparator    = ";"
+	valueSeparator    = "="
+	qualityParam      = "q"
+	wildcardMediaType = "*/*"
+	wildcardSubtype   = "/*"
+)
+
+const (
+	encoderAttachmentName = "responseEncoder"
+)
+
+// acceptRange defines a media range of the Accept header together with its quality value
+type acceptRange struct {
+	mediaType string
+	quality   float64
+}
+
+func getEncoders() map[string]model.Encoder {
+	var encoders = map[string]model.Encoder{}
+	for mediaType, encoder := range builtInEncoders {
+		encoders[mediaType] = encoder
+	}
+	if customization.ResponseEncoders == nil {
+		return encoders
+	}
+	for mediaType, encoder := range customization.ResponseEncoders() {
+		var normalizedMediaType = stringsToLower(
+			stringsTrimSpace(mediaType),
+		)
+		if encoder == nil {
+			delete(encoders, normalizedMediaType)
+		} else {
+			encoders[normalizedMediaType] = encoder
+		}
+	}
+	return encoders
+}
+
+func getDefaultMediaTypes(encoders map[string]model.Encoder) []string {
+	var mediaTypes = []string{}
+	var customMediaTypes = []string{}
+	for _, mediaType := range builtInMediaTypes {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	for mediaType := range encoders {
+		var _, isBuiltIn = builtInEncoders[mediaType]
+		if !isBuiltIn {
+			customMediaTypes = append(customMediaTypes, mediaType)
+		}
+	}
+	sortStrings(customMediaTypes)
+	return append(mediaTypes, customMediaTypes...)
+}
+
+// getCandidates returns the given media types in the order of preference that have encoders, or all media types with encoders if none is given
+func getCandidates(
+	mediaTypes []string,
+	encoders map[string]model.Encoder,
+) []string {
+	if len(mediaTypes) == 0 {
+		mediaTypes = getDefaultMediaTypesFunc(
+			encoders,
+		)
+	}
+	var candidates = []string{}
+	for _, mediaType := range mediaTypes {
+		var normalizedMediaType = stringsToLower(
+			stringsTrimSpace(mediaType),
+		)
+		var _, hasEncoder = encoders[normalizedMediaType]
+		if hasEncoder {
+			candidates = append(candidates, normalizedMediaType)
+		}
+	}
+	return candidates
+}
+
+func parseAcceptRange(value string) (acceptRange, bool) {
+	var parts = stringsSplit(value, paramSeparator)
+	var mediaType = stringsToLower(
+		stringsTrimSpace(parts[0]),
+	)
+	if mediaType == "" {
+		return acceptRange{}, false
+	}
+	var quality = 1.0
+	for _, param := range parts[1:] {
+		var pair = stringsSplitN(param, valueSeparator, 2)
+		if len(pair) != 2 ||
+			stringsToLower(stringsTrimSpace(pair[0])) != qualityParam {
+			continue
+		}
+		var parsedQuality, parseError = strconvParseFloat(
+			stringsTrimSpace(pair[1]),
+			64,
+		)
+		if parseError != nil ||
+			parsedQuality < 0 ||
+			parsedQuality > 1 {
+			return acceptRange{}, false
+		}
+		quality = parsedQuality
+	}
+	return acceptRange{
+		mediaType: mediaType,
+		quality:   quality,
+	}, true
+}
+
+func parseAccept(acceptValue string) []acceptRange {
+	var acceptRanges = []acceptRange{}
+	for _, value := range stringsSplit(acceptValue, rangeSeparator) {
+		var parsedRange, isValid = parseAcceptRangeFunc(
+			value,
+		)
+		if isValid {
+			acceptRanges = append(acceptRanges, parsedRange)
+		}
+	}
+	return acceptRanges
+}
+
+// getQuality returns the quality value of the most specific media range matching the given media type, or 0 if none matches
+func getQuality(acceptRanges []acceptRange, mediaType string) float64 {
+	var quality float64
+	var specificity int
+	var mediaTypePrefix = stringsSplitN(mediaType, "/", 2)[0] + wildcardSubtype
+	for _, acceptRange := range acceptRanges {
+		var rangeSpecificity int
+		if acceptRange.mediaType == mediaType {
+			rangeSpecificity = 3
+		} else if acceptRange.mediaType == mediaTypePrefix {
+			rangeSpecificity = 2
+		} else if acceptRange.mediaType == wildcardMediaType {
+			rangeSpecificity = 1
+		}
+		if rangeSpecificity > specificity {
+			specificity = rangeSpecificity
+			quality = acceptRange.quality
+		}
+	}
+	return quality
+}
+
+// negotiate selects the encoder for the response of given session upon the Accept header of its HTTP request and the given media types supported by its route; the default JSON encoder is returned with a NotAcceptable error if none is acceptable
+func negotiate(session sessionModel.Session, mediaTypes []string) (model.Encoder, error) {
+	var httpRequest = session.GetRequest()
+	if httpRequest == nil {
+		return defaultEncoder, nil
+	}
+	var encoders = getEncodersFunc()
+	var candidates = getCandidatesFunc(
+		mediaTypes,
+		encoders,
+	)
+	var acceptValue = stringsTrimSpace(
+		httpRequest.Header.Get(acceptHeader),
+	)
+	if acceptValue == "" &&
+		len(candidates) > 0 {
+		return encoders[candidates[0]], nil
+	}
+	var acceptRanges = parseAcceptFunc(
+		acceptValue,
+	)
+	var bestMediaType string
+	var bestQuality float64
+	for _, candidate := range candidates {
+		var quality = getQualityFunc(
+			acceptRanges,
+			candidate,
+		)
+		if quality > bestQuality {
+			bestMediaType = candidate
+			bestQuality = quality
+		}
+	}
+	if bestQuality <= 0 {
+		return defaultEncoder,
+			apperrorGetNotAcceptableError(
+				fmtErrorf(
+					"No acceptable media type among [%v] for Accept header [%v]",
+					stringsJoin(candidates, ", "),
+					acceptValue,
+				),
+			)
+	}
+	return encoders[bestMediaType], nil
+}
+
+// Negotiate selects the encoder for the response of given session upon the Accept header of its HTTP request and the given media types supported by its route in the order of preference, or all media types with encoders if empty, and stores it in the session for response writing; returns a NotAcceptable error if none is acceptable, so that the request could be rejected before its action is executed
+func Negotiate(session sessionModel.Session, mediaTypes []string) error {
+	var encoder, negotiateError = negotiateFunc(
+		session,
+		mediaTypes,
+	)
+	if negotiateError != nil {
+		return negotiateError
+	}
+	session.Attach(
+		encoderAttachmentName,
+		encoder,
+	)
+	return nil
+}
+
+// getEncoder returns the encoder stored in the given session by Negotiate, or negotiates one among all media types with encoders if not yet stored
+func getEncoder(session sessionModel.Session) (model.Encoder, error) {
+	var attachment, found = session.GetRawAttachment(
+		encoderAttachmentName,
+	)
+	if found {
+		var encoder, ok = attachment.(model.Encoder)
+		if ok {
+			return encoder, nil
+		}
+	}
+	return negotiateFunc(
+		session,
+		nil,
+	)
+}
@@ -0,0 +1,899 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/response/model"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+func TestGetEncoders_NoCustomization(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getEncoders()
+
+	// assert
+	assert.Equal(t, builtInEncoders, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetEncoders_Customized(t *testing.T) {
+	// arrange
+	var dummyYAMLEncoder = &dummyEncoder{t: t}
+	var dummyXMLEncoder = &dummyEncoder{t: t}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationResponseEncodersExpected = 1
+	customization.ResponseEncoders = func() map[string]model.Encoder {
+		customizationResponseEncodersCalled++
+		return map[string]model.Encoder{
+			"Application/YAML": dummyYAMLEncoder,
+			MediaTypeXML:       dummyXMLEncoder,
+			MediaTypeCSV:       nil,
+		}
+	}
+	stringsTrimSpaceExpected = 3
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	stringsToLowerExpected = 3
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		return strings.ToLower(s)
+	}
+
+	// SUT + act
+	var result = getEncoders()
+
+	// assert
+	assert.Equal(t, 5, len(result))
+	assert.Equal(t, builtInEncoders[MediaTypeJSON], result[MediaTypeJSON])
+	assert.Equal(t, dummyXMLEncoder, result[MediaTypeXML])
+	assert.Equal(t, builtInEncoders[MediaTypeText], result[MediaTypeText])
+	assert.Equal(t, builtInEncoders[MediaTypeMsgPack], result[MediaTypeMsgPack])
+	assert.Equal(t, dummyYAMLEncoder, result["application/yaml"])
+	var _, found = result[MediaTypeCSV]
+	assert.False(t, found)
+	assert.Equal(t, 5, len(builtInEncoders))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetDefaultMediaTypes(t *testing.T) {
+	// arrange
+	var dummyEncoders = map[string]model.Encoder{
+		MediaTypeJSON:      nil,
+		"text/yaml":        nil,
+		"application/toml": nil,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	sortStringsExpected = 1
+	sortStrings = func(x []string) {
+		sortStringsCalled++
+		sort.Strings(x)
+	}
+
+	// SUT + act
+	var result = getDefaultMediaTypes(
+		dummyEncoders,
+	)
+
+	// assert
+	assert.Equal(t, append(builtInMediaTypes, "application/toml", "text/yaml"), result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCandidates_RouteMediaTypes(t *testing.T) {
+	// arrange
+	var dummyMediaTypes = []string{" Text/CSV ", MediaTypeXML, "application/JSON"}
+	var dummyEncoders = map[string]model.Encoder{
+		MediaTypeJSON: nil,
+		MediaTypeCSV:  nil,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsTrimSpaceExpected = 3
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	stringsToLowerExpected = 3
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		return strings.ToLower(s)
+	}
+
+	// SUT + act
+	var result = getCandidates(
+		dummyMediaTypes,
+		dummyEncoders,
+	)
+
+	// assert
+	assert.Equal(t, []string{MediaTypeCSV, MediaTypeJSON}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCandidates_DefaultMediaTypes(t *testing.T) {
+	// arrange
+	var dummyEncoders = map[string]model.Encoder{
+		MediaTypeJSON: nil,
+		"text/yaml":   nil,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getDefaultMediaTypesFuncExpected = 1
+	getDefaultMediaTypesFunc = func(encoders map[string]model.Encoder) []string {
+		getDefaultMediaTypesFuncCalled++
+		assert.Equal(t, dummyEncoders, encoders)
+		return []string{"text/yaml", MediaTypeXML, MediaTypeJSON}
+	}
+	stringsTrimSpaceExpected = 3
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	stringsToLowerExpected = 3
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		return strings.ToLower(s)
+	}
+
+	// SUT + act
+	var result = getCandidates(
+		nil,
+		dummyEncoders,
+	)
+
+	// assert
+	assert.Equal(t, []string{"text/yaml", MediaTypeJSON}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func mockParseAcceptRangeStrings(t *testing.T, trimCount int, lowerCount int, splitNCount int, parseFloatCount int) {
+	stringsSplitExpected = 1
+	stringsSplit = func(s string, sep string) []string {
+		stringsSplitCalled++
+		assert.Equal(t, paramSeparator, sep)
+		return strings.Split(s, sep)
+	}
+	stringsTrimSpaceExpected = trimCount
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	stringsToLowerExpected = lowerCount
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		return strings.ToLower(s)
+	}
+	stringsSplitNExpected = splitNCount
+	stringsSplitN = func(s string, sep string, n int) []string {
+		stringsSplitNCalled++
+		assert.Equal(t, valueSeparator, sep)
+		assert.Equal(t, 2, n)
+		return strings.SplitN(s, sep, n)
+	}
+	strconvParseFloatExpected = parseFloatCount
+	strconvParseFloat = func(s string, bitSize int) (float64, error) {
+		strconvParseFloatCalled++
+		assert.Equal(t, 64, bitSize)
+		return strconv.ParseFloat(s, bitSize)
+	}
+}
+
+func TestParseAcceptRange_EmptyMediaType(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	mockParseAcceptRangeStrings(t, 1, 1, 0, 0)
+
+	// SUT + act
+	var result, isValid = parseAcceptRange(
+		" ;q=0.5",
+	)
+
+	// assert
+	assert.Zero(t, result)
+	assert.False(t, isValid)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseAcceptRange_NoQuality(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	mockParseAcceptRangeStrings(t, 2, 2, 1, 0)
+
+	// SUT + act
+	var result, isValid = parseAcceptRange(
+		" Text/HTML; level=1",
+	)
+
+	// assert
+	assert.Equal(t, acceptRange{mediaType: "text/html", quality: 1}, result)
+	assert.True(t, isValid)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseAcceptRange_InvalidQuality(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	mockParseAcceptRangeStrings(t, 3, 2, 1, 1)
+
+	// SUT + act
+	var result, isValid = parseAcceptRange(
+		"text/html;q=abc",
+	)
+
+	// assert
+	assert.Zero(t, result)
+	assert.False(t, isValid)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseAcceptRange_QualityOutOfRange(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	mockParseAcceptRangeStrings(t, 3, 2, 1, 1)
+
+	// SUT + act
+	var result, isValid = parseAcceptRange(
+		"text/html;q=1.5",
+	)
+
+	// assert
+	assert.Zero(t, result)
+	assert.False(t, isValid)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseAcceptRange_WithQuality(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	mockParseAcceptRangeStrings(t, 3, 2, 1, 1)
+
+	// SUT + act
+	var result, isValid = parseAcceptRange(
+		"application/*; Q=0.8",
+	)
+
+	// assert
+	assert.Equal(t, acceptRange{mediaType: "application/*", quality: 0.8}, result)
+	assert.True(t, isValid)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseAccept(t *testing.T) {
+	// arrange
+	var dummyAcceptValue = "some range 1,some range 2,some range 3"
+	var dummyRange1 = acceptRange{mediaType: "some media type 1", quality: 0.5}
+	var dummyRange3 = acceptRange{mediaType: "some media type 3", quality: 1}
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsSplitExpected = 1
+	stringsSplit = func(s string, sep string) []string {
+		stringsSplitCalled++
+		assert.Equal(t, dummyAcceptValue, s)
+		assert.Equal(t, rangeSeparator, sep)
+		return strings.Split(s, sep)
+	}
+	parseAcceptRangeFuncExpected = 3
+	parseAcceptRangeFunc = func(value string) (acceptRange, bool) {
+		parseAcceptRangeFuncCalled++
+		switch value {
+		case "some range 1":
+			return dummyRange1, true
+		case "some range 3":
+			return dummyRange3, true
+		}
+		return acceptRange{}, false
+	}
+
+	// SUT + act
+	var result = parseAccept(
+		dummyAcceptValue,
+	)
+
+	// assert
+	assert.Equal(t, []acceptRange{dummyRange1, dummyRange3}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetQuality(t *testing.T) {
+	// arrange
+	var dummyAcceptRanges = []acceptRange{
+		{mediaType: "*/*", quality: 0.1},
+		{mediaType: "text/*", quality: 0.5},
+		{mediaType: "text/csv", quality: 0},
+		{mediaType: "application/json", quality: 0.9},
+	}
+	type testCase struct {
+		mediaType string
+		quality   float64
+	}
+	var testCases = []testCase{
+		{MediaTypeJSON, 0.9},
+		{MediaTypeCSV, 0},
+		{MediaTypeText, 0.5},
+		{MediaTypeXML, 0.1},
+	}
+
+	for _, test := range testCases {
+		// mock
+		createMock(t)
+
+		// expect
+		stringsSplitNExpected = 1
+		stringsSplitN = func(s string, sep string, n int) []string {
+			stringsSplitNCalled++
+			return strings.SplitN(s, sep, n)
+		}
+
+		// SUT + act
+		var result = getQuality(
+			dummyAcceptRanges,
+			test.mediaType,
+		)
+
+		// assert
+		assert.Equal(t, test.quality, result, test.mediaType)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestGetQuality_NoMatch(t *testing.T) {
+	// arrange
+	var dummyAcceptRanges = []acceptRange{
+		{mediaType: "text/*", quality: 0.5},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsSplitNExpected = 1
+	stringsSplitN = func(s string, sep string, n int) []string {
+		stringsSplitNCalled++
+		return strings.SplitN(s, sep, n)
+	}
+
+	// SUT + act
+	var result = getQuality(
+		dummyAcceptRanges,
+		MediaTypeJSON,
+	)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNegotiate_NoRequest(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{
+		t:               t,
+		allowNilRequest: true,
+	}
+	var dummyMediaTypes = []string{"some media type"}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, err = negotiate(
+		dummySessionObject,
+		dummyMediaTypes,
+	)
+
+	// assert
+	assert.Equal(t, defaultEncoder, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNegotiate_NoAcceptHeader(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method: "some method",
+		Header: http.Header{},
+	}
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+	}
+	var dummyEncoderObject = &dummyEncoder{t: t}
+	var dummyEncoders = map[string]model.Encoder{
+		"some media type": dummyEncoderObject,
+	}
+	var dummyMediaTypes = []string{"some media type"}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getEncodersFuncExpected = 1
+	getEncodersFunc = func() map[string]model.Encoder {
+		getEncodersFuncCalled++
+		return dummyEncoders
+	}
+	getCandidatesFuncExpected = 1
+	getCandidatesFunc = func(mediaTypes []string, encoders map[string]model.Encoder) []string {
+		getCandidatesFuncCalled++
+		assert.Equal(t, dummyMediaTypes, mediaTypes)
+		assert.Equal(t, dummyEncoders, encoders)
+		return []string{"some media type"}
+	}
+	stringsTrimSpaceExpected = 1
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+
+	// SUT + act
+	var result, err = negotiate(
+		dummySessionObject,
+		dummyMediaTypes,
+	)
+
+	// assert
+	assert.Equal(t, dummyEncoderObject, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNegotiate_NotAcceptable(t *testing.T) {
+	// arrange
+	var dummyAcceptValue = "some accept value"
+	var dummyHTTPRequest = &http.Request{
+		Method: "some method",
+		Header: http.Header{},
+	}
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+	}
+	var dummyCandidates = []string{"some media type 1", "some media type 2"}
+	var dummyAcceptRanges = []acceptRange{{mediaType: "some media range"}}
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+	var dummyMediaTypes = []string{"some media type"}
+
+	// stub
+	dummyHTTPRequest.Header.Set("Accept", dummyAcceptValue)
+
+	// mock
+	createMock(t)
+
+	// expect
+	getEncodersFuncExpected = 1
+	getEncodersFunc = func() map[string]model.Encoder {
+		getEncodersFuncCalled++
+		return nil
+	}
+	getCandidatesFuncExpected = 1
+	getCandidatesFunc = func(mediaTypes []string, encoders map[string]model.Encoder) []string {
+		getCandidatesFuncCalled++
+		assert.Equal(t, dummyMediaTypes, mediaTypes)
+		return dummyCandidates
+	}
+	stringsTrimSpaceExpected = 1
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	parseAcceptFuncExpected = 1
+	parseAcceptFunc = func(acceptValue string) []acceptRange {
+		parseAcceptFuncCalled++
+		assert.Equal(t, dummyAcceptValue, acceptValue)
+		return dummyAcceptRanges
+	}
+	getQualityFuncExpected = 2
+	getQualityFunc = func(acceptRanges []acceptRange, mediaType string) float64 {
+		getQualityFuncCalled++
+		assert.Equal(t, dummyAcceptRanges, acceptRanges)
+		assert.Equal(t, dummyCandidates[getQualityFuncCalled-1], mediaType)
+		return 0
+	}
+	stringsJoinExpected = 1
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		return strings.Join(elems, sep)
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "No acceptable media type among [%v] for Accept header [%v]", format)
+		assert.Equal(t, 2, len(a))
+		assert.Equal(t, "some media type 1, some media type 2", a[0])
+		assert.Equal(t, dummyAcceptValue, a[1])
+		return dummyError
+	}
+	apperrorGetNotAcceptableErrorExpected = 1
+	apperrorGetNotAcceptableError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetNotAcceptableErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var result, err = negotiate(
+		dummySessionObject,
+		dummyMediaTypes,
+	)
+
+	// assert
+	assert.Equal(t, defaultEncoder, result)
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNegotiate_BestQuality(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method: "some method",
+		Header: http.Header{},
+	}
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+	}
+	var dummyEncoderObject = &dummyEncoder{t: t}
+	var dummyEncoders = map[string]model.Encoder{
+		"some media type 1": &dummyEncoder{t: t},
+		"some media type 2": dummyEncoderObject,
+		"some media type 3": &dummyEncoder{t: t},
+	}
+	var dummyQualities = map[string]float64{
+		"some media type 1": 0.5,
+		"some media type 2": 0.8,
+		"some media type 3": 0.8,
+	}
+	var dummyMediaTypes = []string{"some media type"}
+
+	// stub
+	dummyHTTPRequest.Header.Set("Accept", "some accept value")
+
+	// mock
+	createMock(t)
+
+	// expect
+	getEncodersFuncExpected = 1
+	getEncodersFunc = func() map[string]model.Encoder {
+		getEncodersFuncCalled++
+		return dummyEncoders
+	}
+	getCandidatesFuncExpected = 1
+	getCandidatesFunc = func(mediaTypes []string, encoders map[string]model.Encoder) []string {
+		getCandidatesFuncCalled++
+		assert.Equal(t, dummyMediaTypes, mediaTypes)
+		return []string{"some media type 1", "some media type 2", "some media type 3"}
+	}
+	stringsTrimSpaceExpected = 1
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return strings.TrimSpace(s)
+	}
+	parseAcceptFuncExpected = 1
+	parseAcceptFunc = func(acceptValue string) []acceptRange {
+		parseAcceptFuncCalled++
+		return nil
+	}
+	getQualityFuncExpected = 3
+	getQualityFunc = func(acceptRanges []acceptRange, mediaType string) float64 {
+		getQualityFuncCalled++
+		return dummyQualities[mediaType]
+	}
+
+	// SUT + act
+	var result, err = negotiate(
+		dummySessionObject,
+		dummyMediaTypes,
+	)
+
+	// assert
+	assert.Equal(t, dummyEncoderObject, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNegotiate_Integration(t *testing.T) {
+	// arrange
+	type testCase struct {
+		mediaTypes  []string
+		accept      string
+		contentType string
+		acceptable  bool
+	}
+	var testCases = []testCase{
+		{nil, "", ContentTypeJSON, true},
+		{nil, "*/*", ContentTypeJSON, true},
+		{nil, "application/xml, application/json;q=0.9", ContentTypeXML, true},
+		{nil, "text/*;q=0.5, text/csv;q=0.6, */*;q=0.1", ContentTypeCSV, true},
+		{nil, "application/msgpack", ContentTypeMsgPack, true},
+		{nil, "image/png, */*;q=0", ContentTypeJSON, false},
+		{[]string{" Text/CSV ", "application/xml"}, "", ContentTypeCSV, true},
+		{[]string{" Text/CSV ", "application/xml"}, "*/*", ContentTypeCSV, true},
+		{[]string{"text/csv"}, "application/json", ContentTypeJSON, false},
+	}
+
+	for _, test := range testCases {
+		// arrange
+		var dummyHTTPRequest = &http.Request{
+			Method: http.MethodGet,
+			Header: http.Header{},
+		}
+		dummyHTTPRequest.Header.Set("Accept", test.accept)
+		var dummyName = "some name"
+		var dummySessionObject = &dummySession{
+			t:           t,
+			httpRequest: dummyHTTPRequest,
+			name:        &dummyName,
+		}
+
+		// mock
+		createMock(t)
+
+		// expect
+		fmtErrorf = fmt.Errorf
+		stringsJoin = strings.Join
+		sortStrings = sort.Strings
+		stringsToLower = strings.ToLower
+		stringsTrimSpace = strings.TrimSpace
+		stringsSplit = strings.Split
+		stringsSplitN = strings.SplitN
+		strconvParseFloat = strconv.ParseFloat
+		apperrorGetNotAcceptableError = apperror.GetNotAcceptableError
+		getEncodersFunc = getEncoders
+		getDefaultMediaTypesFunc = getDefaultMediaTypes
+		getCandidatesFunc = getCandidates
+		parseAcceptRangeFunc = parseAcceptRange
+		parseAcceptFunc = parseAccept
+		getQualityFunc = getQuality
+
+		// SUT + act
+		var result, err = negotiate(
+			dummySessionObject,
+			test.mediaTypes,
+		)
+
+		// assert
+		assert.Equal(t, test.contentType, result.ContentType(), test.accept)
+		assert.Equal(t, test.acceptable, err == nil, test.accept)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestNegotiateForSession_NotAcceptable(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyEncoderObject = &dummyEncoder{t: t}
+	var dummyNegotiateError = errors.New("some negotiate error")
+	var dummyMediaTypes = []string{"some media type"}
+
+	// mock
+	createMock(t)
+
+	// expect
+	negotiateFuncExpected = 1
+	negotiateFunc = func(session sessionModel.Session, mediaTypes []string) (model.Encoder, error) {
+		negotiateFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyMediaTypes, mediaTypes)
+		return dummyEncoderObject, dummyNegotiateError
+	}
+
+	// SUT + act
+	var err = Negotiate(
+		dummySessionObject,
+		dummyMediaTypes,
+	)
+
+	// assert
+	assert.Equal(t, dummyNegotiateError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNegotiateForSession_Acceptable(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{
+		t:          t,
+		attachment: map[string]interface{}{},
+	}
+	var dummyEncoderObject = &dummyEncoder{t: t}
+	var dummyMediaTypes = []string{"some media type"}
+
+	// mock
+	createMock(t)
+
+	// expect
+	negotiateFuncExpected = 1
+	negotiateFunc = func(session sessionModel.Session, mediaTypes []string) (model.Encoder, error) {
+		negotiateFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyMediaTypes, mediaTypes)
+		return dummyEncoderObject, nil
+	}
+
+	// SUT + act
+	var err = Negotiate(
+		dummySessionObject,
+		dummyMediaTypes,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, dummyEncoderObject, dummySessionObject.attachment[encoderAttachmentName])
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetEncoder_NotNegotiated(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{
+		t:          t,
+		attachment: map[string]interface{}{},
+	}
+	var dummyEncoderObject = &dummyEncoder{t: t}
+	var dummyNegotiateError = errors.New("some negotiate error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	negotiateFuncExpected = 1
+	negotiateFunc = func(session sessionModel.Session, mediaTypes []string) (model.Encoder, error) {
+		negotiateFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Nil(t, mediaTypes)
+		return dummyEncoderObject, dummyNegotiateError
+	}
+
+	// SUT + act
+	var encoder, err = getEncoder(
+		dummySessionObject,
+	)
+
+	// assert
+	assert.Equal(t, dummyEncoderObject, encoder)
+	assert.Equal(t, dummyNegotiateError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetEncoder_InvalidAttachment(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{
+		t: t,
+		attachment: map[string]interface{}{
+			encoderAttachmentName: "some invalid encoder",
+		},
+	}
+	var dummyEncoderObject = &dummyEncoder{t: t}
+
+	// mock
+	createMock(t)
+
+	// expect
+	negotiateFuncExpected = 1
+	negotiateFunc = func(session sessionModel.Session, mediaTypes []string) (model.Encoder, error) {
+		negotiateFuncCalled++
+		assert.Nil(t, mediaTypes)
+		return dummyEncoderObject, nil
+	}
+
+	// SUT + act
+	var encoder, err = getEncoder(
+		dummySessionObject,
+	)
+
+	// assert
+	assert.Equal(t, dummyEncoderObject, encoder)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetEncoder_Negotiated(t *testing.T) {
+	// arrange
+	var dummyEncoderObject = &dummyEncoder{t: t}
+	var dummySessionObject = &dummySession{
+		t: t,
+		attachment: map[string]interface{}{
+			encoderAttachmentName: dummyEncoderObject,
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var encoder, err = getEncoder(
+		dummySessionObject,
+	)
+
+	// assert
+	assert.Equal(t, dummyEncoderObject, encoder)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
@@ -1,23 +1,70 @@
 package response
 
 import (
+	"encoding/xml"
 	"net/http"
 
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/response/model"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
 )
 
 // These are the constants used by the HTTP modules
 const (
-	ContentTypeJSON = "application/json; charset=utf-8"
+	ContentTypeJSON    = "application/json; charset=utf-8"
+	ContentTypeXML     = "application/xml; charset=utf-8"
+	ContentTypeText    = "text/plain; charset=utf-8"
+	ContentTypeCSV     = "text/csv; charset=utf-8"
+	ContentTypeMsgPack = "application/msgpack"
 )
 
 // errorResponseModel defines the response object that is written back to consumer of the API
 type errorResponseModel struct {
-	Code      string            `json:"code"`
-	Messages  []string          `json:"messages"`
-	ExtraData map[string]string `json:"extraData,omitempty"`
+	XMLName   xml.Name       `json:"-" xml:"error"`
+	Code      string         `json:"code" xml:"code"`
+	Messages  []string       `json:"messages" xml:"messages>message"`
+	ExtraData extraDataModel `json:"extraData,omitempty" xml:"extraData,omitempty"`
+}
+
+// String returns the plain text representation of the error response
+func (response errorResponseModel) String() string {
+	return fmtSprintf(
+		"%v: %v",
+		response.Code,
+		stringsJoin(response.Messages, "; "),
+	)
+}
+
+// extraDataModel defines the extra data of the error response, which is written as entry elements with key attributes in XML
+type extraDataModel map[string]string
+
+// MarshalXML writes the extra data as entry elements sorted by keys
+func (extraData extraDataModel) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	var keys = []string{}
+	for key := range extraData {
+		keys = append(keys, key)
+	}
+	sortStrings(keys)
+	var tokenError = encoder.EncodeToken(start)
+	if tokenError != nil {
+		return tokenError
+	}
+	for _, key := range keys {
+		var elementError = encoder.EncodeElement(
+			extraData[key],
+			xml.StartElement{
+				Name: xml.Name{Local: "entry"},
+				Attr: []xml.Attr{
+					{Name: xml.Name{Local: "key"}, Value: key},
+				},
+			},
+		)
+		if elementError != nil {
+			return elementError
+		}
+	}
+	return encoder.EncodeToken(start.End())
 }
 
 // overrideResponse defines a dummy response returned by override to suppress logging
@@ -25,15 +72,24 @@ type overrideResponse struct{}
 
 func createOkResponse(
 	responseContent interface{},
+	encoder model.Encoder,
 ) (string, int) {
 	if responseContent == nil {
 		return "", http.StatusNoContent
 	}
-	var responseMessage = jsonutilMarshalIgnoreError(responseContent)
-	if responseMessage == "" {
+	var responseBytes, encodeError = encoder.Encode(responseContent)
+	if encodeError != nil {
+		return createErrorResponseFunc(
+			apperrorGetGeneralFailureError(
+				encodeError,
+			),
+			encoder,
+		)
+	}
+	if len(responseBytes) == 0 {
 		return "", http.StatusNoContent
 	}
-	return responseMessage, http.StatusOK
+	return string(responseBytes), http.StatusOK
 }
 
 func getAppError(
@@ -64,36 +120,66 @@ func generateErrorResponse(
 
 func createErrorResponse(
 	err error,
+	encoder model.Encoder,
 ) (string, int) {
 	var appError = getAppErrorFunc(err)
 	var response = generateErrorResponseFunc(appError)
-	var responseMessage = jsonutilMarshalIgnoreError(response)
+	var responseBytes, _ = encoder.Encode(response)
 	var statusCode = appError.HTTPStatusCode()
-	return responseMessage, statusCode
+	return string(responseBytes), statusCode
 }
 
 func writeResponse(
 	session sessionModel.Session,
 	statusCode int,
 	responseMessage string,
+	contentType string,
 ) {
+	var responseWriter = session.GetResponseWriter()
+	responseWriter.Header().Set("Content-Type", contentType)
+	responseWriter.Header().Add("Vary", acceptHeader)
+	headerutilSetCorrelationIDHeader(
+		session,
+		responseWriter.Header(),
+	)
+	var responseBody = compressResponseFunc(
+		session.GetRequest(),
+		responseWriter.Header(),
+		statusCode,
+		contentType,
+		responseMessage,
+	)
+	responseBody, statusCode = evaluateConditionalFunc(
+		session.GetRequest(),
+		responseWriter.Header(),
+		statusCode,
+		responseBody,
+	)
+	if statusCode == http.StatusNotModified {
+		responseMessage = ""
+	}
 	loggerAPIResponse(
 		session,
 		httpStatusText(statusCode),
 		strconvItoa(statusCode),
 		responseMessage,
 	)
-	var responseWriter = session.GetResponseWriter()
-	responseWriter.Header().Set("Content-Type", ContentTypeJSON)
 	responseWriter.WriteHeader(statusCode)
-	responseWriter.Write([]byte(responseMessage))
+	responseWriter.Write([]byte(responseBody))
 }
 
 func constructResponse(
 	responseObject interface{},
 	responseError error,
+	encoder model.Encoder,
 ) (string, int) {
 	if responseError != nil {
+		if customization.CreateEncodedErrorResponseFunc != nil {
+			return customization.CreateEncodedErrorResponseFunc(
+				responseError,
+				encoder,
+			)
+		}
 		if customization.CreateErrorResponseFunc != nil {
 			return customization.CreateErrorResponseFunc(
 				responseError,
@@ -101,22 +187,31 @@ func constructResponse(
 		}
 		return createErrorResponseFunc(
 			responseError,
+			encoder,
 		)
 	}
 	return createOkResponseFunc(
 		responseObject,
+		encoder,
 	)
 }
 
-// Write responds to the consumer with corresponding HTTP status code and response body
+// Write responds to the consumer with corresponding HTTP status code and response body, encoded in the media type negotiated through Negotiate, or upon the Accept header of the request and the media types supported by the route if not yet negotiated; a NotAcceptable error is responded in JSON instead if none is acceptable
 func Write(
 	session sessionModel.Session,
 	responseObject interface{},
 	responseError error,
 ) {
+	var encoder, negotiateError = getEncoderFunc(
+		session,
+	)
+	if negotiateError != nil {
+		responseError = negotiateError
+	}
 	var responseMessage, statusCode = constructResponseFunc(
 		responseObject,
 		responseError,
+		encoder,
 	)
 	var _, isOverrided = responseObject.(overrideResponse)
 	if !isOverrided {
@@ -124,6 +219,7 @@ func Write(
 			session,
 			statusCode,
 			responseMessage,
+			encoder.ContentType(),
 		)
 	}
 }
@@ -14,27 +14,25 @@ import (
 	apperrorEnum "github.com/zhongjie-cai/WebServiceTemplate/apperror/enum"
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/response/model"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
 )
 
 func TestCreateOkResponse_EmptyContent(t *testing.T) {
 	// arrange
 	var dummyResponseObject = ""
+	var dummyEncoderObject = &dummyEncoder{
+		t:        t,
+		expected: dummyResponseObject,
+	}
 
 	// mock
 	createMock(t)
 
-	// expect
-	jsonutilMarshalIgnoreErrorExpected = 1
-	jsonutilMarshalIgnoreError = func(v interface{}) string {
-		jsonutilMarshalIgnoreErrorCalled++
-		assert.Equal(t, dummyResponseObject, v)
-		return ""
-	}
-
 	// SUT + act
 	var result, code = createOkResponse(
 		dummyResponseObject,
+		dummyEncoderObject,
 	)
 
 	// assert
@@ -55,6 +53,7 @@ func TestCreateOkResponse_DirectNilContent(t *testing.T) {
 	// SUT + act
 	var result, code = createOkResponse(
 		dummyResponseObject,
+		&dummyEncoder{t: t},
 	)
 
 	// assert
@@ -76,6 +75,7 @@ func TestCreateOkResponse_IndirectNilContent(t *testing.T) {
 	// SUT + act
 	var result, code = createOkResponse(
 		dummyResponseObject,
+		&dummyEncoder{t: t},
 	)
 
 	// assert
@@ -90,26 +90,70 @@ func TestCreateOkResponse_ValidContent(t *testing.T) {
 	// arrange
 	var dummyResponseObject = "some response content"
 	var dummyResponseMessage = "some response message"
+	var dummyEncoderObject = &dummyEncoder{
+		t:        t,
+		expected: dummyResponseObject,
+		encoded:  []byte(dummyResponseMessage),
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, code = createOkResponse(
+		dummyResponseObject,
+		dummyEncoderObject,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseMessage, result)
+	assert.Equal(t, http.StatusOK, code)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCreateOkResponse_EncodeError(t *testing.T) {
+	// arrange
+	var dummyResponseObject = "some response content"
+	var dummyEncodeError = errors.New("some encode error")
+	var dummyEncoderObject = &dummyEncoder{
+		t:        t,
+		expected: dummyResponseObject,
+		err:      dummyEncodeError,
+	}
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+	var dummyResponseMessage = "some response message"
+	var dummyStatusCode = rand.Int()
 
 	// mock
 	createMock(t)
 
 	// expect
-	jsonutilMarshalIgnoreErrorExpected = 1
-	jsonutilMarshalIgnoreError = func(v interface{}) string {
-		jsonutilMarshalIgnoreErrorCalled++
-		assert.Equal(t, dummyResponseObject, v)
-		return dummyResponseMessage
+	apperrorGetGeneralFailureErrorExpected = 1
+	apperrorGetGeneralFailureError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetGeneralFailureErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyEncodeError, innerErrors[0])
+		return dummyAppError
+	}
+	createErrorResponseFuncExpected = 1
+	createErrorResponseFunc = func(err error, encoder model.Encoder) (string, int) {
+		createErrorResponseFuncCalled++
+		assert.Equal(t, dummyAppError, err)
+		assert.Equal(t, dummyEncoderObject, encoder)
+		return dummyResponseMessage, dummyStatusCode
 	}
 
 	// SUT + act
 	var result, code = createOkResponse(
 		dummyResponseObject,
+		dummyEncoderObject,
 	)
 
 	// assert
 	assert.Equal(t, dummyResponseMessage, result)
-	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, dummyStatusCode, code)
 
 	// verify
 	verifyAll(t)
@@ -188,7 +232,7 @@ func TestGenerateErrorResponse(t *testing.T) {
 	// assert
 	assert.Equal(t, expectedCode, result.Code)
 	assert.Equal(t, expectedMessages, result.Messages)
-	assert.Equal(t, expectedExtraData, result.ExtraData)
+	assert.Equal(t, extraDataModel(expectedExtraData), result.ExtraData)
 
 	// verify
 	verifyAll(t)
@@ -211,6 +255,11 @@ func TestCreateErrorResponse(t *testing.T) {
 		ExtraData: map[string]string{"foo": "bar", "test": "me"},
 	}
 	var dummyResponseMessage = "some response message"
+	var dummyEncoderObject = &dummyEncoder{
+		t:        t,
+		expected: dummyErrorResponseModel,
+		encoded:  []byte(dummyResponseMessage),
+	}
 
 	// mock
 	createMock(t)
@@ -228,16 +277,11 @@ func TestCreateErrorResponse(t *testing.T) {
 		assert.Equal(t, dummyAppError, appError)
 		return dummyErrorResponseModel
 	}
-	jsonutilMarshalIgnoreErrorExpected = 1
-	jsonutilMarshalIgnoreError = func(v interface{}) string {
-		jsonutilMarshalIgnoreErrorCalled++
-		assert.Equal(t, dummyErrorResponseModel, v)
-		return dummyResponseMessage
-	}
 
 	// SUT + act
 	var result, code = createErrorResponse(
 		dummyError,
+		dummyEncoderObject,
 	)
 
 	// assert
@@ -252,20 +296,26 @@ func TestWriteResponse(t *testing.T) {
 	// arrange
 	var dummyHeader = make(http.Header)
 	var dummyStatusCode = rand.Int()
-	var dummyStatusCodeString = strconv.Itoa(dummyStatusCode)
+	var dummyEvaluatedStatusCode = rand.Int()
+	var dummyStatusCodeString = strconv.Itoa(dummyEvaluatedStatusCode)
 	var dummyStatusName = "some status name"
 	var dummyResponseMessage = "some response message"
-	var dummyResponseBytes = []byte(dummyResponseMessage)
+	var dummyEvaluatedMessage = "some evaluated message"
+	var dummyCompressedBody = "some compressed body"
+	var dummyResponseBytes = []byte(dummyEvaluatedMessage)
+	var dummyHTTPRequest = &http.Request{}
 	var dummyResponseWriter = &dummyResponseWriter{
 		t,
 		&dummyHeader,
-		&dummyStatusCode,
+		&dummyEvaluatedStatusCode,
 		&dummyResponseBytes,
 	}
 	var dummySessionObject = &dummySession{
 		t:              t,
+		httpRequest:    dummyHTTPRequest,
 		responseWriter: dummyResponseWriter,
 	}
+	var dummyContentType = "some content type"
 
 	// mock
 	createMock(t)
@@ -279,7 +329,7 @@ func TestWriteResponse(t *testing.T) {
 	httpStatusTextExpected = 1
 	httpStatusText = func(code int) string {
 		httpStatusTextCalled++
-		assert.Equal(t, dummyStatusCode, code)
+		assert.Equal(t, dummyEvaluatedStatusCode, code)
 		return dummyStatusName
 	}
 	loggerAPIResponseExpected = 1
@@ -291,14 +341,174 @@ func TestWriteResponse(t *testing.T) {
 		assert.Equal(t, dummyResponseMessage, messageFormat)
 		assert.Equal(t, 0, len(parameters))
 	}
+	headerutilSetCorrelationIDHeaderExpected = 1
+	headerutilSetCorrelationIDHeader = func(session sessionModel.Session, header http.Header) {
+		headerutilSetCorrelationIDHeaderCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyHeader, header)
+	}
+	compressResponseFuncExpected = 1
+	compressResponseFunc = func(httpRequest *http.Request, header http.Header, statusCode int, contentType string, responseMessage string) string {
+		compressResponseFuncCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyHeader, header)
+		assert.Equal(t, dummyStatusCode, statusCode)
+		assert.Equal(t, dummyContentType, contentType)
+		assert.Equal(t, dummyResponseMessage, responseMessage)
+		return dummyCompressedBody
+	}
+	evaluateConditionalFuncExpected = 1
+	evaluateConditionalFunc = func(httpRequest *http.Request, header http.Header, statusCode int, responseMessage string) (string, int) {
+		evaluateConditionalFuncCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyHeader, header)
+		assert.Equal(t, dummyStatusCode, statusCode)
+		assert.Equal(t, dummyCompressedBody, responseMessage)
+		return dummyEvaluatedMessage, dummyEvaluatedStatusCode
+	}
 
 	// SUT + act
 	writeResponse(
 		dummySessionObject,
 		dummyStatusCode,
 		dummyResponseMessage,
+		dummyContentType,
 	)
 
+	// assert
+	assert.Equal(t, dummyContentType, dummyHeader.Get("Content-Type"))
+	assert.Equal(t, "Accept", dummyHeader.Get("Vary"))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWriteResponse_NotModified(t *testing.T) {
+	// arrange
+	var dummyHeader = make(http.Header)
+	var dummyStatusCode = rand.Int()
+	var dummyEvaluatedStatusCode = http.StatusNotModified
+	var dummyStatusCodeString = strconv.Itoa(dummyEvaluatedStatusCode)
+	var dummyStatusName = "some status name"
+	var dummyResponseMessage = "some response message"
+	var dummyEvaluatedMessage = ""
+	var dummyCompressedBody = "some compressed body"
+	var dummyResponseBytes = []byte(dummyEvaluatedMessage)
+	var dummyHTTPRequest = &http.Request{}
+	var dummyResponseWriter = &dummyResponseWriter{
+		t,
+		&dummyHeader,
+		&dummyEvaluatedStatusCode,
+		&dummyResponseBytes,
+	}
+	var dummySessionObject = &dummySession{
+		t:              t,
+		httpRequest:    dummyHTTPRequest,
+		responseWriter: dummyResponseWriter,
+	}
+	var dummyContentType = "some content type"
+
+	// mock
+	createMock(t)
+
+	// expect
+	strconvItoaExpected = 1
+	strconvItoa = func(i int) string {
+		strconvItoaCalled++
+		return strconv.Itoa(i)
+	}
+	httpStatusTextExpected = 1
+	httpStatusText = func(code int) string {
+		httpStatusTextCalled++
+		assert.Equal(t, dummyEvaluatedStatusCode, code)
+		return dummyStatusName
+	}
+	loggerAPIResponseExpected = 1
+	loggerAPIResponse = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAPIResponseCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyStatusCodeString, subcategory)
+		assert.Equal(t, dummyStatusName, category)
+		assert.Equal(t, "", messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+	headerutilSetCorrelationIDHeaderExpected = 1
+	headerutilSetCorrelationIDHeader = func(session sessionModel.Session, header http.Header) {
+		headerutilSetCorrelationIDHeaderCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyHeader, header)
+	}
+	compressResponseFuncExpected = 1
+	compressResponseFunc = func(httpRequest *http.Request, header http.Header, statusCode int, contentType string, responseMessage string) string {
+		compressResponseFuncCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyHeader, header)
+		assert.Equal(t, dummyStatusCode, statusCode)
+		assert.Equal(t, dummyContentType, contentType)
+		assert.Equal(t, dummyResponseMessage, responseMessage)
+		return dummyCompressedBody
+	}
+	evaluateConditionalFuncExpected = 1
+	evaluateConditionalFunc = func(httpRequest *http.Request, header http.Header, statusCode int, responseMessage string) (string, int) {
+		evaluateConditionalFuncCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyHeader, header)
+		assert.Equal(t, dummyStatusCode, statusCode)
+		assert.Equal(t, dummyCompressedBody, responseMessage)
+		return dummyEvaluatedMessage, dummyEvaluatedStatusCode
+	}
+
+	// SUT + act
+	writeResponse(
+		dummySessionObject,
+		dummyStatusCode,
+		dummyResponseMessage,
+		dummyContentType,
+	)
+
+	// assert
+	assert.Equal(t, dummyContentType, dummyHeader.Get("Content-Type"))
+	assert.Equal(t, "Accept", dummyHeader.Get("Vary"))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestConstructResponse_Error_WithEncodedCustomization(t *testing.T) {
+	// arrange
+	var dummyResponseObject = "some response content"
+	var dummyResponseError = errors.New("some response error")
+	var dummyResponseMessage = "some response message"
+	var dummyStatusCode = rand.Int()
+	var dummyEncoderObject = &dummyEncoder{t: t}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationCreateEncodedErrorResponseFuncExpected = 1
+	customization.CreateEncodedErrorResponseFunc = func(err error, encoder model.Encoder) (string, int) {
+		customizationCreateEncodedErrorResponseFuncCalled++
+		assert.Equal(t, dummyResponseError, err)
+		assert.Equal(t, dummyEncoderObject, encoder)
+		return dummyResponseMessage, dummyStatusCode
+	}
+	customization.CreateErrorResponseFunc = func(err error) (string, int) {
+		customizationCreateErrorResponseFuncCalled++
+		return "", 0
+	}
+
+	// SUT + act
+	var message, code = constructResponse(
+		dummyResponseObject,
+		dummyResponseError,
+		dummyEncoderObject,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseMessage, message)
+	assert.Equal(t, dummyStatusCode, code)
+
 	// verify
 	verifyAll(t)
 }
@@ -309,6 +519,7 @@ func TestConstructResponse_Error_WithCustomization(t *testing.T) {
 	var dummyResponseError = errors.New("some response error")
 	var dummyResponseMessage = "some response message"
 	var dummyStatusCode = rand.Int()
+	var dummyEncoderObject = &dummyEncoder{t: t}
 
 	// mock
 	createMock(t)
@@ -325,6 +536,7 @@ func TestConstructResponse_Error_WithCustomization(t *testing.T) {
 	var message, code = constructResponse(
 		dummyResponseObject,
 		dummyResponseError,
+		dummyEncoderObject,
 	)
 
 	// assert
@@ -341,15 +553,17 @@ func TestConstructResponse_Error_NoCustomization(t *testing.T) {
 	var dummyResponseError = errors.New("some response error")
 	var dummyResponseMessage = "some response message"
 	var dummyStatusCode = rand.Int()
+	var dummyEncoderObject = &dummyEncoder{t: t}
 
 	// mock
 	createMock(t)
 
 	// expect
 	createErrorResponseFuncExpected = 1
-	createErrorResponseFunc = func(err error) (string, int) {
+	createErrorResponseFunc = func(err error, encoder model.Encoder) (string, int) {
 		createErrorResponseFuncCalled++
 		assert.Equal(t, dummyResponseError, err)
+		assert.Equal(t, dummyEncoderObject, encoder)
 		return dummyResponseMessage, dummyStatusCode
 	}
 
@@ -357,6 +571,7 @@ func TestConstructResponse_Error_NoCustomization(t *testing.T) {
 	var message, code = constructResponse(
 		dummyResponseObject,
 		dummyResponseError,
+		dummyEncoderObject,
 	)
 
 	// assert
@@ -373,15 +588,17 @@ func TestConstructResponse_NoError(t *testing.T) {
 	var dummyResponseError apperrorModel.AppError
 	var dummyResponseMessage = "some response message"
 	var dummyStatusCode = rand.Int()
+	var dummyEncoderObject = &dummyEncoder{t: t}
 
 	// mock
 	createMock(t)
 
 	// expect
 	createOkResponseFuncExpected = 1
-	createOkResponseFunc = func(responseContent interface{}) (string, int) {
+	createOkResponseFunc = func(responseContent interface{}, encoder model.Encoder) (string, int) {
 		createOkResponseFuncCalled++
 		assert.Equal(t, dummyResponseObject, responseContent)
+		assert.Equal(t, dummyEncoderObject, encoder)
 		return dummyResponseMessage, dummyStatusCode
 	}
 
@@ -389,6 +606,7 @@ func TestConstructResponse_NoError(t *testing.T) {
 	var message, code = constructResponse(
 		dummyResponseObject,
 		dummyResponseError,
+		dummyEncoderObject,
 	)
 
 	// assert
@@ -406,24 +624,36 @@ func TestWrite_NotOverrided(t *testing.T) {
 	var dummySessionObject = &dummySession{t: t}
 	var dummyResponseMessage = "some response message"
 	var dummyStatusCode = rand.Int()
+	var dummyEncoderObject = &dummyEncoder{
+		t:           t,
+		contentType: "some content type",
+	}
 
 	// mock
 	createMock(t)
 
 	// expect
+	getEncoderFuncExpected = 1
+	getEncoderFunc = func(session sessionModel.Session) (model.Encoder, error) {
+		getEncoderFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		return dummyEncoderObject, nil
+	}
 	constructResponseFuncExpected = 1
-	constructResponseFunc = func(responseObject interface{}, responseError error) (string, int) {
+	constructResponseFunc = func(responseObject interface{}, responseError error, encoder model.Encoder) (string, int) {
 		constructResponseFuncCalled++
 		assert.Equal(t, dummyResponseObject, responseObject)
 		assert.Equal(t, dummyResponseError, responseError)
+		assert.Equal(t, dummyEncoderObject, encoder)
 		return dummyResponseMessage, dummyStatusCode
 	}
 	writeResponseFuncExpected = 1
-	writeResponseFunc = func(session sessionModel.Session, statusCode int, responseMessage string) {
+	writeResponseFunc = func(session sessionModel.Session, statusCode int, responseMessage string, contentType string) {
 		writeResponseFuncCalled++
 		assert.Equal(t, dummySessionObject, session)
 		assert.Equal(t, dummyStatusCode, statusCode)
 		assert.Equal(t, dummyResponseMessage, responseMessage)
+		assert.Equal(t, dummyEncoderObject.contentType, contentType)
 	}
 
 	// SUT + act
@@ -437,6 +667,56 @@ func TestWrite_NotOverrided(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestWrite_NotAcceptable(t *testing.T) {
+	// arrange
+	var dummyResponseObject = "some response content"
+	var dummySessionObject = &dummySession{t: t}
+	var dummyNegotiateError = errors.New("some negotiate error")
+	var dummyResponseMessage = "some response message"
+	var dummyStatusCode = rand.Int()
+	var dummyEncoderObject = &dummyEncoder{
+		t:           t,
+		contentType: "some content type",
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getEncoderFuncExpected = 1
+	getEncoderFunc = func(session sessionModel.Session) (model.Encoder, error) {
+		getEncoderFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		return dummyEncoderObject, dummyNegotiateError
+	}
+	constructResponseFuncExpected = 1
+	constructResponseFunc = func(responseObject interface{}, responseError error, encoder model.Encoder) (string, int) {
+		constructResponseFuncCalled++
+		assert.Equal(t, dummyResponseObject, responseObject)
+		assert.Equal(t, dummyNegotiateError, responseError)
+		assert.Equal(t, dummyEncoderObject, encoder)
+		return dummyResponseMessage, dummyStatusCode
+	}
+	writeResponseFuncExpected = 1
+	writeResponseFunc = func(session sessionModel.Session, statusCode int, responseMessage string, contentType string) {
+		writeResponseFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyStatusCode, statusCode)
+		assert.Equal(t, dummyResponseMessage, responseMessage)
+		assert.Equal(t, dummyEncoderObject.contentType, contentType)
+	}
+
+	// SUT + act
+	Write(
+		dummySessionObject,
+		dummyResponseObject,
+		nil,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestWrite_Overrided(t *testing.T) {
 	// arrange
 	var dummyResponseObject = overrideResponse{}
@@ -444,16 +724,27 @@ func TestWrite_Overrided(t *testing.T) {
 	var dummySessionObject = &dummySession{t: t}
 	var dummyResponseMessage = "some response message"
 	var dummyStatusCode = rand.Int()
+	var dummyEncoderObject = &dummyEncoder{
+		t:           t,
+		contentType: "some content type",
+	}
 
 	// mock
 	createMock(t)
 
 	// expect
+	getEncoderFuncExpected = 1
+	getEncoderFunc = func(session sessionModel.Session) (model.Encoder, error) {
+		getEncoderFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		return dummyEncoderObject, nil
+	}
 	constructResponseFuncExpected = 1
-	constructResponseFunc = func(responseObject interface{}, responseError error) (string, int) {
+	constructResponseFunc = func(responseObject interface{}, responseError error, encoder model.Encoder) (string, int) {
 		constructResponseFuncCalled++
 		assert.Equal(t, dummyResponseObject, responseObject)
 		assert.Equal(t, dummyResponseError, responseError)
+		assert.Equal(t, dummyEncoderObject, encoder)
 		return dummyResponseMessage, dummyStatusCode
 	}
 
@@ -482,9 +773,9 @@ func TestOverride(t *testing.T) {
 		nil,
 	}
 	var dummySessionObject = &dummySession{
-		t,
-		dummyHTTPRequest,
-		dummyResponseWriter,
+		t:              t,
+		httpRequest:    dummyHTTPRequest,
+		responseWriter: dummyResponseWriter,
 	}
 	var dummyCallbackExpected int
 	var dummyCallbackCalled int
@@ -3,11 +3,13 @@ package server
 import (
 	"context"
 	"os/signal"
+	"time"
 
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
 	"github.com/zhongjie-cai/WebServiceTemplate/certificate"
 	"github.com/zhongjie-cai/WebServiceTemplate/config"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/health"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/register"
 )
 
@@ -15,17 +17,22 @@ import (
 var (
 	certificateGetServerCertificate = certificate.GetServerCertificate
 	certificateGetCaCertPool        = certificate.GetCaCertPool
+	apperrorGetCustomError          = apperror.GetCustomError
 	apperrorWrapSimpleError         = apperror.WrapSimpleError
 	registerInstantiate             = register.Instantiate
+	healthSetReadiness              = health.SetReadiness
 	loggerAppRoot                   = logger.AppRoot
 	signalNotify                    = signal.Notify
+	timeSleep                       = time.Sleep
 	contextWithTimeout              = context.WithTimeout
 	contextBackground               = context.Background
 	configGraceShutdownWaitTime     = config.GraceShutdownWaitTime
+	getDurationFunc                 = getDuration
+	getMaxHeaderBytesFunc           = getMaxHeaderBytes
 	createServerFunc                = createServer
 	listenAndServeFunc              = listenAndServe
 	shutDownFunc                    = shutDown
 	consolidateErrorFunc            = consolidateError
+	getListenersFunc                = getListeners
 	runServerFunc                   = runServer
-	haltFunc                        = Halt
 )
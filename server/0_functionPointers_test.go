@@ -13,10 +13,13 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorEnum "github.com/zhongjie-cai/WebServiceTemplate/apperror/enum"
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/certificate"
 	"github.com/zhongjie-cai/WebServiceTemplate/config"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/health"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/register"
 )
 
@@ -27,18 +30,28 @@ var (
 	certificateGetCaCertPoolCalled          int
 	apperrorWrapSimpleErrorExpected         int
 	apperrorWrapSimpleErrorCalled           int
+	apperrorGetCustomErrorExpected          int
+	apperrorGetCustomErrorCalled            int
 	registerInstantiateExpected             int
 	registerInstantiateCalled               int
+	healthSetReadinessExpected              int
+	healthSetReadinessCalled                int
 	loggerAppRootExpected                   int
 	loggerAppRootCalled                     int
 	signalNotifyExpected                    int
 	signalNotifyCalled                      int
+	timeSleepExpected                       int
+	timeSleepCalled                         int
 	contextWithTimeoutExpected              int
 	contextWithTimeoutCalled                int
 	contextBackgroundExpected               int
 	contextBackgroundCalled                 int
 	configGraceShutdownWaitTimeExpected     int
 	configGraceShutdownWaitTimeCalled       int
+	getDurationFuncExpected                 int
+	getDurationFuncCalled                   int
+	getMaxHeaderBytesFuncExpected           int
+	getMaxHeaderBytesFuncCalled             int
 	createServerFuncExpected                int
 	createServerFuncCalled                  int
 	listenAndServeFuncExpected              int
@@ -47,10 +60,10 @@ var (
 	shutDownFuncCalled                      int
 	consolidateErrorFuncExpected            int
 	consolidateErrorFuncCalled              int
+	getListenersFuncExpected                int
+	getListenersFuncCalled                  int
 	runServerFuncExpected                   int
 	runServerFuncCalled                     int
-	haltFuncExpected                        int
-	haltFuncCalled                          int
 )
 
 func createMock(t *testing.T) {
@@ -72,12 +85,23 @@ func createMock(t *testing.T) {
 		apperrorWrapSimpleErrorCalled++
 		return nil
 	}
+	apperrorGetCustomErrorExpected = 0
+	apperrorGetCustomErrorCalled = 0
+	apperrorGetCustomError = func(errorCode apperrorEnum.Code, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
+		apperrorGetCustomErrorCalled++
+		return nil
+	}
 	registerInstantiateExpected = 0
 	registerInstantiateCalled = 0
-	registerInstantiate = func() (*mux.Router, error) {
+	registerInstantiate = func(endpoints []string) (*mux.Router, error) {
 		registerInstantiateCalled++
 		return nil, nil
 	}
+	healthSetReadinessExpected = 0
+	healthSetReadinessCalled = 0
+	healthSetReadiness = func(ready bool) {
+		healthSetReadinessCalled++
+	}
 	loggerAppRootExpected = 0
 	loggerAppRootCalled = 0
 	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
@@ -88,6 +112,11 @@ func createMock(t *testing.T) {
 	signalNotify = func(c chan<- os.Signal, sig ...os.Signal) {
 		signalNotifyCalled++
 	}
+	timeSleepExpected = 0
+	timeSleepCalled = 0
+	timeSleep = func(d time.Duration) {
+		timeSleepCalled++
+	}
 	contextWithTimeoutExpected = 0
 	contextWithTimeoutCalled = 0
 	contextWithTimeout = func(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
@@ -106,11 +135,23 @@ func createMock(t *testing.T) {
 		configGraceShutdownWaitTimeCalled++
 		return 0
 	}
+	getDurationFuncExpected = 0
+	getDurationFuncCalled = 0
+	getDurationFunc = func(customizedFunc func() time.Duration) time.Duration {
+		getDurationFuncCalled++
+		return 0
+	}
+	getMaxHeaderBytesFuncExpected = 0
+	getMaxHeaderBytesFuncCalled = 0
+	getMaxHeaderBytesFunc = func() int {
+		getMaxHeaderBytesFuncCalled++
+		return 0
+	}
 	createServerFuncExpected = 0
 	createServerFuncCalled = 0
-	createServerFunc = func(serveHTTPS bool, validateClientCert bool, appPort string, router *mux.Router) *http.Server {
+	createServerFunc = func(serveHTTPS bool, validateClientCert bool, appPort string, router *mux.Router) (*http.Server, error) {
 		createServerFuncCalled++
-		return nil
+		return nil, nil
 	}
 	listenAndServeFuncExpected = 0
 	listenAndServeFuncCalled = 0
@@ -126,21 +167,22 @@ func createMock(t *testing.T) {
 	}
 	consolidateErrorFuncExpected = 0
 	consolidateErrorFuncCalled = 0
-	consolidateErrorFunc = func(hostError error, shutdownError error) error {
+	consolidateErrorFunc = func(hostErrors []error, shutdownErrors []error) error {
 		consolidateErrorFuncCalled++
 		return nil
 	}
+	getListenersFuncExpected = 0
+	getListenersFuncCalled = 0
+	getListenersFunc = func(serveHTTPS bool, validateClientCert bool, appPort string) []model.Listener {
+		getListenersFuncCalled++
+		return nil
+	}
 	runServerFuncExpected = 0
 	runServerFuncCalled = 0
-	runServerFunc = func(serveHTTPS bool, validateClientCert bool, appPort string, router *mux.Router) error {
+	runServerFunc = func(listeners []model.Listener, routers []*mux.Router) error {
 		runServerFuncCalled++
 		return nil
 	}
-	haltFuncExpected = 0
-	haltFuncCalled = 0
-	haltFunc = func() {
-		haltFuncCalled++
-	}
 }
 
 func verifyAll(t *testing.T) {
@@ -150,18 +192,28 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, certificateGetCaCertPoolExpected, certificateGetCaCertPoolCalled, "Unexpected number of calls to certificateGetCaCertPool")
 	apperrorWrapSimpleError = apperror.WrapSimpleError
 	assert.Equal(t, apperrorWrapSimpleErrorExpected, apperrorWrapSimpleErrorCalled, "Unexpected number of calls to apperrorWrapSimpleError")
+	apperrorGetCustomError = apperror.GetCustomError
+	assert.Equal(t, apperrorGetCustomErrorExpected, apperrorGetCustomErrorCalled, "Unexpected number of calls to apperrorGetCustomError")
 	registerInstantiate = register.Instantiate
 	assert.Equal(t, registerInstantiateExpected, registerInstantiateCalled, "Unexpected number of calls to registerInstantiate")
+	healthSetReadiness = health.SetReadiness
+	assert.Equal(t, healthSetReadinessExpected, healthSetReadinessCalled, "Unexpected number of calls to healthSetReadiness")
 	loggerAppRoot = logger.AppRoot
 	assert.Equal(t, loggerAppRootExpected, loggerAppRootCalled, "Unexpected number of calls to loggerAppRoot")
 	signalNotify = signal.Notify
 	assert.Equal(t, signalNotifyExpected, signalNotifyCalled, "Unexpected number of calls to signalNotify")
+	timeSleep = time.Sleep
+	assert.Equal(t, timeSleepExpected, timeSleepCalled, "Unexpected number of calls to timeSleep")
 	contextWithTimeout = context.WithTimeout
 	assert.Equal(t, contextWithTimeoutExpected, contextWithTimeoutCalled, "Unexpected number of calls to contextWithTimeout")
 	contextBackground = context.Background
 	assert.Equal(t, contextBackgroundExpected, contextBackgroundCalled, "Unexpected number of calls to contextBackground")
 	configGraceShutdownWaitTime = config.GraceShutdownWaitTime
 	assert.Equal(t, configGraceShutdownWaitTimeExpected, configGraceShutdownWaitTimeCalled, "Unexpected number of calls to configGraceShutdownWaitTime")
+	getDurationFunc = getDuration
+	assert.Equal(t, getDurationFuncExpected, getDurationFuncCalled, "Unexpected number of calls to getDurationFunc")
+	getMaxHeaderBytesFunc = getMaxHeaderBytes
+	assert.Equal(t, getMaxHeaderBytesFuncExpected, getMaxHeaderBytesFuncCalled, "Unexpected number of calls to getMaxHeaderBytesFunc")
 	createServerFunc = createServer
 	assert.Equal(t, createServerFuncExpected, createServerFuncCalled, "Unexpected number of calls to createServerFunc")
 	listenAndServeFunc = listenAndServe
@@ -170,8 +222,8 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, shutDownFuncExpected, shutDownFuncCalled, "Unexpected number of calls to shutDownFunc")
 	consolidateErrorFunc = consolidateError
 	assert.Equal(t, consolidateErrorFuncExpected, consolidateErrorFuncCalled, "Unexpected number of calls to consolidateErrorFunc")
+	getListenersFunc = getListeners
+	assert.Equal(t, getListenersFuncExpected, getListenersFuncCalled, "Unexpected number of calls to getListenersFunc")
 	runServerFunc = runServer
 	assert.Equal(t, runServerFuncExpected, runServerFuncCalled, "Unexpected number of calls to runServerFunc")
-	haltFunc = Halt
-	assert.Equal(t, haltFuncExpected, haltFuncCalled, "Unexpected number of calls to haltFunc")
 }
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
+)
+
+// func pointers for injection / testing: auth.go
+var (
+	fmtErrorf                       = fmt.Errorf
+	apperrorGetUnauthorized         = apperror.GetUnauthorized
+	apperrorGetAccessForbiddenError = apperror.GetAccessForbiddenError
+	authenticateFunc                = authenticate
+	hasAllScopesFunc                = hasAllScopes
+	hasAnyRoleFunc                  = hasAnyRole
+)
+
+// func pointers for injection / testing: jwt.go
+var (
+	base64RawURLEncodingDecodeString = base64.RawURLEncoding.DecodeString
+	ioutilReadFile                   = ioutil.ReadFile
+	jsonUnmarshal                    = json.Unmarshal
+	stringsFields                    = strings.Fields
+	stringsEqualFold                 = strings.EqualFold
+	stringsSplit                     = strings.Split
+	stringsTrimSpace                 = strings.TrimSpace
+	timeutilGetTimeNowUTC            = timeutil.GetTimeNowUTC
+	parseRSAKeyFunc                  = parseRSAKey
+	loadJWKSFunc                     = loadJWKS
+	decodeSegmentFunc                = decodeSegment
+	getRSAKeyFunc                    = getRSAKey
+	verifySignatureFunc              = verifySignature
+	getTimeClaimFunc                 = getTimeClaim
+	getStringListClaimFunc           = getStringListClaim
+	containsStringFunc               = containsString
+	validateClaimsFunc               = validateClaims
+)
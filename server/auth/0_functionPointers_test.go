@@ -0,0 +1,421 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
+	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
+)
+
+var (
+	fmtErrorfExpected                        int
+	fmtErrorfCalled                          int
+	apperrorGetUnauthorizedExpected          int
+	apperrorGetUnauthorizedCalled            int
+	apperrorGetAccessForbiddenErrorExpected  int
+	apperrorGetAccessForbiddenErrorCalled    int
+	authenticateFuncExpected                 int
+	authenticateFuncCalled                   int
+	hasAllScopesFuncExpected                 int
+	hasAllScopesFuncCalled                   int
+	hasAnyRoleFuncExpected                   int
+	hasAnyRoleFuncCalled                     int
+	base64RawURLEncodingDecodeStringExpected int
+	base64RawURLEncodingDecodeStringCalled   int
+	ioutilReadFileExpected                   int
+	ioutilReadFileCalled                     int
+	jsonUnmarshalExpected                    int
+	jsonUnmarshalCalled                      int
+	stringsFieldsExpected                    int
+	stringsFieldsCalled                      int
+	stringsEqualFoldExpected                 int
+	stringsEqualFoldCalled                   int
+	stringsSplitExpected                     int
+	stringsSplitCalled                       int
+	stringsTrimSpaceExpected                 int
+	stringsTrimSpaceCalled                   int
+	timeutilGetTimeNowUTCExpected            int
+	timeutilGetTimeNowUTCCalled              int
+	parseRSAKeyFuncExpected                  int
+	parseRSAKeyFuncCalled                    int
+	loadJWKSFuncExpected                     int
+	loadJWKSFuncCalled                       int
+	decodeSegmentFuncExpected                int
+	decodeSegmentFuncCalled                  int
+	getRSAKeyFuncExpected                    int
+	getRSAKeyFuncCalled                      int
+	verifySignatureFuncExpected              int
+	verifySignatureFuncCalled                int
+	getTimeClaimFuncExpected                 int
+	getTimeClaimFuncCalled                   int
+	getStringListClaimFuncExpected           int
+	getStringListClaimFuncCalled             int
+	containsStringFuncExpected               int
+	containsStringFuncCalled                 int
+	validateClaimsFuncExpected               int
+	validateClaimsFuncCalled                 int
+	customizationAuthenticatorsExpected      int
+	customizationAuthenticatorsCalled        int
+)
+
+func createMock(t *testing.T) {
+	fmtErrorfExpected = 0
+	fmtErrorfCalled = 0
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		return fmt.Errorf(format, a...)
+	}
+	apperrorGetUnauthorizedExpected = 0
+	apperrorGetUnauthorizedCalled = 0
+	apperrorGetUnauthorized = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetUnauthorizedCalled++
+		return nil
+	}
+	apperrorGetAccessForbiddenErrorExpected = 0
+	apperrorGetAccessForbiddenErrorCalled = 0
+	apperrorGetAccessForbiddenError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetAccessForbiddenErrorCalled++
+		return nil
+	}
+	authenticateFuncExpected = 0
+	authenticateFuncCalled = 0
+	authenticateFunc = func(httpRequest *http.Request) (*model.Principal, error) {
+		authenticateFuncCalled++
+		return nil, nil
+	}
+	hasAllScopesFuncExpected = 0
+	hasAllScopesFuncCalled = 0
+	hasAllScopesFunc = func(principal *model.Principal, scopes []string) bool {
+		hasAllScopesFuncCalled++
+		return false
+	}
+	hasAnyRoleFuncExpected = 0
+	hasAnyRoleFuncCalled = 0
+	hasAnyRoleFunc = func(principal *model.Principal, roles []string) bool {
+		hasAnyRoleFuncCalled++
+		return false
+	}
+	base64RawURLEncodingDecodeStringExpected = 0
+	base64RawURLEncodingDecodeStringCalled = 0
+	base64RawURLEncodingDecodeString = func(s string) ([]byte, error) {
+		base64RawURLEncodingDecodeStringCalled++
+		return nil, nil
+	}
+	ioutilReadFileExpected = 0
+	ioutilReadFileCalled = 0
+	ioutilReadFile = func(filename string) ([]byte, error) {
+		ioutilReadFileCalled++
+		return nil, nil
+	}
+	jsonUnmarshalExpected = 0
+	jsonUnmarshalCalled = 0
+	jsonUnmarshal = func(data []byte, v interface{}) error {
+		jsonUnmarshalCalled++
+		return nil
+	}
+	stringsFieldsExpected = 0
+	stringsFieldsCalled = 0
+	stringsFields = func(s string) []string {
+		stringsFieldsCalled++
+		return nil
+	}
+	stringsEqualFoldExpected = 0
+	stringsEqualFoldCalled = 0
+	stringsEqualFold = func(s, t string) bool {
+		stringsEqualFoldCalled++
+		return false
+	}
+	stringsSplitExpected = 0
+	stringsSplitCalled = 0
+	stringsSplit = func(s, sep string) []string {
+		stringsSplitCalled++
+		return nil
+	}
+	stringsTrimSpaceExpected = 0
+	stringsTrimSpaceCalled = 0
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return ""
+	}
+	timeutilGetTimeNowUTCExpected = 0
+	timeutilGetTimeNowUTCCalled = 0
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Time{}
+	}
+	parseRSAKeyFuncExpected = 0
+	parseRSAKeyFuncCalled = 0
+	parseRSAKeyFunc = func(key jsonWebKey) (*rsa.PublicKey, error) {
+		parseRSAKeyFuncCalled++
+		return nil, nil
+	}
+	loadJWKSFuncExpected = 0
+	loadJWKSFuncCalled = 0
+	loadJWKSFunc = func(jwksFile string) (map[string]*rsa.PublicKey, error) {
+		loadJWKSFuncCalled++
+		return nil, nil
+	}
+	decodeSegmentFuncExpected = 0
+	decodeSegmentFuncCalled = 0
+	decodeSegmentFunc = func(segment string, dataTemplate interface{}) error {
+		decodeSegmentFuncCalled++
+		return nil
+	}
+	getRSAKeyFuncExpected = 0
+	getRSAKeyFuncCalled = 0
+	getRSAKeyFunc = func(rsaKeys map[string]*rsa.PublicKey, keyID string) *rsa.PublicKey {
+		getRSAKeyFuncCalled++
+		return nil
+	}
+	verifySignatureFuncExpected = 0
+	verifySignatureFuncCalled = 0
+	verifySignatureFunc = func(settings model.JWTSettings, rsaKeys map[string]*rsa.PublicKey, header jwtHeader, signingInput string, signature []byte) error {
+		verifySignatureFuncCalled++
+		return nil
+	}
+	getTimeClaimFuncExpected = 0
+	getTimeClaimFuncCalled = 0
+	getTimeClaimFunc = func(claims map[string]interface{}, name string) (time.Time, bool) {
+		getTimeClaimFuncCalled++
+		return time.Time{}, false
+	}
+	getStringListClaimFuncExpected = 0
+	getStringListClaimFuncCalled = 0
+	getStringListClaimFunc = func(claims map[string]interface{}, name string) []string {
+		getStringListClaimFuncCalled++
+		return nil
+	}
+	containsStringFuncExpected = 0
+	containsStringFuncCalled = 0
+	containsStringFunc = func(values []string, value string) bool {
+		containsStringFuncCalled++
+		return false
+	}
+	validateClaimsFuncExpected = 0
+	validateClaimsFuncCalled = 0
+	validateClaimsFunc = func(settings model.JWTSettings, claims map[string]interface{}) error {
+		validateClaimsFuncCalled++
+		return nil
+	}
+	customizationAuthenticatorsExpected = 0
+	customizationAuthenticatorsCalled = 0
+	customization.Authenticators = nil
+}
+
+func verifyAll(t *testing.T) {
+	fmtErrorf = fmt.Errorf
+	assert.Equal(t, fmtErrorfExpected, fmtErrorfCalled, "Unexpected number of calls to fmtErrorf")
+	apperrorGetUnauthorized = apperror.GetUnauthorized
+	assert.Equal(t, apperrorGetUnauthorizedExpected, apperrorGetUnauthorizedCalled, "Unexpected number of calls to apperrorGetUnauthorized")
+	apperrorGetAccessForbiddenError = apperror.GetAccessForbiddenError
+	assert.Equal(t, apperrorGetAccessForbiddenErrorExpected, apperrorGetAccessForbiddenErrorCalled, "Unexpected number of calls to apperrorGetAccessForbiddenError")
+	authenticateFunc = authenticate
+	assert.Equal(t, authenticateFuncExpected, authenticateFuncCalled, "Unexpected number of calls to authenticateFunc")
+	hasAllScopesFunc = hasAllScopes
+	assert.Equal(t, hasAllScopesFuncExpected, hasAllScopesFuncCalled, "Unexpected number of calls to hasAllScopesFunc")
+	hasAnyRoleFunc = hasAnyRole
+	assert.Equal(t, hasAnyRoleFuncExpected, hasAnyRoleFuncCalled, "Unexpected number of calls to hasAnyRoleFunc")
+	base64RawURLEncodingDecodeString = base64.RawURLEncoding.DecodeString
+	assert.Equal(t, base64RawURLEncodingDecodeStringExpected, base64RawURLEncodingDecodeStringCalled, "Unexpected number of calls to base64RawURLEncodingDecodeString")
+	ioutilReadFile = ioutil.ReadFile
+	assert.Equal(t, ioutilReadFileExpected, ioutilReadFileCalled, "Unexpected number of calls to ioutilReadFile")
+	jsonUnmarshal = json.Unmarshal
+	assert.Equal(t, jsonUnmarshalExpected, jsonUnmarshalCalled, "Unexpected number of calls to jsonUnmarshal")
+	stringsFields = strings.Fields
+	assert.Equal(t, stringsFieldsExpected, stringsFieldsCalled, "Unexpected number of calls to stringsFields")
+	stringsEqualFold = strings.EqualFold
+	assert.Equal(t, stringsEqualFoldExpected, stringsEqualFoldCalled, "Unexpected number of calls to stringsEqualFold")
+	stringsSplit = strings.Split
+	assert.Equal(t, stringsSplitExpected, stringsSplitCalled, "Unexpected number of calls to stringsSplit")
+	stringsTrimSpace = strings.TrimSpace
+	assert.Equal(t, stringsTrimSpaceExpected, stringsTrimSpaceCalled, "Unexpected number of calls to stringsTrimSpace")
+	timeutilGetTimeNowUTC = timeutil.GetTimeNowUTC
+	assert.Equal(t, timeutilGetTimeNowUTCExpected, timeutilGetTimeNowUTCCalled, "Unexpected number of calls to timeutilGetTimeNowUTC")
+	parseRSAKeyFunc = parseRSAKey
+	assert.Equal(t, parseRSAKeyFuncExpected, parseRSAKeyFuncCalled, "Unexpected number of calls to parseRSAKeyFunc")
+	loadJWKSFunc = loadJWKS
+	assert.Equal(t, loadJWKSFuncExpected, loadJWKSFuncCalled, "Unexpected number of calls to loadJWKSFunc")
+	decodeSegmentFunc = decodeSegment
+	assert.Equal(t, decodeSegmentFuncExpected, decodeSegmentFuncCalled, "Unexpected number of calls to decodeSegmentFunc")
+	getRSAKeyFunc = getRSAKey
+	assert.Equal(t, getRSAKeyFuncExpected, getRSAKeyFuncCalled, "Unexpected number of calls to getRSAKeyFunc")
+	verifySignatureFunc = verifySignature
+	assert.Equal(t, verifySignatureFuncExpected, verifySignatureFuncCalled, "Unexpected number of calls to verifySignatureFunc")
+	getTimeClaimFunc = getTimeClaim
+	assert.Equal(t, getTimeClaimFuncExpected, getTimeClaimFuncCalled, "Unexpected number of calls to getTimeClaimFunc")
+	getStringListClaimFunc = getStringListClaim
+	assert.Equal(t, getStringListClaimFuncExpected, getStringListClaimFuncCalled, "Unexpected number of calls to getStringListClaimFunc")
+	containsStringFunc = containsString
+	assert.Equal(t, containsStringFuncExpected, containsStringFuncCalled, "Unexpected number of calls to containsStringFunc")
+	validateClaimsFunc = validateClaims
+	assert.Equal(t, validateClaimsFuncExpected, validateClaimsFuncCalled, "Unexpected number of calls to validateClaimsFunc")
+	customization.Authenticators = nil
+	assert.Equal(t, customizationAuthenticatorsExpected, customizationAuthenticatorsCalled, "Unexpected number of calls to customization.Authenticators")
+}
+
+// mock structs
+type dummySession struct {
+	t           *testing.T
+	httpRequest *http.Request
+	attachment  map[string]interface{}
+}
+
+func (session *dummySession) GetID() uuid.UUID {
+	assert.Fail(session.t, "Unexpected call to GetID")
+	return uuid.Nil
+}
+
+func (session *dummySession) GetName() string {
+	assert.Fail(session.t, "Unexpected call to GetName")
+	return ""
+}
+
+func (session *dummySession) GetCorrelationID() string {
+	assert.Fail(session.t, "Unexpected call to GetCorrelationID")
+	return ""
+}
+
+func (session *dummySession) GetRequest() *http.Request {
+	if session.httpRequest == nil {
+		assert.Fail(session.t, "Unexpected call to GetRequest")
+	}
+	return session.httpRequest
+}
+
+func (session *dummySession) GetResponseWriter() http.ResponseWriter {
+	assert.Fail(session.t, "Unexpected call to GetResponseWriter")
+	return nil
+}
+
+func (session *dummySession) SetResponseETag(etag string, weak bool) {
+	assert.Fail(session.t, "Unexpected call to SetResponseETag")
+}
+
+func (session *dummySession) SetResponseLastModified(lastModified time.Time) {
+	assert.Fail(session.t, "Unexpected call to SetResponseLastModified")
+}
+
+func (session *dummySession) GetRequestBody(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestBody")
+	return nil
+}
+
+func (session *dummySession) GetRequestParameter(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestParameter")
+	return nil
+}
+
+func (session *dummySession) GetRequestQuery(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestQuery")
+	return nil
+}
+
+func (session *dummySession) GetRequestQueries(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestQueries")
+	return nil
+}
+
+func (session *dummySession) GetRequestHeader(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestHeader")
+	return nil
+}
+
+func (session *dummySession) GetRequestHeaders(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestHeaders")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValue(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValue")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValues(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValues")
+	return nil
+}
+
+func (session *dummySession) GetRequestFile(name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFile")
+	return nil
+}
+
+func (session *dummySession) BindRequest(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to BindRequest")
+	return nil
+}
+
+func (session *dummySession) Attach(name string, value interface{}) bool {
+	if session.attachment == nil {
+		assert.Fail(session.t, "Unexpected call to Attach")
+		return false
+	}
+	session.attachment[name] = value
+	return true
+}
+
+func (session *dummySession) Detach(name string) bool {
+	assert.Fail(session.t, "Unexpected call to Detach")
+	return false
+}
+
+func (session *dummySession) GetRawAttachment(name string) (interface{}, bool) {
+	if session.attachment == nil {
+		assert.Fail(session.t, "Unexpected call to GetRawAttachment")
+		return nil, false
+	}
+	var value, found = session.attachment[name]
+	return value, found
+}
+
+func (session *dummySession) GetAttachment(name string, dataTemplate interface{}) bool {
+	assert.Fail(session.t, "Unexpected call to GetAttachment")
+	return false
+}
+
+func (session *dummySession) IsLoggingAllowed(logType logtype.LogType, logLevel loglevel.LogLevel) bool {
+	assert.Fail(session.t, "Unexpected call to IsLoggingAllowed")
+	return false
+}
+
+func (session *dummySession) LogMethodEnter() {
+	assert.Fail(session.t, "Unexpected call to LogMethodEnter")
+}
+
+func (session *dummySession) LogMethodParameter(parameters ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodParameter")
+}
+
+func (session *dummySession) LogMethodLogic(logLevel loglevel.LogLevel, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodLogic")
+}
+
+func (session *dummySession) LogMethodReturn(returns ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodReturn")
+}
+
+func (session *dummySession) LogMethodExit() {
+	assert.Fail(session.t, "Unexpected call to LogMethodExit")
+}
+
+func (session *dummySession) CreateNetworkRequest(method string, url string, payload string, header map[string]string) networkModel.NetworkRequest {
+	assert.Fail(session.t, "Unexpected call to CreateNetworkRequest")
+	return nil
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+const (
+	// DefaultAPIKeyHeader is the request header carrying the API key if not customized
+	DefaultAPIKeyHeader = "X-API-Key"
+
+	apiKeyMethod = "apikey"
+)
+
+type apiKeyAuthenticator struct {
+	headerName string
+	lookupFunc model.APIKeyLookupFunc
+}
+
+// NewAPIKeyAuthenticator creates an authenticator identifying clients by the API key carried in the given request header, resolving the principal through the given lookup function; DefaultAPIKeyHeader is used if headerName is empty
+func NewAPIKeyAuthenticator(headerName string, lookupFunc model.APIKeyLookupFunc) model.Authenticator {
+	if headerName == "" {
+		headerName = DefaultAPIKeyHeader
+	}
+	return &apiKeyAuthenticator{
+		headerName: headerName,
+		lookupFunc: lookupFunc,
+	}
+}
+
+// Authenticate identifies the client of the given request by its API key
+func (authenticator *apiKeyAuthenticator) Authenticate(httpRequest *http.Request) (*model.Principal, error) {
+	var apiKey = httpRequest.Header.Get(
+		authenticator.headerName,
+	)
+	if apiKey == "" {
+		return nil, nil
+	}
+	if authenticator.lookupFunc == nil {
+		return nil, fmtErrorf(
+			"No lookup function configured for API key header [%v]",
+			authenticator.headerName,
+		)
+	}
+	var principal, lookupError = authenticator.lookupFunc(
+		apiKey,
+	)
+	if lookupError != nil {
+		return nil, lookupError
+	}
+	if principal == nil {
+		return nil, fmtErrorf(
+			"Invalid API key provided in header [%v]",
+			authenticator.headerName,
+		)
+	}
+	if principal.Method == "" {
+		principal.Method = apiKeyMethod
+	}
+	return principal, nil
+}
@@ -0,0 +1,229 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+func TestNewAPIKeyAuthenticator_DefaultHeader(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = NewAPIKeyAuthenticator(
+		"",
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, &apiKeyAuthenticator{headerName: DefaultAPIKeyHeader}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewAPIKeyAuthenticator_CustomHeader(t *testing.T) {
+	// arrange
+	var dummyHeaderName = "some header name"
+	var dummyLookupFuncExpected = 0
+	var dummyLookupFuncCalled = 0
+	var dummyLookupFunc = func(apiKey string) (*model.Principal, error) {
+		dummyLookupFuncCalled++
+		return nil, nil
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, ok = NewAPIKeyAuthenticator(
+		dummyHeaderName,
+		dummyLookupFunc,
+	).(*apiKeyAuthenticator)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, dummyHeaderName, result.headerName)
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyLookupFunc)), fmt.Sprintf("%v", reflect.ValueOf(result.lookupFunc)))
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyLookupFuncExpected, dummyLookupFuncCalled, "Unexpected number of calls to dummyLookupFunc")
+}
+
+func TestAPIKeyAuthenticate_NoAPIKey(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{Header: http.Header{}}
+	var authenticator = &apiKeyAuthenticator{headerName: DefaultAPIKeyHeader}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var principal, err = authenticator.Authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, principal)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAPIKeyAuthenticate_NoLookupFunc(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{Header: http.Header{}}
+	var authenticator = &apiKeyAuthenticator{headerName: DefaultAPIKeyHeader}
+	var dummyError = errors.New("some error")
+
+	// stub
+	dummyHTTPRequest.Header.Set(DefaultAPIKeyHeader, "some API key")
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "No lookup function configured for API key header [%v]", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, DefaultAPIKeyHeader, a[0])
+		return dummyError
+	}
+
+	// SUT + act
+	var principal, err = authenticator.Authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, principal)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAPIKeyAuthenticate_LookupError(t *testing.T) {
+	// arrange
+	var dummyAPIKey = "some API key"
+	var dummyHTTPRequest = &http.Request{Header: http.Header{}}
+	var dummyError = errors.New("some error")
+	var dummyLookupFuncExpected = 1
+	var dummyLookupFuncCalled = 0
+	var authenticator = &apiKeyAuthenticator{
+		headerName: DefaultAPIKeyHeader,
+		lookupFunc: func(apiKey string) (*model.Principal, error) {
+			dummyLookupFuncCalled++
+			assert.Equal(t, dummyAPIKey, apiKey)
+			return nil, dummyError
+		},
+	}
+
+	// stub
+	dummyHTTPRequest.Header.Set(DefaultAPIKeyHeader, dummyAPIKey)
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var principal, err = authenticator.Authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, principal)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyLookupFuncExpected, dummyLookupFuncCalled, "Unexpected number of calls to dummyLookupFunc")
+}
+
+func TestAPIKeyAuthenticate_UnknownAPIKey(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{Header: http.Header{}}
+	var dummyError = errors.New("some error")
+	var dummyLookupFuncExpected = 1
+	var dummyLookupFuncCalled = 0
+	var authenticator = &apiKeyAuthenticator{
+		headerName: DefaultAPIKeyHeader,
+		lookupFunc: func(apiKey string) (*model.Principal, error) {
+			dummyLookupFuncCalled++
+			return nil, nil
+		},
+	}
+
+	// stub
+	dummyHTTPRequest.Header.Set(DefaultAPIKeyHeader, "some API key")
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Invalid API key provided in header [%v]", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, DefaultAPIKeyHeader, a[0])
+		return dummyError
+	}
+
+	// SUT + act
+	var principal, err = authenticator.Authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, principal)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyLookupFuncExpected, dummyLookupFuncCalled, "Unexpected number of calls to dummyLookupFunc")
+}
+
+func TestAPIKeyAuthenticate_Success(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{Header: http.Header{}}
+	var dummyPrincipal = &model.Principal{Subject: "some subject"}
+	var dummyLookupFuncExpected = 1
+	var dummyLookupFuncCalled = 0
+	var authenticator = &apiKeyAuthenticator{
+		headerName: DefaultAPIKeyHeader,
+		lookupFunc: func(apiKey string) (*model.Principal, error) {
+			dummyLookupFuncCalled++
+			return dummyPrincipal, nil
+		},
+	}
+
+	// stub
+	dummyHTTPRequest.Header.Set(DefaultAPIKeyHeader, "some API key")
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var principal, err = authenticator.Authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyPrincipal, principal)
+	assert.Equal(t, apiKeyMethod, principal.Method)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyLookupFuncExpected, dummyLookupFuncCalled, "Unexpected number of calls to dummyLookupFunc")
+}
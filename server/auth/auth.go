@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+const (
+	principalAttachmentName = "principal"
+)
+
+func authenticate(httpRequest *http.Request) (*model.Principal, error) {
+	if customization.Authenticators == nil {
+		return nil, nil
+	}
+	for _, authenticator := range customization.Authenticators() {
+		if authenticator == nil {
+			continue
+		}
+		var principal, authError = authenticator.Authenticate(
+			httpRequest,
+		)
+		if authError != nil {
+			return nil, authError
+		}
+		if principal != nil {
+			return principal, nil
+		}
+	}
+	return nil, nil
+}
+
+func hasAllScopes(principal *model.Principal, scopes []string) bool {
+	var grantedScopes = map[string]bool{}
+	for _, scope := range principal.Scopes {
+		grantedScopes[scope] = true
+	}
+	for _, scope := range scopes {
+		if !grantedScopes[scope] {
+			return false
+		}
+	}
+	return true
+}
+
+func hasAnyRole(principal *model.Principal, roles []string) bool {
+	if len(roles) == 0 {
+		return true
+	}
+	for _, grantedRole := range principal.Roles {
+		for _, role := range roles {
+			if grantedRole == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Check authenticates the client of the given session through customization.Authenticators and stores the principal in the session, then enforces the authorization requirement of the given route; returns an Unauthorized error if the route requires authentication but the client is not authenticated, or an AccessForbidden error if the principal lacks the required scopes or roles
+func Check(session sessionModel.Session, route model.Route) error {
+	var requirement = route.Auth
+	var principal, authError = authenticateFunc(
+		session.GetRequest(),
+	)
+	if principal != nil {
+		session.Attach(
+			principalAttachmentName,
+			principal,
+		)
+	}
+	if requirement == nil {
+		return nil
+	}
+	if authError != nil {
+		return apperrorGetUnauthorized(
+			authError,
+		)
+	}
+	if principal == nil {
+		return apperrorGetUnauthorized(
+			fmtErrorf(
+				"No credential provided for endpoint [%v]",
+				route.Endpoint,
+			),
+		)
+	}
+	if !hasAllScopesFunc(principal, requirement.Scopes) {
+		return apperrorGetAccessForbiddenError(
+			fmtErrorf(
+				"Principal [%v] is not granted all scopes %v for endpoint [%v]",
+				principal.Subject,
+				requirement.Scopes,
+				route.Endpoint,
+			),
+		)
+	}
+	if !hasAnyRoleFunc(principal, requirement.Roles) {
+		return apperrorGetAccessForbiddenError(
+			fmtErrorf(
+				"Principal [%v] is not granted any role of %v for endpoint [%v]",
+				principal.Subject,
+				requirement.Roles,
+				route.Endpoint,
+			),
+		)
+	}
+	return nil
+}
+
+// GetPrincipal retrieves the principal of the authenticated client stored in the given session; returns nil if the client is not authenticated
+func GetPrincipal(session sessionModel.Session) *model.Principal {
+	if session == nil {
+		return nil
+	}
+	var attachment, found = session.GetRawAttachment(
+		principalAttachmentName,
+	)
+	if !found {
+		return nil
+	}
+	var principal, ok = attachment.(*model.Principal)
+	if !ok {
+		return nil
+	}
+	return principal
+}
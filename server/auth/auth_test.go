@@ -0,0 +1,547 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+type dummyAuthenticator struct {
+	t           *testing.T
+	httpRequest *http.Request
+	principal   *model.Principal
+	err         error
+	called      int
+}
+
+func (authenticator *dummyAuthenticator) Authenticate(httpRequest *http.Request) (*model.Principal, error) {
+	authenticator.called++
+	assert.Equal(authenticator.t, authenticator.httpRequest, httpRequest)
+	return authenticator.principal, authenticator.err
+}
+
+func TestAuthenticate_NoCustomization(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var principal, err = authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, principal)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAuthenticate_AuthError(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyError = errors.New("some error")
+	var dummyAuthenticator1 = &dummyAuthenticator{t: t, httpRequest: dummyHTTPRequest, err: dummyError}
+	var dummyAuthenticator2 = &dummyAuthenticator{t: t, httpRequest: dummyHTTPRequest}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationAuthenticatorsExpected = 1
+	customization.Authenticators = func() []model.Authenticator {
+		customizationAuthenticatorsCalled++
+		return []model.Authenticator{dummyAuthenticator1, dummyAuthenticator2}
+	}
+
+	// SUT + act
+	var principal, err = authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, principal)
+	assert.Equal(t, dummyError, err)
+	assert.Equal(t, 1, dummyAuthenticator1.called)
+	assert.Zero(t, dummyAuthenticator2.called)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAuthenticate_Recognised(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyPrincipal = &model.Principal{Subject: "some subject"}
+	var dummyAuthenticator1 = &dummyAuthenticator{t: t, httpRequest: dummyHTTPRequest}
+	var dummyAuthenticator2 = &dummyAuthenticator{t: t, httpRequest: dummyHTTPRequest, principal: dummyPrincipal}
+	var dummyAuthenticator3 = &dummyAuthenticator{t: t, httpRequest: dummyHTTPRequest}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationAuthenticatorsExpected = 1
+	customization.Authenticators = func() []model.Authenticator {
+		customizationAuthenticatorsCalled++
+		return []model.Authenticator{nil, dummyAuthenticator1, dummyAuthenticator2, dummyAuthenticator3}
+	}
+
+	// SUT + act
+	var principal, err = authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyPrincipal, principal)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dummyAuthenticator1.called)
+	assert.Equal(t, 1, dummyAuthenticator2.called)
+	assert.Zero(t, dummyAuthenticator3.called)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAuthenticate_NotRecognised(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyAuthenticator1 = &dummyAuthenticator{t: t, httpRequest: dummyHTTPRequest}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationAuthenticatorsExpected = 1
+	customization.Authenticators = func() []model.Authenticator {
+		customizationAuthenticatorsCalled++
+		return []model.Authenticator{dummyAuthenticator1}
+	}
+
+	// SUT + act
+	var principal, err = authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, principal)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dummyAuthenticator1.called)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestHasAllScopes(t *testing.T) {
+	// arrange
+	var dummyPrincipal = &model.Principal{
+		Scopes: []string{"read", "write"},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var noScopes = hasAllScopes(dummyPrincipal, nil)
+	var allGranted = hasAllScopes(dummyPrincipal, []string{"write", "read"})
+	var someMissing = hasAllScopes(dummyPrincipal, []string{"read", "admin"})
+
+	// assert
+	assert.True(t, noScopes)
+	assert.True(t, allGranted)
+	assert.False(t, someMissing)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestHasAnyRole(t *testing.T) {
+	// arrange
+	var dummyPrincipal = &model.Principal{
+		Roles: []string{"reader"},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var noRoles = hasAnyRole(dummyPrincipal, nil)
+	var oneGranted = hasAnyRole(dummyPrincipal, []string{"admin", "reader"})
+	var noneGranted = hasAnyRole(dummyPrincipal, []string{"admin", "writer"})
+
+	// assert
+	assert.True(t, noRoles)
+	assert.True(t, oneGranted)
+	assert.False(t, noneGranted)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheck_Anonymous(t *testing.T) {
+	// arrange
+	var dummyEndpoint = "some endpoint"
+	var dummyHTTPRequest = &http.Request{}
+	var dummySessionObject = &dummySession{t: t, httpRequest: dummyHTTPRequest}
+	var dummyRoute = model.Route{Endpoint: dummyEndpoint}
+
+	// mock
+	createMock(t)
+
+	// expect
+	authenticateFuncExpected = 1
+	authenticateFunc = func(httpRequest *http.Request) (*model.Principal, error) {
+		authenticateFuncCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return nil, errors.New("some error")
+	}
+
+	// SUT + act
+	var err = Check(
+		dummySessionObject,
+		dummyRoute,
+	)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheck_AuthError(t *testing.T) {
+	// arrange
+	var dummyEndpoint = "some endpoint"
+	var dummyHTTPRequest = &http.Request{}
+	var dummySessionObject = &dummySession{t: t, httpRequest: dummyHTTPRequest}
+	var dummyRequirement = &model.AuthRequirement{}
+	var dummyRoute = model.Route{Endpoint: dummyEndpoint, Auth: dummyRequirement}
+	var dummyAuthError = errors.New("some auth error")
+	var dummyAppError = apperror.GetGeneralFailureError(errors.New("some app error"))
+
+	// mock
+	createMock(t)
+
+	// expect
+	authenticateFuncExpected = 1
+	authenticateFunc = func(httpRequest *http.Request) (*model.Principal, error) {
+		authenticateFuncCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return nil, dummyAuthError
+	}
+	apperrorGetUnauthorizedExpected = 1
+	apperrorGetUnauthorized = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetUnauthorizedCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyAuthError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = Check(
+		dummySessionObject,
+		dummyRoute,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheck_NoPrincipal(t *testing.T) {
+	// arrange
+	var dummyEndpoint = "some endpoint"
+	var dummyHTTPRequest = &http.Request{}
+	var dummySessionObject = &dummySession{t: t, httpRequest: dummyHTTPRequest}
+	var dummyRequirement = &model.AuthRequirement{}
+	var dummyRoute = model.Route{Endpoint: dummyEndpoint, Auth: dummyRequirement}
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetGeneralFailureError(errors.New("some app error"))
+
+	// mock
+	createMock(t)
+
+	// expect
+	authenticateFuncExpected = 1
+	authenticateFunc = func(httpRequest *http.Request) (*model.Principal, error) {
+		authenticateFuncCalled++
+		return nil, nil
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "No credential provided for endpoint [%v]", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyEndpoint, a[0])
+		return dummyError
+	}
+	apperrorGetUnauthorizedExpected = 1
+	apperrorGetUnauthorized = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetUnauthorizedCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = Check(
+		dummySessionObject,
+		dummyRoute,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheck_MissingScopes(t *testing.T) {
+	// arrange
+	var dummyEndpoint = "some endpoint"
+	var dummyHTTPRequest = &http.Request{}
+	var dummySessionObject = &dummySession{t: t, httpRequest: dummyHTTPRequest, attachment: map[string]interface{}{}}
+	var dummyRequirement = &model.AuthRequirement{Scopes: []string{"some scope"}}
+	var dummyRoute = model.Route{Endpoint: dummyEndpoint, Auth: dummyRequirement}
+	var dummyPrincipal = &model.Principal{Subject: "some subject"}
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetGeneralFailureError(errors.New("some app error"))
+
+	// mock
+	createMock(t)
+
+	// expect
+	authenticateFuncExpected = 1
+	authenticateFunc = func(httpRequest *http.Request) (*model.Principal, error) {
+		authenticateFuncCalled++
+		return dummyPrincipal, nil
+	}
+	hasAllScopesFuncExpected = 1
+	hasAllScopesFunc = func(principal *model.Principal, scopes []string) bool {
+		hasAllScopesFuncCalled++
+		assert.Equal(t, dummyPrincipal, principal)
+		assert.Equal(t, dummyRequirement.Scopes, scopes)
+		return false
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Principal [%v] is not granted all scopes %v for endpoint [%v]", format)
+		assert.Equal(t, 3, len(a))
+		assert.Equal(t, dummyPrincipal.Subject, a[0])
+		assert.Equal(t, dummyRequirement.Scopes, a[1])
+		assert.Equal(t, dummyEndpoint, a[2])
+		return dummyError
+	}
+	apperrorGetAccessForbiddenErrorExpected = 1
+	apperrorGetAccessForbiddenError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetAccessForbiddenErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = Check(
+		dummySessionObject,
+		dummyRoute,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+	assert.Equal(t, dummyPrincipal, dummySessionObject.attachment[principalAttachmentName])
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheck_MissingRoles(t *testing.T) {
+	// arrange
+	var dummyEndpoint = "some endpoint"
+	var dummyHTTPRequest = &http.Request{}
+	var dummySessionObject = &dummySession{t: t, httpRequest: dummyHTTPRequest, attachment: map[string]interface{}{}}
+	var dummyRequirement = &model.AuthRequirement{Roles: []string{"some role"}}
+	var dummyRoute = model.Route{Endpoint: dummyEndpoint, Auth: dummyRequirement}
+	var dummyPrincipal = &model.Principal{Subject: "some subject"}
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetGeneralFailureError(errors.New("some app error"))
+
+	// mock
+	createMock(t)
+
+	// expect
+	authenticateFuncExpected = 1
+	authenticateFunc = func(httpRequest *http.Request) (*model.Principal, error) {
+		authenticateFuncCalled++
+		return dummyPrincipal, nil
+	}
+	hasAllScopesFuncExpected = 1
+	hasAllScopesFunc = func(principal *model.Principal, scopes []string) bool {
+		hasAllScopesFuncCalled++
+		return true
+	}
+	hasAnyRoleFuncExpected = 1
+	hasAnyRoleFunc = func(principal *model.Principal, roles []string) bool {
+		hasAnyRoleFuncCalled++
+		assert.Equal(t, dummyPrincipal, principal)
+		assert.Equal(t, dummyRequirement.Roles, roles)
+		return false
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Principal [%v] is not granted any role of %v for endpoint [%v]", format)
+		assert.Equal(t, 3, len(a))
+		assert.Equal(t, dummyPrincipal.Subject, a[0])
+		assert.Equal(t, dummyRequirement.Roles, a[1])
+		assert.Equal(t, dummyEndpoint, a[2])
+		return dummyError
+	}
+	apperrorGetAccessForbiddenErrorExpected = 1
+	apperrorGetAccessForbiddenError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetAccessForbiddenErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = Check(
+		dummySessionObject,
+		dummyRoute,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheck_Authorized(t *testing.T) {
+	// arrange
+	var dummyEndpoint = "some endpoint"
+	var dummyHTTPRequest = &http.Request{}
+	var dummySessionObject = &dummySession{t: t, httpRequest: dummyHTTPRequest, attachment: map[string]interface{}{}}
+	var dummyRequirement = &model.AuthRequirement{}
+	var dummyRoute = model.Route{Endpoint: dummyEndpoint, Auth: dummyRequirement}
+	var dummyPrincipal = &model.Principal{Subject: "some subject"}
+
+	// mock
+	createMock(t)
+
+	// expect
+	authenticateFuncExpected = 1
+	authenticateFunc = func(httpRequest *http.Request) (*model.Principal, error) {
+		authenticateFuncCalled++
+		return dummyPrincipal, nil
+	}
+	hasAllScopesFuncExpected = 1
+	hasAllScopesFunc = func(principal *model.Principal, scopes []string) bool {
+		hasAllScopesFuncCalled++
+		return true
+	}
+	hasAnyRoleFuncExpected = 1
+	hasAnyRoleFunc = func(principal *model.Principal, roles []string) bool {
+		hasAnyRoleFuncCalled++
+		return true
+	}
+
+	// SUT + act
+	var err = Check(
+		dummySessionObject,
+		dummyRoute,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, dummyPrincipal, dummySessionObject.attachment[principalAttachmentName])
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetPrincipal_NilSession(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = GetPrincipal(
+		nil,
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetPrincipal_NotFound(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t, attachment: map[string]interface{}{}}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = GetPrincipal(
+		dummySessionObject,
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetPrincipal_InvalidType(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t, attachment: map[string]interface{}{principalAttachmentName: "some value"}}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = GetPrincipal(
+		dummySessionObject,
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetPrincipal_Found(t *testing.T) {
+	// arrange
+	var dummyPrincipal = &model.Principal{Subject: "some subject"}
+	var dummySessionObject = &dummySession{t: t, attachment: map[string]interface{}{principalAttachmentName: dummyPrincipal}}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = GetPrincipal(
+		dummySessionObject,
+	)
+
+	// assert
+	assert.Equal(t, dummyPrincipal, result)
+
+	// verify
+	verifyAll(t)
+}
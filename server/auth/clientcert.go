@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+const (
+	clientCertMethod = "mtls"
+)
+
+type clientCertAuthenticator struct {
+	grantFunc model.ClientCertGrantFunc
+}
+
+// NewClientCertAuthenticator creates an authenticator identifying clients by the leaf certificate presented during mutual TLS handshake, granting scopes and roles through the given grant function if set; only certificates whose chains are verified by the TLS server, i.e. when client certificate validation is enabled for the listener, are accepted
+func NewClientCertAuthenticator(grantFunc model.ClientCertGrantFunc) model.Authenticator {
+	return &clientCertAuthenticator{
+		grantFunc: grantFunc,
+	}
+}
+
+// Authenticate identifies the client of the given request by its verified client certificate subject and SANs; a client certificate not verified against the trusted CA certificates is rejected
+func (authenticator *clientCertAuthenticator) Authenticate(httpRequest *http.Request) (*model.Principal, error) {
+	if httpRequest.TLS == nil ||
+		len(httpRequest.TLS.PeerCertificates) == 0 {
+		return nil, nil
+	}
+	if len(httpRequest.TLS.VerifiedChains) == 0 ||
+		len(httpRequest.TLS.VerifiedChains[0]) == 0 {
+		return nil, fmtErrorf(
+			"Client certificate [%v] is not verified against trusted CA certificates",
+			httpRequest.TLS.PeerCertificates[0].Subject,
+		)
+	}
+	var certificate = httpRequest.TLS.VerifiedChains[0][0]
+	var subject = certificate.Subject.CommonName
+	if subject == "" {
+		subject = certificate.Subject.String()
+	}
+	var uris = []string{}
+	for _, uri := range certificate.URIs {
+		uris = append(uris, uri.String())
+	}
+	var ipAddresses = []string{}
+	for _, ipAddress := range certificate.IPAddresses {
+		ipAddresses = append(ipAddresses, ipAddress.String())
+	}
+	var principal = &model.Principal{
+		Subject: subject,
+		Method:  clientCertMethod,
+		Claims: map[string]interface{}{
+			"subject":        certificate.Subject.String(),
+			"issuer":         certificate.Issuer.String(),
+			"serialNumber":   certificate.SerialNumber.String(),
+			"dnsNames":       certificate.DNSNames,
+			"emailAddresses": certificate.EmailAddresses,
+			"ipAddresses":    ipAddresses,
+			"uris":           uris,
+		},
+	}
+	if authenticator.grantFunc != nil {
+		principal.Scopes, principal.Roles = authenticator.grantFunc(
+			certificate,
+		)
+	}
+	return principal, nil
+}
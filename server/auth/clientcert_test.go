@@ -0,0 +1,219 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientCertAuthenticator(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = NewClientCertAuthenticator(
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, &clientCertAuthenticator{}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestClientCertAuthenticate_NoCertificate(t *testing.T) {
+	// arrange
+	var authenticator = &clientCertAuthenticator{}
+	var plainRequest = &http.Request{}
+	var noCertRequest = &http.Request{TLS: &tls.ConnectionState{}}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var plainPrincipal, plainError = authenticator.Authenticate(plainRequest)
+	var noCertPrincipal, noCertError = authenticator.Authenticate(noCertRequest)
+
+	// assert
+	assert.Nil(t, plainPrincipal)
+	assert.NoError(t, plainError)
+	assert.Nil(t, noCertPrincipal)
+	assert.NoError(t, noCertError)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestClientCertAuthenticate_NotVerified(t *testing.T) {
+	// arrange
+	var authenticator = &clientCertAuthenticator{}
+	var dummyCertificate = &x509.Certificate{
+		Subject:      pkix.Name{CommonName: "some client"},
+		SerialNumber: big.NewInt(123),
+	}
+	var dummyHTTPRequest = &http.Request{
+		TLS: &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{dummyCertificate},
+		},
+	}
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Client certificate [%v] is not verified against trusted CA certificates", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyCertificate.Subject, a[0])
+		return dummyError
+	}
+
+	// SUT + act
+	var principal, err = authenticator.Authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, principal)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestClientCertAuthenticate_VerifiedLeaf(t *testing.T) {
+	// arrange
+	var authenticator = &clientCertAuthenticator{}
+	var dummyPeerCertificate = &x509.Certificate{
+		Subject:      pkix.Name{CommonName: "some peer"},
+		SerialNumber: big.NewInt(123),
+	}
+	var dummyVerifiedCertificate = &x509.Certificate{
+		Subject:      pkix.Name{CommonName: "some verified client"},
+		SerialNumber: big.NewInt(456),
+	}
+	var dummyHTTPRequest = &http.Request{
+		TLS: &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{dummyPeerCertificate},
+			VerifiedChains:   [][]*x509.Certificate{{dummyVerifiedCertificate}},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var principal, err = authenticator.Authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "some verified client", principal.Subject)
+	assert.Equal(t, "456", principal.Claims["serialNumber"])
+
+	// verify
+	verifyAll(t)
+}
+
+func TestClientCertAuthenticate_NoCommonName(t *testing.T) {
+	// arrange
+	var authenticator = &clientCertAuthenticator{}
+	var dummyCertificate = &x509.Certificate{
+		Subject:      pkix.Name{Organization: []string{"some organization"}},
+		SerialNumber: big.NewInt(123),
+	}
+	var dummyHTTPRequest = &http.Request{
+		TLS: &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{dummyCertificate},
+			VerifiedChains:   [][]*x509.Certificate{{dummyCertificate}},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var principal, err = authenticator.Authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "O=some organization", principal.Subject)
+	assert.Equal(t, clientCertMethod, principal.Method)
+	assert.Nil(t, principal.Scopes)
+	assert.Nil(t, principal.Roles)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestClientCertAuthenticate_WithGrantFunc(t *testing.T) {
+	// arrange
+	var dummyURI, _ = url.Parse("spiffe://some/workload")
+	var dummyCertificate = &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "some client"},
+		Issuer:         pkix.Name{CommonName: "some CA"},
+		SerialNumber:   big.NewInt(123),
+		DNSNames:       []string{"some.client.local"},
+		EmailAddresses: []string{"some@client.local"},
+		IPAddresses:    []net.IP{net.ParseIP("127.0.0.1")},
+		URIs:           []*url.URL{dummyURI},
+	}
+	var dummyScopes = []string{"some scope"}
+	var dummyRoles = []string{"some role"}
+	var dummyGrantFuncExpected = 1
+	var dummyGrantFuncCalled = 0
+	var authenticator = &clientCertAuthenticator{
+		grantFunc: func(certificate *x509.Certificate) ([]string, []string) {
+			dummyGrantFuncCalled++
+			assert.Equal(t, dummyCertificate, certificate)
+			return dummyScopes, dummyRoles
+		},
+	}
+	var dummyHTTPRequest = &http.Request{
+		TLS: &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{dummyCertificate},
+			VerifiedChains:   [][]*x509.Certificate{{dummyCertificate}},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var principal, err = authenticator.Authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "some client", principal.Subject)
+	assert.Equal(t, clientCertMethod, principal.Method)
+	assert.Equal(t, dummyScopes, principal.Scopes)
+	assert.Equal(t, dummyRoles, principal.Roles)
+	assert.Equal(t, "CN=some client", principal.Claims["subject"])
+	assert.Equal(t, "CN=some CA", principal.Claims["issuer"])
+	assert.Equal(t, "123", principal.Claims["serialNumber"])
+	assert.Equal(t, dummyCertificate.DNSNames, principal.Claims["dnsNames"])
+	assert.Equal(t, dummyCertificate.EmailAddresses, principal.Claims["emailAddresses"])
+	assert.Equal(t, []string{"127.0.0.1"}, principal.Claims["ipAddresses"])
+	assert.Equal(t, []string{"spiffe://some/workload"}, principal.Claims["uris"])
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyGrantFuncExpected, dummyGrantFuncCalled, "Unexpected number of calls to dummyGrantFunc")
+}
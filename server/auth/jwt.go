@@ -0,0 +1,357 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	// register SHA-256, SHA-384 and SHA-512 for JWT signature verification
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+const (
+	jwtMethod    = "jwt"
+	bearerPrefix = "Bearer "
+	rsaKeyType   = "RSA"
+)
+
+var (
+	hmacAlgorithms = map[string]crypto.Hash{
+		"HS256": crypto.SHA256,
+		"HS384": crypto.SHA384,
+		"HS512": crypto.SHA512,
+	}
+	rsaAlgorithms = map[string]crypto.Hash{
+		"RS256": crypto.SHA256,
+		"RS384": crypto.SHA384,
+		"RS512": crypto.SHA512,
+	}
+)
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type jsonWebKey struct {
+	KeyType  string `json:"kty"`
+	KeyID    string `json:"kid"`
+	Modulus  string `json:"n"`
+	Exponent string `json:"e"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jwtAuthenticator struct {
+	settings model.JWTSettings
+	rsaKeys  map[string]*rsa.PublicKey
+}
+
+func parseRSAKey(key jsonWebKey) (*rsa.PublicKey, error) {
+	var modulusBytes, modulusError = base64RawURLEncodingDecodeString(
+		key.Modulus,
+	)
+	if modulusError != nil {
+		return nil, modulusError
+	}
+	var exponentBytes, exponentError = base64RawURLEncodingDecodeString(
+		key.Exponent,
+	)
+	if exponentError != nil {
+		return nil, exponentError
+	}
+	var exponent = 0
+	for _, exponentByte := range exponentBytes {
+		exponent = exponent<<8 | int(exponentByte)
+	}
+	if len(modulusBytes) == 0 || exponent == 0 {
+		return nil, fmtErrorf(
+			"Missing modulus or exponent for RSA key [%v]",
+			key.KeyID,
+		)
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulusBytes),
+		E: exponent,
+	}, nil
+}
+
+func loadJWKS(jwksFile string) (map[string]*rsa.PublicKey, error) {
+	var rsaKeys = map[string]*rsa.PublicKey{}
+	if jwksFile == "" {
+		return rsaKeys, nil
+	}
+	var fileBytes, fileError = ioutilReadFile(
+		jwksFile,
+	)
+	if fileError != nil {
+		return nil, fileError
+	}
+	var keySet jsonWebKeySet
+	var unmarshalError = jsonUnmarshal(
+		fileBytes,
+		&keySet,
+	)
+	if unmarshalError != nil {
+		return nil, unmarshalError
+	}
+	for _, key := range keySet.Keys {
+		if key.KeyType != rsaKeyType {
+			continue
+		}
+		var rsaKey, keyError = parseRSAKeyFunc(
+			key,
+		)
+		if keyError != nil {
+			return nil, fmtErrorf(
+				"Invalid RSA key [%v] in JWKS file [%v]: %v",
+				key.KeyID,
+				jwksFile,
+				keyError,
+			)
+		}
+		rsaKeys[key.KeyID] = rsaKey
+	}
+	return rsaKeys, nil
+}
+
+// NewJWTAuthenticator creates an authenticator identifying clients by the bearer JWT carried in the Authorization header, verified with the HMAC secret or the RSA public keys from the local JWKS file given in settings; the "sub" claim becomes the principal subject, the "scope" or "scp" claim the scopes and the "roles" claim the roles
+func NewJWTAuthenticator(settings model.JWTSettings) (model.Authenticator, error) {
+	var rsaKeys, loadError = loadJWKSFunc(
+		settings.JWKSFile,
+	)
+	if loadError != nil {
+		return nil, loadError
+	}
+	return &jwtAuthenticator{
+		settings: settings,
+		rsaKeys:  rsaKeys,
+	}, nil
+}
+
+func decodeSegment(segment string, dataTemplate interface{}) error {
+	var segmentBytes, decodeError = base64RawURLEncodingDecodeString(
+		segment,
+	)
+	if decodeError != nil {
+		return decodeError
+	}
+	return jsonUnmarshal(
+		segmentBytes,
+		dataTemplate,
+	)
+}
+
+func getRSAKey(rsaKeys map[string]*rsa.PublicKey, keyID string) *rsa.PublicKey {
+	var rsaKey, found = rsaKeys[keyID]
+	if found {
+		return rsaKey
+	}
+	if keyID != "" || len(rsaKeys) != 1 {
+		return nil
+	}
+	for _, rsaKey = range rsaKeys {
+		return rsaKey
+	}
+	return nil
+}
+
+func verifySignature(settings model.JWTSettings, rsaKeys map[string]*rsa.PublicKey, header jwtHeader, signingInput string, signature []byte) error {
+	var hmacHash, isHMAC = hmacAlgorithms[header.Algorithm]
+	if isHMAC {
+		if len(settings.HMACSecret) == 0 {
+			return fmtErrorf(
+				"No HMAC secret configured for token algorithm [%v]",
+				header.Algorithm,
+			)
+		}
+		var mac = hmac.New(
+			hmacHash.New,
+			settings.HMACSecret,
+		)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return fmtErrorf(
+				"Invalid token signature",
+			)
+		}
+		return nil
+	}
+	var rsaHash, isRSA = rsaAlgorithms[header.Algorithm]
+	if isRSA {
+		var rsaKey = getRSAKeyFunc(
+			rsaKeys,
+			header.KeyID,
+		)
+		if rsaKey == nil {
+			return fmtErrorf(
+				"No RSA key found for token key ID [%v]",
+				header.KeyID,
+			)
+		}
+		var digest = rsaHash.New()
+		digest.Write([]byte(signingInput))
+		return rsa.VerifyPKCS1v15(
+			rsaKey,
+			rsaHash,
+			digest.Sum(nil),
+			signature,
+		)
+	}
+	return fmtErrorf(
+		"Unsupported token algorithm [%v]",
+		header.Algorithm,
+	)
+}
+
+func getTimeClaim(claims map[string]interface{}, name string) (time.Time, bool) {
+	var seconds, ok = claims[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(seconds), 0).UTC(), true
+}
+
+func getStringListClaim(claims map[string]interface{}, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return stringsFields(value)
+	case []interface{}:
+		var values = []string{}
+		for _, item := range value {
+			var text, ok = item.(string)
+			if ok {
+				values = append(values, text)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func validateClaims(settings model.JWTSettings, claims map[string]interface{}) error {
+	var now = timeutilGetTimeNowUTC()
+	var expiry, hasExpiry = getTimeClaimFunc(
+		claims,
+		"exp",
+	)
+	if hasExpiry && now.After(expiry.Add(settings.Leeway)) {
+		return fmtErrorf(
+			"Token expired at [%v]",
+			expiry,
+		)
+	}
+	var notBefore, hasNotBefore = getTimeClaimFunc(
+		claims,
+		"nbf",
+	)
+	if hasNotBefore && now.Before(notBefore.Add(-settings.Leeway)) {
+		return fmtErrorf(
+			"Token not valid before [%v]",
+			notBefore,
+		)
+	}
+	if settings.Issuer != "" &&
+		claims["iss"] != settings.Issuer {
+		return fmtErrorf(
+			"Token issuer [%v] does not match [%v]",
+			claims["iss"],
+			settings.Issuer,
+		)
+	}
+	if settings.Audience != "" &&
+		!containsStringFunc(getStringListClaimFunc(claims, "aud"), settings.Audience) {
+		return fmtErrorf(
+			"Token audience [%v] does not include [%v]",
+			claims["aud"],
+			settings.Audience,
+		)
+	}
+	return nil
+}
+
+// Authenticate identifies the client of the given request by its bearer JWT
+func (authenticator *jwtAuthenticator) Authenticate(httpRequest *http.Request) (*model.Principal, error) {
+	var authorization = httpRequest.Header.Get(
+		"Authorization",
+	)
+	if len(authorization) < len(bearerPrefix) ||
+		!stringsEqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return nil, nil
+	}
+	var segments = stringsSplit(
+		stringsTrimSpace(authorization[len(bearerPrefix):]),
+		".",
+	)
+	if len(segments) != 3 {
+		return nil, fmtErrorf(
+			"Malformed bearer token",
+		)
+	}
+	var header jwtHeader
+	var headerError = decodeSegmentFunc(
+		segments[0],
+		&header,
+	)
+	if headerError != nil {
+		return nil, headerError
+	}
+	var signature, signatureError = base64RawURLEncodingDecodeString(
+		segments[2],
+	)
+	if signatureError != nil {
+		return nil, signatureError
+	}
+	var verifyError = verifySignatureFunc(
+		authenticator.settings,
+		authenticator.rsaKeys,
+		header,
+		segments[0]+"."+segments[1],
+		signature,
+	)
+	if verifyError != nil {
+		return nil, verifyError
+	}
+	var claims map[string]interface{}
+	var claimsError = decodeSegmentFunc(
+		segments[1],
+		&claims,
+	)
+	if claimsError != nil {
+		return nil, claimsError
+	}
+	var validateError = validateClaimsFunc(
+		authenticator.settings,
+		claims,
+	)
+	if validateError != nil {
+		return nil, validateError
+	}
+	var subject, _ = claims["sub"].(string)
+	return &model.Principal{
+		Subject: subject,
+		Method:  jwtMethod,
+		Scopes: append(
+			getStringListClaimFunc(claims, "scope"),
+			getStringListClaimFunc(claims, "scp")...,
+		),
+		Roles:  getStringListClaimFunc(claims, "roles"),
+		Claims: claims,
+	}, nil
+}
@@ -0,0 +1,736 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+func useRealJWTFunctions() {
+	fmtErrorf = fmt.Errorf
+	base64RawURLEncodingDecodeString = base64.RawURLEncoding.DecodeString
+	ioutilReadFile = ioutil.ReadFile
+	jsonUnmarshal = json.Unmarshal
+	stringsFields = strings.Fields
+	stringsEqualFold = strings.EqualFold
+	stringsSplit = strings.Split
+	stringsTrimSpace = strings.TrimSpace
+	parseRSAKeyFunc = parseRSAKey
+	loadJWKSFunc = loadJWKS
+	decodeSegmentFunc = decodeSegment
+	getRSAKeyFunc = getRSAKey
+	verifySignatureFunc = verifySignature
+	getTimeClaimFunc = getTimeClaim
+	getStringListClaimFunc = getStringListClaim
+	containsStringFunc = containsString
+	validateClaimsFunc = validateClaims
+}
+
+func encodeSegment(t *testing.T, value interface{}) string {
+	var bytes, marshalError = json.Marshal(value)
+	assert.NoError(t, marshalError)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+func createHMACToken(t *testing.T, secret []byte, claims map[string]interface{}) string {
+	var signingInput = encodeSegment(t, map[string]string{"alg": "HS256", "typ": "JWT"}) +
+		"." + encodeSegment(t, claims)
+	var mac = hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func createRSAToken(t *testing.T, privateKey *rsa.PrivateKey, keyID string, claims map[string]interface{}) string {
+	var signingInput = encodeSegment(t, map[string]string{"alg": "RS256", "kid": keyID}) +
+		"." + encodeSegment(t, claims)
+	var digest = sha256.Sum256([]byte(signingInput))
+	var signature, signError = rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+	assert.NoError(t, signError)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func createJSONWebKey(publicKey *rsa.PublicKey, keyID string) jsonWebKey {
+	return jsonWebKey{
+		KeyType:  rsaKeyType,
+		KeyID:    keyID,
+		Modulus:  base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+		Exponent: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+	}
+}
+
+func TestParseRSAKey_InvalidModulus(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+
+	// SUT + act
+	var result, err = parseRSAKey(
+		jsonWebKey{Modulus: "!!!", Exponent: "AQAB"},
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Error(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseRSAKey_InvalidExponent(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+
+	// SUT + act
+	var result, err = parseRSAKey(
+		jsonWebKey{Modulus: "AQAB", Exponent: "!!!"},
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Error(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseRSAKey_MissingExponent(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+
+	// SUT + act
+	var result, err = parseRSAKey(
+		jsonWebKey{KeyID: "some key", Modulus: "AQAB"},
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, "Missing modulus or exponent for RSA key [some key]", err.Error())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseRSAKey_Valid(t *testing.T) {
+	// arrange
+	var privateKey, _ = rsa.GenerateKey(rand.Reader, 1024)
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+
+	// SUT + act
+	var result, err = parseRSAKey(
+		createJSONWebKey(&privateKey.PublicKey, "some key"),
+	)
+
+	// assert
+	assert.Equal(t, &privateKey.PublicKey, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLoadJWKS_NoFile(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, err = loadJWKS(
+		"",
+	)
+
+	// assert
+	assert.Empty(t, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLoadJWKS_ReadError(t *testing.T) {
+	// arrange
+	var dummyJWKSFile = "some JWKS file"
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	ioutilReadFileExpected = 1
+	ioutilReadFile = func(filename string) ([]byte, error) {
+		ioutilReadFileCalled++
+		assert.Equal(t, dummyJWKSFile, filename)
+		return nil, dummyError
+	}
+
+	// SUT + act
+	var result, err = loadJWKS(
+		dummyJWKSFile,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLoadJWKS_InvalidKey(t *testing.T) {
+	// arrange
+	var dummyJWKSFile = filepath.Join(t.TempDir(), "jwks.json")
+	var dummyContent = `{"keys":[{"kty":"RSA","kid":"some key","n":"AQAB"}]}`
+
+	// stub
+	ioutil.WriteFile(dummyJWKSFile, []byte(dummyContent), os.ModePerm)
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+
+	// SUT + act
+	var result, err = loadJWKS(
+		dummyJWKSFile,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, "Invalid RSA key [some key] in JWKS file ["+dummyJWKSFile+"]: Missing modulus or exponent for RSA key [some key]", err.Error())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLoadJWKS_Valid(t *testing.T) {
+	// arrange
+	var privateKey, _ = rsa.GenerateKey(rand.Reader, 1024)
+	var dummyJWKSFile = filepath.Join(t.TempDir(), "jwks.json")
+	var dummyKeySet = jsonWebKeySet{
+		Keys: []jsonWebKey{
+			{KeyType: "EC", KeyID: "some EC key"},
+			createJSONWebKey(&privateKey.PublicKey, "some RSA key"),
+		},
+	}
+	var dummyContent, _ = json.Marshal(dummyKeySet)
+
+	// stub
+	ioutil.WriteFile(dummyJWKSFile, dummyContent, os.ModePerm)
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+
+	// SUT + act
+	var result, err = loadJWKS(
+		dummyJWKSFile,
+	)
+
+	// assert
+	assert.Equal(t, map[string]*rsa.PublicKey{"some RSA key": &privateKey.PublicKey}, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewJWTAuthenticator_LoadError(t *testing.T) {
+	// arrange
+	var dummySettings = model.JWTSettings{JWKSFile: "some JWKS file"}
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	loadJWKSFuncExpected = 1
+	loadJWKSFunc = func(jwksFile string) (map[string]*rsa.PublicKey, error) {
+		loadJWKSFuncCalled++
+		assert.Equal(t, dummySettings.JWKSFile, jwksFile)
+		return nil, dummyError
+	}
+
+	// SUT + act
+	var result, err = NewJWTAuthenticator(
+		dummySettings,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewJWTAuthenticator_Success(t *testing.T) {
+	// arrange
+	var dummySettings = model.JWTSettings{JWKSFile: "some JWKS file"}
+	var dummyRSAKeys = map[string]*rsa.PublicKey{"some key": {}}
+
+	// mock
+	createMock(t)
+
+	// expect
+	loadJWKSFuncExpected = 1
+	loadJWKSFunc = func(jwksFile string) (map[string]*rsa.PublicKey, error) {
+		loadJWKSFuncCalled++
+		return dummyRSAKeys, nil
+	}
+
+	// SUT + act
+	var result, err = NewJWTAuthenticator(
+		dummySettings,
+	)
+
+	// assert
+	assert.Equal(t, &jwtAuthenticator{settings: dummySettings, rsaKeys: dummyRSAKeys}, result)
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRSAKey(t *testing.T) {
+	// arrange
+	var dummyKey1 = &rsa.PublicKey{E: 1}
+	var dummyKey2 = &rsa.PublicKey{E: 2}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var byKeyID = getRSAKey(map[string]*rsa.PublicKey{"key1": dummyKey1, "key2": dummyKey2}, "key2")
+	var unknownKeyID = getRSAKey(map[string]*rsa.PublicKey{"key1": dummyKey1}, "key2")
+	var onlyKey = getRSAKey(map[string]*rsa.PublicKey{"key1": dummyKey1}, "")
+	var ambiguousKey = getRSAKey(map[string]*rsa.PublicKey{"key1": dummyKey1, "key2": dummyKey2}, "")
+
+	// assert
+	assert.Equal(t, dummyKey2, byKeyID)
+	assert.Nil(t, unknownKeyID)
+	assert.Equal(t, dummyKey1, onlyKey)
+	assert.Nil(t, ambiguousKey)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestVerifySignature_HMAC(t *testing.T) {
+	// arrange
+	var dummySecret = []byte("some secret")
+	var segments = strings.Split(createHMACToken(t, dummySecret, map[string]interface{}{"sub": "some subject"}), ".")
+	var signingInput = segments[0] + "." + segments[1]
+	var signature, _ = base64.RawURLEncoding.DecodeString(segments[2])
+	var header = jwtHeader{Algorithm: "HS256"}
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+
+	// SUT + act
+	var noSecretError = verifySignature(model.JWTSettings{}, nil, header, signingInput, signature)
+	var wrongSecretError = verifySignature(model.JWTSettings{HMACSecret: []byte("other secret")}, nil, header, signingInput, signature)
+	var validError = verifySignature(model.JWTSettings{HMACSecret: dummySecret}, nil, header, signingInput, signature)
+
+	// assert
+	assert.Equal(t, "No HMAC secret configured for token algorithm [HS256]", noSecretError.Error())
+	assert.Equal(t, "Invalid token signature", wrongSecretError.Error())
+	assert.NoError(t, validError)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestVerifySignature_RSA(t *testing.T) {
+	// arrange
+	var privateKey, _ = rsa.GenerateKey(rand.Reader, 1024)
+	var otherKey, _ = rsa.GenerateKey(rand.Reader, 1024)
+	var segments = strings.Split(createRSAToken(t, privateKey, "some key", map[string]interface{}{"sub": "some subject"}), ".")
+	var signingInput = segments[0] + "." + segments[1]
+	var signature, _ = base64.RawURLEncoding.DecodeString(segments[2])
+	var header = jwtHeader{Algorithm: "RS256", KeyID: "some key"}
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+
+	// SUT + act
+	var noKeyError = verifySignature(model.JWTSettings{}, map[string]*rsa.PublicKey{}, header, signingInput, signature)
+	var wrongKeyError = verifySignature(model.JWTSettings{}, map[string]*rsa.PublicKey{"some key": &otherKey.PublicKey}, header, signingInput, signature)
+	var validError = verifySignature(model.JWTSettings{}, map[string]*rsa.PublicKey{"some key": &privateKey.PublicKey}, header, signingInput, signature)
+
+	// assert
+	assert.Equal(t, "No RSA key found for token key ID [some key]", noKeyError.Error())
+	assert.Error(t, wrongKeyError)
+	assert.NoError(t, validError)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestVerifySignature_UnsupportedAlgorithm(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+
+	// SUT + act
+	var err = verifySignature(
+		model.JWTSettings{HMACSecret: []byte("some secret")},
+		nil,
+		jwtHeader{Algorithm: "none"},
+		"some signing input",
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, "Unsupported token algorithm [none]", err.Error())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetTimeClaim(t *testing.T) {
+	// arrange
+	var dummyClaims = map[string]interface{}{
+		"exp": float64(1600000000),
+		"nbf": "some text",
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var expiry, hasExpiry = getTimeClaim(dummyClaims, "exp")
+	var _, hasNotBefore = getTimeClaim(dummyClaims, "nbf")
+	var _, hasIssuedAt = getTimeClaim(dummyClaims, "iat")
+
+	// assert
+	assert.Equal(t, time.Unix(1600000000, 0).UTC(), expiry)
+	assert.True(t, hasExpiry)
+	assert.False(t, hasNotBefore)
+	assert.False(t, hasIssuedAt)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetStringListClaim(t *testing.T) {
+	// arrange
+	var dummyClaims = map[string]interface{}{
+		"scope": "read  write",
+		"roles": []interface{}{"admin", 123, "reader"},
+		"sub":   123.0,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+
+	// SUT + act
+	var scopes = getStringListClaim(dummyClaims, "scope")
+	var roles = getStringListClaim(dummyClaims, "roles")
+	var subject = getStringListClaim(dummyClaims, "sub")
+	var missing = getStringListClaim(dummyClaims, "aud")
+
+	// assert
+	assert.Equal(t, []string{"read", "write"}, scopes)
+	assert.Equal(t, []string{"admin", "reader"}, roles)
+	assert.Nil(t, subject)
+	assert.Nil(t, missing)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestContainsString(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var found = containsString([]string{"a", "b"}, "b")
+	var notFound = containsString([]string{"a", "b"}, "c")
+
+	// assert
+	assert.True(t, found)
+	assert.False(t, notFound)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestValidateClaims(t *testing.T) {
+	// arrange
+	var dummyNow = time.Unix(1600000000, 0).UTC()
+	var dummySettings = model.JWTSettings{
+		Issuer:   "some issuer",
+		Audience: "some audience",
+		Leeway:   time.Minute,
+	}
+	var validClaims = func() map[string]interface{} {
+		return map[string]interface{}{
+			"exp": float64(dummyNow.Unix() - 30),
+			"nbf": float64(dummyNow.Unix() + 30),
+			"iss": "some issuer",
+			"aud": []interface{}{"other audience", "some audience"},
+		}
+	}
+	var expiredClaims = validClaims()
+	expiredClaims["exp"] = float64(dummyNow.Unix() - 61)
+	var notYetValidClaims = validClaims()
+	notYetValidClaims["nbf"] = float64(dummyNow.Unix() + 61)
+	var wrongIssuerClaims = validClaims()
+	wrongIssuerClaims["iss"] = "other issuer"
+	var wrongAudienceClaims = validClaims()
+	wrongAudienceClaims["aud"] = "other audience"
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+	timeutilGetTimeNowUTCExpected = 5
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+
+	// SUT + act
+	var validError = validateClaims(dummySettings, validClaims())
+	var expiredError = validateClaims(dummySettings, expiredClaims)
+	var notYetValidError = validateClaims(dummySettings, notYetValidClaims)
+	var wrongIssuerError = validateClaims(dummySettings, wrongIssuerClaims)
+	var wrongAudienceError = validateClaims(dummySettings, wrongAudienceClaims)
+
+	// assert
+	assert.NoError(t, validError)
+	assert.Contains(t, expiredError.Error(), "Token expired at")
+	assert.Contains(t, notYetValidError.Error(), "Token not valid before")
+	assert.Equal(t, "Token issuer [other issuer] does not match [some issuer]", wrongIssuerError.Error())
+	assert.Equal(t, "Token audience [other audience] does not include [some audience]", wrongAudienceError.Error())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestJWTAuthenticate_NoBearerToken(t *testing.T) {
+	// arrange
+	var authenticator = &jwtAuthenticator{}
+	var noHeaderRequest, _ = http.NewRequest(http.MethodGet, "http://localhost", nil)
+	var basicRequest, _ = http.NewRequest(http.MethodGet, "http://localhost", nil)
+	basicRequest.Header.Set("Authorization", "Basic c29tZTp1c2Vy")
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+
+	// SUT + act
+	var noHeaderPrincipal, noHeaderError = authenticator.Authenticate(noHeaderRequest)
+	var basicPrincipal, basicError = authenticator.Authenticate(basicRequest)
+
+	// assert
+	assert.Nil(t, noHeaderPrincipal)
+	assert.NoError(t, noHeaderError)
+	assert.Nil(t, basicPrincipal)
+	assert.NoError(t, basicError)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestJWTAuthenticate_MalformedToken(t *testing.T) {
+	// arrange
+	var authenticator = &jwtAuthenticator{}
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodGet, "http://localhost", nil)
+	dummyHTTPRequest.Header.Set("Authorization", "Bearer some.token")
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+
+	// SUT + act
+	var principal, err = authenticator.Authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, principal)
+	assert.Equal(t, "Malformed bearer token", err.Error())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestJWTAuthenticate_InvalidSignature(t *testing.T) {
+	// arrange
+	var authenticator = &jwtAuthenticator{settings: model.JWTSettings{HMACSecret: []byte("some secret")}}
+	var dummyToken = createHMACToken(t, []byte("other secret"), map[string]interface{}{"sub": "some subject"})
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodGet, "http://localhost", nil)
+	dummyHTTPRequest.Header.Set("Authorization", "Bearer "+dummyToken)
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+
+	// SUT + act
+	var principal, err = authenticator.Authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, principal)
+	assert.Equal(t, "Invalid token signature", err.Error())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestJWTAuthenticate_InvalidClaims(t *testing.T) {
+	// arrange
+	var authenticator = &jwtAuthenticator{settings: model.JWTSettings{HMACSecret: []byte("some secret"), Issuer: "some issuer"}}
+	var dummyToken = createHMACToken(t, []byte("some secret"), map[string]interface{}{"iss": "other issuer"})
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodGet, "http://localhost", nil)
+	dummyHTTPRequest.Header.Set("Authorization", "Bearer "+dummyToken)
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now().UTC()
+	}
+
+	// SUT + act
+	var principal, err = authenticator.Authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, principal)
+	assert.Equal(t, "Token issuer [other issuer] does not match [some issuer]", err.Error())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestJWTAuthenticate_HMAC(t *testing.T) {
+	// arrange
+	var authenticator = &jwtAuthenticator{settings: model.JWTSettings{HMACSecret: []byte("some secret")}}
+	var dummyClaims = map[string]interface{}{
+		"sub":   "some subject",
+		"scope": "read write",
+		"scp":   []interface{}{"admin"},
+		"roles": []interface{}{"reader"},
+	}
+	var dummyToken = createHMACToken(t, []byte("some secret"), dummyClaims)
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodGet, "http://localhost", nil)
+	dummyHTTPRequest.Header.Set("Authorization", "bearer "+dummyToken)
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now().UTC()
+	}
+
+	// SUT + act
+	var principal, err = authenticator.Authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "some subject", principal.Subject)
+	assert.Equal(t, jwtMethod, principal.Method)
+	assert.Equal(t, []string{"read", "write", "admin"}, principal.Scopes)
+	assert.Equal(t, []string{"reader"}, principal.Roles)
+	assert.Equal(t, dummyClaims, principal.Claims)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestJWTAuthenticate_RSA(t *testing.T) {
+	// arrange
+	var privateKey, _ = rsa.GenerateKey(rand.Reader, 1024)
+	var authenticator = &jwtAuthenticator{rsaKeys: map[string]*rsa.PublicKey{"some key": &privateKey.PublicKey}}
+	var dummyToken = createRSAToken(t, privateKey, "some key", map[string]interface{}{"sub": "some subject"})
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodGet, "http://localhost", nil)
+	dummyHTTPRequest.Header.Set("Authorization", "Bearer "+dummyToken)
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealJWTFunctions()
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Now().UTC()
+	}
+
+	// SUT + act
+	var principal, err = authenticator.Authenticate(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "some subject", principal.Subject)
+	assert.Empty(t, principal.Scopes)
+	assert.Nil(t, principal.Roles)
+
+	// verify
+	verifyAll(t)
+}
@@ -0,0 +1,26 @@
+package bodylimit
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	"github.com/zhongjie-cai/WebServiceTemplate/request"
+)
+
+// func pointers for injection / testing: bodylimit.go
+var (
+	fmtErrorf                             = fmt.Errorf
+	ioLimitReader                         = io.LimitReader
+	ioutilReadAll                         = ioutil.ReadAll
+	ioutilNopCloser                       = ioutil.NopCloser
+	bytesNewBuffer                        = bytes.NewBuffer
+	apperrorGetBadRequestError            = apperror.GetBadRequestError
+	apperrorGetRequestEntityTooLargeError = apperror.GetRequestEntityTooLargeError
+	requestDecompressBody                 = request.DecompressBody
+	getMaxBodyBytesFunc                   = getMaxBodyBytes
+	getTooLargeErrorFunc                  = getTooLargeError
+	limitBodyFunc                         = limitBody
+)
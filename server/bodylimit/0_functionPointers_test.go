@@ -0,0 +1,290 @@
+package bodylimit
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
+	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/request"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+var (
+	fmtErrorfExpected                             int
+	fmtErrorfCalled                               int
+	ioLimitReaderExpected                         int
+	ioLimitReaderCalled                           int
+	ioutilReadAllExpected                         int
+	ioutilReadAllCalled                           int
+	ioutilNopCloserExpected                       int
+	ioutilNopCloserCalled                         int
+	bytesNewBufferExpected                        int
+	bytesNewBufferCalled                          int
+	apperrorGetBadRequestErrorExpected            int
+	apperrorGetBadRequestErrorCalled              int
+	apperrorGetRequestEntityTooLargeErrorExpected int
+	apperrorGetRequestEntityTooLargeErrorCalled   int
+	requestDecompressBodyExpected                 int
+	requestDecompressBodyCalled                   int
+	getMaxBodyBytesFuncExpected                   int
+	getMaxBodyBytesFuncCalled                     int
+	getTooLargeErrorFuncExpected                  int
+	getTooLargeErrorFuncCalled                    int
+	limitBodyFuncExpected                         int
+	limitBodyFuncCalled                           int
+	customizationMaxRequestBodyBytesExpected      int
+	customizationMaxRequestBodyBytesCalled        int
+)
+
+func createMock(t *testing.T) {
+	fmtErrorfExpected = 0
+	fmtErrorfCalled = 0
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		return nil
+	}
+	ioLimitReaderExpected = 0
+	ioLimitReaderCalled = 0
+	ioLimitReader = func(r io.Reader, n int64) io.Reader {
+		ioLimitReaderCalled++
+		return nil
+	}
+	ioutilReadAllExpected = 0
+	ioutilReadAllCalled = 0
+	ioutilReadAll = func(r io.Reader) ([]byte, error) {
+		ioutilReadAllCalled++
+		return nil, nil
+	}
+	ioutilNopCloserExpected = 0
+	ioutilNopCloserCalled = 0
+	ioutilNopCloser = func(r io.Reader) io.ReadCloser {
+		ioutilNopCloserCalled++
+		return nil
+	}
+	bytesNewBufferExpected = 0
+	bytesNewBufferCalled = 0
+	bytesNewBuffer = func(buf []byte) *bytes.Buffer {
+		bytesNewBufferCalled++
+		return nil
+	}
+	apperrorGetBadRequestErrorExpected = 0
+	apperrorGetBadRequestErrorCalled = 0
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		return nil
+	}
+	apperrorGetRequestEntityTooLargeErrorExpected = 0
+	apperrorGetRequestEntityTooLargeErrorCalled = 0
+	apperrorGetRequestEntityTooLargeError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetRequestEntityTooLargeErrorCalled++
+		return nil
+	}
+	requestDecompressBodyExpected = 0
+	requestDecompressBodyCalled = 0
+	requestDecompressBody = func(httpRequest *http.Request, maxBytes int64) error {
+		requestDecompressBodyCalled++
+		return nil
+	}
+	getMaxBodyBytesFuncExpected = 0
+	getMaxBodyBytesFuncCalled = 0
+	getMaxBodyBytesFunc = func(route model.Route) int64 {
+		getMaxBodyBytesFuncCalled++
+		return 0
+	}
+	getTooLargeErrorFuncExpected = 0
+	getTooLargeErrorFuncCalled = 0
+	getTooLargeErrorFunc = func(endpoint string, maxBodyBytes int64) error {
+		getTooLargeErrorFuncCalled++
+		return nil
+	}
+	limitBodyFuncExpected = 0
+	limitBodyFuncCalled = 0
+	limitBodyFunc = func(httpRequest *http.Request, endpoint string, maxBodyBytes int64) error {
+		limitBodyFuncCalled++
+		return nil
+	}
+	customizationMaxRequestBodyBytesExpected = 0
+	customizationMaxRequestBodyBytesCalled = 0
+	customization.MaxRequestBodyBytes = nil
+}
+
+func verifyAll(t *testing.T) {
+	fmtErrorf = fmt.Errorf
+	assert.Equal(t, fmtErrorfExpected, fmtErrorfCalled, "Unexpected number of calls to fmtErrorf")
+	ioLimitReader = io.LimitReader
+	assert.Equal(t, ioLimitReaderExpected, ioLimitReaderCalled, "Unexpected number of calls to ioLimitReader")
+	ioutilReadAll = ioutil.ReadAll
+	assert.Equal(t, ioutilReadAllExpected, ioutilReadAllCalled, "Unexpected number of calls to ioutilReadAll")
+	ioutilNopCloser = ioutil.NopCloser
+	assert.Equal(t, ioutilNopCloserExpected, ioutilNopCloserCalled, "Unexpected number of calls to ioutilNopCloser")
+	bytesNewBuffer = bytes.NewBuffer
+	assert.Equal(t, bytesNewBufferExpected, bytesNewBufferCalled, "Unexpected number of calls to bytesNewBuffer")
+	apperrorGetBadRequestError = apperror.GetBadRequestError
+	assert.Equal(t, apperrorGetBadRequestErrorExpected, apperrorGetBadRequestErrorCalled, "Unexpected number of calls to apperrorGetBadRequestError")
+	apperrorGetRequestEntityTooLargeError = apperror.GetRequestEntityTooLargeError
+	assert.Equal(t, apperrorGetRequestEntityTooLargeErrorExpected, apperrorGetRequestEntityTooLargeErrorCalled, "Unexpected number of calls to apperrorGetRequestEntityTooLargeError")
+	requestDecompressBody = request.DecompressBody
+	assert.Equal(t, requestDecompressBodyExpected, requestDecompressBodyCalled, "Unexpected number of calls to requestDecompressBody")
+	getMaxBodyBytesFunc = getMaxBodyBytes
+	assert.Equal(t, getMaxBodyBytesFuncExpected, getMaxBodyBytesFuncCalled, "Unexpected number of calls to getMaxBodyBytesFunc")
+	getTooLargeErrorFunc = getTooLargeError
+	assert.Equal(t, getTooLargeErrorFuncExpected, getTooLargeErrorFuncCalled, "Unexpected number of calls to getTooLargeErrorFunc")
+	limitBodyFunc = limitBody
+	assert.Equal(t, limitBodyFuncExpected, limitBodyFuncCalled, "Unexpected number of calls to limitBodyFunc")
+	customization.MaxRequestBodyBytes = nil
+	assert.Equal(t, customizationMaxRequestBodyBytesExpected, customizationMaxRequestBodyBytesCalled, "Unexpected number of calls to customization.MaxRequestBodyBytes")
+}
+
+// mock structs
+type dummySession struct {
+	t           *testing.T
+	httpRequest *http.Request
+}
+
+func (session *dummySession) GetID() uuid.UUID {
+	assert.Fail(session.t, "Unexpected call to GetID")
+	return uuid.Nil
+}
+
+func (session *dummySession) GetName() string {
+	assert.Fail(session.t, "Unexpected call to GetName")
+	return ""
+}
+
+func (session *dummySession) GetCorrelationID() string {
+	assert.Fail(session.t, "Unexpected call to GetCorrelationID")
+	return ""
+}
+
+func (session *dummySession) GetRequest() *http.Request {
+	if session.httpRequest == nil {
+		assert.Fail(session.t, "Unexpected call to GetRequest")
+	}
+	return session.httpRequest
+}
+
+func (session *dummySession) GetResponseWriter() http.ResponseWriter {
+	assert.Fail(session.t, "Unexpected call to GetResponseWriter")
+	return nil
+}
+
+func (session *dummySession) SetResponseETag(etag string, weak bool) {
+	assert.Fail(session.t, "Unexpected call to SetResponseETag")
+}
+
+func (session *dummySession) SetResponseLastModified(lastModified time.Time) {
+	assert.Fail(session.t, "Unexpected call to SetResponseLastModified")
+}
+
+func (session *dummySession) GetRequestBody(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestBody")
+	return nil
+}
+
+func (session *dummySession) GetRequestParameter(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestParameter")
+	return nil
+}
+
+func (session *dummySession) GetRequestQuery(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestQuery")
+	return nil
+}
+
+func (session *dummySession) GetRequestQueries(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestQueries")
+	return nil
+}
+
+func (session *dummySession) GetRequestHeader(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestHeader")
+	return nil
+}
+
+func (session *dummySession) GetRequestHeaders(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestHeaders")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValue(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValue")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValues(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValues")
+	return nil
+}
+
+func (session *dummySession) GetRequestFile(name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFile")
+	return nil
+}
+
+func (session *dummySession) BindRequest(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to BindRequest")
+	return nil
+}
+
+func (session *dummySession) Attach(name string, value interface{}) bool {
+	assert.Fail(session.t, "Unexpected call to Attach")
+	return false
+}
+
+func (session *dummySession) Detach(name string) bool {
+	assert.Fail(session.t, "Unexpected call to Detach")
+	return false
+}
+
+func (session *dummySession) GetRawAttachment(name string) (interface{}, bool) {
+	assert.Fail(session.t, "Unexpected call to GetRawAttachment")
+	return nil, false
+}
+
+func (session *dummySession) GetAttachment(name string, dataTemplate interface{}) bool {
+	assert.Fail(session.t, "Unexpected call to GetAttachment")
+	return false
+}
+
+func (session *dummySession) IsLoggingAllowed(logType logtype.LogType, logLevel loglevel.LogLevel) bool {
+	assert.Fail(session.t, "Unexpected call to IsLoggingAllowed")
+	return false
+}
+
+func (session *dummySession) LogMethodEnter() {
+	assert.Fail(session.t, "Unexpected call to LogMethodEnter")
+}
+
+func (session *dummySession) LogMethodParameter(parameters ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodParameter")
+}
+
+func (session *dummySession) LogMethodLogic(logLevel loglevel.LogLevel, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodLogic")
+}
+
+func (session *dummySession) LogMethodReturn(returns ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodReturn")
+}
+
+func (session *dummySession) LogMethodExit() {
+	assert.Fail(session.t, "Unexpected call to LogMethodExit")
+}
+
+func (session *dummySession) CreateNetworkRequest(method string, url string, payload string, header map[string]string) networkModel.NetworkRequest {
+	assert.Fail(session.t, "Unexpected call to CreateNetworkRequest")
+	return nil
+}
@@ -0,0 +1,89 @@
+package bodylimit
+
+import (
+	"net/http"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+func getMaxBodyBytes(route model.Route) int64 {
+	if route.MaxBodyBytes > 0 {
+		return route.MaxBodyBytes
+	}
+	if customization.MaxRequestBodyBytes == nil {
+		return 0
+	}
+	return customization.MaxRequestBodyBytes()
+}
+
+func getTooLargeError(endpoint string, maxBodyBytes int64) error {
+	return apperrorGetRequestEntityTooLargeError(
+		fmtErrorf(
+			"Request body exceeds the limit of [%v] bytes for endpoint [%v]",
+			maxBodyBytes,
+			endpoint,
+		),
+	)
+}
+
+func limitBody(httpRequest *http.Request, endpoint string, maxBodyBytes int64) error {
+	if maxBodyBytes <= 0 {
+		return nil
+	}
+	if httpRequest.ContentLength > maxBodyBytes {
+		return getTooLargeErrorFunc(
+			endpoint,
+			maxBodyBytes,
+		)
+	}
+	if httpRequest.Body == nil ||
+		httpRequest.Body == http.NoBody {
+		return nil
+	}
+	defer httpRequest.Body.Close()
+	var bodyBytes, bodyError = ioutilReadAll(
+		ioLimitReader(
+			httpRequest.Body,
+			maxBodyBytes+1,
+		),
+	)
+	if bodyError != nil {
+		return apperrorGetBadRequestError(
+			bodyError,
+		)
+	}
+	if int64(len(bodyBytes)) > maxBodyBytes {
+		return getTooLargeErrorFunc(
+			endpoint,
+			maxBodyBytes,
+		)
+	}
+	httpRequest.Body = ioutilNopCloser(
+		bytesNewBuffer(
+			bodyBytes,
+		),
+	)
+	return nil
+}
+
+// Check enforces the maximum request body size of the given route for the given session, falling back to customization.MaxRequestBodyBytes if the route sets none, buffering the request body within the limit for later consumption, and decompresses gzip-encoded request body within the same limit; returns a RequestEntityTooLarge error if the limit is exceeded, or a BadRequest error if the request body could not be read or decompressed
+func Check(session sessionModel.Session, route model.Route) error {
+	var maxBodyBytes = getMaxBodyBytesFunc(
+		route,
+	)
+	var httpRequest = session.GetRequest()
+	var limitError = limitBodyFunc(
+		httpRequest,
+		route.Endpoint,
+		maxBodyBytes,
+	)
+	if limitError != nil {
+		return limitError
+	}
+	return requestDecompressBody(
+		httpRequest,
+		maxBodyBytes,
+	)
+}
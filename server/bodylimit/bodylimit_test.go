@@ -0,0 +1,504 @@
+package bodylimit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+func TestGetMaxBodyBytes_RouteConfigured(t *testing.T) {
+	// arrange
+	var dummyRoute = model.Route{
+		Endpoint:     "some endpoint",
+		MaxBodyBytes: rand.Int63n(100) + 1,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customization.MaxRequestBodyBytes = func() int64 {
+		customizationMaxRequestBodyBytesCalled++
+		return 0
+	}
+
+	// SUT + act
+	var result = getMaxBodyBytes(
+		dummyRoute,
+	)
+
+	// assert
+	assert.Equal(t, dummyRoute.MaxBodyBytes, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetMaxBodyBytes_NoCustomization(t *testing.T) {
+	// arrange
+	var dummyRoute = model.Route{
+		Endpoint: "some endpoint",
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getMaxBodyBytes(
+		dummyRoute,
+	)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetMaxBodyBytes_Customized(t *testing.T) {
+	// arrange
+	var dummyRoute = model.Route{
+		Endpoint:     "some endpoint",
+		MaxBodyBytes: -rand.Int63n(100),
+	}
+	var dummyMaxBodyBytes = rand.Int63n(100) + 1
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationMaxRequestBodyBytesExpected = 1
+	customization.MaxRequestBodyBytes = func() int64 {
+		customizationMaxRequestBodyBytesCalled++
+		return dummyMaxBodyBytes
+	}
+
+	// SUT + act
+	var result = getMaxBodyBytes(
+		dummyRoute,
+	)
+
+	// assert
+	assert.Equal(t, dummyMaxBodyBytes, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetTooLargeError(t *testing.T) {
+	// arrange
+	var dummyEndpoint = "some endpoint"
+	var dummyMaxBodyBytes = rand.Int63n(100) + 1
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Request body exceeds the limit of [%v] bytes for endpoint [%v]", format)
+		assert.Equal(t, 2, len(a))
+		assert.Equal(t, dummyMaxBodyBytes, a[0])
+		assert.Equal(t, dummyEndpoint, a[1])
+		return dummyError
+	}
+	apperrorGetRequestEntityTooLargeErrorExpected = 1
+	apperrorGetRequestEntityTooLargeError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetRequestEntityTooLargeErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = getTooLargeError(
+		dummyEndpoint,
+		dummyMaxBodyBytes,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLimitBody_NoLimit(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummyEndpoint = "some endpoint"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err = limitBody(
+		dummyHTTPRequest,
+		dummyEndpoint,
+		0,
+	)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLimitBody_ContentLengthTooLarge(t *testing.T) {
+	// arrange
+	var dummyMaxBodyBytes = rand.Int63n(100) + 1
+	var dummyHTTPRequest = &http.Request{
+		ContentLength: dummyMaxBodyBytes + 1,
+	}
+	var dummyEndpoint = "some endpoint"
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	getTooLargeErrorFuncExpected = 1
+	getTooLargeErrorFunc = func(endpoint string, maxBodyBytes int64) error {
+		getTooLargeErrorFuncCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyMaxBodyBytes, maxBodyBytes)
+		return dummyError
+	}
+
+	// SUT + act
+	var err = limitBody(
+		dummyHTTPRequest,
+		dummyEndpoint,
+		dummyMaxBodyBytes,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLimitBody_NoBody(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Body: http.NoBody,
+	}
+	var dummyEndpoint = "some endpoint"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err = limitBody(
+		dummyHTTPRequest,
+		dummyEndpoint,
+		rand.Int63n(100)+1,
+	)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLimitBody_ReadError(t *testing.T) {
+	// arrange
+	var dummyMaxBodyBytes = rand.Int63n(100) + 1
+	var dummyBody = ioutil.NopCloser(strings.NewReader("some body"))
+	var dummyHTTPRequest = &http.Request{
+		ContentLength: -1,
+		Body:          dummyBody,
+	}
+	var dummyEndpoint = "some endpoint"
+	var dummyReader = strings.NewReader("some reader")
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	ioLimitReaderExpected = 1
+	ioLimitReader = func(r io.Reader, n int64) io.Reader {
+		ioLimitReaderCalled++
+		assert.Equal(t, dummyBody, r)
+		assert.Equal(t, dummyMaxBodyBytes+1, n)
+		return dummyReader
+	}
+	ioutilReadAllExpected = 1
+	ioutilReadAll = func(r io.Reader) ([]byte, error) {
+		ioutilReadAllCalled++
+		assert.Equal(t, dummyReader, r)
+		return nil, dummyError
+	}
+	apperrorGetBadRequestErrorExpected = 1
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = limitBody(
+		dummyHTTPRequest,
+		dummyEndpoint,
+		dummyMaxBodyBytes,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLimitBody_BodyTooLarge(t *testing.T) {
+	// arrange
+	var dummyMaxBodyBytes = int64(4)
+	var dummyHTTPRequest = &http.Request{
+		ContentLength: -1,
+		Body:          ioutil.NopCloser(strings.NewReader("some body")),
+	}
+	var dummyEndpoint = "some endpoint"
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	ioLimitReaderExpected = 1
+	ioLimitReader = func(r io.Reader, n int64) io.Reader {
+		ioLimitReaderCalled++
+		return io.LimitReader(r, n)
+	}
+	ioutilReadAllExpected = 1
+	ioutilReadAll = func(r io.Reader) ([]byte, error) {
+		ioutilReadAllCalled++
+		return ioutil.ReadAll(r)
+	}
+	getTooLargeErrorFuncExpected = 1
+	getTooLargeErrorFunc = func(endpoint string, maxBodyBytes int64) error {
+		getTooLargeErrorFuncCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyMaxBodyBytes, maxBodyBytes)
+		return dummyError
+	}
+
+	// SUT + act
+	var err = limitBody(
+		dummyHTTPRequest,
+		dummyEndpoint,
+		dummyMaxBodyBytes,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLimitBody_WithinLimit(t *testing.T) {
+	// arrange
+	var dummyMaxBodyBytes = int64(9)
+	var dummyContent = "some body"
+	var dummyHTTPRequest = &http.Request{
+		ContentLength: int64(len(dummyContent)),
+		Body:          ioutil.NopCloser(strings.NewReader(dummyContent)),
+	}
+	var dummyEndpoint = "some endpoint"
+	var dummyBuffer = &bytes.Buffer{}
+	var dummyReadCloser = ioutil.NopCloser(nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	ioLimitReaderExpected = 1
+	ioLimitReader = func(r io.Reader, n int64) io.Reader {
+		ioLimitReaderCalled++
+		return io.LimitReader(r, n)
+	}
+	ioutilReadAllExpected = 1
+	ioutilReadAll = func(r io.Reader) ([]byte, error) {
+		ioutilReadAllCalled++
+		return ioutil.ReadAll(r)
+	}
+	bytesNewBufferExpected = 1
+	bytesNewBuffer = func(buf []byte) *bytes.Buffer {
+		bytesNewBufferCalled++
+		assert.Equal(t, []byte(dummyContent), buf)
+		return dummyBuffer
+	}
+	ioutilNopCloserExpected = 1
+	ioutilNopCloser = func(r io.Reader) io.ReadCloser {
+		ioutilNopCloserCalled++
+		assert.Equal(t, dummyBuffer, r)
+		return dummyReadCloser
+	}
+
+	// SUT + act
+	var err = limitBody(
+		dummyHTTPRequest,
+		dummyEndpoint,
+		dummyMaxBodyBytes,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, dummyReadCloser, dummyHTTPRequest.Body)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheck_LimitError(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+	}
+	var dummyRoute = model.Route{
+		Endpoint: "some endpoint",
+		Method:   "some method",
+	}
+	var dummyMaxBodyBytes = rand.Int63n(100)
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	getMaxBodyBytesFuncExpected = 1
+	getMaxBodyBytesFunc = func(route model.Route) int64 {
+		getMaxBodyBytesFuncCalled++
+		assert.Equal(t, dummyRoute, route)
+		return dummyMaxBodyBytes
+	}
+	limitBodyFuncExpected = 1
+	limitBodyFunc = func(httpRequest *http.Request, endpoint string, maxBodyBytes int64) error {
+		limitBodyFuncCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyRoute.Endpoint, endpoint)
+		assert.Equal(t, dummyMaxBodyBytes, maxBodyBytes)
+		return dummyError
+	}
+
+	// SUT + act
+	var err = Check(
+		dummySessionObject,
+		dummyRoute,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheck_Decompress(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+	}
+	var dummyRoute = model.Route{
+		Endpoint: "some endpoint",
+		Method:   "some method",
+	}
+	var dummyMaxBodyBytes = rand.Int63n(100)
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	getMaxBodyBytesFuncExpected = 1
+	getMaxBodyBytesFunc = func(route model.Route) int64 {
+		getMaxBodyBytesFuncCalled++
+		return dummyMaxBodyBytes
+	}
+	limitBodyFuncExpected = 1
+	limitBodyFunc = func(httpRequest *http.Request, endpoint string, maxBodyBytes int64) error {
+		limitBodyFuncCalled++
+		return nil
+	}
+	requestDecompressBodyExpected = 1
+	requestDecompressBody = func(httpRequest *http.Request, maxBytes int64) error {
+		requestDecompressBodyCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyMaxBodyBytes, maxBytes)
+		return dummyError
+	}
+
+	// SUT + act
+	var err = Check(
+		dummySessionObject,
+		dummyRoute,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheck_GzipBombRejected(t *testing.T) {
+	// arrange
+	var compressedBuffer = &bytes.Buffer{}
+	var gzipWriter = gzip.NewWriter(compressedBuffer)
+	gzipWriter.Write(bytes.Repeat([]byte("a"), 1024))
+	gzipWriter.Close()
+	var compressedLength = int64(compressedBuffer.Len())
+	var dummyMaxBodyBytes = int64(100)
+	var dummyHTTPRequest = &http.Request{
+		Header:        http.Header{"Content-Encoding": {"gzip"}},
+		ContentLength: compressedLength,
+		Body:          ioutil.NopCloser(compressedBuffer),
+	}
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: dummyHTTPRequest,
+	}
+
+	var dummyRoute = model.Route{
+		Endpoint:     "some endpoint",
+		Method:       "some method",
+		MaxBodyBytes: dummyMaxBodyBytes,
+	}
+
+	// SUT + act
+	var err = Check(
+		dummySessionObject,
+		dummyRoute,
+	)
+
+	// assert
+	assert.Less(t, compressedLength, dummyMaxBodyBytes)
+	var appError, ok = err.(apperrorModel.AppError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, appError.HTTPStatusCode())
+}
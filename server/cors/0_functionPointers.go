@@ -0,0 +1,32 @@
+package cors
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/server/route"
+)
+
+// func pointers for injection / testing: cors.go
+var (
+	stringsIndex             = strings.Index
+	stringsEqualFold         = strings.EqualFold
+	stringsToLower           = strings.ToLower
+	stringsHasPrefix         = strings.HasPrefix
+	stringsHasSuffix         = strings.HasSuffix
+	stringsSplit             = strings.Split
+	stringsTrimSpace         = strings.TrimSpace
+	stringsJoin              = strings.Join
+	strconvItoa              = strconv.Itoa
+	routeGetRouteInfoByRoute = route.GetRouteInfoByRoute
+	getPolicyFunc            = getPolicy
+	matchOriginFunc          = matchOrigin
+	isOriginAllowedFunc      = isOriginAllowed
+	isMethodAllowedFunc      = isMethodAllowed
+	getRequestedHeadersFunc  = getRequestedHeaders
+	isHeaderAllowedFunc      = isHeaderAllowed
+	areHeadersAllowedFunc    = areHeadersAllowed
+	writeOriginHeadersFunc   = writeOriginHeaders
+	matchRouteFunc           = matchRoute
+	handlePreflightFunc      = handlePreflight
+)
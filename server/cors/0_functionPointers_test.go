@@ -0,0 +1,251 @@
+package cors
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/route"
+)
+
+var (
+	stringsIndexExpected             int
+	stringsIndexCalled               int
+	stringsEqualFoldExpected         int
+	stringsEqualFoldCalled           int
+	stringsToLowerExpected           int
+	stringsToLowerCalled             int
+	stringsHasPrefixExpected         int
+	stringsHasPrefixCalled           int
+	stringsHasSuffixExpected         int
+	stringsHasSuffixCalled           int
+	stringsSplitExpected             int
+	stringsSplitCalled               int
+	stringsTrimSpaceExpected         int
+	stringsTrimSpaceCalled           int
+	stringsJoinExpected              int
+	stringsJoinCalled                int
+	strconvItoaExpected              int
+	strconvItoaCalled                int
+	routeGetRouteInfoByRouteExpected int
+	routeGetRouteInfoByRouteCalled   int
+	getPolicyFuncExpected            int
+	getPolicyFuncCalled              int
+	matchOriginFuncExpected          int
+	matchOriginFuncCalled            int
+	isOriginAllowedFuncExpected      int
+	isOriginAllowedFuncCalled        int
+	isMethodAllowedFuncExpected      int
+	isMethodAllowedFuncCalled        int
+	getRequestedHeadersFuncExpected  int
+	getRequestedHeadersFuncCalled    int
+	isHeaderAllowedFuncExpected      int
+	isHeaderAllowedFuncCalled        int
+	areHeadersAllowedFuncExpected    int
+	areHeadersAllowedFuncCalled      int
+	writeOriginHeadersFuncExpected   int
+	writeOriginHeadersFuncCalled     int
+	matchRouteFuncExpected           int
+	matchRouteFuncCalled             int
+	handlePreflightFuncExpected      int
+	handlePreflightFuncCalled        int
+	customizationCORSExpected        int
+	customizationCORSCalled          int
+)
+
+func createMock(t *testing.T) {
+	stringsIndexExpected = 0
+	stringsIndexCalled = 0
+	stringsIndex = func(s, substr string) int {
+		stringsIndexCalled++
+		return 0
+	}
+	stringsEqualFoldExpected = 0
+	stringsEqualFoldCalled = 0
+	stringsEqualFold = func(s, t string) bool {
+		stringsEqualFoldCalled++
+		return false
+	}
+	stringsToLowerExpected = 0
+	stringsToLowerCalled = 0
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		return ""
+	}
+	stringsHasPrefixExpected = 0
+	stringsHasPrefixCalled = 0
+	stringsHasPrefix = func(s, prefix string) bool {
+		stringsHasPrefixCalled++
+		return false
+	}
+	stringsHasSuffixExpected = 0
+	stringsHasSuffixCalled = 0
+	stringsHasSuffix = func(s, suffix string) bool {
+		stringsHasSuffixCalled++
+		return false
+	}
+	stringsSplitExpected = 0
+	stringsSplitCalled = 0
+	stringsSplit = func(s, sep string) []string {
+		stringsSplitCalled++
+		return nil
+	}
+	stringsTrimSpaceExpected = 0
+	stringsTrimSpaceCalled = 0
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return ""
+	}
+	stringsJoinExpected = 0
+	stringsJoinCalled = 0
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		return ""
+	}
+	strconvItoaExpected = 0
+	strconvItoaCalled = 0
+	strconvItoa = func(i int) string {
+		strconvItoaCalled++
+		return ""
+	}
+	routeGetRouteInfoByRouteExpected = 0
+	routeGetRouteInfoByRouteCalled = 0
+	routeGetRouteInfoByRoute = func(route *mux.Route) (model.Route, bool) {
+		routeGetRouteInfoByRouteCalled++
+		return model.Route{}, false
+	}
+	getPolicyFuncExpected = 0
+	getPolicyFuncCalled = 0
+	getPolicyFunc = func(routePolicy *model.CORS) *model.CORS {
+		getPolicyFuncCalled++
+		return nil
+	}
+	matchOriginFuncExpected = 0
+	matchOriginFuncCalled = 0
+	matchOriginFunc = func(allowedOrigin string, origin string) bool {
+		matchOriginFuncCalled++
+		return false
+	}
+	isOriginAllowedFuncExpected = 0
+	isOriginAllowedFuncCalled = 0
+	isOriginAllowedFunc = func(policy *model.CORS, origin string) bool {
+		isOriginAllowedFuncCalled++
+		return false
+	}
+	isMethodAllowedFuncExpected = 0
+	isMethodAllowedFuncCalled = 0
+	isMethodAllowedFunc = func(policy *model.CORS, method string) bool {
+		isMethodAllowedFuncCalled++
+		return false
+	}
+	getRequestedHeadersFuncExpected = 0
+	getRequestedHeadersFuncCalled = 0
+	getRequestedHeadersFunc = func(httpRequest *http.Request) []string {
+		getRequestedHeadersFuncCalled++
+		return nil
+	}
+	isHeaderAllowedFuncExpected = 0
+	isHeaderAllowedFuncCalled = 0
+	isHeaderAllowedFunc = func(policy *model.CORS, header string) bool {
+		isHeaderAllowedFuncCalled++
+		return false
+	}
+	areHeadersAllowedFuncExpected = 0
+	areHeadersAllowedFuncCalled = 0
+	areHeadersAllowedFunc = func(policy *model.CORS, headers []string) bool {
+		areHeadersAllowedFuncCalled++
+		return false
+	}
+	writeOriginHeadersFuncExpected = 0
+	writeOriginHeadersFuncCalled = 0
+	writeOriginHeadersFunc = func(header http.Header, policy *model.CORS, origin string) {
+		writeOriginHeadersFuncCalled++
+	}
+	matchRouteFuncExpected = 0
+	matchRouteFuncCalled = 0
+	matchRouteFunc = func(router *mux.Router, httpRequest *http.Request, method string) (model.Route, bool) {
+		matchRouteFuncCalled++
+		return model.Route{}, false
+	}
+	handlePreflightFuncExpected = 0
+	handlePreflightFuncCalled = 0
+	handlePreflightFunc = func(router *mux.Router, responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		handlePreflightFuncCalled++
+	}
+	customizationCORSExpected = 0
+	customizationCORSCalled = 0
+	customization.CORS = nil
+}
+
+func verifyAll(t *testing.T) {
+	stringsIndex = strings.Index
+	assert.Equal(t, stringsIndexExpected, stringsIndexCalled, "Unexpected number of calls to stringsIndex")
+	stringsEqualFold = strings.EqualFold
+	assert.Equal(t, stringsEqualFoldExpected, stringsEqualFoldCalled, "Unexpected number of calls to stringsEqualFold")
+	stringsToLower = strings.ToLower
+	assert.Equal(t, stringsToLowerExpected, stringsToLowerCalled, "Unexpected number of calls to stringsToLower")
+	stringsHasPrefix = strings.HasPrefix
+	assert.Equal(t, stringsHasPrefixExpected, stringsHasPrefixCalled, "Unexpected number of calls to stringsHasPrefix")
+	stringsHasSuffix = strings.HasSuffix
+	assert.Equal(t, stringsHasSuffixExpected, stringsHasSuffixCalled, "Unexpected number of calls to stringsHasSuffix")
+	stringsSplit = strings.Split
+	assert.Equal(t, stringsSplitExpected, stringsSplitCalled, "Unexpected number of calls to stringsSplit")
+	stringsTrimSpace = strings.TrimSpace
+	assert.Equal(t, stringsTrimSpaceExpected, stringsTrimSpaceCalled, "Unexpected number of calls to stringsTrimSpace")
+	stringsJoin = strings.Join
+	assert.Equal(t, stringsJoinExpected, stringsJoinCalled, "Unexpected number of calls to stringsJoin")
+	strconvItoa = strconv.Itoa
+	assert.Equal(t, strconvItoaExpected, strconvItoaCalled, "Unexpected number of calls to strconvItoa")
+	routeGetRouteInfoByRoute = route.GetRouteInfoByRoute
+	assert.Equal(t, routeGetRouteInfoByRouteExpected, routeGetRouteInfoByRouteCalled, "Unexpected number of calls to routeGetRouteInfoByRoute")
+	getPolicyFunc = getPolicy
+	assert.Equal(t, getPolicyFuncExpected, getPolicyFuncCalled, "Unexpected number of calls to getPolicyFunc")
+	matchOriginFunc = matchOrigin
+	assert.Equal(t, matchOriginFuncExpected, matchOriginFuncCalled, "Unexpected number of calls to matchOriginFunc")
+	isOriginAllowedFunc = isOriginAllowed
+	assert.Equal(t, isOriginAllowedFuncExpected, isOriginAllowedFuncCalled, "Unexpected number of calls to isOriginAllowedFunc")
+	isMethodAllowedFunc = isMethodAllowed
+	assert.Equal(t, isMethodAllowedFuncExpected, isMethodAllowedFuncCalled, "Unexpected number of calls to isMethodAllowedFunc")
+	getRequestedHeadersFunc = getRequestedHeaders
+	assert.Equal(t, getRequestedHeadersFuncExpected, getRequestedHeadersFuncCalled, "Unexpected number of calls to getRequestedHeadersFunc")
+	isHeaderAllowedFunc = isHeaderAllowed
+	assert.Equal(t, isHeaderAllowedFuncExpected, isHeaderAllowedFuncCalled, "Unexpected number of calls to isHeaderAllowedFunc")
+	areHeadersAllowedFunc = areHeadersAllowed
+	assert.Equal(t, areHeadersAllowedFuncExpected, areHeadersAllowedFuncCalled, "Unexpected number of calls to areHeadersAllowedFunc")
+	writeOriginHeadersFunc = writeOriginHeaders
+	assert.Equal(t, writeOriginHeadersFuncExpected, writeOriginHeadersFuncCalled, "Unexpected number of calls to writeOriginHeadersFunc")
+	matchRouteFunc = matchRoute
+	assert.Equal(t, matchRouteFuncExpected, matchRouteFuncCalled, "Unexpected number of calls to matchRouteFunc")
+	handlePreflightFunc = handlePreflight
+	assert.Equal(t, handlePreflightFuncExpected, handlePreflightFuncCalled, "Unexpected number of calls to handlePreflightFunc")
+	customization.CORS = nil
+	assert.Equal(t, customizationCORSExpected, customizationCORSCalled, "Unexpected number of calls to customization.CORS")
+}
+
+// mock structs
+type dummyResponseWriter struct {
+	header     http.Header
+	statusCode int
+	body       []byte
+}
+
+func (drw *dummyResponseWriter) Header() http.Header {
+	if drw.header == nil {
+		drw.header = http.Header{}
+	}
+	return drw.header
+}
+
+func (drw *dummyResponseWriter) WriteHeader(statusCode int) {
+	drw.statusCode = statusCode
+}
+
+func (drw *dummyResponseWriter) Write(bytes []byte) (int, error) {
+	drw.body = append(drw.body, bytes...)
+	return len(bytes), nil
+}
@@ -0,0 +1,234 @@
+package cors
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+// These are the constants used by CORS handling
+const (
+	// Endpoint is the name of the route answering CORS preflight requests
+	Endpoint = "Preflight"
+
+	headerOrigin           = "Origin"
+	headerRequestMethod    = "Access-Control-Request-Method"
+	headerRequestHeaders   = "Access-Control-Request-Headers"
+	headerAllowOrigin      = "Access-Control-Allow-Origin"
+	headerAllowCredentials = "Access-Control-Allow-Credentials"
+	headerAllowMethods     = "Access-Control-Allow-Methods"
+	headerAllowHeaders     = "Access-Control-Allow-Headers"
+	headerExposeHeaders    = "Access-Control-Expose-Headers"
+	headerMaxAge           = "Access-Control-Max-Age"
+	headerVary             = "Vary"
+	wildcard               = "*"
+)
+
+func getPolicy(routePolicy *model.CORS) *model.CORS {
+	if routePolicy != nil {
+		return routePolicy
+	}
+	if customization.CORS == nil {
+		return nil
+	}
+	return customization.CORS()
+}
+
+func matchOrigin(allowedOrigin string, origin string) bool {
+	if allowedOrigin == wildcard {
+		return true
+	}
+	var wildcardIndex = stringsIndex(
+		allowedOrigin,
+		wildcard,
+	)
+	if wildcardIndex < 0 {
+		return stringsEqualFold(
+			allowedOrigin,
+			origin,
+		)
+	}
+	var prefix = stringsToLower(allowedOrigin[:wildcardIndex])
+	var suffix = stringsToLower(allowedOrigin[wildcardIndex+1:])
+	var lowerOrigin = stringsToLower(origin)
+	return len(lowerOrigin) > len(prefix)+len(suffix) &&
+		stringsHasPrefix(lowerOrigin, prefix) &&
+		stringsHasSuffix(lowerOrigin, suffix)
+}
+
+func isOriginAllowed(policy *model.CORS, origin string) bool {
+	for _, allowedOrigin := range policy.AllowedOrigins {
+		if matchOriginFunc(allowedOrigin, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func isMethodAllowed(policy *model.CORS, method string) bool {
+	if len(policy.AllowedMethods) == 0 {
+		return true
+	}
+	for _, allowedMethod := range policy.AllowedMethods {
+		if stringsEqualFold(allowedMethod, method) {
+			return true
+		}
+	}
+	return false
+}
+
+func getRequestedHeaders(httpRequest *http.Request) []string {
+	var requestedHeaders = []string{}
+	var headerValues = stringsSplit(
+		httpRequest.Header.Get(headerRequestHeaders),
+		",",
+	)
+	for _, headerValue := range headerValues {
+		var requestedHeader = stringsTrimSpace(headerValue)
+		if requestedHeader != "" {
+			requestedHeaders = append(requestedHeaders, requestedHeader)
+		}
+	}
+	return requestedHeaders
+}
+
+func isHeaderAllowed(policy *model.CORS, header string) bool {
+	for _, allowedHeader := range policy.AllowedHeaders {
+		if allowedHeader == wildcard ||
+			stringsEqualFold(allowedHeader, header) {
+			return true
+		}
+	}
+	return false
+}
+
+func areHeadersAllowed(policy *model.CORS, headers []string) bool {
+	for _, header := range headers {
+		if !isHeaderAllowedFunc(policy, header) {
+			return false
+		}
+	}
+	return true
+}
+
+// writeOriginHeaders allows any origin through a literal "*" without credentials if the policy allows any origin, so that arbitrary origins are never granted credentialed access; otherwise the requesting origin is echoed, together with credentials if allowed
+func writeOriginHeaders(header http.Header, policy *model.CORS, origin string) {
+	for _, allowedOrigin := range policy.AllowedOrigins {
+		if allowedOrigin == wildcard {
+			header.Set(headerAllowOrigin, wildcard)
+			return
+		}
+	}
+	if policy.AllowCredentials {
+		header.Set(headerAllowCredentials, "true")
+	}
+	header.Set(headerAllowOrigin, origin)
+	header.Add(headerVary, headerOrigin)
+}
+
+// WriteHeaders writes the CORS response headers for the actual request, if the request origin is allowed by the given route policy, or customization.CORS if the route policy is nil
+func WriteHeaders(responseWriter http.ResponseWriter, httpRequest *http.Request, routePolicy *model.CORS) {
+	var origin = httpRequest.Header.Get(headerOrigin)
+	if origin == "" {
+		return
+	}
+	var policy = getPolicyFunc(
+		routePolicy,
+	)
+	if policy == nil ||
+		!isOriginAllowedFunc(policy, origin) {
+		return
+	}
+	var header = responseWriter.Header()
+	writeOriginHeadersFunc(
+		header,
+		policy,
+		origin,
+	)
+	if len(policy.ExposedHeaders) > 0 {
+		header.Set(
+			headerExposeHeaders,
+			stringsJoin(policy.ExposedHeaders, ", "),
+		)
+	}
+}
+
+func matchRoute(router *mux.Router, httpRequest *http.Request, method string) (model.Route, bool) {
+	var matchRequest = httpRequest.Clone(
+		httpRequest.Context(),
+	)
+	matchRequest.Method = method
+	var routeMatch mux.RouteMatch
+	if !router.Match(matchRequest, &routeMatch) ||
+		routeMatch.MatchErr != nil ||
+		routeMatch.Route == nil {
+		return model.Route{}, false
+	}
+	return routeGetRouteInfoByRoute(
+		routeMatch.Route,
+	)
+}
+
+func handlePreflight(router *mux.Router, responseWriter http.ResponseWriter, httpRequest *http.Request) {
+	var origin = httpRequest.Header.Get(headerOrigin)
+	var method = httpRequest.Header.Get(headerRequestMethod)
+	var routeInfo, found = matchRouteFunc(
+		router,
+		httpRequest,
+		method,
+	)
+	var policy *model.CORS
+	if found {
+		policy = getPolicyFunc(
+			routeInfo.CORS,
+		)
+	}
+	if origin == "" ||
+		policy == nil ||
+		!isOriginAllowedFunc(policy, origin) ||
+		!isMethodAllowedFunc(policy, method) {
+		responseWriter.WriteHeader(http.StatusForbidden)
+		return
+	}
+	var requestedHeaders = getRequestedHeadersFunc(
+		httpRequest,
+	)
+	if !areHeadersAllowedFunc(policy, requestedHeaders) {
+		responseWriter.WriteHeader(http.StatusForbidden)
+		return
+	}
+	var header = responseWriter.Header()
+	writeOriginHeadersFunc(
+		header,
+		policy,
+		origin,
+	)
+	header.Set(headerAllowMethods, method)
+	if len(requestedHeaders) > 0 {
+		header.Set(
+			headerAllowHeaders,
+			stringsJoin(requestedHeaders, ", "),
+		)
+	}
+	if policy.MaxAge > 0 {
+		header.Set(
+			headerMaxAge,
+			strconvItoa(int(policy.MaxAge/time.Second)),
+		)
+	}
+	responseWriter.WriteHeader(http.StatusNoContent)
+}
+
+// PreflightHandler returns the handler answering CORS preflight requests upon any route registered to the given router, according to the CORS policy of the route serving the requested method; preflight requests not allowed by the policy are rejected with 403 Forbidden
+func PreflightHandler(router *mux.Router) func(http.ResponseWriter, *http.Request) {
+	return func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		handlePreflightFunc(
+			router,
+			responseWriter,
+			httpRequest,
+		)
+	}
+}
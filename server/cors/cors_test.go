@@ -0,0 +1,1014 @@
+package cors
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/route"
+)
+
+func useRealFunctions() {
+	stringsIndex = strings.Index
+	stringsEqualFold = strings.EqualFold
+	stringsToLower = strings.ToLower
+	stringsHasPrefix = strings.HasPrefix
+	stringsHasSuffix = strings.HasSuffix
+	stringsSplit = strings.Split
+	stringsTrimSpace = strings.TrimSpace
+	stringsJoin = strings.Join
+	strconvItoa = strconv.Itoa
+	routeGetRouteInfoByRoute = route.GetRouteInfoByRoute
+	getPolicyFunc = getPolicy
+	matchOriginFunc = matchOrigin
+	isOriginAllowedFunc = isOriginAllowed
+	isMethodAllowedFunc = isMethodAllowed
+	getRequestedHeadersFunc = getRequestedHeaders
+	isHeaderAllowedFunc = isHeaderAllowed
+	areHeadersAllowedFunc = areHeadersAllowed
+	writeOriginHeadersFunc = writeOriginHeaders
+	matchRouteFunc = matchRoute
+	handlePreflightFunc = handlePreflight
+}
+
+func TestGetPolicy_RoutePolicy(t *testing.T) {
+	// arrange
+	var dummyRoutePolicy = &model.CORS{}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getPolicy(
+		dummyRoutePolicy,
+	)
+
+	// assert
+	assert.Equal(t, dummyRoutePolicy, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetPolicy_NoCustomization(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getPolicy(
+		nil,
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetPolicy_Customized(t *testing.T) {
+	// arrange
+	var dummyPolicy = &model.CORS{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationCORSExpected = 1
+	customization.CORS = func() *model.CORS {
+		customizationCORSCalled++
+		return dummyPolicy
+	}
+
+	// SUT + act
+	var result = getPolicy(
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, dummyPolicy, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMatchOrigin(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	useRealFunctions()
+
+	// SUT + act
+	var anyOrigin = matchOrigin("*", "https://some.origin")
+	var exactOrigin = matchOrigin("https://Some.Origin", "https://some.origin")
+	var otherOrigin = matchOrigin("https://some.origin", "https://other.origin")
+	var subdomain = matchOrigin("https://*.example.com", "https://App.Example.com")
+	var nestedSubdomain = matchOrigin("https://*.example.com", "https://a.b.example.com")
+	var emptySubdomain = matchOrigin("https://*.example.com", "https://.example.com")
+	var otherScheme = matchOrigin("https://*.example.com", "http://app.example.com")
+	var otherDomain = matchOrigin("https://*.example.com", "https://app.example.org")
+
+	// assert
+	assert.True(t, anyOrigin)
+	assert.True(t, exactOrigin)
+	assert.False(t, otherOrigin)
+	assert.True(t, subdomain)
+	assert.True(t, nestedSubdomain)
+	assert.False(t, emptySubdomain)
+	assert.False(t, otherScheme)
+	assert.False(t, otherDomain)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsOriginAllowed(t *testing.T) {
+	// arrange
+	var dummyOrigin = "some origin"
+	var dummyPolicy = &model.CORS{
+		AllowedOrigins: []string{"origin 1", "origin 2", "origin 3"},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	matchOriginFuncExpected = 2
+	matchOriginFunc = func(allowedOrigin string, origin string) bool {
+		matchOriginFuncCalled++
+		assert.Equal(t, dummyPolicy.AllowedOrigins[matchOriginFuncCalled-1], allowedOrigin)
+		assert.Equal(t, dummyOrigin, origin)
+		return allowedOrigin == "origin 2"
+	}
+
+	// SUT + act
+	var result = isOriginAllowed(
+		dummyPolicy,
+		dummyOrigin,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsMethodAllowed(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	useRealFunctions()
+
+	// SUT + act
+	var noMethods = isMethodAllowed(&model.CORS{}, http.MethodPut)
+	var listedMethod = isMethodAllowed(&model.CORS{AllowedMethods: []string{"get", "put"}}, http.MethodPut)
+	var unlistedMethod = isMethodAllowed(&model.CORS{AllowedMethods: []string{"get"}}, http.MethodPut)
+
+	// assert
+	assert.True(t, noMethods)
+	assert.True(t, listedMethod)
+	assert.False(t, unlistedMethod)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestedHeaders(t *testing.T) {
+	// arrange
+	var noHeaderRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost", nil)
+	var headerRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost", nil)
+	headerRequest.Header.Set(headerRequestHeaders, "content-type, ,X-Custom ")
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealFunctions()
+
+	// SUT + act
+	var noHeaders = getRequestedHeaders(noHeaderRequest)
+	var headers = getRequestedHeaders(headerRequest)
+
+	// assert
+	assert.Empty(t, noHeaders)
+	assert.Equal(t, []string{"content-type", "X-Custom"}, headers)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsHeaderAllowed(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	useRealFunctions()
+
+	// SUT + act
+	var noHeaders = isHeaderAllowed(&model.CORS{}, "X-Custom")
+	var anyHeader = isHeaderAllowed(&model.CORS{AllowedHeaders: []string{"*"}}, "X-Custom")
+	var listedHeader = isHeaderAllowed(&model.CORS{AllowedHeaders: []string{"Content-Type", "x-custom"}}, "X-Custom")
+	var unlistedHeader = isHeaderAllowed(&model.CORS{AllowedHeaders: []string{"Content-Type"}}, "X-Custom")
+
+	// assert
+	assert.False(t, noHeaders)
+	assert.True(t, anyHeader)
+	assert.True(t, listedHeader)
+	assert.False(t, unlistedHeader)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestAreHeadersAllowed(t *testing.T) {
+	// arrange
+	var dummyPolicy = &model.CORS{}
+	var dummyHeaders = []string{"header 1", "header 2", "header 3"}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isHeaderAllowedFuncExpected = 2
+	isHeaderAllowedFunc = func(policy *model.CORS, header string) bool {
+		isHeaderAllowedFuncCalled++
+		assert.Equal(t, dummyPolicy, policy)
+		assert.Equal(t, dummyHeaders[isHeaderAllowedFuncCalled-1], header)
+		return header != "header 2"
+	}
+
+	// SUT + act
+	var noHeaders = areHeadersAllowed(dummyPolicy, nil)
+	var result = areHeadersAllowed(dummyPolicy, dummyHeaders)
+
+	// assert
+	assert.True(t, noHeaders)
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWriteOriginHeaders_AllowCredentials(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{}
+	var dummyPolicy = &model.CORS{
+		AllowedOrigins:   []string{"https://*.example.com"},
+		AllowCredentials: true,
+	}
+	var dummyOrigin = "https://app.example.com"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	writeOriginHeaders(
+		dummyHeader,
+		dummyPolicy,
+		dummyOrigin,
+	)
+
+	// assert
+	assert.Equal(t, dummyOrigin, dummyHeader.Get(headerAllowOrigin))
+	assert.Equal(t, "true", dummyHeader.Get(headerAllowCredentials))
+	assert.Equal(t, headerOrigin, dummyHeader.Get(headerVary))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWriteOriginHeaders_AnyOriginWithCredentials(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{}
+	var dummyPolicy = &model.CORS{
+		AllowedOrigins:   []string{"*"},
+		AllowCredentials: true,
+	}
+	var dummyOrigin = "some origin"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	writeOriginHeaders(
+		dummyHeader,
+		dummyPolicy,
+		dummyOrigin,
+	)
+
+	// assert
+	assert.Equal(t, "*", dummyHeader.Get(headerAllowOrigin))
+	assert.Empty(t, dummyHeader.Get(headerAllowCredentials))
+	assert.Empty(t, dummyHeader.Get(headerVary))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWriteOriginHeaders_AnyOrigin(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{}
+	var dummyPolicy = &model.CORS{
+		AllowedOrigins: []string{"some origin", "*"},
+	}
+	var dummyOrigin = "some origin"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	writeOriginHeaders(
+		dummyHeader,
+		dummyPolicy,
+		dummyOrigin,
+	)
+
+	// assert
+	assert.Equal(t, "*", dummyHeader.Get(headerAllowOrigin))
+	assert.Empty(t, dummyHeader.Get(headerAllowCredentials))
+	assert.Empty(t, dummyHeader.Get(headerVary))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWriteOriginHeaders_SpecificOrigin(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{}
+	var dummyPolicy = &model.CORS{
+		AllowedOrigins: []string{"https://*.example.com"},
+	}
+	var dummyOrigin = "https://app.example.com"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	writeOriginHeaders(
+		dummyHeader,
+		dummyPolicy,
+		dummyOrigin,
+	)
+
+	// assert
+	assert.Equal(t, dummyOrigin, dummyHeader.Get(headerAllowOrigin))
+	assert.Empty(t, dummyHeader.Get(headerAllowCredentials))
+	assert.Equal(t, headerOrigin, dummyHeader.Get(headerVary))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWriteHeaders_NoOrigin(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodGet, "http://localhost", nil)
+	var dummyRoutePolicy = &model.CORS{}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	WriteHeaders(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+		dummyRoutePolicy,
+	)
+
+	// assert
+	assert.Empty(t, dummyResponseWriter.Header())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWriteHeaders_NoPolicy(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodGet, "http://localhost", nil)
+	var dummyRoutePolicy *model.CORS
+
+	// stub
+	dummyHTTPRequest.Header.Set(headerOrigin, "some origin")
+
+	// mock
+	createMock(t)
+
+	// expect
+	getPolicyFuncExpected = 1
+	getPolicyFunc = func(routePolicy *model.CORS) *model.CORS {
+		getPolicyFuncCalled++
+		assert.Equal(t, dummyRoutePolicy, routePolicy)
+		return nil
+	}
+
+	// SUT + act
+	WriteHeaders(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+		dummyRoutePolicy,
+	)
+
+	// assert
+	assert.Empty(t, dummyResponseWriter.Header())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWriteHeaders_OriginNotAllowed(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodGet, "http://localhost", nil)
+	var dummyOrigin = "some origin"
+	var dummyRoutePolicy = &model.CORS{AllowedOrigins: []string{"some allowed origin"}}
+	var dummyPolicy = &model.CORS{}
+
+	// stub
+	dummyHTTPRequest.Header.Set(headerOrigin, dummyOrigin)
+
+	// mock
+	createMock(t)
+
+	// expect
+	getPolicyFuncExpected = 1
+	getPolicyFunc = func(routePolicy *model.CORS) *model.CORS {
+		getPolicyFuncCalled++
+		assert.Equal(t, dummyRoutePolicy, routePolicy)
+		return dummyPolicy
+	}
+	isOriginAllowedFuncExpected = 1
+	isOriginAllowedFunc = func(policy *model.CORS, origin string) bool {
+		isOriginAllowedFuncCalled++
+		assert.Equal(t, dummyPolicy, policy)
+		assert.Equal(t, dummyOrigin, origin)
+		return false
+	}
+
+	// SUT + act
+	WriteHeaders(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+		dummyRoutePolicy,
+	)
+
+	// assert
+	assert.Empty(t, dummyResponseWriter.Header())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWriteHeaders_Allowed(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodGet, "http://localhost", nil)
+	var dummyOrigin = "some origin"
+	var dummyPolicy = &model.CORS{
+		ExposedHeaders: []string{"header 1", "header 2"},
+	}
+	var dummyExposedHeaders = "some exposed headers"
+	var dummyRoutePolicy *model.CORS
+
+	// stub
+	dummyHTTPRequest.Header.Set(headerOrigin, dummyOrigin)
+
+	// mock
+	createMock(t)
+
+	// expect
+	getPolicyFuncExpected = 1
+	getPolicyFunc = func(routePolicy *model.CORS) *model.CORS {
+		getPolicyFuncCalled++
+		assert.Equal(t, dummyRoutePolicy, routePolicy)
+		return dummyPolicy
+	}
+	isOriginAllowedFuncExpected = 1
+	isOriginAllowedFunc = func(policy *model.CORS, origin string) bool {
+		isOriginAllowedFuncCalled++
+		return true
+	}
+	writeOriginHeadersFuncExpected = 1
+	writeOriginHeadersFunc = func(header http.Header, policy *model.CORS, origin string) {
+		writeOriginHeadersFuncCalled++
+		assert.Equal(t, dummyResponseWriter.Header(), header)
+		assert.Equal(t, dummyPolicy, policy)
+		assert.Equal(t, dummyOrigin, origin)
+	}
+	stringsJoinExpected = 1
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		assert.Equal(t, dummyPolicy.ExposedHeaders, elems)
+		assert.Equal(t, ", ", sep)
+		return dummyExposedHeaders
+	}
+
+	// SUT + act
+	WriteHeaders(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+		dummyRoutePolicy,
+	)
+
+	// assert
+	assert.Equal(t, dummyExposedHeaders, dummyResponseWriter.Header().Get(headerExposeHeaders))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMatchRoute_NotFound(t *testing.T) {
+	// arrange
+	var router = mux.NewRouter()
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost/foo", nil)
+
+	// stub
+	router.HandleFunc("/foo", func(http.ResponseWriter, *http.Request) {}).Methods(http.MethodGet).Name("Foo:GET")
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result, found = matchRoute(
+		router,
+		dummyHTTPRequest,
+		http.MethodPut,
+	)
+
+	// assert
+	assert.Zero(t, result)
+	assert.False(t, found)
+	assert.Equal(t, http.MethodOptions, dummyHTTPRequest.Method)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMatchRoute_Found(t *testing.T) {
+	// arrange
+	var router = mux.NewRouter()
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost/api/foo", nil)
+	var dummyRouteInfo = model.Route{
+		Endpoint: "Foo",
+		Method:   http.MethodPut,
+		CORS:     &model.CORS{},
+	}
+
+	// stub
+	var subrouter = router.PathPrefix("/api").Subrouter()
+	subrouter.HandleFunc("/foo", func(http.ResponseWriter, *http.Request) {}).Methods(http.MethodGet).Name("Foo:GET")
+	subrouter.HandleFunc("/foo", func(http.ResponseWriter, *http.Request) {}).Methods(http.MethodPut).Name("Foo:PUT")
+
+	// mock
+	createMock(t)
+
+	// expect
+	routeGetRouteInfoByRouteExpected = 1
+	routeGetRouteInfoByRoute = func(route *mux.Route) (model.Route, bool) {
+		routeGetRouteInfoByRouteCalled++
+		assert.Equal(t, "Foo:PUT", route.GetName())
+		return dummyRouteInfo, true
+	}
+
+	// SUT + act
+	var result, found = matchRoute(
+		router,
+		dummyHTTPRequest,
+		http.MethodPut,
+	)
+
+	// assert
+	assert.Equal(t, dummyRouteInfo, result)
+	assert.True(t, found)
+	assert.Equal(t, http.MethodOptions, dummyHTTPRequest.Method)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestHandlePreflight_RouteNotFound(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost", nil)
+
+	// stub
+	dummyHTTPRequest.Header.Set(headerOrigin, "some origin")
+	dummyHTTPRequest.Header.Set(headerRequestMethod, http.MethodPut)
+
+	// mock
+	createMock(t)
+
+	// expect
+	matchRouteFuncExpected = 1
+	matchRouteFunc = func(router *mux.Router, httpRequest *http.Request, method string) (model.Route, bool) {
+		matchRouteFuncCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, http.MethodPut, method)
+		return model.Route{}, false
+	}
+
+	// SUT + act
+	handlePreflight(
+		dummyRouter,
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusForbidden, dummyResponseWriter.statusCode)
+	assert.Empty(t, dummyResponseWriter.Header())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestHandlePreflight_NoPolicy(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost", nil)
+	var dummyRouteInfo = model.Route{CORS: &model.CORS{}}
+
+	// stub
+	dummyHTTPRequest.Header.Set(headerOrigin, "some origin")
+	dummyHTTPRequest.Header.Set(headerRequestMethod, http.MethodPut)
+
+	// mock
+	createMock(t)
+
+	// expect
+	matchRouteFuncExpected = 1
+	matchRouteFunc = func(router *mux.Router, httpRequest *http.Request, method string) (model.Route, bool) {
+		matchRouteFuncCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, http.MethodPut, method)
+		return dummyRouteInfo, true
+	}
+	getPolicyFuncExpected = 1
+	getPolicyFunc = func(routePolicy *model.CORS) *model.CORS {
+		getPolicyFuncCalled++
+		assert.Equal(t, dummyRouteInfo.CORS, routePolicy)
+		return nil
+	}
+
+	// SUT + act
+	handlePreflight(
+		dummyRouter,
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusForbidden, dummyResponseWriter.statusCode)
+	assert.Empty(t, dummyResponseWriter.Header())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestHandlePreflight_MethodNotAllowed(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost", nil)
+	var dummyOrigin = "some origin"
+	var dummyPolicy = &model.CORS{}
+
+	// stub
+	dummyHTTPRequest.Header.Set(headerOrigin, dummyOrigin)
+	dummyHTTPRequest.Header.Set(headerRequestMethod, http.MethodPut)
+
+	// mock
+	createMock(t)
+
+	// expect
+	matchRouteFuncExpected = 1
+	matchRouteFunc = func(router *mux.Router, httpRequest *http.Request, method string) (model.Route, bool) {
+		matchRouteFuncCalled++
+		return model.Route{}, true
+	}
+	getPolicyFuncExpected = 1
+	getPolicyFunc = func(routePolicy *model.CORS) *model.CORS {
+		getPolicyFuncCalled++
+		assert.Nil(t, routePolicy)
+		return dummyPolicy
+	}
+	isOriginAllowedFuncExpected = 1
+	isOriginAllowedFunc = func(policy *model.CORS, origin string) bool {
+		isOriginAllowedFuncCalled++
+		assert.Equal(t, dummyPolicy, policy)
+		assert.Equal(t, dummyOrigin, origin)
+		return true
+	}
+	isMethodAllowedFuncExpected = 1
+	isMethodAllowedFunc = func(policy *model.CORS, method string) bool {
+		isMethodAllowedFuncCalled++
+		assert.Equal(t, dummyPolicy, policy)
+		assert.Equal(t, http.MethodPut, method)
+		return false
+	}
+
+	// SUT + act
+	handlePreflight(
+		dummyRouter,
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusForbidden, dummyResponseWriter.statusCode)
+	assert.Empty(t, dummyResponseWriter.Header())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestHandlePreflight_HeadersNotAllowed(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost", nil)
+	var dummyPolicy = &model.CORS{}
+	var dummyRequestedHeaders = []string{"some header"}
+
+	// stub
+	dummyHTTPRequest.Header.Set(headerOrigin, "some origin")
+	dummyHTTPRequest.Header.Set(headerRequestMethod, http.MethodPut)
+
+	// mock
+	createMock(t)
+
+	// expect
+	matchRouteFuncExpected = 1
+	matchRouteFunc = func(router *mux.Router, httpRequest *http.Request, method string) (model.Route, bool) {
+		matchRouteFuncCalled++
+		return model.Route{}, true
+	}
+	getPolicyFuncExpected = 1
+	getPolicyFunc = func(routePolicy *model.CORS) *model.CORS {
+		getPolicyFuncCalled++
+		assert.Nil(t, routePolicy)
+		return dummyPolicy
+	}
+	isOriginAllowedFuncExpected = 1
+	isOriginAllowedFunc = func(policy *model.CORS, origin string) bool {
+		isOriginAllowedFuncCalled++
+		return true
+	}
+	isMethodAllowedFuncExpected = 1
+	isMethodAllowedFunc = func(policy *model.CORS, method string) bool {
+		isMethodAllowedFuncCalled++
+		return true
+	}
+	getRequestedHeadersFuncExpected = 1
+	getRequestedHeadersFunc = func(httpRequest *http.Request) []string {
+		getRequestedHeadersFuncCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return dummyRequestedHeaders
+	}
+	areHeadersAllowedFuncExpected = 1
+	areHeadersAllowedFunc = func(policy *model.CORS, headers []string) bool {
+		areHeadersAllowedFuncCalled++
+		assert.Equal(t, dummyPolicy, policy)
+		assert.Equal(t, dummyRequestedHeaders, headers)
+		return false
+	}
+
+	// SUT + act
+	handlePreflight(
+		dummyRouter,
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusForbidden, dummyResponseWriter.statusCode)
+	assert.Empty(t, dummyResponseWriter.Header())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestHandlePreflight_Allowed(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost", nil)
+	var dummyOrigin = "some origin"
+	var dummyPolicy = &model.CORS{
+		MaxAge: 10 * time.Minute,
+	}
+	var dummyRequestedHeaders = []string{"header 1", "header 2"}
+
+	// stub
+	dummyHTTPRequest.Header.Set(headerOrigin, dummyOrigin)
+	dummyHTTPRequest.Header.Set(headerRequestMethod, http.MethodPut)
+
+	// mock
+	createMock(t)
+
+	// expect
+	matchRouteFuncExpected = 1
+	matchRouteFunc = func(router *mux.Router, httpRequest *http.Request, method string) (model.Route, bool) {
+		matchRouteFuncCalled++
+		return model.Route{}, true
+	}
+	getPolicyFuncExpected = 1
+	getPolicyFunc = func(routePolicy *model.CORS) *model.CORS {
+		getPolicyFuncCalled++
+		assert.Nil(t, routePolicy)
+		return dummyPolicy
+	}
+	isOriginAllowedFuncExpected = 1
+	isOriginAllowedFunc = func(policy *model.CORS, origin string) bool {
+		isOriginAllowedFuncCalled++
+		return true
+	}
+	isMethodAllowedFuncExpected = 1
+	isMethodAllowedFunc = func(policy *model.CORS, method string) bool {
+		isMethodAllowedFuncCalled++
+		return true
+	}
+	getRequestedHeadersFuncExpected = 1
+	getRequestedHeadersFunc = func(httpRequest *http.Request) []string {
+		getRequestedHeadersFuncCalled++
+		return dummyRequestedHeaders
+	}
+	areHeadersAllowedFuncExpected = 1
+	areHeadersAllowedFunc = func(policy *model.CORS, headers []string) bool {
+		areHeadersAllowedFuncCalled++
+		return true
+	}
+	writeOriginHeadersFuncExpected = 1
+	writeOriginHeadersFunc = func(header http.Header, policy *model.CORS, origin string) {
+		writeOriginHeadersFuncCalled++
+		assert.Equal(t, dummyResponseWriter.Header(), header)
+		assert.Equal(t, dummyPolicy, policy)
+		assert.Equal(t, dummyOrigin, origin)
+	}
+	stringsJoinExpected = 1
+	stringsJoin = func(elems []string, sep string) string {
+		stringsJoinCalled++
+		assert.Equal(t, dummyRequestedHeaders, elems)
+		assert.Equal(t, ", ", sep)
+		return "some joined headers"
+	}
+	strconvItoaExpected = 1
+	strconvItoa = func(i int) string {
+		strconvItoaCalled++
+		assert.Equal(t, 600, i)
+		return "some max age"
+	}
+
+	// SUT + act
+	handlePreflight(
+		dummyRouter,
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusNoContent, dummyResponseWriter.statusCode)
+	assert.Equal(t, http.MethodPut, dummyResponseWriter.Header().Get(headerAllowMethods))
+	assert.Equal(t, "some joined headers", dummyResponseWriter.Header().Get(headerAllowHeaders))
+	assert.Equal(t, "some max age", dummyResponseWriter.Header().Get(headerMaxAge))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestPreflightHandler(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyHTTPRequest = &http.Request{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	handlePreflightFuncExpected = 1
+	handlePreflightFunc = func(router *mux.Router, responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		handlePreflightFuncCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyResponseWriter, responseWriter)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+	}
+
+	// SUT
+	var result = PreflightHandler(
+		dummyRouter,
+	)
+
+	// act
+	result(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestPreflight_Integration(t *testing.T) {
+	// arrange
+	var router = mux.NewRouter()
+	var dummyHandlerFunc = func(http.ResponseWriter, *http.Request) {}
+	var allowedResponseWriter = &dummyResponseWriter{}
+	var allowedRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost/users/123", nil)
+	var overriddenResponseWriter = &dummyResponseWriter{}
+	var overriddenRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost/users/123", nil)
+
+	// stub
+	route.HandleFunc(router, model.Route{Endpoint: "GetUser", Method: http.MethodGet}, "/users/{id}", nil, dummyHandlerFunc)
+	route.HandleFunc(router, model.Route{Endpoint: "DeleteUser", Method: http.MethodDelete, CORS: &model.CORS{AllowedOrigins: []string{"https://admin.example.com"}}}, "/users/{id}", nil, dummyHandlerFunc)
+	router.HandleFunc("/", PreflightHandler(router)).Methods(http.MethodOptions).Name(Endpoint)
+	allowedRequest.Header.Set(headerOrigin, "https://app.example.com")
+	allowedRequest.Header.Set(headerRequestMethod, http.MethodGet)
+	allowedRequest.Header.Set(headerRequestHeaders, "Content-Type")
+	overriddenRequest.Header.Set(headerOrigin, "https://app.example.com")
+	overriddenRequest.Header.Set(headerRequestMethod, http.MethodDelete)
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealFunctions()
+	customizationCORSExpected = 1
+	customization.CORS = func() *model.CORS {
+		customizationCORSCalled++
+		return &model.CORS{
+			AllowedOrigins:   []string{"https://*.example.com"},
+			AllowedHeaders:   []string{"Content-Type"},
+			AllowCredentials: true,
+		}
+	}
+
+	// act
+	handlePreflight(router, allowedResponseWriter, allowedRequest)
+	handlePreflight(router, overriddenResponseWriter, overriddenRequest)
+
+	// assert
+	assert.Equal(t, http.StatusNoContent, allowedResponseWriter.statusCode)
+	assert.Equal(t, "https://app.example.com", allowedResponseWriter.Header().Get(headerAllowOrigin))
+	assert.Equal(t, "true", allowedResponseWriter.Header().Get(headerAllowCredentials))
+	assert.Equal(t, http.MethodGet, allowedResponseWriter.Header().Get(headerAllowMethods))
+	assert.Equal(t, "Content-Type", allowedResponseWriter.Header().Get(headerAllowHeaders))
+	assert.Equal(t, http.StatusForbidden, overriddenResponseWriter.statusCode)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestPreflight_RouteGroupsSharingEndpoint(t *testing.T) {
+	// arrange
+	var router = mux.NewRouter()
+	var dummyHandlerFunc = func(http.ResponseWriter, *http.Request) {}
+	var adminResponseWriter = &dummyResponseWriter{}
+	var adminRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost/admin/users", nil)
+	var publicResponseWriter = &dummyResponseWriter{}
+	var publicRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost/public/users", nil)
+	var dummyRouteInfo = model.Route{Endpoint: "GetUsers", Method: http.MethodGet}
+
+	// stub
+	var adminRouteInfo = dummyRouteInfo
+	adminRouteInfo.CORS = &model.CORS{AllowedOrigins: []string{"https://admin.example.com"}}
+	var publicRouteInfo = dummyRouteInfo
+	publicRouteInfo.CORS = &model.CORS{AllowedOrigins: []string{"https://app.example.com"}}
+	route.HandleFunc(router.PathPrefix("/admin").Subrouter(), adminRouteInfo, "/users", nil, dummyHandlerFunc)
+	route.HandleFunc(router.PathPrefix("/public").Subrouter(), publicRouteInfo, "/users", nil, dummyHandlerFunc)
+	adminRequest.Header.Set(headerOrigin, "https://app.example.com")
+	adminRequest.Header.Set(headerRequestMethod, http.MethodGet)
+	publicRequest.Header.Set(headerOrigin, "https://app.example.com")
+	publicRequest.Header.Set(headerRequestMethod, http.MethodGet)
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealFunctions()
+
+	// act
+	handlePreflight(router, adminResponseWriter, adminRequest)
+	handlePreflight(router, publicResponseWriter, publicRequest)
+
+	// assert
+	assert.Equal(t, http.StatusForbidden, adminResponseWriter.statusCode)
+	assert.Equal(t, http.StatusNoContent, publicResponseWriter.statusCode)
+	assert.Equal(t, "https://app.example.com", publicResponseWriter.Header().Get(headerAllowOrigin))
+
+	// verify
+	verifyAll(t)
+}
@@ -8,11 +8,17 @@ import (
 
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger"
+	"github.com/zhongjie-cai/WebServiceTemplate/metrics"
 	"github.com/zhongjie-cai/WebServiceTemplate/response"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/auth"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/bodylimit"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/cors"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/panic"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/ratelimit"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/route"
 	"github.com/zhongjie-cai/WebServiceTemplate/session"
 	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/tracing"
 )
 
 // func pointers for injection / testing: handler.go
@@ -21,12 +27,27 @@ var (
 	sessionRegister               = session.Register
 	panicHandle                   = panic.Handle
 	responseWrite                 = response.Write
+	responseNegotiate             = response.Negotiate
 	loggerAPIEnter                = logger.APIEnter
 	loggerAPIExit                 = logger.APIExit
 	apperrorGetInvalidOperation   = apperror.GetInvalidOperation
 	timeutilGetTimeNowUTC         = timeutil.GetTimeNowUTC
 	timeSince                     = time.Since
+	metricsWrapResponseWriter     = metrics.WrapResponseWriter
+	metricsSessionStarted         = metrics.SessionStarted
+	metricsSessionFinished        = metrics.SessionFinished
+	tracingStartServerSpan        = tracing.StartServerSpan
+	tracingWithSpan               = tracing.WithSpan
+	tracingStartSpan              = tracing.StartSpan
+	tracingEndSpan                = tracing.EndSpan
+	tracingGetSessionSpan         = tracing.GetSessionSpan
+	corsWriteHeaders              = cors.WriteHeaders
+	ratelimitCheck                = ratelimit.Check
+	authCheck                     = auth.Check
+	bodylimitCheck                = bodylimit.Check
 	executeCustomizedFunctionFunc = executeCustomizedFunction
+	executePreActionsFunc         = executePreActions
+	executePostActionsFunc        = executePostActions
 )
 
 // func pointers for injection / testing: methodNotAllowed.go
@@ -13,15 +13,23 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/logger"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
+	"github.com/zhongjie-cai/WebServiceTemplate/metrics"
 	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/request"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/response"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/auth"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/bodylimit"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/cors"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/panic"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/ratelimit"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/route"
 	"github.com/zhongjie-cai/WebServiceTemplate/session"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/tracing"
+	tracingModel "github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
 )
 
 var (
@@ -33,6 +41,8 @@ var (
 	panicHandleCalled                     int
 	responseWriteExpected                 int
 	responseWriteCalled                   int
+	responseNegotiateExpected             int
+	responseNegotiateCalled               int
 	loggerAPIEnterExpected                int
 	loggerAPIEnterCalled                  int
 	loggerAPIExitExpected                 int
@@ -43,8 +53,36 @@ var (
 	timeutilGetTimeNowUTCCalled           int
 	timeSinceExpected                     int
 	timeSinceCalled                       int
+	metricsWrapResponseWriterExpected     int
+	metricsWrapResponseWriterCalled       int
+	metricsSessionStartedExpected         int
+	metricsSessionStartedCalled           int
+	metricsSessionFinishedExpected        int
+	metricsSessionFinishedCalled          int
+	tracingStartServerSpanExpected        int
+	tracingStartServerSpanCalled          int
+	tracingWithSpanExpected               int
+	tracingWithSpanCalled                 int
+	tracingStartSpanExpected              int
+	tracingStartSpanCalled                int
+	tracingEndSpanExpected                int
+	tracingEndSpanCalled                  int
+	tracingGetSessionSpanExpected         int
+	tracingGetSessionSpanCalled           int
+	corsWriteHeadersExpected              int
+	corsWriteHeadersCalled                int
+	ratelimitCheckExpected                int
+	ratelimitCheckCalled                  int
+	authCheckExpected                     int
+	authCheckCalled                       int
+	bodylimitCheckExpected                int
+	bodylimitCheckCalled                  int
 	executeCustomizedFunctionFuncExpected int
 	executeCustomizedFunctionFuncCalled   int
+	executePreActionsFuncExpected         int
+	executePreActionsFuncCalled           int
+	executePostActionsFuncExpected        int
+	executePostActionsFuncCalled          int
 	customizationPreActionFuncExpected    int
 	customizationPreActionFuncCalled      int
 	customizationPostActionFuncExpected   int
@@ -60,9 +98,9 @@ var (
 func createMock(t *testing.T) {
 	routeGetRouteInfoExpected = 0
 	routeGetRouteInfoCalled = 0
-	routeGetRouteInfo = func(httpRequest *http.Request) (string, model.ActionFunc, error) {
+	routeGetRouteInfo = func(httpRequest *http.Request) (model.Route, error) {
 		routeGetRouteInfoCalled++
-		return "", nil, nil
+		return model.Route{}, nil
 	}
 	sessionRegisterExpected = 0
 	sessionRegisterCalled = 0
@@ -80,6 +118,12 @@ func createMock(t *testing.T) {
 	responseWrite = func(session sessionModel.Session, responseObject interface{}, responseError error) {
 		responseWriteCalled++
 	}
+	responseNegotiateExpected = 0
+	responseNegotiateCalled = 0
+	responseNegotiate = func(session sessionModel.Session, mediaTypes []string) error {
+		responseNegotiateCalled++
+		return nil
+	}
 	loggerAPIEnterExpected = 0
 	loggerAPIEnterCalled = 0
 	loggerAPIEnter = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
@@ -108,12 +152,92 @@ func createMock(t *testing.T) {
 		timeSinceCalled++
 		return 0
 	}
+	metricsWrapResponseWriterExpected = 0
+	metricsWrapResponseWriterCalled = 0
+	metricsWrapResponseWriter = func(responseWriter http.ResponseWriter) http.ResponseWriter {
+		metricsWrapResponseWriterCalled++
+		return nil
+	}
+	metricsSessionStartedExpected = 0
+	metricsSessionStartedCalled = 0
+	metricsSessionStarted = func() {
+		metricsSessionStartedCalled++
+	}
+	metricsSessionFinishedExpected = 0
+	metricsSessionFinishedCalled = 0
+	metricsSessionFinished = func(endpoint string, method string, responseWriter http.ResponseWriter, duration time.Duration) {
+		metricsSessionFinishedCalled++
+	}
+	tracingStartServerSpanExpected = 0
+	tracingStartServerSpanCalled = 0
+	tracingStartServerSpan = func(header http.Header, name string) *tracingModel.Span {
+		tracingStartServerSpanCalled++
+		return nil
+	}
+	tracingWithSpanExpected = 0
+	tracingWithSpanCalled = 0
+	tracingWithSpan = func(httpRequest *http.Request, span *tracingModel.Span) *http.Request {
+		tracingWithSpanCalled++
+		return nil
+	}
+	tracingStartSpanExpected = 0
+	tracingStartSpanCalled = 0
+	tracingStartSpan = func(parent *tracingModel.Span, name string, kind tracingModel.SpanKind) *tracingModel.Span {
+		tracingStartSpanCalled++
+		return nil
+	}
+	tracingEndSpanExpected = 0
+	tracingEndSpanCalled = 0
+	tracingEndSpan = func(span *tracingModel.Span, err error) {
+		tracingEndSpanCalled++
+	}
+	tracingGetSessionSpanExpected = 0
+	tracingGetSessionSpanCalled = 0
+	tracingGetSessionSpan = func(session sessionModel.Session) *tracingModel.Span {
+		tracingGetSessionSpanCalled++
+		return nil
+	}
+	corsWriteHeadersExpected = 0
+	corsWriteHeadersCalled = 0
+	corsWriteHeaders = func(responseWriter http.ResponseWriter, httpRequest *http.Request, routePolicy *model.CORS) {
+		corsWriteHeadersCalled++
+	}
+	ratelimitCheckExpected = 0
+	ratelimitCheckCalled = 0
+	ratelimitCheck = func(session sessionModel.Session, route model.Route) error {
+		ratelimitCheckCalled++
+		return nil
+	}
+	authCheckExpected = 0
+	authCheckCalled = 0
+	authCheck = func(session sessionModel.Session, route model.Route) error {
+		authCheckCalled++
+		return nil
+	}
+	bodylimitCheckExpected = 0
+	bodylimitCheckCalled = 0
+	bodylimitCheck = func(session sessionModel.Session, route model.Route) error {
+		bodylimitCheckCalled++
+		return nil
+	}
 	executeCustomizedFunctionFuncExpected = 0
 	executeCustomizedFunctionFuncCalled = 0
-	executeCustomizedFunctionFunc = func(session sessionModel.Session, customFunc func(sessionModel.Session) error) error {
+	executeCustomizedFunctionFunc = func(session sessionModel.Session, spanName string, customFunc func(sessionModel.Session) error) error {
 		executeCustomizedFunctionFuncCalled++
 		return nil
 	}
+	executePreActionsFuncExpected = 0
+	executePreActionsFuncCalled = 0
+	executePreActionsFunc = func(session sessionModel.Session, preActionFuncs []model.HookFunc) error {
+		executePreActionsFuncCalled++
+		return nil
+	}
+	executePostActionsFuncExpected = 0
+	executePostActionsFuncCalled = 0
+	executePostActionsFunc = func(session sessionModel.Session, postActionFuncs []model.HookFunc) error {
+		executePostActionsFuncCalled++
+		return nil
+	}
 	customizationPreActionFuncExpected = 0
 	customizationPreActionFuncCalled = 0
 	customization.PreActionFunc = func(session sessionModel.Session) error {
@@ -153,6 +277,8 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, panicHandleExpected, panicHandleCalled, "Unexpected number of calls to panicHandle")
 	responseWrite = response.Write
 	assert.Equal(t, responseWriteExpected, responseWriteCalled, "Unexpected number of calls to responseWrite")
+	responseNegotiate = response.Negotiate
+	assert.Equal(t, responseNegotiateExpected, responseNegotiateCalled, "Unexpected number of calls to responseNegotiate")
 	loggerAPIEnter = logger.APIEnter
 	assert.Equal(t, loggerAPIEnterExpected, loggerAPIEnterCalled, "Unexpected number of calls to loggerAPIEnter")
 	loggerAPIExit = logger.APIExit
@@ -163,8 +289,36 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, timeutilGetTimeNowUTCExpected, timeutilGetTimeNowUTCCalled, "Unexpected number of calls to timeutilGetTimeNowUTC")
 	timeSince = time.Since
 	assert.Equal(t, timeSinceExpected, timeSinceCalled, "Unexpected number of calls to timeSince")
+	metricsWrapResponseWriter = metrics.WrapResponseWriter
+	assert.Equal(t, metricsWrapResponseWriterExpected, metricsWrapResponseWriterCalled, "Unexpected number of calls to metricsWrapResponseWriter")
+	metricsSessionStarted = metrics.SessionStarted
+	assert.Equal(t, metricsSessionStartedExpected, metricsSessionStartedCalled, "Unexpected number of calls to metricsSessionStarted")
+	metricsSessionFinished = metrics.SessionFinished
+	assert.Equal(t, metricsSessionFinishedExpected, metricsSessionFinishedCalled, "Unexpected number of calls to metricsSessionFinished")
+	tracingStartServerSpan = tracing.StartServerSpan
+	assert.Equal(t, tracingStartServerSpanExpected, tracingStartServerSpanCalled, "Unexpected number of calls to tracingStartServerSpan")
+	tracingWithSpan = tracing.WithSpan
+	assert.Equal(t, tracingWithSpanExpected, tracingWithSpanCalled, "Unexpected number of calls to tracingWithSpan")
+	tracingStartSpan = tracing.StartSpan
+	assert.Equal(t, tracingStartSpanExpected, tracingStartSpanCalled, "Unexpected number of calls to tracingStartSpan")
+	tracingEndSpan = tracing.EndSpan
+	assert.Equal(t, tracingEndSpanExpected, tracingEndSpanCalled, "Unexpected number of calls to tracingEndSpan")
+	tracingGetSessionSpan = tracing.GetSessionSpan
+	assert.Equal(t, tracingGetSessionSpanExpected, tracingGetSessionSpanCalled, "Unexpected number of calls to tracingGetSessionSpan")
+	corsWriteHeaders = cors.WriteHeaders
+	assert.Equal(t, corsWriteHeadersExpected, corsWriteHeadersCalled, "Unexpected number of calls to corsWriteHeaders")
+	ratelimitCheck = ratelimit.Check
+	assert.Equal(t, ratelimitCheckExpected, ratelimitCheckCalled, "Unexpected number of calls to ratelimitCheck")
+	authCheck = auth.Check
+	assert.Equal(t, authCheckExpected, authCheckCalled, "Unexpected number of calls to authCheck")
+	bodylimitCheck = bodylimit.Check
+	assert.Equal(t, bodylimitCheckExpected, bodylimitCheckCalled, "Unexpected number of calls to bodylimitCheck")
 	executeCustomizedFunctionFunc = executeCustomizedFunction
 	assert.Equal(t, executeCustomizedFunctionFuncExpected, executeCustomizedFunctionFuncCalled, "Unexpected number of calls to executeCustomizedFunctionFunc")
+	executePreActionsFunc = executePreActions
+	assert.Equal(t, executePreActionsFuncExpected, executePreActionsFuncCalled, "Unexpected number of calls to executePreActionsFunc")
+	executePostActionsFunc = executePostActions
+	assert.Equal(t, executePostActionsFuncExpected, executePostActionsFuncCalled, "Unexpected number of calls to executePostActionsFunc")
 	customization.PreActionFunc = nil
 	assert.Equal(t, customizationPreActionFuncExpected, customizationPreActionFuncCalled, "Unexpected number of calls to customization.PreActionFunc")
 	customization.PostActionFunc = nil
@@ -210,6 +364,11 @@ func (session *dummySession) GetName() string {
 	return ""
 }
 
+func (session *dummySession) GetCorrelationID() string {
+	assert.Fail(session.t, "Unexpected call to GetCorrelationID")
+	return ""
+}
+
 func (session *dummySession) GetRequest() *http.Request {
 	assert.Fail(session.t, "Unexpected call to GetRequest")
 	return nil
@@ -220,6 +379,14 @@ func (session *dummySession) GetResponseWriter() http.ResponseWriter {
 	return nil
 }
 
+func (session *dummySession) SetResponseETag(etag string, weak bool) {
+	assert.Fail(session.t, "Unexpected call to SetResponseETag")
+}
+
+func (session *dummySession) SetResponseLastModified(lastModified time.Time) {
+	assert.Fail(session.t, "Unexpected call to SetResponseLastModified")
+}
+
 func (session *dummySession) GetRequestBody(dataTemplate interface{}) apperrorModel.AppError {
 	assert.Fail(session.t, "Unexpected call to GetRequestBody")
 	return nil
@@ -250,6 +417,26 @@ func (session *dummySession) GetRequestHeaders(name string, dataTemplate interfa
 	return nil
 }
 
+func (session *dummySession) GetRequestFormValue(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValue")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValues(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValues")
+	return nil
+}
+
+func (session *dummySession) GetRequestFile(name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFile")
+	return nil
+}
+
+func (session *dummySession) BindRequest(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to BindRequest")
+	return nil
+}
+
 func (session *dummySession) Attach(name string, value interface{}) bool {
 	assert.Fail(session.t, "Unexpected call to Attach")
 	return false
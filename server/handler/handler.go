@@ -3,20 +3,39 @@ package handler
 import (
 	"net/http"
 
-	"github.com/zhongjie-cai/WebServiceTemplate/customization"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+	tracingModel "github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
+)
+
+// These are the span names of the customized functions executed around each route action
+const (
+	preActionSpanName  = "PreAction"
+	postActionSpanName = "PostAction"
 )
 
 func executeCustomizedFunction(
 	session sessionModel.Session,
+	spanName string,
 	customFunc func(sessionModel.Session) error,
 ) error {
 	if customFunc == nil {
 		return nil
 	}
-	return customFunc(
+	var span = tracingStartSpan(
+		tracingGetSessionSpan(
+			session,
+		),
+		spanName,
+		tracingModel.SpanKindInternal,
+	)
+	var customError = customFunc(
 		session,
 	)
+	tracingEndSpan(
+		span,
+		customError,
+	)
+	return customError
 }
 
 // Session wraps the HTTP handler with session related operations
@@ -24,15 +43,28 @@ func Session(
 	responseWriter http.ResponseWriter,
 	httpRequest *http.Request,
 ) {
-	var endpoint, action, routeError = routeGetRouteInfo(
+	var routeInfo, routeError = routeGetRouteInfo(
 		httpRequest,
 	)
-	var session = sessionRegister(
+	var endpoint = routeInfo.Endpoint
+	var span = tracingStartServerSpan(
+		httpRequest.Header,
 		endpoint,
+	)
+	httpRequest = tracingWithSpan(
 		httpRequest,
+		span,
+	)
+	var metricsResponseWriter = metricsWrapResponseWriter(
 		responseWriter,
 	)
+	var session = sessionRegister(
+		endpoint,
+		httpRequest,
+		metricsResponseWriter,
+	)
 	var startTime = timeutilGetTimeNowUTC()
+	metricsSessionStarted()
 	loggerAPIEnter(
 		session,
 		endpoint,
@@ -44,12 +76,23 @@ func Session(
 			session,
 			recover(),
 		)
+		var duration = timeSince(startTime)
+		metricsSessionFinished(
+			endpoint,
+			httpRequest.Method,
+			metricsResponseWriter,
+			duration,
+		)
+		tracingEndSpan(
+			span,
+			nil,
+		)
 		loggerAPIExit(
 			session,
 			endpoint,
 			httpRequest.Method,
 			"%s",
-			timeSince(startTime),
+			duration,
 		)
 	}()
 	if routeError != nil {
@@ -61,51 +104,86 @@ func Session(
 			),
 		)
 	} else {
-		var preActionError = executeCustomizedFunctionFunc(
+		corsWriteHeaders(
+			metricsResponseWriter,
+			httpRequest,
+			routeInfo.CORS,
+		)
+		var admissionError = ratelimitCheck(
 			session,
-			customization.PreActionFunc,
+			routeInfo,
 		)
-		if preActionError != nil {
+		if admissionError == nil {
+			admissionError = authCheck(
+				session,
+				routeInfo,
+			)
+		}
+		if admissionError == nil {
+			admissionError = bodylimitCheck(
+				session,
+				routeInfo,
+			)
+		}
+		if admissionError == nil {
+			admissionError = responseNegotiate(
+				session,
+				routeInfo.MediaTypes,
+			)
+		}
+		if admissionError != nil {
 			responseWrite(
 				session,
 				nil,
-				preActionError,
+				admissionError,
 			)
 		} else {
-			var responseObject, responseError = action(
+			var preActionError = executePreActionsFunc(
 				session,
+				routeInfo.PreActionFuncs,
 			)
-			var postActionError = executeCustomizedFunctionFunc(
-				session,
-				customization.PostActionFunc,
-			)
-			if postActionError != nil {
-				if responseError != nil {
-					loggerAPIExit(
-						session,
-						endpoint,
-						httpRequest.Method,
-						"Post-action error: %v",
-						postActionError,
-					)
-					responseWrite(
-						session,
-						nil,
-						responseError,
-					)
+			if preActionError != nil {
+				responseWrite(
+					session,
+					nil,
+					preActionError,
+				)
+			} else {
+				var responseObject, responseError = routeInfo.ActionFunc(
+					session,
+				)
+				var postActionError = executePostActionsFunc(
+					session,
+					routeInfo.PostActionFuncs,
+				)
+				if postActionError != nil {
+					if responseError != nil {
+						loggerAPIExit(
+							session,
+							endpoint,
+							httpRequest.Method,
+							"Post-action error: %v",
+							postActionError,
+						)
+						responseWrite(
+							session,
+							nil,
+							responseError,
+						)
+					} else {
+						responseWrite(
+							session,
+							nil,
+							postActionError,
+						)
+					}
 				} else {
 					responseWrite(
 						session,
-						nil,
-						postActionError,
+						responseObject,
+						responseError,
 					)
 				}
-			} else {
-				responseWrite(
-					session,
-					responseObject,
-					responseError,
-				)
 			}
 		}
 	}
@@ -5,21 +5,21 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
-	"reflect"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
-	"github.com/zhongjie-cai/WebServiceTemplate/customization"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+	tracingModel "github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
 )
 
 func TestExecuteCustomizedFunction_NoCustomization(t *testing.T) {
 	// arrange
 	var dummySessionObject = &dummySession{t}
+	var dummySpanName = "some span name"
 
 	// mock
 	createMock(t)
@@ -32,6 +32,7 @@ func TestExecuteCustomizedFunction_NoCustomization(t *testing.T) {
 	// SUT + act
 	var err = executeCustomizedFunction(
 		dummySessionObject,
+		dummySpanName,
 		dummyCustomFunc,
 	)
 
@@ -46,52 +47,735 @@ func TestExecuteCustomizedFunction_NoCustomization(t *testing.T) {
 func TestExecuteCustomizedFunction_WithCustomization(t *testing.T) {
 	// arrange
 	var dummySessionObject = &dummySession{t}
+	var dummySpanName = "some span name"
+	var dummySessionSpan = &tracingModel.Span{Name: "some session span"}
+	var dummySpan = &tracingModel.Span{Name: "some span"}
 	var dummyError = errors.New("some error")
 
 	// mock
 	createMock(t)
 
 	// expect
+	tracingGetSessionSpanExpected = 1
+	tracingGetSessionSpan = func(session sessionModel.Session) *tracingModel.Span {
+		tracingGetSessionSpanCalled++
+		assert.Equal(t, dummySessionObject, session)
+		return dummySessionSpan
+	}
+	tracingStartSpanExpected = 1
+	tracingStartSpan = func(parent *tracingModel.Span, name string, kind tracingModel.SpanKind) *tracingModel.Span {
+		tracingStartSpanCalled++
+		assert.Equal(t, dummySessionSpan, parent)
+		assert.Equal(t, dummySpanName, name)
+		assert.Equal(t, tracingModel.SpanKindInternal, kind)
+		return dummySpan
+	}
 	var dummyCustomFuncExpected = 1
 	var dummyCustomFuncCalled = 0
 	var dummyCustomFunc = func(session sessionModel.Session) error {
 		dummyCustomFuncCalled++
 		assert.Equal(t, dummySessionObject, session)
-		return dummyError
+		return dummyError
+	}
+	tracingEndSpanExpected = 1
+	tracingEndSpan = func(span *tracingModel.Span, err error) {
+		tracingEndSpanCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.Equal(t, dummyError, err)
+	}
+
+	// SUT + act
+	var err = executeCustomizedFunction(
+		dummySessionObject,
+		dummySpanName,
+		dummyCustomFunc,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyCustomFuncExpected, dummyCustomFuncCalled, "Unexpected number of calls to dummyCustomFunc")
+}
+
+func TestHandleInSession_RouteError(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method:     http.MethodGet,
+		RequestURI: "http://localhost/",
+		Header:     map[string][]string{},
+	}
+	var dummyMetricsResponseWriter = &dummyResponseWriter{t}
+	var dummyResponseWriter = &dummyResponseWriter{t}
+	var dummyEndpoint = "some endpoint"
+	var dummySessionObject = &dummySession{t}
+	var dummyActionExpected = 0
+	var dummyActionCalled = 0
+	var dummyAction = func(session sessionModel.Session) (interface{}, error) {
+		dummyActionCalled++
+		return nil, nil
+	}
+	var dummyRouteError = errors.New("some route error")
+	var dummyResponseError = apperror.GetCustomError(0, "some app error")
+	var dummySpan = &tracingModel.Span{Name: "some span"}
+	var dummyStartTime = time.Now()
+	var dummyTimeSince = time.Duration(rand.Intn(1000))
+
+	// mock
+	createMock(t)
+
+	// expect
+	routeGetRouteInfoExpected = 1
+	routeGetRouteInfo = func(httpRequest *http.Request) (model.Route, error) {
+		routeGetRouteInfoCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return model.Route{
+			Endpoint:   dummyEndpoint,
+			ActionFunc: dummyAction,
+		}, dummyRouteError
+	}
+	tracingStartServerSpanExpected = 1
+	tracingStartServerSpan = func(header http.Header, name string) *tracingModel.Span {
+		tracingStartServerSpanCalled++
+		assert.Equal(t, dummyHTTPRequest.Header, header)
+		assert.Equal(t, dummyEndpoint, name)
+		return dummySpan
+	}
+	tracingWithSpanExpected = 1
+	tracingWithSpan = func(httpRequest *http.Request, span *tracingModel.Span) *http.Request {
+		tracingWithSpanCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummySpan, span)
+		return httpRequest
+	}
+	metricsWrapResponseWriterExpected = 1
+	metricsWrapResponseWriter = func(responseWriter http.ResponseWriter) http.ResponseWriter {
+		metricsWrapResponseWriterCalled++
+		assert.Equal(t, dummyResponseWriter, responseWriter)
+		return dummyMetricsResponseWriter
+	}
+	sessionRegisterExpected = 1
+	sessionRegister = func(name string, httpRequest *http.Request, responseWriter http.ResponseWriter) sessionModel.Session {
+		sessionRegisterCalled++
+		assert.Equal(t, dummyEndpoint, name)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		return dummySessionObject
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyStartTime
+	}
+	metricsSessionStartedExpected = 1
+	metricsSessionStarted = func() {
+		metricsSessionStartedCalled++
+	}
+	loggerAPIEnterExpected = 1
+	loggerAPIEnter = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAPIEnterCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyHTTPRequest.Method, subcategory)
+		assert.Equal(t, dummyEndpoint, category)
+		assert.Zero(t, messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+	apperrorGetInvalidOperationExpected = 1
+	apperrorGetInvalidOperation = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetInvalidOperationCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyRouteError, innerErrors[0])
+		return dummyResponseError
+	}
+	responseWriteExpected = 1
+	responseWrite = func(session sessionModel.Session, responseObject interface{}, responseError error) {
+		responseWriteCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Nil(t, responseObject)
+		assert.Equal(t, dummyResponseError, responseError)
+	}
+	timeSinceExpected = 1
+	timeSince = func(ts time.Time) time.Duration {
+		timeSinceCalled++
+		assert.Equal(t, dummyStartTime, ts)
+		return dummyTimeSince
+	}
+	metricsSessionFinishedExpected = 1
+	metricsSessionFinished = func(endpoint string, method string, responseWriter http.ResponseWriter, duration time.Duration) {
+		metricsSessionFinishedCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyHTTPRequest.Method, method)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyTimeSince, duration)
+	}
+	tracingEndSpanExpected = 1
+	tracingEndSpan = func(span *tracingModel.Span, err error) {
+		tracingEndSpanCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.NoError(t, err)
+	}
+	loggerAPIExitExpected = 1
+	loggerAPIExit = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAPIExitCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyHTTPRequest.Method, subcategory)
+		assert.Equal(t, dummyEndpoint, category)
+		assert.Equal(t, "%s", messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, dummyTimeSince, parameters[0])
+	}
+	panicHandleExpected = 1
+	panicHandle = func(session sessionModel.Session, recoverResult interface{}) {
+		panicHandleCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, recover(), recoverResult)
+	}
+
+	// SUT + act
+	Session(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyActionExpected, dummyActionCalled, "Unexpected number of calls to dummyAction")
+}
+
+func TestHandleInSession_RateLimited(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method:     http.MethodGet,
+		RequestURI: "http://localhost/",
+		Header:     map[string][]string{},
+	}
+	var dummyMetricsResponseWriter = &dummyResponseWriter{t}
+	var dummyResponseWriter = &dummyResponseWriter{t}
+	var dummyEndpoint = "some endpoint"
+	var dummyRateLimit = &model.RateLimit{Rate: 1}
+	var dummyAuth = &model.AuthRequirement{Scopes: []string{"some scope"}}
+	var dummyMediaTypes = []string{"some media type"}
+	var dummyCORS = &model.CORS{AllowedOrigins: []string{"some origin"}}
+	var dummyMaxBodyBytes = rand.Int63()
+	var dummyPreActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummyPostActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummySessionObject = &dummySession{t}
+	var dummyAction func(sessionModel.Session) (interface{}, error)
+	var dummyActionExpected int
+	var dummyActionCalled int
+	var dummyRateLimitError = errors.New("some rate limit error")
+	var dummySpan = &tracingModel.Span{Name: "some span"}
+	var dummyStartTime = time.Now()
+	var dummyTimeSince = time.Duration(rand.Intn(1000))
+
+	// mock
+	createMock(t)
+
+	// expect
+	routeGetRouteInfoExpected = 1
+	routeGetRouteInfo = func(httpRequest *http.Request) (model.Route, error) {
+		routeGetRouteInfoCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return model.Route{
+			Endpoint:        dummyEndpoint,
+			ActionFunc:      dummyAction,
+			PreActionFuncs:  dummyPreActionFuncs,
+			RateLimit:       dummyRateLimit,
+			Auth:            dummyAuth,
+			MediaTypes:      dummyMediaTypes,
+			CORS:            dummyCORS,
+			MaxBodyBytes:    dummyMaxBodyBytes,
+			PostActionFuncs: dummyPostActionFuncs,
+		}, nil
+	}
+	tracingStartServerSpanExpected = 1
+	tracingStartServerSpan = func(header http.Header, name string) *tracingModel.Span {
+		tracingStartServerSpanCalled++
+		assert.Equal(t, dummyHTTPRequest.Header, header)
+		assert.Equal(t, dummyEndpoint, name)
+		return dummySpan
+	}
+	tracingWithSpanExpected = 1
+	tracingWithSpan = func(httpRequest *http.Request, span *tracingModel.Span) *http.Request {
+		tracingWithSpanCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummySpan, span)
+		return httpRequest
+	}
+	metricsWrapResponseWriterExpected = 1
+	metricsWrapResponseWriter = func(responseWriter http.ResponseWriter) http.ResponseWriter {
+		metricsWrapResponseWriterCalled++
+		assert.Equal(t, dummyResponseWriter, responseWriter)
+		return dummyMetricsResponseWriter
+	}
+	sessionRegisterExpected = 1
+	sessionRegister = func(endpoint string, httpRequest *http.Request, responseWriter http.ResponseWriter) sessionModel.Session {
+		sessionRegisterCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		return dummySessionObject
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyStartTime
+	}
+	metricsSessionStartedExpected = 1
+	metricsSessionStarted = func() {
+		metricsSessionStartedCalled++
+	}
+	loggerAPIEnterExpected = 1
+	loggerAPIEnter = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAPIEnterCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyHTTPRequest.Method, subcategory)
+		assert.Equal(t, dummyEndpoint, category)
+		assert.Zero(t, messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+	corsWriteHeadersExpected = 1
+	corsWriteHeaders = func(responseWriter http.ResponseWriter, httpRequest *http.Request, routePolicy *model.CORS) {
+		corsWriteHeadersCalled++
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyCORS, routePolicy)
+	}
+	ratelimitCheckExpected = 1
+	ratelimitCheck = func(session sessionModel.Session, route model.Route) error {
+		ratelimitCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyRateLimit, route.RateLimit)
+		return dummyRateLimitError
+	}
+	responseWriteExpected = 1
+	responseWrite = func(session sessionModel.Session, responseObject interface{}, responseError error) {
+		responseWriteCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Nil(t, responseObject)
+		assert.Equal(t, dummyRateLimitError, responseError)
+	}
+	timeSinceExpected = 1
+	timeSince = func(ts time.Time) time.Duration {
+		timeSinceCalled++
+		assert.Equal(t, dummyStartTime, ts)
+		return dummyTimeSince
+	}
+	metricsSessionFinishedExpected = 1
+	metricsSessionFinished = func(endpoint string, method string, responseWriter http.ResponseWriter, duration time.Duration) {
+		metricsSessionFinishedCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyHTTPRequest.Method, method)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyTimeSince, duration)
+	}
+	tracingEndSpanExpected = 1
+	tracingEndSpan = func(span *tracingModel.Span, err error) {
+		tracingEndSpanCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.NoError(t, err)
+	}
+	loggerAPIExitExpected = 1
+	loggerAPIExit = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAPIExitCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyHTTPRequest.Method, subcategory)
+		assert.Equal(t, dummyEndpoint, category)
+		assert.Equal(t, "%s", messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, dummyTimeSince, parameters[0])
+	}
+	panicHandleExpected = 1
+	panicHandle = func(session sessionModel.Session, recoverResult interface{}) {
+		panicHandleCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, recover(), recoverResult)
+	}
+
+	// SUT + act
+	Session(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyActionExpected, dummyActionCalled, "Unexpected number of calls to dummyAction")
+}
+
+func TestHandleInSession_Unauthorized(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method:     http.MethodGet,
+		RequestURI: "http://localhost/",
+		Header:     map[string][]string{},
+	}
+	var dummyMetricsResponseWriter = &dummyResponseWriter{t}
+	var dummyResponseWriter = &dummyResponseWriter{t}
+	var dummyEndpoint = "some endpoint"
+	var dummyRateLimit = &model.RateLimit{Rate: 1}
+	var dummyAuth = &model.AuthRequirement{Scopes: []string{"some scope"}}
+	var dummyMediaTypes = []string{"some media type"}
+	var dummyCORS = &model.CORS{AllowedOrigins: []string{"some origin"}}
+	var dummyMaxBodyBytes = rand.Int63()
+	var dummyPreActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummyPostActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummySessionObject = &dummySession{t}
+	var dummyAction func(sessionModel.Session) (interface{}, error)
+	var dummyActionExpected int
+	var dummyActionCalled int
+	var dummyAuthError = errors.New("some auth error")
+	var dummySpan = &tracingModel.Span{Name: "some span"}
+	var dummyStartTime = time.Now()
+	var dummyTimeSince = time.Duration(rand.Intn(1000))
+
+	// mock
+	createMock(t)
+
+	// expect
+	routeGetRouteInfoExpected = 1
+	routeGetRouteInfo = func(httpRequest *http.Request) (model.Route, error) {
+		routeGetRouteInfoCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return model.Route{
+			Endpoint:        dummyEndpoint,
+			ActionFunc:      dummyAction,
+			PreActionFuncs:  dummyPreActionFuncs,
+			RateLimit:       dummyRateLimit,
+			Auth:            dummyAuth,
+			MediaTypes:      dummyMediaTypes,
+			CORS:            dummyCORS,
+			MaxBodyBytes:    dummyMaxBodyBytes,
+			PostActionFuncs: dummyPostActionFuncs,
+		}, nil
+	}
+	tracingStartServerSpanExpected = 1
+	tracingStartServerSpan = func(header http.Header, name string) *tracingModel.Span {
+		tracingStartServerSpanCalled++
+		assert.Equal(t, dummyHTTPRequest.Header, header)
+		assert.Equal(t, dummyEndpoint, name)
+		return dummySpan
+	}
+	tracingWithSpanExpected = 1
+	tracingWithSpan = func(httpRequest *http.Request, span *tracingModel.Span) *http.Request {
+		tracingWithSpanCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummySpan, span)
+		return httpRequest
+	}
+	metricsWrapResponseWriterExpected = 1
+	metricsWrapResponseWriter = func(responseWriter http.ResponseWriter) http.ResponseWriter {
+		metricsWrapResponseWriterCalled++
+		assert.Equal(t, dummyResponseWriter, responseWriter)
+		return dummyMetricsResponseWriter
+	}
+	sessionRegisterExpected = 1
+	sessionRegister = func(endpoint string, httpRequest *http.Request, responseWriter http.ResponseWriter) sessionModel.Session {
+		sessionRegisterCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		return dummySessionObject
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyStartTime
+	}
+	metricsSessionStartedExpected = 1
+	metricsSessionStarted = func() {
+		metricsSessionStartedCalled++
+	}
+	loggerAPIEnterExpected = 1
+	loggerAPIEnter = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAPIEnterCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyHTTPRequest.Method, subcategory)
+		assert.Equal(t, dummyEndpoint, category)
+		assert.Zero(t, messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+	corsWriteHeadersExpected = 1
+	corsWriteHeaders = func(responseWriter http.ResponseWriter, httpRequest *http.Request, routePolicy *model.CORS) {
+		corsWriteHeadersCalled++
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyCORS, routePolicy)
+	}
+	ratelimitCheckExpected = 1
+	ratelimitCheck = func(session sessionModel.Session, route model.Route) error {
+		ratelimitCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyRateLimit, route.RateLimit)
+		return nil
+	}
+	authCheckExpected = 1
+	authCheck = func(session sessionModel.Session, route model.Route) error {
+		authCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyAuth, route.Auth)
+		return dummyAuthError
+	}
+	responseWriteExpected = 1
+	responseWrite = func(session sessionModel.Session, responseObject interface{}, responseError error) {
+		responseWriteCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Nil(t, responseObject)
+		assert.Equal(t, dummyAuthError, responseError)
+	}
+	timeSinceExpected = 1
+	timeSince = func(ts time.Time) time.Duration {
+		timeSinceCalled++
+		assert.Equal(t, dummyStartTime, ts)
+		return dummyTimeSince
+	}
+	metricsSessionFinishedExpected = 1
+	metricsSessionFinished = func(endpoint string, method string, responseWriter http.ResponseWriter, duration time.Duration) {
+		metricsSessionFinishedCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyHTTPRequest.Method, method)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyTimeSince, duration)
+	}
+	tracingEndSpanExpected = 1
+	tracingEndSpan = func(span *tracingModel.Span, err error) {
+		tracingEndSpanCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.NoError(t, err)
+	}
+	loggerAPIExitExpected = 1
+	loggerAPIExit = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAPIExitCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyHTTPRequest.Method, subcategory)
+		assert.Equal(t, dummyEndpoint, category)
+		assert.Equal(t, "%s", messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, dummyTimeSince, parameters[0])
+	}
+	panicHandleExpected = 1
+	panicHandle = func(session sessionModel.Session, recoverResult interface{}) {
+		panicHandleCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, recover(), recoverResult)
+	}
+
+	// SUT + act
+	Session(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyActionExpected, dummyActionCalled, "Unexpected number of calls to dummyAction")
+}
+
+func TestHandleInSession_BodyTooLarge(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method:     http.MethodGet,
+		RequestURI: "http://localhost/",
+		Header:     map[string][]string{},
+	}
+	var dummyMetricsResponseWriter = &dummyResponseWriter{t}
+	var dummyResponseWriter = &dummyResponseWriter{t}
+	var dummyEndpoint = "some endpoint"
+	var dummyRateLimit = &model.RateLimit{Rate: 1}
+	var dummyAuth = &model.AuthRequirement{Scopes: []string{"some scope"}}
+	var dummyMediaTypes = []string{"some media type"}
+	var dummyCORS = &model.CORS{AllowedOrigins: []string{"some origin"}}
+	var dummyMaxBodyBytes = rand.Int63()
+	var dummyPreActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummyPostActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummySessionObject = &dummySession{t}
+	var dummyAction func(sessionModel.Session) (interface{}, error)
+	var dummyActionExpected int
+	var dummyActionCalled int
+	var dummyBodyLimitError = errors.New("some body limit error")
+	var dummySpan = &tracingModel.Span{Name: "some span"}
+	var dummyStartTime = time.Now()
+	var dummyTimeSince = time.Duration(rand.Intn(1000))
+
+	// mock
+	createMock(t)
+
+	// expect
+	routeGetRouteInfoExpected = 1
+	routeGetRouteInfo = func(httpRequest *http.Request) (model.Route, error) {
+		routeGetRouteInfoCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return model.Route{
+			Endpoint:        dummyEndpoint,
+			ActionFunc:      dummyAction,
+			PreActionFuncs:  dummyPreActionFuncs,
+			RateLimit:       dummyRateLimit,
+			Auth:            dummyAuth,
+			MediaTypes:      dummyMediaTypes,
+			CORS:            dummyCORS,
+			MaxBodyBytes:    dummyMaxBodyBytes,
+			PostActionFuncs: dummyPostActionFuncs,
+		}, nil
+	}
+	tracingStartServerSpanExpected = 1
+	tracingStartServerSpan = func(header http.Header, name string) *tracingModel.Span {
+		tracingStartServerSpanCalled++
+		assert.Equal(t, dummyHTTPRequest.Header, header)
+		assert.Equal(t, dummyEndpoint, name)
+		return dummySpan
+	}
+	tracingWithSpanExpected = 1
+	tracingWithSpan = func(httpRequest *http.Request, span *tracingModel.Span) *http.Request {
+		tracingWithSpanCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummySpan, span)
+		return httpRequest
+	}
+	metricsWrapResponseWriterExpected = 1
+	metricsWrapResponseWriter = func(responseWriter http.ResponseWriter) http.ResponseWriter {
+		metricsWrapResponseWriterCalled++
+		assert.Equal(t, dummyResponseWriter, responseWriter)
+		return dummyMetricsResponseWriter
+	}
+	sessionRegisterExpected = 1
+	sessionRegister = func(endpoint string, httpRequest *http.Request, responseWriter http.ResponseWriter) sessionModel.Session {
+		sessionRegisterCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		return dummySessionObject
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyStartTime
+	}
+	metricsSessionStartedExpected = 1
+	metricsSessionStarted = func() {
+		metricsSessionStartedCalled++
+	}
+	loggerAPIEnterExpected = 1
+	loggerAPIEnter = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAPIEnterCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyHTTPRequest.Method, subcategory)
+		assert.Equal(t, dummyEndpoint, category)
+		assert.Zero(t, messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+	corsWriteHeadersExpected = 1
+	corsWriteHeaders = func(responseWriter http.ResponseWriter, httpRequest *http.Request, routePolicy *model.CORS) {
+		corsWriteHeadersCalled++
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyCORS, routePolicy)
+	}
+	ratelimitCheckExpected = 1
+	ratelimitCheck = func(session sessionModel.Session, route model.Route) error {
+		ratelimitCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyRateLimit, route.RateLimit)
+		return nil
+	}
+	authCheckExpected = 1
+	authCheck = func(session sessionModel.Session, route model.Route) error {
+		authCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyAuth, route.Auth)
+		return nil
+	}
+	bodylimitCheckExpected = 1
+	bodylimitCheck = func(session sessionModel.Session, route model.Route) error {
+		bodylimitCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyMaxBodyBytes, route.MaxBodyBytes)
+		return dummyBodyLimitError
+	}
+	responseWriteExpected = 1
+	responseWrite = func(session sessionModel.Session, responseObject interface{}, responseError error) {
+		responseWriteCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Nil(t, responseObject)
+		assert.Equal(t, dummyBodyLimitError, responseError)
+	}
+	timeSinceExpected = 1
+	timeSince = func(ts time.Time) time.Duration {
+		timeSinceCalled++
+		assert.Equal(t, dummyStartTime, ts)
+		return dummyTimeSince
+	}
+	metricsSessionFinishedExpected = 1
+	metricsSessionFinished = func(endpoint string, method string, responseWriter http.ResponseWriter, duration time.Duration) {
+		metricsSessionFinishedCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyHTTPRequest.Method, method)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyTimeSince, duration)
+	}
+	tracingEndSpanExpected = 1
+	tracingEndSpan = func(span *tracingModel.Span, err error) {
+		tracingEndSpanCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.NoError(t, err)
+	}
+	loggerAPIExitExpected = 1
+	loggerAPIExit = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAPIExitCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyHTTPRequest.Method, subcategory)
+		assert.Equal(t, dummyEndpoint, category)
+		assert.Equal(t, "%s", messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, dummyTimeSince, parameters[0])
+	}
+	panicHandleExpected = 1
+	panicHandle = func(session sessionModel.Session, recoverResult interface{}) {
+		panicHandleCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, recover(), recoverResult)
 	}
 
 	// SUT + act
-	var err = executeCustomizedFunction(
-		dummySessionObject,
-		dummyCustomFunc,
+	Session(
+		dummyResponseWriter,
+		dummyHTTPRequest,
 	)
 
-	// assert
-	assert.Equal(t, dummyError, err)
-
 	// verify
 	verifyAll(t)
-	assert.Equal(t, dummyCustomFuncExpected, dummyCustomFuncCalled, "Unexpected number of calls to dummyCustomFunc")
+	assert.Equal(t, dummyActionExpected, dummyActionCalled, "Unexpected number of calls to dummyAction")
 }
 
-func TestHandleInSession_RouteError(t *testing.T) {
+func TestHandleInSession_NotAcceptable(t *testing.T) {
 	// arrange
 	var dummyHTTPRequest = &http.Request{
 		Method:     http.MethodGet,
 		RequestURI: "http://localhost/",
 		Header:     map[string][]string{},
 	}
+	var dummyMetricsResponseWriter = &dummyResponseWriter{t}
 	var dummyResponseWriter = &dummyResponseWriter{t}
 	var dummyEndpoint = "some endpoint"
+	var dummyRateLimit = &model.RateLimit{Rate: 1}
+	var dummyAuth = &model.AuthRequirement{Scopes: []string{"some scope"}}
+	var dummyMediaTypes = []string{"some media type"}
+	var dummyCORS = &model.CORS{AllowedOrigins: []string{"some origin"}}
+	var dummyMaxBodyBytes = rand.Int63()
+	var dummyPreActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummyPostActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
 	var dummySessionObject = &dummySession{t}
-	var dummyActionExpected = 0
-	var dummyActionCalled = 0
-	var dummyAction = func(session sessionModel.Session) (interface{}, error) {
-		dummyActionCalled++
-		return nil, nil
-	}
-	var dummyRouteError = errors.New("some route error")
-	var dummyResponseError = apperror.GetCustomError(0, "some app error")
+	var dummyAction func(sessionModel.Session) (interface{}, error)
+	var dummyActionExpected int
+	var dummyActionCalled int
+	var dummyNegotiateError = errors.New("some negotiate error")
+	var dummySpan = &tracingModel.Span{Name: "some span"}
 	var dummyStartTime = time.Now()
 	var dummyTimeSince = time.Duration(rand.Intn(1000))
 
@@ -100,17 +784,47 @@ func TestHandleInSession_RouteError(t *testing.T) {
 
 	// expect
 	routeGetRouteInfoExpected = 1
-	routeGetRouteInfo = func(httpRequest *http.Request) (string, model.ActionFunc, error) {
+	routeGetRouteInfo = func(httpRequest *http.Request) (model.Route, error) {
 		routeGetRouteInfoCalled++
 		assert.Equal(t, dummyHTTPRequest, httpRequest)
-		return dummyEndpoint, dummyAction, dummyRouteError
+		return model.Route{
+			Endpoint:        dummyEndpoint,
+			ActionFunc:      dummyAction,
+			PreActionFuncs:  dummyPreActionFuncs,
+			RateLimit:       dummyRateLimit,
+			Auth:            dummyAuth,
+			MediaTypes:      dummyMediaTypes,
+			CORS:            dummyCORS,
+			MaxBodyBytes:    dummyMaxBodyBytes,
+			PostActionFuncs: dummyPostActionFuncs,
+		}, nil
 	}
-	sessionRegisterExpected = 1
-	sessionRegister = func(name string, httpRequest *http.Request, responseWriter http.ResponseWriter) sessionModel.Session {
-		sessionRegisterCalled++
+	tracingStartServerSpanExpected = 1
+	tracingStartServerSpan = func(header http.Header, name string) *tracingModel.Span {
+		tracingStartServerSpanCalled++
+		assert.Equal(t, dummyHTTPRequest.Header, header)
 		assert.Equal(t, dummyEndpoint, name)
+		return dummySpan
+	}
+	tracingWithSpanExpected = 1
+	tracingWithSpan = func(httpRequest *http.Request, span *tracingModel.Span) *http.Request {
+		tracingWithSpanCalled++
 		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummySpan, span)
+		return httpRequest
+	}
+	metricsWrapResponseWriterExpected = 1
+	metricsWrapResponseWriter = func(responseWriter http.ResponseWriter) http.ResponseWriter {
+		metricsWrapResponseWriterCalled++
 		assert.Equal(t, dummyResponseWriter, responseWriter)
+		return dummyMetricsResponseWriter
+	}
+	sessionRegisterExpected = 1
+	sessionRegister = func(endpoint string, httpRequest *http.Request, responseWriter http.ResponseWriter) sessionModel.Session {
+		sessionRegisterCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
 		return dummySessionObject
 	}
 	timeutilGetTimeNowUTCExpected = 1
@@ -118,6 +832,10 @@ func TestHandleInSession_RouteError(t *testing.T) {
 		timeutilGetTimeNowUTCCalled++
 		return dummyStartTime
 	}
+	metricsSessionStartedExpected = 1
+	metricsSessionStarted = func() {
+		metricsSessionStartedCalled++
+	}
 	loggerAPIEnterExpected = 1
 	loggerAPIEnter = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAPIEnterCalled++
@@ -127,19 +845,50 @@ func TestHandleInSession_RouteError(t *testing.T) {
 		assert.Zero(t, messageFormat)
 		assert.Equal(t, 0, len(parameters))
 	}
-	apperrorGetInvalidOperationExpected = 1
-	apperrorGetInvalidOperation = func(innerErrors ...error) apperrorModel.AppError {
-		apperrorGetInvalidOperationCalled++
-		assert.Equal(t, 1, len(innerErrors))
-		assert.Equal(t, dummyRouteError, innerErrors[0])
-		return dummyResponseError
+	corsWriteHeadersExpected = 1
+	corsWriteHeaders = func(responseWriter http.ResponseWriter, httpRequest *http.Request, routePolicy *model.CORS) {
+		corsWriteHeadersCalled++
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyCORS, routePolicy)
+	}
+	ratelimitCheckExpected = 1
+	ratelimitCheck = func(session sessionModel.Session, route model.Route) error {
+		ratelimitCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyRateLimit, route.RateLimit)
+		return nil
+	}
+	authCheckExpected = 1
+	authCheck = func(session sessionModel.Session, route model.Route) error {
+		authCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyAuth, route.Auth)
+		return nil
+	}
+	bodylimitCheckExpected = 1
+	bodylimitCheck = func(session sessionModel.Session, route model.Route) error {
+		bodylimitCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyMaxBodyBytes, route.MaxBodyBytes)
+		return nil
+	}
+	responseNegotiateExpected = 1
+	responseNegotiate = func(session sessionModel.Session, mediaTypes []string) error {
+		responseNegotiateCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyMediaTypes, mediaTypes)
+		return dummyNegotiateError
 	}
 	responseWriteExpected = 1
 	responseWrite = func(session sessionModel.Session, responseObject interface{}, responseError error) {
 		responseWriteCalled++
 		assert.Equal(t, dummySessionObject, session)
 		assert.Nil(t, responseObject)
-		assert.Equal(t, dummyResponseError, responseError)
+		assert.Equal(t, dummyNegotiateError, responseError)
 	}
 	timeSinceExpected = 1
 	timeSince = func(ts time.Time) time.Duration {
@@ -147,6 +896,20 @@ func TestHandleInSession_RouteError(t *testing.T) {
 		assert.Equal(t, dummyStartTime, ts)
 		return dummyTimeSince
 	}
+	metricsSessionFinishedExpected = 1
+	metricsSessionFinished = func(endpoint string, method string, responseWriter http.ResponseWriter, duration time.Duration) {
+		metricsSessionFinishedCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyHTTPRequest.Method, method)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyTimeSince, duration)
+	}
+	tracingEndSpanExpected = 1
+	tracingEndSpan = func(span *tracingModel.Span, err error) {
+		tracingEndSpanCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.NoError(t, err)
+	}
 	loggerAPIExitExpected = 1
 	loggerAPIExit = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAPIExitCalled++
@@ -182,13 +945,22 @@ func TestHandleInSession_PreActionError(t *testing.T) {
 		RequestURI: "http://localhost/",
 		Header:     map[string][]string{},
 	}
+	var dummyMetricsResponseWriter = &dummyResponseWriter{t}
 	var dummyResponseWriter = &dummyResponseWriter{t}
 	var dummyEndpoint = "some endpoint"
+	var dummyRateLimit = &model.RateLimit{Rate: 1}
+	var dummyAuth = &model.AuthRequirement{Scopes: []string{"some scope"}}
+	var dummyMediaTypes = []string{"some media type"}
+	var dummyCORS = &model.CORS{AllowedOrigins: []string{"some origin"}}
+	var dummyMaxBodyBytes = rand.Int63()
+	var dummyPreActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummyPostActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
 	var dummySessionObject = &dummySession{t}
 	var dummyAction func(sessionModel.Session) (interface{}, error)
 	var dummyActionExpected int
 	var dummyActionCalled int
 	var dummyPreActionError = errors.New("some pre-action error")
+	var dummySpan = &tracingModel.Span{Name: "some span"}
 	var dummyStartTime = time.Now()
 	var dummyTimeSince = time.Duration(rand.Intn(1000))
 
@@ -197,17 +969,47 @@ func TestHandleInSession_PreActionError(t *testing.T) {
 
 	// expect
 	routeGetRouteInfoExpected = 1
-	routeGetRouteInfo = func(httpRequest *http.Request) (string, model.ActionFunc, error) {
+	routeGetRouteInfo = func(httpRequest *http.Request) (model.Route, error) {
 		routeGetRouteInfoCalled++
 		assert.Equal(t, dummyHTTPRequest, httpRequest)
-		return dummyEndpoint, dummyAction, nil
+		return model.Route{
+			Endpoint:        dummyEndpoint,
+			ActionFunc:      dummyAction,
+			PreActionFuncs:  dummyPreActionFuncs,
+			RateLimit:       dummyRateLimit,
+			Auth:            dummyAuth,
+			MediaTypes:      dummyMediaTypes,
+			CORS:            dummyCORS,
+			MaxBodyBytes:    dummyMaxBodyBytes,
+			PostActionFuncs: dummyPostActionFuncs,
+		}, nil
+	}
+	tracingStartServerSpanExpected = 1
+	tracingStartServerSpan = func(header http.Header, name string) *tracingModel.Span {
+		tracingStartServerSpanCalled++
+		assert.Equal(t, dummyHTTPRequest.Header, header)
+		assert.Equal(t, dummyEndpoint, name)
+		return dummySpan
+	}
+	tracingWithSpanExpected = 1
+	tracingWithSpan = func(httpRequest *http.Request, span *tracingModel.Span) *http.Request {
+		tracingWithSpanCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummySpan, span)
+		return httpRequest
+	}
+	metricsWrapResponseWriterExpected = 1
+	metricsWrapResponseWriter = func(responseWriter http.ResponseWriter) http.ResponseWriter {
+		metricsWrapResponseWriterCalled++
+		assert.Equal(t, dummyResponseWriter, responseWriter)
+		return dummyMetricsResponseWriter
 	}
 	sessionRegisterExpected = 1
 	sessionRegister = func(endpoint string, httpRequest *http.Request, responseWriter http.ResponseWriter) sessionModel.Session {
 		sessionRegisterCalled++
 		assert.Equal(t, dummyEndpoint, endpoint)
 		assert.Equal(t, dummyHTTPRequest, httpRequest)
-		assert.Equal(t, dummyResponseWriter, responseWriter)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
 		return dummySessionObject
 	}
 	timeutilGetTimeNowUTCExpected = 1
@@ -215,6 +1017,10 @@ func TestHandleInSession_PreActionError(t *testing.T) {
 		timeutilGetTimeNowUTCCalled++
 		return dummyStartTime
 	}
+	metricsSessionStartedExpected = 1
+	metricsSessionStarted = func() {
+		metricsSessionStartedCalled++
+	}
 	loggerAPIEnterExpected = 1
 	loggerAPIEnter = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAPIEnterCalled++
@@ -224,13 +1030,49 @@ func TestHandleInSession_PreActionError(t *testing.T) {
 		assert.Zero(t, messageFormat)
 		assert.Equal(t, 0, len(parameters))
 	}
-	executeCustomizedFunctionFuncExpected = 1
-	executeCustomizedFunctionFunc = func(session sessionModel.Session, customFunc func(sessionModel.Session) error) error {
-		executeCustomizedFunctionFuncCalled++
+	corsWriteHeadersExpected = 1
+	corsWriteHeaders = func(responseWriter http.ResponseWriter, httpRequest *http.Request, routePolicy *model.CORS) {
+		corsWriteHeadersCalled++
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyCORS, routePolicy)
+	}
+	ratelimitCheckExpected = 1
+	ratelimitCheck = func(session sessionModel.Session, route model.Route) error {
+		ratelimitCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyRateLimit, route.RateLimit)
+		return nil
+	}
+	authCheckExpected = 1
+	authCheck = func(session sessionModel.Session, route model.Route) error {
+		authCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyAuth, route.Auth)
+		return nil
+	}
+	bodylimitCheckExpected = 1
+	bodylimitCheck = func(session sessionModel.Session, route model.Route) error {
+		bodylimitCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyMaxBodyBytes, route.MaxBodyBytes)
+		return nil
+	}
+	responseNegotiateExpected = 1
+	responseNegotiate = func(session sessionModel.Session, mediaTypes []string) error {
+		responseNegotiateCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyMediaTypes, mediaTypes)
+		return nil
+	}
+	executePreActionsFuncExpected = 1
+	executePreActionsFunc = func(session sessionModel.Session, preActionFuncs []model.HookFunc) error {
+		executePreActionsFuncCalled++
 		assert.Equal(t, dummySessionObject, session)
-		var pointerExpect = fmt.Sprintf("%v", reflect.ValueOf(customization.PreActionFunc))
-		var pointerActual = fmt.Sprintf("%v", reflect.ValueOf(customFunc))
-		assert.Equal(t, pointerExpect, pointerActual)
+		assert.Equal(t, fmt.Sprintf("%v", dummyPreActionFuncs), fmt.Sprintf("%v", preActionFuncs))
 		return dummyPreActionError
 	}
 	responseWriteExpected = 1
@@ -246,6 +1088,20 @@ func TestHandleInSession_PreActionError(t *testing.T) {
 		assert.Equal(t, dummyStartTime, ts)
 		return dummyTimeSince
 	}
+	metricsSessionFinishedExpected = 1
+	metricsSessionFinished = func(endpoint string, method string, responseWriter http.ResponseWriter, duration time.Duration) {
+		metricsSessionFinishedCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyHTTPRequest.Method, method)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyTimeSince, duration)
+	}
+	tracingEndSpanExpected = 1
+	tracingEndSpan = func(span *tracingModel.Span, err error) {
+		tracingEndSpanCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.NoError(t, err)
+	}
 	loggerAPIExitExpected = 1
 	loggerAPIExit = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAPIExitCalled++
@@ -281,8 +1137,16 @@ func TestHandleInSession_PostActionError_WithResponseError(t *testing.T) {
 		RequestURI: "http://localhost/",
 		Header:     map[string][]string{},
 	}
+	var dummyMetricsResponseWriter = &dummyResponseWriter{t}
 	var dummyResponseWriter = &dummyResponseWriter{t}
 	var dummyEndpoint = "some endpoint"
+	var dummyRateLimit = &model.RateLimit{Rate: 1}
+	var dummyAuth = &model.AuthRequirement{Scopes: []string{"some scope"}}
+	var dummyMediaTypes = []string{"some media type"}
+	var dummyCORS = &model.CORS{AllowedOrigins: []string{"some origin"}}
+	var dummyMaxBodyBytes = rand.Int63()
+	var dummyPreActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummyPostActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
 	var dummySessionObject = &dummySession{t}
 	var dummyAction func(sessionModel.Session) (interface{}, error)
 	var dummyActionExpected int
@@ -290,6 +1154,7 @@ func TestHandleInSession_PostActionError_WithResponseError(t *testing.T) {
 	var dummyResponseObject = "some response object"
 	var dummyResponseError = apperror.GetCustomError(0, "some app error")
 	var dummyPostActionError = errors.New("some post-action error")
+	var dummySpan = &tracingModel.Span{Name: "some span"}
 	var dummyStartTime = time.Now()
 	var dummyTimeSince = time.Duration(rand.Intn(1000))
 
@@ -298,17 +1163,47 @@ func TestHandleInSession_PostActionError_WithResponseError(t *testing.T) {
 
 	// expect
 	routeGetRouteInfoExpected = 1
-	routeGetRouteInfo = func(httpRequest *http.Request) (string, model.ActionFunc, error) {
+	routeGetRouteInfo = func(httpRequest *http.Request) (model.Route, error) {
 		routeGetRouteInfoCalled++
 		assert.Equal(t, dummyHTTPRequest, httpRequest)
-		return dummyEndpoint, dummyAction, nil
+		return model.Route{
+			Endpoint:        dummyEndpoint,
+			ActionFunc:      dummyAction,
+			PreActionFuncs:  dummyPreActionFuncs,
+			RateLimit:       dummyRateLimit,
+			Auth:            dummyAuth,
+			MediaTypes:      dummyMediaTypes,
+			CORS:            dummyCORS,
+			MaxBodyBytes:    dummyMaxBodyBytes,
+			PostActionFuncs: dummyPostActionFuncs,
+		}, nil
+	}
+	tracingStartServerSpanExpected = 1
+	tracingStartServerSpan = func(header http.Header, name string) *tracingModel.Span {
+		tracingStartServerSpanCalled++
+		assert.Equal(t, dummyHTTPRequest.Header, header)
+		assert.Equal(t, dummyEndpoint, name)
+		return dummySpan
+	}
+	tracingWithSpanExpected = 1
+	tracingWithSpan = func(httpRequest *http.Request, span *tracingModel.Span) *http.Request {
+		tracingWithSpanCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummySpan, span)
+		return httpRequest
+	}
+	metricsWrapResponseWriterExpected = 1
+	metricsWrapResponseWriter = func(responseWriter http.ResponseWriter) http.ResponseWriter {
+		metricsWrapResponseWriterCalled++
+		assert.Equal(t, dummyResponseWriter, responseWriter)
+		return dummyMetricsResponseWriter
 	}
 	sessionRegisterExpected = 1
 	sessionRegister = func(endpoint string, httpRequest *http.Request, responseWriter http.ResponseWriter) sessionModel.Session {
 		sessionRegisterCalled++
 		assert.Equal(t, dummyEndpoint, endpoint)
 		assert.Equal(t, dummyHTTPRequest, httpRequest)
-		assert.Equal(t, dummyResponseWriter, responseWriter)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
 		return dummySessionObject
 	}
 	timeutilGetTimeNowUTCExpected = 1
@@ -316,6 +1211,10 @@ func TestHandleInSession_PostActionError_WithResponseError(t *testing.T) {
 		timeutilGetTimeNowUTCCalled++
 		return dummyStartTime
 	}
+	metricsSessionStartedExpected = 1
+	metricsSessionStarted = func() {
+		metricsSessionStartedCalled++
+	}
 	loggerAPIEnterExpected = 1
 	loggerAPIEnter = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAPIEnterCalled++
@@ -325,20 +1224,49 @@ func TestHandleInSession_PostActionError_WithResponseError(t *testing.T) {
 		assert.Zero(t, messageFormat)
 		assert.Equal(t, 0, len(parameters))
 	}
-	executeCustomizedFunctionFuncExpected = 2
-	executeCustomizedFunctionFunc = func(session sessionModel.Session, customFunc func(sessionModel.Session) error) error {
-		executeCustomizedFunctionFuncCalled++
-		assert.Equal(t, dummySessionObject, session)
-		var pointerActual = fmt.Sprintf("%v", reflect.ValueOf(customFunc))
-		if executeCustomizedFunctionFuncCalled == 1 {
-			var pointerExpect = fmt.Sprintf("%v", reflect.ValueOf(customization.PreActionFunc))
-			assert.Equal(t, pointerExpect, pointerActual)
-			return nil
-		} else if executeCustomizedFunctionFuncCalled == 2 {
-			var pointerExpect = fmt.Sprintf("%v", reflect.ValueOf(customization.PostActionFunc))
-			assert.Equal(t, pointerExpect, pointerActual)
-			return dummyPostActionError
-		}
+	corsWriteHeadersExpected = 1
+	corsWriteHeaders = func(responseWriter http.ResponseWriter, httpRequest *http.Request, routePolicy *model.CORS) {
+		corsWriteHeadersCalled++
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyCORS, routePolicy)
+	}
+	ratelimitCheckExpected = 1
+	ratelimitCheck = func(session sessionModel.Session, route model.Route) error {
+		ratelimitCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyRateLimit, route.RateLimit)
+		return nil
+	}
+	authCheckExpected = 1
+	authCheck = func(session sessionModel.Session, route model.Route) error {
+		authCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyAuth, route.Auth)
+		return nil
+	}
+	bodylimitCheckExpected = 1
+	bodylimitCheck = func(session sessionModel.Session, route model.Route) error {
+		bodylimitCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyMaxBodyBytes, route.MaxBodyBytes)
+		return nil
+	}
+	responseNegotiateExpected = 1
+	responseNegotiate = func(session sessionModel.Session, mediaTypes []string) error {
+		responseNegotiateCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyMediaTypes, mediaTypes)
+		return nil
+	}
+	executePreActionsFuncExpected = 1
+	executePreActionsFunc = func(session sessionModel.Session, preActionFuncs []model.HookFunc) error {
+		executePreActionsFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, fmt.Sprintf("%v", dummyPreActionFuncs), fmt.Sprintf("%v", preActionFuncs))
 		return nil
 	}
 	dummyActionExpected = 1
@@ -347,6 +1275,13 @@ func TestHandleInSession_PostActionError_WithResponseError(t *testing.T) {
 		assert.Equal(t, dummySessionObject, session)
 		return dummyResponseObject, dummyResponseError
 	}
+	executePostActionsFuncExpected = 1
+	executePostActionsFunc = func(session sessionModel.Session, postActionFuncs []model.HookFunc) error {
+		executePostActionsFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, fmt.Sprintf("%v", dummyPostActionFuncs), fmt.Sprintf("%v", postActionFuncs))
+		return dummyPostActionError
+	}
 	responseWriteExpected = 1
 	responseWrite = func(session sessionModel.Session, responseObject interface{}, responseError error) {
 		responseWriteCalled++
@@ -360,6 +1295,20 @@ func TestHandleInSession_PostActionError_WithResponseError(t *testing.T) {
 		assert.Equal(t, dummyStartTime, ts)
 		return dummyTimeSince
 	}
+	metricsSessionFinishedExpected = 1
+	metricsSessionFinished = func(endpoint string, method string, responseWriter http.ResponseWriter, duration time.Duration) {
+		metricsSessionFinishedCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyHTTPRequest.Method, method)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyTimeSince, duration)
+	}
+	tracingEndSpanExpected = 1
+	tracingEndSpan = func(span *tracingModel.Span, err error) {
+		tracingEndSpanCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.NoError(t, err)
+	}
 	loggerAPIExitExpected = 2
 	loggerAPIExit = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAPIExitCalled++
@@ -401,14 +1350,23 @@ func TestHandleInSession_PostActionError_NoResponseError(t *testing.T) {
 		RequestURI: "http://localhost/",
 		Header:     map[string][]string{},
 	}
+	var dummyMetricsResponseWriter = &dummyResponseWriter{t}
 	var dummyResponseWriter = &dummyResponseWriter{t}
 	var dummyEndpoint = "some endpoint"
+	var dummyRateLimit = &model.RateLimit{Rate: 1}
+	var dummyAuth = &model.AuthRequirement{Scopes: []string{"some scope"}}
+	var dummyMediaTypes = []string{"some media type"}
+	var dummyCORS = &model.CORS{AllowedOrigins: []string{"some origin"}}
+	var dummyMaxBodyBytes = rand.Int63()
+	var dummyPreActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummyPostActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
 	var dummySessionObject = &dummySession{t}
 	var dummyAction func(sessionModel.Session) (interface{}, error)
 	var dummyActionExpected int
 	var dummyActionCalled int
 	var dummyResponseObject = "some response object"
 	var dummyPostActionError = errors.New("some post-action error")
+	var dummySpan = &tracingModel.Span{Name: "some span"}
 	var dummyStartTime = time.Now()
 	var dummyTimeSince = time.Duration(rand.Intn(1000))
 
@@ -417,17 +1375,47 @@ func TestHandleInSession_PostActionError_NoResponseError(t *testing.T) {
 
 	// expect
 	routeGetRouteInfoExpected = 1
-	routeGetRouteInfo = func(httpRequest *http.Request) (string, model.ActionFunc, error) {
+	routeGetRouteInfo = func(httpRequest *http.Request) (model.Route, error) {
 		routeGetRouteInfoCalled++
 		assert.Equal(t, dummyHTTPRequest, httpRequest)
-		return dummyEndpoint, dummyAction, nil
+		return model.Route{
+			Endpoint:        dummyEndpoint,
+			ActionFunc:      dummyAction,
+			PreActionFuncs:  dummyPreActionFuncs,
+			RateLimit:       dummyRateLimit,
+			Auth:            dummyAuth,
+			MediaTypes:      dummyMediaTypes,
+			CORS:            dummyCORS,
+			MaxBodyBytes:    dummyMaxBodyBytes,
+			PostActionFuncs: dummyPostActionFuncs,
+		}, nil
+	}
+	tracingStartServerSpanExpected = 1
+	tracingStartServerSpan = func(header http.Header, name string) *tracingModel.Span {
+		tracingStartServerSpanCalled++
+		assert.Equal(t, dummyHTTPRequest.Header, header)
+		assert.Equal(t, dummyEndpoint, name)
+		return dummySpan
+	}
+	tracingWithSpanExpected = 1
+	tracingWithSpan = func(httpRequest *http.Request, span *tracingModel.Span) *http.Request {
+		tracingWithSpanCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummySpan, span)
+		return httpRequest
+	}
+	metricsWrapResponseWriterExpected = 1
+	metricsWrapResponseWriter = func(responseWriter http.ResponseWriter) http.ResponseWriter {
+		metricsWrapResponseWriterCalled++
+		assert.Equal(t, dummyResponseWriter, responseWriter)
+		return dummyMetricsResponseWriter
 	}
 	sessionRegisterExpected = 1
 	sessionRegister = func(endpoint string, httpRequest *http.Request, responseWriter http.ResponseWriter) sessionModel.Session {
 		sessionRegisterCalled++
 		assert.Equal(t, dummyEndpoint, endpoint)
 		assert.Equal(t, dummyHTTPRequest, httpRequest)
-		assert.Equal(t, dummyResponseWriter, responseWriter)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
 		return dummySessionObject
 	}
 	timeutilGetTimeNowUTCExpected = 1
@@ -435,6 +1423,10 @@ func TestHandleInSession_PostActionError_NoResponseError(t *testing.T) {
 		timeutilGetTimeNowUTCCalled++
 		return dummyStartTime
 	}
+	metricsSessionStartedExpected = 1
+	metricsSessionStarted = func() {
+		metricsSessionStartedCalled++
+	}
 	loggerAPIEnterExpected = 1
 	loggerAPIEnter = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAPIEnterCalled++
@@ -444,20 +1436,49 @@ func TestHandleInSession_PostActionError_NoResponseError(t *testing.T) {
 		assert.Zero(t, messageFormat)
 		assert.Equal(t, 0, len(parameters))
 	}
-	executeCustomizedFunctionFuncExpected = 2
-	executeCustomizedFunctionFunc = func(session sessionModel.Session, customFunc func(sessionModel.Session) error) error {
-		executeCustomizedFunctionFuncCalled++
-		assert.Equal(t, dummySessionObject, session)
-		var pointerActual = fmt.Sprintf("%v", reflect.ValueOf(customFunc))
-		if executeCustomizedFunctionFuncCalled == 1 {
-			var pointerExpect = fmt.Sprintf("%v", reflect.ValueOf(customization.PreActionFunc))
-			assert.Equal(t, pointerExpect, pointerActual)
-			return nil
-		} else if executeCustomizedFunctionFuncCalled == 2 {
-			var pointerExpect = fmt.Sprintf("%v", reflect.ValueOf(customization.PostActionFunc))
-			assert.Equal(t, pointerExpect, pointerActual)
-			return dummyPostActionError
-		}
+	corsWriteHeadersExpected = 1
+	corsWriteHeaders = func(responseWriter http.ResponseWriter, httpRequest *http.Request, routePolicy *model.CORS) {
+		corsWriteHeadersCalled++
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyCORS, routePolicy)
+	}
+	ratelimitCheckExpected = 1
+	ratelimitCheck = func(session sessionModel.Session, route model.Route) error {
+		ratelimitCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyRateLimit, route.RateLimit)
+		return nil
+	}
+	authCheckExpected = 1
+	authCheck = func(session sessionModel.Session, route model.Route) error {
+		authCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyAuth, route.Auth)
+		return nil
+	}
+	bodylimitCheckExpected = 1
+	bodylimitCheck = func(session sessionModel.Session, route model.Route) error {
+		bodylimitCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyMaxBodyBytes, route.MaxBodyBytes)
+		return nil
+	}
+	responseNegotiateExpected = 1
+	responseNegotiate = func(session sessionModel.Session, mediaTypes []string) error {
+		responseNegotiateCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyMediaTypes, mediaTypes)
+		return nil
+	}
+	executePreActionsFuncExpected = 1
+	executePreActionsFunc = func(session sessionModel.Session, preActionFuncs []model.HookFunc) error {
+		executePreActionsFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, fmt.Sprintf("%v", dummyPreActionFuncs), fmt.Sprintf("%v", preActionFuncs))
 		return nil
 	}
 	dummyActionExpected = 1
@@ -466,6 +1487,13 @@ func TestHandleInSession_PostActionError_NoResponseError(t *testing.T) {
 		assert.Equal(t, dummySessionObject, session)
 		return dummyResponseObject, nil
 	}
+	executePostActionsFuncExpected = 1
+	executePostActionsFunc = func(session sessionModel.Session, postActionFuncs []model.HookFunc) error {
+		executePostActionsFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, fmt.Sprintf("%v", dummyPostActionFuncs), fmt.Sprintf("%v", postActionFuncs))
+		return dummyPostActionError
+	}
 	responseWriteExpected = 1
 	responseWrite = func(session sessionModel.Session, responseObject interface{}, responseError error) {
 		responseWriteCalled++
@@ -479,6 +1507,20 @@ func TestHandleInSession_PostActionError_NoResponseError(t *testing.T) {
 		assert.Equal(t, dummyStartTime, ts)
 		return dummyTimeSince
 	}
+	metricsSessionFinishedExpected = 1
+	metricsSessionFinished = func(endpoint string, method string, responseWriter http.ResponseWriter, duration time.Duration) {
+		metricsSessionFinishedCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyHTTPRequest.Method, method)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyTimeSince, duration)
+	}
+	tracingEndSpanExpected = 1
+	tracingEndSpan = func(span *tracingModel.Span, err error) {
+		tracingEndSpanCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.NoError(t, err)
+	}
 	loggerAPIExitExpected = 1
 	loggerAPIExit = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAPIExitCalled++
@@ -514,14 +1556,23 @@ func TestHandleInSession_Success(t *testing.T) {
 		RequestURI: "http://localhost/",
 		Header:     map[string][]string{},
 	}
+	var dummyMetricsResponseWriter = &dummyResponseWriter{t}
 	var dummyResponseWriter = &dummyResponseWriter{t}
 	var dummyEndpoint = "some endpoint"
+	var dummyRateLimit = &model.RateLimit{Rate: 1}
+	var dummyAuth = &model.AuthRequirement{Scopes: []string{"some scope"}}
+	var dummyMediaTypes = []string{"some media type"}
+	var dummyCORS = &model.CORS{AllowedOrigins: []string{"some origin"}}
+	var dummyMaxBodyBytes = rand.Int63()
+	var dummyPreActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummyPostActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
 	var dummySessionObject = &dummySession{t}
 	var dummyAction func(sessionModel.Session) (interface{}, error)
 	var dummyActionExpected int
 	var dummyActionCalled int
 	var dummyResponseObject = "some response object"
 	var dummyResponseError = apperror.GetCustomError(0, "some app error")
+	var dummySpan = &tracingModel.Span{Name: "some span"}
 	var dummyStartTime = time.Now()
 	var dummyTimeSince = time.Duration(rand.Intn(1000))
 
@@ -530,17 +1581,47 @@ func TestHandleInSession_Success(t *testing.T) {
 
 	// expect
 	routeGetRouteInfoExpected = 1
-	routeGetRouteInfo = func(httpRequest *http.Request) (string, model.ActionFunc, error) {
+	routeGetRouteInfo = func(httpRequest *http.Request) (model.Route, error) {
 		routeGetRouteInfoCalled++
 		assert.Equal(t, dummyHTTPRequest, httpRequest)
-		return dummyEndpoint, dummyAction, nil
+		return model.Route{
+			Endpoint:        dummyEndpoint,
+			ActionFunc:      dummyAction,
+			PreActionFuncs:  dummyPreActionFuncs,
+			RateLimit:       dummyRateLimit,
+			Auth:            dummyAuth,
+			MediaTypes:      dummyMediaTypes,
+			CORS:            dummyCORS,
+			MaxBodyBytes:    dummyMaxBodyBytes,
+			PostActionFuncs: dummyPostActionFuncs,
+		}, nil
+	}
+	tracingStartServerSpanExpected = 1
+	tracingStartServerSpan = func(header http.Header, name string) *tracingModel.Span {
+		tracingStartServerSpanCalled++
+		assert.Equal(t, dummyHTTPRequest.Header, header)
+		assert.Equal(t, dummyEndpoint, name)
+		return dummySpan
+	}
+	tracingWithSpanExpected = 1
+	tracingWithSpan = func(httpRequest *http.Request, span *tracingModel.Span) *http.Request {
+		tracingWithSpanCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummySpan, span)
+		return httpRequest
+	}
+	metricsWrapResponseWriterExpected = 1
+	metricsWrapResponseWriter = func(responseWriter http.ResponseWriter) http.ResponseWriter {
+		metricsWrapResponseWriterCalled++
+		assert.Equal(t, dummyResponseWriter, responseWriter)
+		return dummyMetricsResponseWriter
 	}
 	sessionRegisterExpected = 1
 	sessionRegister = func(endpoint string, httpRequest *http.Request, responseWriter http.ResponseWriter) sessionModel.Session {
 		sessionRegisterCalled++
 		assert.Equal(t, dummyEndpoint, endpoint)
 		assert.Equal(t, dummyHTTPRequest, httpRequest)
-		assert.Equal(t, dummyResponseWriter, responseWriter)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
 		return dummySessionObject
 	}
 	timeutilGetTimeNowUTCExpected = 1
@@ -548,6 +1629,10 @@ func TestHandleInSession_Success(t *testing.T) {
 		timeutilGetTimeNowUTCCalled++
 		return dummyStartTime
 	}
+	metricsSessionStartedExpected = 1
+	metricsSessionStarted = func() {
+		metricsSessionStartedCalled++
+	}
 	loggerAPIEnterExpected = 1
 	loggerAPIEnter = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAPIEnterCalled++
@@ -557,18 +1642,49 @@ func TestHandleInSession_Success(t *testing.T) {
 		assert.Zero(t, messageFormat)
 		assert.Equal(t, 0, len(parameters))
 	}
-	executeCustomizedFunctionFuncExpected = 2
-	executeCustomizedFunctionFunc = func(session sessionModel.Session, customFunc func(sessionModel.Session) error) error {
-		executeCustomizedFunctionFuncCalled++
-		assert.Equal(t, dummySessionObject, session)
-		var pointerActual = fmt.Sprintf("%v", reflect.ValueOf(customFunc))
-		if executeCustomizedFunctionFuncCalled == 1 {
-			var pointerExpect = fmt.Sprintf("%v", reflect.ValueOf(customization.PreActionFunc))
-			assert.Equal(t, pointerExpect, pointerActual)
-		} else if executeCustomizedFunctionFuncCalled == 2 {
-			var pointerExpect = fmt.Sprintf("%v", reflect.ValueOf(customization.PostActionFunc))
-			assert.Equal(t, pointerExpect, pointerActual)
-		}
+	corsWriteHeadersExpected = 1
+	corsWriteHeaders = func(responseWriter http.ResponseWriter, httpRequest *http.Request, routePolicy *model.CORS) {
+		corsWriteHeadersCalled++
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyCORS, routePolicy)
+	}
+	ratelimitCheckExpected = 1
+	ratelimitCheck = func(session sessionModel.Session, route model.Route) error {
+		ratelimitCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyRateLimit, route.RateLimit)
+		return nil
+	}
+	authCheckExpected = 1
+	authCheck = func(session sessionModel.Session, route model.Route) error {
+		authCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyAuth, route.Auth)
+		return nil
+	}
+	bodylimitCheckExpected = 1
+	bodylimitCheck = func(session sessionModel.Session, route model.Route) error {
+		bodylimitCheckCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyEndpoint, route.Endpoint)
+		assert.Equal(t, dummyMaxBodyBytes, route.MaxBodyBytes)
+		return nil
+	}
+	responseNegotiateExpected = 1
+	responseNegotiate = func(session sessionModel.Session, mediaTypes []string) error {
+		responseNegotiateCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyMediaTypes, mediaTypes)
+		return nil
+	}
+	executePreActionsFuncExpected = 1
+	executePreActionsFunc = func(session sessionModel.Session, preActionFuncs []model.HookFunc) error {
+		executePreActionsFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, fmt.Sprintf("%v", dummyPreActionFuncs), fmt.Sprintf("%v", preActionFuncs))
 		return nil
 	}
 	dummyActionExpected = 1
@@ -577,6 +1693,13 @@ func TestHandleInSession_Success(t *testing.T) {
 		assert.Equal(t, dummySessionObject, session)
 		return dummyResponseObject, dummyResponseError
 	}
+	executePostActionsFuncExpected = 1
+	executePostActionsFunc = func(session sessionModel.Session, postActionFuncs []model.HookFunc) error {
+		executePostActionsFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, fmt.Sprintf("%v", dummyPostActionFuncs), fmt.Sprintf("%v", postActionFuncs))
+		return nil
+	}
 	responseWriteExpected = 1
 	responseWrite = func(session sessionModel.Session, responseObject interface{}, responseError error) {
 		responseWriteCalled++
@@ -590,6 +1713,20 @@ func TestHandleInSession_Success(t *testing.T) {
 		assert.Equal(t, dummyStartTime, ts)
 		return dummyTimeSince
 	}
+	metricsSessionFinishedExpected = 1
+	metricsSessionFinished = func(endpoint string, method string, responseWriter http.ResponseWriter, duration time.Duration) {
+		metricsSessionFinishedCalled++
+		assert.Equal(t, dummyEndpoint, endpoint)
+		assert.Equal(t, dummyHTTPRequest.Method, method)
+		assert.Equal(t, dummyMetricsResponseWriter, responseWriter)
+		assert.Equal(t, dummyTimeSince, duration)
+	}
+	tracingEndSpanExpected = 1
+	tracingEndSpan = func(span *tracingModel.Span, err error) {
+		tracingEndSpanCalled++
+		assert.Equal(t, dummySpan, span)
+		assert.NoError(t, err)
+	}
 	loggerAPIExitExpected = 1
 	loggerAPIExit = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAPIExitCalled++
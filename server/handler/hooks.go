@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+// executePreActions executes the customized global pre-action function followed by the given ones of the route, stopping at the first error
+func executePreActions(session sessionModel.Session, preActionFuncs []model.HookFunc) error {
+	var preActionError = executeCustomizedFunctionFunc(
+		session,
+		preActionSpanName,
+		customization.PreActionFunc,
+	)
+	if preActionError != nil {
+		return preActionError
+	}
+	for _, preActionFunc := range preActionFuncs {
+		preActionError = executeCustomizedFunctionFunc(
+			session,
+			preActionSpanName,
+			preActionFunc,
+		)
+		if preActionError != nil {
+			return preActionError
+		}
+	}
+	return nil
+}
+
+// executePostActions executes the given post-action functions of the route followed by the customized global one, all of them regardless of errors, and returns the first error
+func executePostActions(session sessionModel.Session, postActionFuncs []model.HookFunc) error {
+	var firstError error
+	for _, postActionFunc := range postActionFuncs {
+		var postActionError = executeCustomizedFunctionFunc(
+			session,
+			postActionSpanName,
+			postActionFunc,
+		)
+		if firstError == nil {
+			firstError = postActionError
+		}
+	}
+	var postActionError = executeCustomizedFunctionFunc(
+		session,
+		postActionSpanName,
+		customization.PostActionFunc,
+	)
+	if firstError == nil {
+		return postActionError
+	}
+	return firstError
+}
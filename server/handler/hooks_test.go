@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+func createDummyHooks(count int) ([]model.HookFunc, []string) {
+	var hooks = []model.HookFunc{}
+	var pointers = []string{}
+	for index := 0; index < count; index++ {
+		var hook = model.HookFunc(func(sessionModel.Session) error { return nil })
+		hooks = append(hooks, hook)
+		pointers = append(pointers, fmt.Sprintf("%v", reflect.ValueOf(hook)))
+	}
+	return hooks, pointers
+}
+
+func TestExecutePreActions_GlobalError(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t}
+	var dummyHooks, _ = createDummyHooks(1)
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	executeCustomizedFunctionFuncExpected = 1
+	executeCustomizedFunctionFunc = func(session sessionModel.Session, spanName string, customFunc func(sessionModel.Session) error) error {
+		executeCustomizedFunctionFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, preActionSpanName, spanName)
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(customization.PreActionFunc)), fmt.Sprintf("%v", reflect.ValueOf(customFunc)))
+		return dummyError
+	}
+
+	// SUT + act
+	var err = executePreActions(
+		dummySessionObject,
+		dummyHooks,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestExecutePreActions_RouteError(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t}
+	var dummyHooks, dummyPointers = createDummyHooks(3)
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	executeCustomizedFunctionFuncExpected = 3
+	executeCustomizedFunctionFunc = func(session sessionModel.Session, spanName string, customFunc func(sessionModel.Session) error) error {
+		executeCustomizedFunctionFuncCalled++
+		assert.Equal(t, preActionSpanName, spanName)
+		if executeCustomizedFunctionFuncCalled == 1 {
+			assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(customization.PreActionFunc)), fmt.Sprintf("%v", reflect.ValueOf(customFunc)))
+			return nil
+		}
+		assert.Equal(t, dummyPointers[executeCustomizedFunctionFuncCalled-2], fmt.Sprintf("%v", reflect.ValueOf(customFunc)))
+		if executeCustomizedFunctionFuncCalled == 3 {
+			return dummyError
+		}
+		return nil
+	}
+
+	// SUT + act
+	var err = executePreActions(
+		dummySessionObject,
+		dummyHooks,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestExecutePreActions_Success(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t}
+	var dummyHooks, _ = createDummyHooks(2)
+
+	// mock
+	createMock(t)
+
+	// expect
+	executeCustomizedFunctionFuncExpected = 3
+	executeCustomizedFunctionFunc = func(session sessionModel.Session, spanName string, customFunc func(sessionModel.Session) error) error {
+		executeCustomizedFunctionFuncCalled++
+		return nil
+	}
+
+	// SUT + act
+	var err = executePreActions(
+		dummySessionObject,
+		dummyHooks,
+	)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestExecutePostActions_RouteErrors(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t}
+	var dummyHooks, dummyPointers = createDummyHooks(2)
+	var dummyErrors = []error{
+		nil,
+		errors.New("some error 1"),
+		errors.New("some error 2"),
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	executeCustomizedFunctionFuncExpected = 3
+	executeCustomizedFunctionFunc = func(session sessionModel.Session, spanName string, customFunc func(sessionModel.Session) error) error {
+		executeCustomizedFunctionFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, postActionSpanName, spanName)
+		if executeCustomizedFunctionFuncCalled == 3 {
+			assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(customization.PostActionFunc)), fmt.Sprintf("%v", reflect.ValueOf(customFunc)))
+		} else {
+			assert.Equal(t, dummyPointers[executeCustomizedFunctionFuncCalled-1], fmt.Sprintf("%v", reflect.ValueOf(customFunc)))
+		}
+		return dummyErrors[executeCustomizedFunctionFuncCalled-1]
+	}
+
+	// SUT + act
+	var err = executePostActions(
+		dummySessionObject,
+		dummyHooks,
+	)
+
+	// assert
+	assert.Equal(t, dummyErrors[1], err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestExecutePostActions_GlobalError(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t}
+	var dummyHooks, _ = createDummyHooks(1)
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	executeCustomizedFunctionFuncExpected = 2
+	executeCustomizedFunctionFunc = func(session sessionModel.Session, spanName string, customFunc func(sessionModel.Session) error) error {
+		executeCustomizedFunctionFuncCalled++
+		if executeCustomizedFunctionFuncCalled == 2 {
+			return dummyError
+		}
+		return nil
+	}
+
+	// SUT + act
+	var err = executePostActions(
+		dummySessionObject,
+		dummyHooks,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
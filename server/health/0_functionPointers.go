@@ -0,0 +1,28 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
+)
+
+// func pointers for injection / testing: health.go
+var (
+	fmtErrorf                  = fmt.Errorf
+	contextWithTimeout         = context.WithTimeout
+	contextBackground          = context.Background
+	timeSince                  = time.Since
+	timeutilGetTimeNowUTC      = timeutil.GetTimeNowUTC
+	jsonutilMarshalIgnoreError = jsonutil.MarshalIgnoreError
+	getHealthChecksFunc        = getHealthChecks
+	getCheckTimeoutFunc        = getCheckTimeout
+	executeCheckFunc           = executeCheck
+	runCheckFunc               = runCheck
+	runChecksFunc              = runChecks
+	createReportFunc           = createReport
+	writeReportFunc            = writeReport
+	isReadyFunc                = IsReady
+)
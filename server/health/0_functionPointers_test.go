@@ -0,0 +1,218 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/config"
+	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
+)
+
+var (
+	fmtErrorfExpected                  int
+	fmtErrorfCalled                    int
+	contextWithTimeoutExpected         int
+	contextWithTimeoutCalled           int
+	contextBackgroundExpected          int
+	contextBackgroundCalled            int
+	timeSinceExpected                  int
+	timeSinceCalled                    int
+	timeutilGetTimeNowUTCExpected      int
+	timeutilGetTimeNowUTCCalled        int
+	jsonutilMarshalIgnoreErrorExpected int
+	jsonutilMarshalIgnoreErrorCalled   int
+	configAppNameExpected              int
+	configAppNameCalled                int
+	configAppVersionExpected           int
+	configAppVersionCalled             int
+	getHealthChecksFuncExpected        int
+	getHealthChecksFuncCalled          int
+	getCheckTimeoutFuncExpected        int
+	getCheckTimeoutFuncCalled          int
+	executeCheckFuncExpected           int
+	executeCheckFuncCalled             int
+	runCheckFuncExpected               int
+	runCheckFuncCalled                 int
+	runChecksFuncExpected              int
+	runChecksFuncCalled                int
+	createReportFuncExpected           int
+	createReportFuncCalled             int
+	writeReportFuncExpected            int
+	writeReportFuncCalled              int
+	isReadyFuncExpected                int
+	isReadyFuncCalled                  int
+)
+
+func createMock(t *testing.T) {
+	fmtErrorfExpected = 0
+	fmtErrorfCalled = 0
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		return nil
+	}
+	contextWithTimeoutExpected = 0
+	contextWithTimeoutCalled = 0
+	contextWithTimeout = func(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+		contextWithTimeoutCalled++
+		return nil, nil
+	}
+	contextBackgroundExpected = 0
+	contextBackgroundCalled = 0
+	contextBackground = func() context.Context {
+		contextBackgroundCalled++
+		return nil
+	}
+	timeSinceExpected = 0
+	timeSinceCalled = 0
+	timeSince = func(t time.Time) time.Duration {
+		timeSinceCalled++
+		return 0
+	}
+	timeutilGetTimeNowUTCExpected = 0
+	timeutilGetTimeNowUTCCalled = 0
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Time{}
+	}
+	jsonutilMarshalIgnoreErrorExpected = 0
+	jsonutilMarshalIgnoreErrorCalled = 0
+	jsonutilMarshalIgnoreError = func(v interface{}) string {
+		jsonutilMarshalIgnoreErrorCalled++
+		return ""
+	}
+	configAppNameExpected = 0
+	configAppNameCalled = 0
+	config.AppName = func() string {
+		configAppNameCalled++
+		return ""
+	}
+	configAppVersionExpected = 0
+	configAppVersionCalled = 0
+	config.AppVersion = func() string {
+		configAppVersionCalled++
+		return ""
+	}
+	getHealthChecksFuncExpected = 0
+	getHealthChecksFuncCalled = 0
+	getHealthChecksFunc = func() []model.HealthCheck {
+		getHealthChecksFuncCalled++
+		return nil
+	}
+	getCheckTimeoutFuncExpected = 0
+	getCheckTimeoutFuncCalled = 0
+	getCheckTimeoutFunc = func(check model.HealthCheck) time.Duration {
+		getCheckTimeoutFuncCalled++
+		return 0
+	}
+	executeCheckFuncExpected = 0
+	executeCheckFuncCalled = 0
+	executeCheckFunc = func(checkContext context.Context, checkFunc model.HealthCheckFunc) error {
+		executeCheckFuncCalled++
+		return nil
+	}
+	runCheckFuncExpected = 0
+	runCheckFuncCalled = 0
+	runCheckFunc = func(check model.HealthCheck) checkResult {
+		runCheckFuncCalled++
+		return checkResult{}
+	}
+	runChecksFuncExpected = 0
+	runChecksFuncCalled = 0
+	runChecksFunc = func(checks []model.HealthCheck) []checkResult {
+		runChecksFuncCalled++
+		return nil
+	}
+	createReportFuncExpected = 0
+	createReportFuncCalled = 0
+	createReportFunc = func(status string, checks []checkResult) healthReport {
+		createReportFuncCalled++
+		return healthReport{}
+	}
+	writeReportFuncExpected = 0
+	writeReportFuncCalled = 0
+	writeReportFunc = func(responseWriter http.ResponseWriter, statusCode int, report healthReport) {
+		writeReportFuncCalled++
+	}
+	isReadyFuncExpected = 0
+	isReadyFuncCalled = 0
+	isReadyFunc = func() bool {
+		isReadyFuncCalled++
+		return false
+	}
+}
+
+func verifyAll(t *testing.T) {
+	fmtErrorf = fmt.Errorf
+	assert.Equal(t, fmtErrorfExpected, fmtErrorfCalled, "Unexpected number of calls to fmtErrorf")
+	contextWithTimeout = context.WithTimeout
+	assert.Equal(t, contextWithTimeoutExpected, contextWithTimeoutCalled, "Unexpected number of calls to contextWithTimeout")
+	contextBackground = context.Background
+	assert.Equal(t, contextBackgroundExpected, contextBackgroundCalled, "Unexpected number of calls to contextBackground")
+	timeSince = time.Since
+	assert.Equal(t, timeSinceExpected, timeSinceCalled, "Unexpected number of calls to timeSince")
+	timeutilGetTimeNowUTC = timeutil.GetTimeNowUTC
+	assert.Equal(t, timeutilGetTimeNowUTCExpected, timeutilGetTimeNowUTCCalled, "Unexpected number of calls to timeutilGetTimeNowUTC")
+	jsonutilMarshalIgnoreError = jsonutil.MarshalIgnoreError
+	assert.Equal(t, jsonutilMarshalIgnoreErrorExpected, jsonutilMarshalIgnoreErrorCalled, "Unexpected number of calls to jsonutilMarshalIgnoreError")
+	config.AppName = func() string { return "" }
+	assert.Equal(t, configAppNameExpected, configAppNameCalled, "Unexpected number of calls to configAppName")
+	config.AppVersion = func() string { return "" }
+	assert.Equal(t, configAppVersionExpected, configAppVersionCalled, "Unexpected number of calls to configAppVersion")
+	getHealthChecksFunc = getHealthChecks
+	assert.Equal(t, getHealthChecksFuncExpected, getHealthChecksFuncCalled, "Unexpected number of calls to getHealthChecksFunc")
+	getCheckTimeoutFunc = getCheckTimeout
+	assert.Equal(t, getCheckTimeoutFuncExpected, getCheckTimeoutFuncCalled, "Unexpected number of calls to getCheckTimeoutFunc")
+	executeCheckFunc = executeCheck
+	assert.Equal(t, executeCheckFuncExpected, executeCheckFuncCalled, "Unexpected number of calls to executeCheckFunc")
+	runCheckFunc = runCheck
+	assert.Equal(t, runCheckFuncExpected, runCheckFuncCalled, "Unexpected number of calls to runCheckFunc")
+	runChecksFunc = runChecks
+	assert.Equal(t, runChecksFuncExpected, runChecksFuncCalled, "Unexpected number of calls to runChecksFunc")
+	createReportFunc = createReport
+	assert.Equal(t, createReportFuncExpected, createReportFuncCalled, "Unexpected number of calls to createReportFunc")
+	writeReportFunc = writeReport
+	assert.Equal(t, writeReportFuncExpected, writeReportFuncCalled, "Unexpected number of calls to writeReportFunc")
+	isReadyFunc = IsReady
+	assert.Equal(t, isReadyFuncExpected, isReadyFuncCalled, "Unexpected number of calls to isReadyFunc")
+
+	SetReadiness(false)
+}
+
+// mock structs
+type dummyResponseWriter struct {
+	t               *testing.T
+	expectedHeader  *http.Header
+	expectedCode    *int
+	expectedContent *[]byte
+}
+
+func (drw *dummyResponseWriter) Header() http.Header {
+	if drw.expectedHeader == nil {
+		assert.Fail(drw.t, "Unexpected number of calls to Header")
+		return nil
+	}
+	return *drw.expectedHeader
+}
+
+func (drw *dummyResponseWriter) WriteHeader(statusCode int) {
+	if drw.expectedCode == nil {
+		assert.Fail(drw.t, "Unexpected number of calls to WriteHeader")
+	} else {
+		assert.Equal(drw.t, *drw.expectedCode, statusCode)
+	}
+}
+
+func (drw *dummyResponseWriter) Write(bytes []byte) (int, error) {
+	if drw.expectedContent == nil {
+		assert.Fail(drw.t, "Unexpected number of calls to Write")
+	} else {
+		assert.Equal(drw.t, *drw.expectedContent, bytes)
+	}
+	return 0, nil
+}
@@ -0,0 +1,211 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/config"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/response"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+// These are the endpoint names and paths of the built-in health endpoints, which could be used in listener endpoints
+const (
+	LiveEndpoint  = "HealthLive"
+	LivePath      = "/health/live"
+	ReadyEndpoint = "HealthReady"
+	ReadyPath     = "/health/ready"
+)
+
+const (
+	statusUp            = "UP"
+	statusDown          = "DOWN"
+	defaultCheckTimeout = 5 * time.Second
+)
+
+type checkResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+type healthReport struct {
+	Status     string        `json:"status"`
+	AppName    string        `json:"appName"`
+	AppVersion string        `json:"appVersion"`
+	Checks     []checkResult `json:"checks,omitempty"`
+}
+
+var (
+	readiness int32
+)
+
+func getHealthChecks() []model.HealthCheck {
+	if customization.HealthChecks == nil {
+		return nil
+	}
+	return customization.HealthChecks()
+}
+
+func getCheckTimeout(check model.HealthCheck) time.Duration {
+	if check.Timeout <= 0 {
+		return defaultCheckTimeout
+	}
+	return check.Timeout
+}
+
+func executeCheck(
+	checkContext context.Context,
+	checkFunc model.HealthCheckFunc,
+) error {
+	if checkFunc == nil {
+		return fmtErrorf("Health check function is not configured")
+	}
+	var checkErrors = make(chan error, 1)
+	go func() {
+		defer func() {
+			var recoverResult = recover()
+			if recoverResult != nil {
+				checkErrors <- fmtErrorf("Health check panicked: %v", recoverResult)
+			}
+		}()
+		checkErrors <- checkFunc(checkContext)
+	}()
+	select {
+	case checkError := <-checkErrors:
+		return checkError
+	case <-checkContext.Done():
+		return checkContext.Err()
+	}
+}
+
+func runCheck(check model.HealthCheck) checkResult {
+	var startTime = timeutilGetTimeNowUTC()
+	var checkContext, cancelCallback = contextWithTimeout(
+		contextBackground(),
+		getCheckTimeoutFunc(check),
+	)
+	defer cancelCallback()
+	var checkError = executeCheckFunc(
+		checkContext,
+		check.CheckFunc,
+	)
+	var result = checkResult{
+		Name:     check.Name,
+		Status:   statusUp,
+		Duration: timeSince(startTime).String(),
+	}
+	if checkError != nil {
+		result.Status = statusDown
+		result.Error = checkError.Error()
+	}
+	return result
+}
+
+func runChecks(checks []model.HealthCheck) []checkResult {
+	var results = make([]checkResult, len(checks))
+	var waitGroup sync.WaitGroup
+	for index, check := range checks {
+		waitGroup.Add(1)
+		go func(index int, check model.HealthCheck) {
+			defer waitGroup.Done()
+			results[index] = runCheckFunc(check)
+		}(index, check)
+	}
+	waitGroup.Wait()
+	return results
+}
+
+func createReport(
+	status string,
+	checks []checkResult,
+) healthReport {
+	return healthReport{
+		Status:     status,
+		AppName:    config.AppName(),
+		AppVersion: config.AppVersion(),
+		Checks:     checks,
+	}
+}
+
+func writeReport(
+	responseWriter http.ResponseWriter,
+	statusCode int,
+	report healthReport,
+) {
+	responseWriter.Header().Set("Content-Type", response.ContentTypeJSON)
+	responseWriter.WriteHeader(statusCode)
+	responseWriter.Write([]byte(jsonutilMarshalIgnoreError(report)))
+}
+
+// SetReadiness sets whether the application is ready to serve traffic as reported by the readiness endpoint
+func SetReadiness(ready bool) {
+	var value int32
+	if ready {
+		value = 1
+	}
+	atomic.StoreInt32(&readiness, value)
+}
+
+// IsReady returns whether the application is ready to serve traffic, which is false before hosting starts or once graceful shutdown begins
+func IsReady() bool {
+	return atomic.LoadInt32(&readiness) == 1
+}
+
+// LiveHandler reports the application as alive as long as it is able to respond
+func LiveHandler(
+	responseWriter http.ResponseWriter,
+	httpRequest *http.Request,
+) {
+	writeReportFunc(
+		responseWriter,
+		http.StatusOK,
+		createReportFunc(
+			statusUp,
+			nil,
+		),
+	)
+}
+
+// ReadyHandler reports the application as ready only if it is not shutting down and all customized health checks pass
+func ReadyHandler(
+	responseWriter http.ResponseWriter,
+	httpRequest *http.Request,
+) {
+	if !isReadyFunc() {
+		writeReportFunc(
+			responseWriter,
+			http.StatusServiceUnavailable,
+			createReportFunc(
+				statusDown,
+				nil,
+			),
+		)
+		return
+	}
+	var results = runChecksFunc(
+		getHealthChecksFunc(),
+	)
+	var status = statusUp
+	var statusCode = http.StatusOK
+	for _, result := range results {
+		if result.Status != statusUp {
+			status = statusDown
+			statusCode = http.StatusServiceUnavailable
+			break
+		}
+	}
+	writeReportFunc(
+		responseWriter,
+		statusCode,
+		createReportFunc(
+			status,
+			results,
+		),
+	)
+}
@@ -0,0 +1,691 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/config"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/response"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+func TestGetHealthChecks_NoCustomization(t *testing.T) {
+	// stub
+	customization.HealthChecks = nil
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getHealthChecks()
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetHealthChecks_WithCustomization(t *testing.T) {
+	// arrange
+	var dummyHealthChecks = []model.HealthCheck{
+		{Name: "some check 1"},
+		{Name: "some check 2"},
+	}
+
+	// stub
+	customization.HealthChecks = func() []model.HealthCheck {
+		return dummyHealthChecks
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getHealthChecks()
+
+	// assert
+	assert.Equal(t, dummyHealthChecks, result)
+
+	// verify
+	verifyAll(t)
+	customization.HealthChecks = nil
+}
+
+func TestGetCheckTimeout_Default(t *testing.T) {
+	// arrange
+	var dummyHealthCheck = model.HealthCheck{
+		Timeout: -time.Duration(rand.Intn(100)),
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getCheckTimeout(dummyHealthCheck)
+
+	// assert
+	assert.Equal(t, defaultCheckTimeout, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCheckTimeout_Customized(t *testing.T) {
+	// arrange
+	var dummyTimeout = time.Duration(rand.Intn(100)+1) * time.Second
+	var dummyHealthCheck = model.HealthCheck{
+		Timeout: dummyTimeout,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getCheckTimeout(dummyHealthCheck)
+
+	// assert
+	assert.Equal(t, dummyTimeout, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestExecuteCheck_NilCheckFunc(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Health check function is not configured", format)
+		assert.Empty(t, a)
+		return dummyError
+	}
+
+	// SUT + act
+	var err = executeCheck(
+		context.Background(),
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestExecuteCheck_CheckError(t *testing.T) {
+	// arrange
+	var dummyContext = context.Background()
+	var dummyCheckError = errors.New("some check error")
+	var dummyCheckFuncExpected = 1
+	var dummyCheckFuncCalled = 0
+	var dummyCheckFunc = func(ctx context.Context) error {
+		dummyCheckFuncCalled++
+		assert.Equal(t, dummyContext, ctx)
+		return dummyCheckError
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err = executeCheck(
+		dummyContext,
+		dummyCheckFunc,
+	)
+
+	// assert
+	assert.Equal(t, dummyCheckError, err)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyCheckFuncExpected, dummyCheckFuncCalled, "Unexpected number of calls to dummyCheckFunc")
+}
+
+func TestExecuteCheck_CheckPanic(t *testing.T) {
+	// arrange
+	var dummyPanic = "some panic"
+	var dummyError = errors.New("some error")
+	var dummyCheckFunc = func(ctx context.Context) error {
+		panic(dummyPanic)
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Health check panicked: %v", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyPanic, a[0])
+		return dummyError
+	}
+
+	// SUT + act
+	var err = executeCheck(
+		context.Background(),
+		dummyCheckFunc,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestExecuteCheck_Timeout(t *testing.T) {
+	// arrange
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyRelease = make(chan struct{})
+	var dummyCheckFunc = func(ctx context.Context) error {
+		<-dummyRelease
+		return nil
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	dummyCancel()
+
+	// act
+	var err = executeCheck(
+		dummyContext,
+		dummyCheckFunc,
+	)
+
+	// assert
+	assert.Equal(t, context.Canceled, err)
+
+	// verify
+	verifyAll(t)
+	close(dummyRelease)
+}
+
+func TestExecuteCheck_Success(t *testing.T) {
+	// arrange
+	var dummyCheckFunc = func(ctx context.Context) error {
+		return nil
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var err = executeCheck(
+		context.Background(),
+		dummyCheckFunc,
+	)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRunCheck_Failure(t *testing.T) {
+	// arrange
+	var dummyHealthCheck = model.HealthCheck{
+		Name: "some name",
+		CheckFunc: func(ctx context.Context) error {
+			return nil
+		},
+	}
+	var dummyStartTime = time.Now()
+	var dummyBackgroundContext = context.Background()
+	var dummyCheckContext = context.TODO()
+	var dummyTimeout = time.Duration(rand.Intn(100)) * time.Second
+	var dummyCheckError = errors.New("some check error")
+	var dummyDuration = time.Duration(rand.Intn(1000)) * time.Millisecond
+	var cancelCallbackExpected = 1
+	var cancelCallbackCalled = 0
+	var cancelCallback = func() {
+		cancelCallbackCalled++
+	}
+	var expectedResult = checkResult{
+		Name:     dummyHealthCheck.Name,
+		Status:   statusDown,
+		Error:    dummyCheckError.Error(),
+		Duration: dummyDuration.String(),
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyStartTime
+	}
+	contextBackgroundExpected = 1
+	contextBackground = func() context.Context {
+		contextBackgroundCalled++
+		return dummyBackgroundContext
+	}
+	getCheckTimeoutFuncExpected = 1
+	getCheckTimeoutFunc = func(check model.HealthCheck) time.Duration {
+		getCheckTimeoutFuncCalled++
+		assert.Equal(t, dummyHealthCheck.Name, check.Name)
+		return dummyTimeout
+	}
+	contextWithTimeoutExpected = 1
+	contextWithTimeout = func(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+		contextWithTimeoutCalled++
+		assert.Equal(t, dummyBackgroundContext, parent)
+		assert.Equal(t, dummyTimeout, timeout)
+		return dummyCheckContext, cancelCallback
+	}
+	executeCheckFuncExpected = 1
+	executeCheckFunc = func(checkContext context.Context, checkFunc model.HealthCheckFunc) error {
+		executeCheckFuncCalled++
+		assert.Equal(t, dummyCheckContext, checkContext)
+		assert.NotNil(t, checkFunc)
+		return dummyCheckError
+	}
+	timeSinceExpected = 1
+	timeSince = func(t time.Time) time.Duration {
+		timeSinceCalled++
+		return dummyDuration
+	}
+
+	// SUT + act
+	var result = runCheck(dummyHealthCheck)
+
+	// assert
+	assert.Equal(t, expectedResult, result)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, cancelCallbackExpected, cancelCallbackCalled, "Unexpected number of calls to cancelCallback")
+}
+
+func TestRunCheck_Success(t *testing.T) {
+	// arrange
+	var dummyHealthCheck = model.HealthCheck{
+		Name: "some name",
+	}
+	var dummyDuration = time.Duration(rand.Intn(1000)) * time.Millisecond
+	var expectedResult = checkResult{
+		Name:     dummyHealthCheck.Name,
+		Status:   statusUp,
+		Duration: dummyDuration.String(),
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	contextBackgroundExpected = 1
+	getCheckTimeoutFuncExpected = 1
+	contextWithTimeoutExpected = 1
+	contextWithTimeout = func(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+		contextWithTimeoutCalled++
+		return nil, func() {}
+	}
+	executeCheckFuncExpected = 1
+	timeSinceExpected = 1
+	timeSince = func(t time.Time) time.Duration {
+		timeSinceCalled++
+		return dummyDuration
+	}
+
+	// SUT + act
+	var result = runCheck(dummyHealthCheck)
+
+	// assert
+	assert.Equal(t, expectedResult, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRunChecks(t *testing.T) {
+	// arrange
+	var dummyHealthChecks = []model.HealthCheck{
+		{Name: "some check 1"},
+		{Name: "some check 2"},
+		{Name: "some check 3"},
+	}
+	var expectedResults = []checkResult{
+		{Name: "some check 1", Status: statusUp},
+		{Name: "some check 2", Status: statusDown},
+		{Name: "some check 3", Status: statusUp},
+	}
+	var mockLock sync.Mutex
+
+	// mock
+	createMock(t)
+
+	// expect
+	runCheckFuncExpected = 3
+	runCheckFunc = func(check model.HealthCheck) checkResult {
+		mockLock.Lock()
+		runCheckFuncCalled++
+		mockLock.Unlock()
+		for _, expectedResult := range expectedResults {
+			if expectedResult.Name == check.Name {
+				return expectedResult
+			}
+		}
+		return checkResult{}
+	}
+
+	// SUT + act
+	var result = runChecks(dummyHealthChecks)
+
+	// assert
+	assert.Equal(t, expectedResults, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCreateReport(t *testing.T) {
+	// arrange
+	var dummyStatus = "some status"
+	var dummyChecks = []checkResult{
+		{Name: "some check"},
+	}
+	var dummyAppName = "some app name"
+	var dummyAppVersion = "some app version"
+	var expectedReport = healthReport{
+		Status:     dummyStatus,
+		AppName:    dummyAppName,
+		AppVersion: dummyAppVersion,
+		Checks:     dummyChecks,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	configAppNameExpected = 1
+	config.AppName = func() string {
+		configAppNameCalled++
+		return dummyAppName
+	}
+	configAppVersionExpected = 1
+	config.AppVersion = func() string {
+		configAppVersionCalled++
+		return dummyAppVersion
+	}
+
+	// SUT + act
+	var result = createReport(
+		dummyStatus,
+		dummyChecks,
+	)
+
+	// assert
+	assert.Equal(t, expectedReport, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWriteReport(t *testing.T) {
+	// arrange
+	var dummyHeader = make(http.Header)
+	var dummyStatusCode = rand.Int()
+	var dummyReport = healthReport{
+		Status: "some status",
+	}
+	var dummyResponseMessage = "some response message"
+	var dummyResponseBytes = []byte(dummyResponseMessage)
+	var dummyResponseWriter = &dummyResponseWriter{
+		t,
+		&dummyHeader,
+		&dummyStatusCode,
+		&dummyResponseBytes,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	jsonutilMarshalIgnoreErrorExpected = 1
+	jsonutilMarshalIgnoreError = func(v interface{}) string {
+		jsonutilMarshalIgnoreErrorCalled++
+		assert.Equal(t, dummyReport, v)
+		return dummyResponseMessage
+	}
+
+	// SUT + act
+	writeReport(
+		dummyResponseWriter,
+		dummyStatusCode,
+		dummyReport,
+	)
+
+	// assert
+	assert.Equal(t, response.ContentTypeJSON, dummyHeader.Get("Content-Type"))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetReadiness(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act + assert
+	assert.False(t, IsReady())
+	SetReadiness(true)
+	assert.True(t, IsReady())
+	SetReadiness(false)
+	assert.False(t, IsReady())
+
+	// verify
+	verifyAll(t)
+}
+
+func TestLiveHandler(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{t: t}
+	var dummyHTTPRequest = &http.Request{}
+	var dummyReport = healthReport{
+		Status: "some status",
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	createReportFuncExpected = 1
+	createReportFunc = func(status string, checks []checkResult) healthReport {
+		createReportFuncCalled++
+		assert.Equal(t, statusUp, status)
+		assert.Nil(t, checks)
+		return dummyReport
+	}
+	writeReportFuncExpected = 1
+	writeReportFunc = func(responseWriter http.ResponseWriter, statusCode int, report healthReport) {
+		writeReportFuncCalled++
+		assert.Equal(t, dummyResponseWriter, responseWriter)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, dummyReport, report)
+	}
+
+	// SUT + act
+	LiveHandler(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestReadyHandler_NotReady(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{t: t}
+	var dummyHTTPRequest = &http.Request{}
+	var dummyReport = healthReport{
+		Status: "some status",
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isReadyFuncExpected = 1
+	isReadyFunc = func() bool {
+		isReadyFuncCalled++
+		return false
+	}
+	createReportFuncExpected = 1
+	createReportFunc = func(status string, checks []checkResult) healthReport {
+		createReportFuncCalled++
+		assert.Equal(t, statusDown, status)
+		assert.Nil(t, checks)
+		return dummyReport
+	}
+	writeReportFuncExpected = 1
+	writeReportFunc = func(responseWriter http.ResponseWriter, statusCode int, report healthReport) {
+		writeReportFuncCalled++
+		assert.Equal(t, dummyResponseWriter, responseWriter)
+		assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+		assert.Equal(t, dummyReport, report)
+	}
+
+	// SUT + act
+	ReadyHandler(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestReadyHandler_CheckFailed(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{t: t}
+	var dummyHTTPRequest = &http.Request{}
+	var dummyHealthChecks = []model.HealthCheck{
+		{Name: "some check 1"},
+		{Name: "some check 2"},
+	}
+	var dummyResults = []checkResult{
+		{Name: "some check 1", Status: statusUp},
+		{Name: "some check 2", Status: statusDown},
+	}
+	var dummyReport = healthReport{
+		Status: "some status",
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isReadyFuncExpected = 1
+	isReadyFunc = func() bool {
+		isReadyFuncCalled++
+		return true
+	}
+	getHealthChecksFuncExpected = 1
+	getHealthChecksFunc = func() []model.HealthCheck {
+		getHealthChecksFuncCalled++
+		return dummyHealthChecks
+	}
+	runChecksFuncExpected = 1
+	runChecksFunc = func(checks []model.HealthCheck) []checkResult {
+		runChecksFuncCalled++
+		assert.Equal(t, dummyHealthChecks, checks)
+		return dummyResults
+	}
+	createReportFuncExpected = 1
+	createReportFunc = func(status string, checks []checkResult) healthReport {
+		createReportFuncCalled++
+		assert.Equal(t, statusDown, status)
+		assert.Equal(t, dummyResults, checks)
+		return dummyReport
+	}
+	writeReportFuncExpected = 1
+	writeReportFunc = func(responseWriter http.ResponseWriter, statusCode int, report healthReport) {
+		writeReportFuncCalled++
+		assert.Equal(t, dummyResponseWriter, responseWriter)
+		assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+		assert.Equal(t, dummyReport, report)
+	}
+
+	// SUT + act
+	ReadyHandler(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestReadyHandler_AllPassed(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = &dummyResponseWriter{t: t}
+	var dummyHTTPRequest = &http.Request{}
+	var dummyResults = []checkResult{
+		{Name: "some check 1", Status: statusUp},
+		{Name: "some check 2", Status: statusUp},
+	}
+	var dummyReport = healthReport{
+		Status: "some status",
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isReadyFuncExpected = 1
+	isReadyFunc = func() bool {
+		isReadyFuncCalled++
+		return true
+	}
+	getHealthChecksFuncExpected = 1
+	runChecksFuncExpected = 1
+	runChecksFunc = func(checks []model.HealthCheck) []checkResult {
+		runChecksFuncCalled++
+		return dummyResults
+	}
+	createReportFuncExpected = 1
+	createReportFunc = func(status string, checks []checkResult) healthReport {
+		createReportFuncCalled++
+		assert.Equal(t, statusUp, status)
+		assert.Equal(t, dummyResults, checks)
+		return dummyReport
+	}
+	writeReportFuncExpected = 1
+	writeReportFunc = func(responseWriter http.ResponseWriter, statusCode int, report healthReport) {
+		writeReportFuncCalled++
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, dummyReport, report)
+	}
+
+	// SUT + act
+	ReadyHandler(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// verify
+	verifyAll(t)
+}
@@ -11,3 +11,8 @@ type ActionFunc func(
 	responseObject interface{},
 	responseError error,
 )
+
+// HookFunc defines the hook function to be called before or after route actions, e.g. authorization, finalization, etc.
+type HookFunc func(
+	session sessionModel.Session,
+) error
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"crypto/x509"
+	"net/http"
+	"time"
+)
+
+// Principal holds the identity of an authenticated client, together with the scopes and roles granted to it
+type Principal struct {
+	// Subject identifies the client, e.g. the JWT subject, the API key owner or the client certificate common name
+	Subject string
+	// Method is the name of the authentication method that authenticated the client, e.g. "jwt", "apikey" or "mtls"
+	Method string
+	// Scopes lists the scopes granted to the client
+	Scopes []string
+	// Roles lists the roles granted to the client
+	Roles []string
+	// Claims holds any additional identity attributes, e.g. the JWT claims or the client certificate SANs
+	Claims map[string]interface{}
+}
+
+// Authenticator authenticates the clients of incoming HTTP requests
+type Authenticator interface {
+	// Authenticate returns the principal of the client of the given request; returns nil principal with no error if the request carries no credential recognised by this authenticator, or an error if the credential is present but invalid
+	Authenticate(httpRequest *http.Request) (*Principal, error)
+}
+
+// AuthRequirement holds the authorization requirement of a route; routes without requirement allow anonymous access
+type AuthRequirement struct {
+	// Scopes lists the scopes that must all be granted to the principal
+	Scopes []string
+	// Roles lists the roles of which at least one must be granted to the principal; any role is accepted if empty
+	Roles []string
+}
+
+// JWTSettings holds the verification settings of the bearer JWT authenticator
+type JWTSettings struct {
+	// HMACSecret is the shared secret for verifying HS256, HS384 and HS512 signed tokens; HMAC signed tokens are rejected if empty
+	HMACSecret []byte
+	// JWKSFile is the path to a local JWKS file holding the RSA public keys for verifying RS256, RS384 and RS512 signed tokens; RSA signed tokens are rejected if empty
+	JWKSFile string
+	// Issuer is the expected "iss" claim; not checked if empty
+	Issuer string
+	// Audience is the expected "aud" claim; not checked if empty
+	Audience string
+	// Leeway is the clock skew tolerated when checking the "exp" and "nbf" claims
+	Leeway time.Duration
+}
+
+// APIKeyLookupFunc looks up the principal owning the given API key; returns nil principal if the API key is unknown
+type APIKeyLookupFunc func(
+	apiKey string,
+) (*Principal, error)
+
+// ClientCertGrantFunc determines the scopes and roles granted to the client presenting the given certificate
+type ClientCertGrantFunc func(
+	certificate *x509.Certificate,
+) (scopes []string, roles []string)
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+)
+
+// CORS holds the cross-origin resource sharing policy of routes
+type CORS struct {
+	// AllowedOrigins lists the origins allowed to access the routes, e.g. "https://app.example.com"; "*" allows any origin, while a single "*" within an origin matches any subdomain, e.g. "https://*.example.com"
+	AllowedOrigins []string
+	// AllowedMethods lists the methods allowed by preflight requests; the method of the requested route is allowed if empty
+	AllowedMethods []string
+	// AllowedHeaders lists the request headers allowed by preflight requests; "*" allows any header
+	AllowedHeaders []string
+	// ExposedHeaders lists the response headers exposed to client scripts
+	ExposedHeaders []string
+	// AllowCredentials allows clients to send credentials such as cookies and authorization headers, echoing the requesting origin; ignored if AllowedOrigins contains "*", as credentials are never granted to any origin
+	AllowCredentials bool
+	// MaxAge is the duration for which preflight responses could be cached by clients; not sent if not positive
+	MaxAge time.Duration
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+// HealthCheckFunc checks the health of a dependency, returning error if unhealthy; the given context is cancelled upon the check timeout
+type HealthCheckFunc func(ctx context.Context) error
+
+// HealthCheck holds the registration information of a named readiness check
+type HealthCheck struct {
+	Name      string
+	Timeout   time.Duration
+	CheckFunc HealthCheckFunc
+}
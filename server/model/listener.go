@@ -0,0 +1,15 @@
+package model
+
+// Listener holds the hosting information of a server listener; multiple listeners are hosted simultaneously and shut down together
+type Listener struct {
+	// Name is used to identify the listener in logs
+	Name string
+	// Port is the port the listener listens on
+	Port string
+	// ServeHTTPS determines whether the listener serves HTTPS with the configured server certificate
+	ServeHTTPS bool
+	// ValidateClientCert determines whether the listener requires and validates client certificates; only effective when ServeHTTPS is set
+	ValidateClientCert bool
+	// Endpoints limits the routes and statics hosted by the listener to those with matching endpoint or static names; all are hosted if empty
+	Endpoints []string
+}
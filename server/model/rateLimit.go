@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+// RateLimitKeyFunc determines the client key of the given session for rate limiting, e.g. client IP or client certificate subject; sessions with empty key are not rate limited
+type RateLimitKeyFunc func(
+	session sessionModel.Session,
+) string
+
+// RateLimit holds the token-bucket rate limiting settings applied per client key
+type RateLimit struct {
+	// Rate is the number of tokens replenished per second; rate limiting is disabled if not positive
+	Rate float64
+	// Burst is the capacity of the token bucket, i.e. the maximum number of requests allowed at once; at least 1 token is allowed
+	Burst int
+	// KeyFunc determines the client key of each session; client IP is used if not set
+	KeyFunc RateLimitKeyFunc
+}
+
+// RateLimitStore is the storage of token buckets for rate limiting; implementations must be safe for concurrent use
+type RateLimitStore interface {
+	// Take takes a token from the bucket identified by the given key, with given rate (tokens per second) and burst (bucket capacity), and returns whether the token is granted, together with the wait time until the next token becomes available if not
+	Take(key string, rate float64, burst int) (granted bool, retryAfter time.Duration)
+	// Refund returns a previously granted token to the bucket identified by the given key, with given rate (tokens per second) and burst (bucket capacity), never exceeding the bucket capacity
+	Refund(key string, rate float64, burst int)
+}
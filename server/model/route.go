@@ -2,10 +2,19 @@ package model
 
 // Route holds the registration information of a dynamic route hosting
 type Route struct {
-	Endpoint   string
-	Method     string
-	Path       string
-	Parameters map[string]ParameterType
-	Queries    map[string]ParameterType
-	ActionFunc ActionFunc
+	Endpoint        string
+	Method          string
+	Path            string
+	Parameters      map[string]ParameterType
+	Queries         map[string]ParameterType
+	ActionFunc      ActionFunc
+	PreActionFuncs  []HookFunc
+	PostActionFuncs []HookFunc
+	RateLimit       *RateLimit
+	MaxBodyBytes    int64
+	MediaTypes      []string
+	RequestType     interface{}
+	ResponseType    interface{}
+	Auth            *AuthRequirement
+	CORS            *CORS
 }
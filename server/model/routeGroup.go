@@ -0,0 +1,17 @@
+package model
+
+// RouteGroup holds the registration information of a group of dynamic routes sharing the same path prefix, middlewares and pre/post action functions
+type RouteGroup struct {
+	// Name is used to identify the group in logs
+	Name string
+	// PathPrefix is prepended to the paths of all routes in the group, e.g. "/api/v1"
+	PathPrefix string
+	// Middlewares are applied to the routes in the group only, after the global middlewares
+	Middlewares []MiddlewareFunc
+	// PreActionFunc is executed before each route action in the group, after the global pre-action function
+	PreActionFunc HookFunc
+	// PostActionFunc is executed after each route action in the group, before the global post-action function
+	PostActionFunc HookFunc
+	// Routes are the routes in the group, with paths relative to the path prefix
+	Routes []Route
+}
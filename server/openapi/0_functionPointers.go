@@ -0,0 +1,35 @@
+package openapi
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// func pointers for injection / testing: openapi.go
+var (
+	stringsSplitN               = strings.SplitN
+	stringsToLower              = strings.ToLower
+	sortStrings                 = sort.Strings
+	jsonMarshal                 = json.Marshal
+	getPathTemplateFunc         = getPathTemplate
+	getParameterSchemaFunc      = getParameterSchema
+	hasBindingTagsFunc          = hasBindingTags
+	hasParameterFunc            = hasParameter
+	getMediaTypesFunc           = getMediaTypes
+	getRoutePathParametersFunc  = getRoutePathParameters
+	getRouteQueryParametersFunc = getRouteQueryParameters
+	getBindingParametersFunc    = getBindingParameters
+	createOperationFunc         = createOperation
+	generateDocumentFunc        = generateDocument
+)
+
+// func pointers for injection / testing: schema.go
+var (
+	stringsSplit         = strings.Split
+	stringsTrimSpace     = strings.TrimSpace
+	stringsNewReplacer   = strings.NewReplacer
+	getPropertyNameFunc  = getPropertyName
+	isRequiredFunc       = isRequired
+	getComponentNameFunc = getComponentName
+)
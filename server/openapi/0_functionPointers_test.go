@@ -0,0 +1,264 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/config"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+var (
+	stringsSplitNExpected               int
+	stringsSplitNCalled                 int
+	stringsToLowerExpected              int
+	stringsToLowerCalled                int
+	sortStringsExpected                 int
+	sortStringsCalled                   int
+	jsonMarshalExpected                 int
+	jsonMarshalCalled                   int
+	configAppNameExpected               int
+	configAppNameCalled                 int
+	configAppVersionExpected            int
+	configAppVersionCalled              int
+	getPathTemplateFuncExpected         int
+	getPathTemplateFuncCalled           int
+	getParameterSchemaFuncExpected      int
+	getParameterSchemaFuncCalled        int
+	hasBindingTagsFuncExpected          int
+	hasBindingTagsFuncCalled            int
+	hasParameterFuncExpected            int
+	hasParameterFuncCalled              int
+	getMediaTypesFuncExpected           int
+	getMediaTypesFuncCalled             int
+	getRoutePathParametersFuncExpected  int
+	getRoutePathParametersFuncCalled    int
+	getRouteQueryParametersFuncExpected int
+	getRouteQueryParametersFuncCalled   int
+	getBindingParametersFuncExpected    int
+	getBindingParametersFuncCalled      int
+	createOperationFuncExpected         int
+	createOperationFuncCalled           int
+	generateDocumentFuncExpected        int
+	generateDocumentFuncCalled          int
+	stringsSplitExpected                int
+	stringsSplitCalled                  int
+	stringsTrimSpaceExpected            int
+	stringsTrimSpaceCalled              int
+	stringsNewReplacerExpected          int
+	stringsNewReplacerCalled            int
+	getPropertyNameFuncExpected         int
+	getPropertyNameFuncCalled           int
+	isRequiredFuncExpected              int
+	isRequiredFuncCalled                int
+	getComponentNameFuncExpected        int
+	getComponentNameFuncCalled          int
+)
+
+func createMock(t *testing.T) {
+	stringsSplitNExpected = 0
+	stringsSplitNCalled = 0
+	stringsSplitN = func(s string, sep string, n int) []string {
+		stringsSplitNCalled++
+		return nil
+	}
+	stringsToLowerExpected = 0
+	stringsToLowerCalled = 0
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		return ""
+	}
+	sortStringsExpected = 0
+	sortStringsCalled = 0
+	sortStrings = func(x []string) {
+		sortStringsCalled++
+	}
+	jsonMarshalExpected = 0
+	jsonMarshalCalled = 0
+	jsonMarshal = func(v interface{}) ([]byte, error) {
+		jsonMarshalCalled++
+		return nil, nil
+	}
+	configAppNameExpected = 0
+	configAppNameCalled = 0
+	config.AppName = func() string {
+		configAppNameCalled++
+		return ""
+	}
+	configAppVersionExpected = 0
+	configAppVersionCalled = 0
+	config.AppVersion = func() string {
+		configAppVersionCalled++
+		return ""
+	}
+	getPathTemplateFuncExpected = 0
+	getPathTemplateFuncCalled = 0
+	getPathTemplateFunc = func(path string) (string, []string) {
+		getPathTemplateFuncCalled++
+		return "", nil
+	}
+	getParameterSchemaFuncExpected = 0
+	getParameterSchemaFuncCalled = 0
+	getParameterSchemaFunc = func(parameterType model.ParameterType) *schema {
+		getParameterSchemaFuncCalled++
+		return nil
+	}
+	hasBindingTagsFuncExpected = 0
+	hasBindingTagsFuncCalled = 0
+	hasBindingTagsFunc = func(structType reflect.Type) bool {
+		hasBindingTagsFuncCalled++
+		return false
+	}
+	hasParameterFuncExpected = 0
+	hasParameterFuncCalled = 0
+	hasParameterFunc = func(parameters []parameter, name string, location string) bool {
+		hasParameterFuncCalled++
+		return false
+	}
+	getMediaTypesFuncExpected = 0
+	getMediaTypesFuncCalled = 0
+	getMediaTypesFunc = func(mediaTypes []string, dataSchema *schema) map[string]mediaType {
+		getMediaTypesFuncCalled++
+		return nil
+	}
+	getRoutePathParametersFuncExpected = 0
+	getRoutePathParametersFuncCalled = 0
+	getRoutePathParametersFunc = func(names []string, parameterTypes map[string]model.ParameterType) []parameter {
+		getRoutePathParametersFuncCalled++
+		return nil
+	}
+	getRouteQueryParametersFuncExpected = 0
+	getRouteQueryParametersFuncCalled = 0
+	getRouteQueryParametersFunc = func(queryTypes map[string]model.ParameterType) []parameter {
+		getRouteQueryParametersFuncCalled++
+		return nil
+	}
+	getBindingParametersFuncExpected = 0
+	getBindingParametersFuncCalled = 0
+	getBindingParametersFunc = func(components *components, parameters []parameter, bindingType reflect.Type) ([]parameter, *requestBody) {
+		getBindingParametersFuncCalled++
+		return nil, nil
+	}
+	createOperationFuncExpected = 0
+	createOperationFuncCalled = 0
+	createOperationFunc = func(components *components, route model.Route, pathNames []string) *operation {
+		createOperationFuncCalled++
+		return nil
+	}
+	generateDocumentFuncExpected = 0
+	generateDocumentFuncCalled = 0
+	generateDocumentFunc = func(routes []model.Route) *document {
+		generateDocumentFuncCalled++
+		return nil
+	}
+	stringsSplitExpected = 0
+	stringsSplitCalled = 0
+	stringsSplit = func(s string, sep string) []string {
+		stringsSplitCalled++
+		return nil
+	}
+	stringsTrimSpaceExpected = 0
+	stringsTrimSpaceCalled = 0
+	stringsTrimSpace = func(s string) string {
+		stringsTrimSpaceCalled++
+		return ""
+	}
+	stringsNewReplacerExpected = 0
+	stringsNewReplacerCalled = 0
+	stringsNewReplacer = func(oldnew ...string) *strings.Replacer {
+		stringsNewReplacerCalled++
+		return nil
+	}
+	getPropertyNameFuncExpected = 0
+	getPropertyNameFuncCalled = 0
+	getPropertyNameFunc = func(field reflect.StructField) string {
+		getPropertyNameFuncCalled++
+		return ""
+	}
+	isRequiredFuncExpected = 0
+	isRequiredFuncCalled = 0
+	isRequiredFunc = func(field reflect.StructField) bool {
+		isRequiredFuncCalled++
+		return false
+	}
+	getComponentNameFuncExpected = 0
+	getComponentNameFuncCalled = 0
+	getComponentNameFunc = func(components *components, dataType reflect.Type) string {
+		getComponentNameFuncCalled++
+		return ""
+	}
+}
+
+func verifyAll(t *testing.T) {
+	stringsSplitN = strings.SplitN
+	assert.Equal(t, stringsSplitNExpected, stringsSplitNCalled, "Unexpected number of calls to stringsSplitN")
+	stringsToLower = strings.ToLower
+	assert.Equal(t, stringsToLowerExpected, stringsToLowerCalled, "Unexpected number of calls to stringsToLower")
+	sortStrings = sort.Strings
+	assert.Equal(t, sortStringsExpected, sortStringsCalled, "Unexpected number of calls to sortStrings")
+	jsonMarshal = json.Marshal
+	assert.Equal(t, jsonMarshalExpected, jsonMarshalCalled, "Unexpected number of calls to jsonMarshal")
+	config.AppName = func() string { return "" }
+	assert.Equal(t, configAppNameExpected, configAppNameCalled, "Unexpected number of calls to configAppName")
+	config.AppVersion = func() string { return "" }
+	assert.Equal(t, configAppVersionExpected, configAppVersionCalled, "Unexpected number of calls to configAppVersion")
+	getPathTemplateFunc = getPathTemplate
+	assert.Equal(t, getPathTemplateFuncExpected, getPathTemplateFuncCalled, "Unexpected number of calls to getPathTemplateFunc")
+	getParameterSchemaFunc = getParameterSchema
+	assert.Equal(t, getParameterSchemaFuncExpected, getParameterSchemaFuncCalled, "Unexpected number of calls to getParameterSchemaFunc")
+	hasBindingTagsFunc = hasBindingTags
+	assert.Equal(t, hasBindingTagsFuncExpected, hasBindingTagsFuncCalled, "Unexpected number of calls to hasBindingTagsFunc")
+	hasParameterFunc = hasParameter
+	assert.Equal(t, hasParameterFuncExpected, hasParameterFuncCalled, "Unexpected number of calls to hasParameterFunc")
+	getMediaTypesFunc = getMediaTypes
+	assert.Equal(t, getMediaTypesFuncExpected, getMediaTypesFuncCalled, "Unexpected number of calls to getMediaTypesFunc")
+	getRoutePathParametersFunc = getRoutePathParameters
+	assert.Equal(t, getRoutePathParametersFuncExpected, getRoutePathParametersFuncCalled, "Unexpected number of calls to getRoutePathParametersFunc")
+	getRouteQueryParametersFunc = getRouteQueryParameters
+	assert.Equal(t, getRouteQueryParametersFuncExpected, getRouteQueryParametersFuncCalled, "Unexpected number of calls to getRouteQueryParametersFunc")
+	getBindingParametersFunc = getBindingParameters
+	assert.Equal(t, getBindingParametersFuncExpected, getBindingParametersFuncCalled, "Unexpected number of calls to getBindingParametersFunc")
+	createOperationFunc = createOperation
+	assert.Equal(t, createOperationFuncExpected, createOperationFuncCalled, "Unexpected number of calls to createOperationFunc")
+	generateDocumentFunc = generateDocument
+	assert.Equal(t, generateDocumentFuncExpected, generateDocumentFuncCalled, "Unexpected number of calls to generateDocumentFunc")
+	stringsSplit = strings.Split
+	assert.Equal(t, stringsSplitExpected, stringsSplitCalled, "Unexpected number of calls to stringsSplit")
+	stringsTrimSpace = strings.TrimSpace
+	assert.Equal(t, stringsTrimSpaceExpected, stringsTrimSpaceCalled, "Unexpected number of calls to stringsTrimSpace")
+	stringsNewReplacer = strings.NewReplacer
+	assert.Equal(t, stringsNewReplacerExpected, stringsNewReplacerCalled, "Unexpected number of calls to stringsNewReplacer")
+	getPropertyNameFunc = getPropertyName
+	assert.Equal(t, getPropertyNameFuncExpected, getPropertyNameFuncCalled, "Unexpected number of calls to getPropertyNameFunc")
+	isRequiredFunc = isRequired
+	assert.Equal(t, isRequiredFuncExpected, isRequiredFuncCalled, "Unexpected number of calls to isRequiredFunc")
+	getComponentNameFunc = getComponentName
+	assert.Equal(t, getComponentNameFuncExpected, getComponentNameFuncCalled, "Unexpected number of calls to getComponentNameFunc")
+}
+
+type dummyResponseWriter struct {
+	header     http.Header
+	statusCode int
+	body       []byte
+}
+
+func (drw *dummyResponseWriter) Header() http.Header {
+	if drw.header == nil {
+		drw.header = http.Header{}
+	}
+	return drw.header
+}
+
+func (drw *dummyResponseWriter) WriteHeader(statusCode int) {
+	drw.statusCode = statusCode
+}
+
+func (drw *dummyResponseWriter) Write(bytes []byte) (int, error) {
+	drw.body = append(drw.body, bytes...)
+	return len(bytes), nil
+}
@@ -0,0 +1,354 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/binding"
+	"github.com/zhongjie-cai/WebServiceTemplate/config"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+// These are the constants used by the built-in OpenAPI document endpoint
+const (
+	Endpoint         = "OpenAPI"
+	openAPIVersion   = "3.0.3"
+	contentType      = "application/json; charset=utf-8"
+	defaultMediaType = "application/json"
+	bodyName         = "body"
+	pathLocation     = "path"
+	queryLocation    = "query"
+	headerLocation   = "header"
+	successStatus    = "200"
+	successMessage   = "OK"
+	variableStart    = '{'
+	variableEnd      = '}'
+	variableSplitter = ":"
+)
+
+type info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *schema `json:"schema"`
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema,omitempty"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type response struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type operation struct {
+	OperationID string              `json:"operationId"`
+	Parameters  []parameter         `json:"parameters,omitempty"`
+	RequestBody *requestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]response `json:"responses"`
+}
+
+type document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       info                             `json:"info"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components *components                      `json:"components,omitempty"`
+}
+
+// getPathTemplate strips the regular expressions off the variables of the given route path, returning the OpenAPI path template and the variable names in order
+func getPathTemplate(path string) (string, []string) {
+	var template = []byte{}
+	var names = []string{}
+	var depth = 0
+	var start = 0
+	for index := 0; index < len(path); index++ {
+		var char = path[index]
+		if char == variableStart {
+			depth++
+			if depth == 1 {
+				start = index + 1
+			}
+		} else if char == variableEnd && depth > 0 {
+			depth--
+			if depth == 0 {
+				var name = stringsSplitN(
+					path[start:index],
+					variableSplitter,
+					2,
+				)[0]
+				names = append(names, name)
+				template = append(template, variableStart)
+				template = append(template, name...)
+				template = append(template, variableEnd)
+			}
+			continue
+		}
+		if depth == 0 {
+			template = append(template, char)
+		}
+	}
+	return string(template), names
+}
+
+// getParameterSchema maps the given parameter type into its schema, falling back to string with pattern for custom types
+func getParameterSchema(parameterType model.ParameterType) *schema {
+	switch parameterType {
+	case "", model.ParameterTypeAnything:
+		return &schema{Type: "string"}
+	case model.ParameterTypeInteger:
+		return &schema{Type: "integer"}
+	case model.ParameterTypeFloat:
+		return &schema{Type: "number"}
+	case model.ParameterTypeBoolean:
+		return &schema{Type: "boolean"}
+	case model.ParameterTypeUUID:
+		return &schema{Type: "string", Format: "uuid"}
+	case model.ParameterTypeDate:
+		return &schema{Type: "string", Format: "date"}
+	case model.ParameterTypeDateTime:
+		return &schema{Type: "string", Format: "date-time"}
+	}
+	return &schema{
+		Type:    "string",
+		Pattern: "^" + string(parameterType) + "$",
+	}
+}
+
+// hasBindingTags returns whether the given struct type declares any request binding tags, i.e. is meant for session.BindRequest
+func hasBindingTags(structType reflect.Type) bool {
+	for index := 0; index < structType.NumField(); index++ {
+		var tag = structType.Field(index).Tag
+		for _, tagName := range []string{binding.PathTag, binding.QueryTag, binding.HeaderTag, binding.BodyTag} {
+			var _, isTagged = tag.Lookup(tagName)
+			if isTagged {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasParameter(parameters []parameter, name string, location string) bool {
+	for _, parameter := range parameters {
+		if parameter.Name == name &&
+			parameter.In == location {
+			return true
+		}
+	}
+	return false
+}
+
+func getMediaTypes(mediaTypes []string, dataSchema *schema) map[string]mediaType {
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{defaultMediaType}
+	}
+	var content = map[string]mediaType{}
+	for _, name := range mediaTypes {
+		content[name] = mediaType{Schema: dataSchema}
+	}
+	return content
+}
+
+// getRoutePathParameters lists the path variables of a route, typed by the route parameters
+func getRoutePathParameters(names []string, parameterTypes map[string]model.ParameterType) []parameter {
+	var parameters = []parameter{}
+	for _, name := range names {
+		parameters = append(
+			parameters,
+			parameter{
+				Name:     name,
+				In:       pathLocation,
+				Required: true,
+				Schema:   getParameterSchemaFunc(parameterTypes[name]),
+			},
+		)
+	}
+	return parameters
+}
+
+// getRouteQueryParameters lists the queries of a route in name order; they are all required, as routes only match with them present
+func getRouteQueryParameters(queryTypes map[string]model.ParameterType) []parameter {
+	var names = []string{}
+	for name := range queryTypes {
+		names = append(names, name)
+	}
+	sortStrings(names)
+	var parameters = []parameter{}
+	for _, name := range names {
+		parameters = append(
+			parameters,
+			parameter{
+				Name:     name,
+				In:       queryLocation,
+				Required: true,
+				Schema:   getParameterSchemaFunc(queryTypes[name]),
+			},
+		)
+	}
+	return parameters
+}
+
+// getBindingParameters adds the tagged fields of the given binding type as parameters or request body, unless already declared by the route
+func getBindingParameters(components *components, parameters []parameter, bindingType reflect.Type) ([]parameter, *requestBody) {
+	var body *requestBody
+	for index := 0; index < bindingType.NumField(); index++ {
+		var field = bindingType.Field(index)
+		if field.PkgPath != "" {
+			continue
+		}
+		var _, isBody = field.Tag.Lookup(binding.BodyTag)
+		if isBody {
+			body = &requestBody{
+				Required: isRequiredFunc(field),
+				Content: getMediaTypesFunc(
+					nil,
+					getSchema(components, field.Type),
+				),
+			}
+			continue
+		}
+		for _, location := range []string{pathLocation, queryLocation, headerLocation} {
+			var name = field.Tag.Get(location)
+			if name == "" ||
+				hasParameterFunc(parameters, name, location) {
+				continue
+			}
+			parameters = append(
+				parameters,
+				parameter{
+					Name:     name,
+					In:       location,
+					Required: location == pathLocation || isRequiredFunc(field),
+					Schema:   getSchema(components, field.Type),
+				},
+			)
+			break
+		}
+	}
+	return parameters, body
+}
+
+// createOperation describes the given route with its path variables, queries and optional request and response types
+func createOperation(components *components, route model.Route, pathNames []string) *operation {
+	var parameters = append(
+		getRoutePathParametersFunc(
+			pathNames,
+			route.Parameters,
+		),
+		getRouteQueryParametersFunc(
+			route.Queries,
+		)...,
+	)
+	var body *requestBody
+	if route.RequestType != nil {
+		var requestType = reflect.TypeOf(route.RequestType)
+		for requestType.Kind() == reflect.Ptr {
+			requestType = requestType.Elem()
+		}
+		if requestType.Kind() == reflect.Struct &&
+			hasBindingTagsFunc(requestType) {
+			parameters, body = getBindingParametersFunc(
+				components,
+				parameters,
+				requestType,
+			)
+		} else {
+			body = &requestBody{
+				Required: true,
+				Content: getMediaTypesFunc(
+					nil,
+					getSchema(components, requestType),
+				),
+			}
+		}
+	}
+	var success = response{
+		Description: successMessage,
+	}
+	if route.ResponseType != nil {
+		success.Content = getMediaTypesFunc(
+			route.MediaTypes,
+			getSchema(
+				components,
+				reflect.TypeOf(route.ResponseType),
+			),
+		)
+	}
+	return &operation{
+		OperationID: route.Endpoint,
+		Parameters:  parameters,
+		RequestBody: body,
+		Responses: map[string]response{
+			successStatus: success,
+		},
+	}
+}
+
+// generateDocument generates the OpenAPI document describing the given routes
+func generateDocument(routes []model.Route) *document {
+	var components = newComponents()
+	var paths = map[string]map[string]*operation{}
+	for _, route := range routes {
+		var template, pathNames = getPathTemplateFunc(
+			route.Path,
+		)
+		var operations, isFound = paths[template]
+		if !isFound {
+			operations = map[string]*operation{}
+			paths[template] = operations
+		}
+		operations[stringsToLower(route.Method)] = createOperationFunc(
+			components,
+			route,
+			pathNames,
+		)
+	}
+	var document = &document{
+		OpenAPI: openAPIVersion,
+		Info: info{
+			Title:   config.AppName(),
+			Version: config.AppVersion(),
+		},
+		Paths: paths,
+	}
+	if len(components.Schemas) > 0 {
+		document.Components = components
+	}
+	return document
+}
+
+// GetHandler generates the OpenAPI document of the given routes once, and returns the handler serving it as JSON
+func GetHandler(routes []model.Route) func(http.ResponseWriter, *http.Request) {
+	var content, marshalError = jsonMarshal(
+		generateDocumentFunc(
+			routes,
+		),
+	)
+	return func(
+		responseWriter http.ResponseWriter,
+		httpRequest *http.Request,
+	) {
+		if marshalError != nil {
+			http.Error(
+				responseWriter,
+				marshalError.Error(),
+				http.StatusInternalServerError,
+			)
+			return
+		}
+		responseWriter.Header().Set("Content-Type", contentType)
+		responseWriter.WriteHeader(http.StatusOK)
+		responseWriter.Write(content)
+	}
+}
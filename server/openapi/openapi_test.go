@@ -0,0 +1,788 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/config"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+func TestGetPathTemplate(t *testing.T) {
+	// arrange
+	var dummyPaths = []string{
+		"/some/path",
+		"/users/{id}/orders/{orderID}",
+		"/items/{code:[A-Z]{2}\\d+}/{name:\\w+}",
+	}
+	var expectedTemplates = []string{
+		"/some/path",
+		"/users/{id}/orders/{orderID}",
+		"/items/{code}/{name}",
+	}
+	var expectedNames = [][]string{
+		{},
+		{"id", "orderID"},
+		{"code", "name"},
+	}
+
+	for index, dummyPath := range dummyPaths {
+		// mock
+		createMock(t)
+
+		// expect
+		stringsSplitNExpected = len(expectedNames[index])
+		stringsSplitN = func(s string, sep string, n int) []string {
+			stringsSplitNCalled++
+			assert.Equal(t, ":", sep)
+			assert.Equal(t, 2, n)
+			return strings.SplitN(s, sep, n)
+		}
+
+		// SUT + act
+		var template, names = getPathTemplate(
+			dummyPath,
+		)
+
+		// assert
+		assert.Equal(t, expectedTemplates[index], template)
+		assert.Equal(t, expectedNames[index], names)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestGetParameterSchema(t *testing.T) {
+	// arrange
+	var dummyParameterTypes = []model.ParameterType{
+		"",
+		model.ParameterTypeAnything,
+		model.ParameterTypeInteger,
+		model.ParameterTypeFloat,
+		model.ParameterTypeBoolean,
+		model.ParameterTypeUUID,
+		model.ParameterTypeDate,
+		model.ParameterTypeDateTime,
+		model.ParameterTypeString,
+	}
+	var expectedSchemas = []*schema{
+		{Type: "string"},
+		{Type: "string"},
+		{Type: "integer"},
+		{Type: "number"},
+		{Type: "boolean"},
+		{Type: "string", Format: "uuid"},
+		{Type: "string", Format: "date"},
+		{Type: "string", Format: "date-time"},
+		{Type: "string", Pattern: `^\w+$`},
+	}
+
+	for index, dummyParameterType := range dummyParameterTypes {
+		// mock
+		createMock(t)
+
+		// SUT + act
+		var result = getParameterSchema(
+			dummyParameterType,
+		)
+
+		// assert
+		assert.Equal(t, expectedSchemas[index], result)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestHasBindingTags(t *testing.T) {
+	// arrange
+	type dummyPlain struct {
+		Name string `json:"name"`
+	}
+	type dummyBinding struct {
+		Name string `json:"name"`
+		Body string `body:""`
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var plainResult = hasBindingTags(
+		reflect.TypeOf(dummyPlain{}),
+	)
+	var bindingResult = hasBindingTags(
+		reflect.TypeOf(dummyBinding{}),
+	)
+
+	// assert
+	assert.False(t, plainResult)
+	assert.True(t, bindingResult)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestHasParameter(t *testing.T) {
+	// arrange
+	var dummyParameters = []parameter{
+		{Name: "id", In: pathLocation},
+		{Name: "page", In: queryLocation},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var foundResult = hasParameter(
+		dummyParameters,
+		"page",
+		queryLocation,
+	)
+	var missingResult = hasParameter(
+		dummyParameters,
+		"page",
+		headerLocation,
+	)
+
+	// assert
+	assert.True(t, foundResult)
+	assert.False(t, missingResult)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetMediaTypes_Default(t *testing.T) {
+	// arrange
+	var dummySchema = &schema{Type: "string"}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getMediaTypes(
+		nil,
+		dummySchema,
+	)
+
+	// assert
+	assert.Equal(t, map[string]mediaType{"application/json": {Schema: dummySchema}}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetMediaTypes_Given(t *testing.T) {
+	// arrange
+	var dummyMediaTypes = []string{"text/csv", "application/xml"}
+	var dummySchema = &schema{Type: "string"}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getMediaTypes(
+		dummyMediaTypes,
+		dummySchema,
+	)
+
+	// assert
+	assert.Equal(t, map[string]mediaType{"text/csv": {Schema: dummySchema}, "application/xml": {Schema: dummySchema}}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRoutePathParameters(t *testing.T) {
+	// arrange
+	var dummyNames = []string{"id", "name"}
+	var dummyParameterTypes = map[string]model.ParameterType{
+		"id": model.ParameterTypeInteger,
+	}
+	var dummySchemas = map[model.ParameterType]*schema{
+		model.ParameterTypeInteger: {Type: "integer"},
+		"":                         {Type: "string"},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getParameterSchemaFuncExpected = 2
+	getParameterSchemaFunc = func(parameterType model.ParameterType) *schema {
+		getParameterSchemaFuncCalled++
+		return dummySchemas[parameterType]
+	}
+
+	// SUT + act
+	var result = getRoutePathParameters(
+		dummyNames,
+		dummyParameterTypes,
+	)
+
+	// assert
+	assert.Equal(t, []parameter{
+		{Name: "id", In: pathLocation, Required: true, Schema: &schema{Type: "integer"}},
+		{Name: "name", In: pathLocation, Required: true, Schema: &schema{Type: "string"}},
+	}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRouteQueryParameters(t *testing.T) {
+	// arrange
+	var dummyQueryTypes = map[string]model.ParameterType{
+		"size": model.ParameterTypeInteger,
+		"from": model.ParameterTypeDate,
+	}
+	var dummySchema = &schema{Type: "some type"}
+
+	// mock
+	createMock(t)
+
+	// expect
+	sortStringsExpected = 1
+	sortStrings = func(x []string) {
+		sortStringsCalled++
+		sort.Strings(x)
+	}
+	getParameterSchemaFuncExpected = 2
+	getParameterSchemaFunc = func(parameterType model.ParameterType) *schema {
+		getParameterSchemaFuncCalled++
+		return dummySchema
+	}
+
+	// SUT + act
+	var result = getRouteQueryParameters(
+		dummyQueryTypes,
+	)
+
+	// assert
+	assert.Equal(t, []parameter{
+		{Name: "from", In: queryLocation, Required: true, Schema: dummySchema},
+		{Name: "size", In: queryLocation, Required: true, Schema: dummySchema},
+	}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetBindingParameters(t *testing.T) {
+	// arrange
+	type dummyBody struct {
+		Name string `json:"name"`
+	}
+	type dummyBinding struct {
+		ID        string    `path:"id"`
+		Code      int       `path:"code"`
+		Page      int       `query:"page"`
+		Tags      []string  `query:"tag"`
+		Tenant    string    `header:"X-Tenant" validate:"required"`
+		Body      dummyBody `body:"" validate:"required"`
+		Untagged  string
+		unexposed string `query:"unexposed"`
+	}
+	var dummyComponents = newComponents()
+	var dummyParameters = []parameter{
+		{Name: "id", In: pathLocation, Required: true, Schema: &schema{Type: "string", Format: "uuid"}},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	hasParameterFunc = hasParameter
+	isRequiredFunc = isRequired
+	getMediaTypesFunc = getMediaTypes
+	stringsSplit = strings.Split
+	stringsTrimSpace = strings.TrimSpace
+	getPropertyNameFunc = getPropertyName
+	getComponentNameFunc = getComponentName
+
+	// SUT + act
+	var parameters, body = getBindingParameters(
+		dummyComponents,
+		dummyParameters,
+		reflect.TypeOf(dummyBinding{}),
+	)
+
+	// assert
+	assert.Equal(t, []parameter{
+		{Name: "id", In: pathLocation, Required: true, Schema: &schema{Type: "string", Format: "uuid"}},
+		{Name: "code", In: pathLocation, Required: true, Schema: &schema{Type: "integer", Format: "int64"}},
+		{Name: "page", In: queryLocation, Schema: &schema{Type: "integer", Format: "int64"}},
+		{Name: "tag", In: queryLocation, Schema: &schema{Type: "array", Items: &schema{Type: "string"}}},
+		{Name: "X-Tenant", In: headerLocation, Required: true, Schema: &schema{Type: "string"}},
+	}, parameters)
+	assert.Equal(t, &requestBody{
+		Required: true,
+		Content: map[string]mediaType{
+			"application/json": {Schema: &schema{Ref: "#/components/schemas/dummyBody"}},
+		},
+	}, body)
+	assert.Contains(t, dummyComponents.Schemas, "dummyBody")
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCreateOperation_NoTypes(t *testing.T) {
+	// arrange
+	var dummyComponents = newComponents()
+	var dummyRoute = model.Route{
+		Endpoint:   "some endpoint",
+		Parameters: map[string]model.ParameterType{"id": model.ParameterTypeInteger},
+		Queries:    map[string]model.ParameterType{"page": model.ParameterTypeInteger},
+	}
+	var dummyPathNames = []string{"id"}
+	var dummyPathParameters = []parameter{{Name: "id"}}
+	var dummyQueryParameters = []parameter{{Name: "page"}}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getRoutePathParametersFuncExpected = 1
+	getRoutePathParametersFunc = func(names []string, parameterTypes map[string]model.ParameterType) []parameter {
+		getRoutePathParametersFuncCalled++
+		assert.Equal(t, dummyPathNames, names)
+		assert.Equal(t, dummyRoute.Parameters, parameterTypes)
+		return dummyPathParameters
+	}
+	getRouteQueryParametersFuncExpected = 1
+	getRouteQueryParametersFunc = func(queryTypes map[string]model.ParameterType) []parameter {
+		getRouteQueryParametersFuncCalled++
+		assert.Equal(t, dummyRoute.Queries, queryTypes)
+		return dummyQueryParameters
+	}
+
+	// SUT + act
+	var result = createOperation(
+		dummyComponents,
+		dummyRoute,
+		dummyPathNames,
+	)
+
+	// assert
+	assert.Equal(t, &operation{
+		OperationID: "some endpoint",
+		Parameters:  []parameter{{Name: "id"}, {Name: "page"}},
+		Responses: map[string]response{
+			"200": {Description: "OK"},
+		},
+	}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCreateOperation_BodyTypes(t *testing.T) {
+	// arrange
+	var dummyComponents = newComponents()
+	var dummyRoute = model.Route{
+		Endpoint:     "some endpoint",
+		MediaTypes:   []string{"text/csv"},
+		RequestType:  &[]string{},
+		ResponseType: map[string]int{},
+	}
+	var dummyRequestContent = map[string]mediaType{"some request": {}}
+	var dummyResponseContent = map[string]mediaType{"some response": {}}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getRoutePathParametersFuncExpected = 1
+	getRoutePathParametersFunc = func(names []string, parameterTypes map[string]model.ParameterType) []parameter {
+		getRoutePathParametersFuncCalled++
+		return []parameter{}
+	}
+	getRouteQueryParametersFuncExpected = 1
+	getRouteQueryParametersFunc = func(queryTypes map[string]model.ParameterType) []parameter {
+		getRouteQueryParametersFuncCalled++
+		return []parameter{}
+	}
+	getMediaTypesFuncExpected = 2
+	getMediaTypesFunc = func(mediaTypes []string, dataSchema *schema) map[string]mediaType {
+		getMediaTypesFuncCalled++
+		if getMediaTypesFuncCalled == 1 {
+			assert.Nil(t, mediaTypes)
+			assert.Equal(t, &schema{Type: "array", Items: &schema{Type: "string"}}, dataSchema)
+			return dummyRequestContent
+		}
+		assert.Equal(t, dummyRoute.MediaTypes, mediaTypes)
+		assert.Equal(t, &schema{Type: "object", AdditionalProperties: &schema{Type: "integer", Format: "int64"}}, dataSchema)
+		return dummyResponseContent
+	}
+
+	// SUT + act
+	var result = createOperation(
+		dummyComponents,
+		dummyRoute,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, &operation{
+		OperationID: "some endpoint",
+		Parameters:  []parameter{},
+		RequestBody: &requestBody{
+			Required: true,
+			Content:  dummyRequestContent,
+		},
+		Responses: map[string]response{
+			"200": {Description: "OK", Content: dummyResponseContent},
+		},
+	}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCreateOperation_BindingType(t *testing.T) {
+	// arrange
+	type dummyBinding struct {
+		Page int `query:"page"`
+	}
+	var dummyComponents = newComponents()
+	var dummyRoute = model.Route{
+		Endpoint:    "some endpoint",
+		RequestType: &dummyBinding{},
+	}
+	var dummyParameters = []parameter{{Name: "id"}}
+	var dummyBindingParameters = []parameter{{Name: "id"}, {Name: "page"}}
+	var dummyBody = &requestBody{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getRoutePathParametersFuncExpected = 1
+	getRoutePathParametersFunc = func(names []string, parameterTypes map[string]model.ParameterType) []parameter {
+		getRoutePathParametersFuncCalled++
+		return dummyParameters
+	}
+	getRouteQueryParametersFuncExpected = 1
+	getRouteQueryParametersFunc = func(queryTypes map[string]model.ParameterType) []parameter {
+		getRouteQueryParametersFuncCalled++
+		return nil
+	}
+	hasBindingTagsFuncExpected = 1
+	hasBindingTagsFunc = func(structType reflect.Type) bool {
+		hasBindingTagsFuncCalled++
+		assert.Equal(t, reflect.TypeOf(dummyBinding{}), structType)
+		return true
+	}
+	getBindingParametersFuncExpected = 1
+	getBindingParametersFunc = func(components *components, parameters []parameter, bindingType reflect.Type) ([]parameter, *requestBody) {
+		getBindingParametersFuncCalled++
+		assert.Equal(t, dummyComponents, components)
+		assert.Equal(t, dummyParameters, parameters)
+		assert.Equal(t, reflect.TypeOf(dummyBinding{}), bindingType)
+		return dummyBindingParameters, dummyBody
+	}
+
+	// SUT + act
+	var result = createOperation(
+		dummyComponents,
+		dummyRoute,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, dummyBindingParameters, result.Parameters)
+	assert.Equal(t, dummyBody, result.RequestBody)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGenerateDocument(t *testing.T) {
+	// arrange
+	var dummyRoutes = []model.Route{
+		{Endpoint: "some endpoint 1", Method: http.MethodGet, Path: "some path 1"},
+		{Endpoint: "some endpoint 2", Method: http.MethodPost, Path: "some path 1"},
+		{Endpoint: "some endpoint 3", Method: http.MethodGet, Path: "some path 2"},
+	}
+	var dummyTemplates = map[string]string{
+		"some path 1": "some template 1",
+		"some path 2": "some template 2",
+	}
+	var dummyPathNames = []string{"some name"}
+	var dummyOperations = map[string]*operation{
+		"some endpoint 1": {OperationID: "some operation 1"},
+		"some endpoint 2": {OperationID: "some operation 2"},
+		"some endpoint 3": {OperationID: "some operation 3"},
+	}
+	var dummyAppName = "some app name"
+	var dummyAppVersion = "some app version"
+
+	// mock
+	createMock(t)
+
+	// expect
+	getPathTemplateFuncExpected = 3
+	getPathTemplateFunc = func(path string) (string, []string) {
+		getPathTemplateFuncCalled++
+		return dummyTemplates[path], dummyPathNames
+	}
+	stringsToLowerExpected = 3
+	stringsToLower = func(s string) string {
+		stringsToLowerCalled++
+		return strings.ToLower(s)
+	}
+	createOperationFuncExpected = 3
+	createOperationFunc = func(components *components, route model.Route, pathNames []string) *operation {
+		createOperationFuncCalled++
+		assert.Equal(t, dummyRoutes[createOperationFuncCalled-1], route)
+		assert.Equal(t, dummyPathNames, pathNames)
+		return dummyOperations[route.Endpoint]
+	}
+	configAppNameExpected = 1
+	config.AppName = func() string {
+		configAppNameCalled++
+		return dummyAppName
+	}
+	configAppVersionExpected = 1
+	config.AppVersion = func() string {
+		configAppVersionCalled++
+		return dummyAppVersion
+	}
+
+	// SUT + act
+	var result = generateDocument(
+		dummyRoutes,
+	)
+
+	// assert
+	assert.Equal(t, &document{
+		OpenAPI: "3.0.3",
+		Info: info{
+			Title:   dummyAppName,
+			Version: dummyAppVersion,
+		},
+		Paths: map[string]map[string]*operation{
+			"some template 1": {
+				"get":  dummyOperations["some endpoint 1"],
+				"post": dummyOperations["some endpoint 2"],
+			},
+			"some template 2": {
+				"get": dummyOperations["some endpoint 3"],
+			},
+		},
+	}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetHandler_MarshalError(t *testing.T) {
+	// arrange
+	var dummyRoutes = []model.Route{{Endpoint: "some endpoint"}}
+	var dummyDocument = &document{OpenAPI: "some version"}
+	var dummyError = errors.New("some error")
+	var dummyResponseWriter = &dummyResponseWriter{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	generateDocumentFuncExpected = 1
+	generateDocumentFunc = func(routes []model.Route) *document {
+		generateDocumentFuncCalled++
+		assert.Equal(t, dummyRoutes, routes)
+		return dummyDocument
+	}
+	jsonMarshalExpected = 1
+	jsonMarshal = func(v interface{}) ([]byte, error) {
+		jsonMarshalCalled++
+		assert.Equal(t, dummyDocument, v)
+		return nil, dummyError
+	}
+
+	// SUT
+	var handler = GetHandler(
+		dummyRoutes,
+	)
+
+	// act
+	handler(
+		dummyResponseWriter,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusInternalServerError, dummyResponseWriter.statusCode)
+	assert.Equal(t, "some error\n", string(dummyResponseWriter.body))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetHandler_Success(t *testing.T) {
+	// arrange
+	var dummyRoutes = []model.Route{{Endpoint: "some endpoint"}}
+	var dummyDocument = &document{OpenAPI: "some version"}
+	var dummyContent = []byte("some content")
+	var dummyResponseWriter = &dummyResponseWriter{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	generateDocumentFuncExpected = 1
+	generateDocumentFunc = func(routes []model.Route) *document {
+		generateDocumentFuncCalled++
+		return dummyDocument
+	}
+	jsonMarshalExpected = 1
+	jsonMarshal = func(v interface{}) ([]byte, error) {
+		jsonMarshalCalled++
+		return dummyContent, nil
+	}
+
+	// SUT
+	var handler = GetHandler(
+		dummyRoutes,
+	)
+
+	// act
+	handler(
+		dummyResponseWriter,
+		nil,
+	)
+	handler(
+		dummyResponseWriter,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, dummyResponseWriter.statusCode)
+	assert.Equal(t, "application/json; charset=utf-8", dummyResponseWriter.Header().Get("Content-Type"))
+	assert.Equal(t, "some contentsome content", string(dummyResponseWriter.body))
+
+	// verify
+	verifyAll(t)
+}
+
+func useRealFunctions() {
+	stringsSplitN = strings.SplitN
+	stringsToLower = strings.ToLower
+	sortStrings = sort.Strings
+	jsonMarshal = json.Marshal
+	getPathTemplateFunc = getPathTemplate
+	getParameterSchemaFunc = getParameterSchema
+	hasBindingTagsFunc = hasBindingTags
+	hasParameterFunc = hasParameter
+	getMediaTypesFunc = getMediaTypes
+	getRoutePathParametersFunc = getRoutePathParameters
+	getRouteQueryParametersFunc = getRouteQueryParameters
+	getBindingParametersFunc = getBindingParameters
+	createOperationFunc = createOperation
+	generateDocumentFunc = generateDocument
+	stringsSplit = strings.Split
+	stringsTrimSpace = strings.TrimSpace
+	stringsNewReplacer = strings.NewReplacer
+	getPropertyNameFunc = getPropertyName
+	isRequiredFunc = isRequired
+	getComponentNameFunc = getComponentName
+}
+
+func TestGetHandler_Integration(t *testing.T) {
+	// arrange
+	type dummyUser struct {
+		ID   int    `json:"id"`
+		Name string `json:"name" validate:"required"`
+	}
+	type dummyUpdateUser struct {
+		ID     int       `path:"id"`
+		Tenant string    `header:"X-Tenant"`
+		User   dummyUser `body:"" validate:"required"`
+	}
+	var dummyRoutes = []model.Route{
+		{
+			Endpoint:     "GetUser",
+			Method:       http.MethodGet,
+			Path:         "/users/{id}",
+			Parameters:   map[string]model.ParameterType{"id": model.ParameterTypeInteger},
+			ResponseType: dummyUser{},
+		},
+		{
+			Endpoint:     "UpdateUser",
+			Method:       http.MethodPut,
+			Path:         "/users/{id}",
+			Parameters:   map[string]model.ParameterType{"id": model.ParameterTypeInteger},
+			RequestType:  dummyUpdateUser{},
+			ResponseType: &dummyUser{},
+		},
+	}
+	var dummyResponseWriter = &dummyResponseWriter{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	useRealFunctions()
+	config.AppName = func() string { return "some app" }
+	config.AppVersion = func() string { return "1.0.0" }
+
+	// SUT
+	var handler = GetHandler(
+		dummyRoutes,
+	)
+
+	// act
+	handler(
+		dummyResponseWriter,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, dummyResponseWriter.statusCode)
+	assert.JSONEq(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "some app", "version": "1.0.0"},
+		"paths": {
+			"/users/{id}": {
+				"get": {
+					"operationId": "GetUser",
+					"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}}],
+					"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/dummyUser"}}}}}
+				},
+				"put": {
+					"operationId": "UpdateUser",
+					"parameters": [
+						{"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}},
+						{"name": "X-Tenant", "in": "header", "schema": {"type": "string"}}
+					],
+					"requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/dummyUser"}}}},
+					"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/dummyUser"}}}}}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"dummyUser": {
+					"type": "object",
+					"properties": {
+						"id": {"type": "integer", "format": "int64"},
+						"name": {"type": "string"}
+					},
+					"required": ["name"]
+				}
+			}
+		}
+	}`, string(dummyResponseWriter.body))
+
+	// verify
+	verifyAll(t)
+}
@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/binding"
+)
+
+// These are the constants used for mapping Go types into OpenAPI schemas
+const (
+	jsonTag           = "json"
+	skippedTagName    = "-"
+	tagSeparator      = ","
+	componentsRefBase = "#/components/schemas/"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// schema holds the OpenAPI schema object describing a data type
+type schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *schema            `json:"additionalProperties,omitempty"`
+}
+
+// components holds the named schemas of struct types shared across operations
+type components struct {
+	Schemas map[string]*schema `json:"schemas"`
+	names   map[reflect.Type]string
+}
+
+func newComponents() *components {
+	return &components{
+		Schemas: map[string]*schema{},
+		names:   map[reflect.Type]string{},
+	}
+}
+
+// getPropertyName returns the JSON name of the given struct field, or "-" if the field is skipped by JSON encoding
+func getPropertyName(field reflect.StructField) string {
+	var name = stringsSplit(
+		field.Tag.Get(jsonTag),
+		tagSeparator,
+	)[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// isRequired returns whether the given struct field is marked as required in its binding validate tag
+func isRequired(field reflect.StructField) bool {
+	var rules = stringsSplit(
+		field.Tag.Get(binding.ValidateTag),
+		tagSeparator,
+	)
+	for _, rule := range rules {
+		if stringsTrimSpace(rule) == binding.RuleRequired {
+			return true
+		}
+	}
+	return false
+}
+
+// getComponentName returns a unique component name for the given named type, qualifying it by its package path upon conflicts
+func getComponentName(components *components, dataType reflect.Type) string {
+	var name = dataType.Name()
+	var _, isConflicted = components.Schemas[name]
+	if !isConflicted {
+		return name
+	}
+	return stringsNewReplacer(
+		"/", "_",
+		".", "_",
+	).Replace(
+		dataType.PkgPath() + "." + name,
+	)
+}
+
+func addStructProperties(components *components, structSchema *schema, structType reflect.Type) {
+	for index := 0; index < structType.NumField(); index++ {
+		var field = structType.Field(index)
+		var fieldType = field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous &&
+			fieldType.Kind() == reflect.Struct &&
+			field.Tag.Get(jsonTag) == "" {
+			addStructProperties(
+				components,
+				structSchema,
+				fieldType,
+			)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		var name = getPropertyNameFunc(field)
+		if name == skippedTagName {
+			continue
+		}
+		structSchema.Properties[name] = getSchema(
+			components,
+			field.Type,
+		)
+		if isRequiredFunc(field) {
+			structSchema.Required = append(
+				structSchema.Required,
+				name,
+			)
+		}
+	}
+}
+
+func getStructSchema(components *components, structType reflect.Type) *schema {
+	var structSchema = &schema{
+		Type:       "object",
+		Properties: map[string]*schema{},
+	}
+	addStructProperties(
+		components,
+		structSchema,
+		structType,
+	)
+	return structSchema
+}
+
+// getSchema returns the schema of the given data type; named struct types are registered into components and referenced, so that recursive types are supported
+func getSchema(components *components, dataType reflect.Type) *schema {
+	for dataType.Kind() == reflect.Ptr {
+		dataType = dataType.Elem()
+	}
+	if dataType == timeType {
+		return &schema{Type: "string", Format: "date-time"}
+	}
+	switch dataType.Kind() {
+	case reflect.Bool:
+		return &schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if dataType.Elem().Kind() == reflect.Uint8 {
+			return &schema{Type: "string", Format: "byte"}
+		}
+		return &schema{
+			Type:  "array",
+			Items: getSchema(components, dataType.Elem()),
+		}
+	case reflect.Map:
+		return &schema{
+			Type:                 "object",
+			AdditionalProperties: getSchema(components, dataType.Elem()),
+		}
+	case reflect.Struct:
+		if dataType.Name() == "" {
+			return getStructSchema(
+				components,
+				dataType,
+			)
+		}
+		var name, isRegistered = components.names[dataType]
+		if !isRegistered {
+			name = getComponentNameFunc(
+				components,
+				dataType,
+			)
+			components.names[dataType] = name
+			// reserve the name before resolving the fields, so that no nested type takes it
+			components.Schemas[name] = &schema{}
+			components.Schemas[name] = getStructSchema(
+				components,
+				dataType,
+			)
+		}
+		return &schema{Ref: componentsRefBase + name}
+	}
+	return &schema{}
+}
@@ -0,0 +1,287 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPropertyName(t *testing.T) {
+	// arrange
+	type dummyStruct struct {
+		Untagged  string
+		Tagged    string `json:"tagged"`
+		Omitempty string `json:",omitempty"`
+		Skipped   string `json:"-"`
+	}
+	var dummyType = reflect.TypeOf(dummyStruct{})
+	var expectedNames = []string{"Untagged", "tagged", "Omitempty", "-"}
+
+	for index, expectedName := range expectedNames {
+		// mock
+		createMock(t)
+
+		// expect
+		stringsSplitExpected = 1
+		stringsSplit = func(s string, sep string) []string {
+			stringsSplitCalled++
+			assert.Equal(t, ",", sep)
+			return strings.Split(s, sep)
+		}
+
+		// SUT + act
+		var result = getPropertyName(
+			dummyType.Field(index),
+		)
+
+		// assert
+		assert.Equal(t, expectedName, result)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestIsRequired(t *testing.T) {
+	// arrange
+	type dummyStruct struct {
+		Untagged string
+		Optional string `validate:"min=1,max=2"`
+		Required string `validate:"min=1, required"`
+	}
+	var dummyType = reflect.TypeOf(dummyStruct{})
+	var expectedResults = []bool{false, false, true}
+
+	for index, expectedResult := range expectedResults {
+		// mock
+		createMock(t)
+
+		// expect
+		stringsSplitExpected = 1
+		stringsSplit = func(s string, sep string) []string {
+			stringsSplitCalled++
+			return strings.Split(s, sep)
+		}
+		stringsTrimSpace = strings.TrimSpace
+
+		// SUT + act
+		var result = isRequired(
+			dummyType.Field(index),
+		)
+
+		// assert
+		assert.Equal(t, expectedResult, result)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestGetComponentName_Unique(t *testing.T) {
+	// arrange
+	var dummyComponents = newComponents()
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getComponentName(
+		dummyComponents,
+		reflect.TypeOf(time.Duration(0)),
+	)
+
+	// assert
+	assert.Equal(t, "Duration", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetComponentName_Conflicted(t *testing.T) {
+	// arrange
+	var dummyComponents = newComponents()
+
+	// stub
+	dummyComponents.Schemas["Duration"] = &schema{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsNewReplacerExpected = 1
+	stringsNewReplacer = func(oldnew ...string) *strings.Replacer {
+		stringsNewReplacerCalled++
+		assert.Equal(t, []string{"/", "_", ".", "_"}, oldnew)
+		return strings.NewReplacer(oldnew...)
+	}
+
+	// SUT + act
+	var result = getComponentName(
+		dummyComponents,
+		reflect.TypeOf(time.Duration(0)),
+	)
+
+	// assert
+	assert.Equal(t, "time_Duration", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSchema_Primitives(t *testing.T) {
+	// arrange
+	var dummyText = "some text"
+	var dummyValues = []interface{}{
+		true,
+		int16(1),
+		uint32(1),
+		1,
+		uint64(1),
+		float32(1),
+		1.0,
+		"some string",
+		&dummyText,
+		[]byte("some bytes"),
+		time.Time{},
+		[]int{},
+		[2]bool{},
+		map[string]string{},
+		make(chan int),
+	}
+	var expectedSchemas = []*schema{
+		{Type: "boolean"},
+		{Type: "integer", Format: "int32"},
+		{Type: "integer", Format: "int32"},
+		{Type: "integer", Format: "int64"},
+		{Type: "integer", Format: "int64"},
+		{Type: "number", Format: "float"},
+		{Type: "number", Format: "double"},
+		{Type: "string"},
+		{Type: "string"},
+		{Type: "string", Format: "byte"},
+		{Type: "string", Format: "date-time"},
+		{Type: "array", Items: &schema{Type: "integer", Format: "int64"}},
+		{Type: "array", Items: &schema{Type: "boolean"}},
+		{Type: "object", AdditionalProperties: &schema{Type: "string"}},
+		{},
+	}
+
+	for index, dummyValue := range dummyValues {
+		// mock
+		createMock(t)
+
+		// SUT + act
+		var result = getSchema(
+			newComponents(),
+			reflect.TypeOf(dummyValue),
+		)
+
+		// assert
+		assert.Equal(t, expectedSchemas[index], result)
+
+		// verify
+		verifyAll(t)
+	}
+}
+
+func TestGetSchema_AnonymousStruct(t *testing.T) {
+	// arrange
+	var dummyValue struct {
+		Name      string `json:"name"`
+		unexposed string
+	}
+	var dummyComponents = newComponents()
+
+	// mock
+	createMock(t)
+
+	// expect
+	getPropertyNameFuncExpected = 1
+	getPropertyNameFunc = func(field reflect.StructField) string {
+		getPropertyNameFuncCalled++
+		assert.Equal(t, "Name", field.Name)
+		return "name"
+	}
+	isRequiredFuncExpected = 1
+	isRequiredFunc = func(field reflect.StructField) bool {
+		isRequiredFuncCalled++
+		return true
+	}
+
+	// SUT + act
+	var result = getSchema(
+		dummyComponents,
+		reflect.TypeOf(dummyValue),
+	)
+
+	// assert
+	assert.Equal(t, &schema{
+		Type:       "object",
+		Properties: map[string]*schema{"name": {Type: "string"}},
+		Required:   []string{"name"},
+	}, result)
+	assert.Empty(t, dummyComponents.Schemas)
+
+	// verify
+	verifyAll(t)
+}
+
+type dummyBase struct {
+	Created time.Time `json:"created"`
+}
+
+type dummyNode struct {
+	*dummyBase
+	Value    int          `json:"value"`
+	Children []*dummyNode `json:"children"`
+	Skipped  string       `json:"-"`
+}
+
+func TestGetSchema_NamedStruct(t *testing.T) {
+	// arrange
+	var dummyComponents = newComponents()
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsSplit = strings.Split
+	getPropertyNameFunc = getPropertyName
+	stringsTrimSpace = strings.TrimSpace
+	isRequiredFunc = isRequired
+	getComponentNameFuncExpected = 1
+	getComponentNameFunc = func(components *components, dataType reflect.Type) string {
+		getComponentNameFuncCalled++
+		assert.Equal(t, dummyComponents, components)
+		assert.Equal(t, reflect.TypeOf(dummyNode{}), dataType)
+		return "some name"
+	}
+
+	// SUT + act
+	var result = getSchema(
+		dummyComponents,
+		reflect.TypeOf(&dummyNode{}),
+	)
+
+	// assert
+	assert.Equal(t, &schema{Ref: "#/components/schemas/some name"}, result)
+	assert.Equal(t, map[string]*schema{
+		"some name": {
+			Type: "object",
+			Properties: map[string]*schema{
+				"created": {Type: "string", Format: "date-time"},
+				"value":   {Type: "integer", Format: "int64"},
+				"children": {
+					Type:  "array",
+					Items: &schema{Ref: "#/components/schemas/some name"},
+				},
+			},
+		},
+	}, dummyComponents.Schemas)
+
+	// verify
+	verifyAll(t)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger"
+	"github.com/zhongjie-cai/WebServiceTemplate/metrics"
 	"github.com/zhongjie-cai/WebServiceTemplate/response"
 )
 
@@ -16,4 +17,5 @@ var (
 	responseWrite                  = response.Write
 	apperrorGetGeneralFailureError = apperror.GetGeneralFailureError
 	getDebugStackFunc              = getDebugStack
+	metricsPanicRecovered          = metrics.PanicRecovered
 )
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -12,7 +13,9 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/logger"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
+	"github.com/zhongjie-cai/WebServiceTemplate/metrics"
 	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/response"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
 )
@@ -30,6 +33,8 @@ var (
 	apperrorGetGeneralFailureErrorCalled   int
 	getDebugStackFuncExpected              int
 	getDebugStackFuncCalled                int
+	metricsPanicRecoveredExpected          int
+	metricsPanicRecoveredCalled            int
 )
 
 func createMock(t *testing.T) {
@@ -67,6 +72,11 @@ func createMock(t *testing.T) {
 		getDebugStackFuncCalled++
 		return ""
 	}
+	metricsPanicRecoveredExpected = 0
+	metricsPanicRecoveredCalled = 0
+	metricsPanicRecovered = func(endpoint string) {
+		metricsPanicRecoveredCalled++
+	}
 }
 
 func verifyAll(t *testing.T) {
@@ -82,11 +92,14 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, apperrorGetGeneralFailureErrorExpected, apperrorGetGeneralFailureErrorCalled, "Unexpected number of calls to apperrorGetGeneralFailureError")
 	getDebugStackFunc = getDebugStack
 	assert.Equal(t, getDebugStackFuncExpected, getDebugStackFuncCalled, "Unexpected number of calls to getDebugStackFunc")
+	metricsPanicRecovered = metrics.PanicRecovered
+	assert.Equal(t, metricsPanicRecoveredExpected, metricsPanicRecoveredCalled, "Unexpected number of calls to metricsPanicRecovered")
 }
 
 // mock structs
 type dummySession struct {
-	t *testing.T
+	t    *testing.T
+	name string
 }
 
 func (session *dummySession) GetID() uuid.UUID {
@@ -95,7 +108,11 @@ func (session *dummySession) GetID() uuid.UUID {
 }
 
 func (session *dummySession) GetName() string {
-	assert.Fail(session.t, "Unexpected call to GetName")
+	return session.name
+}
+
+func (session *dummySession) GetCorrelationID() string {
+	assert.Fail(session.t, "Unexpected call to GetCorrelationID")
 	return ""
 }
 
@@ -109,6 +126,14 @@ func (session *dummySession) GetResponseWriter() http.ResponseWriter {
 	return nil
 }
 
+func (session *dummySession) SetResponseETag(etag string, weak bool) {
+	assert.Fail(session.t, "Unexpected call to SetResponseETag")
+}
+
+func (session *dummySession) SetResponseLastModified(lastModified time.Time) {
+	assert.Fail(session.t, "Unexpected call to SetResponseLastModified")
+}
+
 func (session *dummySession) GetRequestBody(dataTemplate interface{}) apperrorModel.AppError {
 	assert.Fail(session.t, "Unexpected call to GetRequestBody")
 	return nil
@@ -139,6 +164,26 @@ func (session *dummySession) GetRequestHeaders(name string, dataTemplate interfa
 	return nil
 }
 
+func (session *dummySession) GetRequestFormValue(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValue")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValues(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValues")
+	return nil
+}
+
+func (session *dummySession) GetRequestFile(name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFile")
+	return nil
+}
+
+func (session *dummySession) BindRequest(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to BindRequest")
+	return nil
+}
+
 func (session *dummySession) Attach(name string, value interface{}) bool {
 	assert.Fail(session.t, "Unexpected call to Attach")
 	return false
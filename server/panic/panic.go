@@ -40,5 +40,8 @@ func Handle(session sessionModel.Session, recoverResult interface{}) {
 			appError,
 			getDebugStackFunc(),
 		)
+		metricsPanicRecovered(
+			session.GetName(),
+		)
 	}
 }
@@ -91,7 +91,8 @@ func TestGetDebugStack(t *testing.T) {
 
 func TestHandlePanic(t *testing.T) {
 	// arrange
-	var dummySessionObject = &dummySession{}
+	var dummySessionName = "some session name"
+	var dummySessionObject = &dummySession{t, dummySessionName}
 	var dummyError = errors.New("some error")
 	var dummyRecoverResult = dummyError.(interface{})
 	var dummyAppError = apperror.GetGeneralFailureError(dummyError)
@@ -131,6 +132,11 @@ func TestHandlePanic(t *testing.T) {
 		assert.Equal(t, dummyAppError, parameters[0])
 		assert.Equal(t, dummyDebugStack, parameters[1])
 	}
+	metricsPanicRecoveredExpected = 1
+	metricsPanicRecovered = func(endpoint string) {
+		metricsPanicRecoveredCalled++
+		assert.Equal(t, dummySessionName, endpoint)
+	}
 
 	// SUT + act
 	Handle(
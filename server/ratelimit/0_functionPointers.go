@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
+)
+
+// func pointers for injection / testing: ratelimit.go
+var (
+	netSplitHostPort                = net.SplitHostPort
+	fmtSprintf                      = fmt.Sprintf
+	fmtErrorf                       = fmt.Errorf
+	mathCeil                        = math.Ceil
+	strconvItoa                     = strconv.Itoa
+	apperrorGetTooManyRequestsError = apperror.GetTooManyRequestsError
+	byClientIPFunc                  = ByClientIP
+	getRouteScopeFunc               = getRouteScope
+	getGlobalRateLimitFunc          = getGlobalRateLimit
+	getStoreFunc                    = getStore
+	getClientKeyFunc                = getClientKey
+	getBucketFunc                   = getBucket
+	takeTokenFunc                   = takeToken
+	refundTokenFunc                 = refundToken
+	getRetryAfterSecondsFunc        = getRetryAfterSeconds
+)
+
+// func pointers for injection / testing: memoryStore.go
+var (
+	timeutilGetTimeNowUTC = timeutil.GetTimeNowUTC
+	replenishFunc         = replenish
+)
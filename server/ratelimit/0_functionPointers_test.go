@@ -0,0 +1,403 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
+	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
+)
+
+var (
+	netSplitHostPortExpected                int
+	netSplitHostPortCalled                  int
+	fmtSprintfExpected                      int
+	fmtSprintfCalled                        int
+	fmtErrorfExpected                       int
+	fmtErrorfCalled                         int
+	mathCeilExpected                        int
+	mathCeilCalled                          int
+	strconvItoaExpected                     int
+	strconvItoaCalled                       int
+	apperrorGetTooManyRequestsErrorExpected int
+	apperrorGetTooManyRequestsErrorCalled   int
+	byClientIPFuncExpected                  int
+	byClientIPFuncCalled                    int
+	getRouteScopeFuncExpected               int
+	getRouteScopeFuncCalled                 int
+	getGlobalRateLimitFuncExpected          int
+	getGlobalRateLimitFuncCalled            int
+	getStoreFuncExpected                    int
+	getStoreFuncCalled                      int
+	getClientKeyFuncExpected                int
+	getClientKeyFuncCalled                  int
+	takeTokenFuncExpected                   int
+	takeTokenFuncCalled                     int
+	refundTokenFuncExpected                 int
+	refundTokenFuncCalled                   int
+	getBucketFuncExpected                   int
+	getBucketFuncCalled                     int
+	getRetryAfterSecondsFuncExpected        int
+	getRetryAfterSecondsFuncCalled          int
+	timeutilGetTimeNowUTCExpected           int
+	timeutilGetTimeNowUTCCalled             int
+	replenishFuncExpected                   int
+	replenishFuncCalled                     int
+	customizationRateLimitExpected          int
+	customizationRateLimitCalled            int
+	customizationRateLimitStoreExpected     int
+	customizationRateLimitStoreCalled       int
+)
+
+func createMock(t *testing.T) {
+	netSplitHostPortExpected = 0
+	netSplitHostPortCalled = 0
+	netSplitHostPort = func(hostport string) (host, port string, err error) {
+		netSplitHostPortCalled++
+		return "", "", nil
+	}
+	fmtSprintfExpected = 0
+	fmtSprintfCalled = 0
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		return ""
+	}
+	fmtErrorfExpected = 0
+	fmtErrorfCalled = 0
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		return nil
+	}
+	mathCeilExpected = 0
+	mathCeilCalled = 0
+	mathCeil = func(x float64) float64 {
+		mathCeilCalled++
+		return 0
+	}
+	strconvItoaExpected = 0
+	strconvItoaCalled = 0
+	strconvItoa = func(i int) string {
+		strconvItoaCalled++
+		return ""
+	}
+	apperrorGetTooManyRequestsErrorExpected = 0
+	apperrorGetTooManyRequestsErrorCalled = 0
+	apperrorGetTooManyRequestsError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetTooManyRequestsErrorCalled++
+		return nil
+	}
+	byClientIPFuncExpected = 0
+	byClientIPFuncCalled = 0
+	byClientIPFunc = func(session sessionModel.Session) string {
+		byClientIPFuncCalled++
+		return ""
+	}
+	getRouteScopeFuncExpected = 0
+	getRouteScopeFuncCalled = 0
+	getRouteScopeFunc = func(route model.Route) string {
+		getRouteScopeFuncCalled++
+		return ""
+	}
+	getGlobalRateLimitFuncExpected = 0
+	getGlobalRateLimitFuncCalled = 0
+	getGlobalRateLimitFunc = func() *model.RateLimit {
+		getGlobalRateLimitFuncCalled++
+		return nil
+	}
+	getStoreFuncExpected = 0
+	getStoreFuncCalled = 0
+	getStoreFunc = func() model.RateLimitStore {
+		getStoreFuncCalled++
+		return nil
+	}
+	getClientKeyFuncExpected = 0
+	getClientKeyFuncCalled = 0
+	getClientKeyFunc = func(session sessionModel.Session, keyFunc model.RateLimitKeyFunc) string {
+		getClientKeyFuncCalled++
+		return ""
+	}
+	takeTokenFuncExpected = 0
+	takeTokenFuncCalled = 0
+	takeTokenFunc = func(session sessionModel.Session, scope string, rateLimit *model.RateLimit) (bool, time.Duration) {
+		takeTokenFuncCalled++
+		return false, 0
+	}
+	refundTokenFuncExpected = 0
+	refundTokenFuncCalled = 0
+	refundTokenFunc = func(session sessionModel.Session, scope string, rateLimit *model.RateLimit) {
+		refundTokenFuncCalled++
+	}
+	getBucketFuncExpected = 0
+	getBucketFuncCalled = 0
+	getBucketFunc = func(session sessionModel.Session, scope string, rateLimit *model.RateLimit) (string, int) {
+		getBucketFuncCalled++
+		return "", 0
+	}
+	getRetryAfterSecondsFuncExpected = 0
+	getRetryAfterSecondsFuncCalled = 0
+	getRetryAfterSecondsFunc = func(retryAfter time.Duration) int {
+		getRetryAfterSecondsFuncCalled++
+		return 0
+	}
+	timeutilGetTimeNowUTCExpected = 0
+	timeutilGetTimeNowUTCCalled = 0
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Time{}
+	}
+	replenishFuncExpected = 0
+	replenishFuncCalled = 0
+	replenishFunc = func(bucket *bucket, now time.Time) {
+		replenishFuncCalled++
+	}
+	customizationRateLimitExpected = 0
+	customizationRateLimitCalled = 0
+	customization.RateLimit = nil
+	customizationRateLimitStoreExpected = 0
+	customizationRateLimitStoreCalled = 0
+	customization.RateLimitStore = nil
+}
+
+func verifyAll(t *testing.T) {
+	netSplitHostPort = net.SplitHostPort
+	assert.Equal(t, netSplitHostPortExpected, netSplitHostPortCalled, "Unexpected number of calls to netSplitHostPort")
+	fmtSprintf = fmt.Sprintf
+	assert.Equal(t, fmtSprintfExpected, fmtSprintfCalled, "Unexpected number of calls to fmtSprintf")
+	fmtErrorf = fmt.Errorf
+	assert.Equal(t, fmtErrorfExpected, fmtErrorfCalled, "Unexpected number of calls to fmtErrorf")
+	mathCeil = math.Ceil
+	assert.Equal(t, mathCeilExpected, mathCeilCalled, "Unexpected number of calls to mathCeil")
+	strconvItoa = strconv.Itoa
+	assert.Equal(t, strconvItoaExpected, strconvItoaCalled, "Unexpected number of calls to strconvItoa")
+	apperrorGetTooManyRequestsError = apperror.GetTooManyRequestsError
+	assert.Equal(t, apperrorGetTooManyRequestsErrorExpected, apperrorGetTooManyRequestsErrorCalled, "Unexpected number of calls to apperrorGetTooManyRequestsError")
+	byClientIPFunc = ByClientIP
+	assert.Equal(t, byClientIPFuncExpected, byClientIPFuncCalled, "Unexpected number of calls to byClientIPFunc")
+	getRouteScopeFunc = getRouteScope
+	assert.Equal(t, getRouteScopeFuncExpected, getRouteScopeFuncCalled, "Unexpected number of calls to getRouteScopeFunc")
+	getGlobalRateLimitFunc = getGlobalRateLimit
+	assert.Equal(t, getGlobalRateLimitFuncExpected, getGlobalRateLimitFuncCalled, "Unexpected number of calls to getGlobalRateLimitFunc")
+	getStoreFunc = getStore
+	assert.Equal(t, getStoreFuncExpected, getStoreFuncCalled, "Unexpected number of calls to getStoreFunc")
+	getClientKeyFunc = getClientKey
+	assert.Equal(t, getClientKeyFuncExpected, getClientKeyFuncCalled, "Unexpected number of calls to getClientKeyFunc")
+	takeTokenFunc = takeToken
+	assert.Equal(t, takeTokenFuncExpected, takeTokenFuncCalled, "Unexpected number of calls to takeTokenFunc")
+	refundTokenFunc = refundToken
+	assert.Equal(t, refundTokenFuncExpected, refundTokenFuncCalled, "Unexpected number of calls to refundTokenFunc")
+	getBucketFunc = getBucket
+	assert.Equal(t, getBucketFuncExpected, getBucketFuncCalled, "Unexpected number of calls to getBucketFunc")
+	getRetryAfterSecondsFunc = getRetryAfterSeconds
+	assert.Equal(t, getRetryAfterSecondsFuncExpected, getRetryAfterSecondsFuncCalled, "Unexpected number of calls to getRetryAfterSecondsFunc")
+	timeutilGetTimeNowUTC = timeutil.GetTimeNowUTC
+	assert.Equal(t, timeutilGetTimeNowUTCExpected, timeutilGetTimeNowUTCCalled, "Unexpected number of calls to timeutilGetTimeNowUTC")
+	replenishFunc = replenish
+	assert.Equal(t, replenishFuncExpected, replenishFuncCalled, "Unexpected number of calls to replenishFunc")
+	customization.RateLimit = nil
+	assert.Equal(t, customizationRateLimitExpected, customizationRateLimitCalled, "Unexpected number of calls to customization.RateLimit")
+	customization.RateLimitStore = nil
+	assert.Equal(t, customizationRateLimitStoreExpected, customizationRateLimitStoreCalled, "Unexpected number of calls to customization.RateLimitStore")
+}
+
+// mock structs
+type dummyStore struct {
+	t          *testing.T
+	key        string
+	rate       float64
+	burst      int
+	granted    bool
+	retryAfter time.Duration
+	called     int
+	refunded   int
+}
+
+func (store *dummyStore) Take(key string, rate float64, burst int) (bool, time.Duration) {
+	store.called++
+	assert.Equal(store.t, store.key, key)
+	assert.Equal(store.t, store.rate, rate)
+	assert.Equal(store.t, store.burst, burst)
+	return store.granted, store.retryAfter
+}
+
+func (store *dummyStore) Refund(key string, rate float64, burst int) {
+	store.refunded++
+	assert.Equal(store.t, store.key, key)
+	assert.Equal(store.t, store.rate, rate)
+	assert.Equal(store.t, store.burst, burst)
+}
+
+type dummyResponseWriter struct {
+	t      *testing.T
+	header http.Header
+}
+
+func (drw *dummyResponseWriter) Header() http.Header {
+	return drw.header
+}
+
+func (drw *dummyResponseWriter) Write(bytes []byte) (int, error) {
+	assert.Fail(drw.t, "Unexpected call to Write")
+	return 0, nil
+}
+
+func (drw *dummyResponseWriter) WriteHeader(statusCode int) {
+	assert.Fail(drw.t, "Unexpected call to WriteHeader")
+}
+
+type dummySession struct {
+	t              *testing.T
+	httpRequest    *http.Request
+	responseWriter http.ResponseWriter
+}
+
+func (session *dummySession) GetID() uuid.UUID {
+	assert.Fail(session.t, "Unexpected call to GetID")
+	return uuid.Nil
+}
+
+func (session *dummySession) GetName() string {
+	assert.Fail(session.t, "Unexpected call to GetName")
+	return ""
+}
+
+func (session *dummySession) GetCorrelationID() string {
+	assert.Fail(session.t, "Unexpected call to GetCorrelationID")
+	return ""
+}
+
+func (session *dummySession) GetRequest() *http.Request {
+	if session.httpRequest == nil {
+		assert.Fail(session.t, "Unexpected call to GetRequest")
+	}
+	return session.httpRequest
+}
+
+func (session *dummySession) GetResponseWriter() http.ResponseWriter {
+	if session.responseWriter == nil {
+		assert.Fail(session.t, "Unexpected call to GetResponseWriter")
+	}
+	return session.responseWriter
+}
+
+func (session *dummySession) SetResponseETag(etag string, weak bool) {
+	assert.Fail(session.t, "Unexpected call to SetResponseETag")
+}
+
+func (session *dummySession) SetResponseLastModified(lastModified time.Time) {
+	assert.Fail(session.t, "Unexpected call to SetResponseLastModified")
+}
+
+func (session *dummySession) GetRequestBody(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestBody")
+	return nil
+}
+
+func (session *dummySession) GetRequestParameter(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestParameter")
+	return nil
+}
+
+func (session *dummySession) GetRequestQuery(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestQuery")
+	return nil
+}
+
+func (session *dummySession) GetRequestQueries(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestQueries")
+	return nil
+}
+
+func (session *dummySession) GetRequestHeader(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestHeader")
+	return nil
+}
+
+func (session *dummySession) GetRequestHeaders(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestHeaders")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValue(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValue")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValues(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValues")
+	return nil
+}
+
+func (session *dummySession) GetRequestFile(name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFile")
+	return nil
+}
+
+func (session *dummySession) BindRequest(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to BindRequest")
+	return nil
+}
+
+func (session *dummySession) Attach(name string, value interface{}) bool {
+	assert.Fail(session.t, "Unexpected call to Attach")
+	return false
+}
+
+func (session *dummySession) Detach(name string) bool {
+	assert.Fail(session.t, "Unexpected call to Detach")
+	return false
+}
+
+func (session *dummySession) GetRawAttachment(name string) (interface{}, bool) {
+	assert.Fail(session.t, "Unexpected call to GetRawAttachment")
+	return nil, false
+}
+
+func (session *dummySession) GetAttachment(name string, dataTemplate interface{}) bool {
+	assert.Fail(session.t, "Unexpected call to GetAttachment")
+	return false
+}
+
+func (session *dummySession) IsLoggingAllowed(logType logtype.LogType, logLevel loglevel.LogLevel) bool {
+	assert.Fail(session.t, "Unexpected call to IsLoggingAllowed")
+	return false
+}
+
+func (session *dummySession) LogMethodEnter() {
+	assert.Fail(session.t, "Unexpected call to LogMethodEnter")
+}
+
+func (session *dummySession) LogMethodParameter(parameters ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodParameter")
+}
+
+func (session *dummySession) LogMethodLogic(logLevel loglevel.LogLevel, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodLogic")
+}
+
+func (session *dummySession) LogMethodReturn(returns ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodReturn")
+}
+
+func (session *dummySession) LogMethodExit() {
+	assert.Fail(session.t, "Unexpected call to LogMethodExit")
+}
+
+func (session *dummySession) CreateNetworkRequest(method string, url string, payload string, header map[string]string) networkModel.NetworkRequest {
+	assert.Fail(session.t, "Unexpected call to CreateNetworkRequest")
+	return nil
+}
@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+const (
+	cleanupInterval = time.Minute
+)
+
+type bucket struct {
+	tokens  float64
+	rate    float64
+	burst   int
+	updated time.Time
+}
+
+type memoryStore struct {
+	lock        sync.Mutex
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+}
+
+// NewMemoryStore creates an in-memory token bucket store for rate limiting of a single instance; fully replenished buckets are evicted periodically
+func NewMemoryStore() model.RateLimitStore {
+	return &memoryStore{
+		buckets: map[string]*bucket{},
+	}
+}
+
+func replenish(bucket *bucket, now time.Time) {
+	var elapsed = now.Sub(bucket.updated)
+	if elapsed > 0 {
+		bucket.tokens += elapsed.Seconds() * bucket.rate
+		bucket.updated = now
+	}
+	if bucket.tokens > float64(bucket.burst) {
+		bucket.tokens = float64(bucket.burst)
+	}
+}
+
+func (store *memoryStore) cleanup(now time.Time) {
+	if now.Sub(store.lastCleanup) < cleanupInterval {
+		return
+	}
+	store.lastCleanup = now
+	for key, bucket := range store.buckets {
+		replenishFunc(bucket, now)
+		if bucket.tokens >= float64(bucket.burst) {
+			delete(store.buckets, key)
+		}
+	}
+}
+
+// Take takes a token from the bucket identified by the given key, creating a full bucket if not yet present
+func (store *memoryStore) Take(key string, rate float64, burst int) (bool, time.Duration) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	var now = timeutilGetTimeNowUTC()
+	store.cleanup(now)
+	var tokenBucket, found = store.buckets[key]
+	if !found {
+		tokenBucket = &bucket{
+			tokens:  float64(burst),
+			updated: now,
+		}
+		store.buckets[key] = tokenBucket
+	}
+	tokenBucket.rate = rate
+	tokenBucket.burst = burst
+	replenishFunc(tokenBucket, now)
+	if tokenBucket.tokens >= 1 {
+		tokenBucket.tokens--
+		return true, 0
+	}
+	var waitSeconds = (1 - tokenBucket.tokens) / rate
+	return false, time.Duration(waitSeconds * float64(time.Second))
+}
+
+// Refund returns a token to the bucket identified by the given key; buckets no longer present are already full, thus left untouched
+func (store *memoryStore) Refund(key string, rate float64, burst int) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	var tokenBucket, found = store.buckets[key]
+	if !found {
+		return
+	}
+	tokenBucket.rate = rate
+	tokenBucket.burst = burst
+	replenishFunc(tokenBucket, timeutilGetTimeNowUTC())
+	tokenBucket.tokens++
+	if tokenBucket.tokens > float64(burst) {
+		tokenBucket.tokens = float64(burst)
+	}
+}
@@ -0,0 +1,431 @@
+package ratelimit
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMemoryStore(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = NewMemoryStore()
+
+	// assert
+	var store, ok = result.(*memoryStore)
+	assert.True(t, ok)
+	assert.NotNil(t, store.buckets)
+	assert.Empty(t, store.buckets)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestReplenish_Partial(t *testing.T) {
+	// arrange
+	var dummyNow = time.Now()
+	var dummyBucket = &bucket{
+		tokens:  0.5,
+		rate:    2,
+		burst:   5,
+		updated: dummyNow.Add(-time.Second),
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	replenish(
+		dummyBucket,
+		dummyNow,
+	)
+
+	// assert
+	assert.Equal(t, 2.5, dummyBucket.tokens)
+	assert.Equal(t, dummyNow, dummyBucket.updated)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestReplenish_CappedByBurst(t *testing.T) {
+	// arrange
+	var dummyNow = time.Now()
+	var dummyBucket = &bucket{
+		tokens:  0.5,
+		rate:    2,
+		burst:   5,
+		updated: dummyNow.Add(-time.Hour),
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	replenish(
+		dummyBucket,
+		dummyNow,
+	)
+
+	// assert
+	assert.Equal(t, 5.0, dummyBucket.tokens)
+	assert.Equal(t, dummyNow, dummyBucket.updated)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestReplenish_ClockSkew(t *testing.T) {
+	// arrange
+	var dummyNow = time.Now()
+	var dummyUpdated = dummyNow.Add(time.Second)
+	var dummyBucket = &bucket{
+		tokens:  0.5,
+		rate:    2,
+		burst:   5,
+		updated: dummyUpdated,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	replenish(
+		dummyBucket,
+		dummyNow,
+	)
+
+	// assert
+	assert.Equal(t, 0.5, dummyBucket.tokens)
+	assert.Equal(t, dummyUpdated, dummyBucket.updated)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMemoryStoreCleanup_TooSoon(t *testing.T) {
+	// arrange
+	var dummyNow = time.Now()
+	var dummyLastCleanup = dummyNow.Add(-time.Second)
+	var dummyBucket = &bucket{}
+	var store = &memoryStore{
+		buckets: map[string]*bucket{
+			"some key": dummyBucket,
+		},
+		lastCleanup: dummyLastCleanup,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	store.cleanup(
+		dummyNow,
+	)
+
+	// assert
+	assert.Equal(t, dummyLastCleanup, store.lastCleanup)
+	assert.Equal(t, 1, len(store.buckets))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMemoryStoreCleanup_EvictFullBuckets(t *testing.T) {
+	// arrange
+	var dummyNow = time.Now()
+	var dummyFullBucket = &bucket{burst: 3}
+	var dummyPartialBucket = &bucket{burst: 3}
+	var store = &memoryStore{
+		buckets: map[string]*bucket{
+			"full key":    dummyFullBucket,
+			"partial key": dummyPartialBucket,
+		},
+		lastCleanup: dummyNow.Add(-time.Hour),
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	replenishFuncExpected = 2
+	replenishFunc = func(bucket *bucket, now time.Time) {
+		replenishFuncCalled++
+		assert.Equal(t, dummyNow, now)
+		if bucket == dummyFullBucket {
+			bucket.tokens = 3
+		} else {
+			bucket.tokens = 2
+		}
+	}
+
+	// SUT + act
+	store.cleanup(
+		dummyNow,
+	)
+
+	// assert
+	assert.Equal(t, dummyNow, store.lastCleanup)
+	assert.Equal(t, 1, len(store.buckets))
+	assert.Equal(t, dummyPartialBucket, store.buckets["partial key"])
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMemoryStoreTake_NewBucket(t *testing.T) {
+	// arrange
+	var dummyNow = time.Now()
+	var dummyKey = "some key"
+	var dummyRate = rand.Float64() + 1
+	var dummyBurst = rand.Intn(10) + 2
+	var store = &memoryStore{
+		buckets:     map[string]*bucket{},
+		lastCleanup: dummyNow,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	replenishFuncExpected = 1
+	replenishFunc = func(bucket *bucket, now time.Time) {
+		replenishFuncCalled++
+		assert.Equal(t, float64(dummyBurst), bucket.tokens)
+		assert.Equal(t, dummyRate, bucket.rate)
+		assert.Equal(t, dummyBurst, bucket.burst)
+		assert.Equal(t, dummyNow, bucket.updated)
+		assert.Equal(t, dummyNow, now)
+	}
+
+	// SUT + act
+	var granted, retryAfter = store.Take(
+		dummyKey,
+		dummyRate,
+		dummyBurst,
+	)
+
+	// assert
+	assert.True(t, granted)
+	assert.Zero(t, retryAfter)
+	assert.Equal(t, float64(dummyBurst-1), store.buckets[dummyKey].tokens)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMemoryStoreTake_Exhausted(t *testing.T) {
+	// arrange
+	var dummyNow = time.Now()
+	var dummyKey = "some key"
+	var dummyBucket = &bucket{
+		tokens:  0.5,
+		rate:    1,
+		burst:   1,
+		updated: dummyNow,
+	}
+	var store = &memoryStore{
+		buckets: map[string]*bucket{
+			dummyKey: dummyBucket,
+		},
+		lastCleanup: dummyNow,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	replenishFuncExpected = 1
+	replenishFunc = func(bucket *bucket, now time.Time) {
+		replenishFuncCalled++
+		assert.Equal(t, dummyBucket, bucket)
+		assert.Equal(t, 4.0, bucket.rate)
+		assert.Equal(t, 2, bucket.burst)
+	}
+
+	// SUT + act
+	var granted, retryAfter = store.Take(
+		dummyKey,
+		4,
+		2,
+	)
+
+	// assert
+	assert.False(t, granted)
+	assert.Equal(t, 125*time.Millisecond, retryAfter)
+	assert.Equal(t, 0.5, dummyBucket.tokens)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMemoryStoreTake_Integration(t *testing.T) {
+	// arrange
+	var dummyNow = time.Now()
+	var dummyKey = "some key"
+	var store = NewMemoryStore()
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 4
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		if timeutilGetTimeNowUTCCalled < 4 {
+			return dummyNow
+		}
+		return dummyNow.Add(500 * time.Millisecond)
+	}
+	replenishFuncExpected = 4
+	replenishFunc = func(bucket *bucket, now time.Time) {
+		replenishFuncCalled++
+		replenish(bucket, now)
+	}
+
+	// SUT + act
+	var granted1, _ = store.Take(dummyKey, 2, 2)
+	var granted2, _ = store.Take(dummyKey, 2, 2)
+	var granted3, retryAfter3 = store.Take(dummyKey, 2, 2)
+	var granted4, _ = store.Take(dummyKey, 2, 2)
+
+	// assert
+	assert.True(t, granted1)
+	assert.True(t, granted2)
+	assert.False(t, granted3)
+	assert.Equal(t, 500*time.Millisecond, retryAfter3)
+	assert.True(t, granted4)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMemoryStoreRefund_NotFound(t *testing.T) {
+	// arrange
+	var dummyKey = "some key"
+	var store = &memoryStore{
+		buckets: map[string]*bucket{},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	store.Refund(
+		dummyKey,
+		4,
+		2,
+	)
+
+	// assert
+	assert.Empty(t, store.buckets)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMemoryStoreRefund_Refunded(t *testing.T) {
+	// arrange
+	var dummyNow = time.Now()
+	var dummyKey = "some key"
+	var dummyBucket = &bucket{
+		tokens:  0.5,
+		rate:    1,
+		burst:   1,
+		updated: dummyNow,
+	}
+	var store = &memoryStore{
+		buckets: map[string]*bucket{
+			dummyKey: dummyBucket,
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	replenishFuncExpected = 1
+	replenishFunc = func(bucket *bucket, now time.Time) {
+		replenishFuncCalled++
+		assert.Equal(t, dummyBucket, bucket)
+		assert.Equal(t, dummyNow, now)
+		assert.Equal(t, 4.0, bucket.rate)
+		assert.Equal(t, 2, bucket.burst)
+	}
+
+	// SUT + act
+	store.Refund(
+		dummyKey,
+		4,
+		2,
+	)
+
+	// assert
+	assert.Equal(t, 1.5, dummyBucket.tokens)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestMemoryStoreRefund_CappedByBurst(t *testing.T) {
+	// arrange
+	var dummyNow = time.Now()
+	var dummyKey = "some key"
+	var dummyBucket = &bucket{
+		tokens:  1.5,
+		rate:    1,
+		burst:   2,
+		updated: dummyNow,
+	}
+	var store = &memoryStore{
+		buckets: map[string]*bucket{
+			dummyKey: dummyBucket,
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyNow
+	}
+	replenishFuncExpected = 1
+	replenishFunc = func(bucket *bucket, now time.Time) {
+		replenishFuncCalled++
+	}
+
+	// SUT + act
+	store.Refund(
+		dummyKey,
+		1,
+		2,
+	)
+
+	// assert
+	assert.Equal(t, 2.0, dummyBucket.tokens)
+
+	// verify
+	verifyAll(t)
+}
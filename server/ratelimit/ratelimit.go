@@ -0,0 +1,181 @@
+package ratelimit
+
+import (
+	"time"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+// These are the HTTP headers written by rate limiting
+const (
+	RetryAfterHeader = "Retry-After"
+)
+
+const (
+	globalScope  = "*"
+	keySeparator = "|"
+	minBurst     = 1
+	minRetrySecs = 1
+)
+
+var (
+	defaultStore = NewMemoryStore()
+)
+
+// ByClientIP returns the client IP of the given session as rate limiting key, taken from the remote address of the HTTP request
+func ByClientIP(session sessionModel.Session) string {
+	var httpRequest = session.GetRequest()
+	var host, _, splitError = netSplitHostPort(
+		httpRequest.RemoteAddr,
+	)
+	if splitError != nil {
+		return httpRequest.RemoteAddr
+	}
+	return host
+}
+
+// ByClientCertSubject returns the subject of the client certificate of the given session as rate limiting key, or the client IP if no client certificate is presented
+func ByClientCertSubject(session sessionModel.Session) string {
+	var httpRequest = session.GetRequest()
+	if httpRequest.TLS == nil ||
+		len(httpRequest.TLS.PeerCertificates) == 0 {
+		return byClientIPFunc(session)
+	}
+	return httpRequest.TLS.PeerCertificates[0].Subject.String()
+}
+
+// getRouteScope returns the bucket scope of the given route by its method and full path template, so that routes of different groups sharing the same endpoint never share buckets
+func getRouteScope(route model.Route) string {
+	return fmtSprintf(
+		"%v:%v",
+		route.Method,
+		route.Path,
+	)
+}
+
+func getGlobalRateLimit() *model.RateLimit {
+	if customization.RateLimit == nil {
+		return nil
+	}
+	return customization.RateLimit()
+}
+
+func getStore() model.RateLimitStore {
+	if customization.RateLimitStore == nil {
+		return defaultStore
+	}
+	var store = customization.RateLimitStore()
+	if store == nil {
+		return defaultStore
+	}
+	return store
+}
+
+func getClientKey(session sessionModel.Session, keyFunc model.RateLimitKeyFunc) string {
+	if keyFunc == nil {
+		return byClientIPFunc(session)
+	}
+	return keyFunc(session)
+}
+
+func getBucket(session sessionModel.Session, scope string, rateLimit *model.RateLimit) (string, int) {
+	if rateLimit == nil ||
+		rateLimit.Rate <= 0 {
+		return "", 0
+	}
+	var clientKey = getClientKeyFunc(
+		session,
+		rateLimit.KeyFunc,
+	)
+	if clientKey == "" {
+		return "", 0
+	}
+	var burst = rateLimit.Burst
+	if burst < minBurst {
+		burst = minBurst
+	}
+	return scope + keySeparator + clientKey, burst
+}
+
+func takeToken(session sessionModel.Session, scope string, rateLimit *model.RateLimit) (bool, time.Duration) {
+	var key, burst = getBucketFunc(
+		session,
+		scope,
+		rateLimit,
+	)
+	if key == "" {
+		return true, 0
+	}
+	return getStoreFunc().Take(
+		key,
+		rateLimit.Rate,
+		burst,
+	)
+}
+
+func refundToken(session sessionModel.Session, scope string, rateLimit *model.RateLimit) {
+	var key, burst = getBucketFunc(
+		session,
+		scope,
+		rateLimit,
+	)
+	if key == "" {
+		return
+	}
+	getStoreFunc().Refund(
+		key,
+		rateLimit.Rate,
+		burst,
+	)
+}
+
+func getRetryAfterSeconds(retryAfter time.Duration) int {
+	var seconds = int(mathCeil(retryAfter.Seconds()))
+	if seconds < minRetrySecs {
+		return minRetrySecs
+	}
+	return seconds
+}
+
+// Check takes a token for the given session from both global and the given route rate limiting, and returns a TooManyRequests error together with the Retry-After response header if either is exhausted; the global token is refunded if the route rate limiting rejects the session
+func Check(session sessionModel.Session, route model.Route) error {
+	var globalRateLimit = getGlobalRateLimitFunc()
+	var granted, retryAfter = takeTokenFunc(
+		session,
+		globalScope,
+		globalRateLimit,
+	)
+	if granted {
+		granted, retryAfter = takeTokenFunc(
+			session,
+			getRouteScopeFunc(route),
+			route.RateLimit,
+		)
+		if !granted {
+			refundTokenFunc(
+				session,
+				globalScope,
+				globalRateLimit,
+			)
+		}
+	}
+	if granted {
+		return nil
+	}
+	var retryAfterSeconds = getRetryAfterSecondsFunc(
+		retryAfter,
+	)
+	session.GetResponseWriter().Header().Set(
+		RetryAfterHeader,
+		strconvItoa(retryAfterSeconds),
+	)
+	return apperrorGetTooManyRequestsError(
+		fmtErrorf(
+			"Rate limit exceeded for endpoint [%v]; retry after [%v] seconds",
+			route.Endpoint,
+			retryAfterSeconds,
+		),
+	)
+}
@@ -0,0 +1,1097 @@
+package ratelimit
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+)
+
+func TestByClientIP_SplitError(t *testing.T) {
+	// arrange
+	var dummyRemoteAddr = "some remote address"
+	var dummySessionObject = &dummySession{
+		t: t,
+		httpRequest: &http.Request{
+			RemoteAddr: dummyRemoteAddr,
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	netSplitHostPortExpected = 1
+	netSplitHostPort = func(hostport string) (string, string, error) {
+		netSplitHostPortCalled++
+		assert.Equal(t, dummyRemoteAddr, hostport)
+		return "", "", errors.New("some error")
+	}
+
+	// SUT + act
+	var result = ByClientIP(
+		dummySessionObject,
+	)
+
+	// assert
+	assert.Equal(t, dummyRemoteAddr, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestByClientIP_Success(t *testing.T) {
+	// arrange
+	var dummyRemoteAddr = "some remote address"
+	var dummyHost = "some host"
+	var dummySessionObject = &dummySession{
+		t: t,
+		httpRequest: &http.Request{
+			RemoteAddr: dummyRemoteAddr,
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	netSplitHostPortExpected = 1
+	netSplitHostPort = func(hostport string) (string, string, error) {
+		netSplitHostPortCalled++
+		assert.Equal(t, dummyRemoteAddr, hostport)
+		return dummyHost, "some port", nil
+	}
+
+	// SUT + act
+	var result = ByClientIP(
+		dummySessionObject,
+	)
+
+	// assert
+	assert.Equal(t, dummyHost, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestByClientCertSubject_NoTLS(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{
+		t:           t,
+		httpRequest: &http.Request{},
+	}
+	var dummyClientIP = "some client IP"
+
+	// mock
+	createMock(t)
+
+	// expect
+	byClientIPFuncExpected = 1
+	byClientIPFunc = func(session sessionModel.Session) string {
+		byClientIPFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		return dummyClientIP
+	}
+
+	// SUT + act
+	var result = ByClientCertSubject(
+		dummySessionObject,
+	)
+
+	// assert
+	assert.Equal(t, dummyClientIP, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestByClientCertSubject_NoPeerCertificates(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{
+		t: t,
+		httpRequest: &http.Request{
+			TLS: &tls.ConnectionState{},
+		},
+	}
+	var dummyClientIP = "some client IP"
+
+	// mock
+	createMock(t)
+
+	// expect
+	byClientIPFuncExpected = 1
+	byClientIPFunc = func(session sessionModel.Session) string {
+		byClientIPFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		return dummyClientIP
+	}
+
+	// SUT + act
+	var result = ByClientCertSubject(
+		dummySessionObject,
+	)
+
+	// assert
+	assert.Equal(t, dummyClientIP, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestByClientCertSubject_WithPeerCertificates(t *testing.T) {
+	// arrange
+	var dummySubject = pkix.Name{
+		CommonName:   "some common name",
+		Organization: []string{"some organization"},
+	}
+	var dummySessionObject = &dummySession{
+		t: t,
+		httpRequest: &http.Request{
+			TLS: &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{
+					{Subject: dummySubject},
+				},
+			},
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = ByClientCertSubject(
+		dummySessionObject,
+	)
+
+	// assert
+	assert.Equal(t, dummySubject.String(), result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRouteScope(t *testing.T) {
+	// arrange
+	var dummyRoute = model.Route{
+		Endpoint: "some endpoint",
+		Method:   "some method",
+		Path:     "some path",
+	}
+	var dummyRouteScope = "some route scope"
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		assert.Equal(t, "%v:%v", format)
+		assert.Equal(t, 2, len(a))
+		assert.Equal(t, dummyRoute.Method, a[0])
+		assert.Equal(t, dummyRoute.Path, a[1])
+		return dummyRouteScope
+	}
+
+	// SUT + act
+	var result = getRouteScope(
+		dummyRoute,
+	)
+
+	// assert
+	assert.Equal(t, dummyRouteScope, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetGlobalRateLimit_NotSet(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getGlobalRateLimit()
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetGlobalRateLimit_Customized(t *testing.T) {
+	// arrange
+	var dummyRateLimit = &model.RateLimit{Rate: rand.Float64(), Burst: rand.Intn(100)}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationRateLimitExpected = 1
+	customization.RateLimit = func() *model.RateLimit {
+		customizationRateLimitCalled++
+		return dummyRateLimit
+	}
+
+	// SUT + act
+	var result = getGlobalRateLimit()
+
+	// assert
+	assert.Equal(t, dummyRateLimit, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetStore_NotSet(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getStore()
+
+	// assert
+	assert.Equal(t, defaultStore, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetStore_CustomizedNil(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// expect
+	customizationRateLimitStoreExpected = 1
+	customization.RateLimitStore = func() model.RateLimitStore {
+		customizationRateLimitStoreCalled++
+		return nil
+	}
+
+	// SUT + act
+	var result = getStore()
+
+	// assert
+	assert.Equal(t, defaultStore, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetStore_Customized(t *testing.T) {
+	// arrange
+	var dummyStoreObject = &dummyStore{t: t}
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationRateLimitStoreExpected = 1
+	customization.RateLimitStore = func() model.RateLimitStore {
+		customizationRateLimitStoreCalled++
+		return dummyStoreObject
+	}
+
+	// SUT + act
+	var result = getStore()
+
+	// assert
+	assert.Equal(t, dummyStoreObject, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetClientKey_NoKeyFunc(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyClientIP = "some client IP"
+
+	// mock
+	createMock(t)
+
+	// expect
+	byClientIPFuncExpected = 1
+	byClientIPFunc = func(session sessionModel.Session) string {
+		byClientIPFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		return dummyClientIP
+	}
+
+	// SUT + act
+	var result = getClientKey(
+		dummySessionObject,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, dummyClientIP, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetClientKey_WithKeyFunc(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyClientKey = "some client key"
+	var dummyKeyFuncExpected = 1
+	var dummyKeyFuncCalled = 0
+	var dummyKeyFunc = func(session sessionModel.Session) string {
+		dummyKeyFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		return dummyClientKey
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getClientKey(
+		dummySessionObject,
+		dummyKeyFunc,
+	)
+
+	// assert
+	assert.Equal(t, dummyClientKey, result)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyKeyFuncExpected, dummyKeyFuncCalled, "Unexpected number of calls to dummyKeyFunc")
+}
+
+func TestGetBucket_NilRateLimit(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var key, burst = getBucket(
+		dummySessionObject,
+		"some scope",
+		nil,
+	)
+
+	// assert
+	assert.Empty(t, key)
+	assert.Zero(t, burst)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetBucket_NonPositiveRate(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyRateLimit = &model.RateLimit{Rate: -rand.Float64(), Burst: rand.Intn(100)}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var key, burst = getBucket(
+		dummySessionObject,
+		"some scope",
+		dummyRateLimit,
+	)
+
+	// assert
+	assert.Empty(t, key)
+	assert.Zero(t, burst)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetBucket_EmptyClientKey(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyRateLimit = &model.RateLimit{
+		Rate:  rand.Float64() + 1,
+		Burst: rand.Intn(100),
+		KeyFunc: func(session sessionModel.Session) string {
+			return "some key"
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getClientKeyFuncExpected = 1
+	getClientKeyFunc = func(session sessionModel.Session, keyFunc model.RateLimitKeyFunc) string {
+		getClientKeyFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyRateLimit.KeyFunc)), fmt.Sprintf("%v", reflect.ValueOf(keyFunc)))
+		return ""
+	}
+
+	// SUT + act
+	var key, burst = getBucket(
+		dummySessionObject,
+		"some scope",
+		dummyRateLimit,
+	)
+
+	// assert
+	assert.Empty(t, key)
+	assert.Zero(t, burst)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetBucket_MinimumBurst(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyScope = "some scope"
+	var dummyClientKey = "some client key"
+	var dummyRateLimit = &model.RateLimit{
+		Rate:  rand.Float64() + 1,
+		Burst: -rand.Intn(100),
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getClientKeyFuncExpected = 1
+	getClientKeyFunc = func(session sessionModel.Session, keyFunc model.RateLimitKeyFunc) string {
+		getClientKeyFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Nil(t, keyFunc)
+		return dummyClientKey
+	}
+
+	// SUT + act
+	var key, burst = getBucket(
+		dummySessionObject,
+		dummyScope,
+		dummyRateLimit,
+	)
+
+	// assert
+	assert.Equal(t, dummyScope+"|"+dummyClientKey, key)
+	assert.Equal(t, 1, burst)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetBucket_Success(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyScope = "some scope"
+	var dummyClientKey = "some client key"
+	var dummyRateLimit = &model.RateLimit{
+		Rate:  rand.Float64() + 1,
+		Burst: rand.Intn(100) + 1,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getClientKeyFuncExpected = 1
+	getClientKeyFunc = func(session sessionModel.Session, keyFunc model.RateLimitKeyFunc) string {
+		getClientKeyFuncCalled++
+		return dummyClientKey
+	}
+
+	// SUT + act
+	var key, burst = getBucket(
+		dummySessionObject,
+		dummyScope,
+		dummyRateLimit,
+	)
+
+	// assert
+	assert.Equal(t, dummyScope+"|"+dummyClientKey, key)
+	assert.Equal(t, dummyRateLimit.Burst, burst)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestTakeToken_NotLimited(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyScope = "some scope"
+	var dummyRateLimit = &model.RateLimit{Rate: rand.Float64()}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getBucketFuncExpected = 1
+	getBucketFunc = func(session sessionModel.Session, scope string, rateLimit *model.RateLimit) (string, int) {
+		getBucketFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyScope, scope)
+		assert.Equal(t, dummyRateLimit, rateLimit)
+		return "", 0
+	}
+
+	// SUT + act
+	var granted, retryAfter = takeToken(
+		dummySessionObject,
+		dummyScope,
+		dummyRateLimit,
+	)
+
+	// assert
+	assert.True(t, granted)
+	assert.Zero(t, retryAfter)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestTakeToken_Taken(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyScope = "some scope"
+	var dummyKey = "some key"
+	var dummyBurst = rand.Intn(100) + 1
+	var dummyRateLimit = &model.RateLimit{
+		Rate: rand.Float64() + 1,
+	}
+	var dummyGranted = rand.Intn(100) < 50
+	var dummyRetryAfter = time.Duration(rand.Intn(1000))
+	var dummyStoreObject = &dummyStore{
+		t:          t,
+		key:        dummyKey,
+		rate:       dummyRateLimit.Rate,
+		burst:      dummyBurst,
+		granted:    dummyGranted,
+		retryAfter: dummyRetryAfter,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getBucketFuncExpected = 1
+	getBucketFunc = func(session sessionModel.Session, scope string, rateLimit *model.RateLimit) (string, int) {
+		getBucketFuncCalled++
+		return dummyKey, dummyBurst
+	}
+	getStoreFuncExpected = 1
+	getStoreFunc = func() model.RateLimitStore {
+		getStoreFuncCalled++
+		return dummyStoreObject
+	}
+
+	// SUT + act
+	var granted, retryAfter = takeToken(
+		dummySessionObject,
+		dummyScope,
+		dummyRateLimit,
+	)
+
+	// assert
+	assert.Equal(t, dummyGranted, granted)
+	assert.Equal(t, dummyRetryAfter, retryAfter)
+	assert.Equal(t, 1, dummyStoreObject.called)
+	assert.Zero(t, dummyStoreObject.refunded)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRefundToken_NotLimited(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyScope = "some scope"
+	var dummyRateLimit = &model.RateLimit{Rate: rand.Float64()}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getBucketFuncExpected = 1
+	getBucketFunc = func(session sessionModel.Session, scope string, rateLimit *model.RateLimit) (string, int) {
+		getBucketFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyScope, scope)
+		assert.Equal(t, dummyRateLimit, rateLimit)
+		return "", 0
+	}
+
+	// SUT + act
+	refundToken(
+		dummySessionObject,
+		dummyScope,
+		dummyRateLimit,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRefundToken_Refunded(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyScope = "some scope"
+	var dummyKey = "some key"
+	var dummyBurst = rand.Intn(100) + 1
+	var dummyRateLimit = &model.RateLimit{
+		Rate: rand.Float64() + 1,
+	}
+	var dummyStoreObject = &dummyStore{
+		t:     t,
+		key:   dummyKey,
+		rate:  dummyRateLimit.Rate,
+		burst: dummyBurst,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getBucketFuncExpected = 1
+	getBucketFunc = func(session sessionModel.Session, scope string, rateLimit *model.RateLimit) (string, int) {
+		getBucketFuncCalled++
+		return dummyKey, dummyBurst
+	}
+	getStoreFuncExpected = 1
+	getStoreFunc = func() model.RateLimitStore {
+		getStoreFuncCalled++
+		return dummyStoreObject
+	}
+
+	// SUT + act
+	refundToken(
+		dummySessionObject,
+		dummyScope,
+		dummyRateLimit,
+	)
+
+	// assert
+	assert.Zero(t, dummyStoreObject.called)
+	assert.Equal(t, 1, dummyStoreObject.refunded)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryAfterSeconds_BelowMinimum(t *testing.T) {
+	// arrange
+	var dummyRetryAfter = time.Duration(rand.Intn(1000))
+
+	// mock
+	createMock(t)
+
+	// expect
+	mathCeilExpected = 1
+	mathCeil = func(x float64) float64 {
+		mathCeilCalled++
+		assert.Equal(t, dummyRetryAfter.Seconds(), x)
+		return 0
+	}
+
+	// SUT + act
+	var result = getRetryAfterSeconds(
+		dummyRetryAfter,
+	)
+
+	// assert
+	assert.Equal(t, 1, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRetryAfterSeconds_AboveMinimum(t *testing.T) {
+	// arrange
+	var dummyRetryAfter = 2500 * time.Millisecond
+
+	// mock
+	createMock(t)
+
+	// expect
+	mathCeilExpected = 1
+	mathCeil = func(x float64) float64 {
+		mathCeilCalled++
+		return math.Ceil(x)
+	}
+
+	// SUT + act
+	var result = getRetryAfterSeconds(
+		dummyRetryAfter,
+	)
+
+	// assert
+	assert.Equal(t, 3, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheck_Granted(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{t: t}
+	var dummyRoute = model.Route{
+		Endpoint:  "some endpoint",
+		Method:    "some method",
+		Path:      "some path",
+		RateLimit: &model.RateLimit{Rate: rand.Float64()},
+	}
+	var dummyRouteScope = "some route scope"
+	var dummyGlobalRateLimit = &model.RateLimit{Rate: rand.Float64()}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getGlobalRateLimitFuncExpected = 1
+	getGlobalRateLimitFunc = func() *model.RateLimit {
+		getGlobalRateLimitFuncCalled++
+		return dummyGlobalRateLimit
+	}
+	getRouteScopeFuncExpected = 1
+	getRouteScopeFunc = func(route model.Route) string {
+		getRouteScopeFuncCalled++
+		assert.Equal(t, dummyRoute, route)
+		return dummyRouteScope
+	}
+	takeTokenFuncExpected = 2
+	takeTokenFunc = func(session sessionModel.Session, scope string, rateLimit *model.RateLimit) (bool, time.Duration) {
+		takeTokenFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		if takeTokenFuncCalled == 1 {
+			assert.Equal(t, globalScope, scope)
+			assert.Equal(t, dummyGlobalRateLimit, rateLimit)
+		} else if takeTokenFuncCalled == 2 {
+			assert.Equal(t, dummyRouteScope, scope)
+			assert.Equal(t, dummyRoute.RateLimit, rateLimit)
+		}
+		return true, 0
+	}
+
+	// SUT + act
+	var err = Check(
+		dummySessionObject,
+		dummyRoute,
+	)
+
+	// assert
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheck_GlobalExhausted(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{}
+	var dummySessionObject = &dummySession{
+		t: t,
+		responseWriter: &dummyResponseWriter{
+			t:      t,
+			header: dummyHeader,
+		},
+	}
+	var dummyRoute = model.Route{
+		Endpoint: "some endpoint",
+		Method:   "some method",
+	}
+	var dummyGlobalRateLimit = &model.RateLimit{Rate: rand.Float64()}
+	var dummyRetryAfter = time.Duration(rand.Intn(1000))
+	var dummyRetryAfterSeconds = rand.Intn(100) + 1
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	getGlobalRateLimitFuncExpected = 1
+	getGlobalRateLimitFunc = func() *model.RateLimit {
+		getGlobalRateLimitFuncCalled++
+		return dummyGlobalRateLimit
+	}
+	takeTokenFuncExpected = 1
+	takeTokenFunc = func(session sessionModel.Session, scope string, rateLimit *model.RateLimit) (bool, time.Duration) {
+		takeTokenFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, globalScope, scope)
+		assert.Equal(t, dummyGlobalRateLimit, rateLimit)
+		return false, dummyRetryAfter
+	}
+	getRetryAfterSecondsFuncExpected = 1
+	getRetryAfterSecondsFunc = func(retryAfter time.Duration) int {
+		getRetryAfterSecondsFuncCalled++
+		assert.Equal(t, dummyRetryAfter, retryAfter)
+		return dummyRetryAfterSeconds
+	}
+	strconvItoaExpected = 1
+	strconvItoa = func(i int) string {
+		strconvItoaCalled++
+		return strconv.Itoa(i)
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "Rate limit exceeded for endpoint [%v]; retry after [%v] seconds", format)
+		assert.Equal(t, 2, len(a))
+		assert.Equal(t, dummyRoute.Endpoint, a[0])
+		assert.Equal(t, dummyRetryAfterSeconds, a[1])
+		return dummyError
+	}
+	apperrorGetTooManyRequestsErrorExpected = 1
+	apperrorGetTooManyRequestsError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetTooManyRequestsErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = Check(
+		dummySessionObject,
+		dummyRoute,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+	assert.Equal(t, strconv.Itoa(dummyRetryAfterSeconds), dummyHeader.Get(RetryAfterHeader))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheck_RouteExhausted(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{}
+	var dummySessionObject = &dummySession{
+		t: t,
+		responseWriter: &dummyResponseWriter{
+			t:      t,
+			header: dummyHeader,
+		},
+	}
+	var dummyRoute = model.Route{
+		Endpoint: "some endpoint",
+		Method:   "some method",
+		Path:     "some path",
+	}
+	var dummyRouteScope = "some route scope"
+	var dummyRetryAfter = time.Duration(rand.Intn(1000))
+	var dummyRetryAfterSeconds = rand.Intn(100) + 1
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetGeneralFailureError(nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	getGlobalRateLimitFuncExpected = 1
+	getGlobalRateLimitFunc = func() *model.RateLimit {
+		getGlobalRateLimitFuncCalled++
+		return nil
+	}
+	getRouteScopeFuncExpected = 1
+	getRouteScopeFunc = func(route model.Route) string {
+		getRouteScopeFuncCalled++
+		assert.Equal(t, dummyRoute, route)
+		return dummyRouteScope
+	}
+	takeTokenFuncExpected = 2
+	takeTokenFunc = func(session sessionModel.Session, scope string, rateLimit *model.RateLimit) (bool, time.Duration) {
+		takeTokenFuncCalled++
+		assert.Nil(t, rateLimit)
+		if takeTokenFuncCalled == 1 {
+			return true, 0
+		}
+		assert.Equal(t, dummyRouteScope, scope)
+		return false, dummyRetryAfter
+	}
+	refundTokenFuncExpected = 1
+	refundTokenFunc = func(session sessionModel.Session, scope string, rateLimit *model.RateLimit) {
+		refundTokenFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, globalScope, scope)
+		assert.Nil(t, rateLimit)
+	}
+	getRetryAfterSecondsFuncExpected = 1
+	getRetryAfterSecondsFunc = func(retryAfter time.Duration) int {
+		getRetryAfterSecondsFuncCalled++
+		assert.Equal(t, dummyRetryAfter, retryAfter)
+		return dummyRetryAfterSeconds
+	}
+	strconvItoaExpected = 1
+	strconvItoa = func(i int) string {
+		strconvItoaCalled++
+		return strconv.Itoa(i)
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		return dummyError
+	}
+	apperrorGetTooManyRequestsErrorExpected = 1
+	apperrorGetTooManyRequestsError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetTooManyRequestsErrorCalled++
+		assert.Equal(t, dummyError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = Check(
+		dummySessionObject,
+		dummyRoute,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+	assert.Equal(t, strconv.Itoa(dummyRetryAfterSeconds), dummyHeader.Get(RetryAfterHeader))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestByClientIP_RealRemoteAddr(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{
+		t: t,
+		httpRequest: &http.Request{
+			RemoteAddr: "192.168.0.1:12345",
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	netSplitHostPortExpected = 1
+	netSplitHostPort = func(hostport string) (string, string, error) {
+		netSplitHostPortCalled++
+		return net.SplitHostPort(hostport)
+	}
+
+	// SUT + act
+	var result = ByClientIP(
+		dummySessionObject,
+	)
+
+	// assert
+	assert.Equal(t, "192.168.0.1", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCheck_RouteExhaustedKeepsGlobalToken(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{}
+	var dummySessionObject = &dummySession{
+		t: t,
+		httpRequest: &http.Request{
+			RemoteAddr: "127.0.0.1:12345",
+		},
+		responseWriter: &dummyResponseWriter{
+			t:      t,
+			header: dummyHeader,
+		},
+	}
+	var dummyStoreObject = NewMemoryStore()
+	var dummyLimitedRoute = model.Route{
+		Endpoint:  "some endpoint",
+		Method:    "some method",
+		Path:      "/some/limited/path",
+		RateLimit: &model.RateLimit{Rate: 0.001, Burst: 1},
+	}
+	var dummyOtherRoute = model.Route{
+		Endpoint: "some endpoint",
+		Method:   "some method",
+		Path:     "/some/other/path",
+	}
+
+	// stub
+	customization.RateLimit = func() *model.RateLimit {
+		return &model.RateLimit{Rate: 0.001, Burst: 2}
+	}
+	customization.RateLimitStore = func() model.RateLimitStore {
+		return dummyStoreObject
+	}
+
+	// SUT + act
+	var err1 = Check(dummySessionObject, dummyLimitedRoute)
+	var err2 = Check(dummySessionObject, dummyLimitedRoute)
+	var err3 = Check(dummySessionObject, dummyOtherRoute)
+	var err4 = Check(dummySessionObject, dummyOtherRoute)
+
+	// assert
+	assert.NoError(t, err1)
+	assert.Error(t, err2)
+	assert.NoError(t, err3)
+	assert.Error(t, err4)
+
+	// tear down
+	customization.RateLimit = nil
+	customization.RateLimitStore = nil
+}
+
+func TestCheck_RoutesSharingEndpointScopedPerPath(t *testing.T) {
+	// arrange
+	var dummySessionObject = &dummySession{
+		t: t,
+		httpRequest: &http.Request{
+			RemoteAddr: "127.0.0.1:12345",
+		},
+		responseWriter: &dummyResponseWriter{
+			t:      t,
+			header: http.Header{},
+		},
+	}
+	var dummyStoreObject = NewMemoryStore()
+	var dummyAdminRoute = model.Route{
+		Endpoint:  "some endpoint",
+		Method:    "some method",
+		Path:      "/admin/some/path",
+		RateLimit: &model.RateLimit{Rate: 0.001, Burst: 1},
+	}
+	var dummyPublicRoute = model.Route{
+		Endpoint:  "some endpoint",
+		Method:    "some method",
+		Path:      "/public/some/path",
+		RateLimit: &model.RateLimit{Rate: 0.001, Burst: 1},
+	}
+
+	// stub
+	customization.RateLimitStore = func() model.RateLimitStore {
+		return dummyStoreObject
+	}
+
+	// SUT + act
+	var err1 = Check(dummySessionObject, dummyAdminRoute)
+	var err2 = Check(dummySessionObject, dummyPublicRoute)
+	var err3 = Check(dummySessionObject, dummyAdminRoute)
+
+	// assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Error(t, err3)
+
+	// tear down
+	customization.RateLimitStore = nil
+}
@@ -6,7 +6,11 @@ import (
 
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger"
+	"github.com/zhongjie-cai/WebServiceTemplate/metrics"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/cors"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/handler"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/health"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/openapi"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/route"
 )
 
@@ -17,16 +21,34 @@ var (
 	loggerAppRoot                  = logger.AppRoot
 	routeHandleFunc                = route.HandleFunc
 	routeHostStatic                = route.HostStatic
+	routeHostHandler               = route.HostHandler
+	routeHostPreflight             = route.HostPreflight
 	routeAddMiddleware             = route.AddMiddleware
+	routeCreateSubrouter           = route.CreateSubrouter
 	routeCreateRouter              = route.CreateRouter
 	routeWalkRegisteredRoutes      = route.WalkRegisteredRoutes
 	apperrorWrapSimpleError        = apperror.WrapSimpleError
 	handlerSession                 = handler.Session
+	healthLiveHandler              = health.LiveHandler
+	healthReadyHandler             = health.ReadyHandler
+	metricsHandler                 = metrics.Handler
+	openapiGetHandler              = openapi.GetHandler
+	corsPreflightHandler           = cors.PreflightHandler
 	doParameterReplacementFunc     = doParameterReplacement
 	evaluatePathWithParametersFunc = evaluatePathWithParameters
 	evaluateQueriesFunc            = evaluateQueries
+	isEndpointIncludedFunc         = isEndpointIncluded
+	combineHookFuncsFunc           = combineHookFuncs
+	registerRouteFunc              = registerRoute
 	registerRoutesFunc             = registerRoutes
+	getHookFuncsFunc               = getHookFuncs
+	getIncludedRoutesFunc          = getIncludedRoutes
+	registerRouteGroupsFunc        = registerRouteGroups
+	registerPreflightFunc          = registerPreflight
 	registerStaticsFunc            = registerStatics
+	registerHealthChecksFunc       = registerHealthChecks
+	registerMetricsFunc            = registerMetrics
+	registerOpenAPIFunc            = registerOpenAPI
 	registerMiddlewaresFunc        = registerMiddlewares
 	registerErrorHandlersFunc      = registerErrorHandlers
 	instrumentRouterFunc           = instrumentRouter
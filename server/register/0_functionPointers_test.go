@@ -12,8 +12,12 @@ import (
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/customization"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger"
+	"github.com/zhongjie-cai/WebServiceTemplate/metrics"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/cors"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/handler"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/health"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/openapi"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/route"
 )
 
@@ -28,8 +32,14 @@ var (
 	routeHandleFuncCalled                        int
 	routeHostStaticExpected                      int
 	routeHostStaticCalled                        int
+	routeHostHandlerExpected                     int
+	routeHostHandlerCalled                       int
+	routeHostPreflightExpected                   int
+	routeHostPreflightCalled                     int
 	routeAddMiddlewareExpected                   int
 	routeAddMiddlewareCalled                     int
+	routeCreateSubrouterExpected                 int
+	routeCreateSubrouterCalled                   int
 	routeCreateRouterExpected                    int
 	routeCreateRouterCalled                      int
 	routeWalkRegisteredRoutesExpected            int
@@ -38,16 +48,46 @@ var (
 	apperrorWrapSimpleErrorCalled                int
 	handlerSessionExpected                       int
 	handlerSessionCalled                         int
+	healthLiveHandlerExpected                    int
+	healthLiveHandlerCalled                      int
+	healthReadyHandlerExpected                   int
+	healthReadyHandlerCalled                     int
+	metricsHandlerExpected                       int
+	metricsHandlerCalled                         int
+	openapiGetHandlerExpected                    int
+	openapiGetHandlerCalled                      int
+	corsPreflightHandlerExpected                 int
+	corsPreflightHandlerCalled                   int
 	doParameterReplacementFuncExpected           int
 	doParameterReplacementFuncCalled             int
 	evaluatePathWithParametersFuncExpected       int
 	evaluatePathWithParametersFuncCalled         int
 	evaluateQueriesFuncExpected                  int
 	evaluateQueriesFuncCalled                    int
+	isEndpointIncludedFuncExpected               int
+	isEndpointIncludedFuncCalled                 int
+	combineHookFuncsFuncExpected                 int
+	combineHookFuncsFuncCalled                   int
+	registerRouteFuncExpected                    int
+	registerRouteFuncCalled                      int
 	registerRoutesFuncExpected                   int
 	registerRoutesFuncCalled                     int
+	getHookFuncsFuncExpected                     int
+	getHookFuncsFuncCalled                       int
+	getIncludedRoutesFuncExpected                int
+	getIncludedRoutesFuncCalled                  int
+	registerRouteGroupsFuncExpected              int
+	registerRouteGroupsFuncCalled                int
+	registerPreflightFuncExpected                int
+	registerPreflightFuncCalled                  int
 	registerStaticsFuncExpected                  int
 	registerStaticsFuncCalled                    int
+	registerHealthChecksFuncExpected             int
+	registerHealthChecksFuncCalled               int
+	registerMetricsFuncExpected                  int
+	registerMetricsFuncCalled                    int
+	registerOpenAPIFuncExpected                  int
+	registerOpenAPIFuncCalled                    int
 	registerMiddlewaresFuncExpected              int
 	registerMiddlewaresFuncCalled                int
 	registerErrorHandlersFuncExpected            int
@@ -82,7 +122,7 @@ func createMock(t *testing.T) {
 	}
 	routeHandleFuncExpected = 0
 	routeHandleFuncCalled = 0
-	routeHandleFunc = func(router *mux.Router, endpoint string, method string, path string, queries []string, handlerFunc func(http.ResponseWriter, *http.Request), actionFunc model.ActionFunc) *mux.Route {
+	routeHandleFunc = func(router *mux.Router, routeInfo model.Route, path string, queries []string, handlerFunc func(http.ResponseWriter, *http.Request)) *mux.Route {
 		routeHandleFuncCalled++
 		return nil
 	}
@@ -92,11 +132,29 @@ func createMock(t *testing.T) {
 		routeHostStaticCalled++
 		return nil
 	}
+	routeHostHandlerExpected = 0
+	routeHostHandlerCalled = 0
+	routeHostHandler = func(router *mux.Router, name string, method string, path string, handleFunc func(http.ResponseWriter, *http.Request)) *mux.Route {
+		routeHostHandlerCalled++
+		return nil
+	}
+	routeHostPreflightExpected = 0
+	routeHostPreflightCalled = 0
+	routeHostPreflight = func(router *mux.Router, name string, handleFunc func(http.ResponseWriter, *http.Request)) *mux.Route {
+		routeHostPreflightCalled++
+		return nil
+	}
 	routeAddMiddlewareExpected = 0
 	routeAddMiddlewareCalled = 0
 	routeAddMiddleware = func(router *mux.Router, middleware model.MiddlewareFunc) {
 		routeAddMiddlewareCalled++
 	}
+	routeCreateSubrouterExpected = 0
+	routeCreateSubrouterCalled = 0
+	routeCreateSubrouter = func(router *mux.Router, pathPrefix string) *mux.Router {
+		routeCreateSubrouterCalled++
+		return nil
+	}
 	routeCreateRouterExpected = 0
 	routeCreateRouterCalled = 0
 	routeCreateRouter = func() *mux.Router {
@@ -120,6 +178,33 @@ func createMock(t *testing.T) {
 	handlerSession = func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
 		handlerSessionCalled++
 	}
+	healthLiveHandlerExpected = 0
+	healthLiveHandlerCalled = 0
+	healthLiveHandler = func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		healthLiveHandlerCalled++
+	}
+	healthReadyHandlerExpected = 0
+	healthReadyHandlerCalled = 0
+	healthReadyHandler = func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		healthReadyHandlerCalled++
+	}
+	metricsHandlerExpected = 0
+	metricsHandlerCalled = 0
+	metricsHandler = func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		metricsHandlerCalled++
+	}
+	openapiGetHandlerExpected = 0
+	openapiGetHandlerCalled = 0
+	openapiGetHandler = func(routes []model.Route) func(http.ResponseWriter, *http.Request) {
+		openapiGetHandlerCalled++
+		return nil
+	}
+	corsPreflightHandlerExpected = 0
+	corsPreflightHandlerCalled = 0
+	corsPreflightHandler = func(router *mux.Router) func(http.ResponseWriter, *http.Request) {
+		corsPreflightHandlerCalled++
+		return nil
+	}
 	doParameterReplacementFuncExpected = 0
 	doParameterReplacementFuncCalled = 0
 	doParameterReplacementFunc = func(originalPath string, parameterName string, parameterType model.ParameterType) string {
@@ -138,16 +223,70 @@ func createMock(t *testing.T) {
 		evaluateQueriesFuncCalled++
 		return nil
 	}
+	isEndpointIncludedFuncExpected = 0
+	isEndpointIncludedFuncCalled = 0
+	isEndpointIncludedFunc = func(name string, endpoints []string) bool {
+		isEndpointIncludedFuncCalled++
+		return false
+	}
+	combineHookFuncsFuncExpected = 0
+	combineHookFuncsFuncCalled = 0
+	combineHookFuncsFunc = func(firstHookFuncs []model.HookFunc, secondHookFuncs []model.HookFunc) []model.HookFunc {
+		combineHookFuncsFuncCalled++
+		return nil
+	}
+	registerRouteFuncExpected = 0
+	registerRouteFuncCalled = 0
+	registerRouteFunc = func(router *mux.Router, configuredRoute model.Route, preActionFuncs []model.HookFunc, postActionFuncs []model.HookFunc) {
+		registerRouteFuncCalled++
+	}
 	registerRoutesFuncExpected = 0
 	registerRoutesFuncCalled = 0
-	registerRoutesFunc = func(router *mux.Router) {
+	registerRoutesFunc = func(router *mux.Router, endpoints []string) {
 		registerRoutesFuncCalled++
 	}
+	getHookFuncsFuncExpected = 0
+	getHookFuncsFuncCalled = 0
+	getHookFuncsFunc = func(hookFunc model.HookFunc) []model.HookFunc {
+		getHookFuncsFuncCalled++
+		return nil
+	}
+	getIncludedRoutesFuncExpected = 0
+	getIncludedRoutesFuncCalled = 0
+	getIncludedRoutesFunc = func(routes []model.Route, endpoints []string) []model.Route {
+		getIncludedRoutesFuncCalled++
+		return nil
+	}
+	registerRouteGroupsFuncExpected = 0
+	registerRouteGroupsFuncCalled = 0
+	registerRouteGroupsFunc = func(router *mux.Router, endpoints []string) {
+		registerRouteGroupsFuncCalled++
+	}
+	registerPreflightFuncExpected = 0
+	registerPreflightFuncCalled = 0
+	registerPreflightFunc = func(router *mux.Router) {
+		registerPreflightFuncCalled++
+	}
 	registerStaticsFuncExpected = 0
 	registerStaticsFuncCalled = 0
-	registerStaticsFunc = func(router *mux.Router) {
+	registerStaticsFunc = func(router *mux.Router, endpoints []string) {
 		registerStaticsFuncCalled++
 	}
+	registerHealthChecksFuncExpected = 0
+	registerHealthChecksFuncCalled = 0
+	registerHealthChecksFunc = func(router *mux.Router, endpoints []string) {
+		registerHealthChecksFuncCalled++
+	}
+	registerMetricsFuncExpected = 0
+	registerMetricsFuncCalled = 0
+	registerMetricsFunc = func(router *mux.Router, endpoints []string) {
+		registerMetricsFuncCalled++
+	}
+	registerOpenAPIFuncExpected = 0
+	registerOpenAPIFuncCalled = 0
+	registerOpenAPIFunc = func(router *mux.Router, endpoints []string) {
+		registerOpenAPIFuncCalled++
+	}
 	registerMiddlewaresFuncExpected = 0
 	registerMiddlewaresFuncCalled = 0
 	registerMiddlewaresFunc = func(router *mux.Router) {
@@ -186,8 +325,14 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, routeHandleFuncExpected, routeHandleFuncCalled, "Unexpected number of calls to routeHandleFunc")
 	routeHostStatic = route.HostStatic
 	assert.Equal(t, routeHostStaticExpected, routeHostStaticCalled, "Unexpected number of calls to routeHostStatic")
+	routeHostHandler = route.HostHandler
+	assert.Equal(t, routeHostHandlerExpected, routeHostHandlerCalled, "Unexpected number of calls to routeHostHandler")
+	routeHostPreflight = route.HostPreflight
+	assert.Equal(t, routeHostPreflightExpected, routeHostPreflightCalled, "Unexpected number of calls to routeHostPreflight")
 	routeAddMiddleware = route.AddMiddleware
 	assert.Equal(t, routeAddMiddlewareExpected, routeAddMiddlewareCalled, "Unexpected number of calls to routeAddMiddleware")
+	routeCreateSubrouter = route.CreateSubrouter
+	assert.Equal(t, routeCreateSubrouterExpected, routeCreateSubrouterCalled, "Unexpected number of calls to routeCreateSubrouter")
 	routeCreateRouter = route.CreateRouter
 	assert.Equal(t, routeCreateRouterExpected, routeCreateRouterCalled, "Unexpected number of calls to routeCreateRouter")
 	routeWalkRegisteredRoutes = route.WalkRegisteredRoutes
@@ -196,16 +341,46 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, apperrorWrapSimpleErrorExpected, apperrorWrapSimpleErrorCalled, "Unexpected number of calls to apperrorWrapSimpleError")
 	handlerSession = handler.Session
 	assert.Equal(t, handlerSessionExpected, handlerSessionCalled, "Unexpected number of calls to handlerSession")
+	healthLiveHandler = health.LiveHandler
+	assert.Equal(t, healthLiveHandlerExpected, healthLiveHandlerCalled, "Unexpected number of calls to healthLiveHandler")
+	healthReadyHandler = health.ReadyHandler
+	assert.Equal(t, healthReadyHandlerExpected, healthReadyHandlerCalled, "Unexpected number of calls to healthReadyHandler")
+	metricsHandler = metrics.Handler
+	assert.Equal(t, metricsHandlerExpected, metricsHandlerCalled, "Unexpected number of calls to metricsHandler")
+	openapiGetHandler = openapi.GetHandler
+	assert.Equal(t, openapiGetHandlerExpected, openapiGetHandlerCalled, "Unexpected number of calls to openapiGetHandler")
+	corsPreflightHandler = cors.PreflightHandler
+	assert.Equal(t, corsPreflightHandlerExpected, corsPreflightHandlerCalled, "Unexpected number of calls to corsPreflightHandler")
 	doParameterReplacementFunc = doParameterReplacement
 	assert.Equal(t, doParameterReplacementFuncExpected, doParameterReplacementFuncCalled, "Unexpected number of calls to doParameterReplacementFunc")
 	evaluatePathWithParametersFunc = evaluatePathWithParameters
 	assert.Equal(t, evaluatePathWithParametersFuncExpected, evaluatePathWithParametersFuncCalled, "Unexpected number of calls to evaluatePathWithParametersFunc")
 	evaluateQueriesFunc = evaluateQueries
 	assert.Equal(t, evaluateQueriesFuncExpected, evaluateQueriesFuncCalled, "Unexpected number of calls to evaluateQueriesFunc")
+	isEndpointIncludedFunc = isEndpointIncluded
+	assert.Equal(t, isEndpointIncludedFuncExpected, isEndpointIncludedFuncCalled, "Unexpected number of calls to isEndpointIncludedFunc")
+	combineHookFuncsFunc = combineHookFuncs
+	assert.Equal(t, combineHookFuncsFuncExpected, combineHookFuncsFuncCalled, "Unexpected number of calls to combineHookFuncsFunc")
+	registerRouteFunc = registerRoute
+	assert.Equal(t, registerRouteFuncExpected, registerRouteFuncCalled, "Unexpected number of calls to registerRouteFunc")
 	registerRoutesFunc = registerRoutes
 	assert.Equal(t, registerRoutesFuncExpected, registerRoutesFuncCalled, "Unexpected number of calls to registerRoutesFunc")
+	getHookFuncsFunc = getHookFuncs
+	assert.Equal(t, getHookFuncsFuncExpected, getHookFuncsFuncCalled, "Unexpected number of calls to getHookFuncsFunc")
+	getIncludedRoutesFunc = getIncludedRoutes
+	assert.Equal(t, getIncludedRoutesFuncExpected, getIncludedRoutesFuncCalled, "Unexpected number of calls to getIncludedRoutesFunc")
+	registerRouteGroupsFunc = registerRouteGroups
+	assert.Equal(t, registerRouteGroupsFuncExpected, registerRouteGroupsFuncCalled, "Unexpected number of calls to registerRouteGroupsFunc")
+	registerPreflightFunc = registerPreflight
+	assert.Equal(t, registerPreflightFuncExpected, registerPreflightFuncCalled, "Unexpected number of calls to registerPreflightFunc")
 	registerStaticsFunc = registerStatics
 	assert.Equal(t, registerStaticsFuncExpected, registerStaticsFuncCalled, "Unexpected number of calls to registerStaticsFunc")
+	registerHealthChecksFunc = registerHealthChecks
+	assert.Equal(t, registerHealthChecksFuncExpected, registerHealthChecksFuncCalled, "Unexpected number of calls to registerHealthChecksFunc")
+	registerMetricsFunc = registerMetrics
+	assert.Equal(t, registerMetricsFuncExpected, registerMetricsFuncCalled, "Unexpected number of calls to registerMetricsFunc")
+	registerOpenAPIFunc = registerOpenAPI
+	assert.Equal(t, registerOpenAPIFuncExpected, registerOpenAPIFuncCalled, "Unexpected number of calls to registerOpenAPIFunc")
 	registerMiddlewaresFunc = registerMiddlewares
 	assert.Equal(t, registerMiddlewaresFuncExpected, registerMiddlewaresFuncCalled, "Unexpected number of calls to registerMiddlewaresFunc")
 	registerErrorHandlersFunc = registerErrorHandlers
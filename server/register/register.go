@@ -1,10 +1,16 @@
 package register
 
 import (
+	"net/http"
+
 	"github.com/gorilla/mux"
 	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/metrics"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/cors"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/handler"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/health"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/openapi"
 )
 
 func doParameterReplacement(
@@ -81,8 +87,71 @@ func evaluateQueries(
 	return evaluatedQueries
 }
 
+func isEndpointIncluded(
+	name string,
+	endpoints []string,
+) bool {
+	if len(endpoints) == 0 {
+		return true
+	}
+	for _, endpoint := range endpoints {
+		if endpoint == name {
+			return true
+		}
+	}
+	return false
+}
+
+// combineHookFuncs combines the given hook functions into a new list in order, leaving the given lists untouched
+func combineHookFuncs(
+	firstHookFuncs []model.HookFunc,
+	secondHookFuncs []model.HookFunc,
+) []model.HookFunc {
+	var hookFuncs = []model.HookFunc{}
+	hookFuncs = append(
+		hookFuncs,
+		firstHookFuncs...,
+	)
+	return append(
+		hookFuncs,
+		secondHookFuncs...,
+	)
+}
+
+func registerRoute(
+	router *mux.Router,
+	configuredRoute model.Route,
+	preActionFuncs []model.HookFunc,
+	postActionFuncs []model.HookFunc,
+) {
+	var evaluatedPath = evaluatePathWithParametersFunc(
+		configuredRoute.Path,
+		configuredRoute.Parameters,
+	)
+	var queries = evaluateQueriesFunc(
+		configuredRoute.Queries,
+	)
+	var routeInfo = configuredRoute
+	routeInfo.PreActionFuncs = combineHookFuncsFunc(
+		preActionFuncs,
+		configuredRoute.PreActionFuncs,
+	)
+	routeInfo.PostActionFuncs = combineHookFuncsFunc(
+		configuredRoute.PostActionFuncs,
+		postActionFuncs,
+	)
+	routeHandleFunc(
+		router,
+		routeInfo,
+		evaluatedPath,
+		queries,
+		handlerSession,
+	)
+}
+
 func registerRoutes(
 	router *mux.Router,
+	endpoints []string,
 ) {
 	if customization.Routes == nil {
 		loggerAppRoot(
@@ -103,27 +172,116 @@ func registerRoutes(
 		return
 	}
 	for _, configuredRoute := range configuredRoutes {
-		var evaluatedPath = evaluatePathWithParametersFunc(
-			configuredRoute.Path,
-			configuredRoute.Parameters,
+		if !isEndpointIncludedFunc(
+			configuredRoute.Endpoint,
+			endpoints,
+		) {
+			continue
+		}
+		registerRouteFunc(
+			router,
+			configuredRoute,
+			nil,
+			nil,
 		)
-		var queries = evaluateQueriesFunc(
-			configuredRoute.Queries,
+	}
+}
+
+func getHookFuncs(hookFunc model.HookFunc) []model.HookFunc {
+	if hookFunc == nil {
+		return nil
+	}
+	return []model.HookFunc{hookFunc}
+}
+
+func getIncludedRoutes(
+	routes []model.Route,
+	endpoints []string,
+) []model.Route {
+	var includedRoutes = []model.Route{}
+	for _, route := range routes {
+		if !isEndpointIncludedFunc(
+			route.Endpoint,
+			endpoints,
+		) {
+			continue
+		}
+		includedRoutes = append(
+			includedRoutes,
+			route,
+		)
+	}
+	return includedRoutes
+}
+
+func registerRouteGroups(
+	router *mux.Router,
+	endpoints []string,
+) {
+	if customization.RouteGroups == nil {
+		loggerAppRoot(
+			"register",
+			"registerRouteGroups",
+			"customization.RouteGroups function not set: no route groups registered!",
 		)
-		routeHandleFunc(
+		return
+	}
+	for _, routeGroup := range customization.RouteGroups() {
+		var includedRoutes = getIncludedRoutesFunc(
+			routeGroup.Routes,
+			endpoints,
+		)
+		if len(includedRoutes) == 0 {
+			loggerAppRoot(
+				"register",
+				"registerRouteGroups",
+				"Route group [%v] has no routes included: group skipped.",
+				routeGroup.Name,
+			)
+			continue
+		}
+		var subrouter = routeCreateSubrouter(
 			router,
-			configuredRoute.Endpoint,
-			configuredRoute.Method,
-			evaluatedPath,
-			queries,
-			handlerSession,
-			configuredRoute.ActionFunc,
+			routeGroup.PathPrefix,
+		)
+		for _, middleware := range routeGroup.Middlewares {
+			routeAddMiddleware(
+				subrouter,
+				middleware,
+			)
+		}
+		var preActionFuncs = getHookFuncsFunc(
+			routeGroup.PreActionFunc,
 		)
+		var postActionFuncs = getHookFuncsFunc(
+			routeGroup.PostActionFunc,
+		)
+		for _, configuredRoute := range includedRoutes {
+			registerRouteFunc(
+				subrouter,
+				configuredRoute,
+				preActionFuncs,
+				postActionFuncs,
+			)
+		}
 	}
 }
 
+func registerPreflight(
+	router *mux.Router,
+) {
+	routeHostPreflight(
+		router,
+		cors.Endpoint,
+		corsPreflightHandler(
+			router,
+		),
+	)
+}
+
 func registerStatics(
 	router *mux.Router,
+	endpoints []string,
 ) {
 	if customization.Statics == nil {
 		loggerAppRoot(
@@ -144,6 +302,12 @@ func registerStatics(
 		return
 	}
 	for _, static := range statics {
+		if !isEndpointIncludedFunc(
+			static.Name,
+			endpoints,
+		) {
+			continue
+		}
 		routeHostStatic(
 			router,
 			static.Name,
@@ -153,6 +317,121 @@ func registerStatics(
 	}
 }
 
+func registerHealthChecks(
+	router *mux.Router,
+	endpoints []string,
+) {
+	if customization.HealthChecks == nil {
+		loggerAppRoot(
+			"register",
+			"registerHealthChecks",
+			"customization.HealthChecks function not set: no health endpoints registered!",
+		)
+		return
+	}
+	if isEndpointIncludedFunc(
+		health.LiveEndpoint,
+		endpoints,
+	) {
+		routeHostHandler(
+			router,
+			health.LiveEndpoint,
+			http.MethodGet,
+			health.LivePath,
+			healthLiveHandler,
+		)
+	}
+	if isEndpointIncludedFunc(
+		health.ReadyEndpoint,
+		endpoints,
+	) {
+		routeHostHandler(
+			router,
+			health.ReadyEndpoint,
+			http.MethodGet,
+			health.ReadyPath,
+			healthReadyHandler,
+		)
+	}
+}
+
+func registerMetrics(
+	router *mux.Router,
+	endpoints []string,
+) {
+	if customization.MetricsPath == nil {
+		loggerAppRoot(
+			"register",
+			"registerMetrics",
+			"customization.MetricsPath function not set: no metrics endpoint registered!",
+		)
+		return
+	}
+	if !isEndpointIncludedFunc(
+		metrics.Endpoint,
+		endpoints,
+	) {
+		return
+	}
+	routeHostHandler(
+		router,
+		metrics.Endpoint,
+		http.MethodGet,
+		customization.MetricsPath(),
+		metricsHandler,
+	)
+}
+
+func registerOpenAPI(
+	router *mux.Router,
+	endpoints []string,
+) {
+	if customization.OpenAPIPath == nil {
+		loggerAppRoot(
+			"register",
+			"registerOpenAPI",
+			"customization.OpenAPIPath function not set: no OpenAPI endpoint registered!",
+		)
+		return
+	}
+	if !isEndpointIncludedFunc(
+		openapi.Endpoint,
+		endpoints,
+	) {
+		return
+	}
+	var documentedRoutes = []model.Route{}
+	if customization.Routes != nil {
+		documentedRoutes = getIncludedRoutesFunc(
+			customization.Routes(),
+			endpoints,
+		)
+	}
+	if customization.RouteGroups != nil {
+		for _, routeGroup := range customization.RouteGroups() {
+			for _, groupRoute := range getIncludedRoutesFunc(
+				routeGroup.Routes,
+				endpoints,
+			) {
+				groupRoute.Path = routeGroup.PathPrefix + groupRoute.Path
+				documentedRoutes = append(
+					documentedRoutes,
+					groupRoute,
+				)
+			}
+		}
+	}
+	routeHostHandler(
+		router,
+		openapi.Endpoint,
+		http.MethodGet,
+		customization.OpenAPIPath(),
+		openapiGetHandler(
+			documentedRoutes,
+		),
+	)
+}
+
 func registerMiddlewares(
 	router *mux.Router,
 ) {
@@ -202,14 +481,35 @@ func instrumentRouter(router *mux.Router) *mux.Router {
 	return customization.InstrumentRouter(router)
 }
 
-// Instantiate instantiates and registers the given routes according to custom specification
-func Instantiate() (*mux.Router, error) {
+// Instantiate instantiates and registers the given routes according to custom specification, limited to routes and statics with names in the given endpoints unless endpoints is empty
+func Instantiate(endpoints []string) (*mux.Router, error) {
 	var router = routeCreateRouter()
 	registerRoutesFunc(
 		router,
+		endpoints,
+	)
+	registerRouteGroupsFunc(
+		router,
+		endpoints,
+	)
+	registerPreflightFunc(
+		router,
 	)
 	registerStaticsFunc(
 		router,
+		endpoints,
+	)
+	registerHealthChecksFunc(
+		router,
+		endpoints,
+	)
+	registerMetricsFunc(
+		router,
+		endpoints,
+	)
+	registerOpenAPIFunc(
+		router,
+		endpoints,
 	)
 	registerMiddlewaresFunc(
 		router,
@@ -4,18 +4,26 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/zhongjie-cai/WebServiceTemplate/server/handler"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/health"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/metrics"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/auth"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/cors"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/openapi"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/route"
+	"github.com/zhongjie-cai/WebServiceTemplate/session"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
 )
 
@@ -191,9 +199,28 @@ func TestEvaluateQueries(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestIsEndpointIncluded(t *testing.T) {
+	// arrange
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
+
+	// mock
+	createMock(t)
+
+	// SUT + act + assert
+	assert.True(t, isEndpointIncluded("some endpoint", nil))
+	assert.True(t, isEndpointIncluded("some endpoint", []string{}))
+	assert.True(t, isEndpointIncluded("some endpoint", dummyEndpoints))
+	assert.True(t, isEndpointIncluded("some other endpoint", dummyEndpoints))
+	assert.False(t, isEndpointIncluded("some unknown endpoint", dummyEndpoints))
+
+	// verify
+	verifyAll(t)
+}
+
 func TestRegisterRoutes_NilRoutesFunc(t *testing.T) {
 	// arrange
 	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
 
 	// stub
 	customization.Routes = nil
@@ -214,6 +241,7 @@ func TestRegisterRoutes_NilRoutesFunc(t *testing.T) {
 	// SUT + act
 	registerRoutes(
 		dummyRouter,
+		dummyEndpoints,
 	)
 
 	// verify
@@ -223,6 +251,7 @@ func TestRegisterRoutes_NilRoutesFunc(t *testing.T) {
 func TestRegisterRoutes_EmptyRoutes(t *testing.T) {
 	// arrange
 	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
 	var routesExpected int
 	var routesCalled int
 	var dummyRoutes []model.Route
@@ -248,6 +277,7 @@ func TestRegisterRoutes_EmptyRoutes(t *testing.T) {
 	// SUT + act
 	registerRoutes(
 		dummyRouter,
+		dummyEndpoints,
 	)
 
 	// verify
@@ -258,6 +288,7 @@ func TestRegisterRoutes_EmptyRoutes(t *testing.T) {
 func TestRegisterRoutes_ValidRoutes(t *testing.T) {
 	// arrange
 	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
 	var routesExpected int
 	var routesCalled int
 	var dummyEndpoint1 = "some endpoint 1"
@@ -273,6 +304,8 @@ func TestRegisterRoutes_ValidRoutes(t *testing.T) {
 		return nil, nil
 	}
 	var dummyActionFunc1Pointer = fmt.Sprintf("%v", reflect.ValueOf(dummyActionFunc1))
+	var dummyRateLimit1 = &model.RateLimit{Rate: 1.5, Burst: 3}
+	var dummyMediaTypes1 = []string{"some media type 1", "some media type 2"}
 	var dummyEndpoint2 = "some endpoint 2"
 	var dummyMethod2 = "some method 2"
 	var dummyPath2 = "some path 2"
@@ -286,6 +319,8 @@ func TestRegisterRoutes_ValidRoutes(t *testing.T) {
 		return nil, nil
 	}
 	var dummyActionFunc2Pointer = fmt.Sprintf("%v", reflect.ValueOf(dummyActionFunc2))
+	var dummyMaxBodyBytes2 = int64(1024)
+	var dummyEndpoint3 = "some endpoint 3"
 	var dummyRoutes = []model.Route{
 		{
 			Endpoint:   dummyEndpoint1,
@@ -294,14 +329,22 @@ func TestRegisterRoutes_ValidRoutes(t *testing.T) {
 			Parameters: dummyParameters1,
 			Queries:    dummyQueries1,
 			ActionFunc: dummyActionFunc1,
+			RateLimit:  dummyRateLimit1,
+			MediaTypes: dummyMediaTypes1,
+		},
+		{
+			Endpoint:     dummyEndpoint2,
+			Method:       dummyMethod2,
+			Path:         dummyPath2,
+			Parameters:   dummyParameters2,
+			Queries:      dummyQueries2,
+			ActionFunc:   dummyActionFunc2,
+			MaxBodyBytes: dummyMaxBodyBytes2,
 		},
 		{
-			Endpoint:   dummyEndpoint2,
-			Method:     dummyMethod2,
-			Path:       dummyPath2,
-			Parameters: dummyParameters2,
-			Queries:    dummyQueries2,
-			ActionFunc: dummyActionFunc2,
+			Endpoint: dummyEndpoint3,
+			Method:   "some method 3",
+			Path:     "some path 3",
 		},
 	}
 	var dummyEvaluatedPath1 = "some evaluated path 1"
@@ -313,11 +356,20 @@ func TestRegisterRoutes_ValidRoutes(t *testing.T) {
 	createMock(t)
 
 	// expect
+	registerRouteFunc = registerRoute
+	combineHookFuncsFunc = combineHookFuncs
 	routesExpected = 1
 	customization.Routes = func() []model.Route {
 		routesCalled++
 		return dummyRoutes
 	}
+	isEndpointIncludedFuncExpected = 3
+	isEndpointIncludedFunc = func(name string, endpoints []string) bool {
+		isEndpointIncludedFuncCalled++
+		assert.Equal(t, dummyRoutes[isEndpointIncludedFuncCalled-1].Endpoint, name)
+		assert.Equal(t, dummyEndpoints, endpoints)
+		return name != dummyEndpoint3
+	}
 	evaluatePathWithParametersFuncExpected = 2
 	evaluatePathWithParametersFunc = func(path string, parameters map[string]model.ParameterType) string {
 		evaluatePathWithParametersFuncCalled++
@@ -341,29 +393,32 @@ func TestRegisterRoutes_ValidRoutes(t *testing.T) {
 		return nil
 	}
 	routeHandleFuncExpected = 2
-	routeHandleFunc = func(router *mux.Router, endpoint string, method string, path string, queries []string, handlerFunc func(http.ResponseWriter, *http.Request), actionFunc model.ActionFunc) *mux.Route {
+	routeHandleFunc = func(router *mux.Router, routeInfo model.Route, path string, queries []string, handlerFunc func(http.ResponseWriter, *http.Request)) *mux.Route {
 		routeHandleFuncCalled++
 		assert.Equal(t, dummyRouter, router)
 		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(handlerSession)), fmt.Sprintf("%v", reflect.ValueOf(handlerFunc)))
 		if routeHandleFuncCalled == 1 {
-			assert.Equal(t, dummyEndpoint1, endpoint)
-			assert.Equal(t, dummyMethod1, method)
+			assert.Equal(t, dummyEndpoint1, routeInfo.Endpoint)
+			assert.Equal(t, dummyMethod1, routeInfo.Method)
 			assert.Equal(t, dummyEvaluatedPath1, path)
 			assert.Equal(t, dummyEvaluatedQueries1, queries)
-			assert.Equal(t, dummyActionFunc1Pointer, fmt.Sprintf("%v", reflect.ValueOf(actionFunc)))
+			assert.Equal(t, dummyActionFunc1Pointer, fmt.Sprintf("%v", reflect.ValueOf(routeInfo.ActionFunc)))
 		} else if routeHandleFuncCalled == 2 {
-			assert.Equal(t, dummyEndpoint2, endpoint)
-			assert.Equal(t, dummyMethod2, method)
+			assert.Equal(t, dummyEndpoint2, routeInfo.Endpoint)
+			assert.Equal(t, dummyMethod2, routeInfo.Method)
 			assert.Equal(t, dummyEvaluatedPath2, path)
 			assert.Equal(t, dummyEvaluatedQueries2, queries)
-			assert.Equal(t, dummyActionFunc2Pointer, fmt.Sprintf("%v", reflect.ValueOf(actionFunc)))
+			assert.Equal(t, dummyActionFunc2Pointer, fmt.Sprintf("%v", reflect.ValueOf(routeInfo.ActionFunc)))
 		}
+		assert.Empty(t, routeInfo.PreActionFuncs)
+		assert.Empty(t, routeInfo.PostActionFuncs)
 		return nil
 	}
 
 	// SUT + act
 	registerRoutes(
 		dummyRouter,
+		dummyEndpoints,
 	)
 
 	// verify
@@ -371,9 +426,466 @@ func TestRegisterRoutes_ValidRoutes(t *testing.T) {
 	assert.Equal(t, routesExpected, routesCalled, "Unexpected number of calls to Routes")
 }
 
+func TestCombineHookFuncs(t *testing.T) {
+	// arrange
+	var dummyHookFunc1 = func(sessionModel.Session) error { return nil }
+	var dummyHookFunc2 = func(sessionModel.Session) error { return nil }
+	var dummyHookFunc3 = func(sessionModel.Session) error { return nil }
+	var dummyFirstHookFuncs = make([]model.HookFunc, 1, 10)
+	var dummySecondHookFuncs = []model.HookFunc{dummyHookFunc2, dummyHookFunc3}
+
+	// stub
+	dummyFirstHookFuncs[0] = dummyHookFunc1
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var emptyResult = combineHookFuncs(
+		nil,
+		nil,
+	)
+	var result = combineHookFuncs(
+		dummyFirstHookFuncs,
+		dummySecondHookFuncs,
+	)
+
+	// assert
+	assert.Empty(t, emptyResult)
+	assert.Equal(t, 3, len(result))
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyHookFunc1)), fmt.Sprintf("%v", reflect.ValueOf(result[0])))
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyHookFunc2)), fmt.Sprintf("%v", reflect.ValueOf(result[1])))
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyHookFunc3)), fmt.Sprintf("%v", reflect.ValueOf(result[2])))
+	assert.Equal(t, 1, len(dummyFirstHookFuncs))
+	assert.Nil(t, dummyFirstHookFuncs[:2][1])
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRegisterRoute(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyRoute = model.Route{
+		Endpoint:        "some endpoint",
+		Method:          "some method",
+		Path:            "some path",
+		Parameters:      map[string]model.ParameterType{"foo": "bar"},
+		Queries:         map[string]model.ParameterType{"test": "me"},
+		RateLimit:       &model.RateLimit{Rate: 1, Burst: 1},
+		MaxBodyBytes:    1024,
+		MediaTypes:      []string{"some media type"},
+		Auth:            &model.AuthRequirement{Scopes: []string{"some scope"}},
+		CORS:            &model.CORS{AllowedOrigins: []string{"some origin"}},
+		PreActionFuncs:  []model.HookFunc{func(sessionModel.Session) error { return nil }},
+		PostActionFuncs: []model.HookFunc{func(sessionModel.Session) error { return nil }},
+	}
+	var dummyPreActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummyPostActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummyCombinedPreActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummyCombinedPostActionFuncs = []model.HookFunc{func(sessionModel.Session) error { return nil }}
+	var dummyEvaluatedPath = "some evaluated path"
+	var dummyEvaluatedQueries = []string{"some evaluated queries"}
+
+	// mock
+	createMock(t)
+
+	// expect
+	evaluatePathWithParametersFuncExpected = 1
+	evaluatePathWithParametersFunc = func(path string, parameters map[string]model.ParameterType) string {
+		evaluatePathWithParametersFuncCalled++
+		assert.Equal(t, dummyRoute.Path, path)
+		assert.Equal(t, dummyRoute.Parameters, parameters)
+		return dummyEvaluatedPath
+	}
+	evaluateQueriesFuncExpected = 1
+	evaluateQueriesFunc = func(queries map[string]model.ParameterType) []string {
+		evaluateQueriesFuncCalled++
+		assert.Equal(t, dummyRoute.Queries, queries)
+		return dummyEvaluatedQueries
+	}
+	routeHandleFuncExpected = 1
+	routeHandleFunc = func(router *mux.Router, routeInfo model.Route, path string, queries []string, handlerFunc func(http.ResponseWriter, *http.Request)) *mux.Route {
+		routeHandleFuncCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyRoute.Endpoint, routeInfo.Endpoint)
+		assert.Equal(t, dummyRoute.Method, routeInfo.Method)
+		assert.Equal(t, dummyRoute.Auth, routeInfo.Auth)
+		assert.Equal(t, fmt.Sprintf("%v", dummyCombinedPreActionFuncs), fmt.Sprintf("%v", routeInfo.PreActionFuncs))
+		assert.Equal(t, fmt.Sprintf("%v", dummyCombinedPostActionFuncs), fmt.Sprintf("%v", routeInfo.PostActionFuncs))
+		assert.Equal(t, dummyEvaluatedPath, path)
+		assert.Equal(t, dummyEvaluatedQueries, queries)
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(handlerSession)), fmt.Sprintf("%v", reflect.ValueOf(handlerFunc)))
+		return nil
+	}
+	combineHookFuncsFuncExpected = 2
+	combineHookFuncsFunc = func(firstHookFuncs []model.HookFunc, secondHookFuncs []model.HookFunc) []model.HookFunc {
+		combineHookFuncsFuncCalled++
+		if combineHookFuncsFuncCalled == 1 {
+			assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyPreActionFuncs[0])), fmt.Sprintf("%v", reflect.ValueOf(firstHookFuncs[0])))
+			assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyRoute.PreActionFuncs[0])), fmt.Sprintf("%v", reflect.ValueOf(secondHookFuncs[0])))
+			return dummyCombinedPreActionFuncs
+		}
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyRoute.PostActionFuncs[0])), fmt.Sprintf("%v", reflect.ValueOf(firstHookFuncs[0])))
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyPostActionFuncs[0])), fmt.Sprintf("%v", reflect.ValueOf(secondHookFuncs[0])))
+		return dummyCombinedPostActionFuncs
+	}
+
+	// SUT + act
+	registerRoute(
+		dummyRouter,
+		dummyRoute,
+		dummyPreActionFuncs,
+		dummyPostActionFuncs,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetHookFuncs(t *testing.T) {
+	// arrange
+	var dummyHookFunc = func(sessionModel.Session) error { return nil }
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var nilResult = getHookFuncs(
+		nil,
+	)
+	var validResult = getHookFuncs(
+		dummyHookFunc,
+	)
+
+	// assert
+	assert.Nil(t, nilResult)
+	assert.Equal(t, 1, len(validResult))
+	assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyHookFunc)), fmt.Sprintf("%v", reflect.ValueOf(validResult[0])))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetIncludedRoutes(t *testing.T) {
+	// arrange
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
+	var dummyRoutes = []model.Route{
+		{Endpoint: "some endpoint 1"},
+		{Endpoint: "some endpoint 2"},
+		{Endpoint: "some endpoint 3"},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEndpointIncludedFuncExpected = 3
+	isEndpointIncludedFunc = func(name string, endpoints []string) bool {
+		isEndpointIncludedFuncCalled++
+		assert.Equal(t, dummyRoutes[isEndpointIncludedFuncCalled-1].Endpoint, name)
+		assert.Equal(t, dummyEndpoints, endpoints)
+		return name != "some endpoint 2"
+	}
+
+	// SUT + act
+	var result = getIncludedRoutes(
+		dummyRoutes,
+		dummyEndpoints,
+	)
+
+	// assert
+	assert.Equal(t, []model.Route{dummyRoutes[0], dummyRoutes[2]}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRegisterRouteGroups_NilRouteGroupsFunc(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
+
+	// stub
+	customization.RouteGroups = nil
+
+	// mock
+	createMock(t)
+
+	// expect
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "register", category)
+		assert.Equal(t, "registerRouteGroups", subcategory)
+		assert.Equal(t, "customization.RouteGroups function not set: no route groups registered!", messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+
+	// SUT + act
+	registerRouteGroups(
+		dummyRouter,
+		dummyEndpoints,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRegisterRouteGroups_ValidRouteGroups(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummySubrouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
+	var dummyMiddleware1 = func(next http.Handler) http.Handler { return next }
+	var dummyMiddleware2 = func(next http.Handler) http.Handler { return next }
+	var dummyPreActionFunc = func(sessionModel.Session) error { return nil }
+	var dummyPreActionFuncs = []model.HookFunc{dummyPreActionFunc}
+	var dummyRouteGroups = []model.RouteGroup{
+		{
+			Name:       "some excluded group",
+			PathPrefix: "some excluded prefix",
+			Routes:     []model.Route{{Endpoint: "some excluded endpoint"}},
+		},
+		{
+			Name:           "some group",
+			PathPrefix:     "some prefix",
+			Middlewares:    []model.MiddlewareFunc{dummyMiddleware1, dummyMiddleware2},
+			PreActionFunc:  dummyPreActionFunc,
+			PostActionFunc: nil,
+			Routes:         []model.Route{{Endpoint: "some endpoint 1"}, {Endpoint: "some endpoint 2"}},
+		},
+	}
+
+	// stub
+	customization.RouteGroups = func() []model.RouteGroup {
+		return dummyRouteGroups
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getIncludedRoutesFuncExpected = 2
+	getIncludedRoutesFunc = func(routes []model.Route, endpoints []string) []model.Route {
+		getIncludedRoutesFuncCalled++
+		assert.Equal(t, dummyRouteGroups[getIncludedRoutesFuncCalled-1].Routes, routes)
+		assert.Equal(t, dummyEndpoints, endpoints)
+		if getIncludedRoutesFuncCalled == 1 {
+			return []model.Route{}
+		}
+		return routes
+	}
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "register", category)
+		assert.Equal(t, "registerRouteGroups", subcategory)
+		assert.Equal(t, "Route group [%v] has no routes included: group skipped.", messageFormat)
+		assert.Equal(t, []interface{}{"some excluded group"}, parameters)
+	}
+	routeCreateSubrouterExpected = 1
+	routeCreateSubrouter = func(router *mux.Router, pathPrefix string) *mux.Router {
+		routeCreateSubrouterCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, "some prefix", pathPrefix)
+		return dummySubrouter
+	}
+	routeAddMiddlewareExpected = 2
+	routeAddMiddleware = func(router *mux.Router, middleware model.MiddlewareFunc) {
+		routeAddMiddlewareCalled++
+		assert.Equal(t, dummySubrouter, router)
+		var expectedMiddleware = dummyRouteGroups[1].Middlewares[routeAddMiddlewareCalled-1]
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(expectedMiddleware)), fmt.Sprintf("%v", reflect.ValueOf(middleware)))
+	}
+	getHookFuncsFuncExpected = 2
+	getHookFuncsFunc = func(hookFunc model.HookFunc) []model.HookFunc {
+		getHookFuncsFuncCalled++
+		if getHookFuncsFuncCalled == 1 {
+			assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyPreActionFunc)), fmt.Sprintf("%v", reflect.ValueOf(hookFunc)))
+			return dummyPreActionFuncs
+		}
+		assert.Nil(t, hookFunc)
+		return nil
+	}
+	registerRouteFuncExpected = 2
+	registerRouteFunc = func(router *mux.Router, configuredRoute model.Route, preActionFuncs []model.HookFunc, postActionFuncs []model.HookFunc) {
+		registerRouteFuncCalled++
+		assert.Equal(t, dummySubrouter, router)
+		assert.Equal(t, dummyRouteGroups[1].Routes[registerRouteFuncCalled-1], configuredRoute)
+		assert.Equal(t, 1, len(preActionFuncs))
+		assert.Nil(t, postActionFuncs)
+	}
+
+	// SUT + act
+	registerRouteGroups(
+		dummyRouter,
+		dummyEndpoints,
+	)
+
+	// verify
+	verifyAll(t)
+	customization.RouteGroups = nil
+}
+
+func TestRegisterRouteGroups_SharedEndpointName(t *testing.T) {
+	// arrange
+	var dummyRouter = mux.NewRouter()
+	var dummyEndpoints = []string{"some endpoint"}
+	var dummyRouteGroups = []model.RouteGroup{
+		{
+			Name:       "some admin group",
+			PathPrefix: "/admin",
+			Routes: []model.Route{
+				{
+					Endpoint: "some endpoint",
+					Method:   http.MethodGet,
+					Path:     "/foo",
+					Auth:     &model.AuthRequirement{Roles: []string{"some role"}},
+				},
+			},
+		},
+		{
+			Name:       "some public group",
+			PathPrefix: "/public",
+			Routes: []model.Route{
+				{
+					Endpoint: "some endpoint",
+					Method:   http.MethodGet,
+					Path:     "/foo",
+				},
+			},
+		},
+	}
+
+	// stub
+	customization.RouteGroups = func() []model.RouteGroup {
+		return dummyRouteGroups
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getIncludedRoutesFuncExpected = 2
+	getIncludedRoutesFunc = func(routes []model.Route, endpoints []string) []model.Route {
+		getIncludedRoutesFuncCalled++
+		return routes
+	}
+	routeCreateSubrouterExpected = 2
+	routeCreateSubrouter = func(router *mux.Router, pathPrefix string) *mux.Router {
+		routeCreateSubrouterCalled++
+		return route.CreateSubrouter(router, pathPrefix)
+	}
+	getHookFuncsFuncExpected = 4
+	getHookFuncsFunc = func(hookFunc model.HookFunc) []model.HookFunc {
+		getHookFuncsFuncCalled++
+		return nil
+	}
+	registerRouteFuncExpected = 2
+	registerRouteFunc = func(router *mux.Router, configuredRoute model.Route, preActionFuncs []model.HookFunc, postActionFuncs []model.HookFunc) {
+		registerRouteFuncCalled++
+		registerRoute(router, configuredRoute, preActionFuncs, postActionFuncs)
+	}
+	evaluatePathWithParametersFuncExpected = 2
+	evaluatePathWithParametersFunc = func(path string, parameters map[string]model.ParameterType) string {
+		evaluatePathWithParametersFuncCalled++
+		return path
+	}
+	evaluateQueriesFuncExpected = 2
+	evaluateQueriesFunc = func(queries map[string]model.ParameterType) []string {
+		evaluateQueriesFuncCalled++
+		return nil
+	}
+	combineHookFuncsFuncExpected = 4
+	combineHookFuncsFunc = func(firstHookFuncs []model.HookFunc, secondHookFuncs []model.HookFunc) []model.HookFunc {
+		combineHookFuncsFuncCalled++
+		return combineHookFuncs(firstHookFuncs, secondHookFuncs)
+	}
+	routeHandleFuncExpected = 2
+	routeHandleFunc = func(router *mux.Router, routeInfo model.Route, path string, queries []string, handlerFunc func(http.ResponseWriter, *http.Request)) *mux.Route {
+		routeHandleFuncCalled++
+		return route.HandleFunc(router, routeInfo, path, queries, handlerFunc)
+	}
+	handlerSessionExpected = 2
+	handlerSession = func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		handlerSessionCalled++
+		var routeInfo, routeError = route.GetRouteInfo(httpRequest)
+		assert.NoError(t, routeError)
+		var authError = auth.Check(
+			session.Register(routeInfo.Endpoint, httpRequest, responseWriter),
+			routeInfo,
+		)
+		if authError != nil {
+			responseWriter.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		responseWriter.WriteHeader(http.StatusOK)
+	}
+
+	// SUT
+	registerRouteGroups(
+		dummyRouter,
+		dummyEndpoints,
+	)
+
+	// act
+	var adminRecorder = httptest.NewRecorder()
+	dummyRouter.ServeHTTP(adminRecorder, httptest.NewRequest(http.MethodGet, "/admin/foo", nil))
+	var publicRecorder = httptest.NewRecorder()
+	dummyRouter.ServeHTTP(publicRecorder, httptest.NewRequest(http.MethodGet, "/public/foo", nil))
+
+	// assert
+	assert.Equal(t, http.StatusUnauthorized, adminRecorder.Code)
+	assert.Equal(t, http.StatusOK, publicRecorder.Code)
+
+	// verify
+	verifyAll(t)
+	customization.RouteGroups = nil
+}
+
+func TestRegisterPreflight(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyPreflightHandlerExpected = 0
+	var dummyPreflightHandlerCalled = 0
+	var dummyPreflightHandler = func(http.ResponseWriter, *http.Request) {
+		dummyPreflightHandlerCalled++
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	corsPreflightHandlerExpected = 1
+	corsPreflightHandler = func(router *mux.Router) func(http.ResponseWriter, *http.Request) {
+		corsPreflightHandlerCalled++
+		assert.Equal(t, dummyRouter, router)
+		return dummyPreflightHandler
+	}
+	routeHostPreflightExpected = 1
+	routeHostPreflight = func(router *mux.Router, name string, handleFunc func(http.ResponseWriter, *http.Request)) *mux.Route {
+		routeHostPreflightCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, cors.Endpoint, name)
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(dummyPreflightHandler)), fmt.Sprintf("%v", reflect.ValueOf(handleFunc)))
+		return nil
+	}
+
+	// SUT + act
+	registerPreflight(
+		dummyRouter,
+	)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyPreflightHandlerExpected, dummyPreflightHandlerCalled, "Unexpected number of calls to dummyPreflightHandler")
+}
+
 func TestRegisterStatics_NilStaticsFunc(t *testing.T) {
 	// arrange
 	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
 
 	// stub
 	customization.Statics = nil
@@ -394,6 +906,7 @@ func TestRegisterStatics_NilStaticsFunc(t *testing.T) {
 	// SUT + act
 	registerStatics(
 		dummyRouter,
+		dummyEndpoints,
 	)
 
 	// verify
@@ -403,6 +916,7 @@ func TestRegisterStatics_NilStaticsFunc(t *testing.T) {
 func TestRegisterStatics_EmptyStatics(t *testing.T) {
 	// arrange
 	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
 	var staticsExpected int
 	var staticsCalled int
 	var dummyStatics []model.Static
@@ -428,6 +942,7 @@ func TestRegisterStatics_EmptyStatics(t *testing.T) {
 	// SUT + act
 	registerStatics(
 		dummyRouter,
+		dummyEndpoints,
 	)
 
 	// verify
@@ -438,6 +953,7 @@ func TestRegisterStatics_EmptyStatics(t *testing.T) {
 func TestRegisterStatics_ValidStatics(t *testing.T) {
 	// arrange
 	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
 	var staticsExpected int
 	var staticsCalled int
 	var dummyName1 = "some name 1"
@@ -446,6 +962,7 @@ func TestRegisterStatics_ValidStatics(t *testing.T) {
 	var dummyName2 = "some name 2"
 	var dummyPathPrefix2 = "some path prefix 2"
 	var dummyHandler2 = dummyHandler{t}
+	var dummyName3 = "some name 3"
 	var dummyStatics = []model.Static{
 		{
 			Name:       dummyName1,
@@ -457,6 +974,10 @@ func TestRegisterStatics_ValidStatics(t *testing.T) {
 			PathPrefix: dummyPathPrefix2,
 			Handler:    dummyHandler2,
 		},
+		{
+			Name:       dummyName3,
+			PathPrefix: "some path prefix 3",
+		},
 	}
 
 	// mock
@@ -468,6 +989,13 @@ func TestRegisterStatics_ValidStatics(t *testing.T) {
 		staticsCalled++
 		return dummyStatics
 	}
+	isEndpointIncludedFuncExpected = 3
+	isEndpointIncludedFunc = func(name string, endpoints []string) bool {
+		isEndpointIncludedFuncCalled++
+		assert.Equal(t, dummyStatics[isEndpointIncludedFuncCalled-1].Name, name)
+		assert.Equal(t, dummyEndpoints, endpoints)
+		return name != dummyName3
+	}
 	routeHostStaticExpected = 2
 	routeHostStatic = func(router *mux.Router, name string, path string, handler http.Handler) *mux.Route {
 		routeHostStaticCalled++
@@ -487,6 +1015,7 @@ func TestRegisterStatics_ValidStatics(t *testing.T) {
 	// SUT + act
 	registerStatics(
 		dummyRouter,
+		dummyEndpoints,
 	)
 
 	// verify
@@ -494,6 +1023,446 @@ func TestRegisterStatics_ValidStatics(t *testing.T) {
 	assert.Equal(t, staticsExpected, staticsCalled, "Unexpected number of calls to Statics")
 }
 
+func TestRegisterHealthChecks_NilHealthChecksFunc(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
+
+	// stub
+	customization.HealthChecks = nil
+
+	// mock
+	createMock(t)
+
+	// expect
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "register", category)
+		assert.Equal(t, "registerHealthChecks", subcategory)
+		assert.Equal(t, "customization.HealthChecks function not set: no health endpoints registered!", messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+
+	// SUT + act
+	registerHealthChecks(
+		dummyRouter,
+		dummyEndpoints,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRegisterHealthChecks_PartialEndpoints(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
+
+	// stub
+	customization.HealthChecks = func() []model.HealthCheck {
+		return nil
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEndpointIncludedFuncExpected = 2
+	isEndpointIncludedFunc = func(name string, endpoints []string) bool {
+		isEndpointIncludedFuncCalled++
+		assert.Equal(t, dummyEndpoints, endpoints)
+		if isEndpointIncludedFuncCalled == 1 {
+			assert.Equal(t, health.LiveEndpoint, name)
+			return false
+		}
+		assert.Equal(t, health.ReadyEndpoint, name)
+		return true
+	}
+	routeHostHandlerExpected = 1
+	routeHostHandler = func(router *mux.Router, name string, method string, path string, handleFunc func(http.ResponseWriter, *http.Request)) *mux.Route {
+		routeHostHandlerCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, health.ReadyEndpoint, name)
+		assert.Equal(t, http.MethodGet, method)
+		assert.Equal(t, health.ReadyPath, path)
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(healthReadyHandler)), fmt.Sprintf("%v", reflect.ValueOf(handleFunc)))
+		return nil
+	}
+
+	// SUT + act
+	registerHealthChecks(
+		dummyRouter,
+		dummyEndpoints,
+	)
+
+	// verify
+	verifyAll(t)
+	customization.HealthChecks = nil
+}
+
+func TestRegisterHealthChecks_AllEndpoints(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var expectedNames = []string{health.LiveEndpoint, health.ReadyEndpoint}
+	var expectedPaths = []string{health.LivePath, health.ReadyPath}
+
+	// stub
+	customization.HealthChecks = func() []model.HealthCheck {
+		return nil
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	var expectedHandlers = []string{
+		fmt.Sprintf("%v", reflect.ValueOf(healthLiveHandler)),
+		fmt.Sprintf("%v", reflect.ValueOf(healthReadyHandler)),
+	}
+	isEndpointIncludedFuncExpected = 2
+	isEndpointIncludedFunc = func(name string, endpoints []string) bool {
+		isEndpointIncludedFuncCalled++
+		assert.Empty(t, endpoints)
+		return true
+	}
+	routeHostHandlerExpected = 2
+	routeHostHandler = func(router *mux.Router, name string, method string, path string, handleFunc func(http.ResponseWriter, *http.Request)) *mux.Route {
+		routeHostHandlerCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, expectedNames[routeHostHandlerCalled-1], name)
+		assert.Equal(t, http.MethodGet, method)
+		assert.Equal(t, expectedPaths[routeHostHandlerCalled-1], path)
+		assert.Equal(t, expectedHandlers[routeHostHandlerCalled-1], fmt.Sprintf("%v", reflect.ValueOf(handleFunc)))
+		return nil
+	}
+
+	// SUT + act
+	registerHealthChecks(
+		dummyRouter,
+		nil,
+	)
+
+	// verify
+	verifyAll(t)
+	customization.HealthChecks = nil
+}
+
+func TestRegisterMetrics_NilMetricsPathFunc(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
+
+	// stub
+	customization.MetricsPath = nil
+
+	// mock
+	createMock(t)
+
+	// expect
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "register", category)
+		assert.Equal(t, "registerMetrics", subcategory)
+		assert.Equal(t, "customization.MetricsPath function not set: no metrics endpoint registered!", messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+
+	// SUT + act
+	registerMetrics(
+		dummyRouter,
+		dummyEndpoints,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRegisterMetrics_EndpointExcluded(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
+
+	// stub
+	customization.MetricsPath = func() string {
+		return "some path"
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEndpointIncludedFuncExpected = 1
+	isEndpointIncludedFunc = func(name string, endpoints []string) bool {
+		isEndpointIncludedFuncCalled++
+		assert.Equal(t, metrics.Endpoint, name)
+		assert.Equal(t, dummyEndpoints, endpoints)
+		return false
+	}
+
+	// SUT + act
+	registerMetrics(
+		dummyRouter,
+		dummyEndpoints,
+	)
+
+	// verify
+	verifyAll(t)
+	customization.MetricsPath = nil
+}
+
+func TestRegisterMetrics_EndpointIncluded(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
+	var dummyPath = "some path"
+
+	// stub
+	customization.MetricsPath = func() string {
+		return dummyPath
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	var expectedHandler = fmt.Sprintf("%v", reflect.ValueOf(metricsHandler))
+	isEndpointIncludedFuncExpected = 1
+	isEndpointIncludedFunc = func(name string, endpoints []string) bool {
+		isEndpointIncludedFuncCalled++
+		assert.Equal(t, metrics.Endpoint, name)
+		assert.Equal(t, dummyEndpoints, endpoints)
+		return true
+	}
+	routeHostHandlerExpected = 1
+	routeHostHandler = func(router *mux.Router, name string, method string, path string, handleFunc func(http.ResponseWriter, *http.Request)) *mux.Route {
+		routeHostHandlerCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, metrics.Endpoint, name)
+		assert.Equal(t, http.MethodGet, method)
+		assert.Equal(t, dummyPath, path)
+		assert.Equal(t, expectedHandler, fmt.Sprintf("%v", reflect.ValueOf(handleFunc)))
+		return nil
+	}
+
+	// SUT + act
+	registerMetrics(
+		dummyRouter,
+		dummyEndpoints,
+	)
+
+	// verify
+	verifyAll(t)
+	customization.MetricsPath = nil
+}
+
+func TestRegisterOpenAPI_NilOpenAPIPathFunc(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
+
+	// stub
+	customization.OpenAPIPath = nil
+
+	// mock
+	createMock(t)
+
+	// expect
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "register", category)
+		assert.Equal(t, "registerOpenAPI", subcategory)
+		assert.Equal(t, "customization.OpenAPIPath function not set: no OpenAPI endpoint registered!", messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+
+	// SUT + act
+	registerOpenAPI(
+		dummyRouter,
+		dummyEndpoints,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRegisterOpenAPI_EndpointExcluded(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
+
+	// stub
+	customization.OpenAPIPath = func() string {
+		return "some path"
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEndpointIncludedFuncExpected = 1
+	isEndpointIncludedFunc = func(name string, endpoints []string) bool {
+		isEndpointIncludedFuncCalled++
+		assert.Equal(t, openapi.Endpoint, name)
+		assert.Equal(t, dummyEndpoints, endpoints)
+		return false
+	}
+
+	// SUT + act
+	registerOpenAPI(
+		dummyRouter,
+		dummyEndpoints,
+	)
+
+	// verify
+	verifyAll(t)
+	customization.OpenAPIPath = nil
+}
+
+func TestRegisterOpenAPI_NilRoutesFunc(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
+	var dummyPath = "some path"
+	var dummyHandlerCalled = 0
+	var dummyHandler = func(http.ResponseWriter, *http.Request) { dummyHandlerCalled++ }
+
+	// stub
+	customization.OpenAPIPath = func() string {
+		return dummyPath
+	}
+	customization.Routes = nil
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEndpointIncludedFuncExpected = 1
+	isEndpointIncludedFunc = func(name string, endpoints []string) bool {
+		isEndpointIncludedFuncCalled++
+		assert.Equal(t, openapi.Endpoint, name)
+		return true
+	}
+	openapiGetHandlerExpected = 1
+	openapiGetHandler = func(routes []model.Route) func(http.ResponseWriter, *http.Request) {
+		openapiGetHandlerCalled++
+		assert.Empty(t, routes)
+		return dummyHandler
+	}
+	routeHostHandlerExpected = 1
+	routeHostHandler = func(router *mux.Router, name string, method string, path string, handleFunc func(http.ResponseWriter, *http.Request)) *mux.Route {
+		routeHostHandlerCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, openapi.Endpoint, name)
+		assert.Equal(t, http.MethodGet, method)
+		assert.Equal(t, dummyPath, path)
+		handleFunc(nil, nil)
+		return nil
+	}
+
+	// SUT + act
+	registerOpenAPI(
+		dummyRouter,
+		dummyEndpoints,
+	)
+
+	// assert
+	assert.Equal(t, 1, dummyHandlerCalled)
+
+	// verify
+	verifyAll(t)
+	customization.OpenAPIPath = nil
+}
+
+func TestRegisterOpenAPI_EndpointIncluded(t *testing.T) {
+	// arrange
+	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
+	var dummyPath = "some path"
+	var dummyRoutes = []model.Route{
+		{Endpoint: "some endpoint", Method: http.MethodGet, Path: "/some"},
+	}
+	var dummyRouteGroups = []model.RouteGroup{
+		{
+			PathPrefix: "/group",
+			Routes: []model.Route{
+				{Endpoint: "some group endpoint", Method: http.MethodPost, Path: "/other"},
+			},
+		},
+	}
+	var dummyHandlerCalled = 0
+	var dummyHandler = func(http.ResponseWriter, *http.Request) { dummyHandlerCalled++ }
+
+	// stub
+	customization.OpenAPIPath = func() string {
+		return dummyPath
+	}
+	customization.Routes = func() []model.Route {
+		return dummyRoutes
+	}
+	customization.RouteGroups = func() []model.RouteGroup {
+		return dummyRouteGroups
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	isEndpointIncludedFuncExpected = 1
+	isEndpointIncludedFunc = func(name string, endpoints []string) bool {
+		isEndpointIncludedFuncCalled++
+		assert.Equal(t, openapi.Endpoint, name)
+		assert.Equal(t, dummyEndpoints, endpoints)
+		return true
+	}
+	getIncludedRoutesFuncExpected = 2
+	getIncludedRoutesFunc = func(routes []model.Route, endpoints []string) []model.Route {
+		getIncludedRoutesFuncCalled++
+		assert.Equal(t, dummyEndpoints, endpoints)
+		if getIncludedRoutesFuncCalled == 1 {
+			assert.Equal(t, dummyRoutes, routes)
+		} else {
+			assert.Equal(t, dummyRouteGroups[0].Routes, routes)
+		}
+		return routes
+	}
+	openapiGetHandlerExpected = 1
+	openapiGetHandler = func(routes []model.Route) func(http.ResponseWriter, *http.Request) {
+		openapiGetHandlerCalled++
+		assert.Equal(t, []model.Route{
+			dummyRoutes[0],
+			{Endpoint: "some group endpoint", Method: http.MethodPost, Path: "/group/other"},
+		}, routes)
+		return dummyHandler
+	}
+	routeHostHandlerExpected = 1
+	routeHostHandler = func(router *mux.Router, name string, method string, path string, handleFunc func(http.ResponseWriter, *http.Request)) *mux.Route {
+		routeHostHandlerCalled++
+		assert.Equal(t, openapi.Endpoint, name)
+		assert.Equal(t, dummyPath, path)
+		handleFunc(nil, nil)
+		return nil
+	}
+
+	// SUT + act
+	registerOpenAPI(
+		dummyRouter,
+		dummyEndpoints,
+	)
+
+	// assert
+	assert.Equal(t, 1, dummyHandlerCalled)
+	assert.Equal(t, "/other", dummyRouteGroups[0].Routes[0].Path)
+
+	// verify
+	verifyAll(t)
+	customization.OpenAPIPath = nil
+	customization.Routes = nil
+	customization.RouteGroups = nil
+}
+
 func TestRegisterMiddlewares_NilMiddlewaresFunc(t *testing.T) {
 	// arrange
 	var dummyRouter = &mux.Router{}
@@ -707,6 +1676,7 @@ func TestInstrumentRouter_WithCustomization(t *testing.T) {
 func TestInstantiate_RouterError(t *testing.T) {
 	// arrange
 	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
 	var dummyRouteError = errors.New("some route error")
 	var dummyMessageFormat = "Failed to instantiate routes"
 	var dummyAppError = apperror.GetCustomError(0, "some app error")
@@ -721,14 +1691,45 @@ func TestInstantiate_RouterError(t *testing.T) {
 		return dummyRouter
 	}
 	registerRoutesFuncExpected = 1
-	registerRoutesFunc = func(router *mux.Router) {
+	registerRoutesFunc = func(router *mux.Router, endpoints []string) {
 		registerRoutesFuncCalled++
 		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyEndpoints, endpoints)
+	}
+	registerRouteGroupsFuncExpected = 1
+	registerRouteGroupsFunc = func(router *mux.Router, endpoints []string) {
+		registerRouteGroupsFuncCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyEndpoints, endpoints)
+	}
+	registerPreflightFuncExpected = 1
+	registerPreflightFunc = func(router *mux.Router) {
+		registerPreflightFuncCalled++
+		assert.Equal(t, dummyRouter, router)
 	}
 	registerStaticsFuncExpected = 1
-	registerStaticsFunc = func(router *mux.Router) {
+	registerStaticsFunc = func(router *mux.Router, endpoints []string) {
 		registerStaticsFuncCalled++
 		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyEndpoints, endpoints)
+	}
+	registerHealthChecksFuncExpected = 1
+	registerHealthChecksFunc = func(router *mux.Router, endpoints []string) {
+		registerHealthChecksFuncCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyEndpoints, endpoints)
+	}
+	registerMetricsFuncExpected = 1
+	registerMetricsFunc = func(router *mux.Router, endpoints []string) {
+		registerMetricsFuncCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyEndpoints, endpoints)
+	}
+	registerOpenAPIFuncExpected = 1
+	registerOpenAPIFunc = func(router *mux.Router, endpoints []string) {
+		registerOpenAPIFuncCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyEndpoints, endpoints)
 	}
 	registerMiddlewaresFuncExpected = 1
 	registerMiddlewaresFunc = func(router *mux.Router) {
@@ -752,7 +1753,7 @@ func TestInstantiate_RouterError(t *testing.T) {
 	}
 
 	// SUT + act
-	var result, err = Instantiate()
+	var result, err = Instantiate(dummyEndpoints)
 
 	// assert
 	assert.Nil(t, result)
@@ -765,6 +1766,7 @@ func TestInstantiate_RouterError(t *testing.T) {
 func TestInstantiate_Success(t *testing.T) {
 	// arrange
 	var dummyRouter = &mux.Router{}
+	var dummyEndpoints = []string{"some endpoint", "some other endpoint"}
 	var dummyInstrumentedRouter = &mux.Router{}
 
 	// mock
@@ -777,14 +1779,45 @@ func TestInstantiate_Success(t *testing.T) {
 		return dummyRouter
 	}
 	registerRoutesFuncExpected = 1
-	registerRoutesFunc = func(router *mux.Router) {
+	registerRoutesFunc = func(router *mux.Router, endpoints []string) {
 		registerRoutesFuncCalled++
 		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyEndpoints, endpoints)
+	}
+	registerRouteGroupsFuncExpected = 1
+	registerRouteGroupsFunc = func(router *mux.Router, endpoints []string) {
+		registerRouteGroupsFuncCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyEndpoints, endpoints)
+	}
+	registerPreflightFuncExpected = 1
+	registerPreflightFunc = func(router *mux.Router) {
+		registerPreflightFuncCalled++
+		assert.Equal(t, dummyRouter, router)
 	}
 	registerStaticsFuncExpected = 1
-	registerStaticsFunc = func(router *mux.Router) {
+	registerStaticsFunc = func(router *mux.Router, endpoints []string) {
 		registerStaticsFuncCalled++
 		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyEndpoints, endpoints)
+	}
+	registerHealthChecksFuncExpected = 1
+	registerHealthChecksFunc = func(router *mux.Router, endpoints []string) {
+		registerHealthChecksFuncCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyEndpoints, endpoints)
+	}
+	registerMetricsFuncExpected = 1
+	registerMetricsFunc = func(router *mux.Router, endpoints []string) {
+		registerMetricsFuncCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyEndpoints, endpoints)
+	}
+	registerOpenAPIFuncExpected = 1
+	registerOpenAPIFunc = func(router *mux.Router, endpoints []string) {
+		registerOpenAPIFuncCalled++
+		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyEndpoints, endpoints)
 	}
 	registerMiddlewaresFuncExpected = 1
 	registerMiddlewaresFunc = func(router *mux.Router) {
@@ -810,7 +1843,7 @@ func TestInstantiate_Success(t *testing.T) {
 	}
 
 	// SUT + act
-	var result, err = Instantiate()
+	var result, err = Instantiate(dummyEndpoints)
 
 	// assert
 	assert.Equal(t, dummyInstrumentedRouter, result)
@@ -29,6 +29,7 @@ var (
 	getQueriesRegexpFunc            = getQueriesRegexp
 	getMethodsFunc                  = getMethods
 	getEndpointByNameFunc           = getEndpointByName
-	getActionByNameFunc             = getActionByName
+	getRouteInfoByRouteFunc         = GetRouteInfoByRoute
 	printRegisteredRouteDetailsFunc = printRegisteredRouteDetails
+	isPreflightRequestFunc          = isPreflightRequest
 )
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -16,6 +17,7 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
 	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
 )
 
@@ -50,12 +52,14 @@ var (
 	getQueriesRegexpFuncCalled              int
 	getMethodsFuncExpected                  int
 	getMethodsFuncCalled                    int
-	getActionByNameFuncExpected             int
-	getActionByNameFuncCalled               int
+	getRouteInfoByRouteFuncExpected         int
+	getRouteInfoByRouteFuncCalled           int
 	getEndpointByNameFuncExpected           int
 	getEndpointByNameFuncCalled             int
 	printRegisteredRouteDetailsFuncExpected int
 	printRegisteredRouteDetailsFuncCalled   int
+	isPreflightRequestFuncExpected          int
+	isPreflightRequestFuncCalled            int
 )
 
 func createMock(t *testing.T) {
@@ -148,11 +152,11 @@ func createMock(t *testing.T) {
 		getMethodsFuncCalled++
 		return ""
 	}
-	getActionByNameFuncExpected = 0
-	getActionByNameFuncCalled = 0
-	getActionByNameFunc = func(name string) model.ActionFunc {
-		getActionByNameFuncCalled++
-		return nil
+	getRouteInfoByRouteFuncExpected = 0
+	getRouteInfoByRouteFuncCalled = 0
+	getRouteInfoByRouteFunc = func(route *mux.Route) (model.Route, bool) {
+		getRouteInfoByRouteFuncCalled++
+		return model.Route{}, false
 	}
 	getEndpointByNameFuncExpected = 0
 	getEndpointByNameFuncCalled = 0
@@ -166,6 +170,12 @@ func createMock(t *testing.T) {
 		printRegisteredRouteDetailsFuncCalled++
 		return nil
 	}
+	isPreflightRequestFuncExpected = 0
+	isPreflightRequestFuncCalled = 0
+	isPreflightRequestFunc = func(httpRequest *http.Request, routeMatch *mux.RouteMatch) bool {
+		isPreflightRequestFuncCalled++
+		return false
+	}
 }
 
 func verifyAll(t *testing.T) {
@@ -199,12 +209,14 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, getQueriesRegexpFuncExpected, getQueriesRegexpFuncCalled, "Unexpected number of calls to getQueriesRegexpFunc")
 	getMethodsFunc = getMethods
 	assert.Equal(t, getMethodsFuncExpected, getMethodsFuncCalled, "Unexpected number of calls to getMethodsFunc")
-	getActionByNameFunc = getActionByName
-	assert.Equal(t, getActionByNameFuncExpected, getActionByNameFuncCalled, "Unexpected number of calls to getActionByNameFunc")
+	getRouteInfoByRouteFunc = GetRouteInfoByRoute
+	assert.Equal(t, getRouteInfoByRouteFuncExpected, getRouteInfoByRouteFuncCalled, "Unexpected number of calls to getRouteInfoByRouteFunc")
 	getEndpointByNameFunc = getEndpointByName
 	assert.Equal(t, getEndpointByNameFuncExpected, getEndpointByNameFuncCalled, "Unexpected number of calls to getEndpointByNameFunc")
 	printRegisteredRouteDetailsFunc = printRegisteredRouteDetails
 	assert.Equal(t, printRegisteredRouteDetailsFuncExpected, printRegisteredRouteDetailsFuncCalled, "Unexpected number of calls to printRegisteredRouteDetailsFunc")
+	isPreflightRequestFunc = isPreflightRequest
+	assert.Equal(t, isPreflightRequestFuncExpected, isPreflightRequestFuncCalled, "Unexpected number of calls to isPreflightRequestFunc")
 }
 
 // mock structs
@@ -230,6 +242,11 @@ func (session *dummySession) GetName() string {
 	return ""
 }
 
+func (session *dummySession) GetCorrelationID() string {
+	assert.Fail(session.t, "Unexpected call to GetCorrelationID")
+	return ""
+}
+
 func (session *dummySession) GetRequest() *http.Request {
 	assert.Fail(session.t, "Unexpected call to GetRequest")
 	return nil
@@ -240,6 +257,14 @@ func (session *dummySession) GetResponseWriter() http.ResponseWriter {
 	return nil
 }
 
+func (session *dummySession) SetResponseETag(etag string, weak bool) {
+	assert.Fail(session.t, "Unexpected call to SetResponseETag")
+}
+
+func (session *dummySession) SetResponseLastModified(lastModified time.Time) {
+	assert.Fail(session.t, "Unexpected call to SetResponseLastModified")
+}
+
 func (session *dummySession) GetRequestBody(dataTemplate interface{}) apperrorModel.AppError {
 	assert.Fail(session.t, "Unexpected call to GetRequestBody")
 	return nil
@@ -270,6 +295,26 @@ func (session *dummySession) GetRequestHeaders(name string, dataTemplate interfa
 	return nil
 }
 
+func (session *dummySession) GetRequestFormValue(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValue")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValues(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValues")
+	return nil
+}
+
+func (session *dummySession) GetRequestFile(name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFile")
+	return nil
+}
+
+func (session *dummySession) BindRequest(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to BindRequest")
+	return nil
+}
+
 func (session *dummySession) Attach(name string, value interface{}) bool {
 	assert.Fail(session.t, "Unexpected call to Attach")
 	return false
@@ -13,7 +13,18 @@ const (
 	stringSeparator string = "|"
 )
 
-var registeredRouteActionFuncs map[string]model.ActionFunc
+// actionHandler carries the settings of a route along with its handler, so that the settings are scoped to the route registered in each router, even if routes of different groups share the same endpoint and method
+type actionHandler struct {
+	handleFunc func(http.ResponseWriter, *http.Request)
+	routeInfo  model.Route
+}
+
+func (handler *actionHandler) ServeHTTP(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+	handler.handleFunc(
+		responseWriter,
+		httpRequest,
+	)
+}
 
 func getName(route *mux.Route) string {
 	return route.GetName()
@@ -98,37 +109,35 @@ func WalkRegisteredRoutes(router *mux.Router) error {
 
 // CreateRouter initializes a router for route registrations
 func CreateRouter() *mux.Router {
-	registeredRouteActionFuncs = map[string]model.ActionFunc{}
 	return muxNewRouter()
 }
 
-// HandleFunc wraps the mux route handler
+// HandleFunc wraps the mux route handler, attaching the given route settings to the registered route for retrieval through GetRouteInfo
 func HandleFunc(
 	router *mux.Router,
-	endpoint string,
-	method string,
+	routeInfo model.Route,
 	path string,
 	queries []string,
 	handleFunc func(http.ResponseWriter, *http.Request),
-	actionFunc model.ActionFunc,
 ) *mux.Route {
 	var name = fmtSprintf(
 		"%v:%v",
-		endpoint,
-		method,
+		routeInfo.Endpoint,
+		routeInfo.Method,
 	)
-	var route = router.HandleFunc(
+	return router.Handle(
 		path,
-		handleFunc,
+		&actionHandler{
+			handleFunc,
+			routeInfo,
+		},
 	).Methods(
-		method,
+		routeInfo.Method,
 	).Queries(
 		queries...,
 	).Name(
 		name,
 	)
-	registeredRouteActionFuncs[name] = actionFunc
-	return route
 }
 
 // HostStatic wraps the mux static content handler
@@ -147,6 +156,57 @@ func HostStatic(
 	)
 }
 
+// HostHandler wraps the mux plain handler registration, bypassing session handling; usually useful for infrastructure endpoints such as health checks
+func HostHandler(
+	router *mux.Router,
+	name string,
+	method string,
+	path string,
+	handleFunc func(http.ResponseWriter, *http.Request),
+) *mux.Route {
+	return router.HandleFunc(
+		path,
+		handleFunc,
+	).Methods(
+		method,
+	).Name(
+		name,
+	)
+}
+
+// HostPreflight wraps the mux registration of the CORS preflight handler, serving OPTIONS requests carrying the Access-Control-Request-Method header upon any path
+func HostPreflight(
+	router *mux.Router,
+	name string,
+	handleFunc func(http.ResponseWriter, *http.Request),
+) *mux.Route {
+	return router.PathPrefix(
+		"/",
+	).MatcherFunc(
+		isPreflightRequestFunc,
+	).HandlerFunc(
+		handleFunc,
+	).Methods(
+		http.MethodOptions,
+	).Name(
+		name,
+	)
+}
+
+func isPreflightRequest(httpRequest *http.Request, routeMatch *mux.RouteMatch) bool {
+	return httpRequest.Header.Get("Access-Control-Request-Method") != ""
+}
+
+// CreateSubrouter wraps the mux subrouter creation for routes sharing the given path prefix, e.g. route groups
+func CreateSubrouter(
+	router *mux.Router,
+	pathPrefix string,
+) *mux.Router {
+	return router.PathPrefix(
+		pathPrefix,
+	).Subrouter()
+}
+
 // AddMiddleware wraps the mux middleware addition function
 func AddMiddleware(
 	router *mux.Router,
@@ -170,27 +230,35 @@ func getEndpointByName(name string) string {
 	return splitSubs[0]
 }
 
-func getActionByName(name string) model.ActionFunc {
-	var actionFunc, found = registeredRouteActionFuncs[name]
-	if !found {
-		return defaultActionFunc
+// GetRouteInfoByRoute retrieves the settings registered through HandleFunc for the given mux route, with its path resolved to the full path template including any route group prefix; returns false if the route is not registered through HandleFunc
+func GetRouteInfoByRoute(route *mux.Route) (model.Route, bool) {
+	var handler, ok = route.GetHandler().(*actionHandler)
+	if !ok {
+		return model.Route{}, false
 	}
-	return actionFunc
+	var routeInfo = handler.routeInfo
+	routeInfo.Path, _ = getPathTemplateFunc(route)
+	return routeInfo, true
 }
 
-// GetRouteInfo retrieves the registered name and action for the given route
-func GetRouteInfo(httpRequest *http.Request) (string, model.ActionFunc, error) {
+// GetRouteInfo retrieves the settings registered through HandleFunc for the route of given request as GetRouteInfoByRoute does, with its action defaulted to NotImplemented if not set
+func GetRouteInfo(httpRequest *http.Request) (model.Route, error) {
 	var route = muxCurrentRoute(httpRequest)
 	if route == nil {
-		return "",
-			nil,
+		return model.Route{},
 			apperrorGetCustomError(
 				apperrorEnum.CodeGeneralFailure,
 				"Failed to retrieve route info for request - no route found",
 			)
 	}
-	var name = getNameFunc(route)
-	var endpoint = getEndpointByNameFunc(name)
-	var action = getActionByNameFunc(name)
-	return endpoint, action, nil
+	var routeInfo, found = getRouteInfoByRouteFunc(route)
+	if !found {
+		routeInfo.Endpoint = getEndpointByNameFunc(
+			getNameFunc(route),
+		)
+	}
+	if routeInfo.ActionFunc == nil {
+		routeInfo.ActionFunc = defaultActionFunc
+	}
+	return routeInfo, nil
 }
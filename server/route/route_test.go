@@ -3,7 +3,9 @@ package route
 import (
 	"errors"
 	"fmt"
+	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
@@ -633,6 +635,45 @@ func TestHostStatic(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestHostHandler(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyMethod = "SOME METHOD"
+	var dummyPath = "/foo/bar"
+	var dummyHandlerFuncExpected = 0
+	var dummyHandlerFuncCalled = 0
+	var dummyHandlerFunc = func(http.ResponseWriter, *http.Request) {
+		dummyHandlerFuncCalled++
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var router = mux.NewRouter()
+
+	// act
+	var route = HostHandler(
+		router,
+		dummyName,
+		dummyMethod,
+		dummyPath,
+		dummyHandlerFunc,
+	)
+	var name = route.GetName()
+	var methods, _ = route.GetMethods()
+	var pathTemplate, _ = route.GetPathTemplate()
+
+	// assert
+	assert.Equal(t, dummyName, name)
+	assert.Equal(t, []string{dummyMethod}, methods)
+	assert.Equal(t, dummyPath, pathTemplate)
+	assert.Equal(t, dummyHandlerFuncExpected, dummyHandlerFuncCalled)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestHandleFunc(t *testing.T) {
 	// arrange
 	var dummyEndpoint = "some endpoint"
@@ -641,6 +682,10 @@ func TestHandleFunc(t *testing.T) {
 	var dummyPath = "/foo/{bar}"
 	var dummyQueries = []string{"test", "{test}"}
 	var dummyQueriesTemplates = []string{"test={test}"}
+	var dummyRouteInfo = model.Route{
+		Endpoint: dummyEndpoint,
+		Method:   dummyMethod,
+	}
 
 	// stub
 	var dummyHandlerFuncExpected = 0
@@ -648,12 +693,6 @@ func TestHandleFunc(t *testing.T) {
 	var dummyHandlerFunc = func(http.ResponseWriter, *http.Request) {
 		dummyHandlerFuncCalled++
 	}
-	var dummyActionFuncExpected = 0
-	var dummyActionFuncCalled = 0
-	var dummyActionFunc = func(sessionModel.Session) (interface{}, error) {
-		dummyActionFuncCalled++
-		return nil, nil
-	}
 
 	// mock
 	createMock(t)
@@ -680,12 +719,10 @@ func TestHandleFunc(t *testing.T) {
 	// act
 	var route = HandleFunc(
 		router,
-		dummyEndpoint,
-		dummyMethod,
+		dummyRouteInfo,
 		dummyPath,
 		dummyQueries,
 		dummyHandlerFunc,
-		dummyActionFunc,
 	)
 	var name = route.GetName()
 	var methods, _ = route.GetMethods()
@@ -699,7 +736,221 @@ func TestHandleFunc(t *testing.T) {
 	assert.Equal(t, dummyPath, pathTemplate)
 	assert.Equal(t, dummyQueriesTemplates, queriesTemplate)
 	assert.Equal(t, dummyHandlerFuncExpected, dummyHandlerFuncCalled)
-	assert.Equal(t, dummyActionFuncExpected, dummyActionFuncCalled)
+	var handler, ok = route.GetHandler().(*actionHandler)
+	assert.True(t, ok)
+	assert.Equal(t, dummyRouteInfo, handler.routeInfo)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCreateRouter_RouteInfoScopedPerRouter(t *testing.T) {
+	// arrange
+	var dummyPath = "/foo"
+	var dummyHandlerFunc = func(http.ResponseWriter, *http.Request) {}
+	var dummyRouteInfo1 = model.Route{
+		Endpoint:     "some endpoint",
+		Method:       http.MethodGet,
+		Path:         dummyPath,
+		MaxBodyBytes: 1,
+	}
+	var dummyRouteInfo2 = model.Route{
+		Endpoint:     "some endpoint",
+		Method:       http.MethodGet,
+		Path:         dummyPath,
+		MaxBodyBytes: 2,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	fmtSprintfExpected = 2
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		return fmt.Sprintf(format, a...)
+	}
+	muxNewRouterExpected = 2
+	muxNewRouter = func() *mux.Router {
+		muxNewRouterCalled++
+		return mux.NewRouter()
+	}
+	getPathTemplateFuncExpected = 2
+	getPathTemplateFunc = func(route *mux.Route) (string, error) {
+		getPathTemplateFuncCalled++
+		return route.GetPathTemplate()
+	}
+
+	// SUT
+	var router1 = CreateRouter()
+	var router2 = CreateRouter()
+
+	// act
+	var route1 = HandleFunc(
+		router1,
+		dummyRouteInfo1,
+		dummyPath,
+		nil,
+		dummyHandlerFunc,
+	)
+	var route2 = HandleFunc(
+		router2,
+		dummyRouteInfo2,
+		dummyPath,
+		nil,
+		dummyHandlerFunc,
+	)
+
+	// assert
+	assert.Equal(t, route1.GetName(), route2.GetName())
+	var routeInfo1, _ = GetRouteInfoByRoute(route1)
+	var routeInfo2, _ = GetRouteInfoByRoute(route2)
+	assert.Equal(t, dummyRouteInfo1, routeInfo1)
+	assert.Equal(t, dummyRouteInfo2, routeInfo2)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestActionHandlerServeHTTP(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = httptest.NewRecorder()
+	var dummyHTTPRequest = &http.Request{
+		Method: http.MethodGet,
+	}
+
+	// stub
+	var dummyHandlerFuncExpected = 1
+	var dummyHandlerFuncCalled = 0
+	var dummyHandlerFunc = func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		dummyHandlerFuncCalled++
+		assert.Equal(t, dummyResponseWriter, responseWriter)
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var sut = &actionHandler{
+		handleFunc: dummyHandlerFunc,
+	}
+
+	// act
+	sut.ServeHTTP(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyHandlerFuncExpected, dummyHandlerFuncCalled, "Unexpected number of calls to dummyHandlerFunc")
+
+	// verify
+	verifyAll(t)
+}
+
+func TestHostPreflight(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyHandlerFuncExpected = 0
+	var dummyHandlerFuncCalled = 0
+	var dummyHandlerFunc = func(http.ResponseWriter, *http.Request) {
+		dummyHandlerFuncCalled++
+	}
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost/foo/bar", nil)
+
+	// mock
+	createMock(t)
+
+	// expect
+	isPreflightRequestFuncExpected = 1
+	isPreflightRequestFunc = func(httpRequest *http.Request, routeMatch *mux.RouteMatch) bool {
+		isPreflightRequestFuncCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return true
+	}
+
+	// SUT
+	var router = mux.NewRouter()
+
+	// act
+	var route = HostPreflight(
+		router,
+		dummyName,
+		dummyHandlerFunc,
+	)
+	var name = route.GetName()
+	var methods, _ = route.GetMethods()
+	var pathTemplate, pathTemplateError = route.GetPathTemplate()
+	var routeMatch mux.RouteMatch
+	var matched = router.Match(dummyHTTPRequest, &routeMatch)
+
+	// assert
+	assert.Equal(t, dummyName, name)
+	assert.Equal(t, []string{http.MethodOptions}, methods)
+	assert.Equal(t, "/", pathTemplate)
+	assert.NoError(t, pathTemplateError)
+	assert.True(t, matched)
+	assert.Equal(t, route, routeMatch.Route)
+	assert.Equal(t, dummyHandlerFuncExpected, dummyHandlerFuncCalled)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsPreflightRequest(t *testing.T) {
+	// arrange
+	var plainRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost", nil)
+	var preflightRequest, _ = http.NewRequest(http.MethodOptions, "http://localhost", nil)
+	preflightRequest.Header.Set("Access-Control-Request-Method", http.MethodPut)
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var plainResult = isPreflightRequest(plainRequest, nil)
+	var preflightResult = isPreflightRequest(preflightRequest, nil)
+
+	// assert
+	assert.False(t, plainResult)
+	assert.True(t, preflightResult)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestCreateSubrouter(t *testing.T) {
+	// arrange
+	var dummyPathPrefix = "/api/v1"
+	var dummyName = "some name"
+	var dummyMethod = "SOME METHOD"
+	var dummyPath = "/foo/bar"
+	var dummyHandlerFunc = func(http.ResponseWriter, *http.Request) {}
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var router = mux.NewRouter()
+
+	// act
+	var subrouter = CreateSubrouter(
+		router,
+		dummyPathPrefix,
+	)
+	HostHandler(
+		subrouter,
+		dummyName,
+		dummyMethod,
+		dummyPath,
+		dummyHandlerFunc,
+	)
+	var route = router.Get(dummyName)
+	var pathTemplate, _ = route.GetPathTemplate()
+
+	// assert
+	assert.NotEqual(t, router, subrouter)
+	assert.Equal(t, dummyPathPrefix+dummyPath, pathTemplate)
 
 	// verify
 	verifyAll(t)
@@ -820,60 +1071,59 @@ func TestGetEndpointByName_WithSeparator(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestGetActionByName_NotFound(t *testing.T) {
+func TestGetRouteInfoByRoute_NotActionHandler(t *testing.T) {
 	// arrange
-	var dummyName = "some name"
-	var dummyAction func(sessionModel.Session) (interface{}, error)
-	var dummyOtherName = "some other name"
-	var expectedActionPointer = fmt.Sprintf("%v", reflect.ValueOf(defaultActionFunc))
-
-	// stub
-	registeredRouteActionFuncs = map[string]model.ActionFunc{
-		dummyName: dummyAction,
-	}
+	var dummyRoute = mux.NewRouter().HandleFunc("/", func(http.ResponseWriter, *http.Request) {})
 
 	// mock
 	createMock(t)
 
 	// SUT + act
-	var result = getActionByName(
-		dummyOtherName,
+	var result, found = GetRouteInfoByRoute(
+		dummyRoute,
 	)
 
 	// assert
-	assert.Equal(t, expectedActionPointer, fmt.Sprintf("%v", reflect.ValueOf(result)))
+	assert.Zero(t, result)
+	assert.False(t, found)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestGetActionByName_Found(t *testing.T) {
+func TestGetRouteInfoByRoute_Found(t *testing.T) {
 	// arrange
-	var dummyName = "some name"
-	var dummyActionExpected = 0
-	var dummyActionCalled = 0
-	var dummyAction = func(sessionModel.Session) (interface{}, error) {
-		dummyActionCalled++
-		return nil, nil
-	}
-	var expectedActionPointer = fmt.Sprintf("%v", reflect.ValueOf(dummyAction))
-
-	// stub
-	registeredRouteActionFuncs = map[string]model.ActionFunc{
-		dummyName: dummyAction,
+	var dummyRouteInfo = model.Route{
+		Endpoint:     "some endpoint",
+		Method:       "some method",
+		Path:         "some path",
+		MaxBodyBytes: rand.Int63(),
 	}
+	var dummyRoute = mux.NewRouter().Handle("/", &actionHandler{routeInfo: dummyRouteInfo})
+	var dummyPathTemplate = "some path template"
 
 	// mock
 	createMock(t)
 
+	// expect
+	getPathTemplateFuncExpected = 1
+	getPathTemplateFunc = func(route *mux.Route) (string, error) {
+		getPathTemplateFuncCalled++
+		assert.Equal(t, dummyRoute, route)
+		return dummyPathTemplate, nil
+	}
+
 	// SUT + act
-	var result = getActionByName(
-		dummyName,
+	var result, found = GetRouteInfoByRoute(
+		dummyRoute,
 	)
 
 	// assert
-	assert.Equal(t, expectedActionPointer, fmt.Sprintf("%v", reflect.ValueOf(result)))
-	assert.Equal(t, dummyActionExpected, dummyActionCalled, "Unexpected number of calls to dummyAction")
+	assert.Equal(t, dummyRouteInfo.Endpoint, result.Endpoint)
+	assert.Equal(t, dummyRouteInfo.Method, result.Method)
+	assert.Equal(t, dummyPathTemplate, result.Path)
+	assert.Equal(t, dummyRouteInfo.MaxBodyBytes, result.MaxBodyBytes)
+	assert.True(t, found)
 
 	// verify
 	verifyAll(t)
@@ -910,20 +1160,19 @@ func TestGetRouteInfo_NilRoute(t *testing.T) {
 	}
 
 	// SUT + act
-	var name, action, err = GetRouteInfo(
+	var result, err = GetRouteInfo(
 		dummyHTTPRequest,
 	)
 
 	// assert
-	assert.Zero(t, name)
-	assert.Nil(t, action)
+	assert.Zero(t, result)
 	assert.Equal(t, dummyAppError, err)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestGetRouteInfo_ValidRoute(t *testing.T) {
+func TestGetRouteInfo_NotActionHandler(t *testing.T) {
 	// arrange
 	var dummyHTTPRequest = &http.Request{
 		Method:     http.MethodGet,
@@ -932,14 +1181,8 @@ func TestGetRouteInfo_ValidRoute(t *testing.T) {
 	}
 	var dummyRoute = &mux.Route{}
 	var dummyName = "some name"
-	var dummyActionExpected = 0
-	var dummyActionCalled = 0
-	var dummyAction = func(sessionModel.Session) (interface{}, error) {
-		dummyActionCalled++
-		return nil, nil
-	}
-	var dummyActionPointer = fmt.Sprintf("%v", reflect.ValueOf(dummyAction))
 	var dummyEndpoint = "some endpoint"
+	var expectedActionPointer = fmt.Sprintf("%v", reflect.ValueOf(defaultActionFunc))
 
 	// mock
 	createMock(t)
@@ -951,6 +1194,12 @@ func TestGetRouteInfo_ValidRoute(t *testing.T) {
 		assert.Equal(t, dummyHTTPRequest, httpRequest)
 		return dummyRoute
 	}
+	getRouteInfoByRouteFuncExpected = 1
+	getRouteInfoByRouteFunc = func(route *mux.Route) (model.Route, bool) {
+		getRouteInfoByRouteFuncCalled++
+		assert.Equal(t, dummyRoute, route)
+		return model.Route{}, false
+	}
 	getNameFuncExpected = 1
 	getNameFunc = func(route *mux.Route) string {
 		getNameFuncCalled++
@@ -963,21 +1212,109 @@ func TestGetRouteInfo_ValidRoute(t *testing.T) {
 		assert.Equal(t, dummyName, name)
 		return dummyEndpoint
 	}
-	getActionByNameFuncExpected = 1
-	getActionByNameFunc = func(name string) model.ActionFunc {
-		getActionByNameFuncCalled++
-		assert.Equal(t, dummyName, name)
-		return dummyAction
+
+	// SUT + act
+	var result, err = GetRouteInfo(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyEndpoint, result.Endpoint)
+	assert.Equal(t, expectedActionPointer, fmt.Sprintf("%v", reflect.ValueOf(result.ActionFunc)))
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRouteInfo_NilAction(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method:     http.MethodGet,
+		RequestURI: "http://localhost/",
+		Header:     map[string][]string{},
+	}
+	var dummyRoute = &mux.Route{}
+	var dummyEndpoint = "some endpoint"
+	var expectedActionPointer = fmt.Sprintf("%v", reflect.ValueOf(defaultActionFunc))
+
+	// mock
+	createMock(t)
+
+	// expect
+	muxCurrentRouteExpected = 1
+	muxCurrentRoute = func(httpRequest *http.Request) *mux.Route {
+		muxCurrentRouteCalled++
+		return dummyRoute
+	}
+	getRouteInfoByRouteFuncExpected = 1
+	getRouteInfoByRouteFunc = func(route *mux.Route) (model.Route, bool) {
+		getRouteInfoByRouteFuncCalled++
+		return model.Route{Endpoint: dummyEndpoint}, true
+	}
+
+	// SUT + act
+	var result, err = GetRouteInfo(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyEndpoint, result.Endpoint)
+	assert.Equal(t, expectedActionPointer, fmt.Sprintf("%v", reflect.ValueOf(result.ActionFunc)))
+	assert.NoError(t, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRouteInfo_ValidRoute(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method:     http.MethodGet,
+		RequestURI: "http://localhost/",
+		Header:     map[string][]string{},
+	}
+	var dummyRoute = &mux.Route{}
+	var dummyActionExpected = 0
+	var dummyActionCalled = 0
+	var dummyAction = func(sessionModel.Session) (interface{}, error) {
+		dummyActionCalled++
+		return nil, nil
+	}
+	var dummyActionPointer = fmt.Sprintf("%v", reflect.ValueOf(dummyAction))
+	var dummyRouteInfo = model.Route{
+		Endpoint:     "some endpoint",
+		Method:       http.MethodGet,
+		ActionFunc:   dummyAction,
+		MaxBodyBytes: rand.Int63(),
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	muxCurrentRouteExpected = 1
+	muxCurrentRoute = func(httpRequest *http.Request) *mux.Route {
+		muxCurrentRouteCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return dummyRoute
+	}
+	getRouteInfoByRouteFuncExpected = 1
+	getRouteInfoByRouteFunc = func(route *mux.Route) (model.Route, bool) {
+		getRouteInfoByRouteFuncCalled++
+		assert.Equal(t, dummyRoute, route)
+		return dummyRouteInfo, true
 	}
 
 	// SUT + act
-	var endpoint, action, err = GetRouteInfo(
+	var result, err = GetRouteInfo(
 		dummyHTTPRequest,
 	)
 
 	// assert
-	assert.Equal(t, dummyEndpoint, endpoint)
-	assert.Equal(t, dummyActionPointer, fmt.Sprintf("%v", reflect.ValueOf(action)))
+	assert.Equal(t, dummyRouteInfo.Endpoint, result.Endpoint)
+	assert.Equal(t, dummyRouteInfo.MaxBodyBytes, result.MaxBodyBytes)
+	assert.Equal(t, dummyActionPointer, fmt.Sprintf("%v", reflect.ValueOf(result.ActionFunc)))
 	assert.NoError(t, err)
 
 	// verify
@@ -5,20 +5,43 @@ import (
 	"crypto/tls"
 	"net/http"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
+	apperrorEnum "github.com/zhongjie-cai/WebServiceTemplate/apperror/enum"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
+)
+
+const (
+	defaultListenerName = "default"
 )
 
 var (
 	shutdownSignal = make(chan os.Signal, 1)
 )
 
+func getDuration(customizedFunc func() time.Duration) time.Duration {
+	if customizedFunc == nil {
+		return 0
+	}
+	return customizedFunc()
+}
+
+func getMaxHeaderBytes() int {
+	if customization.ServerMaxHeaderBytes == nil {
+		return 0
+	}
+	return customization.ServerMaxHeaderBytes()
+}
+
 func createServer(
 	serveHTTPS bool,
 	validateClientCert bool,
 	appPort string,
 	router *mux.Router,
-) *http.Server {
+) (*http.Server, error) {
 	var tlsConfig = &tls.Config{
 		// Force it server side
 		PreferServerCipherSuites: true,
@@ -27,26 +50,43 @@ func createServer(
 	}
 	if serveHTTPS {
 		var serverCert = certificateGetServerCertificate()
+		if serverCert == nil {
+			return nil,
+				apperrorGetCustomError(
+					apperrorEnum.CodeGeneralFailure,
+					"Server certificate is not available for HTTPS on port [%v]",
+					appPort,
+				)
+		}
 		tlsConfig.Certificates = []tls.Certificate{
 			*serverCert,
 		}
 		if validateClientCert {
 			var clientCertPool = certificateGetCaCertPool()
-			if clientCertPool != nil {
-				tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
-				tlsConfig.ClientCAs = clientCertPool
-			} else {
-				tlsConfig.ClientAuth = tls.RequireAnyClientCert
+			if clientCertPool == nil {
+				return nil,
+					apperrorGetCustomError(
+						apperrorEnum.CodeGeneralFailure,
+						"CA certificate pool is not available for client certificate validation on port [%v]",
+						appPort,
+					)
 			}
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+			tlsConfig.ClientCAs = clientCertPool
 		} else {
 			tlsConfig.ClientAuth = tls.RequestClientCert
 		}
 	}
 	return &http.Server{
-		Addr:      ":" + appPort,
-		TLSConfig: tlsConfig,
-		Handler:   router,
-	}
+		Addr:              ":" + appPort,
+		TLSConfig:         tlsConfig,
+		Handler:           router,
+		ReadTimeout:       getDurationFunc(customization.ServerReadTimeout),
+		ReadHeaderTimeout: getDurationFunc(customization.ServerReadHeaderTimeout),
+		WriteTimeout:      getDurationFunc(customization.ServerWriteTimeout),
+		IdleTimeout:       getDurationFunc(customization.ServerIdleTimeout),
+		MaxHeaderBytes:    getMaxHeaderBytesFunc(),
+	}, nil
 }
 
 func listenAndServe(
@@ -69,36 +109,111 @@ func shutDown(
 }
 
 func consolidateError(
-	hostError error,
-	shutdownError error,
+	hostErrors []error,
+	shutdownErrors []error,
 ) error {
-	if hostError == http.ErrServerClosed {
-		hostError = nil
+	var innerErrors = []error{}
+	for _, hostError := range hostErrors {
+		if hostError == http.ErrServerClosed {
+			hostError = nil
+		}
+		innerErrors = append(
+			innerErrors,
+			hostError,
+		)
 	}
-	if shutdownError == http.ErrServerClosed {
-		shutdownError = nil
+	for _, shutdownError := range shutdownErrors {
+		if shutdownError == http.ErrServerClosed {
+			shutdownError = nil
+		}
+		innerErrors = append(
+			innerErrors,
+			shutdownError,
+		)
 	}
 	return apperrorWrapSimpleError(
-		[]error{
-			hostError,
-			shutdownError,
-		},
+		innerErrors,
 		"One or more errors have occurred during server hosting",
 	)
 }
 
-func runServer(
+func getListeners(
 	serveHTTPS bool,
 	validateClientCert bool,
 	appPort string,
-	router *mux.Router,
-) error {
-	var server = createServerFunc(
+) []model.Listener {
+	var defaultListeners = []model.Listener{
+		{
+			Name:               defaultListenerName,
+			Port:               appPort,
+			ServeHTTPS:         serveHTTPS,
+			ValidateClientCert: validateClientCert,
+		},
+	}
+	if customization.Listeners == nil {
+		return defaultListeners
+	}
+	var listeners = customization.Listeners()
+	if len(listeners) == 0 {
+		loggerAppRoot(
+			"server",
+			"getListeners",
+			"customization.Listeners function empty: fallback to default listener on port [%v]",
+			appPort,
+		)
+		return defaultListeners
+	}
+	return listeners
+}
+
+// RequiresCertificates returns whether the server certificate and the CA certificate pool are required respectively by any of the server listeners, which are either customized through customization.Listeners or a single one upon the given settings
+func RequiresCertificates(
+	serveHTTPS bool,
+	validateClientCert bool,
+	appPort string,
+) (bool, bool) {
+	var listeners = getListenersFunc(
 		serveHTTPS,
 		validateClientCert,
 		appPort,
-		router,
 	)
+	var requiresServerCert, requiresCaCertPool bool
+	for _, listener := range listeners {
+		if !listener.ServeHTTPS {
+			continue
+		}
+		requiresServerCert = true
+		if listener.ValidateClientCert {
+			requiresCaCertPool = true
+		}
+	}
+	return requiresServerCert, requiresCaCertPool
+}
+
+func runServer(
+	listeners []model.Listener,
+	routers []*mux.Router,
+) error {
+	var servers = []*http.Server{}
+	for index, listener := range listeners {
+		var server, serverError = createServerFunc(
+			listener.ServeHTTPS,
+			listener.ValidateClientCert,
+			listener.Port,
+			routers[index],
+		)
+		if serverError != nil {
+			return apperrorWrapSimpleError(
+				[]error{serverError},
+				"Failed to create listener [%v]",
+				listener.Name,
+			)
+		}
+		servers = append(
+			servers,
+			server,
+		)
+	}
 
 	signalNotify(
 		shutdownSignal,
@@ -106,21 +221,45 @@ func runServer(
 		os.Kill,
 	)
 
-	var hostError error
-	go func() {
-		hostError = listenAndServeFunc(
-			server,
-			serveHTTPS,
+	healthSetReadiness(true)
+
+	var hostErrors = make([]error, len(servers))
+	var hostFailure = make(chan struct{}, len(servers))
+	var hostWaitGroup sync.WaitGroup
+	for index, server := range servers {
+		hostWaitGroup.Add(1)
+		go func(index int, server *http.Server, serveHTTPS bool) {
+			defer hostWaitGroup.Done()
+			hostErrors[index] = listenAndServeFunc(
+				server,
+				serveHTTPS,
+			)
+			if hostErrors[index] != http.ErrServerClosed {
+				hostFailure <- struct{}{}
+			}
+		}(index, server, listeners[index].ServeHTTPS)
+	}
+
+	select {
+	case <-shutdownSignal:
+		loggerAppRoot(
+			"server",
+			"Host",
+			"Interrupt signal received: Terminating server",
+		)
+	case <-hostFailure:
+		loggerAppRoot(
+			"server",
+			"Host",
+			"Listener failure occurred: Terminating server",
 		)
-		haltFunc()
-	}()
+	}
 
-	<-shutdownSignal
+	healthSetReadiness(false)
 
-	loggerAppRoot(
-		"server",
-		"Host",
-		"Interrupt signal received: Terminating server",
+	// allow load balancers to observe the readiness change and stop routing new requests before shutting down
+	timeSleep(
+		getDurationFunc(customization.ServerShutdownDrainDelay),
 	)
 
 	var runtimeContext, cancelCallback = contextWithTimeout(
@@ -129,44 +268,64 @@ func runServer(
 	)
 	defer cancelCallback()
 
-	var shutdownError = shutDownFunc(
-		runtimeContext,
-		server,
-	)
+	var shutdownErrors = []error{}
+	for _, server := range servers {
+		shutdownErrors = append(
+			shutdownErrors,
+			shutDownFunc(
+				runtimeContext,
+				server,
+			),
+		)
+	}
+	hostWaitGroup.Wait()
 
 	return consolidateErrorFunc(
-		hostError,
-		shutdownError,
+		hostErrors,
+		shutdownErrors,
 	)
 }
 
-// Host hosts the service entries and starts HTTPS server
+// Host hosts the service entries and starts the HTTP/HTTPS server listeners, which are either customized through customization.Listeners or a single one upon the given settings
 func Host(
 	serveHTTPS bool,
 	validateClientCert bool,
 	appPort string,
 ) error {
-	var router, routerError = registerInstantiate()
-	if routerError != nil {
-		return apperrorWrapSimpleError(
-			[]error{routerError},
-			"Failed to host entries on port %v",
-			appPort,
-		)
-	}
-	loggerAppRoot(
-		"server",
-		"Host",
-		"Targeting port [%v] HTTPS [%v] mTLS [%v]",
-		appPort,
+	var listeners = getListenersFunc(
 		serveHTTPS,
 		validateClientCert,
+		appPort,
 	)
+	var routers = []*mux.Router{}
+	for _, listener := range listeners {
+		var router, routerError = registerInstantiate(
+			listener.Endpoints,
+		)
+		if routerError != nil {
+			return apperrorWrapSimpleError(
+				[]error{routerError},
+				"Failed to host entries on port %v",
+				listener.Port,
+			)
+		}
+		loggerAppRoot(
+			"server",
+			"Host",
+			"Targeting listener [%v] on port [%v] HTTPS [%v] mTLS [%v]",
+			listener.Name,
+			listener.Port,
+			listener.ServeHTTPS,
+			listener.ValidateClientCert,
+		)
+		routers = append(
+			routers,
+			router,
+		)
+	}
 	var hostError = runServerFunc(
-		serveHTTPS,
-		validateClientCert,
-		appPort,
-		router,
+		listeners,
+		routers,
 	)
 	loggerAppRoot(
 		"server",
@@ -176,14 +335,16 @@ func Host(
 	if hostError != nil {
 		return apperrorWrapSimpleError(
 			[]error{hostError},
-			"Failed to run server on port %v",
-			appPort,
+			"Failed to run server listeners",
 		)
 	}
 	return nil
 }
 
-// Halt emits the signal interrupt to the server to conduct a graceful shutdown
+// Halt emits the signal interrupt to the server to conduct a graceful shutdown; repeated calls before the signal is consumed are ignored
 func Halt() {
-	shutdownSignal <- os.Interrupt
+	select {
+	case shutdownSignal <- os.Interrupt:
+	default:
+	}
 }
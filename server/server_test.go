@@ -5,16 +5,22 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"fmt"
 	"math/rand"
 	"net/http"
 	"os"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	apperrorEnum "github.com/zhongjie-cai/WebServiceTemplate/apperror/enum"
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/server/model"
 )
 
 func TestCreateServer_NoHTTPS(t *testing.T) {
@@ -27,8 +33,20 @@ func TestCreateServer_NoHTTPS(t *testing.T) {
 	// mock
 	createMock(t)
 
+	// expect
+	getDurationFuncExpected = 4
+	getDurationFunc = func(customizedFunc func() time.Duration) time.Duration {
+		getDurationFuncCalled++
+		return 0
+	}
+	getMaxHeaderBytesFuncExpected = 1
+	getMaxHeaderBytesFunc = func() int {
+		getMaxHeaderBytesFuncCalled++
+		return 0
+	}
+
 	// SUT + act
-	var server = createServer(
+	var server, err = createServer(
 		dummyServeHTTPS,
 		dummyValidateClientCert,
 		dummyAppPort,
@@ -36,6 +54,7 @@ func TestCreateServer_NoHTTPS(t *testing.T) {
 	)
 
 	// assert
+	assert.NoError(t, err)
 	assert.NotNil(t, server)
 	assert.Equal(t, ":"+dummyAppPort, server.Addr)
 	assert.NotNil(t, server.TLSConfig)
@@ -70,9 +89,19 @@ func TestCreateServer_HTTPS_NoValidateClientCert(t *testing.T) {
 		certificateGetServerCertificateCalled++
 		return dummyServerCert
 	}
+	getDurationFuncExpected = 4
+	getDurationFunc = func(customizedFunc func() time.Duration) time.Duration {
+		getDurationFuncCalled++
+		return 0
+	}
+	getMaxHeaderBytesFuncExpected = 1
+	getMaxHeaderBytesFunc = func() int {
+		getMaxHeaderBytesFuncCalled++
+		return 0
+	}
 
 	// SUT + act
-	var server = createServer(
+	var server, err = createServer(
 		dummyServeHTTPS,
 		dummyValidateClientCert,
 		dummyAppPort,
@@ -80,6 +109,7 @@ func TestCreateServer_HTTPS_NoValidateClientCert(t *testing.T) {
 	)
 
 	// assert
+	assert.NoError(t, err)
 	assert.NotNil(t, server)
 	assert.Equal(t, ":"+dummyAppPort, server.Addr)
 	assert.NotNil(t, server.TLSConfig)
@@ -98,6 +128,49 @@ func TestCreateServer_HTTPS_NoValidateClientCert(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestCreateServer_HTTPS_NoServerCert(t *testing.T) {
+	// arrange
+	var dummyServeHTTPS = true
+	var dummyValidateClientCert = rand.Intn(100) < 50
+	var dummyAppPort = "some app port"
+	var dummyRouter = &mux.Router{}
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	certificateGetServerCertificateExpected = 1
+	certificateGetServerCertificate = func() *tls.Certificate {
+		certificateGetServerCertificateCalled++
+		return nil
+	}
+	apperrorGetCustomErrorExpected = 1
+	apperrorGetCustomError = func(errorCode apperrorEnum.Code, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
+		apperrorGetCustomErrorCalled++
+		assert.Equal(t, apperrorEnum.CodeGeneralFailure, errorCode)
+		assert.Equal(t, "Server certificate is not available for HTTPS on port [%v]", messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, dummyAppPort, parameters[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var server, err = createServer(
+		dummyServeHTTPS,
+		dummyValidateClientCert,
+		dummyAppPort,
+		dummyRouter,
+	)
+
+	// assert
+	assert.Nil(t, server)
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestCreateServer_HTTPS_NoCaCert(t *testing.T) {
 	// arrange
 	var dummyServeHTTPS = true
@@ -105,6 +178,7 @@ func TestCreateServer_HTTPS_NoCaCert(t *testing.T) {
 	var dummyAppPort = "some app port"
 	var dummyRouter = &mux.Router{}
 	var dummyServerCert = &tls.Certificate{}
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
 
 	// mock
 	createMock(t)
@@ -120,9 +194,18 @@ func TestCreateServer_HTTPS_NoCaCert(t *testing.T) {
 		certificateGetCaCertPoolCalled++
 		return nil
 	}
+	apperrorGetCustomErrorExpected = 1
+	apperrorGetCustomError = func(errorCode apperrorEnum.Code, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
+		apperrorGetCustomErrorCalled++
+		assert.Equal(t, apperrorEnum.CodeGeneralFailure, errorCode)
+		assert.Equal(t, "CA certificate pool is not available for client certificate validation on port [%v]", messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, dummyAppPort, parameters[0])
+		return dummyAppError
+	}
 
 	// SUT + act
-	var server = createServer(
+	var server, err = createServer(
 		dummyServeHTTPS,
 		dummyValidateClientCert,
 		dummyAppPort,
@@ -130,19 +213,8 @@ func TestCreateServer_HTTPS_NoCaCert(t *testing.T) {
 	)
 
 	// assert
-	assert.NotNil(t, server)
-	assert.Equal(t, ":"+dummyAppPort, server.Addr)
-	assert.NotNil(t, server.TLSConfig)
-	assert.Equal(t, 1, len(server.TLSConfig.Certificates))
-	assert.Equal(t, *dummyServerCert, server.TLSConfig.Certificates[0])
-	assert.Equal(t, tls.RequireAnyClientCert, server.TLSConfig.ClientAuth)
-	assert.Nil(t, server.TLSConfig.ClientCAs)
-	assert.Empty(t, server.TLSConfig.CipherSuites)
-	assert.Equal(t, true, server.TLSConfig.PreferServerCipherSuites)
-	assert.Equal(t, uint16(tls.VersionTLS12), server.TLSConfig.MinVersion)
-	assert.Zero(t, server.WriteTimeout)
-	assert.Zero(t, server.ReadTimeout)
-	assert.Zero(t, server.IdleTimeout)
+	assert.Nil(t, server)
+	assert.Equal(t, dummyAppError, err)
 
 	// verify
 	verifyAll(t)
@@ -171,9 +243,19 @@ func TestCreateServer_HTTPS_ValidateClientCert(t *testing.T) {
 		certificateGetCaCertPoolCalled++
 		return dummyCertPool
 	}
+	getDurationFuncExpected = 4
+	getDurationFunc = func(customizedFunc func() time.Duration) time.Duration {
+		getDurationFuncCalled++
+		return 0
+	}
+	getMaxHeaderBytesFuncExpected = 1
+	getMaxHeaderBytesFunc = func() int {
+		getMaxHeaderBytesFuncCalled++
+		return 0
+	}
 
 	// SUT + act
-	var server = createServer(
+	var server, err = createServer(
 		dummyServeHTTPS,
 		dummyValidateClientCert,
 		dummyAppPort,
@@ -181,6 +263,7 @@ func TestCreateServer_HTTPS_ValidateClientCert(t *testing.T) {
 	)
 
 	// assert
+	assert.NoError(t, err)
 	assert.NotNil(t, server)
 	assert.Equal(t, ":"+dummyAppPort, server.Addr)
 	assert.NotNil(t, server.TLSConfig)
@@ -199,6 +282,137 @@ func TestCreateServer_HTTPS_ValidateClientCert(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestCreateServer_WithServerSettings(t *testing.T) {
+	// arrange
+	var dummyServeHTTPS = false
+	var dummyValidateClientCert = false
+	var dummyAppPort = "some app port"
+	var dummyRouter = &mux.Router{}
+	var dummyReadTimeout = time.Duration(rand.Intn(100) + 1)
+	var dummyReadHeaderTimeout = time.Duration(rand.Intn(100) + 1)
+	var dummyWriteTimeout = time.Duration(rand.Intn(100) + 1)
+	var dummyIdleTimeout = time.Duration(rand.Intn(100) + 1)
+	var dummyMaxHeaderBytes = rand.Intn(100) + 1
+
+	// stub
+	customization.ServerReadTimeout = func() time.Duration { return dummyReadTimeout }
+	customization.ServerReadHeaderTimeout = func() time.Duration { return dummyReadHeaderTimeout }
+	customization.ServerWriteTimeout = func() time.Duration { return dummyWriteTimeout }
+	customization.ServerIdleTimeout = func() time.Duration { return dummyIdleTimeout }
+
+	// mock
+	createMock(t)
+
+	// expect
+	getDurationFuncExpected = 4
+	getDurationFunc = func(customizedFunc func() time.Duration) time.Duration {
+		getDurationFuncCalled++
+		return customizedFunc()
+	}
+	getMaxHeaderBytesFuncExpected = 1
+	getMaxHeaderBytesFunc = func() int {
+		getMaxHeaderBytesFuncCalled++
+		return dummyMaxHeaderBytes
+	}
+
+	// SUT + act
+	var server, err = createServer(
+		dummyServeHTTPS,
+		dummyValidateClientCert,
+		dummyAppPort,
+		dummyRouter,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.NotNil(t, server)
+	assert.Equal(t, dummyReadTimeout, server.ReadTimeout)
+	assert.Equal(t, dummyReadHeaderTimeout, server.ReadHeaderTimeout)
+	assert.Equal(t, dummyWriteTimeout, server.WriteTimeout)
+	assert.Equal(t, dummyIdleTimeout, server.IdleTimeout)
+	assert.Equal(t, dummyMaxHeaderBytes, server.MaxHeaderBytes)
+
+	// tear down
+	customization.ServerReadTimeout = nil
+	customization.ServerReadHeaderTimeout = nil
+	customization.ServerWriteTimeout = nil
+	customization.ServerIdleTimeout = nil
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetDuration_NotSet(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getDuration(nil)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetDuration_Customized(t *testing.T) {
+	// arrange
+	var dummyDuration = time.Duration(rand.Intn(100) + 1)
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getDuration(func() time.Duration { return dummyDuration })
+
+	// assert
+	assert.Equal(t, dummyDuration, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetMaxHeaderBytes_NotSet(t *testing.T) {
+	// stub
+	customization.ServerMaxHeaderBytes = nil
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getMaxHeaderBytes()
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetMaxHeaderBytes_Customized(t *testing.T) {
+	// arrange
+	var dummyMaxHeaderBytes = rand.Intn(100) + 1
+
+	// stub
+	customization.ServerMaxHeaderBytes = func() int { return dummyMaxHeaderBytes }
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getMaxHeaderBytes()
+
+	// assert
+	assert.Equal(t, dummyMaxHeaderBytes, result)
+
+	// tear down
+	customization.ServerMaxHeaderBytes = nil
+
+	// verify
+	verifyAll(t)
+}
+
 func TestListenAndServe_HTTPS(t *testing.T) {
 	// arrange
 	var dummyServer = &http.Server{}
@@ -276,8 +490,8 @@ func TestShutDown(t *testing.T) {
 
 func TestConsolidateError_SkipServerClosedErrors(t *testing.T) {
 	// arrange
-	var dummyHostError = http.ErrServerClosed
-	var dummyShutDownError = http.ErrServerClosed
+	var dummyHostErrors = []error{http.ErrServerClosed, http.ErrServerClosed}
+	var dummyShutDownErrors = []error{http.ErrServerClosed, http.ErrServerClosed}
 	var dummyMessageFormat = "One or more errors have occurred during server hosting"
 	var dummyAppError = apperror.GetCustomError(0, "some app error")
 
@@ -288,9 +502,11 @@ func TestConsolidateError_SkipServerClosedErrors(t *testing.T) {
 	apperrorWrapSimpleErrorExpected = 1
 	apperrorWrapSimpleError = func(innerErrors []error, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
 		apperrorWrapSimpleErrorCalled++
-		assert.Equal(t, 2, len(innerErrors))
+		assert.Equal(t, 4, len(innerErrors))
 		assert.Nil(t, innerErrors[0])
 		assert.Nil(t, innerErrors[1])
+		assert.Nil(t, innerErrors[2])
+		assert.Nil(t, innerErrors[3])
 		assert.Equal(t, dummyMessageFormat, messageFormat)
 		assert.Equal(t, 0, len(parameters))
 		return dummyAppError
@@ -298,8 +514,8 @@ func TestConsolidateError_SkipServerClosedErrors(t *testing.T) {
 
 	// SUT + act
 	var err = consolidateError(
-		dummyHostError,
-		dummyShutDownError,
+		dummyHostErrors,
+		dummyShutDownErrors,
 	)
 
 	// assert
@@ -311,8 +527,10 @@ func TestConsolidateError_SkipServerClosedErrors(t *testing.T) {
 
 func TestConsolidateError_NoSkipOtherErrors(t *testing.T) {
 	// arrange
-	var dummyHostError = errors.New("some host error")
-	var dummyShutDownError = errors.New("some shutdown error")
+	var dummyHostError1 = errors.New("some host error 1")
+	var dummyHostError2 = errors.New("some host error 2")
+	var dummyShutDownError1 = errors.New("some shutdown error 1")
+	var dummyShutDownError2 = errors.New("some shutdown error 2")
 	var dummyMessageFormat = "One or more errors have occurred during server hosting"
 	var dummyAppError = apperror.GetCustomError(0, "some app error")
 
@@ -323,9 +541,11 @@ func TestConsolidateError_NoSkipOtherErrors(t *testing.T) {
 	apperrorWrapSimpleErrorExpected = 1
 	apperrorWrapSimpleError = func(innerErrors []error, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
 		apperrorWrapSimpleErrorCalled++
-		assert.Equal(t, 2, len(innerErrors))
-		assert.Equal(t, dummyHostError, innerErrors[0])
-		assert.Equal(t, dummyShutDownError, innerErrors[1])
+		assert.Equal(t, 4, len(innerErrors))
+		assert.Equal(t, dummyHostError1, innerErrors[0])
+		assert.Equal(t, dummyHostError2, innerErrors[1])
+		assert.Equal(t, dummyShutDownError1, innerErrors[2])
+		assert.Equal(t, dummyShutDownError2, innerErrors[3])
 		assert.Equal(t, dummyMessageFormat, messageFormat)
 		assert.Equal(t, 0, len(parameters))
 		return dummyAppError
@@ -333,8 +553,8 @@ func TestConsolidateError_NoSkipOtherErrors(t *testing.T) {
 
 	// SUT + act
 	var err = consolidateError(
-		dummyHostError,
-		dummyShutDownError,
+		[]error{dummyHostError1, dummyHostError2},
+		[]error{dummyShutDownError1, dummyShutDownError2},
 	)
 
 	// assert
@@ -344,11 +564,301 @@ func TestConsolidateError_NoSkipOtherErrors(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestRunServer_HappyPath(t *testing.T) {
+func TestGetListeners_NoCustomization(t *testing.T) {
+	// arrange
+	var dummyServeHTTPS = rand.Intn(100) < 50
+	var dummyValidateClientCert = rand.Intn(100) < 50
+	var dummyAppPort = "some app port"
+	var expectedListeners = []model.Listener{
+		{
+			Name:               defaultListenerName,
+			Port:               dummyAppPort,
+			ServeHTTPS:         dummyServeHTTPS,
+			ValidateClientCert: dummyValidateClientCert,
+		},
+	}
+
+	// stub
+	customization.Listeners = nil
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getListeners(
+		dummyServeHTTPS,
+		dummyValidateClientCert,
+		dummyAppPort,
+	)
+
+	// assert
+	assert.Equal(t, expectedListeners, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetListeners_EmptyCustomization(t *testing.T) {
+	// arrange
+	var dummyServeHTTPS = rand.Intn(100) < 50
+	var dummyValidateClientCert = rand.Intn(100) < 50
+	var dummyAppPort = "some app port"
+	var expectedListeners = []model.Listener{
+		{
+			Name:               defaultListenerName,
+			Port:               dummyAppPort,
+			ServeHTTPS:         dummyServeHTTPS,
+			ValidateClientCert: dummyValidateClientCert,
+		},
+	}
+
+	// stub
+	customization.Listeners = func() []model.Listener {
+		return nil
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "server", category)
+		assert.Equal(t, "getListeners", subcategory)
+		assert.Equal(t, "customization.Listeners function empty: fallback to default listener on port [%v]", messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, dummyAppPort, parameters[0])
+	}
+
+	// SUT + act
+	var result = getListeners(
+		dummyServeHTTPS,
+		dummyValidateClientCert,
+		dummyAppPort,
+	)
+
+	// assert
+	assert.Equal(t, expectedListeners, result)
+
+	// verify
+	verifyAll(t)
+	customization.Listeners = nil
+}
+
+func TestGetListeners_WithCustomization(t *testing.T) {
+	// arrange
+	var dummyServeHTTPS = rand.Intn(100) < 50
+	var dummyValidateClientCert = rand.Intn(100) < 50
+	var dummyAppPort = "some app port"
+	var dummyListeners = []model.Listener{
+		{
+			Name:               "some public listener",
+			Port:               "some public port",
+			ServeHTTPS:         true,
+			ValidateClientCert: true,
+		},
+		{
+			Name:      "some internal listener",
+			Port:      "some internal port",
+			Endpoints: []string{"some endpoint"},
+		},
+	}
+
+	// stub
+	customization.Listeners = func() []model.Listener {
+		return dummyListeners
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getListeners(
+		dummyServeHTTPS,
+		dummyValidateClientCert,
+		dummyAppPort,
+	)
+
+	// assert
+	assert.Equal(t, dummyListeners, result)
+
+	// verify
+	verifyAll(t)
+	customization.Listeners = nil
+}
+
+func TestRequiresCertificates_NoHTTPS(t *testing.T) {
+	// arrange
+	var dummyServeHTTPS = rand.Intn(100) < 50
+	var dummyValidateClientCert = rand.Intn(100) < 50
+	var dummyAppPort = "some app port"
+	var dummyListeners = []model.Listener{
+		{ServeHTTPS: false, ValidateClientCert: true},
+		{ServeHTTPS: false, ValidateClientCert: false},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getListenersFuncExpected = 1
+	getListenersFunc = func(serveHTTPS bool, validateClientCert bool, appPort string) []model.Listener {
+		getListenersFuncCalled++
+		assert.Equal(t, dummyServeHTTPS, serveHTTPS)
+		assert.Equal(t, dummyValidateClientCert, validateClientCert)
+		assert.Equal(t, dummyAppPort, appPort)
+		return dummyListeners
+	}
+
+	// SUT + act
+	var requiresServerCert, requiresCaCertPool = RequiresCertificates(
+		dummyServeHTTPS,
+		dummyValidateClientCert,
+		dummyAppPort,
+	)
+
+	// assert
+	assert.False(t, requiresServerCert)
+	assert.False(t, requiresCaCertPool)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRequiresCertificates_HTTPSWithoutClientCert(t *testing.T) {
 	// arrange
 	var dummyServeHTTPS = rand.Intn(100) < 50
 	var dummyValidateClientCert = rand.Intn(100) < 50
 	var dummyAppPort = "some app port"
+	var dummyListeners = []model.Listener{
+		{ServeHTTPS: false, ValidateClientCert: true},
+		{ServeHTTPS: true, ValidateClientCert: false},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getListenersFuncExpected = 1
+	getListenersFunc = func(serveHTTPS bool, validateClientCert bool, appPort string) []model.Listener {
+		getListenersFuncCalled++
+		return dummyListeners
+	}
+
+	// SUT + act
+	var requiresServerCert, requiresCaCertPool = RequiresCertificates(
+		dummyServeHTTPS,
+		dummyValidateClientCert,
+		dummyAppPort,
+	)
+
+	// assert
+	assert.True(t, requiresServerCert)
+	assert.False(t, requiresCaCertPool)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRequiresCertificates_HTTPSWithClientCert(t *testing.T) {
+	// arrange
+	var dummyServeHTTPS = rand.Intn(100) < 50
+	var dummyValidateClientCert = rand.Intn(100) < 50
+	var dummyAppPort = "some app port"
+	var dummyListeners = []model.Listener{
+		{ServeHTTPS: false, ValidateClientCert: false},
+		{ServeHTTPS: true, ValidateClientCert: true},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getListenersFuncExpected = 1
+	getListenersFunc = func(serveHTTPS bool, validateClientCert bool, appPort string) []model.Listener {
+		getListenersFuncCalled++
+		return dummyListeners
+	}
+
+	// SUT + act
+	var requiresServerCert, requiresCaCertPool = RequiresCertificates(
+		dummyServeHTTPS,
+		dummyValidateClientCert,
+		dummyAppPort,
+	)
+
+	// assert
+	assert.True(t, requiresServerCert)
+	assert.True(t, requiresCaCertPool)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRunServer_CreateServerError(t *testing.T) {
+	// arrange
+	var dummyListeners = []model.Listener{
+		{
+			Name:               "some name",
+			Port:               "some app port",
+			ServeHTTPS:         true,
+			ValidateClientCert: rand.Intn(100) < 50,
+		},
+	}
+	var dummyRouter = &mux.Router{}
+	var dummyServerError = errors.New("some server error")
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	createServerFuncExpected = 1
+	createServerFunc = func(serveHTTPS bool, validateClientCert bool, appPort string, router *mux.Router) (*http.Server, error) {
+		createServerFuncCalled++
+		assert.Equal(t, dummyListeners[0].ServeHTTPS, serveHTTPS)
+		assert.Equal(t, dummyListeners[0].ValidateClientCert, validateClientCert)
+		assert.Equal(t, dummyListeners[0].Port, appPort)
+		assert.Equal(t, dummyRouter, router)
+		return nil, dummyServerError
+	}
+	apperrorWrapSimpleErrorExpected = 1
+	apperrorWrapSimpleError = func(innerErrors []error, messageFormat string, parameters ...interface{}) apperrorModel.AppError {
+		apperrorWrapSimpleErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyServerError, innerErrors[0])
+		assert.Equal(t, "Failed to create listener [%v]", messageFormat)
+		assert.Equal(t, 1, len(parameters))
+		assert.Equal(t, dummyListeners[0].Name, parameters[0])
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = runServer(
+		dummyListeners,
+		[]*mux.Router{dummyRouter},
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRunServer_SingleListener(t *testing.T) {
+	// arrange
+	var dummyServeHTTPS = rand.Intn(100) < 50
+	var dummyValidateClientCert = rand.Intn(100) < 50
+	var dummyAppPort = "some app port"
+	var dummyListeners = []model.Listener{
+		{
+			Port:               dummyAppPort,
+			ServeHTTPS:         dummyServeHTTPS,
+			ValidateClientCert: dummyValidateClientCert,
+		},
+	}
 	var dummyRouter = &mux.Router{}
 	var dummyServer = &http.Server{}
 	var dummyHostError = errors.New("some host error message")
@@ -356,6 +866,7 @@ func TestRunServer_HappyPath(t *testing.T) {
 	var dummyRuntimeContext = context.TODO()
 	var dummyGraceShutdownWaitTime = time.Duration(rand.Intn(100)) * time.Second
 	var dummyShutDownError = errors.New("some shut down error message")
+	var dummyDrainDelay = time.Duration(rand.Intn(100)) * time.Second
 	var dummyAppError = errors.New("some app error")
 
 	// mock
@@ -363,13 +874,13 @@ func TestRunServer_HappyPath(t *testing.T) {
 
 	// expect
 	createServerFuncExpected = 1
-	createServerFunc = func(serveHTTPS bool, validateClientCert bool, appPort string, router *mux.Router) *http.Server {
+	createServerFunc = func(serveHTTPS bool, validateClientCert bool, appPort string, router *mux.Router) (*http.Server, error) {
 		createServerFuncCalled++
 		assert.Equal(t, dummyServeHTTPS, serveHTTPS)
 		assert.Equal(t, dummyValidateClientCert, validateClientCert)
 		assert.Equal(t, dummyAppPort, appPort)
 		assert.Equal(t, dummyRouter, router)
-		return dummyServer
+		return dummyServer, nil
 	}
 	signalNotifyExpected = 1
 	signalNotify = func(c chan<- os.Signal, sig ...os.Signal) {
@@ -385,17 +896,28 @@ func TestRunServer_HappyPath(t *testing.T) {
 		assert.Equal(t, dummyServeHTTPS, serveHTTPS)
 		return dummyHostError
 	}
-	haltFuncExpected = 1
-	haltFunc = func() {
-		haltFuncCalled++
-		shutdownSignal <- os.Interrupt
+	healthSetReadinessExpected = 2
+	healthSetReadiness = func(ready bool) {
+		healthSetReadinessCalled++
+		assert.Equal(t, healthSetReadinessCalled == 1, ready)
+	}
+	getDurationFuncExpected = 1
+	getDurationFunc = func(customizedFunc func() time.Duration) time.Duration {
+		getDurationFuncCalled++
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(customization.ServerShutdownDrainDelay)), fmt.Sprintf("%v", reflect.ValueOf(customizedFunc)))
+		return dummyDrainDelay
+	}
+	timeSleepExpected = 1
+	timeSleep = func(d time.Duration) {
+		timeSleepCalled++
+		assert.Equal(t, dummyDrainDelay, d)
 	}
 	loggerAppRootExpected = 1
 	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAppRootCalled++
 		assert.Equal(t, "server", category)
 		assert.Equal(t, "Host", subcategory)
-		assert.Equal(t, "Interrupt signal received: Terminating server", messageFormat)
+		assert.Equal(t, "Listener failure occurred: Terminating server", messageFormat)
 		assert.Empty(t, parameters)
 	}
 	contextBackgroundExpected = 1
@@ -428,19 +950,17 @@ func TestRunServer_HappyPath(t *testing.T) {
 		return dummyShutDownError
 	}
 	consolidateErrorFuncExpected = 1
-	consolidateErrorFunc = func(hostError error, shutdownError error) error {
+	consolidateErrorFunc = func(hostErrors []error, shutdownErrors []error) error {
 		consolidateErrorFuncCalled++
-		assert.Equal(t, dummyHostError, hostError)
-		assert.Equal(t, dummyShutDownError, shutdownError)
+		assert.Equal(t, []error{dummyHostError}, hostErrors)
+		assert.Equal(t, []error{dummyShutDownError}, shutdownErrors)
 		return dummyAppError
 	}
 
 	// SUT + act
 	var err = runServer(
-		dummyServeHTTPS,
-		dummyValidateClientCert,
-		dummyAppPort,
-		dummyRouter,
+		dummyListeners,
+		[]*mux.Router{dummyRouter},
 	)
 
 	// assert
@@ -451,11 +971,233 @@ func TestRunServer_HappyPath(t *testing.T) {
 	assert.Equal(t, cancelCallbackExpected, cancelCallbackCalled, "Unexpected number of calls to cancelCallback")
 }
 
+func TestRunServer_MultipleListeners(t *testing.T) {
+	// arrange
+	var dummyListeners = []model.Listener{
+		{
+			Port:               "some public port",
+			ServeHTTPS:         true,
+			ValidateClientCert: true,
+		},
+		{
+			Port: "some internal port",
+		},
+	}
+	var dummyRouters = []*mux.Router{
+		{},
+		{},
+	}
+	var dummyServers = []*http.Server{
+		{Addr: "some public address"},
+		{Addr: "some internal address"},
+	}
+	var dummyHostError = errors.New("some host error message")
+	var dummyRuntimeContext = context.TODO()
+	var dummyShutDownError = errors.New("some shut down error message")
+	var dummyAppError = errors.New("some app error")
+	var dummyDrainDelay = time.Duration(rand.Intn(100)) * time.Second
+	var mockLock sync.Mutex
+	var stopServing = make(chan struct{})
+
+	// mock
+	createMock(t)
+
+	// expect
+	createServerFuncExpected = 2
+	createServerFunc = func(serveHTTPS bool, validateClientCert bool, appPort string, router *mux.Router) (*http.Server, error) {
+		createServerFuncCalled++
+		assert.Equal(t, dummyListeners[createServerFuncCalled-1].ServeHTTPS, serveHTTPS)
+		assert.Equal(t, dummyListeners[createServerFuncCalled-1].ValidateClientCert, validateClientCert)
+		assert.Equal(t, dummyListeners[createServerFuncCalled-1].Port, appPort)
+		assert.Equal(t, dummyRouters[createServerFuncCalled-1], router)
+		return dummyServers[createServerFuncCalled-1], nil
+	}
+	signalNotifyExpected = 1
+	listenAndServeFuncExpected = 2
+	listenAndServeFunc = func(server *http.Server, serveHTTPS bool) error {
+		mockLock.Lock()
+		listenAndServeFuncCalled++
+		mockLock.Unlock()
+		if server == dummyServers[0] {
+			assert.True(t, serveHTTPS)
+			return dummyHostError
+		}
+		assert.Equal(t, dummyServers[1], server)
+		assert.False(t, serveHTTPS)
+		<-stopServing
+		return http.ErrServerClosed
+	}
+	healthSetReadinessExpected = 2
+	healthSetReadiness = func(ready bool) {
+		healthSetReadinessCalled++
+		assert.Equal(t, healthSetReadinessCalled == 1, ready)
+	}
+	getDurationFuncExpected = 1
+	getDurationFunc = func(customizedFunc func() time.Duration) time.Duration {
+		getDurationFuncCalled++
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(customization.ServerShutdownDrainDelay)), fmt.Sprintf("%v", reflect.ValueOf(customizedFunc)))
+		return dummyDrainDelay
+	}
+	timeSleepExpected = 1
+	timeSleep = func(d time.Duration) {
+		timeSleepCalled++
+		assert.Equal(t, dummyDrainDelay, d)
+	}
+	loggerAppRootExpected = 1
+	contextBackgroundExpected = 1
+	configGraceShutdownWaitTimeExpected = 1
+	contextWithTimeoutExpected = 1
+	contextWithTimeout = func(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+		contextWithTimeoutCalled++
+		return dummyRuntimeContext, func() {}
+	}
+	shutDownFuncExpected = 2
+	shutDownFunc = func(runtimeContext context.Context, server *http.Server) error {
+		shutDownFuncCalled++
+		assert.Equal(t, dummyRuntimeContext, runtimeContext)
+		assert.Equal(t, dummyServers[shutDownFuncCalled-1], server)
+		if shutDownFuncCalled == 2 {
+			close(stopServing)
+			return nil
+		}
+		return dummyShutDownError
+	}
+	consolidateErrorFuncExpected = 1
+	consolidateErrorFunc = func(hostErrors []error, shutdownErrors []error) error {
+		consolidateErrorFuncCalled++
+		assert.Equal(t, []error{dummyHostError, http.ErrServerClosed}, hostErrors)
+		assert.Equal(t, []error{dummyShutDownError, nil}, shutdownErrors)
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = runServer(
+		dummyListeners,
+		dummyRouters,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestRunServer_InterruptSignal(t *testing.T) {
+	// arrange
+	var dummyListeners = []model.Listener{
+		{Port: "some public port"},
+		{Port: "some internal port"},
+	}
+	var dummyRouters = []*mux.Router{
+		{},
+		{},
+	}
+	var dummyServers = []*http.Server{
+		{Addr: "some public address"},
+		{Addr: "some internal address"},
+	}
+	var dummyRuntimeContext = context.TODO()
+	var dummyDrainDelay = time.Duration(rand.Intn(100)) * time.Second
+	var dummyAppError = errors.New("some app error")
+	var mockLock sync.Mutex
+	var stopServing = make(chan struct{})
+
+	// mock
+	createMock(t)
+
+	// expect
+	createServerFuncExpected = 2
+	createServerFunc = func(serveHTTPS bool, validateClientCert bool, appPort string, router *mux.Router) (*http.Server, error) {
+		createServerFuncCalled++
+		return dummyServers[createServerFuncCalled-1], nil
+	}
+	signalNotifyExpected = 1
+	signalNotify = func(c chan<- os.Signal, sig ...os.Signal) {
+		signalNotifyCalled++
+		Halt()
+	}
+	listenAndServeFuncExpected = 2
+	listenAndServeFunc = func(server *http.Server, serveHTTPS bool) error {
+		mockLock.Lock()
+		listenAndServeFuncCalled++
+		mockLock.Unlock()
+		<-stopServing
+		return http.ErrServerClosed
+	}
+	healthSetReadinessExpected = 2
+	healthSetReadiness = func(ready bool) {
+		healthSetReadinessCalled++
+		assert.Equal(t, healthSetReadinessCalled == 1, ready)
+	}
+	loggerAppRootExpected = 1
+	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAppRootCalled++
+		assert.Equal(t, "server", category)
+		assert.Equal(t, "Host", subcategory)
+		assert.Equal(t, "Interrupt signal received: Terminating server", messageFormat)
+		assert.Empty(t, parameters)
+	}
+	getDurationFuncExpected = 1
+	getDurationFunc = func(customizedFunc func() time.Duration) time.Duration {
+		getDurationFuncCalled++
+		return dummyDrainDelay
+	}
+	timeSleepExpected = 1
+	timeSleep = func(d time.Duration) {
+		timeSleepCalled++
+		assert.Equal(t, dummyDrainDelay, d)
+	}
+	contextBackgroundExpected = 1
+	configGraceShutdownWaitTimeExpected = 1
+	contextWithTimeoutExpected = 1
+	contextWithTimeout = func(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+		contextWithTimeoutCalled++
+		return dummyRuntimeContext, func() {}
+	}
+	shutDownFuncExpected = 2
+	shutDownFunc = func(runtimeContext context.Context, server *http.Server) error {
+		shutDownFuncCalled++
+		assert.Equal(t, dummyServers[shutDownFuncCalled-1], server)
+		if shutDownFuncCalled == 2 {
+			close(stopServing)
+		}
+		return nil
+	}
+	consolidateErrorFuncExpected = 1
+	consolidateErrorFunc = func(hostErrors []error, shutdownErrors []error) error {
+		consolidateErrorFuncCalled++
+		assert.Equal(t, []error{http.ErrServerClosed, http.ErrServerClosed}, hostErrors)
+		assert.Equal(t, []error{nil, nil}, shutdownErrors)
+		return dummyAppError
+	}
+
+	// SUT + act
+	var err = runServer(
+		dummyListeners,
+		dummyRouters,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+	assert.Zero(t, len(shutdownSignal))
+
+	// verify
+	verifyAll(t)
+}
+
 func TestHost_ErrorRegisterRoutes(t *testing.T) {
 	// arrange
 	var dummyServeHTTPS = rand.Intn(100) < 50
 	var dummyValidateClientCert = rand.Intn(100) < 50
 	var dummyAppPort = "some app port"
+	var dummyListeners = []model.Listener{
+		{
+			Name:      "some listener",
+			Port:      "some port",
+			Endpoints: []string{"some endpoint"},
+		},
+	}
 	var dummyRouter = &mux.Router{}
 	var dummyError = errors.New("some error message")
 	var dummyMessageFormat = "Failed to host entries on port %v"
@@ -465,9 +1207,18 @@ func TestHost_ErrorRegisterRoutes(t *testing.T) {
 	createMock(t)
 
 	// expect
+	getListenersFuncExpected = 1
+	getListenersFunc = func(serveHTTPS bool, validateClientCert bool, appPort string) []model.Listener {
+		getListenersFuncCalled++
+		assert.Equal(t, dummyServeHTTPS, serveHTTPS)
+		assert.Equal(t, dummyValidateClientCert, validateClientCert)
+		assert.Equal(t, dummyAppPort, appPort)
+		return dummyListeners
+	}
 	registerInstantiateExpected = 1
-	registerInstantiate = func() (*mux.Router, error) {
+	registerInstantiate = func(endpoints []string) (*mux.Router, error) {
 		registerInstantiateCalled++
+		assert.Equal(t, dummyListeners[0].Endpoints, endpoints)
 		return dummyRouter, dummyError
 	}
 	apperrorWrapSimpleErrorExpected = 1
@@ -477,7 +1228,7 @@ func TestHost_ErrorRegisterRoutes(t *testing.T) {
 		assert.Equal(t, dummyError, innerErrors[0])
 		assert.Equal(t, dummyMessageFormat, messageFormat)
 		assert.Equal(t, 1, len(parameters))
-		assert.Equal(t, dummyAppPort, parameters[0])
+		assert.Equal(t, dummyListeners[0].Port, parameters[0])
 		return dummyAppError
 	}
 
@@ -500,18 +1251,32 @@ func TestHost_ErrorRunServer(t *testing.T) {
 	var dummyServeHTTPS = rand.Intn(100) < 50
 	var dummyValidateClientCert = rand.Intn(100) < 50
 	var dummyAppPort = "some app port"
+	var dummyListeners = []model.Listener{
+		{
+			Name:               "some listener",
+			Port:               "some port",
+			ServeHTTPS:         rand.Intn(100) < 50,
+			ValidateClientCert: rand.Intn(100) < 50,
+		},
+	}
 	var dummyRouter = &mux.Router{}
 	var dummyError = errors.New("some error message")
-	var dummyMessageFormat = "Failed to run server on port %v"
+	var dummyMessageFormat = "Failed to run server listeners"
 	var dummyAppError = apperror.GetCustomError(0, "some app error")
 
 	// mock
 	createMock(t)
 
 	// expect
+	getListenersFuncExpected = 1
+	getListenersFunc = func(serveHTTPS bool, validateClientCert bool, appPort string) []model.Listener {
+		getListenersFuncCalled++
+		return dummyListeners
+	}
 	registerInstantiateExpected = 1
-	registerInstantiate = func() (*mux.Router, error) {
+	registerInstantiate = func(endpoints []string) (*mux.Router, error) {
 		registerInstantiateCalled++
+		assert.Empty(t, endpoints)
 		return dummyRouter, nil
 	}
 	loggerAppRootExpected = 2
@@ -520,23 +1285,22 @@ func TestHost_ErrorRunServer(t *testing.T) {
 		assert.Equal(t, "server", category)
 		assert.Equal(t, "Host", subcategory)
 		if loggerAppRootCalled == 1 {
-			assert.Equal(t, "Targeting port [%v] HTTPS [%v] mTLS [%v]", messageFormat)
-			assert.Equal(t, 3, len(parameters))
-			assert.Equal(t, dummyAppPort, parameters[0])
-			assert.Equal(t, dummyServeHTTPS, parameters[1])
-			assert.Equal(t, dummyValidateClientCert, parameters[2])
+			assert.Equal(t, "Targeting listener [%v] on port [%v] HTTPS [%v] mTLS [%v]", messageFormat)
+			assert.Equal(t, 4, len(parameters))
+			assert.Equal(t, dummyListeners[0].Name, parameters[0])
+			assert.Equal(t, dummyListeners[0].Port, parameters[1])
+			assert.Equal(t, dummyListeners[0].ServeHTTPS, parameters[2])
+			assert.Equal(t, dummyListeners[0].ValidateClientCert, parameters[3])
 		} else {
 			assert.Equal(t, "Server terminated", messageFormat)
 			assert.Empty(t, parameters)
 		}
 	}
 	runServerFuncExpected = 1
-	runServerFunc = func(serveHTTPS bool, validateClientCert bool, appPort string, router *mux.Router) error {
+	runServerFunc = func(listeners []model.Listener, routers []*mux.Router) error {
 		runServerFuncCalled++
-		assert.Equal(t, dummyServeHTTPS, serveHTTPS)
-		assert.Equal(t, dummyValidateClientCert, validateClientCert)
-		assert.Equal(t, dummyAppPort, appPort)
-		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyListeners, listeners)
+		assert.Equal(t, []*mux.Router{dummyRouter}, routers)
 		return dummyError
 	}
 	apperrorWrapSimpleErrorExpected = 1
@@ -545,8 +1309,7 @@ func TestHost_ErrorRunServer(t *testing.T) {
 		assert.Equal(t, 1, len(innerErrors))
 		assert.Equal(t, dummyError, innerErrors[0])
 		assert.Equal(t, dummyMessageFormat, messageFormat)
-		assert.Equal(t, 1, len(parameters))
-		assert.Equal(t, dummyAppPort, parameters[0])
+		assert.Empty(t, parameters)
 		return dummyAppError
 	}
 
@@ -569,40 +1332,62 @@ func TestHost_Success(t *testing.T) {
 	var dummyServeHTTPS = rand.Intn(100) < 50
 	var dummyValidateClientCert = rand.Intn(100) < 50
 	var dummyAppPort = "some app port"
-	var dummyRouter = &mux.Router{}
+	var dummyListeners = []model.Listener{
+		{
+			Name:               "some public listener",
+			Port:               "some public port",
+			ServeHTTPS:         true,
+			ValidateClientCert: true,
+		},
+		{
+			Name:      "some internal listener",
+			Port:      "some internal port",
+			Endpoints: []string{"some endpoint"},
+		},
+	}
+	var dummyRouters = []*mux.Router{
+		{},
+		{},
+	}
 
 	// mock
 	createMock(t)
 
 	// expect
-	registerInstantiateExpected = 1
-	registerInstantiate = func() (*mux.Router, error) {
+	getListenersFuncExpected = 1
+	getListenersFunc = func(serveHTTPS bool, validateClientCert bool, appPort string) []model.Listener {
+		getListenersFuncCalled++
+		return dummyListeners
+	}
+	registerInstantiateExpected = 2
+	registerInstantiate = func(endpoints []string) (*mux.Router, error) {
 		registerInstantiateCalled++
-		return dummyRouter, nil
+		assert.Equal(t, dummyListeners[registerInstantiateCalled-1].Endpoints, endpoints)
+		return dummyRouters[registerInstantiateCalled-1], nil
 	}
-	loggerAppRootExpected = 2
+	loggerAppRootExpected = 3
 	loggerAppRoot = func(category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAppRootCalled++
 		assert.Equal(t, "server", category)
 		assert.Equal(t, "Host", subcategory)
-		if loggerAppRootCalled == 1 {
-			assert.Equal(t, "Targeting port [%v] HTTPS [%v] mTLS [%v]", messageFormat)
-			assert.Equal(t, 3, len(parameters))
-			assert.Equal(t, dummyAppPort, parameters[0])
-			assert.Equal(t, dummyServeHTTPS, parameters[1])
-			assert.Equal(t, dummyValidateClientCert, parameters[2])
+		if loggerAppRootCalled <= 2 {
+			var listener = dummyListeners[loggerAppRootCalled-1]
+			assert.Equal(t, "Targeting listener [%v] on port [%v] HTTPS [%v] mTLS [%v]", messageFormat)
+			assert.Equal(t, 4, len(parameters))
+			assert.Equal(t, listener.Name, parameters[0])
+			assert.Equal(t, listener.Port, parameters[1])
+			assert.Equal(t, listener.ServeHTTPS, parameters[2])
+			assert.Equal(t, listener.ValidateClientCert, parameters[3])
 		} else {
 			assert.Equal(t, "Server terminated", messageFormat)
 			assert.Empty(t, parameters)
 		}
 	}
 	runServerFuncExpected = 1
-	runServerFunc = func(serveHTTPS bool, validateClientCert bool, appPort string, router *mux.Router) error {
+	runServerFunc = func(listeners []model.Listener, routers []*mux.Router) error {
 		runServerFuncCalled++
-		assert.Equal(t, dummyServeHTTPS, serveHTTPS)
-		assert.Equal(t, dummyValidateClientCert, validateClientCert)
-		assert.Equal(t, dummyAppPort, appPort)
-		assert.Equal(t, dummyRouter, router)
+		assert.Equal(t, dummyListeners, listeners)
+		assert.Equal(t, dummyRouters, routers)
 		return nil
 	}
 
@@ -637,3 +1422,23 @@ func TestHalt(t *testing.T) {
 	// verify
 	verifyAll(t)
 }
+
+func TestHalt_Repeated(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	Halt()
+	Halt()
+
+	// act
+	var result, ok = <-shutdownSignal
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, os.Interrupt, result)
+	assert.Zero(t, len(shutdownSignal))
+
+	// verify
+	verifyAll(t)
+}
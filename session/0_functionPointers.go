@@ -7,10 +7,12 @@ import (
 	"reflect"
 	"runtime"
 	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
+	"github.com/zhongjie-cai/WebServiceTemplate/binding"
 	"github.com/zhongjie-cai/WebServiceTemplate/certificate"
 	"github.com/zhongjie-cai/WebServiceTemplate/headerutil"
 	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
@@ -21,35 +23,49 @@ import (
 
 // func pointers for injection / testing: session.go
 var (
-	reflectValueOf                  = reflect.ValueOf
-	isInterfaceValueNilFunc         = isInterfaceValueNil
-	uuidNew                         = uuid.New
-	jsonMarshal                     = json.Marshal
-	jsonUnmarshal                   = json.Unmarshal
-	fmtErrorf                       = fmt.Errorf
-	muxVars                         = mux.Vars
-	loggerAPIRequest                = logger.APIRequest
-	requestGetRequestBody           = request.GetRequestBody
-	apperrorGetBadRequestError      = apperror.GetBadRequestError
-	textprotoCanonicalMIMEHeaderKey = textproto.CanonicalMIMEHeaderKey
-	jsonutilTryUnmarshal            = jsonutil.TryUnmarshal
-	headerutilLogHTTPHeaderForName  = headerutil.LogHTTPHeaderForName
-	getAllQueriesFunc               = getAllQueries
-	getAllHeadersFunc               = getAllHeaders
-	isLoggingTypeMatchFunc          = isLoggingTypeMatch
-	isLoggingLevelMatchFunc         = isLoggingLevelMatch
-	runtimeCaller                   = runtime.Caller
-	runtimeFuncForPC                = runtime.FuncForPC
-	getMethodNameFunc               = getMethodName
-	strconvItoa                     = strconv.Itoa
-	loggerMethodEnter               = logger.MethodEnter
-	loggerMethodParameter           = logger.MethodParameter
-	loggerMethodLogic               = logger.MethodLogic
-	loggerMethodReturn              = logger.MethodReturn
-	loggerMethodExit                = logger.MethodExit
-	networkNewNetworkRequest        = network.NewNetworkRequest
-	getAllowedLogTypeFunc           = getAllowedLogType
-	getAllowedLogLevelFunc          = getAllowedLogLevel
-	certificateHasClientCert        = certificate.HasClientCert
-	shouldSendClientCertFunc        = shouldSendClientCert
+	reflectValueOf                   = reflect.ValueOf
+	isInterfaceValueNilFunc          = isInterfaceValueNil
+	uuidNew                          = uuid.New
+	uuidParse                        = uuid.Parse
+	headerutilGetCorrelationIDHeader = headerutil.GetCorrelationIDHeader
+	isValidCorrelationIDFunc         = isValidCorrelationID
+	getCorrelationIDFunc             = getCorrelationID
+	getSessionIDFunc                 = getSessionID
+	jsonMarshal                      = json.Marshal
+	jsonUnmarshal                    = json.Unmarshal
+	fmtErrorf                        = fmt.Errorf
+	fmtSprintf                       = fmt.Sprintf
+	muxVars                          = mux.Vars
+	loggerAPIRequest                 = logger.APIRequest
+	requestGetRequestBody            = request.GetRequestBody
+	requestDecodeRequestBody         = request.DecodeRequestBody
+	requestGetFormValues             = request.GetFormValues
+	requestStreamFiles               = request.StreamFiles
+	bindingBind                      = binding.Bind
+	apperrorGetBadRequestError       = apperror.GetBadRequestError
+	textprotoCanonicalMIMEHeaderKey  = textproto.CanonicalMIMEHeaderKey
+	jsonutilTryUnmarshal             = jsonutil.TryUnmarshal
+	headerutilLogHTTPHeaderForName   = headerutil.LogHTTPHeaderForName
+	getAllQueriesFunc                = getAllQueries
+	getAllHeadersFunc                = getAllHeaders
+	getAllFormValuesFunc             = getAllFormValues
+	isLoggingTypeMatchFunc           = isLoggingTypeMatch
+	isLoggingLevelMatchFunc          = isLoggingLevelMatch
+	runtimeCaller                    = runtime.Caller
+	runtimeFuncForPC                 = runtime.FuncForPC
+	getMethodNameFunc                = getMethodName
+	strconvItoa                      = strconv.Itoa
+	loggerMethodEnter                = logger.MethodEnter
+	loggerMethodParameter            = logger.MethodParameter
+	loggerMethodLogic                = logger.MethodLogic
+	loggerMethodReturn               = logger.MethodReturn
+	loggerMethodExit                 = logger.MethodExit
+	networkNewNetworkRequest         = network.NewNetworkRequest
+	getAllowedLogTypeFunc            = getAllowedLogType
+	getAllowedLogLevelFunc           = getAllowedLogLevel
+	certificateHasClientCert         = certificate.HasClientCert
+	shouldSendClientCertFunc         = shouldSendClientCert
+	stringsHasPrefix                 = strings.HasPrefix
+	stringsHasSuffix                 = strings.HasSuffix
+	formatETagFunc                   = formatETag
 )
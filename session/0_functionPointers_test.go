@@ -2,18 +2,24 @@ package session
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/textproto"
+	"net/url"
 	"reflect"
 	"runtime"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/zhongjie-cai/WebServiceTemplate/apperror"
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/binding"
 	"github.com/zhongjie-cai/WebServiceTemplate/certificate"
 	"github.com/zhongjie-cai/WebServiceTemplate/config"
 	"github.com/zhongjie-cai/WebServiceTemplate/customization"
@@ -25,84 +31,115 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/network"
 	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/request"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
 )
 
 var (
-	reflectValueOfExpected                      int
-	reflectValueOfCalled                        int
-	isInterfaceValueNilFuncExpected             int
-	isInterfaceValueNilFuncCalled               int
-	uuidNewExpected                             int
-	uuidNewCalled                               int
-	jsonMarshalExpected                         int
-	jsonMarshalCalled                           int
-	jsonUnmarshalExpected                       int
-	jsonUnmarshalCalled                         int
-	fmtErrorfExpected                           int
-	fmtErrorfCalled                             int
-	muxVarsExpected                             int
-	muxVarsCalled                               int
-	loggerAPIRequestExpected                    int
-	loggerAPIRequestCalled                      int
-	requestGetRequestBodyExpected               int
-	requestGetRequestBodyCalled                 int
-	apperrorGetBadRequestErrorExpected          int
-	apperrorGetBadRequestErrorCalled            int
-	textprotoCanonicalMIMEHeaderKeyExpected     int
-	textprotoCanonicalMIMEHeaderKeyCalled       int
-	jsonutilTryUnmarshalExpected                int
-	jsonutilTryUnmarshalCalled                  int
-	headerutilLogHTTPHeaderForNameExpected      int
-	headerutilLogHTTPHeaderForNameCalled        int
-	getAllQueriesFuncExpected                   int
-	getAllQueriesFuncCalled                     int
-	getAllHeadersFuncExpected                   int
-	getAllHeadersFuncCalled                     int
-	isLoggingTypeMatchFuncExpected              int
-	isLoggingTypeMatchFuncCalled                int
-	isLoggingLevelMatchFuncExpected             int
-	isLoggingLevelMatchFuncCalled               int
-	configIsLocalhostExpected                   int
-	configIsLocalhostCalled                     int
-	configDefaultAllowedLogTypeExpected         int
-	configDefaultAllowedLogTypeCalled           int
-	configDefaultAllowedLogLevelExpected        int
-	configDefaultAllowedLogLevelCalled          int
-	customizationSessionAllowedLogTypeExpected  int
-	customizationSessionAllowedLogTypeCalled    int
-	customizationSessionAllowedLogLevelExpected int
-	customizationSessionAllowedLogLevelCalled   int
-	runtimeCallerExpected                       int
-	runtimeCallerCalled                         int
-	runtimeFuncForPCExpected                    int
-	runtimeFuncForPCCalled                      int
-	getMethodNameFuncExpected                   int
-	getMethodNameFuncCalled                     int
-	strconvItoaExpected                         int
-	strconvItoaCalled                           int
-	loggerMethodEnterExpected                   int
-	loggerMethodEnterCalled                     int
-	loggerMethodParameterExpected               int
-	loggerMethodParameterCalled                 int
-	loggerMethodLogicExpected                   int
-	loggerMethodLogicCalled                     int
-	loggerMethodReturnExpected                  int
-	loggerMethodReturnCalled                    int
-	loggerMethodExitExpected                    int
-	loggerMethodExitCalled                      int
-	networkNewNetworkRequestExpected            int
-	networkNewNetworkRequestCalled              int
-	getAllowedLogTypeFuncExpected               int
-	getAllowedLogTypeFuncCalled                 int
-	getAllowedLogLevelFuncExpected              int
-	getAllowedLogLevelFuncCalled                int
-	certificateHasClientCertExpected            int
-	certificateHasClientCertCalled              int
-	customizationSendClientCertExpected         int
-	customizationSendClientCertCalled           int
-	shouldSendClientCertFuncExpected            int
-	shouldSendClientCertFuncCalled              int
+	reflectValueOfExpected                        int
+	reflectValueOfCalled                          int
+	isInterfaceValueNilFuncExpected               int
+	isInterfaceValueNilFuncCalled                 int
+	uuidNewExpected                               int
+	uuidNewCalled                                 int
+	uuidParseExpected                             int
+	uuidParseCalled                               int
+	headerutilGetCorrelationIDHeaderExpected      int
+	headerutilGetCorrelationIDHeaderCalled        int
+	isValidCorrelationIDFuncExpected              int
+	isValidCorrelationIDFuncCalled                int
+	getCorrelationIDFuncExpected                  int
+	getCorrelationIDFuncCalled                    int
+	getSessionIDFuncExpected                      int
+	getSessionIDFuncCalled                        int
+	jsonMarshalExpected                           int
+	jsonMarshalCalled                             int
+	jsonUnmarshalExpected                         int
+	jsonUnmarshalCalled                           int
+	fmtSprintfExpected                            int
+	fmtSprintfCalled                              int
+	fmtErrorfExpected                             int
+	fmtErrorfCalled                               int
+	muxVarsExpected                               int
+	muxVarsCalled                                 int
+	loggerAPIRequestExpected                      int
+	loggerAPIRequestCalled                        int
+	requestGetRequestBodyExpected                 int
+	requestGetRequestBodyCalled                   int
+	requestDecodeRequestBodyExpected              int
+	requestDecodeRequestBodyCalled                int
+	requestGetFormValuesExpected                  int
+	requestGetFormValuesCalled                    int
+	requestStreamFilesExpected                    int
+	requestStreamFilesCalled                      int
+	bindingBindExpected                           int
+	bindingBindCalled                             int
+	apperrorGetBadRequestErrorExpected            int
+	apperrorGetBadRequestErrorCalled              int
+	textprotoCanonicalMIMEHeaderKeyExpected       int
+	textprotoCanonicalMIMEHeaderKeyCalled         int
+	jsonutilTryUnmarshalExpected                  int
+	jsonutilTryUnmarshalCalled                    int
+	headerutilLogHTTPHeaderForNameExpected        int
+	headerutilLogHTTPHeaderForNameCalled          int
+	getAllQueriesFuncExpected                     int
+	getAllQueriesFuncCalled                       int
+	getAllHeadersFuncExpected                     int
+	getAllHeadersFuncCalled                       int
+	getAllFormValuesFuncExpected                  int
+	getAllFormValuesFuncCalled                    int
+	isLoggingTypeMatchFuncExpected                int
+	isLoggingTypeMatchFuncCalled                  int
+	isLoggingLevelMatchFuncExpected               int
+	isLoggingLevelMatchFuncCalled                 int
+	configIsLocalhostExpected                     int
+	configIsLocalhostCalled                       int
+	configDefaultAllowedLogTypeExpected           int
+	configDefaultAllowedLogTypeCalled             int
+	configDefaultAllowedLogLevelExpected          int
+	configDefaultAllowedLogLevelCalled            int
+	customizationSessionAllowedLogTypeExpected    int
+	customizationSessionAllowedLogTypeCalled      int
+	customizationSessionAllowedLogLevelExpected   int
+	customizationSessionAllowedLogLevelCalled     int
+	runtimeCallerExpected                         int
+	runtimeCallerCalled                           int
+	runtimeFuncForPCExpected                      int
+	runtimeFuncForPCCalled                        int
+	getMethodNameFuncExpected                     int
+	getMethodNameFuncCalled                       int
+	strconvItoaExpected                           int
+	strconvItoaCalled                             int
+	loggerMethodEnterExpected                     int
+	loggerMethodEnterCalled                       int
+	loggerMethodParameterExpected                 int
+	loggerMethodParameterCalled                   int
+	loggerMethodLogicExpected                     int
+	loggerMethodLogicCalled                       int
+	loggerMethodReturnExpected                    int
+	loggerMethodReturnCalled                      int
+	loggerMethodExitExpected                      int
+	loggerMethodExitCalled                        int
+	networkNewNetworkRequestExpected              int
+	networkNewNetworkRequestCalled                int
+	getAllowedLogTypeFuncExpected                 int
+	getAllowedLogTypeFuncCalled                   int
+	getAllowedLogLevelFuncExpected                int
+	getAllowedLogLevelFuncCalled                  int
+	certificateHasClientCertExpected              int
+	certificateHasClientCertCalled                int
+	customizationSendClientCertExpected           int
+	customizationSendClientCertCalled             int
+	shouldSendClientCertFuncExpected              int
+	shouldSendClientCertFuncCalled                int
+	stringsHasPrefixExpected                      int
+	stringsHasPrefixCalled                        int
+	stringsHasSuffixExpected                      int
+	stringsHasSuffixCalled                        int
+	formatETagFuncExpected                        int
+	formatETagFuncCalled                          int
+	customizationCorrelationIDAsSessionIDExpected int
+	customizationCorrelationIDAsSessionIDCalled   int
 )
 
 func createMock(t *testing.T) {
@@ -124,6 +161,36 @@ func createMock(t *testing.T) {
 		uuidNewCalled++
 		return uuid.Nil
 	}
+	uuidParseExpected = 0
+	uuidParseCalled = 0
+	uuidParse = func(s string) (uuid.UUID, error) {
+		uuidParseCalled++
+		return uuid.Nil, nil
+	}
+	headerutilGetCorrelationIDHeaderExpected = 0
+	headerutilGetCorrelationIDHeaderCalled = 0
+	headerutilGetCorrelationIDHeader = func() string {
+		headerutilGetCorrelationIDHeaderCalled++
+		return ""
+	}
+	isValidCorrelationIDFuncExpected = 0
+	isValidCorrelationIDFuncCalled = 0
+	isValidCorrelationIDFunc = func(correlationID string) bool {
+		isValidCorrelationIDFuncCalled++
+		return false
+	}
+	getCorrelationIDFuncExpected = 0
+	getCorrelationIDFuncCalled = 0
+	getCorrelationIDFunc = func(httpRequest *http.Request) string {
+		getCorrelationIDFuncCalled++
+		return ""
+	}
+	getSessionIDFuncExpected = 0
+	getSessionIDFuncCalled = 0
+	getSessionIDFunc = func(correlationID string) uuid.UUID {
+		getSessionIDFuncCalled++
+		return uuid.Nil
+	}
 	jsonMarshalExpected = 0
 	jsonMarshalCalled = 0
 	jsonMarshal = func(v interface{}) ([]byte, error) {
@@ -136,6 +203,12 @@ func createMock(t *testing.T) {
 		jsonUnmarshalCalled++
 		return nil
 	}
+	fmtSprintfExpected = 0
+	fmtSprintfCalled = 0
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		return ""
+	}
 	fmtErrorfExpected = 0
 	fmtErrorfCalled = 0
 	fmtErrorf = func(format string, a ...interface{}) error {
@@ -159,6 +232,30 @@ func createMock(t *testing.T) {
 		requestGetRequestBodyCalled++
 		return ""
 	}
+	requestDecodeRequestBodyExpected = 0
+	requestDecodeRequestBodyCalled = 0
+	requestDecodeRequestBody = func(httpRequest *http.Request, body string, dataTemplate interface{}) apperrorModel.AppError {
+		requestDecodeRequestBodyCalled++
+		return nil
+	}
+	requestGetFormValuesExpected = 0
+	requestGetFormValuesCalled = 0
+	requestGetFormValues = func(httpRequest *http.Request, body string) (url.Values, error) {
+		requestGetFormValuesCalled++
+		return nil, nil
+	}
+	requestStreamFilesExpected = 0
+	requestStreamFilesCalled = 0
+	requestStreamFiles = func(httpRequest *http.Request, name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+		requestStreamFilesCalled++
+		return nil
+	}
+	bindingBindExpected = 0
+	bindingBindCalled = 0
+	bindingBind = func(session sessionModel.Session, dataTemplate interface{}) apperrorModel.AppError {
+		bindingBindCalled++
+		return nil
+	}
 	apperrorGetBadRequestErrorExpected = 0
 	apperrorGetBadRequestErrorCalled = 0
 	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
@@ -194,6 +291,12 @@ func createMock(t *testing.T) {
 		getAllHeadersFuncCalled++
 		return nil
 	}
+	getAllFormValuesFuncExpected = 0
+	getAllFormValuesFuncCalled = 0
+	getAllFormValuesFunc = func(session *session, name string) []string {
+		getAllFormValuesFuncCalled++
+		return nil
+	}
 	isLoggingTypeMatchFuncExpected = 0
 	isLoggingTypeMatchFuncCalled = 0
 	isLoggingTypeMatchFunc = func(session *session, logType logtype.LogType) bool {
@@ -306,6 +409,27 @@ func createMock(t *testing.T) {
 		shouldSendClientCertFuncCalled++
 		return false
 	}
+	stringsHasPrefixExpected = 0
+	stringsHasPrefixCalled = 0
+	stringsHasPrefix = func(s string, prefix string) bool {
+		stringsHasPrefixCalled++
+		return false
+	}
+	stringsHasSuffixExpected = 0
+	stringsHasSuffixCalled = 0
+	stringsHasSuffix = func(s string, suffix string) bool {
+		stringsHasSuffixCalled++
+		return false
+	}
+	formatETagFuncExpected = 0
+	formatETagFuncCalled = 0
+	formatETagFunc = func(etag string, weak bool) string {
+		formatETagFuncCalled++
+		return ""
+	}
+	customizationCorrelationIDAsSessionIDExpected = 0
+	customizationCorrelationIDAsSessionIDCalled = 0
+	customization.CorrelationIDAsSessionID = nil
 }
 
 func verifyAll(t *testing.T) {
@@ -315,16 +439,36 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, isInterfaceValueNilFuncExpected, isInterfaceValueNilFuncCalled, "Unexpected number of calls to isInterfaceValueNilFunc")
 	uuidNew = uuid.New
 	assert.Equal(t, uuidNewExpected, uuidNewCalled, "Unexpected number of calls to uuidNew")
+	uuidParse = uuid.Parse
+	assert.Equal(t, uuidParseExpected, uuidParseCalled, "Unexpected number of calls to uuidParse")
+	headerutilGetCorrelationIDHeader = headerutil.GetCorrelationIDHeader
+	assert.Equal(t, headerutilGetCorrelationIDHeaderExpected, headerutilGetCorrelationIDHeaderCalled, "Unexpected number of calls to headerutilGetCorrelationIDHeader")
+	isValidCorrelationIDFunc = isValidCorrelationID
+	assert.Equal(t, isValidCorrelationIDFuncExpected, isValidCorrelationIDFuncCalled, "Unexpected number of calls to isValidCorrelationIDFunc")
+	getCorrelationIDFunc = getCorrelationID
+	assert.Equal(t, getCorrelationIDFuncExpected, getCorrelationIDFuncCalled, "Unexpected number of calls to getCorrelationIDFunc")
+	getSessionIDFunc = getSessionID
+	assert.Equal(t, getSessionIDFuncExpected, getSessionIDFuncCalled, "Unexpected number of calls to getSessionIDFunc")
 	jsonMarshal = json.Marshal
 	assert.Equal(t, jsonMarshalExpected, jsonMarshalCalled, "Unexpected number of calls to jsonMarshal")
 	jsonUnmarshal = json.Unmarshal
 	assert.Equal(t, jsonUnmarshalExpected, jsonUnmarshalCalled, "Unexpected number of calls to jsonUnmarshal")
+	fmtSprintf = fmt.Sprintf
+	assert.Equal(t, fmtSprintfExpected, fmtSprintfCalled, "Unexpected number of calls to fmtSprintf")
 	muxVars = mux.Vars
 	assert.Equal(t, muxVarsExpected, muxVarsCalled, "Unexpected number of calls to muxVars")
 	loggerAPIRequest = logger.APIRequest
 	assert.Equal(t, loggerAPIRequestExpected, loggerAPIRequestCalled, "Unexpected number of calls to loggerAPIRequest")
 	requestGetRequestBody = request.GetRequestBody
 	assert.Equal(t, requestGetRequestBodyExpected, requestGetRequestBodyCalled, "Unexpected number of calls to requestGetRequestBody")
+	requestDecodeRequestBody = request.DecodeRequestBody
+	assert.Equal(t, requestDecodeRequestBodyExpected, requestDecodeRequestBodyCalled, "Unexpected number of calls to requestDecodeRequestBody")
+	requestGetFormValues = request.GetFormValues
+	assert.Equal(t, requestGetFormValuesExpected, requestGetFormValuesCalled, "Unexpected number of calls to requestGetFormValues")
+	requestStreamFiles = request.StreamFiles
+	assert.Equal(t, requestStreamFilesExpected, requestStreamFilesCalled, "Unexpected number of calls to requestStreamFiles")
+	bindingBind = binding.Bind
+	assert.Equal(t, bindingBindExpected, bindingBindCalled, "Unexpected number of calls to bindingBind")
 	apperrorGetBadRequestError = apperror.GetBadRequestError
 	assert.Equal(t, apperrorGetBadRequestErrorExpected, apperrorGetBadRequestErrorCalled, "Unexpected number of calls to apperrorGetBadRequestError")
 	textprotoCanonicalMIMEHeaderKey = textproto.CanonicalMIMEHeaderKey
@@ -337,6 +481,8 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, getAllQueriesFuncExpected, getAllQueriesFuncCalled, "Unexpected number of calls to getAllQueriesFunc")
 	getAllHeadersFunc = getAllHeaders
 	assert.Equal(t, getAllHeadersFuncExpected, getAllHeadersFuncCalled, "Unexpected number of calls to getAllHeadersFunc")
+	getAllFormValuesFunc = getAllFormValues
+	assert.Equal(t, getAllFormValuesFuncExpected, getAllFormValuesFuncCalled, "Unexpected number of calls to getAllFormValuesFunc")
 	isLoggingTypeMatchFunc = isLoggingTypeMatch
 	assert.Equal(t, isLoggingTypeMatchFuncExpected, isLoggingTypeMatchFuncCalled, "Unexpected number of calls to isLoggingTypeMatchFunc")
 	isLoggingLevelMatchFunc = isLoggingLevelMatch
@@ -379,8 +525,16 @@ func verifyAll(t *testing.T) {
 	assert.Equal(t, certificateHasClientCertExpected, certificateHasClientCertCalled, "Unexpected number of calls to certificateHasClientCert")
 	customization.SendClientCert = nil
 	assert.Equal(t, customizationSendClientCertExpected, customizationSendClientCertCalled, "Unexpected number of calls to customization.SendClientCert")
+	customization.CorrelationIDAsSessionID = nil
+	assert.Equal(t, customizationCorrelationIDAsSessionIDExpected, customizationCorrelationIDAsSessionIDCalled, "Unexpected number of calls to customization.CorrelationIDAsSessionID")
 	shouldSendClientCertFunc = shouldSendClientCert
 	assert.Equal(t, shouldSendClientCertFuncExpected, shouldSendClientCertFuncCalled, "Unexpected number of calls to shouldSendClientCertFunc")
+	stringsHasPrefix = strings.HasPrefix
+	assert.Equal(t, stringsHasPrefixExpected, stringsHasPrefixCalled, "Unexpected number of calls to stringsHasPrefix")
+	stringsHasSuffix = strings.HasSuffix
+	assert.Equal(t, stringsHasSuffixExpected, stringsHasSuffixCalled, "Unexpected number of calls to stringsHasSuffix")
+	formatETagFunc = formatETag
+	assert.Equal(t, formatETagFuncExpected, formatETagFuncCalled, "Unexpected number of calls to formatETagFunc")
 
 	defaultSession = nil
 	defaultSessionID = uuid.Nil
@@ -417,8 +571,69 @@ type dummyNetworkRequest struct {
 	t *testing.T
 }
 
-func (dnr *dummyNetworkRequest) EnableRetry(connectivityRetryCount int, httpStatusRetryCount map[int]int) {
+func (dnr *dummyNetworkRequest) EnableRetry(connectivityRetryCount int, httpStatusRetryCount map[int]int) networkModel.NetworkRequest {
 	assert.Fail(dnr.t, "Unexpected number of calls to EnableRetry")
+	return nil
+}
+
+func (dnr *dummyNetworkRequest) SetRetryPolicy(retryPolicy *networkModel.RetryPolicy) networkModel.NetworkRequest {
+	assert.Fail(dnr.t, "Unexpected number of calls to SetRetryPolicy")
+	return nil
+}
+
+func (dnr *dummyNetworkRequest) SetTimeout(timeout time.Duration) networkModel.NetworkRequest {
+	assert.Fail(dnr.t, "Unexpected number of calls to SetTimeout")
+	return nil
+}
+
+func (dnr *dummyNetworkRequest) SetDependency(dependency string) networkModel.NetworkRequest {
+	assert.Fail(dnr.t, "Unexpected number of calls to SetDependency")
+	return nil
+}
+
+func (dnr *dummyNetworkRequest) SetHeader(name string, value string) networkModel.NetworkRequest {
+	assert.Fail(dnr.t, "Unexpected number of calls to SetHeader")
+	return nil
+}
+
+func (dnr *dummyNetworkRequest) AddHeader(name string, value string) networkModel.NetworkRequest {
+	assert.Fail(dnr.t, "Unexpected number of calls to AddHeader")
+	return nil
+}
+
+func (dnr *dummyNetworkRequest) AddHeaders(header http.Header) networkModel.NetworkRequest {
+	assert.Fail(dnr.t, "Unexpected number of calls to AddHeaders")
+	return nil
+}
+
+func (dnr *dummyNetworkRequest) SetQuery(name string, value string) networkModel.NetworkRequest {
+	assert.Fail(dnr.t, "Unexpected number of calls to SetQuery")
+	return nil
+}
+
+func (dnr *dummyNetworkRequest) AddQuery(name string, value string) networkModel.NetworkRequest {
+	assert.Fail(dnr.t, "Unexpected number of calls to AddQuery")
+	return nil
+}
+
+func (dnr *dummyNetworkRequest) SetBasicAuth(username string, password string) networkModel.NetworkRequest {
+	assert.Fail(dnr.t, "Unexpected number of calls to SetBasicAuth")
+	return nil
+}
+
+func (dnr *dummyNetworkRequest) SetBearerToken(token string) networkModel.NetworkRequest {
+	assert.Fail(dnr.t, "Unexpected number of calls to SetBearerToken")
+	return nil
+}
+
+func (dnr *dummyNetworkRequest) SetPayload(payload io.Reader) networkModel.NetworkRequest {
+	assert.Fail(dnr.t, "Unexpected number of calls to SetPayload")
+	return nil
+}
+
+func (dnr *dummyNetworkRequest) SetJSONPayload(payload interface{}) networkModel.NetworkRequest {
+	assert.Fail(dnr.t, "Unexpected number of calls to SetJSONPayload")
+	return nil
 }
 
 func (dnr *dummyNetworkRequest) Process(dataTemplate interface{}) (statusCode int, responseHeader http.Header, responseError error) {
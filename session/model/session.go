@@ -2,12 +2,14 @@ package model
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
 	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
 )
 
 var (
@@ -31,6 +33,9 @@ type SessionMeta interface {
 
 	// GetName returns the name registered to session object for given session ID
 	GetName() string
+
+	// GetCorrelationID returns the correlation ID adopted from the incoming request header, or the session ID if not available
+	GetCorrelationID() string
 }
 
 // SessionHTTP is a subset of Session interface, containing only HTTP request & response related methods
@@ -44,7 +49,7 @@ type SessionHTTPRequest interface {
 	// GetRequest returns the HTTP request object from session object for given session ID
 	GetRequest() *http.Request
 
-	// GetRequestBody loads HTTP request body associated to session and unmarshals the content JSON to given data template
+	// GetRequestBody loads HTTP request body associated to session and decodes the content to given data template with the decoder registered for its Content-Type header (JSON if absent)
 	GetRequestBody(dataTemplate interface{}) apperrorModel.AppError
 
 	// GetRequestParameter loads HTTP request parameter associated to session for given name and unmarshals the content to given data template
@@ -61,12 +66,30 @@ type SessionHTTPRequest interface {
 
 	// GetRequestHeaders loads HTTP request header strings associated to session for given name and unmarshals the content to given data template; the fillCallback is called when each unmarshal operation succeeds, so consumer could fill in external arrays using data template during the process
 	GetRequestHeaders(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError
+
+	// GetRequestFormValue loads HTTP request single form field associated to session for given name from URL-encoded or multipart form body and unmarshals the content to given data template
+	GetRequestFormValue(name string, dataTemplate interface{}) apperrorModel.AppError
+
+	// GetRequestFormValues loads HTTP request form fields associated to session for given name from URL-encoded or multipart form body and unmarshals the content to given data template; the fillCallback is called when each unmarshal operation succeeds, so consumer could fill in external arrays using data template during the process
+	GetRequestFormValues(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError
+
+	// GetRequestFile streams HTTP request multipart file uploads associated to session for given form field name to the fileCallback one by one, each limited to maxBytes unless maxBytes is not positive; the request body is consumed in the process, thus form values and request body should be loaded beforehand if needed
+	GetRequestFile(name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError
+
+	// BindRequest populates given data template struct from HTTP request associated to session upon the path, query, header and body tags of its fields and validates them upon their validate tags; all violations are returned in a single BadRequest error with messages by field names in its extra data
+	BindRequest(dataTemplate interface{}) apperrorModel.AppError
 }
 
 // SessionHTTPResponse is a subset of SessionHTTP interface, containing only HTTP response related methods
 type SessionHTTPResponse interface {
 	// GetResponseWriter returns the HTTP response writer object from session object for given session ID
 	GetResponseWriter() http.ResponseWriter
+
+	// SetResponseETag sets the entity tag (weak if specified) of the response for the session, against which the conditional request headers are evaluated upon response writing
+	SetResponseETag(etag string, weak bool)
+
+	// SetResponseLastModified sets the last modification time of the response for the session, against which the If-Modified-Since request header is evaluated upon response writing
+	SetResponseLastModified(lastModified time.Time)
 }
 
 // SessionAttachment is a subset of Session interface, containing only attachment related methods
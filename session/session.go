@@ -3,6 +3,7 @@ package session
 import (
 	"net/http"
 	"reflect"
+	"time"
 
 	"github.com/google/uuid"
 	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
@@ -11,11 +12,18 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
 	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
 	"github.com/zhongjie-cai/WebServiceTemplate/session/model"
 )
 
 const (
-	defaultName = "AppRoot"
+	defaultName               = "AppRoot"
+	maxCorrelationIDLength    = 128
+	minCorrelationIDCharacter = '!'
+	maxCorrelationIDCharacter = '~'
+	eTagHeader                = "ETag"
+	weakETagPrefix            = "W/"
+	lastModifiedHeader        = "Last-Modified"
 )
 
 var (
@@ -44,22 +52,66 @@ func isInterfaceValueNil(i interface{}) bool {
 	return !v.IsValid()
 }
 
+func isValidCorrelationID(correlationID string) bool {
+	if correlationID == "" ||
+		len(correlationID) > maxCorrelationIDLength {
+		return false
+	}
+	for _, character := range correlationID {
+		if character < minCorrelationIDCharacter ||
+			character > maxCorrelationIDCharacter {
+			return false
+		}
+	}
+	return true
+}
+
+func getCorrelationID(httpRequest *http.Request) string {
+	var headerName = headerutilGetCorrelationIDHeader()
+	if headerName == "" {
+		return ""
+	}
+	var correlationID = httpRequest.Header.Get(headerName)
+	if !isValidCorrelationIDFunc(correlationID) {
+		return ""
+	}
+	return correlationID
+}
+
+func getSessionID(correlationID string) uuid.UUID {
+	if correlationID == "" ||
+		customization.CorrelationIDAsSessionID == nil ||
+		!customization.CorrelationIDAsSessionID() {
+		return uuidNew()
+	}
+	var sessionID, parseError = uuidParse(correlationID)
+	if parseError != nil {
+		return uuidNew()
+	}
+	return sessionID
+}
+
 // Register registers the information of a session for given session ID
 func Register(
 	name string,
 	httpRequest *http.Request,
 	responseWriter http.ResponseWriter,
 ) model.Session {
-	var sessionID = uuidNew()
 	if httpRequest == nil {
 		httpRequest = defaultRequest
 	}
+	var correlationID = getCorrelationIDFunc(httpRequest)
+	var sessionID = getSessionIDFunc(correlationID)
+	if correlationID == "" {
+		correlationID = sessionID.String()
+	}
 	if isInterfaceValueNilFunc(responseWriter) {
 		responseWriter = defaultResponseWriter
 	}
 	var session = &session{
 		ID:             sessionID,
 		Name:           name,
+		CorrelationID:  correlationID,
 		Request:        httpRequest,
 		ResponseWriter: responseWriter,
 		attachment:     map[string]interface{}{},
@@ -72,6 +124,7 @@ func Register(
 type session struct {
 	ID              uuid.UUID
 	Name            string
+	CorrelationID   string
 	AllowedLogType  logtype.LogType
 	AllowedLogLevel loglevel.LogLevel
 	Request         *http.Request
@@ -95,6 +148,14 @@ func (session *session) GetName() string {
 	return session.Name
 }
 
+// GetCorrelationID returns the correlation ID adopted from the incoming request header, or the session ID if not available
+func (session *session) GetCorrelationID() string {
+	if session == nil {
+		return defaultSessionID.String()
+	}
+	return session.CorrelationID
+}
+
 // GetRequest returns the HTTP request object from session object for given session ID
 func (session *session) GetRequest() *http.Request {
 	if session == nil ||
@@ -113,7 +174,45 @@ func (session *session) GetResponseWriter() http.ResponseWriter {
 	return session.ResponseWriter
 }
 
-// GetRequestBody loads HTTP request body associated to session and unmarshals the content JSON to given data template
+func formatETag(etag string, weak bool) string {
+	if stringsHasPrefix(etag, weakETagPrefix) {
+		return etag
+	}
+	var entityTag = etag
+	if len(etag) < 2 ||
+		!stringsHasPrefix(etag, "\"") ||
+		!stringsHasSuffix(etag, "\"") {
+		entityTag = fmtSprintf(
+			"\"%v\"",
+			etag,
+		)
+	}
+	if weak {
+		entityTag = weakETagPrefix + entityTag
+	}
+	return entityTag
+}
+
+// SetResponseETag sets the entity tag (weak if specified) of the response for the session, against which the conditional request headers are evaluated upon response writing; the given value is quoted unless already quoted, and used as is if already prefixed by W/
+func (session *session) SetResponseETag(etag string, weak bool) {
+	session.GetResponseWriter().Header().Set(
+		eTagHeader,
+		formatETagFunc(
+			etag,
+			weak,
+		),
+	)
+}
+
+// SetResponseLastModified sets the last modification time of the response for the session, against which the If-Modified-Since request header is evaluated upon response writing
+func (session *session) SetResponseLastModified(lastModified time.Time) {
+	session.GetResponseWriter().Header().Set(
+		lastModifiedHeader,
+		lastModified.UTC().Format(http.TimeFormat),
+	)
+}
+
+// GetRequestBody loads HTTP request body associated to session and decodes the content to given data template with the decoder registered for its Content-Type header (JSON if absent)
 func (session *session) GetRequestBody(dataTemplate interface{}) apperrorModel.AppError {
 	var httpRequest = session.GetRequest()
 	var requestBody = requestGetRequestBody(
@@ -132,11 +231,10 @@ func (session *session) GetRequestBody(dataTemplate interface{}) apperrorModel.A
 		"",
 		requestBody,
 	)
-	return apperrorGetBadRequestError(
-		jsonutilTryUnmarshal(
-			requestBody,
-			dataTemplate,
-		),
+	return requestDecodeRequestBody(
+		httpRequest,
+		requestBody,
+		dataTemplate,
 	)
 }
 
@@ -310,6 +408,113 @@ func (session *session) GetRequestHeaders(name string, dataTemplate interface{},
 	)
 }
 
+func getAllFormValues(session *session, name string) []string {
+	var httpRequest = session.GetRequest()
+	var requestBody = requestGetRequestBody(
+		httpRequest,
+	)
+	var values, valuesError = requestGetFormValues(
+		httpRequest,
+		requestBody,
+	)
+	if valuesError != nil {
+		return nil
+	}
+	var formValues, found = values[name]
+	if !found {
+		return nil
+	}
+	return formValues
+}
+
+// GetRequestFormValue loads HTTP request single form field associated to session for given name from URL-encoded or multipart form body and unmarshals the content to given data template
+func (session *session) GetRequestFormValue(name string, dataTemplate interface{}) apperrorModel.AppError {
+	var formValues = getAllFormValuesFunc(
+		session,
+		name,
+	)
+	if len(formValues) == 0 {
+		return apperrorGetBadRequestError(
+			fmtErrorf(
+				"The expected form field [%v] is not found in request",
+				name,
+			),
+		)
+	}
+	var value = formValues[0]
+	loggerAPIRequest(
+		session,
+		"Form",
+		name,
+		value,
+	)
+	return apperrorGetBadRequestError(
+		jsonutilTryUnmarshal(
+			value,
+			dataTemplate,
+		),
+	)
+}
+
+// GetRequestFormValues loads HTTP request form fields associated to session for given name from URL-encoded or multipart form body and unmarshals the content to given data template; the fillCallback is called when each unmarshal operation succeeds, so consumer could fill in external arrays using data template during the process
+func (session *session) GetRequestFormValues(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	var formValues = getAllFormValuesFunc(
+		session,
+		name,
+	)
+	var unmarshalErrors = []error{}
+	for _, formValue := range formValues {
+		loggerAPIRequest(
+			session,
+			"Form",
+			name,
+			formValue,
+		)
+		var unmarshalError = jsonutilTryUnmarshal(
+			formValue,
+			dataTemplate,
+		)
+		if unmarshalError != nil {
+			unmarshalErrors = append(
+				unmarshalErrors,
+				unmarshalError,
+			)
+		} else {
+			fillCallback()
+		}
+	}
+	return apperrorGetBadRequestError(
+		unmarshalErrors...,
+	)
+}
+
+// GetRequestFile streams HTTP request multipart file uploads associated to session for given form field name to the fileCallback one by one, each limited to maxBytes unless maxBytes is not positive; the request body is consumed in the process, thus form values and request body should be loaded beforehand if needed
+func (session *session) GetRequestFile(name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+	var httpRequest = session.GetRequest()
+	return requestStreamFiles(
+		httpRequest,
+		name,
+		maxBytes,
+		func(file requestModel.File) error {
+			loggerAPIRequest(
+				session,
+				"File",
+				name,
+				file.FileName,
+			)
+			return fileCallback(file)
+		},
+	)
+}
+
+// BindRequest populates given data template struct from HTTP request associated to session upon the path, query, header and body tags of its fields and validates them upon their validate tags; all violations are returned in a single BadRequest error with messages by field names in its extra data
+func (session *session) BindRequest(dataTemplate interface{}) apperrorModel.AppError {
+	return bindingBind(
+		session,
+		dataTemplate,
+	)
+}
+
 // Attach attaches any value object into the given session associated to the session ID
 func (session *session) Attach(name string, value interface{}) bool {
 	if session == nil {
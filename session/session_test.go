@@ -7,11 +7,14 @@ import (
 	"math"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"runtime"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -23,6 +26,7 @@ import (
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
 	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
 	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
 	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
 )
 
@@ -155,6 +159,313 @@ func TestIsInterfaceValueNil_ValidValue(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestIsValidCorrelationID_Empty(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isValidCorrelationID("")
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsValidCorrelationID_TooLong(t *testing.T) {
+	// arrange
+	var dummyCorrelationID = strings.Repeat("a", maxCorrelationIDLength+1)
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isValidCorrelationID(dummyCorrelationID)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsValidCorrelationID_InvalidCharacter(t *testing.T) {
+	// arrange
+	var dummyCorrelationID = "some correlation\r\nID"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isValidCorrelationID(dummyCorrelationID)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsValidCorrelationID_Valid(t *testing.T) {
+	// arrange
+	var dummyCorrelationID = uuid.New().String()
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isValidCorrelationID(dummyCorrelationID)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCorrelationID_NoHeaderName(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	headerutilGetCorrelationIDHeaderExpected = 1
+	headerutilGetCorrelationIDHeader = func() string {
+		headerutilGetCorrelationIDHeaderCalled++
+		return ""
+	}
+
+	// SUT + act
+	var result = getCorrelationID(dummyHTTPRequest)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCorrelationID_InvalidValue(t *testing.T) {
+	// arrange
+	var dummyHeaderName = "X-Correlation-ID"
+	var dummyValue = "some value"
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+
+	// stub
+	dummyHTTPRequest.Header.Set(dummyHeaderName, dummyValue)
+
+	// mock
+	createMock(t)
+
+	// expect
+	headerutilGetCorrelationIDHeaderExpected = 1
+	headerutilGetCorrelationIDHeader = func() string {
+		headerutilGetCorrelationIDHeaderCalled++
+		return dummyHeaderName
+	}
+	isValidCorrelationIDFuncExpected = 1
+	isValidCorrelationIDFunc = func(correlationID string) bool {
+		isValidCorrelationIDFuncCalled++
+		assert.Equal(t, dummyValue, correlationID)
+		return false
+	}
+
+	// SUT + act
+	var result = getCorrelationID(dummyHTTPRequest)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetCorrelationID_ValidValue(t *testing.T) {
+	// arrange
+	var dummyHeaderName = "X-Correlation-ID"
+	var dummyValue = "some value"
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+
+	// stub
+	dummyHTTPRequest.Header.Set(dummyHeaderName, dummyValue)
+
+	// mock
+	createMock(t)
+
+	// expect
+	headerutilGetCorrelationIDHeaderExpected = 1
+	headerutilGetCorrelationIDHeader = func() string {
+		headerutilGetCorrelationIDHeaderCalled++
+		return dummyHeaderName
+	}
+	isValidCorrelationIDFuncExpected = 1
+	isValidCorrelationIDFunc = func(correlationID string) bool {
+		isValidCorrelationIDFuncCalled++
+		assert.Equal(t, dummyValue, correlationID)
+		return true
+	}
+
+	// SUT + act
+	var result = getCorrelationID(dummyHTTPRequest)
+
+	// assert
+	assert.Equal(t, dummyValue, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSessionID_NoCorrelationID(t *testing.T) {
+	// arrange
+	var dummySessionID = uuid.New()
+
+	// mock
+	createMock(t)
+
+	// expect
+	uuidNewExpected = 1
+	uuidNew = func() uuid.UUID {
+		uuidNewCalled++
+		return dummySessionID
+	}
+
+	// SUT + act
+	var result = getSessionID("")
+
+	// assert
+	assert.Equal(t, dummySessionID, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSessionID_NoCustomization(t *testing.T) {
+	// arrange
+	var dummySessionID = uuid.New()
+
+	// mock
+	createMock(t)
+
+	// expect
+	uuidNewExpected = 1
+	uuidNew = func() uuid.UUID {
+		uuidNewCalled++
+		return dummySessionID
+	}
+
+	// SUT + act
+	var result = getSessionID("some correlation ID")
+
+	// assert
+	assert.Equal(t, dummySessionID, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSessionID_CustomizationDisabled(t *testing.T) {
+	// arrange
+	var dummySessionID = uuid.New()
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationCorrelationIDAsSessionIDExpected = 1
+	customization.CorrelationIDAsSessionID = func() bool {
+		customizationCorrelationIDAsSessionIDCalled++
+		return false
+	}
+	uuidNewExpected = 1
+	uuidNew = func() uuid.UUID {
+		uuidNewCalled++
+		return dummySessionID
+	}
+
+	// SUT + act
+	var result = getSessionID("some correlation ID")
+
+	// assert
+	assert.Equal(t, dummySessionID, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSessionID_ParseError(t *testing.T) {
+	// arrange
+	var dummyCorrelationID = "some correlation ID"
+	var dummySessionID = uuid.New()
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationCorrelationIDAsSessionIDExpected = 1
+	customization.CorrelationIDAsSessionID = func() bool {
+		customizationCorrelationIDAsSessionIDCalled++
+		return true
+	}
+	uuidParseExpected = 1
+	uuidParse = func(s string) (uuid.UUID, error) {
+		uuidParseCalled++
+		assert.Equal(t, dummyCorrelationID, s)
+		return uuid.Nil, errors.New("some error")
+	}
+	uuidNewExpected = 1
+	uuidNew = func() uuid.UUID {
+		uuidNewCalled++
+		return dummySessionID
+	}
+
+	// SUT + act
+	var result = getSessionID(dummyCorrelationID)
+
+	// assert
+	assert.Equal(t, dummySessionID, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSessionID_Adopted(t *testing.T) {
+	// arrange
+	var dummyCorrelationID = "some correlation ID"
+	var dummySessionID = uuid.New()
+
+	// mock
+	createMock(t)
+
+	// expect
+	customizationCorrelationIDAsSessionIDExpected = 1
+	customization.CorrelationIDAsSessionID = func() bool {
+		customizationCorrelationIDAsSessionIDCalled++
+		return true
+	}
+	uuidParseExpected = 1
+	uuidParse = func(s string) (uuid.UUID, error) {
+		uuidParseCalled++
+		assert.Equal(t, dummyCorrelationID, s)
+		return dummySessionID, nil
+	}
+
+	// SUT + act
+	var result = getSessionID(dummyCorrelationID)
+
+	// assert
+	assert.Equal(t, dummySessionID, result)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestRegister_InvalidValues(t *testing.T) {
 	// arrange
 	var dummySessionID = uuid.New()
@@ -168,9 +479,16 @@ func TestRegister_InvalidValues(t *testing.T) {
 	createMock(t)
 
 	// expect
-	uuidNewExpected = 1
-	uuidNew = func() uuid.UUID {
-		uuidNewCalled++
+	getCorrelationIDFuncExpected = 1
+	getCorrelationIDFunc = func(httpRequest *http.Request) string {
+		getCorrelationIDFuncCalled++
+		assert.Equal(t, defaultRequest, httpRequest)
+		return ""
+	}
+	getSessionIDFuncExpected = 1
+	getSessionIDFunc = func(correlationID string) uuid.UUID {
+		getSessionIDFuncCalled++
+		assert.Empty(t, correlationID)
 		return dummySessionID
 	}
 	isInterfaceValueNilFuncExpected = 1
@@ -206,6 +524,7 @@ func TestRegister_InvalidValues(t *testing.T) {
 	assert.True(t, typeOK)
 	assert.Equal(t, dummySessionID, session.ID)
 	assert.Equal(t, dummyName, session.Name)
+	assert.Equal(t, dummySessionID.String(), session.CorrelationID)
 	assert.Equal(t, dummyAllowedLogType, session.AllowedLogType)
 	assert.Equal(t, dummyAllowedLogLevel, session.AllowedLogLevel)
 	assert.Equal(t, defaultRequest, session.Request)
@@ -223,14 +542,22 @@ func TestRegister_ValidValues(t *testing.T) {
 	var dummyAllowedLogLevel = loglevel.LogLevel(rand.Intn(math.MaxInt8))
 	var dummyHTTPRequest = &http.Request{}
 	var dummyResponseWriterObject = &dummyResponseWriter{}
+	var dummyCorrelationID = "some correlation ID"
 
 	// mock
 	createMock(t)
 
 	// expect
-	uuidNewExpected = 1
-	uuidNew = func() uuid.UUID {
-		uuidNewCalled++
+	getCorrelationIDFuncExpected = 1
+	getCorrelationIDFunc = func(httpRequest *http.Request) string {
+		getCorrelationIDFuncCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return dummyCorrelationID
+	}
+	getSessionIDFuncExpected = 1
+	getSessionIDFunc = func(correlationID string) uuid.UUID {
+		getSessionIDFuncCalled++
+		assert.Equal(t, dummyCorrelationID, correlationID)
 		return dummySessionID
 	}
 	isInterfaceValueNilFuncExpected = 1
@@ -266,6 +593,7 @@ func TestRegister_ValidValues(t *testing.T) {
 	assert.True(t, typeOK)
 	assert.Equal(t, dummySessionID, session.ID)
 	assert.Equal(t, dummyName, session.Name)
+	assert.Equal(t, dummyCorrelationID, session.CorrelationID)
 	assert.Equal(t, dummyAllowedLogType, session.AllowedLogType)
 	assert.Equal(t, dummyAllowedLogLevel, session.AllowedLogLevel)
 	assert.Equal(t, dummyHTTPRequest, session.Request)
@@ -353,7 +681,7 @@ func TestGetName_ValidSessionObject(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestGetRequest_NilSessionObject(t *testing.T) {
+func TestGetCorrelationID_NilSessionObject(t *testing.T) {
 	// mock
 	createMock(t)
 
@@ -361,44 +689,83 @@ func TestGetRequest_NilSessionObject(t *testing.T) {
 	var dummySessionObject *session
 
 	// act
-	var result = dummySessionObject.GetRequest()
+	var result = dummySessionObject.GetCorrelationID()
 
 	// assert
-	assert.Equal(t, defaultRequest, result)
+	assert.Equal(t, defaultSessionID.String(), result)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestGetRequest_NilRequest(t *testing.T) {
+func TestGetCorrelationID_ValidSessionObject(t *testing.T) {
 	// arrange
-	var dummyHTTPRequest *http.Request
+	var dummyCorrelationID = "some correlation ID"
 
 	// mock
 	createMock(t)
 
 	// SUT
 	var dummySessionObject = &session{
-		Request: dummyHTTPRequest,
+		CorrelationID: dummyCorrelationID,
 	}
 
 	// act
-	var result = dummySessionObject.GetRequest()
+	var result = dummySessionObject.GetCorrelationID()
 
 	// assert
-	assert.Equal(t, defaultRequest, result)
+	assert.Equal(t, dummyCorrelationID, result)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestGetRequest_ValidRequest(t *testing.T) {
-	// arrange
-	var dummyHTTPRequest = &http.Request{
-		Method:     http.MethodGet,
-		RequestURI: "http://localhost/",
-		Header:     map[string][]string{},
-	}
+func TestGetRequest_NilSessionObject(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT
+	var dummySessionObject *session
+
+	// act
+	var result = dummySessionObject.GetRequest()
+
+	// assert
+	assert.Equal(t, defaultRequest, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequest_NilRequest(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest *http.Request
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var dummySessionObject = &session{
+		Request: dummyHTTPRequest,
+	}
+
+	// act
+	var result = dummySessionObject.GetRequest()
+
+	// assert
+	assert.Equal(t, defaultRequest, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequest_ValidRequest(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Method:     http.MethodGet,
+		RequestURI: "http://localhost/",
+		Header:     map[string][]string{},
+	}
 
 	// mock
 	createMock(t)
@@ -495,6 +862,311 @@ func TestGetResponseWriter_ValidResponseWriter(t *testing.T) {
 	verifyAll(t)
 }
 
+func TestFormatETag_Strong(t *testing.T) {
+	// arrange
+	var dummyETag = "some etag"
+	var dummyWeak = false
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsHasPrefixExpected = 2
+	stringsHasPrefix = func(s string, prefix string) bool {
+		stringsHasPrefixCalled++
+		return strings.HasPrefix(s, prefix)
+	}
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		return fmt.Sprintf(format, a...)
+	}
+
+	// SUT + act
+	var result = formatETag(
+		dummyETag,
+		dummyWeak,
+	)
+
+	// assert
+	assert.Equal(t, "\"some etag\"", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatETag_Weak(t *testing.T) {
+	// arrange
+	var dummyETag = "some etag"
+	var dummyWeak = true
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsHasPrefixExpected = 2
+	stringsHasPrefix = func(s string, prefix string) bool {
+		stringsHasPrefixCalled++
+		return strings.HasPrefix(s, prefix)
+	}
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		return fmt.Sprintf(format, a...)
+	}
+
+	// SUT + act
+	var result = formatETag(
+		dummyETag,
+		dummyWeak,
+	)
+
+	// assert
+	assert.Equal(t, "W/\"some etag\"", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatETag_AlreadyQuoted(t *testing.T) {
+	// arrange
+	var dummyETag = "\"some etag\""
+	var dummyWeak = false
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsHasPrefixExpected = 2
+	stringsHasPrefix = func(s string, prefix string) bool {
+		stringsHasPrefixCalled++
+		return strings.HasPrefix(s, prefix)
+	}
+	stringsHasSuffixExpected = 1
+	stringsHasSuffix = func(s string, suffix string) bool {
+		stringsHasSuffixCalled++
+		return strings.HasSuffix(s, suffix)
+	}
+
+	// SUT + act
+	var result = formatETag(
+		dummyETag,
+		dummyWeak,
+	)
+
+	// assert
+	assert.Equal(t, "\"some etag\"", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatETag_AlreadyQuoted_Weak(t *testing.T) {
+	// arrange
+	var dummyETag = "\"some etag\""
+	var dummyWeak = true
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsHasPrefixExpected = 2
+	stringsHasPrefix = func(s string, prefix string) bool {
+		stringsHasPrefixCalled++
+		return strings.HasPrefix(s, prefix)
+	}
+	stringsHasSuffixExpected = 1
+	stringsHasSuffix = func(s string, suffix string) bool {
+		stringsHasSuffixCalled++
+		return strings.HasSuffix(s, suffix)
+	}
+
+	// SUT + act
+	var result = formatETag(
+		dummyETag,
+		dummyWeak,
+	)
+
+	// assert
+	assert.Equal(t, "W/\"some etag\"", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatETag_PartiallyQuoted(t *testing.T) {
+	// arrange
+	var dummyETag = "\"some etag"
+	var dummyWeak = false
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsHasPrefixExpected = 2
+	stringsHasPrefix = func(s string, prefix string) bool {
+		stringsHasPrefixCalled++
+		return strings.HasPrefix(s, prefix)
+	}
+	stringsHasSuffixExpected = 1
+	stringsHasSuffix = func(s string, suffix string) bool {
+		stringsHasSuffixCalled++
+		return strings.HasSuffix(s, suffix)
+	}
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		return fmt.Sprintf(format, a...)
+	}
+
+	// SUT + act
+	var result = formatETag(
+		dummyETag,
+		dummyWeak,
+	)
+
+	// assert
+	assert.Equal(t, "\"\"some etag\"", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatETag_SingleQuote(t *testing.T) {
+	// arrange
+	var dummyETag = "\""
+	var dummyWeak = false
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsHasPrefixExpected = 1
+	stringsHasPrefix = func(s string, prefix string) bool {
+		stringsHasPrefixCalled++
+		return strings.HasPrefix(s, prefix)
+	}
+	fmtSprintfExpected = 1
+	fmtSprintf = func(format string, a ...interface{}) string {
+		fmtSprintfCalled++
+		return fmt.Sprintf(format, a...)
+	}
+
+	// SUT + act
+	var result = formatETag(
+		dummyETag,
+		dummyWeak,
+	)
+
+	// assert
+	assert.Equal(t, "\"\"\"", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatETag_AlreadyWeak(t *testing.T) {
+	// arrange
+	var dummyETag = "W/\"some etag\""
+	var dummyWeak = false
+
+	// mock
+	createMock(t)
+
+	// expect
+	stringsHasPrefixExpected = 1
+	stringsHasPrefix = func(s string, prefix string) bool {
+		stringsHasPrefixCalled++
+		return strings.HasPrefix(s, prefix)
+	}
+
+	// SUT + act
+	var result = formatETag(
+		dummyETag,
+		dummyWeak,
+	)
+
+	// assert
+	assert.Equal(t, "W/\"some etag\"", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetResponseETag(t *testing.T) {
+	// arrange
+	var dummyETag = "some etag"
+	var dummyWeak = rand.Intn(100) < 50
+	var dummyEntityTag = "some entity tag"
+	var dummyResponseWriterObject = httptest.NewRecorder()
+
+	// mock
+	createMock(t)
+
+	// expect
+	isInterfaceValueNilFuncExpected = 1
+	isInterfaceValueNilFunc = func(i interface{}) bool {
+		isInterfaceValueNilFuncCalled++
+		return false
+	}
+	formatETagFuncExpected = 1
+	formatETagFunc = func(etag string, weak bool) string {
+		formatETagFuncCalled++
+		assert.Equal(t, dummyETag, etag)
+		assert.Equal(t, dummyWeak, weak)
+		return dummyEntityTag
+	}
+
+	// SUT
+	var dummySessionObject = &session{
+		ResponseWriter: dummyResponseWriterObject,
+	}
+
+	// act
+	dummySessionObject.SetResponseETag(
+		dummyETag,
+		dummyWeak,
+	)
+
+	// assert
+	assert.Equal(t, dummyEntityTag, dummyResponseWriterObject.Header().Get("ETag"))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetResponseLastModified(t *testing.T) {
+	// arrange
+	var dummyLastModified = time.Date(2020, 1, 2, 3, 4, 5, 6, time.FixedZone("some zone", 3600))
+	var dummyResponseWriterObject = httptest.NewRecorder()
+
+	// mock
+	createMock(t)
+
+	// expect
+	isInterfaceValueNilFuncExpected = 1
+	isInterfaceValueNilFunc = func(i interface{}) bool {
+		isInterfaceValueNilFuncCalled++
+		return false
+	}
+
+	// SUT
+	var dummySessionObject = &session{
+		ResponseWriter: dummyResponseWriterObject,
+	}
+
+	// act
+	dummySessionObject.SetResponseLastModified(
+		dummyLastModified,
+	)
+
+	// assert
+	assert.Equal(t, "Thu, 02 Jan 2020 02:04:05 GMT", dummyResponseWriterObject.Header().Get("Last-Modified"))
+
+	// verify
+	verifyAll(t)
+}
+
 func TestGetRequestBody_EmptyBody(t *testing.T) {
 	// arrange
 	var dummyDataTemplate int
@@ -534,7 +1206,298 @@ func TestGetRequestBody_EmptyBody(t *testing.T) {
 	}
 
 	// act
-	var err = dummySessionObject.GetRequestBody(
+	var err = dummySessionObject.GetRequestBody(
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+	assert.Zero(t, dummyDataTemplate)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestBody_ValidBody(t *testing.T) {
+	// arrange
+	var dummyDataTemplate int
+	var dummyHTTPRequest = &http.Request{}
+	var dummyRequestBody = "some request body"
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+	var dummyResult = rand.Int()
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var dummySessionObject = &session{
+		Request: dummyHTTPRequest,
+	}
+
+	// expect
+	requestGetRequestBodyExpected = 1
+	requestGetRequestBody = func(httpRequest *http.Request) string {
+		requestGetRequestBodyCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return dummyRequestBody
+	}
+	loggerAPIRequestExpected = 1
+	loggerAPIRequest = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAPIRequestCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, "Body", category)
+		assert.Zero(t, subcategory)
+		assert.Equal(t, dummyRequestBody, messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+	requestDecodeRequestBodyExpected = 1
+	requestDecodeRequestBody = func(httpRequest *http.Request, body string, dataTemplate interface{}) apperrorModel.AppError {
+		requestDecodeRequestBodyCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyRequestBody, body)
+		*(dataTemplate.(*int)) = dummyResult
+		return dummyAppError
+	}
+
+	// act
+	var err = dummySessionObject.GetRequestBody(
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+	assert.Equal(t, dummyResult, dummyDataTemplate)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestParameter_ValueNotFound(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyDataTemplate int
+	var dummyHTTPRequest = &http.Request{}
+	var dummyParameters = map[string]string{
+		"foo":  "bar",
+		"test": "123",
+	}
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var dummySessionObject = &session{
+		Request: dummyHTTPRequest,
+	}
+
+	// expect
+	muxVarsExpected = 1
+	muxVars = func(r *http.Request) map[string]string {
+		muxVarsCalled++
+		assert.Equal(t, dummyHTTPRequest, r)
+		return dummyParameters
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "The expected parameter [%v] is not found in request", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyName, a[0])
+		return dummyError
+	}
+	apperrorGetBadRequestErrorExpected = 1
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// act
+	var err = dummySessionObject.GetRequestParameter(
+		dummyName,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestParameter_HappyPath(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyValue = "some value"
+	var dummyDataTemplate int
+	var dummyHTTPRequest = &http.Request{}
+	var dummyParameters = map[string]string{
+		"foo":     "bar",
+		"test":    "123",
+		dummyName: dummyValue,
+	}
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+	var dummyResult = rand.Int()
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var dummySessionObject = &session{
+		Request: dummyHTTPRequest,
+	}
+
+	// expect
+	muxVarsExpected = 1
+	muxVars = func(r *http.Request) map[string]string {
+		muxVarsCalled++
+		assert.Equal(t, dummyHTTPRequest, r)
+		return dummyParameters
+	}
+	loggerAPIRequestExpected = 1
+	loggerAPIRequest = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAPIRequestCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, "Parameter", category)
+		assert.Equal(t, dummyName, subcategory)
+		assert.Equal(t, dummyValue, messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+	jsonutilTryUnmarshalExpected = 1
+	jsonutilTryUnmarshal = func(value string, dataTemplate interface{}) error {
+		jsonutilTryUnmarshalCalled++
+		assert.Equal(t, dummyValue, value)
+		*(dataTemplate.(*int)) = dummyResult
+		return dummyError
+	}
+	apperrorGetBadRequestErrorExpected = 1
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// act
+	var err = dummySessionObject.GetRequestParameter(
+		dummyName,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+	assert.Equal(t, dummyResult, dummyDataTemplate)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetAllQueries_NotFound(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyHTTPRequest = &http.Request{
+		URL: &url.URL{
+			RawQuery: "test=me&test=you",
+		},
+	}
+	var dummySessionObject = &session{
+		Request: dummyHTTPRequest,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getAllQueries(
+		dummySessionObject,
+		dummyName,
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetAllQueries_HappyPath(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyHTTPRequest = &http.Request{
+		URL: &url.URL{
+			RawQuery: dummyName + "=me&" + dummyName + "=you",
+		},
+	}
+	var dummySessionObject = &session{
+		Request: dummyHTTPRequest,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = getAllQueries(
+		dummySessionObject,
+		dummyName,
+	)
+
+	// assert
+	assert.Equal(t, 2, len(result))
+	assert.Equal(t, "me", result[0])
+	assert.Equal(t, "you", result[1])
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetRequestQuery_EmptyList(t *testing.T) {
+	// arrange
+	var dummySessionID = uuid.New()
+	var dummyName = "some name"
+	var dummyDataTemplate int
+	var dummyQueries []string
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var dummySessionObject = &session{
+		ID: dummySessionID,
+	}
+
+	// expect
+	getAllQueriesFuncExpected = 1
+	getAllQueriesFunc = func(session *session, name string) []string {
+		getAllQueriesFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyName, name)
+		return dummyQueries
+	}
+	fmtErrorfExpected = 1
+	fmtErrorf = func(format string, a ...interface{}) error {
+		fmtErrorfCalled++
+		assert.Equal(t, "The expected query string [%v] is not found in request", format)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyName, a[0])
+		return dummyError
+	}
+	apperrorGetBadRequestErrorExpected = 1
+	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
+		apperrorGetBadRequestErrorCalled++
+		assert.Equal(t, 1, len(innerErrors))
+		assert.Equal(t, dummyError, innerErrors[0])
+		return dummyAppError
+	}
+
+	// act
+	var err = dummySessionObject.GetRequestQuery(
+		dummyName,
 		&dummyDataTemplate,
 	)
 
@@ -546,11 +1509,16 @@ func TestGetRequestBody_EmptyBody(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestGetRequestBody_ValidBody(t *testing.T) {
+func TestGetRequestQuery_HappyPath(t *testing.T) {
 	// arrange
+	var dummySessionID = uuid.New()
+	var dummyName = "some name"
 	var dummyDataTemplate int
-	var dummyHTTPRequest = &http.Request{}
-	var dummyRequestBody = "some request body"
+	var dummyQueries = []string{
+		"some query string 1",
+		"some query string 2",
+		"some query string 3",
+	}
 	var dummyError = errors.New("some error")
 	var dummyAppError = apperror.GetCustomError(0, "some app error")
 	var dummyResult = rand.Int()
@@ -560,29 +1528,30 @@ func TestGetRequestBody_ValidBody(t *testing.T) {
 
 	// SUT
 	var dummySessionObject = &session{
-		Request: dummyHTTPRequest,
+		ID: dummySessionID,
 	}
 
 	// expect
-	requestGetRequestBodyExpected = 1
-	requestGetRequestBody = func(httpRequest *http.Request) string {
-		requestGetRequestBodyCalled++
-		assert.Equal(t, dummyHTTPRequest, httpRequest)
-		return dummyRequestBody
+	getAllQueriesFuncExpected = 1
+	getAllQueriesFunc = func(session *session, name string) []string {
+		getAllQueriesFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyName, name)
+		return dummyQueries
 	}
 	loggerAPIRequestExpected = 1
 	loggerAPIRequest = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAPIRequestCalled++
 		assert.Equal(t, dummySessionObject, session)
-		assert.Equal(t, "Body", category)
-		assert.Zero(t, subcategory)
-		assert.Equal(t, dummyRequestBody, messageFormat)
+		assert.Equal(t, "Query", category)
+		assert.Equal(t, dummyName, subcategory)
+		assert.Equal(t, dummyQueries[0], messageFormat)
 		assert.Equal(t, 0, len(parameters))
 	}
 	jsonutilTryUnmarshalExpected = 1
 	jsonutilTryUnmarshal = func(value string, dataTemplate interface{}) error {
 		jsonutilTryUnmarshalCalled++
-		assert.Equal(t, dummyRequestBody, value)
+		assert.Equal(t, dummyQueries[0], value)
 		*(dataTemplate.(*int)) = dummyResult
 		return dummyError
 	}
@@ -595,7 +1564,8 @@ func TestGetRequestBody_ValidBody(t *testing.T) {
 	}
 
 	// act
-	var err = dummySessionObject.GetRequestBody(
+	var err = dummySessionObject.GetRequestQuery(
+		dummyName,
 		&dummyDataTemplate,
 	)
 
@@ -607,16 +1577,15 @@ func TestGetRequestBody_ValidBody(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestGetRequestParameter_ValueNotFound(t *testing.T) {
+func TestGetRequestQueries_EmptyList(t *testing.T) {
 	// arrange
+	var dummySessionID = uuid.New()
 	var dummyName = "some name"
 	var dummyDataTemplate int
-	var dummyHTTPRequest = &http.Request{}
-	var dummyParameters = map[string]string{
-		"foo":  "bar",
-		"test": "123",
-	}
-	var dummyError = errors.New("some error")
+	var dummyQueries []string
+	var dummyFillCallbackExpected int
+	var dummyFillCallbackCalled int
+	var dummyFillCallback func()
 	var dummyAppError = apperror.GetCustomError(0, "some app error")
 
 	// mock
@@ -624,57 +1593,62 @@ func TestGetRequestParameter_ValueNotFound(t *testing.T) {
 
 	// SUT
 	var dummySessionObject = &session{
-		Request: dummyHTTPRequest,
+		ID: dummySessionID,
 	}
 
 	// expect
-	muxVarsExpected = 1
-	muxVars = func(r *http.Request) map[string]string {
-		muxVarsCalled++
-		assert.Equal(t, dummyHTTPRequest, r)
-		return dummyParameters
+	getAllQueriesFuncExpected = 1
+	getAllQueriesFunc = func(session *session, name string) []string {
+		getAllQueriesFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyName, name)
+		return dummyQueries
 	}
-	fmtErrorfExpected = 1
-	fmtErrorf = func(format string, a ...interface{}) error {
-		fmtErrorfCalled++
-		assert.Equal(t, "The expected parameter [%v] is not found in request", format)
-		assert.Equal(t, 1, len(a))
-		assert.Equal(t, dummyName, a[0])
-		return dummyError
+	dummyFillCallbackExpected = 0
+	dummyFillCallback = func() {
+		dummyFillCallbackCalled++
 	}
 	apperrorGetBadRequestErrorExpected = 1
 	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
 		apperrorGetBadRequestErrorCalled++
-		assert.Equal(t, 1, len(innerErrors))
-		assert.Equal(t, dummyError, innerErrors[0])
+		assert.Equal(t, 0, len(innerErrors))
 		return dummyAppError
 	}
 
 	// act
-	var err = dummySessionObject.GetRequestParameter(
+	var err = dummySessionObject.GetRequestQueries(
 		dummyName,
 		&dummyDataTemplate,
+		dummyFillCallback,
 	)
 
 	// assert
 	assert.Equal(t, dummyAppError, err)
+	assert.Zero(t, dummyDataTemplate)
 
 	// verify
 	verifyAll(t)
+	assert.Equal(t, dummyFillCallbackExpected, dummyFillCallbackCalled, "Unexpected number of calls to dummyFillCallback")
 }
 
-func TestGetRequestParameter_HappyPath(t *testing.T) {
+func TestGetRequestQueries_HappyPath(t *testing.T) {
 	// arrange
+	var dummySessionID = uuid.New()
 	var dummyName = "some name"
-	var dummyValue = "some value"
 	var dummyDataTemplate int
-	var dummyHTTPRequest = &http.Request{}
-	var dummyParameters = map[string]string{
-		"foo":     "bar",
-		"test":    "123",
-		dummyName: dummyValue,
+	var dummyQueries = []string{
+		"some query string 1",
+		"some query string 2",
+		"some query string 3",
+	}
+	var dummyFillCallbackExpected int
+	var dummyFillCallbackCalled int
+	var dummyFillCallback func()
+	var unmarshalErrors = []error{
+		nil,
+		errors.New("some error"),
+		nil,
 	}
-	var dummyError = errors.New("some error")
 	var dummyAppError = apperror.GetCustomError(0, "some app error")
 	var dummyResult = rand.Int()
 
@@ -683,44 +1657,50 @@ func TestGetRequestParameter_HappyPath(t *testing.T) {
 
 	// SUT
 	var dummySessionObject = &session{
-		Request: dummyHTTPRequest,
+		ID: dummySessionID,
 	}
 
 	// expect
-	muxVarsExpected = 1
-	muxVars = func(r *http.Request) map[string]string {
-		muxVarsCalled++
-		assert.Equal(t, dummyHTTPRequest, r)
-		return dummyParameters
+	getAllQueriesFuncExpected = 1
+	getAllQueriesFunc = func(session *session, name string) []string {
+		getAllQueriesFuncCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyName, name)
+		return dummyQueries
 	}
-	loggerAPIRequestExpected = 1
+	loggerAPIRequestExpected = 3
 	loggerAPIRequest = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAPIRequestCalled++
 		assert.Equal(t, dummySessionObject, session)
-		assert.Equal(t, "Parameter", category)
+		assert.Equal(t, "Query", category)
 		assert.Equal(t, dummyName, subcategory)
-		assert.Equal(t, dummyValue, messageFormat)
+		assert.Contains(t, dummyQueries, messageFormat)
 		assert.Equal(t, 0, len(parameters))
 	}
-	jsonutilTryUnmarshalExpected = 1
+	jsonutilTryUnmarshalExpected = 3
 	jsonutilTryUnmarshal = func(value string, dataTemplate interface{}) error {
 		jsonutilTryUnmarshalCalled++
-		assert.Equal(t, dummyValue, value)
+		assert.Equal(t, dummyQueries[jsonutilTryUnmarshalCalled-1], value)
 		*(dataTemplate.(*int)) = dummyResult
-		return dummyError
+		return unmarshalErrors[jsonutilTryUnmarshalCalled-1]
+	}
+	dummyFillCallbackExpected = 2
+	dummyFillCallback = func() {
+		dummyFillCallbackCalled++
 	}
 	apperrorGetBadRequestErrorExpected = 1
 	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
 		apperrorGetBadRequestErrorCalled++
 		assert.Equal(t, 1, len(innerErrors))
-		assert.Equal(t, dummyError, innerErrors[0])
+		assert.Equal(t, unmarshalErrors[1], innerErrors[0])
 		return dummyAppError
 	}
 
 	// act
-	var err = dummySessionObject.GetRequestParameter(
+	var err = dummySessionObject.GetRequestQueries(
 		dummyName,
 		&dummyDataTemplate,
+		dummyFillCallback,
 	)
 
 	// assert
@@ -729,25 +1709,37 @@ func TestGetRequestParameter_HappyPath(t *testing.T) {
 
 	// verify
 	verifyAll(t)
+	assert.Equal(t, dummyFillCallbackExpected, dummyFillCallbackCalled, "Unexpected number of calls to dummyFillCallback")
 }
 
-func TestGetAllQueries_NotFound(t *testing.T) {
+func TestGetAllHeaders_NotFound(t *testing.T) {
 	// arrange
 	var dummyName = "some name"
+	var dummyCanonicalName = "some canonical name"
 	var dummyHTTPRequest = &http.Request{
-		URL: &url.URL{
-			RawQuery: "test=me&test=you",
-		},
+		Header: http.Header{},
 	}
 	var dummySessionObject = &session{
 		Request: dummyHTTPRequest,
 	}
 
+	// stub
+	dummyHTTPRequest.Header.Add("test", "me")
+	dummyHTTPRequest.Header.Add("test", "you")
+
 	// mock
 	createMock(t)
 
+	// expect
+	textprotoCanonicalMIMEHeaderKeyExpected = 1
+	textprotoCanonicalMIMEHeaderKey = func(s string) string {
+		textprotoCanonicalMIMEHeaderKeyCalled++
+		assert.Equal(t, dummyName, s)
+		return dummyCanonicalName
+	}
+
 	// SUT + act
-	var result = getAllQueries(
+	var result = getAllHeaders(
 		dummySessionObject,
 		dummyName,
 	)
@@ -759,23 +1751,34 @@ func TestGetAllQueries_NotFound(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestGetAllQueries_HappyPath(t *testing.T) {
+func TestGetAllHeaders_HappyPath(t *testing.T) {
 	// arrange
 	var dummyName = "some name"
+	var dummyCanonicalName = "some canonical name"
 	var dummyHTTPRequest = &http.Request{
-		URL: &url.URL{
-			RawQuery: dummyName + "=me&" + dummyName + "=you",
-		},
+		Header: http.Header{},
 	}
 	var dummySessionObject = &session{
 		Request: dummyHTTPRequest,
 	}
 
+	// stub
+	dummyHTTPRequest.Header.Add(dummyCanonicalName, "me")
+	dummyHTTPRequest.Header.Add(dummyCanonicalName, "you")
+
 	// mock
 	createMock(t)
 
+	// expect
+	textprotoCanonicalMIMEHeaderKeyExpected = 1
+	textprotoCanonicalMIMEHeaderKey = func(s string) string {
+		textprotoCanonicalMIMEHeaderKeyCalled++
+		assert.Equal(t, dummyName, s)
+		return dummyCanonicalName
+	}
+
 	// SUT + act
-	var result = getAllQueries(
+	var result = getAllHeaders(
 		dummySessionObject,
 		dummyName,
 	)
@@ -789,12 +1792,12 @@ func TestGetAllQueries_HappyPath(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestGetRequestQuery_EmptyList(t *testing.T) {
+func TestGetRequestHeader_EmptyList(t *testing.T) {
 	// arrange
 	var dummySessionID = uuid.New()
 	var dummyName = "some name"
 	var dummyDataTemplate int
-	var dummyQueries []string
+	var dummyHeaders []string
 	var dummyError = errors.New("some error")
 	var dummyAppError = apperror.GetCustomError(0, "some app error")
 
@@ -807,17 +1810,17 @@ func TestGetRequestQuery_EmptyList(t *testing.T) {
 	}
 
 	// expect
-	getAllQueriesFuncExpected = 1
-	getAllQueriesFunc = func(session *session, name string) []string {
-		getAllQueriesFuncCalled++
+	getAllHeadersFuncExpected = 1
+	getAllHeadersFunc = func(session *session, name string) []string {
+		getAllHeadersFuncCalled++
 		assert.Equal(t, dummySessionObject, session)
 		assert.Equal(t, dummyName, name)
-		return dummyQueries
+		return dummyHeaders
 	}
 	fmtErrorfExpected = 1
 	fmtErrorf = func(format string, a ...interface{}) error {
 		fmtErrorfCalled++
-		assert.Equal(t, "The expected query string [%v] is not found in request", format)
+		assert.Equal(t, "The expected header string [%v] is not found in request", format)
 		assert.Equal(t, 1, len(a))
 		assert.Equal(t, dummyName, a[0])
 		return dummyError
@@ -831,7 +1834,7 @@ func TestGetRequestQuery_EmptyList(t *testing.T) {
 	}
 
 	// act
-	var err = dummySessionObject.GetRequestQuery(
+	var err = dummySessionObject.GetRequestHeader(
 		dummyName,
 		&dummyDataTemplate,
 	)
@@ -844,15 +1847,15 @@ func TestGetRequestQuery_EmptyList(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestGetRequestQuery_HappyPath(t *testing.T) {
+func TestGetRequestHeader_HappyPath(t *testing.T) {
 	// arrange
 	var dummySessionID = uuid.New()
 	var dummyName = "some name"
 	var dummyDataTemplate int
-	var dummyQueries = []string{
-		"some query string 1",
-		"some query string 2",
-		"some query string 3",
+	var dummyHeaders = []string{
+		"some header string 1",
+		"some header string 2",
+		"some header string 3",
 	}
 	var dummyError = errors.New("some error")
 	var dummyAppError = apperror.GetCustomError(0, "some app error")
@@ -867,26 +1870,26 @@ func TestGetRequestQuery_HappyPath(t *testing.T) {
 	}
 
 	// expect
-	getAllQueriesFuncExpected = 1
-	getAllQueriesFunc = func(session *session, name string) []string {
-		getAllQueriesFuncCalled++
+	getAllHeadersFuncExpected = 1
+	getAllHeadersFunc = func(session *session, name string) []string {
+		getAllHeadersFuncCalled++
 		assert.Equal(t, dummySessionObject, session)
 		assert.Equal(t, dummyName, name)
-		return dummyQueries
+		return dummyHeaders
 	}
 	loggerAPIRequestExpected = 1
 	loggerAPIRequest = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAPIRequestCalled++
 		assert.Equal(t, dummySessionObject, session)
-		assert.Equal(t, "Query", category)
+		assert.Equal(t, "Header", category)
 		assert.Equal(t, dummyName, subcategory)
-		assert.Equal(t, dummyQueries[0], messageFormat)
+		assert.Contains(t, dummyHeaders, messageFormat)
 		assert.Equal(t, 0, len(parameters))
 	}
 	jsonutilTryUnmarshalExpected = 1
 	jsonutilTryUnmarshal = func(value string, dataTemplate interface{}) error {
 		jsonutilTryUnmarshalCalled++
-		assert.Equal(t, dummyQueries[0], value)
+		assert.Equal(t, dummyHeaders[0], value)
 		*(dataTemplate.(*int)) = dummyResult
 		return dummyError
 	}
@@ -899,7 +1902,7 @@ func TestGetRequestQuery_HappyPath(t *testing.T) {
 	}
 
 	// act
-	var err = dummySessionObject.GetRequestQuery(
+	var err = dummySessionObject.GetRequestHeader(
 		dummyName,
 		&dummyDataTemplate,
 	)
@@ -912,12 +1915,12 @@ func TestGetRequestQuery_HappyPath(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestGetRequestQueries_EmptyList(t *testing.T) {
+func TestGetRequestHeaders_EmptyList(t *testing.T) {
 	// arrange
 	var dummySessionID = uuid.New()
 	var dummyName = "some name"
 	var dummyDataTemplate int
-	var dummyQueries []string
+	var dummyHeaders []string
 	var dummyFillCallbackExpected int
 	var dummyFillCallbackCalled int
 	var dummyFillCallback func()
@@ -932,17 +1935,25 @@ func TestGetRequestQueries_EmptyList(t *testing.T) {
 	}
 
 	// expect
-	getAllQueriesFuncExpected = 1
-	getAllQueriesFunc = func(session *session, name string) []string {
-		getAllQueriesFuncCalled++
+	getAllHeadersFuncExpected = 1
+	getAllHeadersFunc = func(session *session, name string) []string {
+		getAllHeadersFuncCalled++
 		assert.Equal(t, dummySessionObject, session)
 		assert.Equal(t, dummyName, name)
-		return dummyQueries
+		return dummyHeaders
 	}
 	dummyFillCallbackExpected = 0
 	dummyFillCallback = func() {
 		dummyFillCallbackCalled++
 	}
+	headerutilLogHTTPHeaderForNameExpected = 1
+	headerutilLogHTTPHeaderForName = func(session sessionModel.Session, name string, values []string, logFunc logger.LogFunc) {
+		headerutilLogHTTPHeaderForNameCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyName, name)
+		assert.Equal(t, dummyHeaders, values)
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(loggerAPIRequest)), fmt.Sprintf("%v", reflect.ValueOf(logFunc)))
+	}
 	apperrorGetBadRequestErrorExpected = 1
 	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
 		apperrorGetBadRequestErrorCalled++
@@ -951,7 +1962,7 @@ func TestGetRequestQueries_EmptyList(t *testing.T) {
 	}
 
 	// act
-	var err = dummySessionObject.GetRequestQueries(
+	var err = dummySessionObject.GetRequestHeaders(
 		dummyName,
 		&dummyDataTemplate,
 		dummyFillCallback,
@@ -966,15 +1977,15 @@ func TestGetRequestQueries_EmptyList(t *testing.T) {
 	assert.Equal(t, dummyFillCallbackExpected, dummyFillCallbackCalled, "Unexpected number of calls to dummyFillCallback")
 }
 
-func TestGetRequestQueries_HappyPath(t *testing.T) {
+func TestGetRequestHeaders_HappyPath(t *testing.T) {
 	// arrange
 	var dummySessionID = uuid.New()
 	var dummyName = "some name"
 	var dummyDataTemplate int
-	var dummyQueries = []string{
-		"some query string 1",
-		"some query string 2",
-		"some query string 3",
+	var dummyHeaders = []string{
+		"some header string 1",
+		"some header string 2",
+		"some header string 3",
 	}
 	var dummyFillCallbackExpected int
 	var dummyFillCallbackCalled int
@@ -996,26 +2007,17 @@ func TestGetRequestQueries_HappyPath(t *testing.T) {
 	}
 
 	// expect
-	getAllQueriesFuncExpected = 1
-	getAllQueriesFunc = func(session *session, name string) []string {
-		getAllQueriesFuncCalled++
+	getAllHeadersFuncExpected = 1
+	getAllHeadersFunc = func(session *session, name string) []string {
+		getAllHeadersFuncCalled++
 		assert.Equal(t, dummySessionObject, session)
 		assert.Equal(t, dummyName, name)
-		return dummyQueries
-	}
-	loggerAPIRequestExpected = 3
-	loggerAPIRequest = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
-		loggerAPIRequestCalled++
-		assert.Equal(t, dummySessionObject, session)
-		assert.Equal(t, "Query", category)
-		assert.Equal(t, dummyName, subcategory)
-		assert.Contains(t, dummyQueries, messageFormat)
-		assert.Equal(t, 0, len(parameters))
+		return dummyHeaders
 	}
 	jsonutilTryUnmarshalExpected = 3
 	jsonutilTryUnmarshal = func(value string, dataTemplate interface{}) error {
 		jsonutilTryUnmarshalCalled++
-		assert.Equal(t, dummyQueries[jsonutilTryUnmarshalCalled-1], value)
+		assert.Equal(t, dummyHeaders[jsonutilTryUnmarshalCalled-1], value)
 		*(dataTemplate.(*int)) = dummyResult
 		return unmarshalErrors[jsonutilTryUnmarshalCalled-1]
 	}
@@ -1023,6 +2025,14 @@ func TestGetRequestQueries_HappyPath(t *testing.T) {
 	dummyFillCallback = func() {
 		dummyFillCallbackCalled++
 	}
+	headerutilLogHTTPHeaderForNameExpected = 1
+	headerutilLogHTTPHeaderForName = func(session sessionModel.Session, name string, values []string, logFunc logger.LogFunc) {
+		headerutilLogHTTPHeaderForNameCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, dummyName, name)
+		assert.Equal(t, dummyHeaders, values)
+		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(loggerAPIRequest)), fmt.Sprintf("%v", reflect.ValueOf(logFunc)))
+	}
 	apperrorGetBadRequestErrorExpected = 1
 	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
 		apperrorGetBadRequestErrorCalled++
@@ -1032,7 +2042,7 @@ func TestGetRequestQueries_HappyPath(t *testing.T) {
 	}
 
 	// act
-	var err = dummySessionObject.GetRequestQueries(
+	var err = dummySessionObject.GetRequestHeaders(
 		dummyName,
 		&dummyDataTemplate,
 		dummyFillCallback,
@@ -1047,34 +2057,38 @@ func TestGetRequestQueries_HappyPath(t *testing.T) {
 	assert.Equal(t, dummyFillCallbackExpected, dummyFillCallbackCalled, "Unexpected number of calls to dummyFillCallback")
 }
 
-func TestGetAllHeaders_NotFound(t *testing.T) {
+func TestGetAllFormValues_ValuesError(t *testing.T) {
 	// arrange
 	var dummyName = "some name"
-	var dummyCanonicalName = "some canonical name"
-	var dummyHTTPRequest = &http.Request{
-		Header: http.Header{},
-	}
-	var dummySessionObject = &session{
-		Request: dummyHTTPRequest,
-	}
-
-	// stub
-	dummyHTTPRequest.Header.Add("test", "me")
-	dummyHTTPRequest.Header.Add("test", "you")
+	var dummyHTTPRequest = &http.Request{}
+	var dummyRequestBody = "some request body"
+	var dummyError = errors.New("some error")
 
 	// mock
 	createMock(t)
 
+	// SUT
+	var dummySessionObject = &session{
+		Request: dummyHTTPRequest,
+	}
+
 	// expect
-	textprotoCanonicalMIMEHeaderKeyExpected = 1
-	textprotoCanonicalMIMEHeaderKey = func(s string) string {
-		textprotoCanonicalMIMEHeaderKeyCalled++
-		assert.Equal(t, dummyName, s)
-		return dummyCanonicalName
+	requestGetRequestBodyExpected = 1
+	requestGetRequestBody = func(httpRequest *http.Request) string {
+		requestGetRequestBodyCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		return dummyRequestBody
+	}
+	requestGetFormValuesExpected = 1
+	requestGetFormValues = func(httpRequest *http.Request, body string) (url.Values, error) {
+		requestGetFormValuesCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyRequestBody, body)
+		return nil, dummyError
 	}
 
-	// SUT + act
-	var result = getAllHeaders(
+	// act
+	var result = getAllFormValues(
 		dummySessionObject,
 		dummyName,
 	)
@@ -1086,53 +2100,97 @@ func TestGetAllHeaders_NotFound(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestGetAllHeaders_HappyPath(t *testing.T) {
+func TestGetAllFormValues_NotFound(t *testing.T) {
 	// arrange
 	var dummyName = "some name"
-	var dummyCanonicalName = "some canonical name"
-	var dummyHTTPRequest = &http.Request{
-		Header: http.Header{},
+	var dummyHTTPRequest = &http.Request{}
+	var dummyRequestBody = "some request body"
+	var dummyValues = url.Values{
+		"some other name": []string{"some value"},
 	}
+
+	// mock
+	createMock(t)
+
+	// SUT
 	var dummySessionObject = &session{
 		Request: dummyHTTPRequest,
 	}
 
-	// stub
-	dummyHTTPRequest.Header.Add(dummyCanonicalName, "me")
-	dummyHTTPRequest.Header.Add(dummyCanonicalName, "you")
+	// expect
+	requestGetRequestBodyExpected = 1
+	requestGetRequestBody = func(httpRequest *http.Request) string {
+		requestGetRequestBodyCalled++
+		return dummyRequestBody
+	}
+	requestGetFormValuesExpected = 1
+	requestGetFormValues = func(httpRequest *http.Request, body string) (url.Values, error) {
+		requestGetFormValuesCalled++
+		return dummyValues, nil
+	}
+
+	// act
+	var result = getAllFormValues(
+		dummySessionObject,
+		dummyName,
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetAllFormValues_HappyPath(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyHTTPRequest = &http.Request{}
+	var dummyRequestBody = "some request body"
+	var dummyFormValues = []string{"some value 1", "some value 2"}
+	var dummyValues = url.Values{
+		dummyName: dummyFormValues,
+	}
 
 	// mock
 	createMock(t)
 
+	// SUT
+	var dummySessionObject = &session{
+		Request: dummyHTTPRequest,
+	}
+
 	// expect
-	textprotoCanonicalMIMEHeaderKeyExpected = 1
-	textprotoCanonicalMIMEHeaderKey = func(s string) string {
-		textprotoCanonicalMIMEHeaderKeyCalled++
-		assert.Equal(t, dummyName, s)
-		return dummyCanonicalName
+	requestGetRequestBodyExpected = 1
+	requestGetRequestBody = func(httpRequest *http.Request) string {
+		requestGetRequestBodyCalled++
+		return dummyRequestBody
+	}
+	requestGetFormValuesExpected = 1
+	requestGetFormValues = func(httpRequest *http.Request, body string) (url.Values, error) {
+		requestGetFormValuesCalled++
+		return dummyValues, nil
 	}
 
-	// SUT + act
-	var result = getAllHeaders(
+	// act
+	var result = getAllFormValues(
 		dummySessionObject,
 		dummyName,
 	)
 
 	// assert
-	assert.Equal(t, 2, len(result))
-	assert.Equal(t, "me", result[0])
-	assert.Equal(t, "you", result[1])
+	assert.Equal(t, dummyFormValues, result)
 
 	// verify
 	verifyAll(t)
 }
 
-func TestGetRequestHeader_EmptyList(t *testing.T) {
+func TestGetRequestFormValue_EmptyList(t *testing.T) {
 	// arrange
 	var dummySessionID = uuid.New()
 	var dummyName = "some name"
 	var dummyDataTemplate int
-	var dummyHeaders []string
+	var dummyFormValues []string
 	var dummyError = errors.New("some error")
 	var dummyAppError = apperror.GetCustomError(0, "some app error")
 
@@ -1145,17 +2203,17 @@ func TestGetRequestHeader_EmptyList(t *testing.T) {
 	}
 
 	// expect
-	getAllHeadersFuncExpected = 1
-	getAllHeadersFunc = func(session *session, name string) []string {
-		getAllHeadersFuncCalled++
+	getAllFormValuesFuncExpected = 1
+	getAllFormValuesFunc = func(session *session, name string) []string {
+		getAllFormValuesFuncCalled++
 		assert.Equal(t, dummySessionObject, session)
 		assert.Equal(t, dummyName, name)
-		return dummyHeaders
+		return dummyFormValues
 	}
 	fmtErrorfExpected = 1
 	fmtErrorf = func(format string, a ...interface{}) error {
 		fmtErrorfCalled++
-		assert.Equal(t, "The expected header string [%v] is not found in request", format)
+		assert.Equal(t, "The expected form field [%v] is not found in request", format)
 		assert.Equal(t, 1, len(a))
 		assert.Equal(t, dummyName, a[0])
 		return dummyError
@@ -1169,7 +2227,7 @@ func TestGetRequestHeader_EmptyList(t *testing.T) {
 	}
 
 	// act
-	var err = dummySessionObject.GetRequestHeader(
+	var err = dummySessionObject.GetRequestFormValue(
 		dummyName,
 		&dummyDataTemplate,
 	)
@@ -1182,15 +2240,15 @@ func TestGetRequestHeader_EmptyList(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestGetRequestHeader_HappyPath(t *testing.T) {
+func TestGetRequestFormValue_HappyPath(t *testing.T) {
 	// arrange
 	var dummySessionID = uuid.New()
 	var dummyName = "some name"
 	var dummyDataTemplate int
-	var dummyHeaders = []string{
-		"some header string 1",
-		"some header string 2",
-		"some header string 3",
+	var dummyFormValues = []string{
+		"some form field 1",
+		"some form field 2",
+		"some form field 3",
 	}
 	var dummyError = errors.New("some error")
 	var dummyAppError = apperror.GetCustomError(0, "some app error")
@@ -1205,26 +2263,26 @@ func TestGetRequestHeader_HappyPath(t *testing.T) {
 	}
 
 	// expect
-	getAllHeadersFuncExpected = 1
-	getAllHeadersFunc = func(session *session, name string) []string {
-		getAllHeadersFuncCalled++
+	getAllFormValuesFuncExpected = 1
+	getAllFormValuesFunc = func(session *session, name string) []string {
+		getAllFormValuesFuncCalled++
 		assert.Equal(t, dummySessionObject, session)
 		assert.Equal(t, dummyName, name)
-		return dummyHeaders
+		return dummyFormValues
 	}
 	loggerAPIRequestExpected = 1
 	loggerAPIRequest = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
 		loggerAPIRequestCalled++
 		assert.Equal(t, dummySessionObject, session)
-		assert.Equal(t, "Header", category)
+		assert.Equal(t, "Form", category)
 		assert.Equal(t, dummyName, subcategory)
-		assert.Contains(t, dummyHeaders, messageFormat)
+		assert.Equal(t, dummyFormValues[0], messageFormat)
 		assert.Equal(t, 0, len(parameters))
 	}
 	jsonutilTryUnmarshalExpected = 1
 	jsonutilTryUnmarshal = func(value string, dataTemplate interface{}) error {
 		jsonutilTryUnmarshalCalled++
-		assert.Equal(t, dummyHeaders[0], value)
+		assert.Equal(t, dummyFormValues[0], value)
 		*(dataTemplate.(*int)) = dummyResult
 		return dummyError
 	}
@@ -1237,7 +2295,7 @@ func TestGetRequestHeader_HappyPath(t *testing.T) {
 	}
 
 	// act
-	var err = dummySessionObject.GetRequestHeader(
+	var err = dummySessionObject.GetRequestFormValue(
 		dummyName,
 		&dummyDataTemplate,
 	)
@@ -1250,12 +2308,12 @@ func TestGetRequestHeader_HappyPath(t *testing.T) {
 	verifyAll(t)
 }
 
-func TestGetRequestHeaders_EmptyList(t *testing.T) {
+func TestGetRequestFormValues_EmptyList(t *testing.T) {
 	// arrange
 	var dummySessionID = uuid.New()
 	var dummyName = "some name"
 	var dummyDataTemplate int
-	var dummyHeaders []string
+	var dummyFormValues []string
 	var dummyFillCallbackExpected int
 	var dummyFillCallbackCalled int
 	var dummyFillCallback func()
@@ -1270,25 +2328,17 @@ func TestGetRequestHeaders_EmptyList(t *testing.T) {
 	}
 
 	// expect
-	getAllHeadersFuncExpected = 1
-	getAllHeadersFunc = func(session *session, name string) []string {
-		getAllHeadersFuncCalled++
+	getAllFormValuesFuncExpected = 1
+	getAllFormValuesFunc = func(session *session, name string) []string {
+		getAllFormValuesFuncCalled++
 		assert.Equal(t, dummySessionObject, session)
 		assert.Equal(t, dummyName, name)
-		return dummyHeaders
+		return dummyFormValues
 	}
 	dummyFillCallbackExpected = 0
 	dummyFillCallback = func() {
 		dummyFillCallbackCalled++
 	}
-	headerutilLogHTTPHeaderForNameExpected = 1
-	headerutilLogHTTPHeaderForName = func(session sessionModel.Session, name string, values []string, logFunc logger.LogFunc) {
-		headerutilLogHTTPHeaderForNameCalled++
-		assert.Equal(t, dummySessionObject, session)
-		assert.Equal(t, dummyName, name)
-		assert.Equal(t, dummyHeaders, values)
-		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(loggerAPIRequest)), fmt.Sprintf("%v", reflect.ValueOf(logFunc)))
-	}
 	apperrorGetBadRequestErrorExpected = 1
 	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
 		apperrorGetBadRequestErrorCalled++
@@ -1297,7 +2347,7 @@ func TestGetRequestHeaders_EmptyList(t *testing.T) {
 	}
 
 	// act
-	var err = dummySessionObject.GetRequestHeaders(
+	var err = dummySessionObject.GetRequestFormValues(
 		dummyName,
 		&dummyDataTemplate,
 		dummyFillCallback,
@@ -1312,15 +2362,15 @@ func TestGetRequestHeaders_EmptyList(t *testing.T) {
 	assert.Equal(t, dummyFillCallbackExpected, dummyFillCallbackCalled, "Unexpected number of calls to dummyFillCallback")
 }
 
-func TestGetRequestHeaders_HappyPath(t *testing.T) {
+func TestGetRequestFormValues_HappyPath(t *testing.T) {
 	// arrange
 	var dummySessionID = uuid.New()
 	var dummyName = "some name"
 	var dummyDataTemplate int
-	var dummyHeaders = []string{
-		"some header string 1",
-		"some header string 2",
-		"some header string 3",
+	var dummyFormValues = []string{
+		"some form field 1",
+		"some form field 2",
+		"some form field 3",
 	}
 	var dummyFillCallbackExpected int
 	var dummyFillCallbackCalled int
@@ -1342,17 +2392,26 @@ func TestGetRequestHeaders_HappyPath(t *testing.T) {
 	}
 
 	// expect
-	getAllHeadersFuncExpected = 1
-	getAllHeadersFunc = func(session *session, name string) []string {
-		getAllHeadersFuncCalled++
+	getAllFormValuesFuncExpected = 1
+	getAllFormValuesFunc = func(session *session, name string) []string {
+		getAllFormValuesFuncCalled++
 		assert.Equal(t, dummySessionObject, session)
 		assert.Equal(t, dummyName, name)
-		return dummyHeaders
+		return dummyFormValues
+	}
+	loggerAPIRequestExpected = 3
+	loggerAPIRequest = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAPIRequestCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, "Form", category)
+		assert.Equal(t, dummyName, subcategory)
+		assert.Contains(t, dummyFormValues, messageFormat)
+		assert.Equal(t, 0, len(parameters))
 	}
 	jsonutilTryUnmarshalExpected = 3
 	jsonutilTryUnmarshal = func(value string, dataTemplate interface{}) error {
 		jsonutilTryUnmarshalCalled++
-		assert.Equal(t, dummyHeaders[jsonutilTryUnmarshalCalled-1], value)
+		assert.Equal(t, dummyFormValues[jsonutilTryUnmarshalCalled-1], value)
 		*(dataTemplate.(*int)) = dummyResult
 		return unmarshalErrors[jsonutilTryUnmarshalCalled-1]
 	}
@@ -1360,14 +2419,6 @@ func TestGetRequestHeaders_HappyPath(t *testing.T) {
 	dummyFillCallback = func() {
 		dummyFillCallbackCalled++
 	}
-	headerutilLogHTTPHeaderForNameExpected = 1
-	headerutilLogHTTPHeaderForName = func(session sessionModel.Session, name string, values []string, logFunc logger.LogFunc) {
-		headerutilLogHTTPHeaderForNameCalled++
-		assert.Equal(t, dummySessionObject, session)
-		assert.Equal(t, dummyName, name)
-		assert.Equal(t, dummyHeaders, values)
-		assert.Equal(t, fmt.Sprintf("%v", reflect.ValueOf(loggerAPIRequest)), fmt.Sprintf("%v", reflect.ValueOf(logFunc)))
-	}
 	apperrorGetBadRequestErrorExpected = 1
 	apperrorGetBadRequestError = func(innerErrors ...error) apperrorModel.AppError {
 		apperrorGetBadRequestErrorCalled++
@@ -1377,7 +2428,7 @@ func TestGetRequestHeaders_HappyPath(t *testing.T) {
 	}
 
 	// act
-	var err = dummySessionObject.GetRequestHeaders(
+	var err = dummySessionObject.GetRequestFormValues(
 		dummyName,
 		&dummyDataTemplate,
 		dummyFillCallback,
@@ -1392,6 +2443,104 @@ func TestGetRequestHeaders_HappyPath(t *testing.T) {
 	assert.Equal(t, dummyFillCallbackExpected, dummyFillCallbackCalled, "Unexpected number of calls to dummyFillCallback")
 }
 
+func TestGetRequestFile(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyMaxBytes = rand.Int63()
+	var dummyHTTPRequest = &http.Request{}
+	var dummyFile = requestModel.File{
+		FieldName: dummyName,
+		FileName:  "some file name",
+	}
+	var dummyFileCallbackExpected int
+	var dummyFileCallbackCalled int
+	var dummyFileCallback func(file requestModel.File) error
+	var dummyError = errors.New("some error")
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var dummySessionObject = &session{
+		Request: dummyHTTPRequest,
+	}
+
+	// expect
+	requestStreamFilesExpected = 1
+	requestStreamFiles = func(httpRequest *http.Request, name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+		requestStreamFilesCalled++
+		assert.Equal(t, dummyHTTPRequest, httpRequest)
+		assert.Equal(t, dummyName, name)
+		assert.Equal(t, dummyMaxBytes, maxBytes)
+		assert.Equal(t, dummyError, fileCallback(dummyFile))
+		return dummyAppError
+	}
+	loggerAPIRequestExpected = 1
+	loggerAPIRequest = func(session sessionModel.Session, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+		loggerAPIRequestCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, "File", category)
+		assert.Equal(t, dummyName, subcategory)
+		assert.Equal(t, dummyFile.FileName, messageFormat)
+		assert.Equal(t, 0, len(parameters))
+	}
+	dummyFileCallbackExpected = 1
+	dummyFileCallback = func(file requestModel.File) error {
+		dummyFileCallbackCalled++
+		assert.Equal(t, dummyFile, file)
+		return dummyError
+	}
+
+	// act
+	var err = dummySessionObject.GetRequestFile(
+		dummyName,
+		dummyMaxBytes,
+		dummyFileCallback,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+	assert.Equal(t, dummyFileCallbackExpected, dummyFileCallbackCalled, "Unexpected number of calls to dummyFileCallback")
+}
+
+func TestBindRequest(t *testing.T) {
+	// arrange
+	var dummyDataTemplate int
+	var dummyAppError = apperror.GetCustomError(0, "some app error")
+
+	// mock
+	createMock(t)
+
+	// SUT
+	var dummySessionObject = &session{
+		ID: uuid.New(),
+	}
+
+	// expect
+	bindingBindExpected = 1
+	bindingBind = func(session sessionModel.Session, dataTemplate interface{}) apperrorModel.AppError {
+		bindingBindCalled++
+		assert.Equal(t, dummySessionObject, session)
+		assert.Equal(t, &dummyDataTemplate, dataTemplate)
+		return dummyAppError
+	}
+
+	// act
+	var err = dummySessionObject.BindRequest(
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+
+	// verify
+	verifyAll(t)
+}
+
 func TestAttach_NilSessionObject(t *testing.T) {
 	// arrange
 	var dummyName = "some name"
@@ -0,0 +1,32 @@
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
+)
+
+// func pointers for injection / testing: tracing.go
+var (
+	randRead              = rand.Read
+	hexEncodeToString     = hex.EncodeToString
+	hexDecodeString       = hex.DecodeString
+	timeutilGetTimeNowUTC = timeutil.GetTimeNowUTC
+	isValidHexFunc        = isValidHex
+	newIDFunc             = newID
+	parseTraceParentFunc  = parseTraceParent
+	formatTraceParentFunc = FormatTraceParent
+	getSpanFunc           = GetSpan
+)
+
+// func pointers for injection / testing: exporter.go
+var (
+	fmtPrintln                 = fmt.Println
+	fmtFprintln                = fmt.Fprintln
+	osOpenFile                 = os.OpenFile
+	jsonutilMarshalIgnoreError = jsonutil.MarshalIgnoreError
+)
@@ -0,0 +1,323 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	apperrorModel "github.com/zhongjie-cai/WebServiceTemplate/apperror/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/jsonutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/loglevel"
+	"github.com/zhongjie-cai/WebServiceTemplate/logger/logtype"
+	networkModel "github.com/zhongjie-cai/WebServiceTemplate/network/model"
+	requestModel "github.com/zhongjie-cai/WebServiceTemplate/request/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/timeutil"
+	"github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
+)
+
+var (
+	randReadExpected                   int
+	randReadCalled                     int
+	hexEncodeToStringExpected          int
+	hexEncodeToStringCalled            int
+	hexDecodeStringExpected            int
+	hexDecodeStringCalled              int
+	timeutilGetTimeNowUTCExpected      int
+	timeutilGetTimeNowUTCCalled        int
+	isValidHexFuncExpected             int
+	isValidHexFuncCalled               int
+	newIDFuncExpected                  int
+	newIDFuncCalled                    int
+	parseTraceParentFuncExpected       int
+	parseTraceParentFuncCalled         int
+	formatTraceParentFuncExpected      int
+	formatTraceParentFuncCalled        int
+	getSpanFuncExpected                int
+	getSpanFuncCalled                  int
+	fmtPrintlnExpected                 int
+	fmtPrintlnCalled                   int
+	fmtFprintlnExpected                int
+	fmtFprintlnCalled                  int
+	osOpenFileExpected                 int
+	osOpenFileCalled                   int
+	jsonutilMarshalIgnoreErrorExpected int
+	jsonutilMarshalIgnoreErrorCalled   int
+	customizationSpanExporterExpected  int
+	customizationSpanExporterCalled    int
+)
+
+func createMock(t *testing.T) {
+	randReadExpected = 0
+	randReadCalled = 0
+	randRead = func(b []byte) (n int, err error) {
+		randReadCalled++
+		return 0, nil
+	}
+	hexEncodeToStringExpected = 0
+	hexEncodeToStringCalled = 0
+	hexEncodeToString = func(src []byte) string {
+		hexEncodeToStringCalled++
+		return ""
+	}
+	hexDecodeStringExpected = 0
+	hexDecodeStringCalled = 0
+	hexDecodeString = func(s string) ([]byte, error) {
+		hexDecodeStringCalled++
+		return nil, nil
+	}
+	timeutilGetTimeNowUTCExpected = 0
+	timeutilGetTimeNowUTCCalled = 0
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return time.Time{}
+	}
+	isValidHexFuncExpected = 0
+	isValidHexFuncCalled = 0
+	isValidHexFunc = func(value string, length int) bool {
+		isValidHexFuncCalled++
+		return false
+	}
+	newIDFuncExpected = 0
+	newIDFuncCalled = 0
+	newIDFunc = func(size int) string {
+		newIDFuncCalled++
+		return ""
+	}
+	parseTraceParentFuncExpected = 0
+	parseTraceParentFuncCalled = 0
+	parseTraceParentFunc = func(traceParent string) (string, string, bool, bool) {
+		parseTraceParentFuncCalled++
+		return "", "", false, false
+	}
+	formatTraceParentFuncExpected = 0
+	formatTraceParentFuncCalled = 0
+	formatTraceParentFunc = func(span *model.Span) string {
+		formatTraceParentFuncCalled++
+		return ""
+	}
+	getSpanFuncExpected = 0
+	getSpanFuncCalled = 0
+	getSpanFunc = func(ctx context.Context) *model.Span {
+		getSpanFuncCalled++
+		return nil
+	}
+	fmtPrintlnExpected = 0
+	fmtPrintlnCalled = 0
+	fmtPrintln = func(a ...interface{}) (n int, err error) {
+		fmtPrintlnCalled++
+		return 0, nil
+	}
+	fmtFprintlnExpected = 0
+	fmtFprintlnCalled = 0
+	fmtFprintln = func(w io.Writer, a ...interface{}) (n int, err error) {
+		fmtFprintlnCalled++
+		return 0, nil
+	}
+	osOpenFileExpected = 0
+	osOpenFileCalled = 0
+	osOpenFile = func(name string, flag int, perm os.FileMode) (*os.File, error) {
+		osOpenFileCalled++
+		return nil, nil
+	}
+	jsonutilMarshalIgnoreErrorExpected = 0
+	jsonutilMarshalIgnoreErrorCalled = 0
+	jsonutilMarshalIgnoreError = func(v interface{}) string {
+		jsonutilMarshalIgnoreErrorCalled++
+		return ""
+	}
+	customizationSpanExporterExpected = 0
+	customizationSpanExporterCalled = 0
+}
+
+func verifyAll(t *testing.T) {
+	randRead = rand.Read
+	assert.Equal(t, randReadExpected, randReadCalled, "Unexpected number of calls to randRead")
+	hexEncodeToString = hex.EncodeToString
+	assert.Equal(t, hexEncodeToStringExpected, hexEncodeToStringCalled, "Unexpected number of calls to hexEncodeToString")
+	hexDecodeString = hex.DecodeString
+	assert.Equal(t, hexDecodeStringExpected, hexDecodeStringCalled, "Unexpected number of calls to hexDecodeString")
+	timeutilGetTimeNowUTC = timeutil.GetTimeNowUTC
+	assert.Equal(t, timeutilGetTimeNowUTCExpected, timeutilGetTimeNowUTCCalled, "Unexpected number of calls to timeutilGetTimeNowUTC")
+	isValidHexFunc = isValidHex
+	assert.Equal(t, isValidHexFuncExpected, isValidHexFuncCalled, "Unexpected number of calls to isValidHexFunc")
+	newIDFunc = newID
+	assert.Equal(t, newIDFuncExpected, newIDFuncCalled, "Unexpected number of calls to newIDFunc")
+	parseTraceParentFunc = parseTraceParent
+	assert.Equal(t, parseTraceParentFuncExpected, parseTraceParentFuncCalled, "Unexpected number of calls to parseTraceParentFunc")
+	formatTraceParentFunc = FormatTraceParent
+	assert.Equal(t, formatTraceParentFuncExpected, formatTraceParentFuncCalled, "Unexpected number of calls to formatTraceParentFunc")
+	getSpanFunc = GetSpan
+	assert.Equal(t, getSpanFuncExpected, getSpanFuncCalled, "Unexpected number of calls to getSpanFunc")
+	fmtPrintln = fmt.Println
+	assert.Equal(t, fmtPrintlnExpected, fmtPrintlnCalled, "Unexpected number of calls to fmtPrintln")
+	fmtFprintln = fmt.Fprintln
+	assert.Equal(t, fmtFprintlnExpected, fmtFprintlnCalled, "Unexpected number of calls to fmtFprintln")
+	osOpenFile = os.OpenFile
+	assert.Equal(t, osOpenFileExpected, osOpenFileCalled, "Unexpected number of calls to osOpenFile")
+	jsonutilMarshalIgnoreError = jsonutil.MarshalIgnoreError
+	assert.Equal(t, jsonutilMarshalIgnoreErrorExpected, jsonutilMarshalIgnoreErrorCalled, "Unexpected number of calls to jsonutilMarshalIgnoreError")
+	customization.SpanExporter = nil
+	assert.Equal(t, customizationSpanExporterExpected, customizationSpanExporterCalled, "Unexpected number of calls to customization.SpanExporter")
+}
+
+// mock structs
+type dummySpanExporter struct {
+	t        *testing.T
+	expected *model.Span
+	called   int
+}
+
+func (exporter *dummySpanExporter) Export(span model.Span) {
+	exporter.called++
+	if exporter.expected == nil {
+		assert.Fail(exporter.t, "Unexpected call to Export")
+		return
+	}
+	assert.Equal(exporter.t, *exporter.expected, span)
+}
+
+type dummySession struct {
+	t       *testing.T
+	request *http.Request
+}
+
+func (session *dummySession) GetID() uuid.UUID {
+	assert.Fail(session.t, "Unexpected call to GetID")
+	return uuid.Nil
+}
+
+func (session *dummySession) GetName() string {
+	assert.Fail(session.t, "Unexpected call to GetName")
+	return ""
+}
+
+func (session *dummySession) GetCorrelationID() string {
+	assert.Fail(session.t, "Unexpected call to GetCorrelationID")
+	return ""
+}
+
+func (session *dummySession) GetRequest() *http.Request {
+	return session.request
+}
+
+func (session *dummySession) GetResponseWriter() http.ResponseWriter {
+	assert.Fail(session.t, "Unexpected call to GetResponseWriter")
+	return nil
+}
+
+func (session *dummySession) SetResponseETag(etag string, weak bool) {
+	assert.Fail(session.t, "Unexpected call to SetResponseETag")
+}
+
+func (session *dummySession) SetResponseLastModified(lastModified time.Time) {
+	assert.Fail(session.t, "Unexpected call to SetResponseLastModified")
+}
+
+func (session *dummySession) GetRequestBody(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestBody")
+	return nil
+}
+
+func (session *dummySession) GetRequestParameter(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestParameter")
+	return nil
+}
+
+func (session *dummySession) GetRequestQuery(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestQuery")
+	return nil
+}
+
+func (session *dummySession) GetRequestQueries(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestQueries")
+	return nil
+}
+
+func (session *dummySession) GetRequestHeader(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestHeader")
+	return nil
+}
+
+func (session *dummySession) GetRequestHeaders(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestHeaders")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValue(name string, dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValue")
+	return nil
+}
+
+func (session *dummySession) GetRequestFormValues(name string, dataTemplate interface{}, fillCallback func()) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFormValues")
+	return nil
+}
+
+func (session *dummySession) GetRequestFile(name string, maxBytes int64, fileCallback func(file requestModel.File) error) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to GetRequestFile")
+	return nil
+}
+
+func (session *dummySession) BindRequest(dataTemplate interface{}) apperrorModel.AppError {
+	assert.Fail(session.t, "Unexpected call to BindRequest")
+	return nil
+}
+
+func (session *dummySession) Attach(name string, value interface{}) bool {
+	assert.Fail(session.t, "Unexpected call to Attach")
+	return false
+}
+
+func (session *dummySession) Detach(name string) bool {
+	assert.Fail(session.t, "Unexpected call to Detach")
+	return false
+}
+
+func (session *dummySession) GetRawAttachment(name string) (interface{}, bool) {
+	assert.Fail(session.t, "Unexpected call to GetRawAttachment")
+	return nil, false
+}
+
+func (session *dummySession) GetAttachment(name string, dataTemplate interface{}) bool {
+	assert.Fail(session.t, "Unexpected call to GetAttachment")
+	return false
+}
+
+func (session *dummySession) IsLoggingAllowed(logType logtype.LogType, logLevel loglevel.LogLevel) bool {
+	assert.Fail(session.t, "Unexpected call to IsLoggingAllowed")
+	return false
+}
+
+func (session *dummySession) LogMethodEnter() {
+	assert.Fail(session.t, "Unexpected call to LogMethodEnter")
+}
+
+func (session *dummySession) LogMethodParameter(parameters ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodParameter")
+}
+
+func (session *dummySession) LogMethodLogic(logLevel loglevel.LogLevel, category string, subcategory string, messageFormat string, parameters ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodLogic")
+}
+
+func (session *dummySession) LogMethodReturn(returns ...interface{}) {
+	assert.Fail(session.t, "Unexpected call to LogMethodReturn")
+}
+
+func (session *dummySession) LogMethodExit() {
+	assert.Fail(session.t, "Unexpected call to LogMethodExit")
+}
+
+func (session *dummySession) CreateNetworkRequest(method string, url string, payload string, header map[string]string) networkModel.NetworkRequest {
+	assert.Fail(session.t, "Unexpected call to CreateNetworkRequest")
+	return nil
+}
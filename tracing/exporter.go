@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"os"
+	"sync"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
+)
+
+type stdoutExporter struct{}
+
+type jsonFileExporter struct {
+	path string
+	lock sync.Mutex
+}
+
+// NewStdoutExporter creates a span exporter printing each ended span as a JSON line to standard output
+func NewStdoutExporter() model.SpanExporter {
+	return &stdoutExporter{}
+}
+
+// NewJSONFileExporter creates a span exporter appending each ended span as a JSON line to the file at given path
+func NewJSONFileExporter(path string) model.SpanExporter {
+	return &jsonFileExporter{
+		path: path,
+	}
+}
+
+// Export prints the given span as a JSON line to standard output
+func (exporter *stdoutExporter) Export(span model.Span) {
+	fmtPrintln(
+		jsonutilMarshalIgnoreError(
+			span,
+		),
+	)
+}
+
+// Export appends the given span as a JSON line to the file of the exporter
+func (exporter *jsonFileExporter) Export(span model.Span) {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	var file, fileError = osOpenFile(
+		exporter.path,
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY,
+		0644,
+	)
+	if fileError != nil {
+		fmtFprintln(
+			os.Stderr,
+			fileError,
+		)
+		return
+	}
+	defer file.Close()
+	var _, writeError = file.WriteString(
+		jsonutilMarshalIgnoreError(
+			span,
+		) + "\n",
+	)
+	if writeError != nil {
+		fmtFprintln(
+			os.Stderr,
+			writeError,
+		)
+	}
+}
@@ -0,0 +1,213 @@
+package tracing
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
+)
+
+func TestNewStdoutExporter(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = NewStdoutExporter()
+
+	// assert
+	assert.IsType(t, &stdoutExporter{}, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewJSONFileExporter(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = NewJSONFileExporter(
+		dummyPath,
+	)
+
+	// assert
+	var exporter, ok = result.(*jsonFileExporter)
+	assert.True(t, ok)
+	assert.Equal(t, dummyPath, exporter.path)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStdoutExporterExport(t *testing.T) {
+	// arrange
+	var dummySpan = model.Span{
+		SpanID: "some span ID",
+	}
+	var dummyContent = "some content"
+	var exporter = &stdoutExporter{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	jsonutilMarshalIgnoreErrorExpected = 1
+	jsonutilMarshalIgnoreError = func(v interface{}) string {
+		jsonutilMarshalIgnoreErrorCalled++
+		assert.Equal(t, dummySpan, v)
+		return dummyContent
+	}
+	fmtPrintlnExpected = 1
+	fmtPrintln = func(a ...interface{}) (n int, err error) {
+		fmtPrintlnCalled++
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyContent, a[0])
+		return 0, nil
+	}
+
+	// SUT + act
+	exporter.Export(
+		dummySpan,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestJSONFileExporterExport_OpenError(t *testing.T) {
+	// arrange
+	var dummyPath = "some path"
+	var dummySpan = model.Span{
+		SpanID: "some span ID",
+	}
+	var dummyError = errors.New("some error")
+	var exporter = &jsonFileExporter{
+		path: dummyPath,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	osOpenFileExpected = 1
+	osOpenFile = func(name string, flag int, perm os.FileMode) (*os.File, error) {
+		osOpenFileCalled++
+		assert.Equal(t, dummyPath, name)
+		assert.Equal(t, os.O_APPEND|os.O_CREATE|os.O_WRONLY, flag)
+		assert.Equal(t, os.FileMode(0644), perm)
+		return nil, dummyError
+	}
+	fmtFprintlnExpected = 1
+	fmtFprintln = func(w io.Writer, a ...interface{}) (n int, err error) {
+		fmtFprintlnCalled++
+		assert.Equal(t, os.Stderr, w)
+		assert.Equal(t, 1, len(a))
+		assert.Equal(t, dummyError, a[0])
+		return 0, nil
+	}
+
+	// SUT + act
+	exporter.Export(
+		dummySpan,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestJSONFileExporterExport_WriteError(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "spans.json")
+	var dummySpan = model.Span{
+		SpanID: "some span ID",
+	}
+	var dummyContent = "some content"
+	var exporter = &jsonFileExporter{
+		path: dummyPath,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	osOpenFileExpected = 1
+	osOpenFile = func(name string, flag int, perm os.FileMode) (*os.File, error) {
+		osOpenFileCalled++
+		assert.Equal(t, dummyPath, name)
+		return os.OpenFile(name, os.O_CREATE|os.O_RDONLY, perm)
+	}
+	jsonutilMarshalIgnoreErrorExpected = 1
+	jsonutilMarshalIgnoreError = func(v interface{}) string {
+		jsonutilMarshalIgnoreErrorCalled++
+		assert.Equal(t, dummySpan, v)
+		return dummyContent
+	}
+	fmtFprintlnExpected = 1
+	fmtFprintln = func(w io.Writer, a ...interface{}) (n int, err error) {
+		fmtFprintlnCalled++
+		assert.Equal(t, os.Stderr, w)
+		assert.Equal(t, 1, len(a))
+		assert.Error(t, a[0].(error))
+		return 0, nil
+	}
+
+	// SUT + act
+	exporter.Export(
+		dummySpan,
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestJSONFileExporterExport_Success(t *testing.T) {
+	// arrange
+	var dummyPath = filepath.Join(t.TempDir(), "spans.json")
+	var dummySpan = model.Span{
+		SpanID: "some span ID",
+	}
+	var dummyContent = "some content"
+	var exporter = &jsonFileExporter{
+		path: dummyPath,
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	osOpenFileExpected = 2
+	osOpenFile = func(name string, flag int, perm os.FileMode) (*os.File, error) {
+		osOpenFileCalled++
+		assert.Equal(t, dummyPath, name)
+		return os.OpenFile(name, flag, perm)
+	}
+	jsonutilMarshalIgnoreErrorExpected = 2
+	jsonutilMarshalIgnoreError = func(v interface{}) string {
+		jsonutilMarshalIgnoreErrorCalled++
+		assert.Equal(t, dummySpan, v)
+		return dummyContent
+	}
+
+	// SUT + act
+	exporter.Export(
+		dummySpan,
+	)
+	exporter.Export(
+		dummySpan,
+	)
+
+	// assert
+	var content, _ = ioutil.ReadFile(dummyPath)
+	assert.Equal(t, dummyContent+"\n"+dummyContent+"\n", string(content))
+
+	// verify
+	verifyAll(t)
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+)
+
+// SpanKind is the role of a span within a trace
+type SpanKind string
+
+// These are the kinds of spans created by the application
+const (
+	SpanKindServer   SpanKind = "server"
+	SpanKindClient   SpanKind = "client"
+	SpanKindInternal SpanKind = "internal"
+)
+
+// Span is the record of a timed operation within a W3C trace, handed over to the span exporter when ended
+type Span struct {
+	TraceID      string            `json:"traceId"`
+	SpanID       string            `json:"spanId"`
+	ParentSpanID string            `json:"parentSpanId,omitempty"`
+	TraceState   string            `json:"traceState,omitempty"`
+	Sampled      bool              `json:"sampled"`
+	Name         string            `json:"name"`
+	Kind         SpanKind          `json:"kind"`
+	StartTime    time.Time         `json:"startTime"`
+	EndTime      time.Time         `json:"endTime"`
+	Error        string            `json:"error,omitempty"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+}
+
+// SpanExporter is the interface for exporting ended spans to a tracing backend
+type SpanExporter interface {
+	// Export sends the given ended span to the tracing backend
+	Export(span Span)
+}
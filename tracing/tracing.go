@@ -0,0 +1,221 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	sessionModel "github.com/zhongjie-cai/WebServiceTemplate/session/model"
+	"github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
+)
+
+// These are the W3C trace context header names propagated through sessions and network requests
+const (
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+)
+
+const (
+	traceVersion    = "00"
+	invalidVersion  = "ff"
+	traceIDSize     = 16
+	spanIDSize      = 8
+	flagsSampled    = "01"
+	flagsNotSampled = "00"
+)
+
+type spanContextKey struct{}
+
+func isValidHex(value string, length int) bool {
+	if len(value) != length {
+		return false
+	}
+	var allZeros = true
+	for _, character := range value {
+		if (character < '0' || character > '9') &&
+			(character < 'a' || character > 'f') {
+			return false
+		}
+		if character != '0' {
+			allZeros = false
+		}
+	}
+	return !allZeros
+}
+
+func newID(size int) string {
+	var bytes = make([]byte, size)
+	for {
+		randRead(bytes)
+		var id = hexEncodeToString(bytes)
+		if isValidHexFunc(id, size*2) {
+			return id
+		}
+	}
+}
+
+func parseTraceParent(traceParent string) (traceID string, parentSpanID string, sampled bool, ok bool) {
+	var parts = strings.Split(
+		strings.TrimSpace(traceParent),
+		"-",
+	)
+	if len(parts) < 4 {
+		return "", "", false, false
+	}
+	var version = parts[0]
+	if len(version) != 2 ||
+		version == invalidVersion ||
+		(version == traceVersion && len(parts) != 4) {
+		return "", "", false, false
+	}
+	if !isValidHexFunc(parts[1], traceIDSize*2) ||
+		!isValidHexFunc(parts[2], spanIDSize*2) ||
+		len(parts[3]) != 2 {
+		return "", "", false, false
+	}
+	var flags, flagsError = hexDecodeString(parts[3])
+	if flagsError != nil {
+		return "", "", false, false
+	}
+	return parts[1], parts[2], flags[0]&1 == 1, true
+}
+
+// FormatTraceParent returns the W3C traceparent header value identifying the given span as parent for downstream calls
+func FormatTraceParent(span *model.Span) string {
+	if span == nil {
+		return ""
+	}
+	var flags = flagsNotSampled
+	if span.Sampled {
+		flags = flagsSampled
+	}
+	return traceVersion + "-" + span.TraceID + "-" + span.SpanID + "-" + flags
+}
+
+// StartServerSpan starts the span of an incoming API call, continuing the trace described by the W3C traceparent and tracestate headers if present and valid, or else starting a new trace
+func StartServerSpan(header http.Header, name string) *model.Span {
+	var traceID, parentSpanID, sampled, ok = parseTraceParentFunc(
+		header.Get(TraceParentHeader),
+	)
+	var traceState string
+	if ok {
+		traceState = header.Get(TraceStateHeader)
+	} else {
+		traceID = newIDFunc(traceIDSize)
+		sampled = true
+	}
+	return &model.Span{
+		TraceID:      traceID,
+		SpanID:       newIDFunc(spanIDSize),
+		ParentSpanID: parentSpanID,
+		TraceState:   traceState,
+		Sampled:      sampled,
+		Name:         name,
+		Kind:         model.SpanKindServer,
+		StartTime:    timeutilGetTimeNowUTC(),
+	}
+}
+
+// StartSpan starts a child span of the given parent span, or the root span of a new trace if parent span is nil
+func StartSpan(parent *model.Span, name string, kind model.SpanKind) *model.Span {
+	var span = &model.Span{
+		SpanID:    newIDFunc(spanIDSize),
+		Name:      name,
+		Kind:      kind,
+		StartTime: timeutilGetTimeNowUTC(),
+	}
+	if parent == nil {
+		span.TraceID = newIDFunc(traceIDSize)
+		span.Sampled = true
+	} else {
+		span.TraceID = parent.TraceID
+		span.ParentSpanID = parent.SpanID
+		span.TraceState = parent.TraceState
+		span.Sampled = parent.Sampled
+	}
+	return span
+}
+
+// SetAttribute sets the given attribute onto the span for exporting
+func SetAttribute(span *model.Span, key string, value string) {
+	if span == nil {
+		return
+	}
+	if span.Attributes == nil {
+		span.Attributes = map[string]string{}
+	}
+	span.Attributes[key] = value
+}
+
+// EndSpan ends the given span with the error if applicable, and exports the span through customized span exporter if sampled
+func EndSpan(span *model.Span, err error) {
+	if span == nil {
+		return
+	}
+	span.EndTime = timeutilGetTimeNowUTC()
+	if err != nil {
+		span.Error = err.Error()
+	}
+	if !span.Sampled ||
+		customization.SpanExporter == nil {
+		return
+	}
+	var exporter = customization.SpanExporter()
+	if exporter == nil {
+		return
+	}
+	exporter.Export(*span)
+}
+
+// InjectHeader sets the W3C traceparent and tracestate headers onto the given HTTP header to propagate the given span to downstream calls
+func InjectHeader(header http.Header, span *model.Span) {
+	if header == nil ||
+		span == nil {
+		return
+	}
+	header.Set(
+		TraceParentHeader,
+		formatTraceParentFunc(span),
+	)
+	if span.TraceState != "" {
+		header.Set(
+			TraceStateHeader,
+			span.TraceState,
+		)
+	}
+}
+
+// WithSpan returns a shallow copy of the given HTTP request carrying the given span in its context
+func WithSpan(httpRequest *http.Request, span *model.Span) *http.Request {
+	return httpRequest.WithContext(
+		context.WithValue(
+			httpRequest.Context(),
+			spanContextKey{},
+			span,
+		),
+	)
+}
+
+// GetSpan returns the span carried in the given context, or nil if not available
+func GetSpan(ctx context.Context) *model.Span {
+	if ctx == nil {
+		return nil
+	}
+	var span, _ = ctx.Value(spanContextKey{}).(*model.Span)
+	return span
+}
+
+// GetSessionSpan returns the span of the API call associated to the given session, or nil if not available
+func GetSessionSpan(session sessionModel.Session) *model.Span {
+	if session == nil {
+		return nil
+	}
+	var httpRequest = session.GetRequest()
+	if httpRequest == nil {
+		return nil
+	}
+	return getSpanFunc(
+		httpRequest.Context(),
+	)
+}
@@ -0,0 +1,1194 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/WebServiceTemplate/customization"
+	"github.com/zhongjie-cai/WebServiceTemplate/tracing/model"
+)
+
+func TestIsValidHex_WrongLength(t *testing.T) {
+	// arrange
+	var dummyValue = "0123456789abcdef"
+	var dummyLength = 32
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isValidHex(
+		dummyValue,
+		dummyLength,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsValidHex_InvalidCharacter(t *testing.T) {
+	// arrange
+	var dummyValue = "0123456789ABCDEF"
+	var dummyLength = 16
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isValidHex(
+		dummyValue,
+		dummyLength,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsValidHex_AllZeros(t *testing.T) {
+	// arrange
+	var dummyValue = "0000000000000000"
+	var dummyLength = 16
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isValidHex(
+		dummyValue,
+		dummyLength,
+	)
+
+	// assert
+	assert.False(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestIsValidHex_Valid(t *testing.T) {
+	// arrange
+	var dummyValue = "0123456789abcdef"
+	var dummyLength = 16
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = isValidHex(
+		dummyValue,
+		dummyLength,
+	)
+
+	// assert
+	assert.True(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestNewID_RetryUntilValid(t *testing.T) {
+	// arrange
+	var dummySize = rand.Intn(16) + 1
+	var dummyIDs = []string{"some invalid ID", "some valid ID"}
+	var validatedIDs = []string{}
+
+	// mock
+	createMock(t)
+
+	// expect
+	randReadExpected = 2
+	randRead = func(b []byte) (n int, err error) {
+		randReadCalled++
+		assert.Equal(t, dummySize, len(b))
+		return len(b), nil
+	}
+	hexEncodeToStringExpected = 2
+	hexEncodeToString = func(src []byte) string {
+		hexEncodeToStringCalled++
+		assert.Equal(t, dummySize, len(src))
+		return dummyIDs[hexEncodeToStringCalled-1]
+	}
+	isValidHexFuncExpected = 2
+	isValidHexFunc = func(value string, length int) bool {
+		isValidHexFuncCalled++
+		validatedIDs = append(validatedIDs, value)
+		assert.Equal(t, dummySize*2, length)
+		return isValidHexFuncCalled == 2
+	}
+
+	// SUT + act
+	var result = newID(
+		dummySize,
+	)
+
+	// assert
+	assert.Equal(t, dummyIDs[1], result)
+	assert.Equal(t, dummyIDs, validatedIDs)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseTraceParent_TooFewParts(t *testing.T) {
+	// arrange
+	var dummyTraceParent = "00-some trace ID-some span ID"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var traceID, parentSpanID, sampled, ok = parseTraceParent(
+		dummyTraceParent,
+	)
+
+	// assert
+	assert.Zero(t, traceID)
+	assert.Zero(t, parentSpanID)
+	assert.False(t, sampled)
+	assert.False(t, ok)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseTraceParent_InvalidVersion(t *testing.T) {
+	// arrange
+	var dummyTraceParent = "ff-some trace ID-some span ID-01"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var traceID, parentSpanID, sampled, ok = parseTraceParent(
+		dummyTraceParent,
+	)
+
+	// assert
+	assert.Zero(t, traceID)
+	assert.Zero(t, parentSpanID)
+	assert.False(t, sampled)
+	assert.False(t, ok)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseTraceParent_KnownVersionExtraParts(t *testing.T) {
+	// arrange
+	var dummyTraceParent = "00-some trace ID-some span ID-01-some extra"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var traceID, parentSpanID, sampled, ok = parseTraceParent(
+		dummyTraceParent,
+	)
+
+	// assert
+	assert.Zero(t, traceID)
+	assert.Zero(t, parentSpanID)
+	assert.False(t, sampled)
+	assert.False(t, ok)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseTraceParent_InvalidTraceID(t *testing.T) {
+	// arrange
+	var dummyTraceID = "some trace ID"
+	var dummySpanID = "some span ID"
+	var dummyTraceParent = "00-" + dummyTraceID + "-" + dummySpanID + "-01"
+
+	// mock
+	createMock(t)
+
+	// expect
+	isValidHexFuncExpected = 1
+	isValidHexFunc = func(value string, length int) bool {
+		isValidHexFuncCalled++
+		assert.Equal(t, dummyTraceID, value)
+		assert.Equal(t, 32, length)
+		return false
+	}
+
+	// SUT + act
+	var traceID, parentSpanID, sampled, ok = parseTraceParent(
+		dummyTraceParent,
+	)
+
+	// assert
+	assert.Zero(t, traceID)
+	assert.Zero(t, parentSpanID)
+	assert.False(t, sampled)
+	assert.False(t, ok)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseTraceParent_InvalidSpanID(t *testing.T) {
+	// arrange
+	var dummyTraceID = "some trace ID"
+	var dummySpanID = "some span ID"
+	var dummyTraceParent = "00-" + dummyTraceID + "-" + dummySpanID + "-01"
+
+	// mock
+	createMock(t)
+
+	// expect
+	isValidHexFuncExpected = 2
+	isValidHexFunc = func(value string, length int) bool {
+		isValidHexFuncCalled++
+		if isValidHexFuncCalled == 1 {
+			assert.Equal(t, dummyTraceID, value)
+			assert.Equal(t, 32, length)
+			return true
+		}
+		assert.Equal(t, dummySpanID, value)
+		assert.Equal(t, 16, length)
+		return false
+	}
+
+	// SUT + act
+	var traceID, parentSpanID, sampled, ok = parseTraceParent(
+		dummyTraceParent,
+	)
+
+	// assert
+	assert.Zero(t, traceID)
+	assert.Zero(t, parentSpanID)
+	assert.False(t, sampled)
+	assert.False(t, ok)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseTraceParent_InvalidFlagsLength(t *testing.T) {
+	// arrange
+	var dummyTraceParent = "00-some trace ID-some span ID-001"
+
+	// mock
+	createMock(t)
+
+	// expect
+	isValidHexFuncExpected = 2
+	isValidHexFunc = func(value string, length int) bool {
+		isValidHexFuncCalled++
+		return true
+	}
+
+	// SUT + act
+	var traceID, parentSpanID, sampled, ok = parseTraceParent(
+		dummyTraceParent,
+	)
+
+	// assert
+	assert.Zero(t, traceID)
+	assert.Zero(t, parentSpanID)
+	assert.False(t, sampled)
+	assert.False(t, ok)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseTraceParent_FlagsDecodeError(t *testing.T) {
+	// arrange
+	var dummyFlags = "zz"
+	var dummyTraceParent = "00-some trace ID-some span ID-" + dummyFlags
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	isValidHexFuncExpected = 2
+	isValidHexFunc = func(value string, length int) bool {
+		isValidHexFuncCalled++
+		return true
+	}
+	hexDecodeStringExpected = 1
+	hexDecodeString = func(s string) ([]byte, error) {
+		hexDecodeStringCalled++
+		assert.Equal(t, dummyFlags, s)
+		return nil, dummyError
+	}
+
+	// SUT + act
+	var traceID, parentSpanID, sampled, ok = parseTraceParent(
+		dummyTraceParent,
+	)
+
+	// assert
+	assert.Zero(t, traceID)
+	assert.Zero(t, parentSpanID)
+	assert.False(t, sampled)
+	assert.False(t, ok)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseTraceParent_NotSampled(t *testing.T) {
+	// arrange
+	var dummyTraceID = "some trace ID"
+	var dummySpanID = "some span ID"
+	var dummyFlags = "00"
+	var dummyTraceParent = " 00-" + dummyTraceID + "-" + dummySpanID + "-" + dummyFlags + " "
+
+	// mock
+	createMock(t)
+
+	// expect
+	isValidHexFuncExpected = 2
+	isValidHexFunc = func(value string, length int) bool {
+		isValidHexFuncCalled++
+		return true
+	}
+	hexDecodeStringExpected = 1
+	hexDecodeString = func(s string) ([]byte, error) {
+		hexDecodeStringCalled++
+		assert.Equal(t, dummyFlags, s)
+		return []byte{0}, nil
+	}
+
+	// SUT + act
+	var traceID, parentSpanID, sampled, ok = parseTraceParent(
+		dummyTraceParent,
+	)
+
+	// assert
+	assert.Equal(t, dummyTraceID, traceID)
+	assert.Equal(t, dummySpanID, parentSpanID)
+	assert.False(t, sampled)
+	assert.True(t, ok)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestParseTraceParent_FutureVersionSampled(t *testing.T) {
+	// arrange
+	var dummyTraceID = "some trace ID"
+	var dummySpanID = "some span ID"
+	var dummyFlags = "03"
+	var dummyTraceParent = "01-" + dummyTraceID + "-" + dummySpanID + "-" + dummyFlags + "-some extra"
+
+	// mock
+	createMock(t)
+
+	// expect
+	isValidHexFuncExpected = 2
+	isValidHexFunc = func(value string, length int) bool {
+		isValidHexFuncCalled++
+		return true
+	}
+	hexDecodeStringExpected = 1
+	hexDecodeString = func(s string) ([]byte, error) {
+		hexDecodeStringCalled++
+		assert.Equal(t, dummyFlags, s)
+		return []byte{3}, nil
+	}
+
+	// SUT + act
+	var traceID, parentSpanID, sampled, ok = parseTraceParent(
+		dummyTraceParent,
+	)
+
+	// assert
+	assert.Equal(t, dummyTraceID, traceID)
+	assert.Equal(t, dummySpanID, parentSpanID)
+	assert.True(t, sampled)
+	assert.True(t, ok)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatTraceParent_NilSpan(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = FormatTraceParent(
+		nil,
+	)
+
+	// assert
+	assert.Zero(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatTraceParent_NotSampled(t *testing.T) {
+	// arrange
+	var dummySpan = &model.Span{
+		TraceID: "some trace ID",
+		SpanID:  "some span ID",
+		Sampled: false,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = FormatTraceParent(
+		dummySpan,
+	)
+
+	// assert
+	assert.Equal(t, "00-some trace ID-some span ID-00", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestFormatTraceParent_Sampled(t *testing.T) {
+	// arrange
+	var dummySpan = &model.Span{
+		TraceID: "some trace ID",
+		SpanID:  "some span ID",
+		Sampled: true,
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = FormatTraceParent(
+		dummySpan,
+	)
+
+	// assert
+	assert.Equal(t, "00-some trace ID-some span ID-01", result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStartServerSpan_NewTrace(t *testing.T) {
+	// arrange
+	var dummyTraceParent = "some trace parent"
+	var dummyHeader = http.Header{}
+	var dummyName = "some name"
+	var dummyTraceID = "some trace ID"
+	var dummySpanID = "some span ID"
+	var dummyStartTime = time.Now()
+
+	// stub
+	dummyHeader.Set(TraceParentHeader, dummyTraceParent)
+	dummyHeader.Set(TraceStateHeader, "some trace state")
+
+	// mock
+	createMock(t)
+
+	// expect
+	parseTraceParentFuncExpected = 1
+	parseTraceParentFunc = func(traceParent string) (string, string, bool, bool) {
+		parseTraceParentFuncCalled++
+		assert.Equal(t, dummyTraceParent, traceParent)
+		return "", "", false, false
+	}
+	newIDFuncExpected = 2
+	newIDFunc = func(size int) string {
+		newIDFuncCalled++
+		if newIDFuncCalled == 1 {
+			assert.Equal(t, 16, size)
+			return dummyTraceID
+		}
+		assert.Equal(t, 8, size)
+		return dummySpanID
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyStartTime
+	}
+
+	// SUT + act
+	var result = StartServerSpan(
+		dummyHeader,
+		dummyName,
+	)
+
+	// assert
+	assert.Equal(t, dummyTraceID, result.TraceID)
+	assert.Equal(t, dummySpanID, result.SpanID)
+	assert.Zero(t, result.ParentSpanID)
+	assert.Zero(t, result.TraceState)
+	assert.True(t, result.Sampled)
+	assert.Equal(t, dummyName, result.Name)
+	assert.Equal(t, model.SpanKindServer, result.Kind)
+	assert.Equal(t, dummyStartTime, result.StartTime)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStartServerSpan_ContinueTrace(t *testing.T) {
+	// arrange
+	var dummyTraceParent = "some trace parent"
+	var dummyTraceState = "some trace state"
+	var dummyHeader = http.Header{}
+	var dummyName = "some name"
+	var dummyTraceID = "some trace ID"
+	var dummyParentSpanID = "some parent span ID"
+	var dummySampled = rand.Intn(100) < 50
+	var dummySpanID = "some span ID"
+	var dummyStartTime = time.Now()
+
+	// stub
+	dummyHeader.Set(TraceParentHeader, dummyTraceParent)
+	dummyHeader.Set(TraceStateHeader, dummyTraceState)
+
+	// mock
+	createMock(t)
+
+	// expect
+	parseTraceParentFuncExpected = 1
+	parseTraceParentFunc = func(traceParent string) (string, string, bool, bool) {
+		parseTraceParentFuncCalled++
+		assert.Equal(t, dummyTraceParent, traceParent)
+		return dummyTraceID, dummyParentSpanID, dummySampled, true
+	}
+	newIDFuncExpected = 1
+	newIDFunc = func(size int) string {
+		newIDFuncCalled++
+		assert.Equal(t, 8, size)
+		return dummySpanID
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyStartTime
+	}
+
+	// SUT + act
+	var result = StartServerSpan(
+		dummyHeader,
+		dummyName,
+	)
+
+	// assert
+	assert.Equal(t, dummyTraceID, result.TraceID)
+	assert.Equal(t, dummySpanID, result.SpanID)
+	assert.Equal(t, dummyParentSpanID, result.ParentSpanID)
+	assert.Equal(t, dummyTraceState, result.TraceState)
+	assert.Equal(t, dummySampled, result.Sampled)
+	assert.Equal(t, dummyName, result.Name)
+	assert.Equal(t, model.SpanKindServer, result.Kind)
+	assert.Equal(t, dummyStartTime, result.StartTime)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStartSpan_NilParent(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyKind = model.SpanKindClient
+	var dummySpanID = "some span ID"
+	var dummyTraceID = "some trace ID"
+	var dummyStartTime = time.Now()
+
+	// mock
+	createMock(t)
+
+	// expect
+	newIDFuncExpected = 2
+	newIDFunc = func(size int) string {
+		newIDFuncCalled++
+		if newIDFuncCalled == 1 {
+			assert.Equal(t, 8, size)
+			return dummySpanID
+		}
+		assert.Equal(t, 16, size)
+		return dummyTraceID
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyStartTime
+	}
+
+	// SUT + act
+	var result = StartSpan(
+		nil,
+		dummyName,
+		dummyKind,
+	)
+
+	// assert
+	assert.Equal(t, dummyTraceID, result.TraceID)
+	assert.Equal(t, dummySpanID, result.SpanID)
+	assert.Zero(t, result.ParentSpanID)
+	assert.Zero(t, result.TraceState)
+	assert.True(t, result.Sampled)
+	assert.Equal(t, dummyName, result.Name)
+	assert.Equal(t, dummyKind, result.Kind)
+	assert.Equal(t, dummyStartTime, result.StartTime)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestStartSpan_WithParent(t *testing.T) {
+	// arrange
+	var dummyParent = &model.Span{
+		TraceID:    "some trace ID",
+		SpanID:     "some parent span ID",
+		TraceState: "some trace state",
+		Sampled:    rand.Intn(100) < 50,
+	}
+	var dummyName = "some name"
+	var dummyKind = model.SpanKindInternal
+	var dummySpanID = "some span ID"
+	var dummyStartTime = time.Now()
+
+	// mock
+	createMock(t)
+
+	// expect
+	newIDFuncExpected = 1
+	newIDFunc = func(size int) string {
+		newIDFuncCalled++
+		assert.Equal(t, 8, size)
+		return dummySpanID
+	}
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyStartTime
+	}
+
+	// SUT + act
+	var result = StartSpan(
+		dummyParent,
+		dummyName,
+		dummyKind,
+	)
+
+	// assert
+	assert.Equal(t, dummyParent.TraceID, result.TraceID)
+	assert.Equal(t, dummySpanID, result.SpanID)
+	assert.Equal(t, dummyParent.SpanID, result.ParentSpanID)
+	assert.Equal(t, dummyParent.TraceState, result.TraceState)
+	assert.Equal(t, dummyParent.Sampled, result.Sampled)
+	assert.Equal(t, dummyName, result.Name)
+	assert.Equal(t, dummyKind, result.Kind)
+	assert.Equal(t, dummyStartTime, result.StartTime)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetAttribute_NilSpan(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	SetAttribute(
+		nil,
+		"some key",
+		"some value",
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetAttribute_NilAttributes(t *testing.T) {
+	// arrange
+	var dummySpan = &model.Span{}
+	var dummyKey = "some key"
+	var dummyValue = "some value"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	SetAttribute(
+		dummySpan,
+		dummyKey,
+		dummyValue,
+	)
+
+	// assert
+	assert.Equal(t, map[string]string{dummyKey: dummyValue}, dummySpan.Attributes)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestSetAttribute_ExistingAttributes(t *testing.T) {
+	// arrange
+	var dummySpan = &model.Span{
+		Attributes: map[string]string{
+			"some key":  "some old value",
+			"other key": "other value",
+		},
+	}
+	var dummyKey = "some key"
+	var dummyValue = "some value"
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	SetAttribute(
+		dummySpan,
+		dummyKey,
+		dummyValue,
+	)
+
+	// assert
+	assert.Equal(t, map[string]string{"some key": "some value", "other key": "other value"}, dummySpan.Attributes)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEndSpan_NilSpan(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	EndSpan(
+		nil,
+		errors.New("some error"),
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEndSpan_NotSampled(t *testing.T) {
+	// arrange
+	var dummySpan = &model.Span{
+		Sampled: false,
+	}
+	var dummyEndTime = time.Now()
+	var dummyError = errors.New("some error")
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyEndTime
+	}
+	customization.SpanExporter = func() model.SpanExporter {
+		customizationSpanExporterCalled++
+		return nil
+	}
+
+	// SUT + act
+	EndSpan(
+		dummySpan,
+		dummyError,
+	)
+
+	// assert
+	assert.Equal(t, dummyEndTime, dummySpan.EndTime)
+	assert.Equal(t, dummyError.Error(), dummySpan.Error)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEndSpan_NoCustomization(t *testing.T) {
+	// arrange
+	var dummySpan = &model.Span{
+		Sampled: true,
+	}
+	var dummyEndTime = time.Now()
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyEndTime
+	}
+
+	// SUT + act
+	EndSpan(
+		dummySpan,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, dummyEndTime, dummySpan.EndTime)
+	assert.Zero(t, dummySpan.Error)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEndSpan_NilExporter(t *testing.T) {
+	// arrange
+	var dummySpan = &model.Span{
+		Sampled: true,
+	}
+	var dummyEndTime = time.Now()
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyEndTime
+	}
+	customizationSpanExporterExpected = 1
+	customization.SpanExporter = func() model.SpanExporter {
+		customizationSpanExporterCalled++
+		return nil
+	}
+
+	// SUT + act
+	EndSpan(
+		dummySpan,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, dummyEndTime, dummySpan.EndTime)
+	assert.Zero(t, dummySpan.Error)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestEndSpan_Exported(t *testing.T) {
+	// arrange
+	var dummySpan = &model.Span{
+		TraceID: "some trace ID",
+		SpanID:  "some span ID",
+		Sampled: true,
+		Name:    "some name",
+	}
+	var dummyEndTime = time.Now()
+	var dummyError = errors.New("some error")
+	var dummyExporter = &dummySpanExporter{
+		t: t,
+		expected: &model.Span{
+			TraceID: "some trace ID",
+			SpanID:  "some span ID",
+			Sampled: true,
+			Name:    "some name",
+			EndTime: dummyEndTime,
+			Error:   "some error",
+		},
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	timeutilGetTimeNowUTCExpected = 1
+	timeutilGetTimeNowUTC = func() time.Time {
+		timeutilGetTimeNowUTCCalled++
+		return dummyEndTime
+	}
+	customizationSpanExporterExpected = 1
+	customization.SpanExporter = func() model.SpanExporter {
+		customizationSpanExporterCalled++
+		return dummyExporter
+	}
+
+	// SUT + act
+	EndSpan(
+		dummySpan,
+		dummyError,
+	)
+
+	// assert
+	assert.Equal(t, dummyEndTime, dummySpan.EndTime)
+	assert.Equal(t, dummyError.Error(), dummySpan.Error)
+	assert.Equal(t, 1, dummyExporter.called)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestInjectHeader_NilHeader(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	InjectHeader(
+		nil,
+		&model.Span{},
+	)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestInjectHeader_NilSpan(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	InjectHeader(
+		dummyHeader,
+		nil,
+	)
+
+	// assert
+	assert.Empty(t, dummyHeader)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestInjectHeader_NoTraceState(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{}
+	var dummySpan = &model.Span{}
+	var dummyTraceParent = "some trace parent"
+
+	// mock
+	createMock(t)
+
+	// expect
+	formatTraceParentFuncExpected = 1
+	formatTraceParentFunc = func(span *model.Span) string {
+		formatTraceParentFuncCalled++
+		assert.Equal(t, dummySpan, span)
+		return dummyTraceParent
+	}
+
+	// SUT + act
+	InjectHeader(
+		dummyHeader,
+		dummySpan,
+	)
+
+	// assert
+	assert.Equal(t, dummyTraceParent, dummyHeader.Get(TraceParentHeader))
+	assert.Empty(t, dummyHeader.Get(TraceStateHeader))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestInjectHeader_WithTraceState(t *testing.T) {
+	// arrange
+	var dummyHeader = http.Header{}
+	var dummySpan = &model.Span{
+		TraceState: "some trace state",
+	}
+	var dummyTraceParent = "some trace parent"
+
+	// mock
+	createMock(t)
+
+	// expect
+	formatTraceParentFuncExpected = 1
+	formatTraceParentFunc = func(span *model.Span) string {
+		formatTraceParentFuncCalled++
+		assert.Equal(t, dummySpan, span)
+		return dummyTraceParent
+	}
+
+	// SUT + act
+	InjectHeader(
+		dummyHeader,
+		dummySpan,
+	)
+
+	// assert
+	assert.Equal(t, dummyTraceParent, dummyHeader.Get(TraceParentHeader))
+	assert.Equal(t, dummySpan.TraceState, dummyHeader.Get(TraceStateHeader))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestWithSpan(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest, _ = http.NewRequest(
+		http.MethodGet,
+		"http://localhost/",
+		nil,
+	)
+	var dummySpan = &model.Span{
+		SpanID: "some span ID",
+	}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = WithSpan(
+		dummyHTTPRequest,
+		dummySpan,
+	)
+
+	// assert
+	assert.NotEqual(t, dummyHTTPRequest, result)
+	assert.Nil(t, dummyHTTPRequest.Context().Value(spanContextKey{}))
+	assert.Equal(t, dummySpan, result.Context().Value(spanContextKey{}))
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSpan_NilContext(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = GetSpan(
+		nil,
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSpan_NoSpan(t *testing.T) {
+	// arrange
+	var dummyContext = context.Background()
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = GetSpan(
+		dummyContext,
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSpan_WithSpan(t *testing.T) {
+	// arrange
+	var dummySpan = &model.Span{
+		SpanID: "some span ID",
+	}
+	var dummyContext = context.WithValue(
+		context.Background(),
+		spanContextKey{},
+		dummySpan,
+	)
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = GetSpan(
+		dummyContext,
+	)
+
+	// assert
+	assert.Equal(t, dummySpan, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSessionSpan_NilSession(t *testing.T) {
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = GetSessionSpan(
+		nil,
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSessionSpan_NilRequest(t *testing.T) {
+	// arrange
+	var dummySession = &dummySession{t: t}
+
+	// mock
+	createMock(t)
+
+	// SUT + act
+	var result = GetSessionSpan(
+		dummySession,
+	)
+
+	// assert
+	assert.Nil(t, result)
+
+	// verify
+	verifyAll(t)
+}
+
+func TestGetSessionSpan_WithRequest(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest, _ = http.NewRequest(
+		http.MethodGet,
+		"http://localhost/",
+		nil,
+	)
+	var dummySession = &dummySession{
+		t:       t,
+		request: dummyHTTPRequest,
+	}
+	var dummySpan = &model.Span{
+		SpanID: "some span ID",
+	}
+
+	// mock
+	createMock(t)
+
+	// expect
+	getSpanFuncExpected = 1
+	getSpanFunc = func(ctx context.Context) *model.Span {
+		getSpanFuncCalled++
+		assert.Equal(t, dummyHTTPRequest.Context(), ctx)
+		return dummySpan
+	}
+
+	// SUT + act
+	var result = GetSessionSpan(
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, dummySpan, result)
+
+	// verify
+	verifyAll(t)
+}